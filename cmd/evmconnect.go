@@ -19,11 +19,15 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 
+	"github.com/gorilla/mux"
 	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
 	"github.com/hyperledger/firefly-common/pkg/i18n"
 	"github.com/hyperledger/firefly-common/pkg/log"
 	"github.com/hyperledger/firefly-evmconnect/internal/ethereum"
@@ -39,6 +43,8 @@ type EthereumConnector ethereum.Connector
 
 var sigs = make(chan os.Signal, 1)
 
+var reloadMux sync.Mutex
+
 var rootCmd = &cobra.Command{
 	Use:   "evmconnect",
 	Short: "Hyperledger FireFly Connector for EVM based blockchains",
@@ -100,25 +106,87 @@ func run() error {
 		return i18n.WrapError(ctx, err, i18n.MsgConfigFailed)
 	}
 
-	// Init connector
-	c, err := NewEthereumConnector(ctx, connectorConfig)
+	// Init the connector of the primary chain, and any additional chains hosted by this process
+	primary, err := newChain(ctx, "", connectorConfig, nil)
 	if err != nil {
 		return err
 	}
-	m, err := fftm.NewManager(ctx, c)
+	additional, err := newAdditionalChains(ctx)
 	if err != nil {
+		closeChains([]*chain{primary})
+		cancelCtx()
 		return err
 	}
+	registerChainNamespaces(primary.manager.APIRouter(), additional)
+	chains := append([]*chain{primary}, additional...)
 
-	// Setup signal handling to cancel the context, which shuts down the API Server
-	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	// Setup signal handling to cancel the context, which shuts down the API Server.
+	// SIGHUP instead reloads the configuration of the connector.
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
 	go func() {
-		sig := <-sigs
-		log.L(ctx).Infof("Shutting down due to %s", sig.String())
-		cancelCtx()
+		for {
+			sig := <-sigs
+			if sig == syscall.SIGHUP {
+				log.L(ctx).Infof("Reloading configuration due to %s", sig.String())
+				reloadConfig(ctx, chains)
+				continue
+			}
+			log.L(ctx).Infof("Shutting down due to %s", sig.String())
+			cancelCtx()
+			return
+		}
 	}()
 
-	return runManager(ctx, m)
+	if connectorConfig.GetBool(ethereum.ConfigReloadWatchFile) {
+		if err := config.WatchConfig(ctx, func() {
+			log.L(ctx).Infof("Reloading configuration due to change of %s", cfgFile)
+			reloadConfig(ctx, chains)
+		}, nil); err != nil {
+			return err
+		}
+	}
+
+	return runManagers(ctx, chains)
+}
+
+// reloadConfig re-reads the config file, and applies it to the running connectors. On failure a
+// connector continues with its previous configuration
+func reloadConfig(ctx context.Context, chains []*chain) {
+	reloadMux.Lock()
+	defer reloadMux.Unlock()
+	if err := config.ReadConfig("evmconnect", cfgFile); err != nil {
+		log.L(ctx).Errorf("Configuration reload failed: %s", err)
+		return
+	}
+	for _, ch := range chains {
+		if err := ch.connector.ReloadConfig(ctx, ch.conf); err != nil {
+			log.L(ctx).Errorf("Configuration reload failed for chain '%s': %s", ch.name, err)
+		}
+	}
+}
+
+// registerConnectorRoutes adds the EVM specific routes to the API server of the transaction manager, with
+// their own OpenAPI spec and Swagger UI under /api/connector, as the spec of the transaction manager only
+// includes its own routes
+func registerConnectorRoutes(router *mux.Router, routes []*ffapi.Route) {
+	hf := ffapi.HandlerFactory{
+		DefaultRequestTimeout: config.GetDuration("api.defaultRequestTimeout"),
+		MaxTimeout:            config.GetDuration("api.maxRequestTimeout"),
+		PassthroughHeaders:    config.GetStringSlice("api.passthroughHeaders"),
+	}
+	oah := &ffapi.OpenAPIHandlerFactory{
+		BaseSwaggerGenOptions: ffapi.SwaggerGenOptions{
+			Title:                 "FireFly EVM Connector API",
+			Version:               "1.0",
+			DefaultRequestTimeout: config.GetDuration("api.defaultRequestTimeout"),
+		},
+	}
+	for _, r := range routes {
+		router.Path(r.Path).Methods(r.Method).Handler(hf.RouteHandler(r))
+	}
+	router.Path("/api/connector").Methods(http.MethodGet).Handler(hf.APIWrapper(oah.SwaggerUIHandler("/api/connector/spec.yaml")))
+	router.Path("/api/connector/spec.yaml").Methods(http.MethodGet).Handler(hf.APIWrapper(oah.OpenAPIHandler("", ffapi.OpenAPIFormatYAML, routes)))
+	router.Path("/api/connector/spec.json").Methods(http.MethodGet).Handler(hf.APIWrapper(oah.OpenAPIHandler("", ffapi.OpenAPIFormatJSON, routes)))
 }
 
 func runManager(ctx context.Context, m fftm.Manager) error {
@@ -130,3 +198,26 @@ func runManager(ctx context.Context, m fftm.Manager) error {
 	m.Close()
 	return nil
 }
+
+// runManagers runs the transaction manager of each chain until the context is cancelled, or one fails to start
+func runManagers(ctx context.Context, chains []*chain) error {
+	ctx, cancelCtx := context.WithCancel(ctx)
+	defer cancelCtx()
+	errs := make(chan error, len(chains))
+	for _, ch := range chains {
+		go func(m fftm.Manager) {
+			err := runManager(ctx, m)
+			if err != nil {
+				cancelCtx()
+			}
+			errs <- err
+		}(ch.manager)
+	}
+	var firstErr error
+	for range chains {
+		if err := <-errs; err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
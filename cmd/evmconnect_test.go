@@ -18,11 +18,18 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"syscall"
 	"testing"
 	"time"
 
+	"github.com/gorilla/mux"
+	"github.com/hyperledger/firefly-common/pkg/config"
 	"github.com/hyperledger/firefly-evmconnect/mocks/fftmmocks"
 	"github.com/stretchr/testify/assert"
 )
@@ -50,6 +57,42 @@ func TestRunOK(t *testing.T) {
 
 }
 
+func TestRunReloadOnSIGHUP(t *testing.T) {
+
+	rootCmd.SetArgs([]string{"-f", "../test/firefly.evmconnect.yaml"})
+	defer rootCmd.SetArgs([]string{})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		err := Execute()
+		if err != nil {
+			assert.Regexp(t, "context deadline", err)
+		}
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	sigs <- syscall.SIGHUP
+	sigs <- os.Kill
+
+	<-done
+
+}
+
+func TestReloadConfigBadFile(t *testing.T) {
+
+	InitConfig()
+	err := config.ReadConfig("evmconnect", "../test/firefly.evmconnect.yaml")
+	assert.NoError(t, err)
+	c, err := NewEthereumConnector(context.Background(), connectorConfig)
+	assert.NoError(t, err)
+
+	cfgFile = "../test/bad-config.evmconnect.yaml"
+	defer func() { cfgFile = "" }()
+	reloadConfig(context.Background(), []*chain{{conf: connectorConfig, connector: c}}) // logs the error, and continues with the existing config
+
+}
+
 func TestRunBadConfig(t *testing.T) {
 
 	rootCmd.SetArgs([]string{"-f", "../test/bad-config.evmconnect.yaml"})
@@ -99,3 +142,41 @@ func TestRunMigrationsBadConfig(t *testing.T) {
 	assert.Regexp(t, "FF21049", err)
 
 }
+
+func TestRegisterConnectorRoutesSpec(t *testing.T) {
+	InitConfig()
+	err := config.ReadConfig("evmconnect", "../test/firefly.evmconnect.yaml")
+	assert.NoError(t, err)
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+	c, err := NewEthereumConnector(ctx, connectorConfig)
+	assert.NoError(t, err)
+
+	router := mux.NewRouter()
+	registerConnectorRoutes(router, c.Routes())
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	res, err := http.Get(server.URL + "/api/connector/spec.json")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var spec struct {
+		Paths map[string]interface{} `json:"paths"`
+	}
+	err = json.NewDecoder(res.Body).Decode(&spec)
+	assert.NoError(t, err)
+	for _, r := range c.Routes() {
+		assert.Contains(t, spec.Paths, r.Path)
+	}
+
+	res, err = http.Get(server.URL + "/api/connector/spec.yaml")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	res, err = http.Get(server.URL + "/api/connector")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	b, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), "/api/connector/spec.yaml")
+}
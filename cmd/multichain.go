@@ -0,0 +1,154 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"sort"
+
+	"github.com/gorilla/mux"
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-evmconnect/internal/ethereum"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/fftm"
+)
+
+// ChainsConfig holds the additional chains hosted by the process, keyed by name. Each has its own
+// connector section, and overrides of the transaction manager config it must not share with the others.
+var ChainsConfig = config.AddRootKey("chains")
+
+const (
+	chainAPIPort                = "api.port"
+	chainMonitoringPort         = "monitoring.port"
+	chainPersistenceLevelDBPath = "persistence.leveldb.path"
+	chainPersistencePostgresURL = "persistence.postgres.url"
+)
+
+// chainOverrides are the root keys of the transaction manager config that a chain can set for itself
+var chainOverrides = []string{
+	chainAPIPort,
+	chainMonitoringPort,
+	chainPersistenceLevelDBPath,
+	chainPersistencePostgresURL,
+}
+
+// chain is a connection to one blockchain, with the transaction manager that serves its API
+type chain struct {
+	name      string
+	conf      config.Section
+	connector EthereumConnector
+	manager   fftm.Manager
+}
+
+// newChain creates the connector for a chain, and the transaction manager for it. The connector is built
+// from the connector section of the chain, and the transaction manager from the overrides in the section
+// of the chain, which is nil for the primary chain.
+func newChain(ctx context.Context, name string, conf, chainConf config.Section) (*chain, error) {
+	if name != "" {
+		ctx = log.WithLogField(ctx, "chain", name)
+	}
+	c, err := NewEthereumConnector(ctx, conf)
+	if err != nil {
+		return nil, err
+	}
+	m, err := newChainManager(ctx, c, chainConf)
+	if err != nil {
+		return nil, err
+	}
+	registerConnectorRoutes(m.APIRouter(), c.Routes())
+	return &chain{name: name, conf: conf, connector: c, manager: m}, nil
+}
+
+// newChainManager creates the transaction manager of a chain. fftm.NewManager cannot be passed a config
+// section, and reads the config of its API server and persistence from the root when it is created,
+// which is the only time they are read. So the overrides the chain sets in its own section are applied
+// to the root for the duration of the call, holding the reload lock so a reload cannot see them.
+func newChainManager(ctx context.Context, c EthereumConnector, chainConf config.Section) (fftm.Manager, error) {
+	if chainConf == nil {
+		return fftm.NewManager(ctx, c)
+	}
+	reloadMux.Lock()
+	defer reloadMux.Unlock()
+	for _, k := range chainOverrides {
+		if chainConf.IsSet(k) {
+			previous := config.Get(config.RootKey(k))
+			config.Set(config.RootKey(k), chainConf.Get(k))
+			defer config.Set(config.RootKey(k), previous)
+		}
+	}
+	return fftm.NewManager(ctx, c)
+}
+
+// closeChains closes the transaction managers of chains that were created, but not started
+func closeChains(chains []*chain) {
+	for _, ch := range chains {
+		ch.manager.Close()
+	}
+}
+
+// registerChainNamespaces serves the API of each additional chain under /chains/{name} of the API of the
+// primary chain, so FFCAPI requests can address a chain by its name, as well as by its own API port
+func registerChainNamespaces(router *mux.Router, chains []*chain) {
+	for _, ch := range chains {
+		prefix := "/" + string(ChainsConfig) + "/" + ch.name
+		router.PathPrefix(prefix + "/").Handler(http.StripPrefix(prefix, ch.manager.APIRouter()))
+	}
+}
+
+// newAdditionalChains creates the chains configured under the chains key, in name order. The config of
+// each is only known once the config file is read, so their config keys are registered here.
+func newAdditionalChains(ctx context.Context) ([]*chain, error) {
+	names := make([]string, 0)
+	for name := range config.GetObject(ChainsConfig) {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	chains := make([]*chain, 0, len(names))
+	for _, name := range names {
+		ch, err := newAdditionalChain(ctx, name)
+		if err != nil {
+			closeChains(chains)
+			return nil, err
+		}
+		chains = append(chains, ch)
+	}
+	return chains, nil
+}
+
+func newAdditionalChain(ctx context.Context, name string) (*chain, error) {
+	if err := fftypes.ValidateFFNameField(ctx, name, string(ChainsConfig)); err != nil {
+		return nil, err
+	}
+	chainConf := config.RootSection(string(ChainsConfig) + "." + name)
+	for _, k := range chainOverrides {
+		chainConf.AddKnownKey(k)
+	}
+	if !chainConf.IsSet(chainAPIPort) {
+		return nil, i18n.NewError(ctx, msgs.MsgChainAPIPortRequired, name, chainAPIPort)
+	}
+	if !chainConf.IsSet(chainPersistenceLevelDBPath) && !chainConf.IsSet(chainPersistencePostgresURL) {
+		return nil, i18n.NewError(ctx, msgs.MsgChainPersistenceRequired, name, chainPersistenceLevelDBPath, chainPersistencePostgresURL)
+	}
+	connectorConf := chainConf.SubSection("connector")
+	ethereum.InitConfig(connectorConf)
+	return newChain(ctx, name, connectorConf, chainConf)
+}
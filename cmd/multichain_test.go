@@ -0,0 +1,212 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-evmconnect/mocks/fftmmocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeChainsConfig(t *testing.T, chains string) string {
+	InitConfig()
+	dir := t.TempDir()
+	cfg := fmt.Sprintf(`connector:
+  url: http://localhost:8545
+api:
+  port: 0
+persistence:
+  leveldb:
+    path: %s
+chains:
+%s`, filepath.Join(dir, "ldb"), chains)
+	fileName := filepath.Join(dir, "chains.evmconnect.yaml")
+	err := os.WriteFile(fileName, []byte(cfg), 0644)
+	assert.NoError(t, err)
+	return fileName
+}
+
+func TestRunMultipleChains(t *testing.T) {
+	dir := t.TempDir()
+	rootCmd.SetArgs([]string{"-f", writeChainsConfig(t, fmt.Sprintf(`  chain2:
+    connector:
+      url: http://localhost:8546
+    api:
+      port: 0
+    persistence:
+      leveldb:
+        path: %s
+`, filepath.Join(dir, "ldb2")))})
+	defer rootCmd.SetArgs([]string{})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		err := Execute()
+		if err != nil {
+			assert.Regexp(t, "context deadline", err)
+		}
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	sigs <- syscall.SIGHUP
+	sigs <- os.Kill
+
+	<-done
+}
+
+func TestRunChainMissingAPIPort(t *testing.T) {
+	rootCmd.SetArgs([]string{"-f", writeChainsConfig(t, `  chain2:
+    connector:
+      url: http://localhost:8546
+`)})
+	defer rootCmd.SetArgs([]string{})
+
+	err := Execute()
+	assert.Regexp(t, "FF23124.*chain2", err)
+}
+
+func TestRunChainMissingPersistence(t *testing.T) {
+	rootCmd.SetArgs([]string{"-f", writeChainsConfig(t, `  chain2:
+    connector:
+      url: http://localhost:8546
+    api:
+      port: 0
+`)})
+	defer rootCmd.SetArgs([]string{})
+
+	err := Execute()
+	assert.Regexp(t, "FF23125.*chain2", err)
+}
+
+func TestRunChainBadName(t *testing.T) {
+	rootCmd.SetArgs([]string{"-f", writeChainsConfig(t, `  "!bad":
+    api:
+      port: 0
+`)})
+	defer rootCmd.SetArgs([]string{})
+
+	err := Execute()
+	assert.Regexp(t, "FF00140", err)
+}
+
+func TestRunChainBadConnectorConfig(t *testing.T) {
+	rootCmd.SetArgs([]string{"-f", writeChainsConfig(t, fmt.Sprintf(`  chain2:
+    api:
+      port: 0
+    persistence:
+      leveldb:
+        path: %s
+`, filepath.Join(t.TempDir(), "ldb2")))})
+	defer rootCmd.SetArgs([]string{})
+
+	err := Execute()
+	assert.Regexp(t, "FF23025", err)
+}
+
+func TestRunChainBadManagerConfig(t *testing.T) {
+	rootCmd.SetArgs([]string{"-f", writeChainsConfig(t, `  chain2:
+    connector:
+      url: http://localhost:8546
+    api:
+      port: 0
+    persistence:
+      leveldb:
+        path: ""
+`)})
+	defer rootCmd.SetArgs([]string{})
+
+	err := Execute()
+	assert.Error(t, err)
+}
+
+func TestRunChainFailureClosesCreatedChains(t *testing.T) {
+	dir := t.TempDir()
+	rootCmd.SetArgs([]string{"-f", writeChainsConfig(t, fmt.Sprintf(`  chain2:
+    connector:
+      url: http://localhost:8546
+    api:
+      port: 0
+    persistence:
+      leveldb:
+        path: %s
+  chain3:
+    connector:
+      url: http://localhost:8547
+`, filepath.Join(dir, "ldb2")))})
+	defer rootCmd.SetArgs([]string{})
+
+	err := Execute()
+	assert.Regexp(t, "FF23124.*chain3", err)
+}
+
+func TestNewChainManagerRestoresOverrides(t *testing.T) {
+	fileName := writeChainsConfig(t, fmt.Sprintf(`  chain2:
+    connector:
+      url: http://localhost:8546
+    api:
+      port: 0
+    persistence:
+      leveldb:
+        path: %s
+`, filepath.Join(t.TempDir(), "ldb2")))
+	err := config.ReadConfig("evmconnect", fileName)
+	assert.NoError(t, err)
+	rootPath := config.GetString(config.RootKey(chainPersistenceLevelDBPath))
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	chains, err := newAdditionalChains(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, chains, 1)
+	assert.Equal(t, rootPath, config.GetString(config.RootKey(chainPersistenceLevelDBPath)))
+	closeChains(chains)
+}
+
+func TestRegisterChainNamespaces(t *testing.T) {
+	chainRouter := mux.NewRouter()
+	chainRouter.Path("/status").Methods(http.MethodGet).HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mm := &fftmmocks.Manager{}
+	mm.On("APIRouter").Return(chainRouter)
+
+	router := mux.NewRouter()
+	registerChainNamespaces(router, []*chain{{name: "chain2", manager: mm}})
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	res, err := http.Get(server.URL + "/chains/chain2/status")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	res, err = http.Get(server.URL + "/chains/chain3/status")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	mm.AssertExpectations(t)
+}
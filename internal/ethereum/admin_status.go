@@ -0,0 +1,222 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+)
+
+const redactedValue = "****"
+
+// redactedConfigKeys are the fragments of config key names never returned by the admin API
+var redactedConfigKeys = []string{"password", "secret", "token", "apikey", "privatekey", "mnemonic", "headers"}
+
+type EndpointHealth struct {
+	BlockNumber int64     `json:"blockNumber"`
+	Lag         int64     `json:"lag"`
+	InUse       bool      `json:"inUse"`
+	InFlight    *int64    `json:"inFlight,omitempty"`
+	Node        *NodeInfo `json:"node,omitempty"`
+}
+
+type BlockListenerStatus struct {
+	HighestBlock         int64 `json:"highestBlock"`
+	CanonicalChainLength int   `json:"canonicalChainLength"`
+	KnownForks           int   `json:"knownForks"`
+	Consumers            int   `json:"consumers"`
+	WebSocketReconnects  int   `json:"webSocketReconnects"`
+	LastReconnectGap     int64 `json:"lastReconnectGap"`
+}
+
+type ListenerStatus struct {
+	ID              *fftypes.UUID `json:"id"`
+	Name            string        `json:"name,omitempty"`
+	Signature       string        `json:"signature"`
+	FromBlock       string        `json:"fromBlock,omitempty"`
+	CheckpointBlock int64         `json:"checkpointBlock"`
+	Catchup         bool          `json:"catchup"`
+	Paused          bool          `json:"paused,omitempty"`
+	PrivacyGroupID  string        `json:"privacyGroupId,omitempty"`
+}
+
+type EventStreamStatus struct {
+	ID        *fftypes.UUID     `json:"id"`
+	HeadBlock int64             `json:"headBlock"`
+	Catchup   bool              `json:"catchup"`
+	Listeners []*ListenerStatus `json:"listeners"`
+}
+
+type ConnectorStatus struct {
+	ChainID       string                     `json:"chainId,omitempty"`
+	Endpoints     map[string]*EndpointHealth `json:"endpoints"`
+	BlockListener *BlockListenerStatus       `json:"blockListener"`
+	Caches        map[string]int             `json:"caches"`
+	EventStreams  []*EventStreamStatus       `json:"eventStreams"`
+	Quarantined   int                        `json:"quarantined"`
+	DeadLetters   int                        `json:"deadLetters"`
+	GasUsage      *GasUsageStats             `json:"gasUsage,omitempty"`
+	Config        fftypes.JSONObject         `json:"config"`
+}
+
+// redactedConfig takes a snapshot of all the keys set under a config section, removing
+// credentials from the values of sensitive keys and from any URLs
+func redactedConfig(conf config.Section) fftypes.JSONObject {
+	prefix := strings.TrimSuffix(conf.Resolve(ffresty.HTTPConfigURL), ffresty.HTTPConfigURL)
+	snapshot := fftypes.JSONObject{}
+	for _, fullKey := range config.GetKnownKeys() {
+		if !strings.HasPrefix(fullKey, prefix) || strings.Contains(fullKey, "[]") {
+			continue
+		}
+		key := strings.TrimPrefix(fullKey, prefix)
+		value := conf.Get(key)
+		if value == nil {
+			continue
+		}
+		lowerKey := strings.ToLower(key)
+		for _, sensitive := range redactedConfigKeys {
+			if strings.Contains(lowerKey, sensitive) {
+				value = redactedValue
+				break
+			}
+		}
+		if s, ok := value.(string); ok {
+			if u, err := url.Parse(s); err == nil && u.User != nil {
+				u.User = nil
+				value = strings.Replace(u.String(), "://", "://"+redactedValue+"@", 1)
+			}
+		}
+		snapshot[key] = value
+	}
+	return snapshot
+}
+
+func (bl *blockListener) status() *BlockListenerStatus {
+	bl.mux.Lock()
+	defer bl.mux.Unlock()
+	return &BlockListenerStatus{
+		HighestBlock:         bl.highestBlock,
+		CanonicalChainLength: len(bl.canonicalChainIndex),
+		KnownForks:           len(bl.forkedBlockHashes),
+		Consumers:            len(bl.consumers),
+		WebSocketReconnects:  bl.wsReconnects,
+		LastReconnectGap:     bl.lastReconnectGap,
+	}
+}
+
+func (es *eventStream) status() *EventStreamStatus {
+	es.mux.Lock()
+	defer es.mux.Unlock()
+	ess := &EventStreamStatus{
+		ID:        es.id,
+		HeadBlock: es.headBlock,
+		Catchup:   es.catchup,
+		Listeners: make([]*ListenerStatus, 0, len(es.listeners)),
+	}
+	for _, l := range es.listeners {
+		l.hwmMux.Lock()
+		ess.Listeners = append(ess.Listeners, &ListenerStatus{
+			ID:              l.id,
+			Name:            l.config.name,
+			Signature:       l.config.signature,
+			FromBlock:       l.config.fromBlock,
+			CheckpointBlock: l.hwmBlock,
+			Catchup:         l.catchup,
+			Paused:          l.paused,
+			PrivacyGroupID:  l.config.options.PrivacyGroupID,
+		})
+		l.hwmMux.Unlock()
+	}
+	sort.Slice(ess.Listeners, func(i, j int) bool { return ess.Listeners[i].ID.String() < ess.Listeners[j].ID.String() })
+	return ess
+}
+
+// Status returns a snapshot of the runtime state of the connector, for operators debugging
+// problems such as stuck event streams without needing to restart with debug logging
+func (c *ethConnector) Status(ctx context.Context) *ConnectorStatus {
+	status := &ConnectorStatus{
+		ChainID:       c.chainID,
+		BlockListener: c.blockListener.status(),
+		Endpoints:     map[string]*EndpointHealth{},
+		Caches: map[string]int{
+			"blocks":          c.blockListener.blockCache.Len(),
+			"transactions":    c.txCache.Len(),
+			"blockTimestamps": c.blockTSCache.Len(),
+			"eventFailures":   c.eventFailures.Len(),
+		},
+	}
+
+	c.configMux.Lock()
+	status.Config = c.configSnapshot
+	c.configMux.Unlock()
+
+	c.routingMux.Lock()
+	status.Endpoints["primary"] = &EndpointHealth{
+		BlockNumber: c.primaryStatus.BlockNumber,
+		Lag:         c.primaryStatus.Lag,
+		InUse:       true,
+		InFlight:    inFlightCount(c.backend),
+	}
+	if c.readOnlyBackend != nil {
+		status.Endpoints["read"] = &EndpointHealth{
+			BlockNumber: c.readStatus.BlockNumber,
+			Lag:         c.readStatus.Lag,
+			InUse:       !c.readBehind,
+			InFlight:    inFlightCount(c.readOnlyBackend),
+		}
+	}
+	c.routingMux.Unlock()
+
+	// Query the nodes outside of the lock, so a slow node does not hold up routing
+	status.Endpoints["primary"].Node = nodeInfo(ctx, c.backend)
+	if read := status.Endpoints["read"]; read != nil {
+		read.Node = nodeInfo(ctx, c.readOnlyBackend)
+	}
+
+	c.proxyMux.Lock()
+	status.Caches["proxies"] = len(c.proxyCache)
+	c.proxyMux.Unlock()
+
+	c.quarantineMux.Lock()
+	status.Quarantined = len(c.quarantine)
+	c.quarantineMux.Unlock()
+
+	c.deadLetterMux.Lock()
+	status.DeadLetters = len(c.deadLetters)
+	c.deadLetterMux.Unlock()
+
+	status.GasUsage = c.gasUsage.stats(c.gas().estimationFactor)
+
+	c.mux.Lock()
+	streams := make([]*eventStream, 0, len(c.eventStreams))
+	for _, es := range c.eventStreams {
+		streams = append(streams, es)
+	}
+	c.mux.Unlock()
+	status.EventStreams = make([]*EventStreamStatus, len(streams))
+	for i, es := range streams {
+		status.EventStreams[i] = es.status()
+	}
+	sort.Slice(status.EventStreams, func(i, j int) bool { return status.EventStreams[i].ID.String() < status.EventStreams[j].ID.String() })
+	return status
+}
@@ -0,0 +1,145 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+)
+
+// The statuses of an Arbitrum retryable ticket, from its submission to the inbox on L1 through to its redemption on L2
+const (
+	RetryableStatusSubmitted     = "submitted"      // on L1, the ticket will be created on L2 from the inbox message
+	RetryableStatusCreated       = "created"        // on L2, without a redeem scheduled, so it must be redeemed manually
+	RetryableStatusRedeemPending = "redeem_pending" // the receipt of the scheduled redeem is not available yet
+	RetryableStatusRedeemed      = "redeemed"
+	RetryableStatusRedeemFailed  = "redeem_failed" // the ticket can still be redeemed manually, until it expires
+	RetryableStatusCanceled      = "canceled"
+)
+
+// arbRetryableTxAddress is the ArbRetryableTx precompile of Arbitrum chains, which emits the lifecycle events of
+// retryable tickets on L2
+var arbRetryableTxAddress = ethtypes.MustNewAddress("0x000000000000000000000000000000000000006e")
+
+// arbitrumSubmitRetryableKind is the kind of inbox message (L1MessageType_submitRetryableTx) of a retryable ticket
+const arbitrumSubmitRetryableKind = 9
+
+var (
+	arbMessageDeliveredTopic = eventTopic("MessageDelivered(uint256,bytes32,address,uint8,address,bytes32,uint256,uint64)")
+	arbTicketCreatedTopic    = eventTopic("TicketCreated(bytes32)")
+	arbRedeemScheduledTopic  = eventTopic("RedeemScheduled(bytes32,bytes32,uint64,uint64,address,uint256,uint256)")
+	arbCanceledTopic         = eventTopic("Canceled(bytes32)")
+)
+
+// RetryableTicket is the composite status of an Arbitrum retryable ticket, in the receipt of the transaction that
+// submitted it on L1, or that created or redeemed it on L2. The message index on L1 is the request ID of the ticket
+// on L2, so correlates the two.
+type RetryableTicket struct {
+	TicketID     ethtypes.HexBytes0xPrefix `json:"ticketId,omitempty"`
+	MessageIndex *ethtypes.HexInteger      `json:"messageIndex,omitempty"`
+	Status       string                    `json:"status"`
+	RetryTxHash  ethtypes.HexBytes0xPrefix `json:"retryTxHash,omitempty"`
+	SequenceNum  *ethtypes.HexInteger      `json:"sequenceNum,omitempty"` // the number of the redeem attempt
+}
+
+func eventTopic(signature string) ethtypes.HexBytes0xPrefix {
+	return keccak256([]byte(signature))
+}
+
+// receiptRetryableTickets finds the retryable tickets submitted on L1, or created, redeemed or canceled on L2, by the
+// transaction of a receipt. The status of a scheduled redeem is from the receipt of its retry transaction, and any
+// failure to get it leaves the redeem pending, rather than failing the receipt.
+func (c *ethConnector) receiptRetryableTickets(ctx context.Context, receipt *txReceiptJSONRPC) []*RetryableTicket {
+	var tickets []*RetryableTicket
+	byID := make(map[string]*RetryableTicket)
+	ticket := func(id ethtypes.HexBytes0xPrefix) *RetryableTicket {
+		t := byID[id.String()]
+		if t == nil {
+			t = &RetryableTicket{TicketID: id}
+			byID[id.String()] = t
+			tickets = append(tickets, t)
+		}
+		return t
+	}
+	for _, l := range receipt.Logs {
+		if len(l.Topics) < 2 {
+			continue
+		}
+		if bytes.Equal(l.Topics[0], arbMessageDeliveredTopic) {
+			if kind := dataWord(l.Data, 1); kind != nil && new(big.Int).SetBytes(kind).Int64() == arbitrumSubmitRetryableKind {
+				tickets = append(tickets, &RetryableTicket{
+					MessageIndex: (*ethtypes.HexInteger)(new(big.Int).SetBytes(l.Topics[1])),
+					Status:       RetryableStatusSubmitted,
+				})
+			}
+			continue
+		}
+		if l.Address == nil || !bytes.Equal(l.Address[:], arbRetryableTxAddress[:]) {
+			continue
+		}
+		switch {
+		case bytes.Equal(l.Topics[0], arbTicketCreatedTopic):
+			t := ticket(l.Topics[1])
+			if t.Status == "" {
+				t.Status = RetryableStatusCreated
+			}
+			t.MessageIndex = c.retryableMessageIndex(ctx, receipt, l.Topics[1])
+		case bytes.Equal(l.Topics[0], arbRedeemScheduledTopic) && len(l.Topics) >= 4:
+			t := ticket(l.Topics[1])
+			t.RetryTxHash = l.Topics[2]
+			t.SequenceNum = (*ethtypes.HexInteger)(new(big.Int).SetBytes(l.Topics[3]))
+			t.Status = c.retryRedeemStatus(ctx, t.RetryTxHash)
+		case bytes.Equal(l.Topics[0], arbCanceledTopic):
+			ticket(l.Topics[1]).Status = RetryableStatusCanceled
+		}
+	}
+	return tickets
+}
+
+// retryableMessageIndex is the request ID of a ticket created by the transaction of the receipt, which is the index
+// of the message in the inbox on L1
+func (c *ethConnector) retryableMessageIndex(ctx context.Context, receipt *txReceiptJSONRPC, ticketID ethtypes.HexBytes0xPrefix) *ethtypes.HexInteger {
+	if !bytes.Equal(receipt.TransactionHash, ticketID) {
+		return nil
+	}
+	txInfo, err := c.getTransactionInfo(ctx, receipt.TransactionHash)
+	if err != nil || txInfo == nil || txInfo.RequestID == nil {
+		log.L(ctx).Warnf("No request ID for retryable ticket %s: %v", ticketID, err)
+		return nil
+	}
+	return (*ethtypes.HexInteger)(new(big.Int).SetBytes(txInfo.RequestID))
+}
+
+func (c *ethConnector) retryRedeemStatus(ctx context.Context, retryTxHash ethtypes.HexBytes0xPrefix) string {
+	var retryReceipt *txReceiptJSONRPC
+	if rpcErr := c.readBackendForTx(retryTxHash.String()).CallRPC(ctx, &retryReceipt, "eth_getTransactionReceipt", retryTxHash); rpcErr != nil {
+		log.L(ctx).Warnf("Unable to get the receipt of retryable redeem %s: %s", retryTxHash, rpcErr.Message)
+		return RetryableStatusRedeemPending
+	}
+	switch {
+	case retryReceipt == nil:
+		return RetryableStatusRedeemPending
+	case retryReceipt.Status != nil && retryReceipt.Status.BigInt().Sign() > 0:
+		return RetryableStatusRedeemed
+	default:
+		return RetryableStatusRedeemFailed
+	}
+}
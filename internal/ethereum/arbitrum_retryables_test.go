@@ -0,0 +1,181 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-evmconnect/mocks/rpcbackendmocks"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	testTicketID    = "0x7d48ae971faf089878b57e3c28e3035540d34f38af395958d2c73c36c57c83a2"
+	testRetryTxHash = "0x9b3c1dcc8cf5c3402ea172cd1dd8a3ff5f3c1466800e661b62bd33682b32b5c4"
+)
+
+func testWord(n int64) ethtypes.HexBytes0xPrefix {
+	return abiWord(big.NewInt(n).Bytes())
+}
+
+func testMessageDeliveredLog(index, kind int64) *logJSONRPC {
+	data := append(append(testWord(0), testWord(kind)...), make([]byte, 6*32)...)
+	return &logJSONRPC{
+		Address: ethtypes.MustNewAddress("0x8315177aB297bA92A06054cE80a67Ed4DBd7ed3a"),
+		Topics:  []ethtypes.HexBytes0xPrefix{arbMessageDeliveredTopic, testWord(index), testWord(0)},
+		Data:    data,
+	}
+}
+
+func testRetryableLog(topics ...ethtypes.HexBytes0xPrefix) *logJSONRPC {
+	return &logJSONRPC{Address: arbRetryableTxAddress, Topics: topics}
+}
+
+// testTicketCreationReceipt is the receipt of the submission of a ticket on L2, with its scheduled auto-redeem
+func testTicketCreationReceipt() *txReceiptJSONRPC {
+	ticketID := ethtypes.MustNewHexBytes0xPrefix(testTicketID)
+	return &txReceiptJSONRPC{
+		TransactionHash: ticketID,
+		Logs: []*logJSONRPC{
+			testRetryableLog(arbTicketCreatedTopic, ticketID),
+			testRetryableLog(arbRedeemScheduledTopic, ticketID, ethtypes.MustNewHexBytes0xPrefix(testRetryTxHash), testWord(0)),
+		},
+	}
+}
+
+func mockRetryReceipt(mRPC *rpcbackendmocks.Backend, receipt *txReceiptJSONRPC, rpcErr *rpcbackend.RPCError) {
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionReceipt", ethtypes.MustNewHexBytes0xPrefix(testRetryTxHash)).
+		Run(func(args mock.Arguments) {
+			*(args[1].(**txReceiptJSONRPC)) = receipt
+		}).
+		Return(rpcErr).Once()
+}
+
+func TestReceiptRetryableTicketsSubmitted(t *testing.T) {
+	_, c, _, done := newTestConnector(t)
+	defer done()
+
+	tickets := c.receiptRetryableTickets(context.Background(), &txReceiptJSONRPC{
+		Logs: []*logJSONRPC{
+			testMessageDeliveredLog(42, arbitrumSubmitRetryableKind),
+			testMessageDeliveredLog(43, 3), // an L2 message, not a retryable ticket
+			{Topics: []ethtypes.HexBytes0xPrefix{arbMessageDeliveredTopic, testWord(44)}},
+			{Topics: []ethtypes.HexBytes0xPrefix{arbMessageDeliveredTopic}},
+		},
+	})
+	assert.Len(t, tickets, 1)
+	assert.Equal(t, RetryableStatusSubmitted, tickets[0].Status)
+	assert.Equal(t, int64(42), tickets[0].MessageIndex.Int64())
+	assert.Nil(t, tickets[0].TicketID)
+}
+
+func TestReceiptRetryableTicketsRedeem(t *testing.T) {
+	_, c, mRPC, done := newTestConnector(t)
+	defer done()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionByHash", ethtypes.MustNewHexBytes0xPrefix(testTicketID)).
+		Run(func(args mock.Arguments) {
+			*(args[1].(**txInfoJSONRPC)) = &txInfoJSONRPC{RequestID: testWord(42)}
+		}).
+		Return(nil).Once()
+
+	for _, tc := range []struct {
+		receipt *txReceiptJSONRPC
+		rpcErr  *rpcbackend.RPCError
+		status  string
+	}{
+		{receipt: &txReceiptJSONRPC{Status: ethtypes.NewHexInteger64(1)}, status: RetryableStatusRedeemed},
+		{receipt: &txReceiptJSONRPC{Status: ethtypes.NewHexInteger64(0)}, status: RetryableStatusRedeemFailed},
+		{status: RetryableStatusRedeemPending},
+		{rpcErr: &rpcbackend.RPCError{Message: "pop"}, status: RetryableStatusRedeemPending},
+	} {
+		mockRetryReceipt(mRPC, tc.receipt, tc.rpcErr)
+		tickets := c.receiptRetryableTickets(context.Background(), testTicketCreationReceipt())
+		assert.Len(t, tickets, 1)
+		assert.Equal(t, testTicketID, tickets[0].TicketID.String())
+		assert.Equal(t, int64(42), tickets[0].MessageIndex.Int64())
+		assert.Equal(t, testRetryTxHash, tickets[0].RetryTxHash.String())
+		assert.Equal(t, int64(0), tickets[0].SequenceNum.Int64())
+		assert.Equal(t, tc.status, tickets[0].Status)
+	}
+	mRPC.AssertExpectations(t)
+}
+
+func TestReceiptRetryableTicketsManual(t *testing.T) {
+	_, c, mRPC, done := newTestConnector(t)
+	defer done()
+	ticketID := ethtypes.MustNewHexBytes0xPrefix(testTicketID)
+	otherID := testWord(1)
+
+	// A ticket created without an auto-redeem, in the receipt of another transaction, and a canceled ticket
+	tickets := c.receiptRetryableTickets(context.Background(), &txReceiptJSONRPC{
+		TransactionHash: testWord(2),
+		Logs: []*logJSONRPC{
+			testRetryableLog(arbTicketCreatedTopic, ticketID),
+			testRetryableLog(arbCanceledTopic, otherID),
+			testRetryableLog(arbRedeemScheduledTopic, otherID), // not enough topics
+			{Address: ethtypes.MustNewAddress("0x000000000000000000000000000000000000006f"), Topics: []ethtypes.HexBytes0xPrefix{arbTicketCreatedTopic, otherID}},
+			{Topics: []ethtypes.HexBytes0xPrefix{arbTicketCreatedTopic, otherID}},
+		},
+	})
+	assert.Len(t, tickets, 2)
+	assert.Equal(t, RetryableStatusCreated, tickets[0].Status)
+	assert.Nil(t, tickets[0].MessageIndex)
+	assert.Equal(t, RetryableStatusCanceled, tickets[1].Status)
+
+	// The request ID is not available
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionByHash", ticketID).
+		Return(&rpcbackend.RPCError{Message: "pop"}).Once()
+	mockRetryReceipt(mRPC, nil, nil)
+	tickets = c.receiptRetryableTickets(context.Background(), testTicketCreationReceipt())
+	assert.Nil(t, tickets[0].MessageIndex)
+	assert.Equal(t, RetryableStatusRedeemPending, tickets[0].Status)
+	mRPC.AssertExpectations(t)
+}
+
+func TestTransactionReceiptRetryables(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t, func(conf config.Section) {
+		conf.Set(ArbitrumRetryablesReceipts, true)
+	})
+	defer done()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionReceipt", mock.Anything).
+		Run(func(args mock.Arguments) {
+			err := json.Unmarshal([]byte(sampleJSONRPCReceipt), args[1])
+			assert.NoError(t, err)
+			receipt := *args[1].(**txReceiptJSONRPC)
+			receipt.Logs = append(receipt.Logs, testMessageDeliveredLog(42, arbitrumSubmitRetryableKind))
+		}).
+		Return(nil)
+
+	var req ffcapi.TransactionReceiptRequest
+	err := json.Unmarshal([]byte(sampleGetReceipt), &req)
+	assert.NoError(t, err)
+	res, _, err := c.TransactionReceipt(ctx, &req)
+	assert.NoError(t, err)
+	var extraInfo receiptExtraInfo
+	err = json.Unmarshal(res.ExtraInfo.Bytes(), &extraInfo)
+	assert.NoError(t, err)
+	assert.Len(t, extraInfo.Retryables, 1)
+	assert.Equal(t, RetryableStatusSubmitted, extraInfo.Retryables[0].Status)
+}
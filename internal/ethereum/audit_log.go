@@ -0,0 +1,230 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rlp"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+)
+
+const (
+	auditOperationSend         = "send_transaction"
+	auditOperationPrivateSend  = "private_transaction_send"
+	auditOperationFillNonceGap = "fill_nonce_gap"
+)
+
+// AuditRecord is written for every transaction submission attempt, including those rejected before
+// they are sent to the node
+type AuditRecord struct {
+	Time            *fftypes.FFTime           `json:"time"`
+	RequestID       string                    `json:"requestId,omitempty"` // the FFTM transaction ID, or the ID of the HTTP request
+	Operation       string                    `json:"operation"`
+	PreSigned       bool                      `json:"preSigned,omitempty"`
+	From            string                    `json:"from,omitempty"` // recovered from the signature for pre-signed transactions
+	To              string                    `json:"to,omitempty"`
+	Selector        ethtypes.HexBytes0xPrefix `json:"selector,omitempty"`
+	Value           *ethtypes.HexInteger      `json:"value,omitempty"`
+	Gas             *ethtypes.HexInteger      `json:"gas,omitempty"`
+	Nonce           *ethtypes.HexInteger      `json:"nonce,omitempty"`
+	PrivateFor      []string                  `json:"privateFor,omitempty"`
+	TransactionHash string                    `json:"transactionHash,omitempty"`
+	Reason          ffcapi.ErrorReason        `json:"reason,omitempty"`
+	Error           string                    `json:"error,omitempty"`
+}
+
+// auditLog appends records to a file, and posts them to a webhook in order from a background loop.
+// Submissions wait when the webhook queue is full, rather than records being dropped.
+type auditLog struct {
+	fileMux sync.Mutex
+	file    *os.File
+	webhook *resty.Client
+	queue   chan *AuditRecord
+	done    chan struct{}
+}
+
+// newAuditLog returns nil if no audit sink is configured
+func newAuditLog(ctx context.Context, conf config.Section) (*auditLog, error) {
+	fileName := conf.GetString(AuditLogFile)
+	webhookConf := conf.SubSection(AuditLogWebhookConfig)
+	if fileName == "" && webhookConf.GetString(ffresty.HTTPConfigURL) == "" {
+		return nil, nil
+	}
+	al := &auditLog{}
+	if webhookConf.GetString(ffresty.HTTPConfigURL) != "" {
+		httpConf, err := ffresty.GenerateConfig(ctx, webhookConf)
+		if err != nil {
+			return nil, err
+		}
+		al.webhook = ffresty.NewWithConfig(ctx, *httpConf)
+		al.queue = make(chan *AuditRecord, conf.GetInt(AuditLogQueueSize))
+		al.done = make(chan struct{})
+	}
+	if fileName != "" {
+		f, err := os.OpenFile(fileName, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0640)
+		if err != nil {
+			return nil, i18n.WrapError(ctx, err, msgs.MsgAuditLogOpenFailed, fileName)
+		}
+		al.file = f
+	}
+	if al.webhook != nil {
+		go al.webhookLoop(ctx)
+	}
+	return al, nil
+}
+
+func (al *auditLog) write(ctx context.Context, rec *AuditRecord) {
+	if al == nil {
+		return
+	}
+	if al.file != nil {
+		b, _ := json.Marshal(rec)
+		al.fileMux.Lock()
+		_, err := al.file.Write(append(b, '\n'))
+		al.fileMux.Unlock()
+		if err != nil {
+			log.L(ctx).Errorf("Failed to write audit record %s: %s", b, err)
+		}
+	}
+	if al.webhook != nil {
+		select {
+		case al.queue <- rec:
+		case <-ctx.Done():
+			b, _ := json.Marshal(rec)
+			log.L(ctx).Errorf("Context closed before audit record could be queued for the webhook: %s", b)
+		}
+	}
+}
+
+func (al *auditLog) webhookLoop(ctx context.Context) {
+	defer close(al.done)
+	for {
+		select {
+		case rec := <-al.queue:
+			res, err := al.webhook.R().SetContext(ctx).SetBody(rec).Post("")
+			if err == nil && res.IsError() {
+				err = i18n.NewError(ctx, msgs.MsgAuditWebhookFailed, res.Status())
+			}
+			if err != nil {
+				b, _ := json.Marshal(rec)
+				log.L(ctx).Errorf("Failed to deliver audit record to webhook: %s: %s", err, b)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (al *auditLog) waitClosed() {
+	if al != nil && al.done != nil {
+		<-al.done
+	}
+}
+
+// auditRequestID is the ID of the FFTM managed transaction for submissions from the simple transaction
+// handler, which passes its run context, or otherwise the ID the API server assigned to the HTTP request
+func auditRequestID(ctx context.Context) string {
+	if id := sendRequestID(ctx); id != "" {
+		return id
+	}
+	if id, ok := log.L(ctx).Data["httpreq"].(string); ok {
+		return id
+	}
+	return ""
+}
+
+// auditAddress normalizes the address of a request, keeping it as supplied if it is invalid
+func auditAddress(s string) string {
+	if addr, err := ethtypes.NewAddress(s); err == nil {
+		return addr.String()
+	}
+	return s
+}
+
+// auditSubmission records a submission attempt, with the fields of a pre-signed transaction decoded from
+// the signed payload where possible
+func (c *ethConnector) auditSubmission(ctx context.Context, operation string, req *ffcapi.TransactionSendRequest, privateFor []string, res *ffcapi.TransactionSendResponse, reason ffcapi.ErrorReason, err error) {
+	if c.auditLog == nil {
+		return
+	}
+	rec := &AuditRecord{
+		Time:       fftypes.Now(),
+		RequestID:  auditRequestID(ctx),
+		Operation:  operation,
+		PreSigned:  req.PreSigned,
+		PrivateFor: privateFor,
+		Reason:     reason,
+	}
+	switch {
+	case req.PreSigned && operation == auditOperationPrivateSend:
+		// The V of a private transaction marks it as private, rather than encoding a chain ID, so the
+		// signer is not recovered. The fields are those of a legacy transaction.
+		if _, rawTx, decodeErr := markPrivateRawTx(ctx, req.TransactionData); decodeErr == nil {
+			// markPrivateRawTx has checked this is the list of nine fields of a legacy transaction
+			decoded, _, _ := rlp.Decode(rawTx)
+			fields := decoded.(rlp.List)
+			rec.Nonce = (*ethtypes.HexInteger)(fields[0].ToData().IntOrZero())
+			rec.Gas = (*ethtypes.HexInteger)(fields[2].ToData().IntOrZero())
+			if to := fields[3].ToData().Address(); to != nil {
+				rec.To = to.String()
+			}
+			rec.Value = (*ethtypes.HexInteger)(fields[4].ToData().IntOrZero())
+			if data := fields[5].ToData(); len(data) >= 4 {
+				rec.Selector = ethtypes.HexBytes0xPrefix(data[0:4])
+			}
+		}
+	case req.PreSigned:
+		if rawTx, decodeErr := ethtypes.NewHexBytes0xPrefix(req.TransactionData); decodeErr == nil {
+			if tx, decodeErr := c.DecodeTransaction(ctx, &DecodeTransactionRequest{Transaction: rawTx}); decodeErr == nil {
+				rec.From, rec.Value, rec.Gas, rec.Nonce = tx.From.String(), tx.Value, tx.Gas, tx.Nonce
+				if tx.To != nil {
+					rec.To = tx.To.String()
+				}
+				if len(tx.Input) >= 4 {
+					rec.Selector = tx.Input[0:4]
+				}
+			}
+		}
+	default:
+		rec.From, rec.To = auditAddress(req.From), auditAddress(req.To)
+		rec.Value, rec.Gas, rec.Nonce = (*ethtypes.HexInteger)(req.Value), (*ethtypes.HexInteger)(req.Gas), (*ethtypes.HexInteger)(req.Nonce)
+		if data, decodeErr := hex.DecodeString(strings.TrimPrefix(req.TransactionData, "0x")); decodeErr == nil && len(data) >= 4 {
+			rec.Selector = data[0:4]
+		}
+	}
+	if res != nil {
+		rec.TransactionHash = res.TransactionHash
+	}
+	if err != nil {
+		rec.Error = err.Error()
+	}
+	c.auditLog.write(ctx, rec)
+}
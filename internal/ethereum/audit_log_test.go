@@ -0,0 +1,252 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/fftls"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-signer/pkg/ethsigner"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/apitypes"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/txhandler/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func readAuditFile(t *testing.T, fileName string) []*AuditRecord {
+	f, err := os.Open(fileName)
+	assert.NoError(t, err)
+	defer f.Close()
+	var records []*AuditRecord
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var rec AuditRecord
+		err := json.Unmarshal(scanner.Bytes(), &rec)
+		assert.NoError(t, err)
+		records = append(records, &rec)
+	}
+	return records
+}
+
+func TestAuditLogFile(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "audit.log")
+	ctx, c, mRPC, done := newTestConnector(t, func(conf config.Section) {
+		conf.Set(AuditLogFile, fileName)
+	})
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_sendTransaction", mock.Anything).
+		Run(func(args mock.Arguments) {
+			*(args[1].(*ethtypes.HexBytes0xPrefix)) = ethtypes.MustNewHexBytes0xPrefix("0x3e2398ff4a875a8b9f87a6eeaaa41a139a68adeb509731300d4b90d1bdc1c4fc")
+		}).
+		Return(nil)
+
+	var req ffcapi.TransactionSendRequest
+	err := json.Unmarshal([]byte(sampleSendTX), &req)
+	assert.NoError(t, err)
+	rc := &simple.RunContext{Context: ctx, TX: &apitypes.ManagedTX{ID: "ns1:tx1"}}
+	_, _, err = c.TransactionSend(rc, &req)
+	assert.NoError(t, err)
+
+	// Pre-signed transactions are decoded, and rejected submissions are recorded
+	_, _, err = c.TransactionSend(log.WithLogField(ctx, "httpreq", "req1"), &ffcapi.TransactionSendRequest{
+		PreSigned:       true,
+		TransactionData: sampleSignedLegacyTX[0 : len(sampleSignedLegacyTX)-2],
+	})
+	assert.Error(t, err)
+	c.ethChainID.Store(big.NewInt(1))
+	_, _, err = c.TransactionSend(ctx, &ffcapi.TransactionSendRequest{
+		PreSigned:       true,
+		TransactionData: sampleSigned1559TX,
+	})
+	assert.Regexp(t, "FF23113", err)
+
+	records := readAuditFile(t, fileName)
+	assert.Len(t, records, 3)
+	assert.Equal(t, "ns1:tx1", records[0].RequestID)
+	assert.Equal(t, auditOperationSend, records[0].Operation)
+	assert.Equal(t, "0xb480f96c0a3d6e9e9a263e4665a39bfa6c4d01e8", records[0].From)
+	assert.Equal(t, "0xe1a078b9e2b145d0a7387f09277c6ae1d9470771", records[0].To)
+	assert.Equal(t, "0x60fe47b1", records[0].Selector.String())
+	assert.Equal(t, "12345678901234567890123456789", records[0].Value.BigInt().String())
+	assert.Equal(t, int64(1000000), records[0].Gas.Int64())
+	assert.Equal(t, int64(111), records[0].Nonce.Int64())
+	assert.Equal(t, "0x3e2398ff4a875a8b9f87a6eeaaa41a139a68adeb509731300d4b90d1bdc1c4fc", records[0].TransactionHash)
+	assert.Empty(t, records[0].Error)
+
+	assert.Equal(t, "req1", records[1].RequestID)
+	assert.True(t, records[1].PreSigned)
+	assert.Empty(t, records[1].From)
+	assert.Equal(t, ffcapi.ErrorReasonInvalidInputs, records[1].Reason)
+	assert.NotEmpty(t, records[1].Error)
+
+	assert.Empty(t, records[2].RequestID)
+	assert.Equal(t, sampleSignedTXFrom, records[2].From)
+	assert.Equal(t, "0x60fe47b1", records[2].Selector.String())
+	assert.Regexp(t, "FF23113", records[2].Error)
+}
+
+func TestAuditLogNonceGapFill(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "audit.log")
+	ctx, c, mRPC, done := newTestConnector(t, func(conf config.Section) {
+		conf.Set(AuditLogFile, fileName)
+	})
+	defer done()
+
+	mockNonces(mRPC, 3, 3)
+	mockTxPoolContentFrom(mRPC, `{"pending": {}, "queued": {"5": {}}}`)
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_sendTransaction", mock.MatchedBy(func(tx *ethsigner.Transaction) bool {
+		return tx.Nonce.BigInt().Int64() == 3
+	})).
+		Run(func(args mock.Arguments) {
+			*(args[1].(*ethtypes.HexBytes0xPrefix)) = ethtypes.MustNewHexBytes0xPrefix("0x7d48ae971faf089878b57e3c28e3035540d34f38af395958d2c73c36c57c83a2")
+		}).
+		Return(nil)
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_sendTransaction", mock.MatchedBy(func(tx *ethsigner.Transaction) bool {
+		return tx.Nonce.BigInt().Int64() == 4
+	})).
+		Return(&rpcbackend.RPCError{Message: "nonce too low"})
+
+	_, err := c.NonceGap(ctx, &NonceGapRequest{Signer: sampleSigner, Fill: true, GasPrice: fftypes.JSONAnyPtr(`"100"`)})
+	assert.NoError(t, err)
+
+	// Each fill is recorded, whether or not the node accepted it
+	records := readAuditFile(t, fileName)
+	assert.Len(t, records, 2)
+	assert.Equal(t, auditOperationFillNonceGap, records[0].Operation)
+	assert.Equal(t, sampleSigner, records[0].From)
+	assert.Equal(t, sampleSigner, records[0].To)
+	assert.Equal(t, int64(3), records[0].Nonce.Int64())
+	assert.Equal(t, int64(fillTransactionGas), records[0].Gas.Int64())
+	assert.Equal(t, "0x7d48ae971faf089878b57e3c28e3035540d34f38af395958d2c73c36c57c83a2", records[0].TransactionHash)
+	assert.Empty(t, records[0].Error)
+
+	assert.Equal(t, int64(4), records[1].Nonce.Int64())
+	assert.Empty(t, records[1].TransactionHash)
+	assert.Equal(t, ffcapi.ErrorReasonNonceTooLow, records[1].Reason)
+	assert.Regexp(t, "nonce too low", records[1].Error)
+}
+
+func TestAuditLogPreSigned(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "audit.log")
+	ctx, c, mRPC, done := newTestConnector(t, func(conf config.Section) {
+		conf.Set(AuditLogFile, fileName)
+	})
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_sendRawTransaction", sampleSigned1559TX).
+		Run(func(args mock.Arguments) {
+			*(args[1].(*ethtypes.HexBytes0xPrefix)) = ethtypes.MustNewHexBytes0xPrefix(sampleSigned1559Hash)
+		}).
+		Return(nil)
+
+	res, _, err := c.TransactionSend(ctx, &ffcapi.TransactionSendRequest{
+		PreSigned:       true,
+		TransactionData: sampleSigned1559TX,
+	})
+	assert.NoError(t, err)
+
+	records := readAuditFile(t, fileName)
+	assert.Len(t, records, 1)
+	assert.Equal(t, sampleSignedTXFrom, records[0].From)
+	assert.Equal(t, "0x497eedc4299dea2f2a364be10025d0ad0f702de3", records[0].To)
+	assert.Equal(t, "0x60fe47b1", records[0].Selector.String())
+	assert.Equal(t, int64(111), records[0].Nonce.Int64())
+	assert.Equal(t, res.TransactionHash, records[0].TransactionHash)
+}
+
+func TestAuditLogWebhook(t *testing.T) {
+	received := make(chan *AuditRecord)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var rec AuditRecord
+		err := json.NewDecoder(r.Body).Decode(&rec)
+		assert.NoError(t, err)
+		if len(rec.PrivateFor) == 0 {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		received <- &rec
+	}))
+	defer server.Close()
+
+	ctx, c, _, done := newTestConnector(t, func(conf config.Section) {
+		conf.SubSection(AuditLogWebhookConfig).Set(ffresty.HTTPConfigURL, server.URL)
+	})
+	defer done()
+
+	// A failure of the webhook is logged, and delivery continues with the next record
+	_, _, err := c.TransactionSend(ctx, &ffcapi.TransactionSendRequest{
+		PreSigned:       true,
+		TransactionData: "0x04",
+	})
+	assert.Error(t, err)
+	rec := <-received
+	assert.True(t, rec.PreSigned)
+
+	privateReq := testPrivateSendRequest(t, sampleSendRawTX)
+	privateReq.TransactionData = testSignedLegacyTx(27).String()
+	privateReq.PrivateFrom = "!!"
+	_, _, err = c.PrivateTransactionSend(ctx, privateReq)
+	assert.Regexp(t, "FF23084", err)
+	rec = <-received
+	assert.Equal(t, auditOperationPrivateSend, rec.Operation)
+	assert.Equal(t, []string{testTesseraKey2}, rec.PrivateFor)
+	assert.Empty(t, rec.From)
+	assert.Equal(t, "0xe1a078b9e2b145d0a7387f09277c6ae1d9470771", rec.To)
+	assert.Equal(t, "0x7d48ae97", rec.Selector.String())
+	assert.Equal(t, int64(100000), rec.Gas.Int64())
+	assert.Equal(t, ffcapi.ErrorReasonInvalidInputs, rec.Reason)
+}
+
+func TestAuditLogQueueClosed(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	cancelCtx()
+	al := &auditLog{webhook: ffresty.NewWithConfig(ctx, ffresty.Config{}), queue: make(chan *AuditRecord)}
+	al.write(ctx, &AuditRecord{})
+}
+
+func TestNewAuditLogFail(t *testing.T) {
+	config.RootConfigReset()
+	conf := config.RootSection("audit_test")
+	InitConfig(conf)
+
+	conf.Set(AuditLogFile, filepath.Join(t.TempDir(), "missing", "audit.log"))
+	_, err := newAuditLog(context.Background(), conf)
+	assert.Regexp(t, "FF23120", err)
+
+	webhookConf := conf.SubSection(AuditLogWebhookConfig)
+	webhookConf.Set(ffresty.HTTPConfigURL, "http://localhost:12345")
+	tlsConf := webhookConf.SubSection("tls")
+	tlsConf.Set(fftls.HTTPConfTLSEnabled, true)
+	tlsConf.Set(fftls.HTTPConfTLSCAFile, "!!!badness")
+	_, err = newAuditLog(context.Background(), conf)
+	assert.Regexp(t, "FF00153", err)
+}
@@ -0,0 +1,145 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"math/big"
+	"sort"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+)
+
+// The EIP-1559 parameters of the base fee, which changes by at most 1/8 per block towards the gas target of
+// half the gas limit
+const (
+	baseFeeChangeDenominator = 8
+	baseFeeElasticity        = 2
+)
+
+// BaseFeeWindow is the base fee of the latest blocks, with the projected base fee of the next block
+type BaseFeeWindow struct {
+	BlockNumber       *ethtypes.HexInteger `json:"blockNumber"`
+	BaseFeePerGas     *ethtypes.HexInteger `json:"baseFeePerGas"`
+	NextBaseFeePerGas *ethtypes.HexInteger `json:"nextBaseFeePerGas"`
+	Blocks            []*BlockBaseFee      `json:"blocks"`
+}
+
+// BlockBaseFee is the base fee of a block, with the gas used and limit it is adjusted from for the next block
+type BlockBaseFee struct {
+	BlockNumber   *ethtypes.HexInteger      `json:"blockNumber"`
+	BlockHash     ethtypes.HexBytes0xPrefix `json:"blockHash"`
+	BaseFeePerGas *ethtypes.HexInteger      `json:"baseFeePerGas"`
+	GasUsed       *ethtypes.HexInteger      `json:"gasUsed"`
+	GasLimit      *ethtypes.HexInteger      `json:"gasLimit"`
+}
+
+// nextBaseFee projects the base fee of the block after this one with the EIP-1559 formula. Nil is returned
+// for a block without a base fee, or without the gas used and limit the projection needs.
+func nextBaseFee(bi *blockInfoJSONRPC) *big.Int {
+	if bi.BaseFeePerGas == nil || bi.GasUsed == nil || bi.GasLimit == nil {
+		return nil
+	}
+	baseFee := bi.BaseFeePerGas.BigInt()
+	gasUsed := bi.GasUsed.BigInt()
+	target := new(big.Int).Div(bi.GasLimit.BigInt(), big.NewInt(baseFeeElasticity))
+	if target.Sign() == 0 {
+		return new(big.Int).Set(baseFee)
+	}
+	switch gasUsed.Cmp(target) {
+	case 0:
+		return new(big.Int).Set(baseFee)
+	case 1:
+		delta := new(big.Int).Mul(baseFee, new(big.Int).Sub(gasUsed, target))
+		delta.Div(delta, target)
+		delta.Div(delta, big.NewInt(baseFeeChangeDenominator))
+		if delta.Sign() == 0 {
+			delta.SetInt64(1)
+		}
+		return delta.Add(baseFee, delta)
+	default:
+		delta := new(big.Int).Mul(baseFee, new(big.Int).Sub(target, gasUsed))
+		delta.Div(delta, target)
+		delta.Div(delta, big.NewInt(baseFeeChangeDenominator))
+		return delta.Sub(baseFee, delta)
+	}
+}
+
+// BaseFeeWindow returns the base fee of the latest blocks of the canonical chain held by the block listener. Before
+// the block listener has any blocks, only the latest block is queried from the node.
+func (c *ethConnector) BaseFeeWindow(ctx context.Context) (*BaseFeeWindow, error) {
+	blocks := c.blockListener.recentCanonicalBlocks(c.baseFeeWindowBlocks)
+	if len(blocks) == 0 {
+		latest, err := c.latestBlockInfo(ctx)
+		if err != nil {
+			return nil, err
+		}
+		blocks = []*blockInfoJSONRPC{latest}
+	}
+	head := blocks[len(blocks)-1]
+	next := nextBaseFee(head)
+	if next == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgBaseFeeNotAvailable, head.Number.BigInt())
+	}
+	window := &BaseFeeWindow{
+		BlockNumber:       head.Number,
+		BaseFeePerGas:     head.BaseFeePerGas,
+		NextBaseFeePerGas: (*ethtypes.HexInteger)(next),
+		Blocks:            make([]*BlockBaseFee, 0, len(blocks)),
+	}
+	for _, bi := range blocks {
+		if bi.BaseFeePerGas != nil {
+			window.Blocks = append(window.Blocks, &BlockBaseFee{
+				BlockNumber:   bi.Number,
+				BlockHash:     bi.Hash,
+				BaseFeePerGas: bi.BaseFeePerGas,
+				GasUsed:       bi.GasUsed,
+				GasLimit:      bi.GasLimit,
+			})
+		}
+	}
+	return window, nil
+}
+
+// recentCanonicalBlocks returns up to the given number of the latest blocks of the canonical chain, in block order,
+// from those still in the block cache
+func (bl *blockListener) recentCanonicalBlocks(limit int) []*blockInfoJSONRPC {
+	bl.mux.Lock()
+	numbers := make([]int64, 0, len(bl.canonicalChainIndex))
+	for number := range bl.canonicalChainIndex {
+		numbers = append(numbers, number)
+	}
+	sort.Slice(numbers, func(i, j int) bool { return numbers[i] > numbers[j] })
+	if len(numbers) > limit {
+		numbers = numbers[:limit]
+	}
+	hashes := make([]string, len(numbers))
+	for i, number := range numbers {
+		hashes[len(numbers)-1-i] = bl.canonicalChainIndex[number]
+	}
+	bl.mux.Unlock()
+
+	blocks := make([]*blockInfoJSONRPC, 0, len(hashes))
+	for _, hash := range hashes {
+		if cached, ok := bl.blockCache.Peek(hash); ok {
+			blocks = append(blocks, cached.(*blockInfoJSONRPC))
+		}
+	}
+	return blocks
+}
@@ -0,0 +1,160 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func testBaseFeeBlock(number, baseFee, gasUsed, gasLimit int64) *blockInfoJSONRPC {
+	return &blockInfoJSONRPC{
+		Number:        ethtypes.NewHexInteger64(number),
+		Hash:          ethtypes.MustNewHexBytes0xPrefix(fmt.Sprintf("0x%064x", number)),
+		ParentHash:    ethtypes.MustNewHexBytes0xPrefix(fmt.Sprintf("0x%064x", number-1)),
+		BaseFeePerGas: ethtypes.NewHexInteger64(baseFee),
+		GasUsed:       ethtypes.NewHexInteger64(gasUsed),
+		GasLimit:      ethtypes.NewHexInteger64(gasLimit),
+	}
+}
+
+func TestNextBaseFee(t *testing.T) {
+	assert.Equal(t, int64(1000000000), nextBaseFee(testBaseFeeBlock(1, 1000000000, 15000000, 30000000)).Int64())
+	// Full and empty blocks move the base fee by 1/8
+	assert.Equal(t, int64(1125000000), nextBaseFee(testBaseFeeBlock(1, 1000000000, 30000000, 30000000)).Int64())
+	assert.Equal(t, int64(875000000), nextBaseFee(testBaseFeeBlock(1, 1000000000, 0, 30000000)).Int64())
+	assert.Equal(t, int64(1031250000), nextBaseFee(testBaseFeeBlock(1, 1000000000, 18750000, 30000000)).Int64())
+	// An increase is always at least 1 wei
+	assert.Equal(t, int64(8), nextBaseFee(testBaseFeeBlock(1, 7, 15000001, 30000000)).Int64())
+	assert.Equal(t, int64(7), nextBaseFee(testBaseFeeBlock(1, 7, 0, 0)).Int64())
+
+	bi := testBaseFeeBlock(1, 7, 0, 0)
+	bi.BaseFeePerGas = nil
+	assert.Nil(t, nextBaseFee(bi))
+}
+
+func TestBaseFeeWindowCanonicalChain(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t, func(conf config.Section) {
+		conf.Set(BaseFeeWindowBlocks, 2)
+	})
+	defer done()
+	bl := c.blockListener
+	for i, bi := range []*blockInfoJSONRPC{
+		testBaseFeeBlock(100, 1000000000, 30000000, 30000000),
+		testBaseFeeBlock(101, 1125000000, 30000000, 30000000),
+		testBaseFeeBlock(102, 1265625000, 0, 30000000),
+	} {
+		bl.addToBlockCache(bi)
+		bl.canonicalChainIndex[int64(100+i)] = bi.Hash.String()
+	}
+
+	window, err := c.BaseFeeWindow(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(102), window.BlockNumber.BigInt().Int64())
+	assert.Equal(t, int64(1265625000), window.BaseFeePerGas.BigInt().Int64())
+	assert.Equal(t, int64(1107421875), window.NextBaseFeePerGas.BigInt().Int64())
+	assert.Len(t, window.Blocks, 2)
+	assert.Equal(t, int64(101), window.Blocks[0].BlockNumber.BigInt().Int64())
+	assert.Equal(t, int64(102), window.Blocks[1].BlockNumber.BigInt().Int64())
+}
+
+func TestBaseFeeWindowLatestBlock(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+	url, close := newTestRouteServer(t, c)
+	defer close()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", "latest", false).
+		Run(func(args mock.Arguments) {
+			*(args[1].(**blockInfoJSONRPC)) = testBaseFeeBlock(100, 1000000000, 30000000, 30000000)
+		}).
+		Return(nil).Once()
+
+	res, err := http.Get(url + "/gas/basefee")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var window BaseFeeWindow
+	err = json.NewDecoder(res.Body).Decode(&window)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100), window.BlockNumber.BigInt().Int64())
+	assert.Equal(t, int64(1125000000), window.NextBaseFeePerGas.BigInt().Int64())
+	assert.Len(t, window.Blocks, 1)
+
+	// A block without a base fee is not included in the window
+	c.blockListener.addToBlockCache(&blockInfoJSONRPC{Number: ethtypes.NewHexInteger64(99), Hash: ethtypes.MustNewHexBytes0xPrefix("0x99")})
+	c.blockListener.addToBlockCache(testBaseFeeBlock(100, 1000000000, 30000000, 30000000))
+	c.blockListener.canonicalChainIndex[99] = "0x99"
+	c.blockListener.canonicalChainIndex[100] = testBaseFeeBlock(100, 0, 0, 0).Hash.String()
+	window2, err := c.BaseFeeWindow(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, window2.Blocks, 1)
+}
+
+func TestBaseFeeWindowErrors(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", "latest", false).
+		Return(&rpcbackend.RPCError{Message: "pop"}).Once()
+	_, err := c.BaseFeeWindow(ctx)
+	assert.Regexp(t, "pop", err)
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", "latest", false).
+		Return(nil).Once()
+	_, err = c.BaseFeeWindow(ctx)
+	assert.Regexp(t, "FF23011", err)
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", "latest", false).
+		Run(func(args mock.Arguments) {
+			*(args[1].(**blockInfoJSONRPC)) = &blockInfoJSONRPC{Number: ethtypes.NewHexInteger64(100)}
+		}).
+		Return(nil).Once()
+	_, err = c.BaseFeeWindow(ctx)
+	assert.Regexp(t, "FF23155.*100", err)
+}
+
+func TestPublishBlockToSinksBaseFee(t *testing.T) {
+	_, c, _, done := newTestConnector(t)
+	defer done()
+	bl := c.blockListener
+	bl.publishToSinks(&minimalBlockInfo{number: 100}) // no sinks
+
+	sp, _ := newTestSinkPublisher(c, 0)
+	sp.blocks = true
+	c.sinks = []*sinkPublisher{sp}
+	defer func() { c.sinks = nil }() // not started, so not waited for on close
+
+	bi := testBaseFeeBlock(100, 1000000000, 30000000, 30000000)
+	bl.addToBlockCache(bi)
+	bl.publishToSinks(&minimalBlockInfo{number: 100, hash: bi.Hash.String(), parentHash: bi.ParentHash.String()})
+	bl.publishToSinks(&minimalBlockInfo{number: 101, hash: "0x101"})
+
+	block := (<-sp.queue).Value.(*SinkBlock)
+	assert.Equal(t, int64(1000000000), block.BaseFeePerGas.BigInt().Int64())
+	assert.Equal(t, int64(1125000000), block.NextBaseFeePerGas.BigInt().Int64())
+	block = (<-sp.queue).Value.(*SinkBlock)
+	assert.Nil(t, block.BaseFeePerGas)
+	assert.Nil(t, block.NextBaseFeePerGas)
+}
@@ -0,0 +1,118 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+)
+
+// The blob base fee never falls below 1 wei (EIP-4844)
+var minBlobBaseFee = big.NewInt(1)
+
+// blobGasParams are the EIP-4844 blob gas parameters of the chain, which change with forks so are configured
+type blobGasParams struct {
+	target         int64
+	max            int64
+	updateFraction int64
+}
+
+// fakeExponential approximates factor * e ** (numerator / denominator) with integer arithmetic, exactly as
+// specified by EIP-4844, so the result matches the blob base fee computed by the node
+func fakeExponential(factor, numerator, denominator *big.Int) *big.Int {
+	output := new(big.Int)
+	accum := new(big.Int).Mul(factor, denominator)
+	divisor := new(big.Int)
+	for i := int64(1); accum.Sign() > 0; i++ {
+		output.Add(output, accum)
+		accum.Mul(accum, numerator)
+		accum.Div(accum, divisor.Mul(denominator, big.NewInt(i)))
+	}
+	return output.Div(output, denominator)
+}
+
+// nextExcessBlobGas returns the excess blob gas of the block after this one, which is the blob gas used
+// above the target carried forward. Nil is returned for a block from before EIP-4844.
+func (bp *blobGasParams) nextExcessBlobGas(bi *blockInfoJSONRPC) *big.Int {
+	if bi.ExcessBlobGas == nil {
+		return nil
+	}
+	excess := new(big.Int).Set(bi.ExcessBlobGas.BigInt())
+	if bi.BlobGasUsed != nil {
+		excess.Add(excess, bi.BlobGasUsed.BigInt())
+	}
+	excess.Sub(excess, big.NewInt(bp.target))
+	if excess.Sign() < 0 {
+		excess.SetInt64(0)
+	}
+	return excess
+}
+
+func (bp *blobGasParams) blobBaseFee(excess *big.Int) *big.Int {
+	return fakeExponential(minBlobBaseFee, excess, big.NewInt(bp.updateFraction))
+}
+
+// maxFeePerBlobGas allows for every block until the target inclusion block using the maximum blob gas,
+// which is the fastest the blob base fee can rise
+func (bp *blobGasParams) maxFeePerBlobGas(nextExcess *big.Int, targetBlocks int) *big.Int {
+	rise := new(big.Int).Mul(big.NewInt(bp.max-bp.target), big.NewInt(int64(targetBlocks)))
+	return bp.blobBaseFee(rise.Add(rise, nextExcess))
+}
+
+// addBlobGasFees adds the blob base fee of the next block to the gas price estimate, with the max fee per
+// blob gas of a transaction targeting inclusion within the blocks of each suggestion. Nothing is added if
+// the latest block does not have an excess blob gas, such as on chains without EIP-4844.
+func (c *ethConnector) addBlobGasFees(ctx context.Context, bp *blobGasParams, gp *GasPriceWithSuggestions) {
+	head, err := c.latestBlockInfo(ctx)
+	if err != nil {
+		log.L(ctx).Warnf("Unable to estimate the blob gas fee: %s", err)
+		return
+	}
+	nextExcess := bp.nextExcessBlobGas(head)
+	if nextExcess == nil {
+		log.L(ctx).Debugf("Block %s does not have an excess blob gas", head.Number)
+		return
+	}
+	gp.BlobBaseFee = (*fftypes.FFBigInt)(bp.blobBaseFee(nextExcess))
+	gp.MaxFeePerBlobGas = (*fftypes.FFBigInt)(bp.maxFeePerBlobGas(nextExcess, feeBands[1].targetBlocks))
+	if gp.Suggestions != nil {
+		for _, s := range []*FeeSuggestion{gp.Suggestions.Low, gp.Suggestions.Medium, gp.Suggestions.High} {
+			s.MaxFeePerBlobGas = (*fftypes.FFBigInt)(bp.maxFeePerBlobGas(nextExcess, s.TargetBlocks))
+		}
+	}
+}
+
+// latestBlockInfo returns the head of the canonical chain held by the block listener, or queries the latest
+// block from the node before the block listener has any blocks
+func (c *ethConnector) latestBlockInfo(ctx context.Context) (*blockInfoJSONRPC, error) {
+	if blocks := c.blockListener.recentCanonicalBlocks(1); len(blocks) > 0 {
+		return blocks[0], nil
+	}
+	var latest *blockInfoJSONRPC
+	if rpcErr := c.readBackend().CallRPC(ctx, &latest, "eth_getBlockByNumber", "latest", false); rpcErr != nil {
+		return nil, rpcErr.Error()
+	}
+	if latest == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgBlockNotAvailable)
+	}
+	return latest, nil
+}
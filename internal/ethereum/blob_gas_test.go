@@ -0,0 +1,161 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-signer/pkg/ethsigner"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func testBlobGasBlock(number, excessBlobGas, blobGasUsed int64) *blockInfoJSONRPC {
+	bi := testBaseFeeBlock(number, 1000000000, 15000000, 30000000)
+	bi.ExcessBlobGas = ethtypes.NewHexInteger64(excessBlobGas)
+	bi.BlobGasUsed = ethtypes.NewHexInteger64(blobGasUsed)
+	return bi
+}
+
+func TestFakeExponential(t *testing.T) {
+	// Test vectors from EIP-4844
+	for _, v := range [][4]int64{
+		{1, 0, 1, 1},
+		{38493, 0, 1000, 38493},
+		{0, 1234, 2345, 0},
+		{1, 2, 1, 6},
+		{1, 4, 2, 6},
+		{1, 3, 1, 16},
+		{10, 8, 2, 542},
+		{2, 5, 2, 23},
+		{1, 50000000, 2225652, 5709098764},
+	} {
+		assert.Equal(t, v[3], fakeExponential(big.NewInt(v[0]), big.NewInt(v[1]), big.NewInt(v[2])).Int64())
+	}
+}
+
+func TestNextExcessBlobGas(t *testing.T) {
+	bp := &blobGasParams{target: 786432, max: 1179648, updateFraction: 5007716}
+	assert.Equal(t, int64(50393216), bp.nextExcessBlobGas(testBlobGasBlock(1, 50000000, 1179648)).Int64())
+	assert.Equal(t, int64(0), bp.nextExcessBlobGas(testBlobGasBlock(1, 100000, 0)).Int64())
+	bi := testBlobGasBlock(1, 50000000, 0)
+	bi.BlobGasUsed = nil
+	assert.Equal(t, int64(49213568), bp.nextExcessBlobGas(bi).Int64())
+	bi.ExcessBlobGas = nil
+	assert.Nil(t, bp.nextExcessBlobGas(bi))
+}
+
+func TestBlobGasConfig(t *testing.T) {
+	config.RootConfigReset()
+	conf := config.RootSection("unittest")
+	InitConfig(conf)
+	conf.Set(BlobGasEnabled, true)
+	gp, err := newGasPolicy(context.Background(), conf, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, &blobGasParams{target: 786432, max: 1179648, updateFraction: 5007716}, gp.blobGas)
+
+	conf.Set(BlobGasMaxPerBlock, 1000)
+	_, err = newGasPolicy(context.Background(), conf, nil)
+	assert.Regexp(t, "FF23156", err)
+}
+
+func TestGetGasPriceBlobGas(t *testing.T) {
+	ctx, c, mRPC, done := newTestSuggestionsConnector(t)
+	defer done()
+	conf := config.RootSection("unittest")
+	conf.Set(BlobGasEnabled, true)
+	gp, err := newGasPolicy(ctx, conf, nil)
+	assert.NoError(t, err)
+	c.gasPolicy.Store(gp)
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_feeHistory", ethtypes.NewHexInteger64(4), "latest", []float64{10, 50, 90}).
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			*(args[1].(**feeHistoryJSONRPC)) = &feeHistoryJSONRPC{
+				OldestBlock:   ethtypes.NewHexInteger64(100),
+				BaseFeePerGas: hexIntegers(1000, 1000),
+				GasUsedRatio:  []float64{0.5},
+				Reward:        [][]*ethtypes.HexInteger{hexIntegers(1, 5, 9)},
+			}
+		})
+	bl := c.blockListener
+	bi := testBlobGasBlock(100, 50000000, 1179648)
+	bl.addToBlockCache(bi)
+	bl.canonicalChainIndex[100] = bi.Hash.String()
+
+	res, _, err := c.GasPriceEstimate(ctx, &ffcapi.GasPriceEstimateRequest{})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"gasPrice": "12345",
+		"blobBaseFee": "23461",
+		"maxFeePerBlobGas": "29693",
+		"suggestions": {
+			"low": {"maxPriorityFeePerGas": "1", "maxFeePerGas": "3248", "targetBlocks": 10, "maxFeePerBlobGas": "51448"},
+			"medium": {"maxPriorityFeePerGas": "5", "maxFeePerGas": "1428", "targetBlocks": 3, "maxFeePerBlobGas": "29693"},
+			"high": {"maxPriorityFeePerGas": "9", "maxFeePerGas": "1134", "targetBlocks": 1, "maxFeePerBlobGas": "25377"}
+		}
+	}`, res.GasPrice.String())
+
+	// The estimate is still accepted as the gas price of a transaction
+	tx := &ethsigner.Transaction{}
+	err = c.mapGasPrice(ctx, res.GasPrice, tx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12345), tx.GasPrice.BigInt().Int64())
+}
+
+func TestGetGasPriceBlobGasNoSuggestions(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t, func(conf config.Section) {
+		conf.Set(BlobGasEnabled, true)
+	})
+	defer done()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_gasPrice").
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			*(args[1].(*ethtypes.HexInteger)) = *ethtypes.NewHexInteger64(12345)
+		})
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", "latest", false).
+		Run(func(args mock.Arguments) {
+			*(args[1].(**blockInfoJSONRPC)) = testBlobGasBlock(100, 0, 0)
+		}).
+		Return(nil).Once()
+	res, _, err := c.GasPriceEstimate(ctx, &ffcapi.GasPriceEstimateRequest{})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"gasPrice": "12345", "blobBaseFee": "1", "maxFeePerBlobGas": "1"}`, res.GasPrice.String())
+
+	// Chains without EIP-4844 only have the gas price
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", "latest", false).
+		Run(func(args mock.Arguments) {
+			*(args[1].(**blockInfoJSONRPC)) = testBaseFeeBlock(100, 1000000000, 15000000, 30000000)
+		}).
+		Return(nil).Once()
+	res, _, err = c.GasPriceEstimate(ctx, &ffcapi.GasPriceEstimateRequest{})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"gasPrice": "12345"}`, res.GasPrice.String())
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", "latest", false).
+		Return(&rpcbackend.RPCError{Message: "pop"}).Once()
+	res, _, err = c.GasPriceEstimate(ctx, &ffcapi.GasPriceEstimateRequest{})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"gasPrice": "12345"}`, res.GasPrice.String())
+}
@@ -0,0 +1,131 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+)
+
+const blockTimestampCacheSize = 1000
+
+// BlockAtTimestampResponse is the first block with a timestamp at or after the requested time.
+// Where no such block has been mined yet, the number of the next block is returned as pending
+type BlockAtTimestampResponse struct {
+	Timestamp      *fftypes.FFTime   `json:"timestamp"`
+	BlockNumber    *fftypes.FFBigInt `json:"blockNumber"`
+	BlockHash      string            `json:"blockHash,omitempty"`
+	BlockTimestamp *fftypes.FFTime   `json:"blockTimestamp,omitempty"`
+	Pending        bool              `json:"pending"`
+}
+
+// BlockAtTimestamp resolves a timestamp, either RFC3339 or in seconds since the epoch, to the first block at or after it
+func (c *ethConnector) BlockAtTimestamp(ctx context.Context, timestamp string) (*BlockAtTimestampResponse, error) {
+	var t time.Time
+	if secs, err := strconv.ParseInt(timestamp, 10, 64); err == nil {
+		t = time.Unix(secs, 0)
+	} else if t, err = time.Parse(time.RFC3339Nano, timestamp); err != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidTimestamp, timestamp)
+	}
+
+	blockNumber, err := c.blockNumberAtOrAfter(ctx, t)
+	if err != nil {
+		return nil, err
+	}
+	res := &BlockAtTimestampResponse{
+		Timestamp:   (*fftypes.FFTime)(&t),
+		BlockNumber: fftypes.NewFFBigInt(blockNumber),
+	}
+	bi, _, err := c.blockListener.getBlockInfoByNumber(ctx, blockNumber, true, "")
+	if err != nil {
+		return nil, err
+	}
+	if bi == nil {
+		res.Pending = true
+	} else {
+		res.BlockHash = bi.Hash.String()
+		if bi.Timestamp != nil {
+			res.BlockTimestamp = fftypes.UnixTime(bi.Timestamp.BigInt().Int64())
+		}
+	}
+	return res, nil
+}
+
+// blockNumberByHash resolves a block hash to its number, checking the block is on the canonical chain
+func (c *ethConnector) blockNumberByHash(ctx context.Context, hash string) (int64, error) {
+	bi, err := c.blockListener.getBlockInfoByHash(ctx, hash)
+	if err != nil {
+		return -1, err
+	}
+	if bi == nil {
+		return -1, i18n.NewError(ctx, msgs.MsgBlockNotFound, hash)
+	}
+	blockNumber := bi.Number.BigInt().Int64()
+	canonical, _, err := c.blockListener.getBlockInfoByNumber(ctx, blockNumber, false, "")
+	if err != nil {
+		return -1, err
+	}
+	if canonical == nil || canonical.Hash.String() != bi.Hash.String() {
+		return -1, i18n.NewError(ctx, msgs.MsgBlockNotCanonical, hash, blockNumber)
+	}
+	return blockNumber, nil
+}
+
+// blockNumberAtOrAfter performs a binary search over the block timestamps of the chain, to find the first
+// block with a timestamp at or after the supplied time. If the time is after the head of the chain,
+// the number of the next block to be mined is returned.
+// Results that resolve to a mined block are cached, as later blocks cannot change the answer.
+func (c *ethConnector) blockNumberAtOrAfter(ctx context.Context, t time.Time) (int64, error) {
+	timestamp := t.Unix()
+	if t.Nanosecond() > 0 {
+		timestamp++ // block timestamps are in whole seconds
+	}
+	if cached, ok := c.blockTSCache.Get(timestamp); ok {
+		return cached.(int64), nil
+	}
+	chainHead, ok := c.blockListener.getHighestBlock(ctx)
+	if !ok {
+		return -1, i18n.NewError(ctx, msgs.MsgTimedOutQueryingChainHead)
+	}
+	low, high := int64(0), chainHead+1
+	for low < high {
+		mid := low + (high-low)/2
+		bi, _, err := c.blockListener.getBlockInfoByNumber(ctx, mid, true, "")
+		if err != nil {
+			return -1, err
+		}
+		if bi == nil {
+			return -1, i18n.NewError(ctx, msgs.MsgBlockNotAvailable)
+		}
+		if bi.Timestamp.BigInt().Int64() < timestamp {
+			low = mid + 1
+		} else {
+			high = mid
+		}
+	}
+	log.L(ctx).Debugf("First block at or after %s is %d (chainHead=%d)", t, low, chainHead)
+	if low <= chainHead {
+		c.blockTSCache.Add(timestamp, low)
+	}
+	return low, nil
+}
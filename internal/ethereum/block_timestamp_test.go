@@ -0,0 +1,247 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-evmconnect/mocks/rpcbackendmocks"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func testBlockHash(n int64) string {
+	return fmt.Sprintf("0x%064x", n)
+}
+
+func mockChainHead(mRPC *rpcbackendmocks.Backend, chainHead int64) {
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_blockNumber").Return(nil).Run(func(args mock.Arguments) {
+		*(args[1].(*ethtypes.HexInteger)) = *ethtypes.NewHexInteger64(chainHead)
+	})
+}
+
+// mockBlocksByNumber returns blocks with a timestamp of 1000 + 10 seconds per block
+func mockBlocksByNumber(mRPC *rpcbackendmocks.Backend) {
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", mock.Anything, false).Return(nil).Run(func(args mock.Arguments) {
+		n := args[3].(*ethtypes.HexInteger).BigInt().Int64()
+		*(args[1].(**blockInfoJSONRPC)) = &blockInfoJSONRPC{
+			Number:    ethtypes.NewHexInteger64(n),
+			Hash:      ethtypes.MustNewHexBytes0xPrefix(testBlockHash(n)),
+			Timestamp: ethtypes.NewHexInteger64(1000 + n*10),
+		}
+	})
+}
+
+func TestBlockNumberByHashOK(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByHash", testBlockHash(5), false).Return(nil).Run(func(args mock.Arguments) {
+		*(args[1].(**blockInfoJSONRPC)) = &blockInfoJSONRPC{
+			Number: ethtypes.NewHexInteger64(5),
+			Hash:   ethtypes.MustNewHexBytes0xPrefix(testBlockHash(5)),
+		}
+	})
+	mockBlocksByNumber(mRPC)
+
+	n, err := c.blockNumberByHash(ctx, testBlockHash(5))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), n)
+}
+
+func TestBlockNumberByHashNotCanonical(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByHash", testBlockHash(99), false).Return(nil).Run(func(args mock.Arguments) {
+		*(args[1].(**blockInfoJSONRPC)) = &blockInfoJSONRPC{
+			Number: ethtypes.NewHexInteger64(5),
+			Hash:   ethtypes.MustNewHexBytes0xPrefix(testBlockHash(99)),
+		}
+	})
+	mockBlocksByNumber(mRPC)
+
+	_, err := c.blockNumberByHash(ctx, testBlockHash(99))
+	assert.Regexp(t, "FF23076", err)
+}
+
+func TestBlockNumberByHashNotFound(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByHash", testBlockHash(5), false).Return(nil)
+
+	_, err := c.blockNumberByHash(ctx, testBlockHash(5))
+	assert.Regexp(t, "FF23072", err)
+}
+
+func TestBlockNumberByHashFail(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByHash", testBlockHash(5), false).Return(&rpcbackend.RPCError{Message: "pop"})
+
+	_, err := c.blockNumberByHash(ctx, testBlockHash(5))
+	assert.Regexp(t, "pop", err)
+}
+
+func TestBlockNumberByHashCanonicalLookupFail(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByHash", testBlockHash(5), false).Return(nil).Run(func(args mock.Arguments) {
+		*(args[1].(**blockInfoJSONRPC)) = &blockInfoJSONRPC{
+			Number: ethtypes.NewHexInteger64(5),
+			Hash:   ethtypes.MustNewHexBytes0xPrefix(testBlockHash(5)),
+		}
+	})
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", mock.Anything, false).Return(&rpcbackend.RPCError{Message: "pop"})
+
+	_, err := c.blockNumberByHash(ctx, testBlockHash(5))
+	assert.Regexp(t, "pop", err)
+}
+
+func TestBlockNumberAtOrAfter(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mockChainHead(mRPC, 100)
+	mockBlocksByNumber(mRPC)
+
+	n, err := c.blockNumberAtOrAfter(ctx, time.Unix(1000, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), n)
+
+	n, err = c.blockNumberAtOrAfter(ctx, time.Unix(1500, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(50), n)
+
+	n, err = c.blockNumberAtOrAfter(ctx, time.Unix(1495, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(50), n)
+
+	n, err = c.blockNumberAtOrAfter(ctx, time.Unix(1500, 1))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(51), n)
+
+	n, err = c.blockNumberAtOrAfter(ctx, time.Unix(5000, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(101), n)
+}
+
+func TestBlockNumberAtOrAfterBlockFail(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mockChainHead(mRPC, 100)
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", mock.Anything, false).Return(&rpcbackend.RPCError{Message: "pop"})
+
+	_, err := c.blockNumberAtOrAfter(ctx, time.Unix(1500, 0))
+	assert.Regexp(t, "pop", err)
+}
+
+func TestBlockNumberAtOrAfterBlockMissing(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mockChainHead(mRPC, 100)
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", mock.Anything, false).Return(nil)
+
+	_, err := c.blockNumberAtOrAfter(ctx, time.Unix(1500, 0))
+	assert.Regexp(t, "FF23011", err)
+}
+
+func TestBlockAtTimestampRoute(t *testing.T) {
+	_, c, mRPC, done := newTestConnector(t)
+	defer done()
+	url, close := newTestRouteServer(t, c)
+	defer close()
+
+	mockChainHead(mRPC, 100)
+	mockBlocksByNumber(mRPC)
+
+	res, err := http.Get(url + "/blocks/timestamp/1970-01-01T00:24:55Z")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var bat BlockAtTimestampResponse
+	err = json.NewDecoder(res.Body).Decode(&bat)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(50), bat.BlockNumber.Int64())
+	assert.Equal(t, testBlockHash(50), bat.BlockHash)
+	assert.Equal(t, int64(1500), bat.BlockTimestamp.Time().Unix())
+	assert.False(t, bat.Pending)
+
+	cached, ok := c.blockTSCache.Get(int64(1495))
+	assert.True(t, ok)
+	assert.Equal(t, int64(50), cached)
+}
+
+func TestBlockAtTimestampPending(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mockChainHead(mRPC, 100)
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", ethtypes.NewHexInteger64(101), false).Return(nil).Once()
+	mockBlocksByNumber(mRPC)
+
+	bat, err := c.BlockAtTimestamp(ctx, "5000")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(101), bat.BlockNumber.Int64())
+	assert.True(t, bat.Pending)
+	assert.Empty(t, bat.BlockHash)
+
+	_, ok := c.blockTSCache.Get(int64(5000))
+	assert.False(t, ok)
+}
+
+func TestBlockAtTimestampCached(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	c.blockTSCache.Add(int64(1500), int64(50))
+	mockBlocksByNumber(mRPC)
+
+	bat, err := c.BlockAtTimestamp(ctx, "1500")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(50), bat.BlockNumber.Int64())
+	mRPC.AssertNotCalled(t, "CallRPC", mock.Anything, mock.Anything, "eth_blockNumber")
+}
+
+func TestBlockAtTimestampInvalid(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t)
+	defer done()
+
+	_, err := c.BlockAtTimestamp(ctx, "yesterday")
+	assert.Regexp(t, "FF23077", err)
+}
+
+func TestBlockAtTimestampBlockFail(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	c.blockTSCache.Add(int64(1500), int64(50))
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", mock.Anything, false).Return(&rpcbackend.RPCError{Message: "pop"})
+
+	_, err := c.BlockAtTimestamp(ctx, "1500")
+	assert.Regexp(t, "pop", err)
+}
@@ -0,0 +1,144 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"math/big"
+	"strconv"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+)
+
+// blockTags are the named blocks that can be used in place of a block number
+var blockTags = []string{"latest", "earliest", "pending", "safe", "finalized"}
+
+// BlockTransactionCount is the number of transactions in a block
+type BlockTransactionCount struct {
+	Block            string `json:"block"`
+	TransactionCount int    `json:"transactionCount"`
+}
+
+// BlockTransactionsPage is a page of the transactions of a block, fetched individually by index
+type BlockTransactionsPage struct {
+	Block            string             `json:"block"`
+	BlockHash        string             `json:"blockHash,omitempty"`
+	TransactionCount int                `json:"transactionCount"`
+	Start            int                `json:"start"`
+	Next             *int               `json:"next,omitempty"`
+	Transactions     []*fftypes.JSONAny `json:"transactions"`
+}
+
+// blockNumberParam parses a block number, as a decimal or 0x prefixed hex integer, or a block tag
+func blockNumberParam(ctx context.Context, block string) (interface{}, error) {
+	for _, tag := range blockTags {
+		if block == tag {
+			return tag, nil
+		}
+	}
+	i, ok := new(big.Int).SetString(block, 0)
+	if !ok || i.Sign() < 0 {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidBlockNumber, block, blockTags)
+	}
+	return (*ethtypes.HexInteger)(i), nil
+}
+
+// BlockTransactionCount returns the number of transactions in a block, by number or tag, with
+// eth_getBlockTransactionCountByNumber
+func (c *ethConnector) BlockTransactionCount(ctx context.Context, block string) (*BlockTransactionCount, error) {
+	blockNumber, err := blockNumberParam(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+	count, err := c.blockTransactionCount(ctx, block, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	return &BlockTransactionCount{Block: block, TransactionCount: count}, nil
+}
+
+func (c *ethConnector) blockTransactionCount(ctx context.Context, block string, blockNumber interface{}) (int, error) {
+	var count *ethtypes.HexInteger
+	if rpcErr := c.readBackend().CallRPC(ctx, &count, "eth_getBlockTransactionCountByNumber", blockNumber); rpcErr != nil {
+		return -1, rpcErr.Error()
+	}
+	if count == nil {
+		return -1, i18n.NewError(ctx, msgs.MsgBlockNotFound, block)
+	}
+	return int(count.Int64()), nil
+}
+
+// BlockTransactionsPage returns up to limit transactions of a block, by number or tag, starting at an index. The
+// transactions are fetched individually with eth_getTransactionByBlockNumberAndIndex, so a consumer can page through
+// a huge block without any response holding the full payload of the block.
+func (c *ethConnector) BlockTransactionsPage(ctx context.Context, block, start, limit string) (*BlockTransactionsPage, error) {
+	blockNumber, err := blockNumberParam(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+	startIndex, limitCount := 0, c.blockTxBatchSize
+	if start != "" {
+		if startIndex, err = strconv.Atoi(start); err != nil || startIndex < 0 {
+			return nil, i18n.NewError(ctx, msgs.MsgInvalidTransactionPage, start, limit)
+		}
+	}
+	if limit != "" {
+		if limitCount, err = strconv.Atoi(limit); err != nil || limitCount <= 0 {
+			return nil, i18n.NewError(ctx, msgs.MsgInvalidTransactionPage, start, limit)
+		}
+	}
+
+	count, err := c.blockTransactionCount(ctx, block, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	page := &BlockTransactionsPage{
+		Block:            block,
+		TransactionCount: count,
+		Start:            startIndex,
+		Transactions:     []*fftypes.JSONAny{},
+	}
+	end := min(startIndex+limitCount, count)
+	if startIndex >= end {
+		return page, nil
+	}
+
+	// Every transaction of the page must be from the same block, as a block number (or tag) can resolve to a
+	// different block part way through the page after a re-org, or as new blocks are mined
+	hashes := make([]string, end-startIndex)
+	if page.Transactions, err = c.transactionsByIndex(ctx, blockNumber, startIndex, end, func(i int, tx *fftypes.JSONAny) error {
+		if hashes[i-startIndex] = tx.JSONObjectNowarn().GetString("blockHash"); hashes[i-startIndex] == "" {
+			return i18n.NewError(ctx, msgs.MsgBlockChangedWhilePaging, block)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	for _, hash := range hashes[1:] {
+		if hash != hashes[0] {
+			return nil, i18n.NewError(ctx, msgs.MsgBlockChangedWhilePaging, block)
+		}
+	}
+	page.BlockHash = hashes[0]
+	if end < count {
+		page.Next = &end
+	}
+	return page, nil
+}
@@ -0,0 +1,182 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hyperledger/firefly-evmconnect/mocks/rpcbackendmocks"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func mockBlockTransactionCount(mRPC *rpcbackendmocks.Backend, blockNumber interface{}, count string) *mock.Call {
+	return mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockTransactionCountByNumber", blockNumber).
+		Run(func(args mock.Arguments) {
+			err := json.Unmarshal([]byte(count), args[1])
+			if err != nil {
+				panic(err)
+			}
+		}).
+		Return(nil)
+}
+
+func mockTransactionInBlock(mRPC *rpcbackendmocks.Backend, blockNumber interface{}, index int64, blockHash string) *mock.Call {
+	return mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionByBlockNumberAndIndex", blockNumber, ethtypes.NewHexInteger64(index)).
+		Run(func(args mock.Arguments) {
+			err := json.Unmarshal([]byte(fmt.Sprintf(`{"hash":"%s","blockHash":"%s","transactionIndex":"0x%x"}`, sampleBlockTxHashes[index], blockHash, index)), args[1])
+			if err != nil {
+				panic(err)
+			}
+		}).
+		Return(nil)
+}
+
+func TestBlockTransactionCountRoute(t *testing.T) {
+	_, c, mRPC, done := newTestConnector(t)
+	defer done()
+	url, close := newTestRouteServer(t, c)
+	defer close()
+
+	mockBlockTransactionCount(mRPC, ethtypes.NewHexInteger64(0x1b4), `"0x3"`)
+	mockBlockTransactionCount(mRPC, "finalized", `"0x0"`)
+
+	res, err := http.Get(url + "/blocks/number/436/transactions/count")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var count BlockTransactionCount
+	err = json.NewDecoder(res.Body).Decode(&count)
+	assert.NoError(t, err)
+	assert.Equal(t, "436", count.Block)
+	assert.Equal(t, 3, count.TransactionCount)
+
+	res, err = http.Get(url + "/blocks/number/finalized/transactions/count")
+	assert.NoError(t, err)
+	err = json.NewDecoder(res.Body).Decode(&count)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count.TransactionCount)
+
+	res, err = http.Get(url + "/blocks/number/wrong/transactions/count")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+}
+
+func TestBlockTransactionCountErrors(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	_, err := c.BlockTransactionCount(ctx, "-1")
+	assert.Regexp(t, "FF23168", err)
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockTransactionCountByNumber", mock.Anything).
+		Return(&rpcbackend.RPCError{Message: "pop"}).Once()
+	_, err = c.BlockTransactionCount(ctx, "0x1b4")
+	assert.Regexp(t, "pop", err)
+
+	mockBlockTransactionCount(mRPC, ethtypes.NewHexInteger64(0x1b4), `null`).Once()
+	_, err = c.BlockTransactionCount(ctx, "0x1b4")
+	assert.Regexp(t, "FF23072", err)
+}
+
+func TestBlockTransactionsPageRoute(t *testing.T) {
+	_, c, mRPC, done := newTestPagingConnector(t)
+	defer done()
+	url, close := newTestRouteServer(t, c)
+	defer close()
+
+	blockNumber := ethtypes.NewHexInteger64(0x1b4)
+	mockBlockTransactionCount(mRPC, blockNumber, `"0x3"`)
+	for i := range sampleBlockTxHashes {
+		mockTransactionInBlock(mRPC, blockNumber, int64(i), sampleBlockHash)
+	}
+
+	// The first page defaults to the batch size
+	res, err := http.Get(url + "/blocks/number/0x1b4/transactions")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var page BlockTransactionsPage
+	err = json.NewDecoder(res.Body).Decode(&page)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, page.TransactionCount)
+	assert.Equal(t, sampleBlockHash, page.BlockHash)
+	assert.Equal(t, 0, page.Start)
+	assert.Equal(t, 2, *page.Next)
+	assert.Len(t, page.Transactions, 2)
+	assert.Equal(t, sampleBlockTxHashes[1], page.Transactions[1].JSONObject().GetString("hash"))
+
+	res, err = http.Get(url + "/blocks/number/0x1b4/transactions?start=1&limit=10")
+	assert.NoError(t, err)
+	page = BlockTransactionsPage{}
+	err = json.NewDecoder(res.Body).Decode(&page)
+	assert.NoError(t, err)
+	assert.Nil(t, page.Next)
+	assert.Len(t, page.Transactions, 2)
+	assert.Equal(t, sampleBlockTxHashes[2], page.Transactions[1].JSONObject().GetString("hash"))
+
+	// Beyond the end of the block is an empty page
+	res, err = http.Get(url + "/blocks/number/0x1b4/transactions?start=3")
+	assert.NoError(t, err)
+	page = BlockTransactionsPage{}
+	err = json.NewDecoder(res.Body).Decode(&page)
+	assert.NoError(t, err)
+	assert.Empty(t, page.Transactions)
+	assert.Empty(t, page.BlockHash)
+}
+
+func TestBlockTransactionsPageBlockChanged(t *testing.T) {
+	ctx, c, mRPC, done := newTestPagingConnector(t)
+	defer done()
+
+	mockBlockTransactionCount(mRPC, "latest", `"0x3"`)
+	mockTransactionInBlock(mRPC, "latest", 0, sampleBlockHash)
+	mockTransactionInBlock(mRPC, "latest", 1, "0x0000000000000000000000000000000000000000000000000000000000000001")
+	_, err := c.BlockTransactionsPage(ctx, "latest", "", "")
+	assert.Regexp(t, "FF23170", err)
+
+	// A transaction that is no longer in the block
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionByBlockNumberAndIndex", "latest", ethtypes.NewHexInteger64(2)).Return(nil)
+	_, err = c.BlockTransactionsPage(ctx, "latest", "2", "1")
+	assert.Regexp(t, "FF23170", err)
+}
+
+func TestBlockTransactionsPageErrors(t *testing.T) {
+	ctx, c, mRPC, done := newTestPagingConnector(t)
+	defer done()
+
+	_, err := c.BlockTransactionsPage(ctx, "wrong", "", "")
+	assert.Regexp(t, "FF23168", err)
+	_, err = c.BlockTransactionsPage(ctx, "latest", "-1", "")
+	assert.Regexp(t, "FF23169", err)
+	_, err = c.BlockTransactionsPage(ctx, "latest", "", "0")
+	assert.Regexp(t, "FF23169", err)
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockTransactionCountByNumber", "latest").
+		Return(&rpcbackend.RPCError{Message: "pop"}).Once()
+	_, err = c.BlockTransactionsPage(ctx, "latest", "", "")
+	assert.Regexp(t, "pop", err)
+
+	mockBlockTransactionCount(mRPC, "latest", `"0x3"`)
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionByBlockNumberAndIndex", "latest", mock.Anything).
+		Return(&rpcbackend.RPCError{Message: "pop"})
+	_, err = c.BlockTransactionsPage(ctx, "latest", "", "")
+	assert.Regexp(t, "pop", err)
+}
@@ -0,0 +1,97 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+)
+
+// blockWithTransactions is a block returned by eth_getBlockByHash with full transaction objects
+type blockWithTransactions struct {
+	Transactions []*fftypes.JSONAny `json:"transactions"`
+}
+
+// blockTransactions returns the full transaction objects of a block, in order. A block with no more transactions than
+// the paging threshold is fetched with its transactions in a single eth_getBlockByHash. The transactions of larger
+// blocks are fetched individually by index, in batches of concurrent requests, so that no single response holds the
+// full payload of a huge block.
+func (c *ethConnector) blockTransactions(ctx context.Context, header *BlockHeader) ([]*fftypes.JSONAny, error) {
+	backend := c.readBackend()
+	if len(header.Transactions) <= c.blockTxPagingThreshold {
+		var block *blockWithTransactions
+		if rpcErr := backend.CallRPC(ctx, &block, "eth_getBlockByHash", header.Hash, true); rpcErr != nil {
+			return nil, rpcErr.Error()
+		}
+		if block == nil {
+			return nil, i18n.NewError(ctx, msgs.MsgBlockNotFound, header.Hash)
+		}
+		return block.Transactions, nil
+	}
+
+	log.L(ctx).Infof("Paging %d transactions of block %s in batches of %d", len(header.Transactions), header.Hash, c.blockTxBatchSize)
+	return c.transactionsByIndex(ctx, header.Number, 0, len(header.Transactions), func(i int, tx *fftypes.JSONAny) error {
+		// The transaction is looked up by the number of the block, so is checked against the header in case
+		// the block was replaced by a re-org while paging
+		if hash := tx.JSONObjectNowarn().GetString("hash"); hash != header.Transactions[i].String() {
+			return i18n.NewError(ctx, msgs.MsgBlockTransactionMismatch, i, header.Hash, hash, header.Transactions[i])
+		}
+		return nil
+	})
+}
+
+// transactionsByIndex fetches the transactions from start up to (not including) end of a block, by number or tag, in
+// batches of concurrent eth_getTransactionByBlockNumberAndIndex requests. Each transaction is passed to the check
+// function, so the caller can verify it belongs to the block it expects.
+func (c *ethConnector) transactionsByIndex(ctx context.Context, blockNumber interface{}, start, end int, check func(i int, tx *fftypes.JSONAny) error) ([]*fftypes.JSONAny, error) {
+	backend := c.readBackend()
+	txs := make([]*fftypes.JSONAny, end-start)
+	for batchStart := start; batchStart < end; batchStart += c.blockTxBatchSize {
+		batchEnd := min(batchStart+c.blockTxBatchSize, end)
+		errs := make(chan error, batchEnd-batchStart)
+		for i := batchStart; i < batchEnd; i++ {
+			go func(i int) {
+				var tx *fftypes.JSONAny
+				if rpcErr := backend.CallRPC(ctx, &tx, "eth_getTransactionByBlockNumberAndIndex", blockNumber, ethtypes.NewHexInteger64(int64(i))); rpcErr != nil {
+					errs <- rpcErr.Error()
+					return
+				}
+				if err := check(i, tx); err != nil {
+					errs <- err
+					return
+				}
+				txs[i-start] = tx
+				errs <- nil
+			}(i)
+		}
+		var batchErr error
+		for i := batchStart; i < batchEnd; i++ {
+			if err := <-errs; err != nil && batchErr == nil {
+				batchErr = err
+			}
+		}
+		if batchErr != nil {
+			return nil, batchErr
+		}
+	}
+	return txs, nil
+}
@@ -0,0 +1,159 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-evmconnect/mocks/rpcbackendmocks"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var sampleBlockTxHashes = []string{
+	"0x1a1f797ee000c529b6a2dd330cedd0d081417a30d16a4eecb3f863ab4657246f",
+	"0x3e2398ff4a875a8b9f87a6eeaaa41a139a68adeb509731300d4b90d1bdc1c4fc",
+	"0xdc246933185b64d89e93d47bb6fa0500b9da8f168d5ccf39aebc7f6c36692fe2",
+}
+
+func sampleBlockHeaderWithTransactions() string {
+	return strings.Replace(sampleBlockHeaderJSON, `"transactions": []`, `"transactions": ["`+strings.Join(sampleBlockTxHashes, `","`)+`"]`, 1)
+}
+
+func newTestPagingConnector(t *testing.T) (context.Context, *ethConnector, *rpcbackendmocks.Backend, func()) {
+	return newTestConnector(t, func(conf config.Section) {
+		conf.Set(BlockTransactionsPagingThreshold, 2)
+		conf.Set(BlockTransactionsBatchSize, 2)
+	})
+}
+
+func mockTransactionByIndex(mRPC *rpcbackendmocks.Backend, index int64, txHash string) *mock.Call {
+	return mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionByBlockNumberAndIndex", ethtypes.NewHexInteger64(0x1b4), ethtypes.NewHexInteger64(index)).
+		Run(func(args mock.Arguments) {
+			err := json.Unmarshal([]byte(fmt.Sprintf(`{"hash":"%s","transactionIndex":"0x%x"}`, txHash, index)), args[1])
+			if err != nil {
+				panic(err)
+			}
+		}).
+		Return(nil)
+}
+
+func TestBlockTransactionsSingleRequest(t *testing.T) {
+	_, c, mRPC, done := newTestConnector(t)
+	defer done()
+	url, close := newTestRouteServer(t, c)
+	defer close()
+
+	mockBlockHeader(mRPC, sampleBlockHeaderWithTransactions())
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByHash", mock.Anything, true).
+		Run(func(args mock.Arguments) {
+			err := json.Unmarshal([]byte(`{"transactions": [{"hash":"`+strings.Join(sampleBlockTxHashes, `"},{"hash":"`)+`"}]}`), args[1])
+			assert.NoError(t, err)
+		}).
+		Return(nil).Once()
+
+	res, err := http.Get(url + "/blocks/" + sampleBlockHash + "?includeTransactions=true")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var block BlockHeaderResponse
+	err = json.NewDecoder(res.Body).Decode(&block)
+	assert.NoError(t, err)
+	assert.Len(t, block.FullTransactions, 3)
+	assert.Equal(t, sampleBlockTxHashes[2], block.FullTransactions[2].JSONObject().GetString("hash"))
+	mRPC.AssertExpectations(t)
+}
+
+func TestBlockTransactionsPaged(t *testing.T) {
+	ctx, c, mRPC, done := newTestPagingConnector(t)
+	defer done()
+
+	mockBlockHeader(mRPC, sampleBlockHeaderWithTransactions())
+	for i, h := range sampleBlockTxHashes {
+		mockTransactionByIndex(mRPC, int64(i), h).Once()
+	}
+
+	res, err := c.BlockHeaderByHash(ctx, sampleBlockHash, false, true)
+	assert.NoError(t, err)
+	assert.Len(t, res.FullTransactions, 3)
+	for i, h := range sampleBlockTxHashes {
+		assert.Equal(t, h, res.FullTransactions[i].JSONObject().GetString("hash"))
+	}
+	mRPC.AssertExpectations(t)
+	mRPC.AssertNotCalled(t, "CallRPC", mock.Anything, mock.Anything, "eth_getBlockByHash", mock.Anything, true)
+}
+
+func TestBlockTransactionsPagedReorg(t *testing.T) {
+	ctx, c, mRPC, done := newTestPagingConnector(t)
+	defer done()
+
+	mockBlockHeader(mRPC, sampleBlockHeaderWithTransactions())
+	mockTransactionByIndex(mRPC, 0, sampleBlockTxHashes[0])
+	mockTransactionByIndex(mRPC, 1, sampleBlockTxHashes[2])
+
+	_, err := c.BlockHeaderByHash(ctx, sampleBlockHash, false, true)
+	assert.Regexp(t, "FF23166.*1", err)
+}
+
+func TestBlockTransactionsPagedFail(t *testing.T) {
+	ctx, c, mRPC, done := newTestPagingConnector(t)
+	defer done()
+
+	mockBlockHeader(mRPC, sampleBlockHeaderWithTransactions())
+	mockTransactionByIndex(mRPC, 0, sampleBlockTxHashes[0])
+	mockTransactionByIndex(mRPC, 1, sampleBlockTxHashes[1])
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionByBlockNumberAndIndex", mock.Anything, ethtypes.NewHexInteger64(2)).
+		Return(&rpcbackend.RPCError{Message: "pop"})
+
+	_, err := c.BlockHeaderByHash(ctx, sampleBlockHash, false, true)
+	assert.Regexp(t, "pop", err)
+}
+
+func TestBlockTransactionsSingleRequestFail(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mockBlockHeader(mRPC, sampleBlockHeaderJSON)
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByHash", mock.Anything, true).
+		Return(&rpcbackend.RPCError{Message: "pop"}).Once()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByHash", mock.Anything, true).
+		Return(nil).Once()
+
+	_, err := c.BlockHeaderByHash(ctx, sampleBlockHash, false, true)
+	assert.Regexp(t, "pop", err)
+
+	_, err = c.BlockHeaderByHash(ctx, sampleBlockHash, false, true)
+	assert.Regexp(t, "FF23072", err)
+}
+
+func TestBlockTransactionsPagingConfig(t *testing.T) {
+	config.RootConfigReset()
+	conf := config.RootSection("unittest")
+	InitConfig(conf)
+	conf.Set(ffresty.HTTPConfigURL, "http://localhost:8545")
+	conf.Set(BlockTransactionsBatchSize, 0)
+	_, err := NewEthereumConnector(context.Background(), conf)
+	assert.Regexp(t, "FF23167", err)
+}
@@ -19,6 +19,7 @@ package ethereum
 import (
 	"container/list"
 	"context"
+	"sort"
 	"sync"
 	"time"
 
@@ -56,12 +57,19 @@ type blockListener struct {
 	initialBlockHeightObtained chan struct{}
 	newHeadsTap                chan struct{}
 	newHeadsSub                rpcbackend.Subscription
+	newHeadsSubID              string // the ID the node assigned the subscription, which changes each time the WebSocket reconnects
+	wsReconnectPending         bool   // a reconnect has been detected that the listen loop has not yet reconciled
+	wsReconnects               int
+	lastReconnectGap           int64
 	highestBlock               int64
 	mux                        sync.Mutex
 	consumers                  map[fftypes.UUID]*blockUpdateConsumer
 	blockPollingInterval       time.Duration
 	unstableHeadLength         int
 	canonicalChain             *list.List
+	canonicalChainIndex        map[int64]string             // snapshot of the canonical chain (number to hash) that can be read outside of the listen loop
+	forkedBlocks               *list.List                   // blocks recently displaced from the canonical chain, oldest at the front
+	forkedBlockHashes          map[string]*minimalBlockInfo // lookup of the blocks in forkedBlocks by hash
 	hederaCompatibilityMode    bool
 	blockCache                 *lru.Cache
 }
@@ -85,9 +93,18 @@ func newBlockListener(ctx context.Context, c *ethConnector, conf config.Section,
 		consumers:                  make(map[fftypes.UUID]*blockUpdateConsumer),
 		blockPollingInterval:       conf.GetDuration(BlockPollingInterval),
 		canonicalChain:             list.New(),
+		canonicalChainIndex:        make(map[int64]string),
+		forkedBlocks:               list.New(),
+		forkedBlockHashes:          make(map[string]*minimalBlockInfo),
 		unstableHeadLength:         int(c.checkpointBlockGap),
 		hederaCompatibilityMode:    conf.GetBool(HederaCompatibilityMode),
 	}
+	if conf.IsSet(CanonicalChainDepth) {
+		bl.unstableHeadLength = conf.GetInt(CanonicalChainDepth)
+		if bl.unstableHeadLength < 1 {
+			return nil, i18n.NewError(ctx, msgs.MsgInvalidCanonicalChainDepth, bl.unstableHeadLength)
+		}
+	}
 	if wsConf != nil {
 		bl.wsBackend = rpcbackend.NewWSRPCClient(wsConf)
 	}
@@ -115,7 +132,17 @@ func (bl *blockListener) waitUntilStarted(ctx context.Context) {
 }
 
 func (bl *blockListener) newHeadsSubListener() {
-	for range bl.newHeadsSub.Notifications() {
+	for n := range bl.newHeadsSub.Notifications() {
+		// The backend re-subscribes after a reconnect, and we detect that from the new subscription ID,
+		// as we might have missed blocks while the WebSocket was disconnected
+		bl.mux.Lock()
+		if bl.newHeadsSubID != "" && n.CurrentSubID != bl.newHeadsSubID {
+			log.L(bl.ctx).Infof("WebSocket reconnected with newHeads subscription '%s' (previously '%s')", n.CurrentSubID, bl.newHeadsSubID)
+			bl.wsReconnects++
+			bl.wsReconnectPending = true
+		}
+		bl.newHeadsSubID = n.CurrentSubID
+		bl.mux.Unlock()
 		select {
 		case bl.newHeadsTap <- struct{}{}:
 			// Do nothing apart from tap the listener to wake up early
@@ -186,6 +213,8 @@ func (bl *blockListener) listenLoop() {
 	failCount := 0
 	gapPotential := true
 	firstIteration := true
+	reconnected := false
+	var reconnectedFrom int64
 	for {
 		if failCount > 0 {
 			if bl.c.doFailureDelay(bl.ctx, failCount) {
@@ -207,6 +236,17 @@ func (bl *blockListener) listenLoop() {
 			}
 		}
 
+		if bl.takeWSReconnect() {
+			// The block filter was installed over the connection that dropped, so we recreate it in case we are
+			// now connected to a different node, and check for blocks we missed while disconnected
+			filter = ""
+			gapPotential = true
+			if !reconnected {
+				reconnected = true
+				reconnectedFrom = bl.lastCanonicalBlockNumber()
+			}
+		}
+
 		if filter == "" {
 			err := bl.backend.CallRPC(bl.ctx, &filter, "eth_newBlockFilter")
 			if err != nil {
@@ -233,6 +273,11 @@ func (bl *blockListener) listenLoop() {
 
 		update := &ffcapi.BlockHashEvent{GapPotential: gapPotential, Created: fftypes.Now()}
 		var notifyPos *list.Element
+		if gapPotential {
+			// We might have missed notifications for blocks, so check for a gap to the head of the
+			// chain and backfill before processing the new hashes
+			notifyPos = bl.checkAndBackfillGap()
+		}
 		for _, h := range blockHashes {
 			if len(h) != 32 {
 				if !bl.hederaCompatibilityMode {
@@ -265,10 +310,17 @@ func (bl *blockListener) listenLoop() {
 				}
 			}
 		}
+		bl.updateCanonicalChainIndex()
+		if reconnected {
+			bl.reportWSReconnectGap(reconnectedFrom)
+			reconnected = false
+		}
 		if notifyPos != nil {
 			// We notify for all hashes from the point of change in the chain onwards
 			for notifyPos != nil {
-				update.BlockHashes = append(update.BlockHashes, notifyPos.Value.(*minimalBlockInfo).hash)
+				mbi := notifyPos.Value.(*minimalBlockInfo)
+				update.BlockHashes = append(update.BlockHashes, mbi.hash)
+				bl.publishToSinks(mbi)
 				notifyPos = notifyPos.Next()
 			}
 
@@ -291,6 +343,71 @@ func (bl *blockListener) listenLoop() {
 	}
 }
 
+func (bl *blockListener) takeWSReconnect() bool {
+	bl.mux.Lock()
+	defer bl.mux.Unlock()
+	pending := bl.wsReconnectPending
+	bl.wsReconnectPending = false
+	return pending
+}
+
+// lastCanonicalBlockNumber must only be called from the listen loop, which owns the canonical chain
+func (bl *blockListener) lastCanonicalBlockNumber() int64 {
+	lastElem := bl.canonicalChain.Back()
+	if lastElem == nil || lastElem.Value == nil {
+		return -1
+	}
+	return lastElem.Value.(*minimalBlockInfo).number
+}
+
+// reportWSReconnectGap records how many blocks were backfilled after a WebSocket reconnect, from the last block
+// we had processed before the disconnect to the head of the canonical chain after reconciling
+func (bl *blockListener) reportWSReconnectGap(fromBlock int64) {
+	headBlock := bl.lastCanonicalBlockNumber()
+	gap := int64(0)
+	if fromBlock >= 0 && headBlock > fromBlock {
+		gap = headBlock - fromBlock
+	}
+	bl.mux.Lock()
+	bl.lastReconnectGap = gap
+	reconnects := bl.wsReconnects
+	bl.mux.Unlock()
+	log.L(bl.ctx).Infof("Reconciled %d blocks missed from block %d while the WebSocket was disconnected", gap, fromBlock)
+	bl.c.notifier.notify(bl.ctx, NotificationWebSocketReconnected, fftypes.JSONObject{
+		"reconnects": reconnects,
+		"gapBlocks":  gap,
+		"fromBlock":  fromBlock,
+		"headBlock":  headBlock,
+	}, "WebSocket reconnected after missing %d blocks from block %d", gap, fromBlock)
+}
+
+// checkAndBackfillGap is called when we might have missed block notifications, such as when the block filter
+// has been re-established. It compares the last block in our in-memory canonical chain with the current head
+// of the chain, and if there is a gap it backfills the missing blocks in order (returning the first position
+// to notify from). We do nothing if our canonical chain is empty, as there is no last processed block.
+func (bl *blockListener) checkAndBackfillGap() *list.Element {
+	lastElem := bl.canonicalChain.Back()
+	if lastElem == nil || lastElem.Value == nil {
+		return nil
+	}
+	lastBlock := lastElem.Value.(*minimalBlockInfo)
+
+	var hexBlockHeight ethtypes.HexInteger
+	rpcErr := bl.backend.CallRPC(bl.ctx, &hexBlockHeight, "eth_blockNumber")
+	if rpcErr != nil {
+		log.L(bl.ctx).Warnf("Block height could not be obtained to check for gaps: %s", rpcErr.Message)
+		return nil
+	}
+	chainHead := hexBlockHeight.BigInt().Int64()
+	if chainHead <= lastBlock.number {
+		log.L(bl.ctx).Debugf("No gap detected between last processed block %d and chain head %d", lastBlock.number, chainHead)
+		return nil
+	}
+
+	log.L(bl.ctx).Infof("Detected gap of %d blocks between last processed block %d and chain head %d - backfilling", chainHead-lastBlock.number, lastBlock.number, chainHead)
+	return bl.rebuildCanonicalChain()
+}
+
 // reconcileCanonicalChain takes an update on a block, and reconciles it against the in-memory view of the
 // head of the canonical chain we have. If these blocks do not just fit onto the end of the chain, then we
 // work backwards building a new view and notify about all blocks that are changed in that process.
@@ -490,6 +607,70 @@ func (bl *blockListener) trimToLastValidBlock() (lastValidBlock *minimalBlockInf
 	return lastValidBlock
 }
 
+// updateCanonicalChainIndex takes a snapshot of the in-memory canonical chain, so that it can be used by
+// queries outside of the listen loop. Any block that was in the previous snapshot, but has been replaced
+// by a different block at the same height (or dropped from the head) is recorded as a fork.
+func (bl *blockListener) updateCanonicalChainIndex() {
+	newIndex := make(map[int64]string, bl.canonicalChain.Len())
+	headNumber := int64(-1)
+	for elem := bl.canonicalChain.Front(); elem != nil; elem = elem.Next() {
+		mbi := elem.Value.(*minimalBlockInfo)
+		newIndex[mbi.number] = mbi.hash
+		headNumber = mbi.number
+	}
+
+	bl.mux.Lock()
+	defer bl.mux.Unlock()
+	forked := []*minimalBlockInfo{}
+	for number, hash := range bl.canonicalChainIndex {
+		newHash, stillIndexed := newIndex[number]
+		if (stillIndexed && newHash != hash) || (!stillIndexed && number > headNumber) {
+			forked = append(forked, &minimalBlockInfo{number: number, hash: hash})
+		}
+	}
+	// Record in block order, so the lowest blocks are the first to be aged out
+	sort.Slice(forked, func(i, j int) bool { return forked[i].number < forked[j].number })
+	for _, mbi := range forked {
+		bl.recordForkedBlock(mbi)
+	}
+	if n := bl.c.notifier; n != nil && n.reorgDepth > 0 && len(forked) > n.reorgDepth {
+		n.notify(bl.ctx, NotificationReorg, fftypes.JSONObject{
+			"depth":     len(forked),
+			"fromBlock": forked[0].number,
+			"headBlock": headNumber,
+		}, "Re-org replaced %d blocks from block %d", len(forked), forked[0].number)
+	}
+	bl.canonicalChainIndex = newIndex
+}
+
+// recordForkedBlock must be called holding the mutex
+func (bl *blockListener) recordForkedBlock(mbi *minimalBlockInfo) {
+	if _, exists := bl.forkedBlockHashes[mbi.hash]; exists {
+		return
+	}
+	log.L(bl.ctx).Infof("Block %d / %s is no longer in the canonical chain", mbi.number, mbi.hash)
+	bl.forkedBlocks.PushBack(mbi)
+	bl.forkedBlockHashes[mbi.hash] = mbi
+	for bl.forkedBlocks.Len() > bl.unstableHeadLength {
+		oldest := bl.forkedBlocks.Remove(bl.forkedBlocks.Front()).(*minimalBlockInfo)
+		delete(bl.forkedBlockHashes, oldest.hash)
+	}
+}
+
+func (bl *blockListener) isKnownFork(hash string) bool {
+	bl.mux.Lock()
+	defer bl.mux.Unlock()
+	_, isFork := bl.forkedBlockHashes[hash]
+	return isFork
+}
+
+func (bl *blockListener) getCanonicalBlockHash(blockNumber int64) (string, bool) {
+	bl.mux.Lock()
+	defer bl.mux.Unlock()
+	hash, ok := bl.canonicalChainIndex[blockNumber]
+	return hash, ok
+}
+
 func (bl *blockListener) dispatchToConsumers(consumers []*blockUpdateConsumer, update *ffcapi.BlockHashEvent) {
 	for _, c := range consumers {
 		log.L(bl.ctx).Tracef("Notifying consumer %s of blocks %v (gap=%t)", c.id, update.BlockHashes, update.GapPotential)
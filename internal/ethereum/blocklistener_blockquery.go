@@ -30,11 +30,17 @@ import (
 
 // blockInfoJSONRPC are the info fields we parse from the JSON/RPC response, and cache
 type blockInfoJSONRPC struct {
-	Number       *ethtypes.HexInteger        `json:"number"`
-	Hash         ethtypes.HexBytes0xPrefix   `json:"hash"`
-	ParentHash   ethtypes.HexBytes0xPrefix   `json:"parentHash"`
-	Timestamp    *ethtypes.HexInteger        `json:"timestamp"`
-	Transactions []ethtypes.HexBytes0xPrefix `json:"transactions"`
+	Number        *ethtypes.HexInteger        `json:"number"`
+	Hash          ethtypes.HexBytes0xPrefix   `json:"hash"`
+	ParentHash    ethtypes.HexBytes0xPrefix   `json:"parentHash"`
+	Timestamp     *ethtypes.HexInteger        `json:"timestamp"`
+	LogsBloom     ethtypes.HexBytes0xPrefix   `json:"logsBloom,omitempty"`
+	GasLimit      *ethtypes.HexInteger        `json:"gasLimit,omitempty"`
+	GasUsed       *ethtypes.HexInteger        `json:"gasUsed,omitempty"`
+	BaseFeePerGas *ethtypes.HexInteger        `json:"baseFeePerGas,omitempty"`
+	BlobGasUsed   *ethtypes.HexInteger        `json:"blobGasUsed,omitempty"`
+	ExcessBlobGas *ethtypes.HexInteger        `json:"excessBlobGas,omitempty"`
+	Transactions  []ethtypes.HexBytes0xPrefix `json:"transactions"`
 }
 
 func transformBlockInfo(bi *blockInfoJSONRPC, t *ffcapi.BlockInfo) {
@@ -53,16 +59,35 @@ func (bl *blockListener) addToBlockCache(blockInfo *blockInfoJSONRPC) {
 	bl.blockCache.Add(blockInfo.Number.BigInt().String(), blockInfo)
 }
 
+// getCachedBlockByNumber uses our in-memory view of the canonical chain where the block number is within it,
+// so that we do not return a block from the cache that has been replaced by a re-org. Outside of the canonical
+// chain we fall back to the cache by number, discarding any entry we know to be a fork.
+func (bl *blockListener) getCachedBlockByNumber(ctx context.Context, blockNumber int64) *blockInfoJSONRPC {
+	var cached interface{}
+	var ok bool
+	if canonicalHash, inCanonicalChain := bl.getCanonicalBlockHash(blockNumber); inCanonicalChain {
+		cached, ok = bl.blockCache.Get(canonicalHash)
+	} else {
+		cached, ok = bl.blockCache.Get(strconv.FormatInt(blockNumber, 10))
+	}
+	if !ok {
+		return nil
+	}
+	blockInfo := cached.(*blockInfoJSONRPC)
+	if bl.isKnownFork(blockInfo.Hash.String()) {
+		log.L(ctx).Debugf("Block cache miss for block %d as cached block %s is a known fork", blockNumber, blockInfo.Hash)
+		return nil
+	}
+	return blockInfo
+}
+
 func (bl *blockListener) getBlockInfoByNumber(ctx context.Context, blockNumber int64, allowCache bool, expectedHashStr string) (*blockInfoJSONRPC, ffcapi.ErrorReason, error) {
 	var blockInfo *blockInfoJSONRPC
 	if allowCache {
-		cached, ok := bl.blockCache.Get(strconv.FormatInt(blockNumber, 10))
-		if ok {
-			blockInfo = cached.(*blockInfoJSONRPC)
-			if expectedHashStr != "" && blockInfo.ParentHash.String() != expectedHashStr {
-				log.L(ctx).Debugf("Block cache miss for block %d due to mismatched parent hash expected=%s found=%s", blockNumber, expectedHashStr, blockInfo.ParentHash)
-				blockInfo = nil
-			}
+		blockInfo = bl.getCachedBlockByNumber(ctx, blockNumber)
+		if blockInfo != nil && expectedHashStr != "" && blockInfo.ParentHash.String() != expectedHashStr {
+			log.L(ctx).Debugf("Block cache miss for block %d due to mismatched parent hash expected=%s found=%s", blockNumber, expectedHashStr, blockInfo.ParentHash)
+			blockInfo = nil
 		}
 	}
 
@@ -39,6 +39,13 @@ const testBlockFilterID2 = "block_filter_2"
 const testLogsFilterID1 = "log_filter_1"
 const testLogsFilterID2 = "log_filter_2"
 
+// blockListenerConsumers counts the consumers of the block listener, for mock callbacks that run on the listen loop
+func blockListenerConsumers(bl *blockListener) int {
+	bl.mux.Lock()
+	defer bl.mux.Unlock()
+	return len(bl.consumers)
+}
+
 func TestBlockListenerStartGettingHighestBlockRetry(t *testing.T) {
 
 	_, c, mRPC, done := newTestConnectorWithNoBlockerFilterDefaultMocks(t)
@@ -110,7 +117,7 @@ func TestBlockListenerOKSequential(t *testing.T) {
 	})
 	conditionalMockOnce(
 		mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getFilterChanges", testBlockFilterID1).Return(nil),
-		func() bool { return len(bl.consumers) > 0 },
+		func() bool { return blockListenerConsumers(bl) > 0 },
 		func(args mock.Arguments) {
 			hbh := args[1].(*[]ethtypes.HexBytes0xPrefix)
 			*hbh = []ethtypes.HexBytes0xPrefix{
@@ -296,7 +303,7 @@ func TestBlockListenerOKDuplicates(t *testing.T) {
 	// wait for consumer to be added before returning get filter changes
 	conditionalMockOnce(
 		mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getFilterChanges", testBlockFilterID1).Return(nil),
-		func() bool { return len(bl.consumers) > 0 },
+		func() bool { return blockListenerConsumers(bl) > 0 },
 		func(args mock.Arguments) {
 			hbh := args[1].(*[]ethtypes.HexBytes0xPrefix)
 			*hbh = []ethtypes.HexBytes0xPrefix{
@@ -305,7 +312,7 @@ func TestBlockListenerOKDuplicates(t *testing.T) {
 			}
 		})
 	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getFilterChanges", testBlockFilterID1).Return(nil).Run(func(args mock.Arguments) {
-		if len(bl.consumers) > 0 {
+		if blockListenerConsumers(bl) > 0 {
 			hbh := args[1].(*[]ethtypes.HexBytes0xPrefix)
 			*hbh = []ethtypes.HexBytes0xPrefix{
 				block1001Hash,
@@ -414,7 +421,7 @@ func TestBlockListenerReorgKeepLatestHeadInSameBatch(t *testing.T) {
 	})
 	conditionalMockOnce(
 		mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getFilterChanges", testBlockFilterID1).Return(nil),
-		func() bool { return len(bl.consumers) > 0 },
+		func() bool { return blockListenerConsumers(bl) > 0 },
 		func(args mock.Arguments) {
 			hbh := args[1].(*[]ethtypes.HexBytes0xPrefix)
 			*hbh = []ethtypes.HexBytes0xPrefix{
@@ -508,7 +515,7 @@ func TestBlockListenerReorgKeepLatestHeadInSameBatchValidHashFirst(t *testing.T)
 	})
 	conditionalMockOnce(
 		mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getFilterChanges", testBlockFilterID1).Return(nil),
-		func() bool { return len(bl.consumers) > 0 },
+		func() bool { return blockListenerConsumers(bl) > 0 },
 		func(args mock.Arguments) {
 			hbh := args[1].(*[]ethtypes.HexBytes0xPrefix)
 			*hbh = []ethtypes.HexBytes0xPrefix{
@@ -626,7 +633,7 @@ func TestBlockListenerReorgKeepLatestMiddleInSameBatch(t *testing.T) {
 	})
 	conditionalMockOnce(
 		mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getFilterChanges", testBlockFilterID1).Return(nil),
-		func() bool { return len(bl.consumers) > 0 },
+		func() bool { return blockListenerConsumers(bl) > 0 },
 		func(args mock.Arguments) {
 			hbh := args[1].(*[]ethtypes.HexBytes0xPrefix)
 			*hbh = []ethtypes.HexBytes0xPrefix{
@@ -720,7 +727,7 @@ func TestBlockListenerReorgKeepLatestTailInSameBatch(t *testing.T) {
 	})
 	conditionalMockOnce(
 		mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getFilterChanges", testBlockFilterID1).Return(nil),
-		func() bool { return len(bl.consumers) > 0 },
+		func() bool { return blockListenerConsumers(bl) > 0 },
 		func(args mock.Arguments) {
 			hbh := args[1].(*[]ethtypes.HexBytes0xPrefix)
 			*hbh = []ethtypes.HexBytes0xPrefix{
@@ -814,7 +821,7 @@ func TestBlockListenerReorgReplaceTail(t *testing.T) {
 	})
 	conditionalMockOnce(
 		mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getFilterChanges", testBlockFilterID1).Return(nil),
-		func() bool { return len(bl.consumers) > 0 },
+		func() bool { return blockListenerConsumers(bl) > 0 },
 		func(args mock.Arguments) {
 			hbh := args[1].(*[]ethtypes.HexBytes0xPrefix)
 			*hbh = []ethtypes.HexBytes0xPrefix{
@@ -934,7 +941,7 @@ func TestBlockListenerGap(t *testing.T) {
 	})
 	conditionalMockOnce(
 		mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getFilterChanges", testBlockFilterID1).Return(nil),
-		func() bool { return len(bl.consumers) > 0 },
+		func() bool { return blockListenerConsumers(bl) > 0 },
 		func(args mock.Arguments) {
 			hbh := args[1].(*[]ethtypes.HexBytes0xPrefix)
 			*hbh = []ethtypes.HexBytes0xPrefix{
@@ -1079,7 +1086,7 @@ func TestBlockListenerReorgWhileRebuilding(t *testing.T) {
 	})
 	conditionalMockOnce(
 		mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getFilterChanges", testBlockFilterID1).Return(nil),
-		func() bool { return len(bl.consumers) > 0 },
+		func() bool { return blockListenerConsumers(bl) > 0 },
 		func(args mock.Arguments) {
 			hbh := args[1].(*[]ethtypes.HexBytes0xPrefix)
 			*hbh = []ethtypes.HexBytes0xPrefix{
@@ -1187,7 +1194,7 @@ func TestBlockListenerReorgReplaceWholeCanonicalChain(t *testing.T) {
 	})
 	conditionalMockOnce(
 		mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getFilterChanges", testBlockFilterID1).Return(nil),
-		func() bool { return len(bl.consumers) > 0 },
+		func() bool { return blockListenerConsumers(bl) > 0 },
 		func(args mock.Arguments) {
 			hbh := args[1].(*[]ethtypes.HexBytes0xPrefix)
 			*hbh = []ethtypes.HexBytes0xPrefix{
@@ -1298,7 +1305,7 @@ func TestBlockListenerClosed(t *testing.T) {
 	})
 	conditionalMockOnce(
 		mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getFilterChanges", testBlockFilterID1).Return(nil),
-		func() bool { return len(bl.consumers) > 0 },
+		func() bool { return blockListenerConsumers(bl) > 0 },
 		func(args mock.Arguments) {
 			hbh := args[1].(*[]ethtypes.HexBytes0xPrefix)
 			*hbh = []ethtypes.HexBytes0xPrefix{
@@ -1306,7 +1313,7 @@ func TestBlockListenerClosed(t *testing.T) {
 			}
 		})
 	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getFilterChanges", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
-		if len(bl.consumers) == 0 {
+		if blockListenerConsumers(bl) == 0 {
 			go done() // Close after we've processed the log
 		}
 	})
@@ -1352,7 +1359,7 @@ func TestBlockListenerBlockNotFound(t *testing.T) {
 	})
 	conditionalMockOnce(
 		mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getFilterChanges", testBlockFilterID1).Return(nil),
-		func() bool { return len(bl.consumers) > 0 },
+		func() bool { return blockListenerConsumers(bl) > 0 },
 		func(args mock.Arguments) {
 			hbh := args[1].(*[]ethtypes.HexBytes0xPrefix)
 			*hbh = []ethtypes.HexBytes0xPrefix{
@@ -1392,7 +1399,7 @@ func TestBlockListenerBlockHashFailed(t *testing.T) {
 	})
 	conditionalMockOnce(
 		mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getFilterChanges", testBlockFilterID1).Return(nil),
-		func() bool { return len(bl.consumers) > 0 },
+		func() bool { return blockListenerConsumers(bl) > 0 },
 		func(args mock.Arguments) {
 			hbh := args[1].(*[]ethtypes.HexBytes0xPrefix)
 			*hbh = []ethtypes.HexBytes0xPrefix{
@@ -1434,7 +1441,7 @@ func TestBlockListenerProcessNonStandardHashRejectedWhenNotInHederaCompatibility
 	})
 	conditionalMockOnce(
 		mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getFilterChanges", testBlockFilterID1).Return(nil),
-		func() bool { return len(bl.consumers) > 0 },
+		func() bool { return blockListenerConsumers(bl) > 0 },
 		func(args mock.Arguments) {
 			hbh := args[1].(*[]ethtypes.HexBytes0xPrefix)
 			*hbh = []ethtypes.HexBytes0xPrefix{
@@ -1472,7 +1479,7 @@ func TestBlockListenerProcessNonStandardHashRejectedWhenWrongSizeForHedera(t *te
 	})
 	conditionalMockOnce(
 		mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getFilterChanges", testBlockFilterID1).Return(nil),
-		func() bool { return len(bl.consumers) > 0 },
+		func() bool { return blockListenerConsumers(bl) > 0 },
 		func(args mock.Arguments) {
 			hbh := args[1].(*[]ethtypes.HexBytes0xPrefix)
 			*hbh = []ethtypes.HexBytes0xPrefix{
@@ -1511,7 +1518,7 @@ func TestBlockListenerProcessNonStandardHashAcceptedWhenInHederaCompatbilityMode
 	})
 	conditionalMockOnce(
 		mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getFilterChanges", testBlockFilterID1).Return(nil),
-		func() bool { return len(bl.consumers) > 0 },
+		func() bool { return blockListenerConsumers(bl) > 0 },
 		func(args mock.Arguments) {
 			hbh := args[1].(*[]ethtypes.HexBytes0xPrefix)
 			*hbh = []ethtypes.HexBytes0xPrefix{
@@ -1704,3 +1711,408 @@ func TestBlockListenerRebuildCanonicalFailTerminate(t *testing.T) {
 
 	mRPC.AssertExpectations(t)
 }
+
+func TestBlockListenerBackfillGapAfterFilterReset(t *testing.T) {
+
+	_, c, mRPC, done := newTestConnectorWithNoBlockerFilterDefaultMocks(t)
+	bl := c.blockListener
+	bl.blockPollingInterval = 1 * time.Microsecond
+
+	block999Hash := ethtypes.MustNewHexBytes0xPrefix(fftypes.NewRandB32().String())
+	block1000Hash := ethtypes.MustNewHexBytes0xPrefix(fftypes.NewRandB32().String())
+	block1001Hash := ethtypes.MustNewHexBytes0xPrefix(fftypes.NewRandB32().String())
+	block1002Hash := ethtypes.MustNewHexBytes0xPrefix(fftypes.NewRandB32().String())
+	bl.canonicalChain.PushBack(&minimalBlockInfo{
+		number:     1000,
+		hash:       block1000Hash.String(),
+		parentHash: block999Hash.String(),
+	})
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_blockNumber").Return(nil).Run(func(args mock.Arguments) {
+		hbh := args[1].(*ethtypes.HexInteger)
+		*hbh = *ethtypes.NewHexInteger64(1000)
+	}).Once()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_blockNumber").Return(nil).Run(func(args mock.Arguments) {
+		hbh := args[1].(*ethtypes.HexInteger)
+		*hbh = *ethtypes.NewHexInteger64(1002)
+	}).Once()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_newBlockFilter").Return(nil).Run(func(args mock.Arguments) {
+		hbh := args[1].(*string)
+		*hbh = testBlockFilterID1
+	}).Once()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_newBlockFilter").Return(nil).Run(func(args mock.Arguments) {
+		hbh := args[1].(*string)
+		*hbh = testBlockFilterID2
+	}).Once()
+	conditionalMockOnce(
+		mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getFilterChanges", testBlockFilterID1).Return(&rpcbackend.RPCError{Message: "filter not found"}),
+		func() bool { return blockListenerConsumers(bl) > 0 },
+		func(args mock.Arguments) {},
+	)
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getFilterChanges", testBlockFilterID2).Return(nil).Run(func(args mock.Arguments) {
+		hbh := args[1].(*[]ethtypes.HexBytes0xPrefix)
+		*hbh = []ethtypes.HexBytes0xPrefix{
+			block1002Hash,
+		}
+	}).Once()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getFilterChanges", mock.Anything).Return(nil)
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", mock.MatchedBy(func(bn *ethtypes.HexInteger) bool {
+		return bn.BigInt().Int64() == 1000
+	}), false).Return(nil).Run(func(args mock.Arguments) {
+		*args[1].(**blockInfoJSONRPC) = &blockInfoJSONRPC{
+			Number:     ethtypes.NewHexInteger64(1000),
+			Hash:       block1000Hash,
+			ParentHash: block999Hash,
+		}
+	})
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", mock.MatchedBy(func(bn *ethtypes.HexInteger) bool {
+		return bn.BigInt().Int64() == 1001
+	}), false).Return(nil).Run(func(args mock.Arguments) {
+		*args[1].(**blockInfoJSONRPC) = &blockInfoJSONRPC{
+			Number:     ethtypes.NewHexInteger64(1001),
+			Hash:       block1001Hash,
+			ParentHash: block1000Hash,
+		}
+	})
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", mock.MatchedBy(func(bn *ethtypes.HexInteger) bool {
+		return bn.BigInt().Int64() == 1002
+	}), false).Return(nil).Run(func(args mock.Arguments) {
+		*args[1].(**blockInfoJSONRPC) = &blockInfoJSONRPC{
+			Number:     ethtypes.NewHexInteger64(1002),
+			Hash:       block1002Hash,
+			ParentHash: block1001Hash,
+		}
+	})
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", mock.MatchedBy(func(bn *ethtypes.HexInteger) bool {
+		return bn.BigInt().Int64() == 1003 // not found
+	}), false).Return(nil)
+
+	updates := make(chan *ffcapi.BlockHashEvent)
+	bl.addConsumer(context.Background(), &blockUpdateConsumer{
+		id:      fftypes.NewUUID(),
+		ctx:     context.Background(),
+		updates: updates,
+	})
+
+	bu := <-updates
+	assert.Equal(t, []string{
+		block1001Hash.String(), // The gap we filled in
+		block1002Hash.String(),
+	}, bu.BlockHashes)
+	assert.True(t, bu.GapPotential)
+
+	done()
+	<-bl.listenLoopDone
+
+	assert.Equal(t, int64(1002), bl.highestBlock)
+
+	mRPC.AssertExpectations(t)
+
+}
+
+func TestBlockListenerBackfillGapEmptyChain(t *testing.T) {
+
+	_, c, _, done := newTestConnectorWithNoBlockerFilterDefaultMocks(t)
+	defer done()
+	bl := c.blockListener
+
+	res := bl.checkAndBackfillGap()
+	assert.Nil(t, res)
+
+}
+
+func TestBlockListenerBackfillGapNoGap(t *testing.T) {
+
+	_, c, mRPC, done := newTestConnectorWithNoBlockerFilterDefaultMocks(t)
+	defer done()
+	bl := c.blockListener
+	bl.canonicalChain.PushBack(&minimalBlockInfo{
+		number:     1000,
+		hash:       ethtypes.MustNewHexBytes0xPrefix(fftypes.NewRandB32().String()).String(),
+		parentHash: ethtypes.MustNewHexBytes0xPrefix(fftypes.NewRandB32().String()).String(),
+	})
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_blockNumber").Return(nil).Run(func(args mock.Arguments) {
+		hbh := args[1].(*ethtypes.HexInteger)
+		*hbh = *ethtypes.NewHexInteger64(1000)
+	}).Once()
+
+	res := bl.checkAndBackfillGap()
+	assert.Nil(t, res)
+
+}
+
+func TestBlockListenerBackfillGapBlockNumberFail(t *testing.T) {
+
+	_, c, mRPC, done := newTestConnectorWithNoBlockerFilterDefaultMocks(t)
+	defer done()
+	bl := c.blockListener
+	bl.canonicalChain.PushBack(&minimalBlockInfo{
+		number:     1000,
+		hash:       ethtypes.MustNewHexBytes0xPrefix(fftypes.NewRandB32().String()).String(),
+		parentHash: ethtypes.MustNewHexBytes0xPrefix(fftypes.NewRandB32().String()).String(),
+	})
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_blockNumber").Return(&rpcbackend.RPCError{Message: "pop"}).Once()
+
+	res := bl.checkAndBackfillGap()
+	assert.Nil(t, res)
+
+}
+
+type testSubscription struct {
+	notifications chan *rpcbackend.RPCSubscriptionNotification
+}
+
+func (ts *testSubscription) LocalID() *fftypes.UUID {
+	return nil
+}
+
+func (ts *testSubscription) Notifications() chan *rpcbackend.RPCSubscriptionNotification {
+	return ts.notifications
+}
+
+func (ts *testSubscription) Unsubscribe(_ context.Context) *rpcbackend.RPCError {
+	return nil
+}
+
+func TestBlockListenerWSReconnectDetected(t *testing.T) {
+
+	_, c, _, done := newTestConnectorWithNoBlockerFilterDefaultMocks(t)
+	defer done()
+	bl := c.blockListener
+
+	sub := &testSubscription{notifications: make(chan *rpcbackend.RPCSubscriptionNotification, 4)}
+	bl.newHeadsSub = sub
+	sub.notifications <- &rpcbackend.RPCSubscriptionNotification{CurrentSubID: "sub1"}
+	sub.notifications <- &rpcbackend.RPCSubscriptionNotification{CurrentSubID: "sub1"}
+	sub.notifications <- &rpcbackend.RPCSubscriptionNotification{CurrentSubID: "sub2"}
+	sub.notifications <- &rpcbackend.RPCSubscriptionNotification{CurrentSubID: "sub3"}
+	close(sub.notifications)
+	bl.newHeadsSubListener()
+
+	assert.Equal(t, 2, bl.status().WebSocketReconnects)
+	assert.True(t, bl.takeWSReconnect())
+	assert.False(t, bl.takeWSReconnect())
+
+}
+
+func TestBlockListenerWSReconnectBackfillGap(t *testing.T) {
+
+	_, c, mRPC, done := newTestConnectorWithNoBlockerFilterDefaultMocks(t)
+	bl := c.blockListener
+	bl.blockPollingInterval = 1 * time.Microsecond
+	n := newTestQueueNotifier()
+	c.notifier = n
+
+	block999Hash := ethtypes.MustNewHexBytes0xPrefix(fftypes.NewRandB32().String())
+	block1000Hash := ethtypes.MustNewHexBytes0xPrefix(fftypes.NewRandB32().String())
+	block1001Hash := ethtypes.MustNewHexBytes0xPrefix(fftypes.NewRandB32().String())
+	block1002Hash := ethtypes.MustNewHexBytes0xPrefix(fftypes.NewRandB32().String())
+	bl.canonicalChain.PushBack(&minimalBlockInfo{
+		number:     1000,
+		hash:       block1000Hash.String(),
+		parentHash: block999Hash.String(),
+	})
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_blockNumber").Return(nil).Run(func(args mock.Arguments) {
+		hbh := args[1].(*ethtypes.HexInteger)
+		*hbh = *ethtypes.NewHexInteger64(1000)
+	}).Twice()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_blockNumber").Return(nil).Run(func(args mock.Arguments) {
+		hbh := args[1].(*ethtypes.HexInteger)
+		*hbh = *ethtypes.NewHexInteger64(1002)
+	}).Once()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_newBlockFilter").Return(nil).Run(func(args mock.Arguments) {
+		hbh := args[1].(*string)
+		*hbh = testBlockFilterID1
+	}).Once()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_newBlockFilter").Return(nil).Run(func(args mock.Arguments) {
+		hbh := args[1].(*string)
+		*hbh = testBlockFilterID2
+	}).Once()
+	conditionalMockOnce(
+		mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getFilterChanges", testBlockFilterID1).Return(nil),
+		func() bool { return blockListenerConsumers(bl) > 0 },
+		func(args mock.Arguments) {
+			// The WebSocket reconnects while we are waiting for the next poll
+			bl.mux.Lock()
+			bl.wsReconnects++
+			bl.wsReconnectPending = true
+			bl.mux.Unlock()
+		},
+	)
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getFilterChanges", testBlockFilterID2).Return(nil).Run(func(args mock.Arguments) {
+		hbh := args[1].(*[]ethtypes.HexBytes0xPrefix)
+		*hbh = []ethtypes.HexBytes0xPrefix{
+			block1002Hash,
+		}
+	}).Once()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getFilterChanges", mock.Anything).Return(nil)
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", mock.MatchedBy(func(bn *ethtypes.HexInteger) bool {
+		return bn.BigInt().Int64() == 1000
+	}), false).Return(nil).Run(func(args mock.Arguments) {
+		*args[1].(**blockInfoJSONRPC) = &blockInfoJSONRPC{
+			Number:     ethtypes.NewHexInteger64(1000),
+			Hash:       block1000Hash,
+			ParentHash: block999Hash,
+		}
+	})
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", mock.MatchedBy(func(bn *ethtypes.HexInteger) bool {
+		return bn.BigInt().Int64() == 1001
+	}), false).Return(nil).Run(func(args mock.Arguments) {
+		*args[1].(**blockInfoJSONRPC) = &blockInfoJSONRPC{
+			Number:     ethtypes.NewHexInteger64(1001),
+			Hash:       block1001Hash,
+			ParentHash: block1000Hash,
+		}
+	})
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", mock.MatchedBy(func(bn *ethtypes.HexInteger) bool {
+		return bn.BigInt().Int64() == 1002
+	}), false).Return(nil).Run(func(args mock.Arguments) {
+		*args[1].(**blockInfoJSONRPC) = &blockInfoJSONRPC{
+			Number:     ethtypes.NewHexInteger64(1002),
+			Hash:       block1002Hash,
+			ParentHash: block1001Hash,
+		}
+	})
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", mock.MatchedBy(func(bn *ethtypes.HexInteger) bool {
+		return bn.BigInt().Int64() == 1003 // not found
+	}), false).Return(nil)
+
+	updates := make(chan *ffcapi.BlockHashEvent)
+	bl.addConsumer(context.Background(), &blockUpdateConsumer{
+		id:      fftypes.NewUUID(),
+		ctx:     context.Background(),
+		updates: updates,
+	})
+
+	bu := <-updates
+	assert.Equal(t, []string{
+		block1001Hash.String(), // The gap we filled in after reconnecting
+		block1002Hash.String(),
+	}, bu.BlockHashes)
+	assert.True(t, bu.GapPotential)
+
+	notification := <-n.queue
+	assert.Equal(t, NotificationWebSocketReconnected, notification.Type)
+	assert.Equal(t, int64(2), notification.Details.GetInt64("gapBlocks"))
+	assert.Equal(t, int64(1000), notification.Details.GetInt64("fromBlock"))
+	assert.Equal(t, int64(1002), notification.Details.GetInt64("headBlock"))
+	assert.Equal(t, int64(1), notification.Details.GetInt64("reconnects"))
+
+	done()
+	<-bl.listenLoopDone
+
+	status := bl.status()
+	assert.Equal(t, 1, status.WebSocketReconnects)
+	assert.Equal(t, int64(2), status.LastReconnectGap)
+
+	mRPC.AssertExpectations(t)
+
+}
+
+func TestBlockListenerWSReconnectEmptyChain(t *testing.T) {
+
+	_, c, _, done := newTestConnectorWithNoBlockerFilterDefaultMocks(t)
+	defer done()
+	bl := c.blockListener
+	n := newTestQueueNotifier()
+	c.notifier = n
+
+	bl.reportWSReconnectGap(bl.lastCanonicalBlockNumber())
+	notification := <-n.queue
+	assert.Equal(t, int64(0), notification.Details.GetInt64("gapBlocks"))
+	assert.Equal(t, int64(-1), notification.Details.GetInt64("fromBlock"))
+
+}
+
+func TestBlockListenerCanonicalChainIndexTracksForks(t *testing.T) {
+
+	_, c, _, done := newTestConnectorWithNoBlockerFilterDefaultMocks(t)
+	defer done()
+	bl := c.blockListener
+	bl.unstableHeadLength = 2
+
+	block1000Hash := ethtypes.MustNewHexBytes0xPrefix(fftypes.NewRandB32().String()).String()
+	block1001HashA := ethtypes.MustNewHexBytes0xPrefix(fftypes.NewRandB32().String()).String()
+	block1001HashB := ethtypes.MustNewHexBytes0xPrefix(fftypes.NewRandB32().String()).String()
+	block1002HashA := ethtypes.MustNewHexBytes0xPrefix(fftypes.NewRandB32().String()).String()
+
+	bl.canonicalChain.PushBack(&minimalBlockInfo{number: 1000, hash: block1000Hash})
+	bl.canonicalChain.PushBack(&minimalBlockInfo{number: 1001, hash: block1001HashA})
+	bl.canonicalChain.PushBack(&minimalBlockInfo{number: 1002, hash: block1002HashA})
+	bl.updateCanonicalChainIndex()
+
+	hash, ok := bl.getCanonicalBlockHash(1001)
+	assert.True(t, ok)
+	assert.Equal(t, block1001HashA, hash)
+	assert.False(t, bl.isKnownFork(block1001HashA))
+
+	// Re-org replaces 1001 and drops 1002
+	bl.canonicalChain.Remove(bl.canonicalChain.Back())
+	bl.canonicalChain.Back().Value = &minimalBlockInfo{number: 1001, hash: block1001HashB}
+	bl.updateCanonicalChainIndex()
+
+	hash, ok = bl.getCanonicalBlockHash(1001)
+	assert.True(t, ok)
+	assert.Equal(t, block1001HashB, hash)
+	_, ok = bl.getCanonicalBlockHash(1002)
+	assert.False(t, ok)
+	assert.True(t, bl.isKnownFork(block1001HashA))
+	assert.True(t, bl.isKnownFork(block1002HashA))
+	assert.False(t, bl.isKnownFork(block1000Hash))
+
+	// Duplicates are ignored, and we only track forks to the configured depth
+	bl.recordForkedBlock(&minimalBlockInfo{number: 1002, hash: block1002HashA})
+	assert.Equal(t, 2, bl.forkedBlocks.Len())
+	bl.recordForkedBlock(&minimalBlockInfo{number: 1003, hash: ethtypes.MustNewHexBytes0xPrefix(fftypes.NewRandB32().String()).String()})
+	assert.Equal(t, 2, bl.forkedBlocks.Len())
+	assert.False(t, bl.isKnownFork(block1001HashA))
+
+}
+
+func TestBlockListenerCachedBlockByNumberUsesCanonicalChain(t *testing.T) {
+
+	ctx, c, mRPC, done := newTestConnectorWithNoBlockerFilterDefaultMocks(t)
+	defer done()
+	bl := c.blockListener
+
+	block1000Hash := ethtypes.MustNewHexBytes0xPrefix(fftypes.NewRandB32().String())
+	block1001HashA := ethtypes.MustNewHexBytes0xPrefix(fftypes.NewRandB32().String())
+	block1001HashB := ethtypes.MustNewHexBytes0xPrefix(fftypes.NewRandB32().String())
+	block1002Hash := ethtypes.MustNewHexBytes0xPrefix(fftypes.NewRandB32().String())
+
+	// The fork block is the most recent cache entry by number
+	bl.addToBlockCache(&blockInfoJSONRPC{Number: ethtypes.NewHexInteger64(1001), Hash: block1001HashB, ParentHash: block1000Hash})
+	bl.addToBlockCache(&blockInfoJSONRPC{Number: ethtypes.NewHexInteger64(1001), Hash: block1001HashA, ParentHash: block1000Hash})
+	bl.canonicalChain.PushBack(&minimalBlockInfo{number: 1001, hash: block1001HashB.String()})
+	bl.updateCanonicalChainIndex()
+
+	bi, reason, err := bl.getBlockInfoByNumber(ctx, 1001, true, block1000Hash.String())
+	assert.NoError(t, err)
+	assert.Empty(t, reason)
+	assert.Equal(t, block1001HashB, bi.Hash)
+
+	// Outside of the canonical chain, a known fork in the cache is a miss
+	bl.addToBlockCache(&blockInfoJSONRPC{Number: ethtypes.NewHexInteger64(1002), Hash: block1002Hash, ParentHash: block1001HashA})
+	bl.mux.Lock()
+	bl.recordForkedBlock(&minimalBlockInfo{number: 1002, hash: block1002Hash.String()})
+	bl.mux.Unlock()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", mock.Anything, false).Return(nil).Once()
+	bi, reason, err = bl.getBlockInfoByNumber(ctx, 1002, true, "")
+	assert.NoError(t, err)
+	assert.Empty(t, reason)
+	assert.Nil(t, bi)
+
+	// Canonical block evicted from the cache is also a miss
+	bl.blockCache.Remove(block1001HashB.String())
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", mock.Anything, false).Return(nil).Once()
+	bi, _, err = bl.getBlockInfoByNumber(ctx, 1001, true, "")
+	assert.NoError(t, err)
+	assert.Nil(t, bi)
+
+	mRPC.AssertExpectations(t)
+}
@@ -0,0 +1,71 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/log"
+)
+
+const bloomLength = 256 // 2048 bits
+
+// bloomContains checks a value against a logsBloom as defined in the yellow paper, where each value sets
+// three bits taken from the low 11 bits of the first three pairs of bytes of its keccak256 hash.
+// A false result means the value is definitely not in the set, a true result means it might be.
+func bloomContains(bloom []byte, value []byte) bool {
+	hash := keccak256(value)
+	for i := 0; i < 6; i += 2 {
+		bit := (uint(hash[i])<<8 | uint(hash[i+1])) & 2047
+		if bloom[bloomLength-1-bit/8]&(1<<(bit%8)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// bloomMayMatch checks whether a block with the supplied logsBloom might contain an event
+// matching any of the filters of the listeners in the aggregated listener
+func (ag *aggregatedListener) bloomMayMatch(bloom []byte) bool {
+	for _, l := range ag.listeners {
+		for _, f := range l.config.filters {
+			if bloomContains(bloom, f.Topic0) && (f.Address == nil || bloomContains(bloom, f.Address[:])) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// canSkipFilterPoll uses the blooms of the blocks cached by the block listener since the last poll,
+// to determine if the poll can be skipped because none of those blocks can contain matching events.
+// Any block that is not available in the cache, or does not have a bloom, requires a poll.
+// A poll is always made after the maximum skip interval, so the node does not expire the filter.
+func (es *eventStream) canSkipFilterPoll(ctx context.Context, ag *aggregatedListener, polledBlock, headBlock int64, lastPoll time.Time) bool {
+	if !es.c.bloomScreening || time.Since(lastPoll) >= es.c.bloomScreeningMaxSkip {
+		return false
+	}
+	for blockNumber := polledBlock + 1; blockNumber <= headBlock; blockNumber++ {
+		bi := es.c.blockListener.getCachedBlockByNumber(ctx, blockNumber)
+		if bi == nil || len(bi.LogsBloom) != bloomLength || ag.bloomMayMatch(bi.LogsBloom) {
+			return false
+		}
+	}
+	log.L(ctx).Debugf("Skipping filter poll as blooms for blocks %d-%d do not match any listeners", polledBlock+1, headBlock)
+	return true
+}
@@ -0,0 +1,148 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	testTransferTopic0     = ethtypes.MustNewHexBytes0xPrefix("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+	testApprovalTopic0     = ethtypes.MustNewHexBytes0xPrefix("0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925")
+	testBloomAddress       = ethtypes.MustNewAddress("0x171ae0bdd882f7b4c84d5b7fbfa994e39c5a3129")
+	testBloomOtherAddress  = ethtypes.MustNewAddress("0xc1552c7e527f8cb51bbca69c6849a192598fafe6")
+	testBloomTransferEvent = &eventFilter{Topic0: testTransferTopic0, Address: testBloomAddress}
+)
+
+// testBloom builds a bloom by treating it as a big-endian 2048 bit integer, as per the yellow paper definition
+func testBloom(values ...[]byte) ethtypes.HexBytes0xPrefix {
+	bloom := new(big.Int)
+	for _, v := range values {
+		hash := keccak256(v)
+		for i := 0; i < 6; i += 2 {
+			bloom.SetBit(bloom, int(new(big.Int).SetBytes(hash[i:i+2]).Int64()&2047), 1)
+		}
+	}
+	return bloom.FillBytes(make([]byte, 256))
+}
+
+func testBloomAggregatedListener(filters ...*eventFilter) *aggregatedListener {
+	es := &eventStream{}
+	return es.buildAggregatedListener([]*listener{{config: listenerConfig{filters: filters}}})
+}
+
+func TestBloomContains(t *testing.T) {
+	bloom := testBloom(testTransferTopic0, testBloomAddress[:])
+	assert.True(t, bloomContains(bloom, testTransferTopic0))
+	assert.True(t, bloomContains(bloom, testBloomAddress[:]))
+	assert.False(t, bloomContains(bloom, testApprovalTopic0))
+	assert.False(t, bloomContains(bloom, testBloomOtherAddress[:]))
+	assert.False(t, bloomContains(make([]byte, 256), testTransferTopic0))
+}
+
+func TestBloomMayMatch(t *testing.T) {
+	ag := testBloomAggregatedListener(testBloomTransferEvent)
+	assert.True(t, ag.bloomMayMatch(testBloom(testTransferTopic0, testBloomAddress[:])))
+	assert.False(t, ag.bloomMayMatch(testBloom(testTransferTopic0, testBloomOtherAddress[:])))
+	assert.False(t, ag.bloomMayMatch(testBloom(testApprovalTopic0, testBloomAddress[:])))
+
+	ag = testBloomAggregatedListener(&eventFilter{Topic0: testTransferTopic0})
+	assert.True(t, ag.bloomMayMatch(testBloom(testTransferTopic0, testBloomOtherAddress[:])))
+}
+
+func TestCanSkipFilterPoll(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t)
+	defer done()
+	c.bloomScreening = true
+	c.bloomScreeningMaxSkip = 1 * time.Hour
+	es := &eventStream{ctx: ctx, c: c}
+	ag := testBloomAggregatedListener(testBloomTransferEvent)
+
+	addBlock := func(n int64, bloom ethtypes.HexBytes0xPrefix) {
+		c.blockListener.addToBlockCache(&blockInfoJSONRPC{
+			Number:    ethtypes.NewHexInteger64(n),
+			Hash:      ethtypes.MustNewHexBytes0xPrefix(fmt.Sprintf("0x%064x", n)),
+			LogsBloom: bloom,
+		})
+	}
+	addBlock(101, testBloom(testApprovalTopic0, testBloomAddress[:]))
+	addBlock(102, testBloom(testTransferTopic0, testBloomOtherAddress[:]))
+	addBlock(103, testBloom(testTransferTopic0, testBloomAddress[:]))
+	addBlock(104, nil)
+
+	assert.True(t, es.canSkipFilterPoll(ctx, ag, 100, 100, time.Now()))
+	assert.True(t, es.canSkipFilterPoll(ctx, ag, 100, 102, time.Now()))
+	assert.False(t, es.canSkipFilterPoll(ctx, ag, 100, 103, time.Now()))
+	assert.False(t, es.canSkipFilterPoll(ctx, ag, 103, 104, time.Now()))
+	assert.False(t, es.canSkipFilterPoll(ctx, ag, 104, 105, time.Now()))
+	assert.False(t, es.canSkipFilterPoll(ctx, ag, 100, 102, time.Now().Add(-2*time.Hour)))
+
+	c.bloomScreening = false
+	assert.False(t, es.canSkipFilterPoll(ctx, ag, 100, 102, time.Now()))
+}
+
+func TestStreamLoopBloomScreeningSkipsPoll(t *testing.T) {
+	l1req := &ffcapi.EventListenerAddRequest{
+		ListenerID: fftypes.NewUUID(),
+		EventListenerOptions: ffcapi.EventListenerOptions{
+			Filters: []fftypes.JSONAny{
+				*fftypes.JSONAnyPtr(`{"address":"0x171AE0BDd882F7b4C84D5b7FBFA994E39C5a3129","event":` + abiTransferEvent + `}`),
+			},
+			Options:   fftypes.JSONAnyPtr(`{}`),
+			FromBlock: strconv.Itoa(testHighBlock),
+		},
+	}
+	ctx, c, mRPC, done := newTestConnector(t)
+	c.bloomScreening = true
+	c.bloomScreeningMaxSkip = 50 * time.Millisecond
+
+	polled := make(chan time.Time)
+	var lastPoll time.Time
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_blockNumber").Return(nil).Run(func(args mock.Arguments) {
+		hbh := args[1].(*ethtypes.HexInteger)
+		*hbh = *ethtypes.NewHexInteger64(testHighBlock)
+	})
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_newFilter", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		*args[1].(*string) = testLogsFilterID1
+	})
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getFilterLogs", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		*args[1].(*[]*logJSONRPC) = make([]*logJSONRPC, 0)
+		lastPoll = time.Now()
+	}).Once()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getFilterChanges", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		*args[1].(*[]*logJSONRPC) = make([]*logJSONRPC, 0)
+		polled <- time.Now()
+	})
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_uninstallFilter", mock.Anything).Return(nil).Maybe()
+
+	_, _, _, done = testEventStreamExistingConnector(t, ctx, done, c, mRPC, l1req)
+	defer done()
+
+	// With no new blocks, the filter is only polled once the maximum skip interval has passed
+	nextPoll := <-polled
+	assert.GreaterOrEqual(t, nextPoll.Sub(lastPoll), 50*time.Millisecond)
+}
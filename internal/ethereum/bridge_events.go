@@ -0,0 +1,226 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rlp"
+)
+
+const (
+	BridgeProtocolOPStack  = "op-stack"
+	BridgeProtocolArbitrum = "arbitrum"
+
+	BridgeDirectionDeposit    = "deposit"    // L1 to L2
+	BridgeDirectionWithdrawal = "withdrawal" // L2 to L1
+
+	BridgeStageInitiated = "initiated" // emitted on the source chain
+	BridgeStageProven    = "proven"    // emitted on the target chain
+	BridgeStageExecuted  = "executed"  // emitted on the target chain
+
+	BridgeChainL1 = "l1"
+	BridgeChainL2 = "l2"
+)
+
+// The EIP-2718 type of an OP-stack deposit transaction, and the domain of the source hash of a user deposit
+const (
+	opDepositTxType         byte = 0x7e
+	opUserDepositDomain          = 0
+	opDepositOpaqueFixedLen      = 73 // mint, value, gas limit and isCreation, before the data
+)
+
+// BridgeMessage annotates an event of the system contracts of a native L1<->L2 bridge, to correlate the two sides of
+// a deposit or withdrawal. The message hash, or index for Arbitrum, is the same on both chains.
+type BridgeMessage struct {
+	Protocol      string                    `json:"protocol"`
+	Direction     string                    `json:"direction"`
+	Stage         string                    `json:"stage"`
+	MessageHash   ethtypes.HexBytes0xPrefix `json:"messageHash,omitempty"`
+	MessageIndex  *ethtypes.HexInteger      `json:"messageIndex,omitempty"`
+	TargetChain   string                    `json:"targetChain"`
+	TargetChainID string                    `json:"targetChainId,omitempty"` // the counterpart chain configured on the listener, for events on the source chain
+}
+
+type bridgeEvent struct {
+	protocol  string
+	direction string
+	stage     string
+	// decode returns the message hash and index from the log, or false if the log is not in the expected form
+	decode func(ethLog *logJSONRPC) (ethtypes.HexBytes0xPrefix, *big.Int, bool)
+}
+
+// bridgeEvents are the standard events of the bridge system contracts, by topic0
+var bridgeEvents = map[string]*bridgeEvent{}
+
+func init() {
+	for signature, be := range map[string]*bridgeEvent{
+		// OP-stack OptimismPortal (L1), with the hash of the deposit transaction it creates on L2
+		"TransactionDeposited(address,address,uint256,bytes)": {
+			protocol: BridgeProtocolOPStack, direction: BridgeDirectionDeposit, stage: BridgeStageInitiated, decode: decodeOPDeposit,
+		},
+		// OP-stack L2ToL1MessagePasser (L2)
+		"MessagePassed(uint256,address,address,uint256,uint256,bytes,bytes32)": {
+			protocol: BridgeProtocolOPStack, direction: BridgeDirectionWithdrawal, stage: BridgeStageInitiated, decode: dataWordHash(3),
+		},
+		// OP-stack OptimismPortal (L1)
+		"WithdrawalProven(bytes32,address,address)": {
+			protocol: BridgeProtocolOPStack, direction: BridgeDirectionWithdrawal, stage: BridgeStageProven, decode: topicHash(1),
+		},
+		"WithdrawalFinalized(bytes32,bool)": {
+			protocol: BridgeProtocolOPStack, direction: BridgeDirectionWithdrawal, stage: BridgeStageExecuted, decode: topicHash(1),
+		},
+		// Arbitrum Bridge (L1), with the hash of the message data and the index of the message in the inbox
+		"MessageDelivered(uint256,bytes32,address,uint8,address,bytes32,uint256,uint64)": {
+			protocol: BridgeProtocolArbitrum, direction: BridgeDirectionDeposit, stage: BridgeStageInitiated, decode: decodeArbitrumMessageDelivered,
+		},
+		// Arbitrum ArbSys (L2), with the position of the message in the outbox
+		"L2ToL1Tx(address,address,uint256,uint256,uint256,uint256,uint256,uint256,bytes)": {
+			protocol: BridgeProtocolArbitrum, direction: BridgeDirectionWithdrawal, stage: BridgeStageInitiated, decode: decodeArbitrumL2ToL1Tx,
+		},
+		// Arbitrum Outbox (L1), with the position of the executed message
+		"OutBoxTransactionExecuted(address,address,uint256,uint256)": {
+			protocol: BridgeProtocolArbitrum, direction: BridgeDirectionWithdrawal, stage: BridgeStageExecuted, decode: decodeArbitrumOutboxExecuted,
+		},
+	} {
+		bridgeEvents[ethtypes.HexBytes0xPrefix(keccak256([]byte(signature))).String()] = be
+	}
+}
+
+// bridgeMessage returns the annotation of a bridge event, or nil if the log is not a bridge event
+func (ee *eventEnricher) bridgeMessage(ctx context.Context, ethLog *logJSONRPC) *BridgeMessage {
+	if len(ethLog.Topics) == 0 {
+		return nil
+	}
+	be := bridgeEvents[ethLog.Topics[0].String()]
+	if be == nil {
+		return nil
+	}
+	hash, index, ok := be.decode(ethLog)
+	if !ok {
+		log.L(ctx).Warnf("Unable to decode %s bridge event in transaction '%s' log %s", be.protocol, ethLog.TransactionHash, ethLog.LogIndex)
+		return nil
+	}
+	bm := &BridgeMessage{
+		Protocol:    be.protocol,
+		Direction:   be.direction,
+		Stage:       be.stage,
+		MessageHash: hash,
+		TargetChain: BridgeChainL2,
+	}
+	if index != nil {
+		bm.MessageIndex = (*ethtypes.HexInteger)(index)
+	}
+	if be.direction == BridgeDirectionWithdrawal {
+		bm.TargetChain = BridgeChainL1
+	}
+	if be.stage == BridgeStageInitiated {
+		bm.TargetChainID = ee.bridgeCounterpartChainID
+	} else {
+		bm.TargetChainID = ee.connector.chainID
+	}
+	return bm
+}
+
+func topicHash(i int) func(ethLog *logJSONRPC) (ethtypes.HexBytes0xPrefix, *big.Int, bool) {
+	return func(ethLog *logJSONRPC) (ethtypes.HexBytes0xPrefix, *big.Int, bool) {
+		if len(ethLog.Topics) <= i || len(ethLog.Topics[i]) != 32 {
+			return nil, nil, false
+		}
+		return ethLog.Topics[i], nil, true
+	}
+}
+
+func dataWordHash(i int) func(ethLog *logJSONRPC) (ethtypes.HexBytes0xPrefix, *big.Int, bool) {
+	return func(ethLog *logJSONRPC) (ethtypes.HexBytes0xPrefix, *big.Int, bool) {
+		word := dataWord(ethLog.Data, i)
+		return word, nil, word != nil
+	}
+}
+
+func dataWord(data []byte, i int) ethtypes.HexBytes0xPrefix {
+	if len(data) < (i+1)*32 {
+		return nil
+	}
+	return data[i*32 : (i+1)*32]
+}
+
+func decodeArbitrumMessageDelivered(ethLog *logJSONRPC) (ethtypes.HexBytes0xPrefix, *big.Int, bool) {
+	hash := dataWord(ethLog.Data, 3)
+	if len(ethLog.Topics) < 2 || hash == nil {
+		return nil, nil, false
+	}
+	return hash, new(big.Int).SetBytes(ethLog.Topics[1]), true
+}
+
+func decodeArbitrumL2ToL1Tx(ethLog *logJSONRPC) (ethtypes.HexBytes0xPrefix, *big.Int, bool) {
+	if len(ethLog.Topics) < 4 {
+		return nil, nil, false
+	}
+	return ethLog.Topics[2], new(big.Int).SetBytes(ethLog.Topics[3]), true
+}
+
+func decodeArbitrumOutboxExecuted(ethLog *logJSONRPC) (ethtypes.HexBytes0xPrefix, *big.Int, bool) {
+	index := dataWord(ethLog.Data, 0)
+	if index == nil {
+		return nil, nil, false
+	}
+	return nil, new(big.Int).SetBytes(index), true
+}
+
+// decodeOPDeposit computes the hash of the L2 deposit transaction derived from a TransactionDeposited event,
+// which identifies the deposit on L2. The source hash of a user deposit is derived from the L1 block hash and
+// log index, and the opaque data packs the mint, value, gas limit, isCreation flag and data of the transaction.
+func decodeOPDeposit(ethLog *logJSONRPC) (ethtypes.HexBytes0xPrefix, *big.Int, bool) {
+	if len(ethLog.Topics) < 4 || len(ethLog.Topics[1]) != 32 || len(ethLog.Topics[2]) != 32 || len(ethLog.BlockHash) != 32 {
+		return nil, nil, false
+	}
+	if new(big.Int).SetBytes(ethLog.Topics[3]).Sign() != 0 {
+		return nil, nil, false // only version 0 of the opaque data is known
+	}
+	offset := new(big.Int).SetBytes(dataWord(ethLog.Data, 0))
+	if !offset.IsInt64() || offset.Int64()+32 > int64(len(ethLog.Data)) {
+		return nil, nil, false
+	}
+	start := offset.Int64() + 32
+	length := new(big.Int).SetBytes(ethLog.Data[offset.Int64():start])
+	if !length.IsInt64() || length.Int64() < opDepositOpaqueFixedLen || start+length.Int64() > int64(len(ethLog.Data)) {
+		return nil, nil, false
+	}
+	opaque := ethLog.Data[start : start+length.Int64()]
+
+	var to rlp.Data
+	if opaque[72] == 0 {
+		to = rlp.Data(ethLog.Topics[2][12:])
+	}
+	depositID := keccak256(ethLog.BlockHash, abiWord(ethLog.LogIndex.BigInt().Bytes()))
+	sourceHash := keccak256(abiWord(big.NewInt(opUserDepositDomain).Bytes()), depositID)
+	tx := rlp.List{
+		rlp.Data(sourceHash),
+		rlp.Data(ethLog.Topics[1][12:]),
+		to,
+		rlp.WrapInt(new(big.Int).SetBytes(opaque[0:32])),
+		rlp.WrapInt(new(big.Int).SetBytes(opaque[32:64])),
+		rlp.WrapInt(new(big.Int).SetBytes(opaque[64:72])),
+		rlp.Data{}, // not a system transaction
+		rlp.Data(opaque[opDepositOpaqueFixedLen:]),
+	}
+	return keccak256([]byte{opDepositTxType}, tx.Encode()), nil, true
+}
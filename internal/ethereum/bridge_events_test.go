@@ -0,0 +1,223 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rlp"
+	"github.com/stretchr/testify/assert"
+)
+
+func testBridgeLog(t *testing.T, signature string, topics []ethtypes.HexBytes0xPrefix, dataTypes abi.ParameterArray, dataValues ...interface{}) *logJSONRPC {
+	data, err := dataTypes.EncodeABIDataValuesCtx(context.Background(), dataValues)
+	assert.NoError(t, err)
+	return &logJSONRPC{
+		BlockNumber:      ethtypes.NewHexInteger64(100),
+		BlockHash:        ethtypes.MustNewHexBytes0xPrefix(fmt.Sprintf("0x%064x", 100)),
+		TransactionHash:  ethtypes.MustNewHexBytes0xPrefix(fmt.Sprintf("0x%064x", 1)),
+		TransactionIndex: ethtypes.NewHexInteger64(1),
+		LogIndex:         ethtypes.NewHexInteger64(5),
+		Topics:           append([]ethtypes.HexBytes0xPrefix{keccak256([]byte(signature))}, topics...),
+		Data:             data,
+	}
+}
+
+func testTopic(v int64) ethtypes.HexBytes0xPrefix {
+	return abiWord(big.NewInt(v).Bytes())
+}
+
+func testOPDepositLog(t *testing.T, version int64, isCreation byte, callData []byte) *logJSONRPC {
+	opaque := append(abiWord(big.NewInt(1000).Bytes()), abiWord(big.NewInt(2000).Bytes())...)
+	opaque = append(opaque, 0, 0, 0, 0, 0, 0x01, 0x86, 0xa0, isCreation) // gas limit 100000
+	opaque = append(opaque, callData...)
+	return testBridgeLog(t, "TransactionDeposited(address,address,uint256,bytes)",
+		[]ethtypes.HexBytes0xPrefix{testTopic(0xaaaa), testTopic(0xbbbb), testTopic(version)},
+		abi.ParameterArray{{Type: "bytes"}}, opaque)
+}
+
+func TestBridgeMessageOPDeposit(t *testing.T) {
+	_, c, _, done := newTestConnector(t)
+	defer done()
+	c.chainID = "1"
+	ee := &eventEnricher{connector: c, bridges: true, bridgeCounterpartChainID: "10"}
+	ctx := context.Background()
+
+	ethLog := testOPDepositLog(t, 0, 0, []byte{0xfe, 0xed})
+	bm := ee.bridgeMessage(ctx, ethLog)
+	assert.NotNil(t, bm)
+	assert.Equal(t, BridgeProtocolOPStack, bm.Protocol)
+	assert.Equal(t, BridgeDirectionDeposit, bm.Direction)
+	assert.Equal(t, BridgeStageInitiated, bm.Stage)
+	assert.Equal(t, BridgeChainL2, bm.TargetChain)
+	assert.Equal(t, "10", bm.TargetChainID)
+	assert.Nil(t, bm.MessageIndex)
+
+	// The deposit transaction derived by the L2 node from the event
+	sourceHash := keccak256(make([]byte, 32), keccak256(ethLog.BlockHash, testTopic(5)))
+	depositTx := rlp.List{
+		rlp.Data(sourceHash),
+		rlp.Data(testTopic(0xaaaa)[12:]),
+		rlp.Data(testTopic(0xbbbb)[12:]),
+		rlp.WrapInt(big.NewInt(1000)),
+		rlp.WrapInt(big.NewInt(2000)),
+		rlp.WrapInt(big.NewInt(100000)),
+		rlp.Data{},
+		rlp.Data{0xfe, 0xed},
+	}
+	assert.Equal(t, ethtypes.HexBytes0xPrefix(keccak256([]byte{0x7e}, depositTx.Encode())).String(), bm.MessageHash.String())
+
+	// A contract creation has no destination
+	creation := ee.bridgeMessage(ctx, testOPDepositLog(t, 0, 1, []byte{0xfe, 0xed}))
+	depositTx[2] = rlp.Data{}
+	assert.Equal(t, ethtypes.HexBytes0xPrefix(keccak256([]byte{0x7e}, depositTx.Encode())).String(), creation.MessageHash.String())
+
+	// Unknown versions and malformed data are not annotated
+	assert.Nil(t, ee.bridgeMessage(ctx, testOPDepositLog(t, 1, 0, nil)))
+	malformed := testOPDepositLog(t, 0, 0, nil)
+	malformed.Data = malformed.Data[:64]
+	assert.Nil(t, ee.bridgeMessage(ctx, malformed))
+	malformed.Data = abiWord(big.NewInt(1000).Bytes())
+	assert.Nil(t, ee.bridgeMessage(ctx, malformed))
+	malformed.Data = append(testTopic(32), testTopic(10)...)
+	assert.Nil(t, ee.bridgeMessage(ctx, malformed))
+	malformed.Topics = malformed.Topics[0:3]
+	assert.Nil(t, ee.bridgeMessage(ctx, malformed))
+}
+
+func TestBridgeMessageOPWithdrawal(t *testing.T) {
+	_, c, _, done := newTestConnector(t)
+	defer done()
+	c.chainID = "1"
+	ee := &eventEnricher{connector: c, bridges: true}
+	ctx := context.Background()
+	withdrawalHash := keccak256([]byte("withdrawal"))
+
+	passed := testBridgeLog(t, "MessagePassed(uint256,address,address,uint256,uint256,bytes,bytes32)",
+		[]ethtypes.HexBytes0xPrefix{testTopic(1), testTopic(0xaaaa), testTopic(0xbbbb)},
+		abi.ParameterArray{{Type: "uint256"}, {Type: "uint256"}, {Type: "bytes"}, {Type: "bytes32"}},
+		big.NewInt(0), big.NewInt(100000), []byte{0xfe}, withdrawalHash)
+	bm := ee.bridgeMessage(ctx, passed)
+	assert.Equal(t, BridgeDirectionWithdrawal, bm.Direction)
+	assert.Equal(t, BridgeStageInitiated, bm.Stage)
+	assert.Equal(t, BridgeChainL1, bm.TargetChain)
+	assert.Empty(t, bm.TargetChainID)
+	assert.Equal(t, ethtypes.HexBytes0xPrefix(withdrawalHash).String(), bm.MessageHash.String())
+
+	proven := testBridgeLog(t, "WithdrawalProven(bytes32,address,address)",
+		[]ethtypes.HexBytes0xPrefix{withdrawalHash, testTopic(0xaaaa), testTopic(0xbbbb)}, abi.ParameterArray{})
+	bm = ee.bridgeMessage(ctx, proven)
+	assert.Equal(t, BridgeStageProven, bm.Stage)
+	assert.Equal(t, "1", bm.TargetChainID)
+	assert.Equal(t, ethtypes.HexBytes0xPrefix(withdrawalHash).String(), bm.MessageHash.String())
+
+	finalized := testBridgeLog(t, "WithdrawalFinalized(bytes32,bool)",
+		[]ethtypes.HexBytes0xPrefix{withdrawalHash}, abi.ParameterArray{{Type: "bool"}}, true)
+	bm = ee.bridgeMessage(ctx, finalized)
+	assert.Equal(t, BridgeStageExecuted, bm.Stage)
+	assert.Equal(t, ethtypes.HexBytes0xPrefix(withdrawalHash).String(), bm.MessageHash.String())
+
+	finalized.Topics = finalized.Topics[0:1]
+	assert.Nil(t, ee.bridgeMessage(ctx, finalized))
+	passed.Data = passed.Data[0:64]
+	assert.Nil(t, ee.bridgeMessage(ctx, passed))
+}
+
+func TestBridgeMessageArbitrum(t *testing.T) {
+	_, c, _, done := newTestConnector(t)
+	defer done()
+	c.chainID = "1"
+	ee := &eventEnricher{connector: c, bridges: true, bridgeCounterpartChainID: "42161"}
+	ctx := context.Background()
+	messageDataHash := keccak256([]byte("message"))
+
+	delivered := testBridgeLog(t, "MessageDelivered(uint256,bytes32,address,uint8,address,bytes32,uint256,uint64)",
+		[]ethtypes.HexBytes0xPrefix{testTopic(12345), testTopic(0)},
+		abi.ParameterArray{{Type: "address"}, {Type: "uint8"}, {Type: "address"}, {Type: "bytes32"}, {Type: "uint256"}, {Type: "uint64"}},
+		"0x000000000000000000000000000000000000aaaa", 9, "0x000000000000000000000000000000000000bbbb", messageDataHash, big.NewInt(1000), 1700000000)
+	bm := ee.bridgeMessage(ctx, delivered)
+	assert.Equal(t, BridgeProtocolArbitrum, bm.Protocol)
+	assert.Equal(t, BridgeDirectionDeposit, bm.Direction)
+	assert.Equal(t, "42161", bm.TargetChainID)
+	assert.Equal(t, int64(12345), bm.MessageIndex.BigInt().Int64())
+	assert.Equal(t, ethtypes.HexBytes0xPrefix(messageDataHash).String(), bm.MessageHash.String())
+
+	l2ToL1 := testBridgeLog(t, "L2ToL1Tx(address,address,uint256,uint256,uint256,uint256,uint256,uint256,bytes)",
+		[]ethtypes.HexBytes0xPrefix{testTopic(0xbbbb), testTopic(0xcccc), testTopic(77)},
+		abi.ParameterArray{{Type: "address"}, {Type: "uint256"}, {Type: "uint256"}, {Type: "uint256"}, {Type: "uint256"}, {Type: "bytes"}},
+		"0x000000000000000000000000000000000000aaaa", big.NewInt(1), big.NewInt(2), big.NewInt(3), big.NewInt(0), []byte{})
+	bm = ee.bridgeMessage(ctx, l2ToL1)
+	assert.Equal(t, BridgeDirectionWithdrawal, bm.Direction)
+	assert.Equal(t, BridgeStageInitiated, bm.Stage)
+	assert.Equal(t, int64(77), bm.MessageIndex.BigInt().Int64())
+	assert.Equal(t, testTopic(0xcccc).String(), bm.MessageHash.String())
+
+	executed := testBridgeLog(t, "OutBoxTransactionExecuted(address,address,uint256,uint256)",
+		[]ethtypes.HexBytes0xPrefix{testTopic(0xbbbb), testTopic(0xaaaa), testTopic(0)},
+		abi.ParameterArray{{Type: "uint256"}}, big.NewInt(77))
+	bm = ee.bridgeMessage(ctx, executed)
+	assert.Equal(t, BridgeStageExecuted, bm.Stage)
+	assert.Equal(t, "1", bm.TargetChainID)
+	assert.Equal(t, int64(77), bm.MessageIndex.BigInt().Int64())
+	assert.Nil(t, bm.MessageHash)
+
+	delivered.Topics = delivered.Topics[0:1]
+	assert.Nil(t, ee.bridgeMessage(ctx, delivered))
+	l2ToL1.Topics = l2ToL1.Topics[0:3]
+	assert.Nil(t, ee.bridgeMessage(ctx, l2ToL1))
+	executed.Data = nil
+	assert.Nil(t, ee.bridgeMessage(ctx, executed))
+}
+
+func TestBridgeMessageOtherEvents(t *testing.T) {
+	_, c, _, done := newTestConnector(t)
+	defer done()
+	ee := &eventEnricher{connector: c, bridges: true}
+	assert.Nil(t, ee.bridgeMessage(context.Background(), &logJSONRPC{}))
+	assert.Nil(t, ee.bridgeMessage(context.Background(), &logJSONRPC{Topics: []ethtypes.HexBytes0xPrefix{testTopic(1)}}))
+}
+
+func TestFilterEnrichEthLogBridge(t *testing.T) {
+	_, c, _, done := newTestConnector(t)
+	defer done()
+	c.chainID = "1"
+	ee := &eventEnricher{connector: c, bridges: true, bridgeCounterpartChainID: "10"}
+	ethLog := testOPDepositLog(t, 0, 0, nil)
+	ethLog.Address = ethtypes.MustNewAddress("0xbEb5Fc579115071764c7423A4f12eDde41f106Ed")
+	c.blockListener.addToBlockCache(&blockInfoJSONRPC{Number: ethLog.BlockNumber, Hash: ethLog.BlockHash, Timestamp: ethtypes.NewHexInteger64(1700000000)})
+	event := &abi.Entry{Type: abi.Event, Name: "TransactionDeposited", Inputs: abi.ParameterArray{
+		{Name: "from", Type: "address", Indexed: true},
+		{Name: "to", Type: "address", Indexed: true},
+		{Name: "version", Type: "uint256", Indexed: true},
+		{Name: "opaqueData", Type: "bytes"},
+	}}
+	ev, matched, decoded, err := ee.filterEnrichEthLog(context.Background(), &eventFilter{Event: event, Topic0: ethLog.Topics[0]}, nil, ethLog)
+	assert.NoError(t, err)
+	assert.True(t, matched)
+	assert.True(t, decoded)
+	assert.Equal(t, "10", ev.Info.(*eventInfo).Bridge.TargetChainID)
+
+	ee.bridges = false
+	ev, _, _, err = ee.filterEnrichEthLog(context.Background(), &eventFilter{Event: event, Topic0: ethLog.Topics[0]}, nil, ethLog)
+	assert.NoError(t, err)
+	assert.Nil(t, ev.Info.(*eventInfo).Bridge)
+}
@@ -0,0 +1,116 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+)
+
+const (
+	CheckpointStoreFFTM     = "fftm"
+	CheckpointStoreRedis    = "redis"
+	CheckpointStorePostgres = "postgres"
+)
+
+// checkpointStore holds the checkpoints of listeners outside of the transaction manager, so replicas of the
+// connector can share them, and recover them when the transaction manager has an older checkpoint. The checkpoints
+// of the transaction manager are always used when there is no store.
+type checkpointStore interface {
+	name() string
+	get(ctx context.Context, listenerID *fftypes.UUID) (*listenerCheckpoint, error)
+	put(ctx context.Context, listenerID *fftypes.UUID, checkpoint *listenerCheckpoint) error
+	remove(ctx context.Context, listenerID *fftypes.UUID) error
+	close()
+}
+
+// newCheckpointStore returns nil when the checkpoints are only managed by the transaction manager
+func newCheckpointStore(ctx context.Context, conf config.Section) (checkpointStore, error) {
+	storeType := strings.ToLower(conf.GetString(CheckpointsStore))
+	switch storeType {
+	case CheckpointStoreFFTM, "":
+		return nil, nil
+	case CheckpointStoreRedis:
+		return newRedisCheckpointStore(ctx, conf.SubSection(CheckpointsRedisConfig), conf.GetDuration(CheckpointsRequestTimeout))
+	case CheckpointStorePostgres:
+		return newPostgresCheckpointStore(ctx, conf.SubSection(CheckpointsPostgresConfig), conf.GetDuration(CheckpointsRequestTimeout))
+	default:
+		return nil, i18n.NewError(ctx, msgs.MsgUnknownCheckpointStore, storeType, []string{CheckpointStoreFFTM, CheckpointStoreRedis, CheckpointStorePostgres})
+	}
+}
+
+// storedCheckpoint returns the checkpoint a listener should start from, which is the stored or restored checkpoint
+// if it is ahead of the one passed by the transaction manager
+func (c *ethConnector) storedCheckpoint(ctx context.Context, listenerID *fftypes.UUID, checkpoint *listenerCheckpoint) (*listenerCheckpoint, error) {
+	if restored := c.restoredCheckpoint(listenerID); restored != nil && (checkpoint == nil || checkpoint.LessThan(restored)) {
+		log.L(ctx).Infof("Listener '%s' starting from restored checkpoint %+v, ahead of %+v", listenerID, restored, checkpoint)
+		checkpoint = restored
+	}
+	if c.checkpointStore == nil {
+		return checkpoint, nil
+	}
+	stored, err := c.checkpointStore.get(ctx, listenerID)
+	if err != nil {
+		return nil, err
+	}
+	if stored != nil && (checkpoint == nil || checkpoint.LessThan(stored)) {
+		log.L(ctx).Infof("Listener '%s' starting from checkpoint %+v in the %s checkpoint store, ahead of %+v", listenerID, stored, c.checkpointStore.name(), checkpoint)
+		return stored, nil
+	}
+	return checkpoint, nil
+}
+
+// storeCheckpoint is called with the checkpoint returned to the transaction manager for it to persist, so the
+// store never has a checkpoint past events the transaction manager has not yet dispatched. A failure is logged,
+// as the transaction manager still persists the checkpoint.
+func (l *listener) storeCheckpoint(ctx context.Context, checkpoint *listenerCheckpoint) {
+	store := l.c.checkpointStore
+	if store == nil {
+		return
+	}
+	l.hwmMux.Lock()
+	unchanged := l.storedCheckpoint != nil && *l.storedCheckpoint == *checkpoint
+	l.hwmMux.Unlock()
+	if unchanged {
+		return
+	}
+	if err := store.put(ctx, l.id, checkpoint); err != nil {
+		log.L(ctx).Warnf("Failed to store checkpoint %+v of listener '%s' in the %s checkpoint store: %s", checkpoint, l.id, store.name(), err)
+		return
+	}
+	l.hwmMux.Lock()
+	l.storedCheckpoint = checkpoint
+	l.hwmMux.Unlock()
+}
+
+func (c *ethConnector) removeStoredCheckpoint(ctx context.Context, listenerID *fftypes.UUID) {
+	c.snapshotMux.Lock()
+	delete(c.restoredCheckpoints, *listenerID)
+	c.snapshotMux.Unlock()
+	if c.checkpointStore == nil {
+		return
+	}
+	if err := c.checkpointStore.remove(ctx, listenerID); err != nil {
+		log.L(ctx).Warnf("Failed to remove checkpoint of listener '%s' from the %s checkpoint store: %s", listenerID, c.checkpointStore.name(), err)
+	}
+}
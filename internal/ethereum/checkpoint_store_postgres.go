@@ -0,0 +1,125 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+
+	// Registers the postgres driver for database/sql
+	_ "github.com/lib/pq"
+)
+
+var postgresTableName = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*(\.[a-zA-Z_][a-zA-Z0-9_]*)?$`)
+
+// postgresCheckpointStore holds each checkpoint as a JSON document in a row of a table, keyed on the listener
+// ID. The table is created if it does not exist, so no migrations need to be run.
+type postgresCheckpointStore struct {
+	db             *sql.DB
+	table          string
+	requestTimeout time.Duration
+}
+
+func newPostgresCheckpointStore(ctx context.Context, conf config.Section, requestTimeout time.Duration) (*postgresCheckpointStore, error) {
+	table := conf.GetString(CheckpointsPostgresTable)
+	if !postgresTableName.MatchString(table) {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidCheckpointTable, table)
+	}
+	// sql.Open only validates the arguments, with connections made when they are first needed
+	db, err := sql.Open("postgres", conf.GetString(CheckpointsPostgresURL))
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, msgs.MsgPostgresCheckpointStoreFailed)
+	}
+	db.SetMaxOpenConns(conf.GetInt(CheckpointsPostgresMaxConns))
+	return newPostgresCheckpointStoreDB(ctx, db, table, requestTimeout)
+}
+
+func newPostgresCheckpointStoreDB(ctx context.Context, db *sql.DB, table string, requestTimeout time.Duration) (*postgresCheckpointStore, error) {
+	p := &postgresCheckpointStore{
+		db:             db,
+		table:          table,
+		requestTimeout: requestTimeout,
+	}
+	tctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+	if _, err := db.ExecContext(tctx, fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+		listener_id VARCHAR(36) PRIMARY KEY,
+		checkpoint  TEXT NOT NULL,
+		updated     BIGINT NOT NULL
+	)`, table)); err != nil {
+		_ = db.Close()
+		return nil, i18n.WrapError(ctx, err, msgs.MsgPostgresCheckpointStoreFailed)
+	}
+	return p, nil
+}
+
+func (p *postgresCheckpointStore) name() string {
+	return CheckpointStorePostgres
+}
+
+func (p *postgresCheckpointStore) get(ctx context.Context, listenerID *fftypes.UUID) (*listenerCheckpoint, error) {
+	tctx, cancel := context.WithTimeout(ctx, p.requestTimeout)
+	defer cancel()
+	var value string
+	err := p.db.QueryRowContext(tctx, fmt.Sprintf(`SELECT checkpoint FROM %s WHERE listener_id = $1`, p.table), listenerID.String()).Scan(&value)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, msgs.MsgPostgresCheckpointStoreFailed)
+	}
+	var checkpoint listenerCheckpoint
+	if err := json.Unmarshal([]byte(value), &checkpoint); err != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidStoredCheckpoint, listenerID, err)
+	}
+	return &checkpoint, nil
+}
+
+func (p *postgresCheckpointStore) put(ctx context.Context, listenerID *fftypes.UUID, checkpoint *listenerCheckpoint) error {
+	tctx, cancel := context.WithTimeout(ctx, p.requestTimeout)
+	defer cancel()
+	b, _ := json.Marshal(checkpoint)
+	_, err := p.db.ExecContext(tctx, fmt.Sprintf(`INSERT INTO %s (listener_id, checkpoint, updated) VALUES ($1, $2, $3)
+		ON CONFLICT (listener_id) DO UPDATE SET checkpoint = EXCLUDED.checkpoint, updated = EXCLUDED.updated`, p.table),
+		listenerID.String(), string(b), time.Now().UnixNano())
+	if err != nil {
+		return i18n.WrapError(ctx, err, msgs.MsgPostgresCheckpointStoreFailed)
+	}
+	return nil
+}
+
+func (p *postgresCheckpointStore) remove(ctx context.Context, listenerID *fftypes.UUID) error {
+	tctx, cancel := context.WithTimeout(ctx, p.requestTimeout)
+	defer cancel()
+	if _, err := p.db.ExecContext(tctx, fmt.Sprintf(`DELETE FROM %s WHERE listener_id = $1`, p.table), listenerID.String()); err != nil {
+		return i18n.WrapError(ctx, err, msgs.MsgPostgresCheckpointStoreFailed)
+	}
+	return nil
+}
+
+func (p *postgresCheckpointStore) close() {
+	_ = p.db.Close()
+}
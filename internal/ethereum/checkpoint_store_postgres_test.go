@@ -0,0 +1,120 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestPostgresCheckpointStore(t *testing.T) (*postgresCheckpointStore, sqlmock.Sqlmock) {
+	db, mdb, err := sqlmock.New()
+	assert.NoError(t, err)
+	mdb.ExpectExec("CREATE TABLE IF NOT EXISTS checkpoints").WillReturnResult(sqlmock.NewResult(0, 0))
+	p, err := newPostgresCheckpointStoreDB(context.Background(), db, "checkpoints", time.Second)
+	assert.NoError(t, err)
+	return p, mdb
+}
+
+func TestPostgresCheckpointStore(t *testing.T) {
+	p, mdb := newTestPostgresCheckpointStore(t)
+	assert.Equal(t, CheckpointStorePostgres, p.name())
+	ctx := context.Background()
+	lID := fftypes.NewUUID()
+
+	mdb.ExpectQuery("SELECT checkpoint FROM checkpoints WHERE listener_id").WithArgs(lID.String()).
+		WillReturnRows(sqlmock.NewRows([]string{"checkpoint"}))
+	cp, err := p.get(ctx, lID)
+	assert.NoError(t, err)
+	assert.Nil(t, cp)
+
+	mdb.ExpectExec("INSERT INTO checkpoints .* ON CONFLICT \\(listener_id\\) DO UPDATE").
+		WithArgs(lID.String(), `{"block":12345,"transactionIndex":1,"logIndex":2}`, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	err = p.put(ctx, lID, &listenerCheckpoint{Block: 12345, TransactionIndex: 1, LogIndex: 2})
+	assert.NoError(t, err)
+
+	mdb.ExpectQuery("SELECT checkpoint FROM checkpoints").WithArgs(lID.String()).
+		WillReturnRows(sqlmock.NewRows([]string{"checkpoint"}).AddRow(`{"block":12345,"transactionIndex":1,"logIndex":2}`))
+	cp, err = p.get(ctx, lID)
+	assert.NoError(t, err)
+	assert.Equal(t, &listenerCheckpoint{Block: 12345, TransactionIndex: 1, LogIndex: 2}, cp)
+
+	mdb.ExpectExec("DELETE FROM checkpoints WHERE listener_id").WithArgs(lID.String()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	err = p.remove(ctx, lID)
+	assert.NoError(t, err)
+
+	mdb.ExpectClose()
+	p.close()
+	assert.NoError(t, mdb.ExpectationsWereMet())
+}
+
+func TestPostgresCheckpointStoreErrors(t *testing.T) {
+	p, mdb := newTestPostgresCheckpointStore(t)
+	ctx := context.Background()
+	lID := fftypes.NewUUID()
+
+	mdb.ExpectQuery("SELECT").WillReturnError(fmt.Errorf("pop"))
+	_, err := p.get(ctx, lID)
+	assert.Regexp(t, "FF23141.*pop", err)
+
+	mdb.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"checkpoint"}).AddRow(`!json`))
+	_, err = p.get(ctx, lID)
+	assert.Regexp(t, "FF23135", err)
+
+	mdb.ExpectExec("INSERT").WillReturnError(fmt.Errorf("pop"))
+	err = p.put(ctx, lID, &listenerCheckpoint{})
+	assert.Regexp(t, "FF23141.*pop", err)
+
+	mdb.ExpectExec("DELETE").WillReturnError(fmt.Errorf("pop"))
+	err = p.remove(ctx, lID)
+	assert.Regexp(t, "FF23141.*pop", err)
+	assert.NoError(t, mdb.ExpectationsWereMet())
+}
+
+func TestPostgresCheckpointStoreCreateTableFail(t *testing.T) {
+	db, mdb, err := sqlmock.New()
+	assert.NoError(t, err)
+	mdb.ExpectExec("CREATE TABLE").WillReturnError(fmt.Errorf("pop"))
+	mdb.ExpectClose()
+	_, err = newPostgresCheckpointStoreDB(context.Background(), db, "checkpoints", time.Second)
+	assert.Regexp(t, "FF23141.*pop", err)
+	assert.NoError(t, mdb.ExpectationsWereMet())
+}
+
+func TestNewPostgresCheckpointStoreUnreachable(t *testing.T) {
+	config.RootConfigReset()
+	conf := config.RootSection("postgres_test")
+	InitConfig(conf)
+	postgresConf := conf.SubSection(CheckpointsPostgresConfig)
+	postgresConf.Set(CheckpointsPostgresURL, "postgres://localhost:1/db?sslmode=disable&connect_timeout=1")
+	postgresConf.Set(CheckpointsPostgresTable, "myschema.checkpoints")
+	_, err := newPostgresCheckpointStore(context.Background(), postgresConf, time.Second)
+	assert.Regexp(t, "FF23141", err)
+
+	postgresConf.Set(CheckpointsPostgresURL, "postgres://localhost:%zz")
+	_, err = newPostgresCheckpointStore(context.Background(), postgresConf, time.Second)
+	assert.Regexp(t, "FF23141", err)
+}
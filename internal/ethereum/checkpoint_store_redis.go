@@ -0,0 +1,105 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftls"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/redis/go-redis/v9"
+)
+
+// redisCheckpointStore holds each checkpoint as a JSON string value, under a key of the listener ID
+type redisCheckpointStore struct {
+	client    *redis.Client
+	keyPrefix string
+}
+
+func newRedisCheckpointStore(ctx context.Context, conf config.Section, requestTimeout time.Duration) (*redisCheckpointStore, error) {
+	redisURL := conf.GetString(CheckpointsRedisURL)
+	options, err := redis.ParseURL(redisURL)
+	if err != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidRedisURL, redisURL)
+	}
+	if options.Password == "" {
+		options.Username = conf.GetString(CheckpointsRedisUsername)
+		options.Password = conf.GetString(CheckpointsRedisPassword)
+	}
+	tlsConfig, err := fftls.ConstructTLSConfig(ctx, conf.SubSection("tls"), fftls.ClientType)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig != nil {
+		if tlsConfig.ServerName == "" && options.TLSConfig != nil {
+			tlsConfig.ServerName = options.TLSConfig.ServerName
+		}
+		options.TLSConfig = tlsConfig
+	}
+	options.DialTimeout = requestTimeout
+	options.ReadTimeout = requestTimeout
+	options.WriteTimeout = requestTimeout
+	options.DisableIdentity = true
+	return &redisCheckpointStore{
+		client:    redis.NewClient(options),
+		keyPrefix: conf.GetString(CheckpointsRedisKeyPrefix),
+	}, nil
+}
+
+func (r *redisCheckpointStore) name() string {
+	return CheckpointStoreRedis
+}
+
+func (r *redisCheckpointStore) get(ctx context.Context, listenerID *fftypes.UUID) (*listenerCheckpoint, error) {
+	value, err := r.client.Get(ctx, r.keyPrefix+listenerID.String()).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgRedisCommandFailed, "GET", err)
+	}
+	var checkpoint listenerCheckpoint
+	if err := json.Unmarshal(value, &checkpoint); err != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidStoredCheckpoint, listenerID, err)
+	}
+	return &checkpoint, nil
+}
+
+func (r *redisCheckpointStore) put(ctx context.Context, listenerID *fftypes.UUID, checkpoint *listenerCheckpoint) error {
+	b, _ := json.Marshal(checkpoint)
+	if err := r.client.Set(ctx, r.keyPrefix+listenerID.String(), b, 0).Err(); err != nil {
+		return i18n.NewError(ctx, msgs.MsgRedisCommandFailed, "SET", err)
+	}
+	return nil
+}
+
+func (r *redisCheckpointStore) remove(ctx context.Context, listenerID *fftypes.UUID) error {
+	if err := r.client.Del(ctx, r.keyPrefix+listenerID.String()).Err(); err != nil {
+		return i18n.NewError(ctx, msgs.MsgRedisCommandFailed, "DEL", err)
+	}
+	return nil
+}
+
+func (r *redisCheckpointStore) close() {
+	_ = r.client.Close()
+}
@@ -0,0 +1,144 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftls"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRedisCheckpointStore(t *testing.T, redisURL string, confFns ...func(conf config.Section)) *redisCheckpointStore {
+	config.RootConfigReset()
+	conf := config.RootSection("redis_test")
+	InitConfig(conf)
+	redisConf := conf.SubSection(CheckpointsRedisConfig)
+	redisConf.Set(CheckpointsRedisURL, redisURL)
+	for _, fn := range confFns {
+		fn(redisConf)
+	}
+	r, err := newRedisCheckpointStore(context.Background(), redisConf, 5*time.Second)
+	assert.NoError(t, err)
+	return r
+}
+
+func TestRedisCheckpointStore(t *testing.T) {
+	s := miniredis.RunT(t)
+	s.RequireUserAuth("user1", "pass1")
+	r := newTestRedisCheckpointStore(t, "redis://user1:pass1@"+s.Addr()+"/2")
+	defer r.close()
+
+	ctx := context.Background()
+	lID := fftypes.NewUUID()
+	cp, err := r.get(ctx, lID)
+	assert.NoError(t, err)
+	assert.Nil(t, cp)
+
+	err = r.put(ctx, lID, &listenerCheckpoint{Block: 12345, TransactionIndex: 1, LogIndex: 2})
+	assert.NoError(t, err)
+	s.Select(2)
+	v, err := s.Get("evmconnect:checkpoint:" + lID.String())
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"block":12345,"transactionIndex":1,"logIndex":2}`, v)
+
+	cp, err = r.get(ctx, lID)
+	assert.NoError(t, err)
+	assert.Equal(t, &listenerCheckpoint{Block: 12345, TransactionIndex: 1, LogIndex: 2}, cp)
+
+	err = r.remove(ctx, lID)
+	assert.NoError(t, err)
+	assert.False(t, s.Exists("evmconnect:checkpoint:"+lID.String()))
+
+	_ = s.Set("evmconnect:checkpoint:"+lID.String(), "!json")
+	_, err = r.get(ctx, lID)
+	assert.Regexp(t, "FF23135", err)
+}
+
+func TestRedisCheckpointStoreConfigAuth(t *testing.T) {
+	s := miniredis.RunT(t)
+	s.RequireUserAuth("user1", "pass1")
+	r := newTestRedisCheckpointStore(t, "redis://"+s.Addr(), func(conf config.Section) {
+		conf.Set(CheckpointsRedisUsername, "user1")
+		conf.Set(CheckpointsRedisPassword, "pass1")
+	})
+	defer r.close()
+
+	lID := fftypes.NewUUID()
+	err := r.put(context.Background(), lID, &listenerCheckpoint{Block: 1})
+	assert.NoError(t, err)
+	assert.True(t, s.Exists("evmconnect:checkpoint:"+lID.String()))
+}
+
+func TestRedisCheckpointStoreErrors(t *testing.T) {
+	s := miniredis.RunT(t)
+	r := newTestRedisCheckpointStore(t, "redis://"+s.Addr())
+	defer r.close()
+	ctx := context.Background()
+	lID := fftypes.NewUUID()
+
+	s.SetError("LOADING server is loading")
+	_, err := r.get(ctx, lID)
+	assert.Regexp(t, "FF23138.*GET.*LOADING", err)
+	err = r.put(ctx, lID, &listenerCheckpoint{})
+	assert.Regexp(t, "FF23138.*SET", err)
+	err = r.remove(ctx, lID)
+	assert.Regexp(t, "FF23138.*DEL", err)
+}
+
+func TestRedisCheckpointStoreTLS(t *testing.T) {
+	s := miniredis.RunT(t)
+	r := newTestRedisCheckpointStore(t, "rediss://"+s.Addr())
+	defer r.close()
+	assert.Equal(t, "127.0.0.1", r.client.Options().TLSConfig.ServerName)
+	// The server does not speak TLS
+	_, err := r.get(context.Background(), fftypes.NewUUID())
+	assert.Regexp(t, "FF23138", err)
+}
+
+func TestRedisCheckpointStoreTLSConfig(t *testing.T) {
+	r := newTestRedisCheckpointStore(t, "rediss://redis.example.com", func(conf config.Section) {
+		conf.SubSection("tls").Set(fftls.HTTPConfTLSEnabled, true)
+	})
+	defer r.close()
+	assert.Equal(t, "redis.example.com", r.client.Options().TLSConfig.ServerName)
+}
+
+func TestNewRedisCheckpointStoreFail(t *testing.T) {
+	config.RootConfigReset()
+	conf := config.RootSection("redis_test")
+	InitConfig(conf)
+	redisConf := conf.SubSection(CheckpointsRedisConfig)
+
+	for _, u := range []string{"", "http://localhost:6379", "redis://localhost:6379/notanumber"} {
+		redisConf.Set(CheckpointsRedisURL, u)
+		_, err := newRedisCheckpointStore(context.Background(), redisConf, time.Second)
+		assert.Regexp(t, "FF23136", err)
+	}
+
+	redisConf.Set(CheckpointsRedisURL, "redis://localhost")
+	tlsConf := redisConf.SubSection("tls")
+	tlsConf.Set(fftls.HTTPConfTLSEnabled, true)
+	tlsConf.Set(fftls.HTTPConfTLSCAFile, "!!!badness")
+	_, err := newRedisCheckpointStore(context.Background(), redisConf, time.Second)
+	assert.Regexp(t, "FF00153", err)
+}
@@ -0,0 +1,222 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+	"github.com/stretchr/testify/assert"
+)
+
+type testCheckpointStore struct {
+	mux         sync.Mutex
+	checkpoints map[fftypes.UUID]*listenerCheckpoint
+	puts        int
+	err         error
+	closed      bool
+}
+
+func newTestCheckpointStore() *testCheckpointStore {
+	return &testCheckpointStore{checkpoints: make(map[fftypes.UUID]*listenerCheckpoint)}
+}
+
+func (ts *testCheckpointStore) name() string {
+	return "test"
+}
+
+func (ts *testCheckpointStore) get(_ context.Context, listenerID *fftypes.UUID) (*listenerCheckpoint, error) {
+	ts.mux.Lock()
+	defer ts.mux.Unlock()
+	return ts.checkpoints[*listenerID], ts.err
+}
+
+func (ts *testCheckpointStore) put(_ context.Context, listenerID *fftypes.UUID, checkpoint *listenerCheckpoint) error {
+	ts.mux.Lock()
+	defer ts.mux.Unlock()
+	if ts.err != nil {
+		return ts.err
+	}
+	ts.puts++
+	ts.checkpoints[*listenerID] = checkpoint
+	return nil
+}
+
+func (ts *testCheckpointStore) remove(_ context.Context, listenerID *fftypes.UUID) error {
+	ts.mux.Lock()
+	defer ts.mux.Unlock()
+	delete(ts.checkpoints, *listenerID)
+	return ts.err
+}
+
+func (ts *testCheckpointStore) close() {
+	ts.closed = true
+}
+
+func TestCheckpointStoreListenerLifecycle(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+	mockStreamLoopEmpty(mRPC)
+	store := newTestCheckpointStore()
+	c.checkpointStore = store
+
+	sContext, sCancel := context.WithCancel(context.Background())
+	defer sCancel()
+	sID := fftypes.NewUUID()
+	lID := fftypes.NewUUID()
+	_, _, err := c.EventStreamStart(ctx, &ffcapi.EventStreamStartRequest{
+		ID:            sID,
+		StreamContext: sContext,
+		EventStream:   make(chan *ffcapi.ListenerEvent),
+		BlockListener: make(chan *ffcapi.BlockHashEvent),
+	})
+	assert.NoError(t, err)
+
+	// The stored checkpoint is ahead of the one passed by the transaction manager
+	store.checkpoints[*lID] = &listenerCheckpoint{Block: testHighBlock, TransactionIndex: -1, LogIndex: -1}
+	_, _, err = c.EventListenerAdd(ctx, &ffcapi.EventListenerAddRequest{
+		StreamID:   sID,
+		ListenerID: lID,
+		EventListenerOptions: ffcapi.EventListenerOptions{
+			FromBlock: "0",
+			Filters:   []fftypes.JSONAny{*fftypes.JSONAnyPtr(`{"event":` + abiTransferEvent + `}`)},
+			Options:   fftypes.JSONAnyPtr(`{}`),
+		},
+		Checkpoint: &listenerCheckpoint{Block: 100, TransactionIndex: -1, LogIndex: -1},
+	})
+	assert.NoError(t, err)
+
+	// The checkpoint returned to the transaction manager is stored, unless it has not changed
+	for i := 0; i < 2; i++ {
+		res, _, err := c.EventListenerHWM(ctx, &ffcapi.EventListenerHWMRequest{StreamID: sID, ListenerID: lID})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(testHighBlock), res.Checkpoint.(*listenerCheckpoint).Block)
+	}
+	assert.Equal(t, 1, store.puts)
+
+	_, _, err = c.EventListenerRemove(ctx, &ffcapi.EventListenerRemoveRequest{StreamID: sID, ListenerID: lID})
+	assert.NoError(t, err)
+	assert.Empty(t, store.checkpoints)
+}
+
+func TestStoredCheckpoint(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t)
+	defer done()
+	lID := fftypes.NewUUID()
+	passed := &listenerCheckpoint{Block: 200, TransactionIndex: 1, LogIndex: 1}
+
+	cp, err := c.storedCheckpoint(ctx, lID, passed)
+	assert.NoError(t, err)
+	assert.Equal(t, passed, cp)
+
+	store := newTestCheckpointStore()
+	c.checkpointStore = store
+	cp, err = c.storedCheckpoint(ctx, lID, passed)
+	assert.NoError(t, err)
+	assert.Equal(t, passed, cp)
+
+	// An older stored checkpoint is not used
+	store.checkpoints[*lID] = &listenerCheckpoint{Block: 100, TransactionIndex: -1, LogIndex: -1}
+	cp, err = c.storedCheckpoint(ctx, lID, passed)
+	assert.NoError(t, err)
+	assert.Equal(t, passed, cp)
+
+	cp, err = c.storedCheckpoint(ctx, lID, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100), cp.Block)
+
+	store.err = fmt.Errorf("pop")
+	_, err = c.storedCheckpoint(ctx, lID, passed)
+	assert.Regexp(t, "pop", err)
+}
+
+func TestCheckpointStoreFailuresLogged(t *testing.T) {
+	l, _, cancel := newTestListener(t, false)
+	defer cancel()
+	store := newTestCheckpointStore()
+	store.err = fmt.Errorf("pop")
+	l.c.checkpointStore = store
+
+	l.storeCheckpoint(context.Background(), l.getHWMCheckpoint())
+	assert.Nil(t, l.storedCheckpoint)
+	l.c.removeStoredCheckpoint(context.Background(), l.id)
+}
+
+func TestAddListenerStoredCheckpointFail(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+	mockStreamLoopEmpty(mRPC)
+	store := newTestCheckpointStore()
+	store.err = fmt.Errorf("pop")
+	c.checkpointStore = store
+
+	es := &eventStream{id: fftypes.NewUUID(), ctx: ctx, c: c, listeners: make(map[fftypes.UUID]*listener)}
+	_, err := es.addEventListener(ctx, &ffcapi.EventListenerAddRequest{ListenerID: fftypes.NewUUID()})
+	assert.Regexp(t, "pop", err)
+}
+
+func TestNewCheckpointStore(t *testing.T) {
+	config.RootConfigReset()
+	conf := config.RootSection("checkpoints_test")
+	InitConfig(conf)
+
+	store, err := newCheckpointStore(context.Background(), conf)
+	assert.NoError(t, err)
+	assert.Nil(t, store)
+
+	conf.Set(CheckpointsStore, "Redis")
+	conf.SubSection(CheckpointsRedisConfig).Set(CheckpointsRedisURL, "redis://localhost:6379")
+	store, err = newCheckpointStore(context.Background(), conf)
+	assert.NoError(t, err)
+	assert.Equal(t, CheckpointStoreRedis, store.name())
+
+	conf.Set(CheckpointsStore, "postgres")
+	conf.SubSection(CheckpointsPostgresConfig).Set(CheckpointsPostgresTable, "bad table")
+	_, err = newCheckpointStore(context.Background(), conf)
+	assert.Regexp(t, "FF23140", err)
+
+	conf.Set(CheckpointsStore, "wrong")
+	_, err = newCheckpointStore(context.Background(), conf)
+	assert.Regexp(t, "FF23134.*wrong", err)
+}
+
+func TestNewConnectorCheckpointStoreFail(t *testing.T) {
+	config.RootConfigReset()
+	conf := config.RootSection("checkpoints_test")
+	InitConfig(conf)
+	conf.Set("url", "http://localhost:8545")
+	conf.Set(CheckpointsStore, "wrong")
+	_, err := NewEthereumConnector(context.Background(), conf)
+	assert.Regexp(t, "FF23134", err)
+}
+
+func TestNewConnectorAuditLogFailClosesCheckpointStore(t *testing.T) {
+	config.RootConfigReset()
+	conf := config.RootSection("checkpoints_test")
+	InitConfig(conf)
+	conf.Set("url", "http://localhost:8545")
+	conf.Set(CheckpointsStore, CheckpointStoreRedis)
+	conf.SubSection(CheckpointsRedisConfig).Set(CheckpointsRedisURL, "redis://localhost:6379")
+	conf.Set(AuditLogFile, t.TempDir()+"/missing/audit.log")
+	_, err := NewEthereumConnector(context.Background(), conf)
+	assert.Regexp(t, "FF23120", err)
+}
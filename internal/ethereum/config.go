@@ -18,20 +18,39 @@ package ethereum
 
 import (
 	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/fftls"
 	"github.com/hyperledger/firefly-common/pkg/wsclient"
 )
 
 const (
 	ConfigGasEstimationFactor   = "gasEstimationFactor"
+	GasEstimationSpoofBalance   = "gasEstimationSpoofBalance"
 	ConfigDataFormat            = "dataFormat"
+	ConfigNumberFormat          = "numberFormat"
+	JSONNumbersStrict           = "jsonNumbers.strict"
+	JSONNumbersEventStreams     = "jsonNumbers.eventStreams"
 	BlockPollingInterval        = "blockPollingInterval"
 	BlockCacheSize              = "blockCacheSize"
+	CanonicalChainDepth         = "canonicalChainDepth"
 	EventsCatchupPageSize       = "events.catchupPageSize"
 	EventsCatchupThreshold      = "events.catchupThreshold"
 	EventsCatchupDownscaleRegex = "events.catchupDownscaleRegex"
 	EventsCheckpointBlockGap    = "events.checkpointBlockGap"
 	EventsBlockTimestamps       = "events.blockTimestamps"
 	EventsFilterPollingInterval = "events.filterPollingInterval"
+	EventsPollingMode           = "events.pollingMode"
+	EventsBloomScreening        = "events.bloomScreening.enabled"
+	EventsBloomScreeningMaxSkip = "events.bloomScreening.maxSkip"
+	EventsLogVerificationRate   = "events.logVerification.sampleRate"
+	EventsLogVerificationFail   = "events.logVerification.failOnMismatch"
+	EventsQuarantineAttempts    = "events.quarantine.maxAttempts"
+	EventsDeadLetterDirectory   = "events.deadLetter.directory"
+	EventsDeadLetterTimeout     = "events.deadLetter.deliveryTimeout"
+	EventsDeadLetterAttempts    = "events.deadLetter.maxAttempts"
+	EventsDeadLetterInitDelay   = "events.deadLetter.retry.initialDelay"
+	EventsDeadLetterMaxDelay    = "events.deadLetter.retry.maxDelay"
+	EventsDeadLetterFactor      = "events.deadLetter.retry.factor"
 	RetryInitDelay              = "queryLoopRetry.initialDelay"
 	RetryMaxDelay               = "queryLoopRetry.maxDelay"
 	RetryFactor                 = "queryLoopRetry.factor"
@@ -40,11 +59,187 @@ const (
 	DeprecatedRetryMaxDelay  = "retry.maxDelay"
 	DeprecatedRetryFactor    = "retry.factor"
 
-	MaxConcurrentRequests   = "maxConcurrentRequests"
-	TxCacheSize             = "txCacheSize"
-	HederaCompatibilityMode = "hederaCompatibilityMode"
-	TraceTXForRevertReason  = "traceTXForRevertReason"
-	WebSocketsEnabled       = "ws.enabled"
+	MaxConcurrentRequests             = "maxConcurrentRequests"
+	TxCacheSize                       = "txCacheSize"
+	HederaCompatibilityMode           = "hederaCompatibilityMode"
+	TraceTXForRevertReason            = "traceTXForRevertReason"
+	SendDeduplicationWindow           = "sendDeduplicationWindow"
+	WebSocketsEnabled                 = "ws.enabled"
+	ReadEndpointConfig                = "read"
+	ReadStickyWindow                  = "read.stickyWindow"
+	ReadMaxLagBlocks                  = "read.maxLagBlocks"
+	ReadLagCheckInterval              = "read.lagCheckInterval"
+	ReadHedgingEnabled                = "read.hedging.enabled"
+	ReadHedgingPercentile             = "read.hedging.percentile"
+	ReadHedgingMinDelay               = "read.hedging.minDelay"
+	ProxyResolutionEnabled            = "proxyResolution.enabled"
+	ProxyResolutionCacheTTL           = "proxyResolution.cacheTTL"
+	ProxyResolutionConfig             = "proxyResolution"
+	ProxyResolutionABIs               = "abis"
+	ProxyResolutionABIsImplementation = "implementation"
+	ProxyResolutionABIsABI            = "abi"
+	GasPriceSmoothing                 = "gasPriceSmoothing.enabled"
+	GasPriceSmoothingAlpha            = "gasPriceSmoothing.alpha"
+	GasPriceSpikeCap                  = "gasPriceSmoothing.spikeCap"
+	GasPriceSuggestions               = "gasPriceSuggestions.enabled"
+	GasPriceFeeHistory                = "gasPriceSuggestions.feeHistoryBlocks"
+	BaseFeeWindowBlocks               = "baseFee.windowBlocks"
+	BlockTransactionsPagingThreshold  = "blockTransactions.pagingThreshold"
+	BlockTransactionsBatchSize        = "blockTransactions.batchSize"
+	BlobGasEnabled                    = "blobGas.enabled"
+	BlobGasTargetPerBlock             = "blobGas.targetPerBlock"
+	BlobGasMaxPerBlock                = "blobGas.maxPerBlock"
+	BlobGasUpdateFraction             = "blobGas.updateFraction"
+	FeeCurrencyAddress                = "feeCurrency.address"
+	FeeBumpPriceBump                  = "feeBump.priceBump"
+	FeeBumpBlobPriceBump              = "feeBump.blobPriceBump"
+	FeeBumpUrgentAfter                = "feeBump.urgentAfter"
+	MinGasPriceValue                  = "minGasPrice.value"
+	MinGasPriceLearn                  = "minGasPrice.learn"
+	ZeroGasAutoDetect                 = "zeroGas.autoDetect"
+	ZeroGasGasLimit                   = "zeroGas.gasLimit"
+	ConfigReloadWatchFile             = "configReload.watchFile"
+
+	PolicyPreSignedVerifyChainID    = "policy.preSigned.verifyChainId"
+	PolicyPreSignedAllowUnprotected = "policy.preSigned.allowUnprotected"
+	PolicyPreSignedAllowedSigners   = "policy.preSigned.allowedSigners"
+	PolicyDestinationsAllowed       = "policy.destinations.allowed"
+	PolicyDestinationsDenied        = "policy.destinations.denied"
+	PolicyValueMaxPerTransaction    = "policy.value.maxPerTransaction"
+	PolicyValueMaxPerWindow         = "policy.value.maxPerWindow"
+	PolicyValueWindow               = "policy.value.window"
+	TxLimitsMaxSize                 = "txLimits.maxSize"
+	TxLimitsCheckBlockGasLimit      = "txLimits.checkBlockGasLimit"
+	PolicyConfig                    = "policy"
+	PolicyMethodsConfig             = "methods"
+	PolicyMethodsContract           = "contract"
+	PolicyMethodsSelectors          = "selectors"
+
+	AuditLogFile          = "auditLog.file"
+	AuditLogWebhookConfig = "auditLog.webhook"
+	AuditLogQueueSize     = "auditLog.queueSize"
+
+	NotificationsWebhookConfig   = "notifications.webhook"
+	NotificationsEvents          = "notifications.events"
+	NotificationsQueueSize       = "notifications.queueSize"
+	NotificationsStreamLagBlocks = "notifications.streamLagBlocks"
+	NotificationsReorgDepth      = "notifications.reorgDepth"
+
+	SinksKafkaConfig   = "sinks.kafka"
+	SinkKafkaClusterID = "clusterId"
+	SinkKafkaTopic     = "topic"
+	SinkEventStreams   = "eventStreams"
+	SinkConfirmations  = "confirmations"
+	SinkQueueSize      = "queueSize"
+
+	SinksNATSConfig        = "sinks.nats"
+	SinkNATSURL            = "url"
+	SinkNATSSubject        = "subject"
+	SinkNATSUsername       = "username"
+	SinkNATSPassword       = "password"
+	SinkNATSToken          = "token"
+	SinkNATSRequestTimeout = "requestTimeout"
+	SinkNATSBlocks         = "blocks"
+
+	CheckpointsStore            = "checkpoints.store"
+	CheckpointsRequestTimeout   = "checkpoints.requestTimeout"
+	CheckpointsRedisConfig      = "checkpoints.redis"
+	CheckpointsRedisURL         = "url"
+	CheckpointsRedisUsername    = "username"
+	CheckpointsRedisPassword    = "password"
+	CheckpointsRedisKeyPrefix   = "keyPrefix"
+	CheckpointsPostgresConfig   = "checkpoints.postgres"
+	CheckpointsPostgresURL      = "url"
+	CheckpointsPostgresTable    = "table"
+	CheckpointsPostgresMaxConns = "maxConns"
+
+	VerificationEndpointConfig = "verification"
+	VerificationMode           = "verification.mode"
+
+	FinalityEnabled          = "finality.enabled"
+	FinalityProfile          = "finality.profile"
+	FinalityCacheTTL         = "finality.cacheTTL"
+	FinalityL1Config         = "finality.l1"
+	FinalityL1RollupContract = "rollupContract"
+	FinalityL1Method         = "method"
+
+	L1InclusionEnabled          = "l1Inclusion.enabled"
+	L1InclusionProfile          = "l1Inclusion.profile"
+	L1InclusionCacheTTL         = "l1Inclusion.cacheTTL"
+	L1InclusionLookbackBlocks   = "l1Inclusion.lookbackBlocks"
+	L1InclusionL1Config         = "l1Inclusion.l1"
+	L1InclusionL1RollupContract = "rollupContract"
+	L1InclusionL1Event          = "event"
+	L1InclusionL1BlockTopic     = "l2BlockTopic"
+	L1InclusionL1StateRootTopic = "stateRootTopic"
+
+	ReceiptConfirmationsMax = "receiptConfirmations.max"
+
+	ReceiptStatusFallback = "receiptStatus.fallback"
+
+	// The credentials of the proxy set in proxy.url, of each of the primary, read and verification endpoints
+	EgressProxyUsername = "proxy.username"
+	EgressProxyPassword = "proxy.password"
+
+	SimulatorEnabled        = "simulator.enabled"
+	SimulatorCommand        = "simulator.command"
+	SimulatorArgs           = "simulator.args"
+	SimulatorPort           = "simulator.port"
+	SimulatorChainID        = "simulator.chainId"
+	SimulatorAccounts       = "simulator.accounts"
+	SimulatorMnemonic       = "simulator.mnemonic"
+	SimulatorBlockTime      = "simulator.blockTime"
+	SimulatorStartupTimeout = "simulator.startupTimeout"
+
+	RPCRecordingMode = "rpcRecording.mode"
+	RPCRecordingFile = "rpcRecording.file"
+
+	FaultInjectionEnabled            = "faultInjection.enabled"
+	FaultInjectionConfig             = "faultInjection"
+	FaultInjectionRules              = "rules"
+	FaultInjectionRulesMethods       = "methods"
+	FaultInjectionRulesLatency       = "latency"
+	FaultInjectionRulesErrorRate     = "errorRate"
+	FaultInjectionRulesErrorCode     = "errorCode"
+	FaultInjectionRulesErrorMessage  = "errorMessage"
+	FaultInjectionRulesDropRate      = "dropRate"
+	FaultInjectionRulesMalformedRate = "malformedRate"
+
+	CompressionResponses      = "compression.responses"
+	CompressionRequests       = "compression.requests"
+	CompressionRequestMinSize = "compression.requestMinSize"
+
+	CorrelationHeadersEnabled     = "correlation.headers.enabled"
+	CorrelationHeadersRequestID   = "correlation.headers.requestId"
+	CorrelationHeadersOperationID = "correlation.headers.operationId"
+
+	RateLimitHeadersEnabled      = "rateLimitHeaders.enabled"
+	RateLimitHeadersRemaining    = "rateLimitHeaders.remaining"
+	RateLimitHeadersReset        = "rateLimitHeaders.reset"
+	RateLimitHeadersMinRemaining = "rateLimitHeaders.minRemaining"
+	RateLimitHeadersMaxPause     = "rateLimitHeaders.maxPause"
+
+	ErrorDetailsEnabled = "errorDetails.enabled"
+
+	LegacyChainEnabled = "legacyChain.enabled"
+
+	CostAccountingEnabled             = "costAccounting.enabled"
+	CostAccountingComputeUnits        = "costAccounting.computeUnits"
+	CostAccountingDefaultComputeUnits = "costAccounting.defaultComputeUnits"
+
+	ContractMetadataReceipts = "contractMetadata.receipts"
+
+	GasReportReceipts = "gasReport.receipts"
+
+	ArbitrumRetryablesReceipts = "arbitrumRetryables.receipts"
+
+	LatencySLOEnabled       = "latencySLO.enabled"
+	LatencySLOPercentile    = "latencySLO.percentile"
+	LatencySLOWindow        = "latencySLO.window"
+	LatencySLODegradeAfter  = "latencySLO.degradeAfter"
+	LatencySLOSendTarget    = "latencySLO.send"
+	LatencySLOQueryTarget   = "latencySLO.query"
+	LatencySLOLogPollTarget = "latencySLO.logPoll"
 )
 
 const (
@@ -66,10 +261,27 @@ func InitConfig(conf config.Section) {
 	conf.AddKnownKey(WebSocketsEnabled, false)
 	conf.AddKnownKey(BlockCacheSize, 250)
 	conf.AddKnownKey(BlockPollingInterval, "1s")
+	conf.AddKnownKey(CanonicalChainDepth)
 	conf.AddKnownKey(ConfigDataFormat, "map")
+	conf.AddKnownKey(ConfigNumberFormat, NumberFormatDecimal)
+	conf.AddKnownKey(JSONNumbersStrict, false)
+	conf.AddKnownKey(JSONNumbersEventStreams)
 	conf.AddKnownKey(ConfigGasEstimationFactor, DefaultGasEstimationFactor)
+	conf.AddKnownKey(GasEstimationSpoofBalance, false)
 	conf.AddKnownKey(EventsBlockTimestamps, true)
 	conf.AddKnownKey(EventsFilterPollingInterval, "1s")
+	conf.AddKnownKey(EventsPollingMode, EventPollingModeFilters)
+	conf.AddKnownKey(EventsBloomScreening, false)
+	conf.AddKnownKey(EventsBloomScreeningMaxSkip, "1m")
+	conf.AddKnownKey(EventsLogVerificationRate, 0)
+	conf.AddKnownKey(EventsLogVerificationFail, false)
+	conf.AddKnownKey(EventsQuarantineAttempts, 0)
+	conf.AddKnownKey(EventsDeadLetterDirectory)
+	conf.AddKnownKey(EventsDeadLetterTimeout, "30s")
+	conf.AddKnownKey(EventsDeadLetterAttempts, 3)
+	conf.AddKnownKey(EventsDeadLetterInitDelay, "1s")
+	conf.AddKnownKey(EventsDeadLetterMaxDelay, "30s")
+	conf.AddKnownKey(EventsDeadLetterFactor, 2.0)
 	conf.AddKnownKey(EventsCatchupPageSize, DefaultCatchupPageSize)
 	conf.AddKnownKey(EventsCatchupThreshold, DefaultEventsCatchupThreshold)
 	conf.AddKnownKey(EventsCatchupDownscaleRegex, DefaultEventsCatchupDownscaleRegex)
@@ -84,4 +296,189 @@ func InitConfig(conf config.Section) {
 	conf.AddKnownKey(TxCacheSize, 250)
 	conf.AddKnownKey(HederaCompatibilityMode, false)
 	conf.AddKnownKey(TraceTXForRevertReason, false)
+	conf.AddKnownKey(SendDeduplicationWindow, 0)
+	conf.AddKnownKey(ReceiptConfirmationsMax, 100)
+	conf.AddKnownKey(ReceiptStatusFallback, ReceiptStatusFallbackGasUsed)
+	initEgressProxyConfig(conf)
+	ffresty.InitConfig(conf.SubSection(ReadEndpointConfig))
+	initEgressProxyConfig(conf.SubSection(ReadEndpointConfig))
+	conf.AddKnownKey(ReadStickyWindow, "1m")
+	conf.AddKnownKey(ReadMaxLagBlocks, 10)
+	conf.AddKnownKey(ReadLagCheckInterval, "5s")
+	conf.AddKnownKey(ReadHedgingEnabled, false)
+	conf.AddKnownKey(ReadHedgingPercentile, 95)
+	conf.AddKnownKey(ReadHedgingMinDelay, "100ms")
+	ffresty.InitConfig(conf.SubSection(VerificationEndpointConfig))
+	initEgressProxyConfig(conf.SubSection(VerificationEndpointConfig))
+	conf.AddKnownKey(VerificationMode, VerificationModeWarn)
+	conf.AddKnownKey(FinalityEnabled, false)
+	conf.AddKnownKey(FinalityProfile)
+	conf.AddKnownKey(FinalityCacheTTL, "10s")
+	finalityL1Conf := conf.SubSection(FinalityL1Config)
+	ffresty.InitConfig(finalityL1Conf)
+	initEgressProxyConfig(finalityL1Conf)
+	finalityL1Conf.AddKnownKey(FinalityL1RollupContract)
+	finalityL1Conf.AddKnownKey(FinalityL1Method)
+	conf.AddKnownKey(L1InclusionEnabled, false)
+	conf.AddKnownKey(L1InclusionProfile)
+	conf.AddKnownKey(L1InclusionCacheTTL, "30s")
+	conf.AddKnownKey(L1InclusionLookbackBlocks, 7200)
+	l1InclusionL1Conf := conf.SubSection(L1InclusionL1Config)
+	ffresty.InitConfig(l1InclusionL1Conf)
+	initEgressProxyConfig(l1InclusionL1Conf)
+	l1InclusionL1Conf.AddKnownKey(L1InclusionL1RollupContract)
+	l1InclusionL1Conf.AddKnownKey(L1InclusionL1Event)
+	l1InclusionL1Conf.AddKnownKey(L1InclusionL1BlockTopic, 0)
+	l1InclusionL1Conf.AddKnownKey(L1InclusionL1StateRootTopic, 0)
+	conf.AddKnownKey(SimulatorEnabled, false)
+	conf.AddKnownKey(SimulatorCommand, "anvil")
+	conf.AddKnownKey(SimulatorArgs)
+	conf.AddKnownKey(SimulatorPort, 0)
+	conf.AddKnownKey(SimulatorChainID, 31337)
+	conf.AddKnownKey(SimulatorAccounts, 10)
+	conf.AddKnownKey(SimulatorMnemonic)
+	conf.AddKnownKey(SimulatorBlockTime, "0s")
+	conf.AddKnownKey(SimulatorStartupTimeout, "30s")
+	conf.AddKnownKey(RPCRecordingMode)
+	conf.AddKnownKey(RPCRecordingFile)
+	conf.AddKnownKey(FaultInjectionEnabled, false)
+	faultInjectionRulesConfig(conf)
+	conf.AddKnownKey(ProxyResolutionEnabled, false)
+	conf.AddKnownKey(ProxyResolutionCacheTTL, "1m")
+	proxyABIsConfig(conf)
+	conf.AddKnownKey(GasPriceSmoothing, false)
+	conf.AddKnownKey(GasPriceSmoothingAlpha, 0.3)
+	conf.AddKnownKey(GasPriceSpikeCap, 2.0)
+	conf.AddKnownKey(GasPriceSuggestions, false)
+	conf.AddKnownKey(GasPriceFeeHistory, 20)
+	conf.AddKnownKey(BaseFeeWindowBlocks, 20)
+	conf.AddKnownKey(BlockTransactionsPagingThreshold, 500)
+	conf.AddKnownKey(BlockTransactionsBatchSize, 50)
+	conf.AddKnownKey(BlobGasEnabled, false)
+	conf.AddKnownKey(BlobGasTargetPerBlock, 786432)
+	conf.AddKnownKey(BlobGasMaxPerBlock, 1179648)
+	conf.AddKnownKey(BlobGasUpdateFraction, 5007716)
+	conf.AddKnownKey(FeeCurrencyAddress, "")
+	conf.AddKnownKey(FeeBumpPriceBump, 10)
+	conf.AddKnownKey(FeeBumpBlobPriceBump, 100)
+	conf.AddKnownKey(FeeBumpUrgentAfter, "5m")
+	conf.AddKnownKey(MinGasPriceValue, "")
+	conf.AddKnownKey(MinGasPriceLearn, true)
+	conf.AddKnownKey(ZeroGasAutoDetect, true)
+	conf.AddKnownKey(ZeroGasGasLimit, 10000000)
+	conf.AddKnownKey(ConfigReloadWatchFile, false)
+	conf.AddKnownKey(PolicyPreSignedVerifyChainID, true)
+	conf.AddKnownKey(PolicyPreSignedAllowUnprotected, false)
+	conf.AddKnownKey(PolicyPreSignedAllowedSigners)
+	conf.AddKnownKey(PolicyDestinationsAllowed)
+	conf.AddKnownKey(PolicyDestinationsDenied)
+	conf.AddKnownKey(PolicyValueMaxPerTransaction)
+	conf.AddKnownKey(PolicyValueMaxPerWindow)
+	conf.AddKnownKey(PolicyValueWindow, "24h")
+	conf.AddKnownKey(TxLimitsMaxSize, 131072)
+	conf.AddKnownKey(TxLimitsCheckBlockGasLimit, false)
+	policyMethodsConfig(conf)
+	conf.AddKnownKey(AuditLogFile)
+	ffresty.InitConfig(conf.SubSection(AuditLogWebhookConfig))
+	conf.AddKnownKey(AuditLogQueueSize, 1000)
+	ffresty.InitConfig(conf.SubSection(NotificationsWebhookConfig))
+	conf.AddKnownKey(NotificationsEvents)
+	conf.AddKnownKey(NotificationsQueueSize, 100)
+	conf.AddKnownKey(NotificationsStreamLagBlocks, 0)
+	conf.AddKnownKey(NotificationsReorgDepth, 0)
+	kafkaConf := conf.SubSection(SinksKafkaConfig)
+	ffresty.InitConfig(kafkaConf)
+	kafkaConf.AddKnownKey(SinkKafkaClusterID)
+	kafkaConf.AddKnownKey(SinkKafkaTopic)
+	sinkConfig(kafkaConf)
+	natsConf := conf.SubSection(SinksNATSConfig)
+	natsConf.AddKnownKey(SinkNATSURL)
+	natsConf.AddKnownKey(SinkNATSSubject, "evmconnect")
+	natsConf.AddKnownKey(SinkNATSUsername)
+	natsConf.AddKnownKey(SinkNATSPassword)
+	natsConf.AddKnownKey(SinkNATSToken)
+	natsConf.AddKnownKey(SinkNATSRequestTimeout, "10s")
+	natsConf.AddKnownKey(SinkNATSBlocks, false)
+	fftls.InitTLSConfig(natsConf.SubSection("tls"))
+	sinkConfig(natsConf)
+	conf.AddKnownKey(CheckpointsStore, CheckpointStoreFFTM)
+	conf.AddKnownKey(CheckpointsRequestTimeout, "5s")
+	redisConf := conf.SubSection(CheckpointsRedisConfig)
+	redisConf.AddKnownKey(CheckpointsRedisURL)
+	redisConf.AddKnownKey(CheckpointsRedisUsername)
+	redisConf.AddKnownKey(CheckpointsRedisPassword)
+	redisConf.AddKnownKey(CheckpointsRedisKeyPrefix, "evmconnect:checkpoint:")
+	fftls.InitTLSConfig(redisConf.SubSection("tls"))
+	postgresConf := conf.SubSection(CheckpointsPostgresConfig)
+	postgresConf.AddKnownKey(CheckpointsPostgresURL)
+	postgresConf.AddKnownKey(CheckpointsPostgresTable, "evmconnect_checkpoints")
+	postgresConf.AddKnownKey(CheckpointsPostgresMaxConns, 5)
+	conf.AddKnownKey(CompressionResponses, true)
+	conf.AddKnownKey(CompressionRequests, false)
+	conf.AddKnownKey(CompressionRequestMinSize, "1Kb")
+	conf.AddKnownKey(CorrelationHeadersEnabled, false)
+	conf.AddKnownKey(CorrelationHeadersRequestID, "X-Request-ID")
+	conf.AddKnownKey(CorrelationHeadersOperationID, "X-FireFly-Operation-ID")
+	conf.AddKnownKey(RateLimitHeadersEnabled, false)
+	conf.AddKnownKey(RateLimitHeadersRemaining, "X-RateLimit-Remaining")
+	conf.AddKnownKey(RateLimitHeadersReset, "X-RateLimit-Reset")
+	conf.AddKnownKey(RateLimitHeadersMinRemaining, 0)
+	conf.AddKnownKey(RateLimitHeadersMaxPause, "1m")
+	conf.AddKnownKey(ErrorDetailsEnabled, false)
+	conf.AddKnownKey(LegacyChainEnabled, false)
+	conf.AddKnownKey(CostAccountingEnabled, false)
+	conf.AddKnownKey(CostAccountingComputeUnits)
+	conf.AddKnownKey(CostAccountingDefaultComputeUnits, 20)
+	conf.AddKnownKey(ContractMetadataReceipts, false)
+	conf.AddKnownKey(GasReportReceipts, false)
+	conf.AddKnownKey(ArbitrumRetryablesReceipts, false)
+	conf.AddKnownKey(LatencySLOEnabled, false)
+	conf.AddKnownKey(LatencySLOPercentile, 95)
+	conf.AddKnownKey(LatencySLOWindow, 100)
+	conf.AddKnownKey(LatencySLODegradeAfter, "1m")
+	conf.AddKnownKey(LatencySLOSendTarget, "5s")
+	conf.AddKnownKey(LatencySLOQueryTarget, "5s")
+	conf.AddKnownKey(LatencySLOLogPollTarget, "10s")
+}
+
+// sinkConfig registers the keys that are common to all sinks
+func sinkConfig(sinkConf config.Section) {
+	sinkConf.AddKnownKey(SinkEventStreams)
+	sinkConf.AddKnownKey(SinkConfirmations, 0)
+	sinkConf.AddKnownKey(SinkQueueSize, 1000)
+}
+
+func initEgressProxyConfig(conf config.Section) {
+	conf.AddKnownKey(EgressProxyUsername)
+	conf.AddKnownKey(EgressProxyPassword)
+}
+
+// proxyABIsConfig returns the array of implementation contract ABIs, with its keys registered
+func proxyABIsConfig(conf config.Section) config.ArraySection {
+	abisConf := conf.SubSection(ProxyResolutionConfig).SubArray(ProxyResolutionABIs)
+	abisConf.AddKnownKey(ProxyResolutionABIsImplementation)
+	abisConf.AddKnownKey(ProxyResolutionABIsABI)
+	return abisConf
+}
+
+// faultInjectionRulesConfig returns the array of fault injection rules, with its keys registered
+func faultInjectionRulesConfig(conf config.Section) config.ArraySection {
+	rulesConf := conf.SubSection(FaultInjectionConfig).SubArray(FaultInjectionRules)
+	rulesConf.AddKnownKey(FaultInjectionRulesMethods)
+	rulesConf.AddKnownKey(FaultInjectionRulesLatency, "0s")
+	rulesConf.AddKnownKey(FaultInjectionRulesErrorRate, 0)
+	rulesConf.AddKnownKey(FaultInjectionRulesErrorCode, -32000)
+	rulesConf.AddKnownKey(FaultInjectionRulesErrorMessage, "injected fault")
+	rulesConf.AddKnownKey(FaultInjectionRulesDropRate, 0)
+	rulesConf.AddKnownKey(FaultInjectionRulesMalformedRate, 0)
+	return rulesConf
+}
+
+// policyMethodsConfig returns the array of method policies, with its keys registered. A new array section does not
+// know the keys of its entries, so this is used both to initialize the config and to read it.
+func policyMethodsConfig(conf config.Section) config.ArraySection {
+	methodsConf := conf.SubSection(PolicyConfig).SubArray(PolicyMethodsConfig)
+	methodsConf.AddKnownKey(PolicyMethodsContract)
+	methodsConf.AddKnownKey(PolicyMethodsSelectors)
+	return methodsConf
 }
@@ -0,0 +1,194 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+)
+
+// gasPolicy is the gas related configuration of the connector, which is replaced as a whole on reload
+type gasPolicy struct {
+	estimationFactor *big.Float
+	spoofBalance     bool
+	smoother         *gasPriceSmoother
+	suggestions      bool
+	suggestionBlocks int64
+	blobGas          *blobGasParams
+	feeCurrency      *ethtypes.Address0xHex
+	priceBump        int64
+	blobPriceBump    int64
+	urgentAfter      time.Duration
+	minGasPrice      *big.Int
+	learnMinGasPrice bool
+	zeroGasDetect    bool
+	zeroGasLimit     int64
+}
+
+// newGasPolicy validates and builds the gas policy from config. The moving average of any previous
+// policy is retained, so a reload does not reset the gas price smoothing.
+func newGasPolicy(ctx context.Context, conf config.Section, previous *gasPolicy) (*gasPolicy, error) {
+	if err := checkLegacyChainConfig(ctx, conf); err != nil {
+		return nil, err
+	}
+	gp := &gasPolicy{
+		estimationFactor: big.NewFloat(conf.GetFloat64(ConfigGasEstimationFactor)),
+		spoofBalance:     conf.GetBool(GasEstimationSpoofBalance),
+		suggestions:      conf.GetBool(GasPriceSuggestions),
+		suggestionBlocks: conf.GetInt64(GasPriceFeeHistory),
+		priceBump:        conf.GetInt64(FeeBumpPriceBump),
+		blobPriceBump:    conf.GetInt64(FeeBumpBlobPriceBump),
+		urgentAfter:      conf.GetDuration(FeeBumpUrgentAfter),
+		learnMinGasPrice: conf.GetBool(MinGasPriceLearn),
+		zeroGasDetect:    conf.GetBool(ZeroGasAutoDetect),
+		zeroGasLimit:     conf.GetInt64(ZeroGasGasLimit),
+	}
+	if gp.priceBump < 0 || gp.blobPriceBump < 0 {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidFeeBumpConfig, gp.priceBump, gp.blobPriceBump)
+	}
+	if conf.GetBool(GasPriceSmoothing) {
+		alpha, spikeCap := conf.GetFloat64(GasPriceSmoothingAlpha), conf.GetFloat64(GasPriceSpikeCap)
+		if alpha <= 0 || alpha > 1 || spikeCap < 1 {
+			return nil, i18n.NewError(ctx, msgs.MsgInvalidGasPriceSmoothing, alpha, spikeCap)
+		}
+		gp.smoother = newGasPriceSmoother(alpha, spikeCap)
+		if previous != nil && previous.smoother != nil {
+			gp.smoother.average = previous.smoother.currentAverage()
+		}
+	}
+	if conf.GetBool(BlobGasEnabled) {
+		gp.blobGas = &blobGasParams{
+			target:         conf.GetInt64(BlobGasTargetPerBlock),
+			max:            conf.GetInt64(BlobGasMaxPerBlock),
+			updateFraction: conf.GetInt64(BlobGasUpdateFraction),
+		}
+		if gp.blobGas.target <= 0 || gp.blobGas.updateFraction <= 0 || gp.blobGas.max < gp.blobGas.target {
+			return nil, i18n.NewError(ctx, msgs.MsgInvalidBlobGasConfig, gp.blobGas.target, gp.blobGas.updateFraction, gp.blobGas.max)
+		}
+	}
+	if minGasPrice := conf.GetString(MinGasPriceValue); minGasPrice != "" {
+		var ok bool
+		if gp.minGasPrice, ok = new(big.Int).SetString(minGasPrice, 0); !ok || gp.minGasPrice.Sign() < 0 {
+			return nil, i18n.NewError(ctx, msgs.MsgInvalidMinGasPrice, minGasPrice)
+		}
+	}
+	if feeCurrency := conf.GetString(FeeCurrencyAddress); feeCurrency != "" {
+		var err error
+		if gp.feeCurrency, err = ethtypes.NewAddress(feeCurrency); err != nil {
+			return nil, i18n.NewError(ctx, msgs.MsgInvalidFeeCurrency, feeCurrency, err)
+		}
+	}
+	return gp, nil
+}
+
+func (c *ethConnector) gas() *gasPolicy {
+	return c.gasPolicy.Load()
+}
+
+// ReloadConfig applies changes to the configuration of the JSON/RPC endpoints (including the
+// rate limiting and concurrency settings of each, and any fault injection), and the gas and transaction policies,
+// to the running connector.
+// Everything is validated before anything is applied, so an invalid config leaves the connector
+// running as before. Other configuration, including the websocket and event stream settings,
+// requires a restart.
+func (c *ethConnector) ReloadConfig(ctx context.Context, conf config.Section) error {
+	gp, err := newGasPolicy(ctx, conf, c.gas())
+	if err != nil {
+		return err
+	}
+	tp, err := newTxPolicy(ctx, conf)
+	if err != nil {
+		return err
+	}
+
+	clientOpts, err := newRPCClientOptions(ctx, conf)
+	if err != nil {
+		return err
+	}
+	primaryClient, err := newRPCClient(ctx, conf, clientOpts)
+	if err != nil {
+		return err
+	}
+	primaryClient = c.rpcRecording.wrap(primaryClient)
+
+	primaryScheduler := newRPCScheduler(conf, clientOpts)
+
+	// Where the read endpoint has been removed from the config, reads move to the primary endpoint
+	// and share its limits
+	readClient, readScheduler := primaryClient, primaryScheduler
+	readConf := conf.SubSection(ReadEndpointConfig)
+	if readConf.GetString(ffresty.HTTPConfigURL) != "" {
+		if c.readOnlyBackend == nil {
+			log.L(ctx).Warnf("The read endpoint added to the configuration will not be used until restart")
+		}
+		if readClient, err = newRPCClient(ctx, readConf, clientOpts); err != nil {
+			return err
+		}
+		readScheduler = newRPCScheduler(readConf, clientOpts)
+	}
+
+	if mb, ok := c.backend.(*managedBackend); ok {
+		mb.swap(primaryClient, primaryScheduler)
+	}
+	if mb, ok := c.readOnlyBackend.(*managedBackend); ok {
+		mb.swap(readClient, readScheduler)
+	}
+	c.gasPolicy.Store(gp)
+	c.txPolicy.Store(tp)
+	// The endpoint might now be a different node, so the chain ID, any learned minimum gas price, and whether
+	// it is a zero gas network, are checked again
+	c.ethChainID.Store(nil)
+	c.learnedMinGasPrice.Store(nil)
+	c.zeroGas.Store(nil)
+
+	snapshot := redactedConfig(conf)
+	c.configMux.Lock()
+	previous := c.configSnapshot
+	c.configSnapshot = snapshot
+	c.configMux.Unlock()
+	c.notifyEndpointChanges(ctx, previous, snapshot)
+
+	log.L(ctx).Infof("Reloaded connector configuration")
+	return nil
+}
+
+// notifyEndpointChanges raises a notification for each endpoint that a config reload has switched to a
+// different URL. The URLs are those of the redacted config, so credentials are not included.
+func (c *ethConnector) notifyEndpointChanges(ctx context.Context, previous, snapshot fftypes.JSONObject) {
+	for endpoint, key := range map[string]string{
+		"primary": ffresty.HTTPConfigURL,
+		"read":    ReadEndpointConfig + "." + ffresty.HTTPConfigURL,
+	} {
+		previousURL, newURL := previous.GetString(key), snapshot.GetString(key)
+		if previousURL != newURL {
+			c.notifier.notify(ctx, NotificationEndpointChanged, fftypes.JSONObject{
+				"endpoint": endpoint,
+				"previous": previousURL,
+				"url":      newURL,
+			}, "The %s endpoint was changed by a config reload", endpoint)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestReloadConnector(t *testing.T, readURL string) (*ethConnector, config.Section) {
+	config.RootConfigReset()
+	conf := config.RootSection("unittest")
+	InitConfig(conf)
+	conf.Set(ffresty.HTTPConfigURL, "http://localhost:8545")
+	conf.Set(ReadLagCheckInterval, "0")
+	conf.SubSection(ReadEndpointConfig).Set(ffresty.HTTPConfigURL, readURL)
+	conf.Set(GasPriceSmoothing, true)
+	cc, err := NewEthereumConnector(context.Background(), conf)
+	assert.NoError(t, err)
+	return cc.(*ethConnector), conf
+}
+
+func TestReloadConfig(t *testing.T) {
+	c, conf := newTestReloadConnector(t, "http://localhost:8546")
+	primaryBefore, _ := c.backend.(*managedBackend).current()
+	readBefore, _ := c.readOnlyBackend.(*managedBackend).current()
+	c.gas().smoother.sample(context.Background(), big.NewInt(100))
+	c.ethChainID.Store(big.NewInt(1337))
+	c.learnedMinGasPrice.Store(big.NewInt(1000))
+	c.zeroGas.Store(&zeroGasNetwork{detected: true})
+
+	conf.Set(ffresty.HTTPConfigURL, "http://localhost:9545")
+	conf.SubSection(ReadEndpointConfig).Set(ffresty.HTTPConfigURL, "http://localhost:9546")
+	conf.Set(ConfigGasEstimationFactor, 2.5)
+	conf.Set(GasPriceSuggestions, true)
+	conf.Set(ffresty.HTTPThrottleRequestsPerSecond, 10)
+	conf.Set(PolicyPreSignedAllowUnprotected, true)
+	err := c.ReloadConfig(context.Background(), conf)
+	assert.NoError(t, err)
+
+	primaryAfter, primaryScheduler := c.backend.(*managedBackend).current()
+	readAfter, readScheduler := c.readOnlyBackend.(*managedBackend).current()
+	assert.NotSame(t, primaryBefore, primaryAfter)
+	assert.NotSame(t, readBefore, readAfter)
+	assert.NotNil(t, primaryScheduler.limiter)
+	assert.Nil(t, readScheduler.limiter)
+	f, _ := c.gas().estimationFactor.Float64()
+	assert.Equal(t, 2.5, f)
+	assert.True(t, c.gas().suggestions)
+	assert.True(t, c.policy().allowUnprotected)
+	assert.Nil(t, c.ethChainID.Load())
+	assert.Nil(t, c.learnedMinGasPrice.Load())
+	assert.Nil(t, c.zeroGas.Load())
+	// The smoothing average carries over
+	assert.Equal(t, int64(100), c.gas().smoother.sample(context.Background(), big.NewInt(100)).Int64())
+	assert.Equal(t, "http://localhost:9545", c.Status(context.Background()).Config["url"])
+}
+
+func TestReloadConfigInvalidLeavesConfigUnchanged(t *testing.T) {
+	c, conf := newTestReloadConnector(t, "")
+	gpBefore := c.gas()
+
+	conf.Set(GasPriceSmoothingAlpha, 0)
+	conf.Set(ConfigGasEstimationFactor, 2.5)
+	err := c.ReloadConfig(context.Background(), conf)
+	assert.Regexp(t, "FF23078", err)
+	assert.Same(t, gpBefore, c.gas())
+
+	conf.Set(GasPriceSmoothingAlpha, 0.5)
+	conf.Set(PolicyPreSignedAllowedSigners, []string{"wrong"})
+	err = c.ReloadConfig(context.Background(), conf)
+	assert.Regexp(t, "FF23110", err)
+	assert.Same(t, gpBefore, c.gas())
+}
+
+func TestReloadConfigReadEndpointAddedOrRemoved(t *testing.T) {
+	c, conf := newTestReloadConnector(t, "")
+
+	// Adding a read endpoint requires a restart
+	conf.SubSection(ReadEndpointConfig).Set(ffresty.HTTPConfigURL, "http://localhost:8546")
+	err := c.ReloadConfig(context.Background(), conf)
+	assert.NoError(t, err)
+	assert.Nil(t, c.readOnlyBackend)
+
+	// Removing it moves reads to the primary endpoint config
+	c, conf = newTestReloadConnector(t, "http://localhost:8546")
+	conf.SubSection(ReadEndpointConfig).Set(ffresty.HTTPConfigURL, "")
+	err = c.ReloadConfig(context.Background(), conf)
+	assert.NoError(t, err)
+	primaryClient, primaryScheduler := c.backend.(*managedBackend).current()
+	readClient, readScheduler := c.readOnlyBackend.(*managedBackend).current()
+	assert.Same(t, primaryClient, readClient)
+	assert.Same(t, primaryScheduler, readScheduler)
+}
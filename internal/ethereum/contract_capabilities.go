@@ -0,0 +1,117 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+)
+
+const (
+	// See https://eips.ethereum.org/EIPS/eip-165
+	erc165InterfaceID       = "0x01ffc9a7"
+	erc165InvalidInterface  = "0xffffffff"
+	erc165SupportsInterface = "01ffc9a7" // supportsInterface(bytes4) selector
+	erc165GasLimit          = 30000
+)
+
+type ContractCapabilitiesRequest struct {
+	Address    string   `json:"address"`
+	Interfaces []string `json:"interfaces,omitempty"`
+}
+
+type ContractCapabilitiesResponse struct {
+	Address    string          `json:"address"`
+	IsContract bool            `json:"isContract"`
+	CodeSize   int             `json:"codeSize"`
+	ERC165     bool            `json:"erc165"`
+	Interfaces map[string]bool `json:"interfaces,omitempty"`
+}
+
+// ContractCapabilities checks there is code deployed at an address, and optionally probes
+// ERC-165 supportsInterface for each of the requested interface IDs
+func (c *ethConnector) ContractCapabilities(ctx context.Context, req *ContractCapabilitiesRequest) (*ContractCapabilitiesResponse, error) {
+	address, err := ethtypes.NewAddress(req.Address)
+	if err != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidContractAddress, req.Address, err)
+	}
+	interfaceIDs := make([]ethtypes.HexBytes0xPrefix, len(req.Interfaces))
+	for i, id := range req.Interfaces {
+		idBytes, err := hex.DecodeString(strings.TrimPrefix(id, "0x"))
+		if err != nil || len(idBytes) != 4 {
+			return nil, i18n.NewError(ctx, msgs.MsgInvalidInterfaceID, id)
+		}
+		interfaceIDs[i] = idBytes
+	}
+
+	var code ethtypes.HexBytes0xPrefix
+	if rpcErr := c.readBackend().CallRPC(ctx, &code, "eth_getCode", address, "latest"); rpcErr != nil {
+		return nil, rpcErr.Error()
+	}
+	res := &ContractCapabilitiesResponse{
+		Address:    address.String(),
+		IsContract: len(code) > 0,
+		CodeSize:   len(code),
+	}
+	if !res.IsContract || len(interfaceIDs) == 0 {
+		return res, nil
+	}
+
+	// ERC-165 detection requires the contract to return true for the ERC-165 interface
+	// itself, and false for 0xffffffff
+	supported, err := c.supportsInterface(ctx, address, ethtypes.MustNewHexBytes0xPrefix(erc165InterfaceID))
+	if err == nil && supported {
+		supported, err = c.supportsInterface(ctx, address, ethtypes.MustNewHexBytes0xPrefix(erc165InvalidInterface))
+		res.ERC165 = err == nil && !supported
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res.Interfaces = make(map[string]bool, len(interfaceIDs))
+	for _, id := range interfaceIDs {
+		res.Interfaces[id.String()] = false
+		if res.ERC165 {
+			if res.Interfaces[id.String()], err = c.supportsInterface(ctx, address, id); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return res, nil
+}
+
+// supportsInterface treats a revert, or any return data other than an ABI encoded bool, as not supported
+func (c *ethConnector) supportsInterface(ctx context.Context, address *ethtypes.Address0xHex, interfaceID ethtypes.HexBytes0xPrefix) (bool, error) {
+	callData, _ := hex.DecodeString(erc165SupportsInterface)
+	callData = append(callData, interfaceID...)
+	callData = append(callData, make([]byte, 28)...)
+	outputData, err := c.callContractIgnoringReverts(ctx, address, callData, ethtypes.NewHexInteger64(erc165GasLimit), "")
+	if err != nil || len(outputData) != 32 {
+		return false, err
+	}
+	for _, b := range outputData[0:31] {
+		if b != 0 {
+			return false, nil
+		}
+	}
+	return outputData[31] == 1, nil
+}
@@ -0,0 +1,200 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"testing"
+
+	"github.com/hyperledger/firefly-evmconnect/mocks/rpcbackendmocks"
+	"github.com/hyperledger/firefly-signer/pkg/ethsigner"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	sampleContractAddress = "0x4a8c8f1717570f9774652075e249ded38124d708"
+	abiTrue               = "0x0000000000000000000000000000000000000000000000000000000000000001"
+	abiFalse              = "0x0000000000000000000000000000000000000000000000000000000000000000"
+)
+
+func mockGetCode(mRPC *rpcbackendmocks.Backend, code string) *mock.Call {
+	return mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getCode", mock.Anything, "latest").
+		Run(func(args mock.Arguments) {
+			*(args[1].(*ethtypes.HexBytes0xPrefix)) = ethtypes.MustNewHexBytes0xPrefix(code)
+		}).
+		Return(nil)
+}
+
+func mockSupportsInterface(mRPC *rpcbackendmocks.Backend, interfaceID, result string) *mock.Call {
+	return mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_call",
+		mock.MatchedBy(func(tx *ethsigner.Transaction) bool {
+			return tx.Data.String() == "0x01ffc9a7"+interfaceID+"00000000000000000000000000000000000000000000000000000000" &&
+				tx.GasLimit.BigInt().Int64() == 30000
+		}), "latest").
+		Run(func(args mock.Arguments) {
+			*(args[1].(*ethtypes.HexBytes0xPrefix)) = ethtypes.MustNewHexBytes0xPrefix(result)
+		}).
+		Return(nil)
+}
+
+func TestContractCapabilitiesERC721(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mockGetCode(mRPC, "0x6080604052")
+	mockSupportsInterface(mRPC, "01ffc9a7", abiTrue)
+	mockSupportsInterface(mRPC, "ffffffff", abiFalse)
+	mockSupportsInterface(mRPC, "80ac58cd", abiTrue)
+	mockSupportsInterface(mRPC, "d9b67a26", abiFalse)
+
+	res, err := c.ContractCapabilities(ctx, &ContractCapabilitiesRequest{
+		Address:    sampleContractAddress,
+		Interfaces: []string{"0x80ac58cd", "d9b67a26"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &ContractCapabilitiesResponse{
+		Address:    sampleContractAddress,
+		IsContract: true,
+		CodeSize:   5,
+		ERC165:     true,
+		Interfaces: map[string]bool{
+			"0x80ac58cd": true,
+			"0xd9b67a26": false,
+		},
+	}, res)
+}
+
+func TestContractCapabilitiesNotERC165(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mockGetCode(mRPC, "0x6080604052")
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_call", mock.Anything, "latest").
+		Return(&rpcbackend.RPCError{Message: "execution reverted"})
+
+	res, err := c.ContractCapabilities(ctx, &ContractCapabilitiesRequest{
+		Address:    sampleContractAddress,
+		Interfaces: []string{"0x80ac58cd"},
+	})
+	assert.NoError(t, err)
+	assert.False(t, res.ERC165)
+	assert.Equal(t, map[string]bool{"0x80ac58cd": false}, res.Interfaces)
+}
+
+func TestContractCapabilitiesAlwaysTrue(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mockGetCode(mRPC, "0x6080604052")
+	mockSupportsInterface(mRPC, "01ffc9a7", abiTrue)
+	mockSupportsInterface(mRPC, "ffffffff", abiTrue)
+
+	res, err := c.ContractCapabilities(ctx, &ContractCapabilitiesRequest{
+		Address:    sampleContractAddress,
+		Interfaces: []string{"0x80ac58cd"},
+	})
+	assert.NoError(t, err)
+	assert.False(t, res.ERC165)
+	assert.False(t, res.Interfaces["0x80ac58cd"])
+}
+
+func TestContractCapabilitiesBadReturnData(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mockGetCode(mRPC, "0x6080604052")
+	mockSupportsInterface(mRPC, "01ffc9a7", "0x0100000000000000000000000000000000000000000000000000000000000001")
+	mockSupportsInterface(mRPC, "80ac58cd", "0x01")
+
+	res, err := c.ContractCapabilities(ctx, &ContractCapabilitiesRequest{
+		Address:    sampleContractAddress,
+		Interfaces: []string{"0x80ac58cd"},
+	})
+	assert.NoError(t, err)
+	assert.False(t, res.ERC165)
+
+	ok, err := c.supportsInterface(ctx, ethtypes.MustNewAddress(sampleContractAddress), ethtypes.MustNewHexBytes0xPrefix("0x80ac58cd"))
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestContractCapabilitiesNoCode(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mockGetCode(mRPC, "0x")
+
+	res, err := c.ContractCapabilities(ctx, &ContractCapabilitiesRequest{
+		Address:    sampleContractAddress,
+		Interfaces: []string{"0x80ac58cd"},
+	})
+	assert.NoError(t, err)
+	assert.False(t, res.IsContract)
+	assert.Nil(t, res.Interfaces)
+}
+
+func TestContractCapabilitiesGetCodeFail(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getCode", mock.Anything, "latest").
+		Return(&rpcbackend.RPCError{Message: "pop"})
+
+	_, err := c.ContractCapabilities(ctx, &ContractCapabilitiesRequest{Address: sampleContractAddress})
+	assert.Regexp(t, "pop", err)
+}
+
+func TestContractCapabilitiesCallFail(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mockGetCode(mRPC, "0x6080604052")
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_call", mock.Anything, "latest").
+		Return(&rpcbackend.RPCError{Message: "pop"}).Once()
+
+	_, err := c.ContractCapabilities(ctx, &ContractCapabilitiesRequest{
+		Address:    sampleContractAddress,
+		Interfaces: []string{"0x80ac58cd"},
+	})
+	assert.Regexp(t, "pop", err)
+
+	mockSupportsInterface(mRPC, "01ffc9a7", abiTrue)
+	mockSupportsInterface(mRPC, "ffffffff", abiFalse)
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_call", mock.Anything, "latest").
+		Return(&rpcbackend.RPCError{Message: "pop"}).Once()
+	_, err = c.ContractCapabilities(ctx, &ContractCapabilitiesRequest{
+		Address:    sampleContractAddress,
+		Interfaces: []string{"0x80ac58cd"},
+	})
+	assert.Regexp(t, "pop", err)
+}
+
+func TestContractCapabilitiesBadInputs(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t)
+	defer done()
+
+	_, err := c.ContractCapabilities(ctx, &ContractCapabilitiesRequest{Address: "wrong"})
+	assert.Regexp(t, "FF23060", err)
+
+	_, err = c.ContractCapabilities(ctx, &ContractCapabilitiesRequest{
+		Address:    sampleContractAddress,
+		Interfaces: []string{"0x1234"},
+	})
+	assert.Regexp(t, "FF23061", err)
+}
@@ -0,0 +1,242 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+)
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// ContractMetadata is the metadata the Solidity compiler appends to the runtime code of a contract, as a CBOR map
+// followed by its two byte length. The hashes identify the metadata JSON of the build that produced the code.
+type ContractMetadata struct {
+	Address      string                    `json:"address,omitempty"`
+	IPFS         string                    `json:"ipfs,omitempty"`
+	IPFSHash     ethtypes.HexBytes0xPrefix `json:"ipfsHash,omitempty"`
+	BZZR0        ethtypes.HexBytes0xPrefix `json:"bzzr0,omitempty"`
+	BZZR1        ethtypes.HexBytes0xPrefix `json:"bzzr1,omitempty"`
+	Solc         string                    `json:"solc,omitempty"`
+	Experimental bool                      `json:"experimental,omitempty"`
+	CBOR         ethtypes.HexBytes0xPrefix `json:"cbor"`
+	Verified     *bool                     `json:"verified,omitempty"`
+}
+
+// parseContractMetadata returns the metadata at the end of runtime code, or nil if the code does not end with a
+// valid CBOR map, such as code compiled without metadata or by another compiler
+func parseContractMetadata(code []byte) *ContractMetadata {
+	if len(code) < 2 {
+		return nil
+	}
+	length := int(binary.BigEndian.Uint16(code[len(code)-2:]))
+	if length == 0 || length > len(code)-2 {
+		return nil
+	}
+	cborData := code[len(code)-2-length : len(code)-2]
+	dec := &cborDecoder{data: cborData}
+	entries, ok := dec.decodeMap()
+	if !ok || dec.pos != len(cborData) {
+		return nil
+	}
+	md := &ContractMetadata{CBOR: cborData}
+	for key, value := range entries {
+		switch v := value.(type) {
+		case []byte:
+			switch key {
+			case "ipfs":
+				md.IPFSHash = v
+				md.IPFS = base58Encode(v)
+			case "bzzr0":
+				md.BZZR0 = v
+			case "bzzr1":
+				md.BZZR1 = v
+			case "solc":
+				if len(v) == 3 {
+					md.Solc = fmt.Sprintf("%d.%d.%d", v[0], v[1], v[2])
+				}
+			}
+		case string:
+			if key == "solc" {
+				// Pre-release builds of the compiler store the full version string
+				md.Solc = v
+			}
+		case bool:
+			if key == "experimental" {
+				md.Experimental = v
+			}
+		}
+	}
+	return md
+}
+
+// cborDecoder decodes the subset of CBOR used in contract metadata, which is a map of text keys to byte string,
+// text, unsigned integer and boolean values
+type cborDecoder struct {
+	data []byte
+	pos  int
+}
+
+func (d *cborDecoder) header() (major byte, arg uint64, ok bool) {
+	if d.pos >= len(d.data) {
+		return 0, 0, false
+	}
+	b := d.data[d.pos]
+	d.pos++
+	major, info := b>>5, b&0x1f
+	switch {
+	case info < 24:
+		return major, uint64(info), true
+	case info <= 27:
+		size := 1 << (info - 24)
+		if d.pos+size > len(d.data) {
+			return 0, 0, false
+		}
+		var buf [8]byte
+		copy(buf[8-size:], d.data[d.pos:d.pos+size])
+		d.pos += size
+		return major, binary.BigEndian.Uint64(buf[:]), true
+	default:
+		// Indefinite lengths are not used in contract metadata
+		return 0, 0, false
+	}
+}
+
+func (d *cborDecoder) decodeValue() (interface{}, bool) {
+	major, arg, ok := d.header()
+	if !ok {
+		return nil, false
+	}
+	switch major {
+	case 0:
+		return arg, true
+	case 2, 3:
+		if arg > uint64(len(d.data)-d.pos) {
+			return nil, false
+		}
+		value := d.data[d.pos : d.pos+int(arg)]
+		d.pos += int(arg)
+		if major == 3 {
+			return string(value), true
+		}
+		return value, true
+	case 7:
+		switch arg {
+		case 20:
+			return false, true
+		case 21:
+			return true, true
+		}
+	}
+	return nil, false
+}
+
+func (d *cborDecoder) decodeMap() (map[string]interface{}, bool) {
+	major, count, ok := d.header()
+	if !ok || major != 5 || count > uint64(len(d.data)) {
+		return nil, false
+	}
+	entries := make(map[string]interface{}, count)
+	for i := uint64(0); i < count; i++ {
+		key, ok := d.decodeValue()
+		keyString, isString := key.(string)
+		if !ok || !isString {
+			return nil, false
+		}
+		if entries[keyString], ok = d.decodeValue(); !ok {
+			return nil, false
+		}
+	}
+	return entries, true
+}
+
+// base58Encode encodes an IPFS multihash as a CIDv0
+func base58Encode(b []byte) string {
+	n := new(big.Int).SetBytes(b)
+	radix := big.NewInt(58)
+	mod := new(big.Int)
+	var out []byte
+	for n.Sign() > 0 {
+		n.DivMod(n, radix, mod)
+		out = append(out, base58Alphabet[mod.Int64()])
+	}
+	for _, v := range b {
+		if v != 0 {
+			break
+		}
+		out = append(out, base58Alphabet[0])
+	}
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return string(out)
+}
+
+// ContractMetadata returns the metadata appended to the code deployed at an address. If the IPFS CID of the metadata
+// JSON of a build is supplied, the response records whether the deployed code was produced by that build.
+func (c *ethConnector) ContractMetadata(ctx context.Context, addrString, expectedIPFS string) (*ContractMetadata, error) {
+	address, err := ethtypes.NewAddress(addrString)
+	if err != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidContractAddress, addrString, err)
+	}
+	md, err := c.contractMetadata(ctx, address, "")
+	if err != nil {
+		return nil, err
+	}
+	if expectedIPFS != "" {
+		verified := md.IPFS == expectedIPFS
+		md.Verified = &verified
+	}
+	return md, nil
+}
+
+func (c *ethConnector) contractMetadata(ctx context.Context, address *ethtypes.Address0xHex, txHash string) (*ContractMetadata, error) {
+	var code ethtypes.HexBytes0xPrefix
+	if rpcErr := c.readBackendForTx(txHash).CallRPC(ctx, &code, "eth_getCode", address, "latest"); rpcErr != nil {
+		return nil, rpcErr.Error()
+	}
+	if len(code) == 0 {
+		return nil, i18n.NewError(ctx, msgs.MsgContractCodeNotFound, address)
+	}
+	md := parseContractMetadata(code)
+	if md == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgContractMetadataNotFound, address)
+	}
+	md.Address = address.String()
+	return md, nil
+}
+
+// deployedContractMetadata returns the metadata of the contract created by a deployment for its receipt, or nil if
+// it cannot be obtained, which does not fail the receipt
+func (c *ethConnector) deployedContractMetadata(ctx context.Context, receipt *txReceiptJSONRPC) *ContractMetadata {
+	if !c.receiptContractMetadata || receipt.ContractAddress == nil {
+		return nil
+	}
+	md, err := c.contractMetadata(ctx, receipt.ContractAddress, receipt.TransactionHash.String())
+	if err != nil {
+		log.L(ctx).Warnf("No metadata for contract %s deployed in transaction %s: %s", receipt.ContractAddress, receipt.TransactionHash, err)
+		return nil
+	}
+	return md
+}
@@ -0,0 +1,188 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	testMetadataCID = "QmYwAPJzv5CZsnA625s3Xf2nemtYgPpHdWEz79ojWnPbdG"
+	// The runtime code of a contract compiled by solc 0.8.28, ending with {"ipfs": <multihash>, "solc": 0.8.28}
+	testMetadataCode = "0x6080604052600080fdfe" +
+		"a264697066735822" + "12209d6c2be50f706953479ab9df2ce3edca90b68053c00b3004b7f0accbe1e8eedf" +
+		"64736f6c63" + "43" + "00081c" + "0033"
+)
+
+func TestParseContractMetadata(t *testing.T) {
+	md := parseContractMetadata(ethtypes.MustNewHexBytes0xPrefix(testMetadataCode))
+	assert.Equal(t, testMetadataCID, md.IPFS)
+	assert.Equal(t, "0x12209d6c2be50f706953479ab9df2ce3edca90b68053c00b3004b7f0accbe1e8eedf", md.IPFSHash.String())
+	assert.Equal(t, "0.8.28", md.Solc)
+	assert.Len(t, md.CBOR, 0x33)
+
+	// Legacy swarm hashes, pre-release compiler versions, and the experimental flag
+	md = parseContractMetadata(ethtypes.MustNewHexBytes0xPrefix("0x00" +
+		"a4" + "65627a7a7230" + "5820" + "0000000000000000000000000000000000000000000000000000000000000001" +
+		"6562797a7231" + "41" + "ff" + // an unknown key is ignored
+		"64736f6c63" + "6a" + "302e342e32342d646576" + // "0.4.24-dev"
+		"6c6578706572696d656e74616c" + "f5" +
+		"004f"))
+	assert.Equal(t, "0x0000000000000000000000000000000000000000000000000000000000000001", md.BZZR0.String())
+	assert.Equal(t, "0.4.24-dev", md.Solc)
+	assert.True(t, md.Experimental)
+
+	// Values of other types, and compiler versions that are not three bytes, are ignored
+	md = parseContractMetadata(ethtypes.MustNewHexBytes0xPrefix("0xa3" + "65627a7a7231" + "4101" + "6473776172" + "19ffff" + "64736f6c63" + "4108" + "0018"))
+	assert.Equal(t, "0x01", md.BZZR1.String())
+	assert.Empty(t, md.Solc)
+
+	for _, invalid := range []string{
+		"0x",
+		"0x00",
+		"0x6080604052600080fd0000",      // no metadata length
+		"0x6080604052600080fd00ff",      // length longer than the code
+		"0x0102a1" + "0002",             // not a map
+		"0xa1" + "0102" + "0003",        // not a text key
+		"0xa1" + "6161" + "0003",        // missing value
+		"0xa1" + "6161" + "5f" + "0004", // indefinite length
+		"0xa1" + "6161" + "45" + "0005", // truncated bytes
+		"0xa1" + "6161" + "19" + "0005", // truncated integer
+		"0xa1" + "6161" + "f6" + "0004", // null
+		"0xa0" + "01" + "0002",          // trailing data
+		"0xbb" + "0003",                 // truncated map count
+		"0xbbffffffffffffffff" + "0009", // impossible map count
+	} {
+		assert.Nil(t, parseContractMetadata(ethtypes.MustNewHexBytes0xPrefix(invalid)), invalid)
+	}
+}
+
+func TestBase58Encode(t *testing.T) {
+	assert.Equal(t, "", base58Encode([]byte{}))
+	assert.Equal(t, "11", base58Encode([]byte{0, 0}))
+	assert.Equal(t, "1Ldp", base58Encode([]byte{0, 1, 2, 3}))
+}
+
+func TestContractMetadataRoute(t *testing.T) {
+	_, c, mRPC, done := newTestConnector(t)
+	defer done()
+	url, close := newTestRouteServer(t, c)
+	defer close()
+
+	address := "0xe1a078b9e2b145d0a7387f09277c6ae1d9470771"
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getCode", ethtypes.MustNewAddress(address), "latest").
+		Run(func(args mock.Arguments) {
+			*(args[1].(*ethtypes.HexBytes0xPrefix)) = ethtypes.MustNewHexBytes0xPrefix(testMetadataCode)
+		}).
+		Return(nil)
+
+	res, err := http.Get(url + "/contracts/" + address + "/metadata?ipfs=" + testMetadataCID)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var md ContractMetadata
+	err = json.NewDecoder(res.Body).Decode(&md)
+	assert.NoError(t, err)
+	assert.Equal(t, address, md.Address)
+	assert.Equal(t, testMetadataCID, md.IPFS)
+	assert.True(t, *md.Verified)
+
+	res, err = http.Get(url + "/contracts/" + address + "/metadata?ipfs=QmOther")
+	assert.NoError(t, err)
+	err = json.NewDecoder(res.Body).Decode(&md)
+	assert.NoError(t, err)
+	assert.False(t, *md.Verified)
+
+	res, err = http.Get(url + "/contracts/" + address + "/metadata")
+	assert.NoError(t, err)
+	md = ContractMetadata{}
+	err = json.NewDecoder(res.Body).Decode(&md)
+	assert.NoError(t, err)
+	assert.Nil(t, md.Verified)
+}
+
+func TestContractMetadataErrors(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	_, err := c.ContractMetadata(ctx, "wrong", "")
+	assert.Regexp(t, "FF23060", err)
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getCode", mock.Anything, "latest").
+		Return(&rpcbackend.RPCError{Message: "pop"}).Once()
+	_, err = c.ContractMetadata(ctx, "0xe1a078b9e2b145d0a7387f09277c6ae1d9470771", "")
+	assert.Regexp(t, "pop", err)
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getCode", mock.Anything, "latest").
+		Return(nil).Once()
+	_, err = c.ContractMetadata(ctx, "0xe1a078b9e2b145d0a7387f09277c6ae1d9470771", "")
+	assert.Regexp(t, "FF23162", err)
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getCode", mock.Anything, "latest").
+		Run(func(args mock.Arguments) {
+			*(args[1].(*ethtypes.HexBytes0xPrefix)) = ethtypes.MustNewHexBytes0xPrefix("0x6080604052600080fd")
+		}).
+		Return(nil).Once()
+	_, err = c.ContractMetadata(ctx, "0xe1a078b9e2b145d0a7387f09277c6ae1d9470771", "")
+	assert.Regexp(t, "FF23163", err)
+}
+
+func TestContractMetadataReceipt(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t, func(conf config.Section) {
+		conf.Set(ContractMetadataReceipts, true)
+	})
+	defer done()
+
+	contractAddress := ethtypes.MustNewAddress("0x5f906824E562B6a0F278D910D388728b833a43bB")
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionReceipt", mock.Anything).
+		Run(func(args mock.Arguments) {
+			err := json.Unmarshal([]byte(sampleJSONRPCReceipt), args[1])
+			assert.NoError(t, err)
+			(*args[1].(**txReceiptJSONRPC)).ContractAddress = contractAddress
+		}).
+		Return(nil)
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getCode", contractAddress, "latest").
+		Run(func(args mock.Arguments) {
+			*(args[1].(*ethtypes.HexBytes0xPrefix)) = ethtypes.MustNewHexBytes0xPrefix(testMetadataCode)
+		}).
+		Return(nil).Once()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getCode", contractAddress, "latest").
+		Return(&rpcbackend.RPCError{Message: "pop"}).Once()
+
+	var req ffcapi.TransactionReceiptRequest
+	err := json.Unmarshal([]byte(sampleGetReceipt), &req)
+	assert.NoError(t, err)
+	res, _, err := c.TransactionReceipt(ctx, &req)
+	assert.NoError(t, err)
+	var extraInfo receiptExtraInfo
+	err = json.Unmarshal(res.ExtraInfo.Bytes(), &extraInfo)
+	assert.NoError(t, err)
+	assert.Equal(t, testMetadataCID, extraInfo.ContractMetadata.IPFS)
+
+	// The receipt is returned without the metadata if it cannot be obtained
+	res, _, err = c.TransactionReceipt(ctx, &req)
+	assert.NoError(t, err)
+	assert.NotContains(t, res.ExtraInfo.String(), "contractMetadata")
+}
@@ -0,0 +1,104 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/txhandler/simple"
+)
+
+type correlationKey struct{}
+
+// correlation is the IDs that tie the log lines and JSON/RPC requests of the connector to those of FireFly and
+// the transaction manager, for correlating the logs of the services during incident response
+type correlation struct {
+	requestID   string // the ID the API server assigned to the HTTP request, or that FireFly passed in the request ID header
+	txID        string // the ID of the FFTM managed transaction, for requests from the simple transaction handler
+	operationID string // the FireFly operation ID, which FireFly submits as the transaction ID <namespace>:<operationID>
+}
+
+// correlationHeaders are the headers the IDs are sent to the node in, for providers that record them
+type correlationHeaders struct {
+	requestID   string
+	operationID string
+}
+
+// withCorrelationIDs adds the correlation IDs of a request to the context, including as log fields, so they are
+// on every log line written with the context. A context that already has them is returned as it is.
+func withCorrelationIDs(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(correlationKey{}).(*correlation); ok {
+		return ctx
+	}
+	cor := &correlation{}
+	if id, ok := ctx.Value(ffapi.CtxFFRequestIDKey{}).(string); ok {
+		cor.requestID = id
+	}
+	if rc, ok := ctx.(*simple.RunContext); ok && rc.TX != nil {
+		cor.txID = rc.TX.ID
+		cor.operationID = cor.txID[strings.LastIndex(cor.txID, ":")+1:]
+	}
+	ctx = context.WithValue(ctx, correlationKey{}, cor)
+	if _, ok := log.L(ctx).Data["httpreq"]; !ok && cor.requestID != "" {
+		ctx = log.WithLogField(ctx, "httpreq", cor.requestID)
+	}
+	if cor.operationID != "" {
+		ctx = log.WithLogField(ctx, "opid", cor.operationID)
+	}
+	return ctx
+}
+
+func correlationFor(ctx context.Context) *correlation {
+	if cor, ok := ctx.Value(correlationKey{}).(*correlation); ok {
+		return cor
+	}
+	return &correlation{}
+}
+
+// newCorrelationHeaders returns nil if the IDs are not to be sent to the node
+func newCorrelationHeaders(conf config.Section) *correlationHeaders {
+	if !conf.GetBool(CorrelationHeadersEnabled) {
+		return nil
+	}
+	return &correlationHeaders{
+		requestID:   conf.GetString(CorrelationHeadersRequestID),
+		operationID: conf.GetString(CorrelationHeadersOperationID),
+	}
+}
+
+// applyCorrelationHeaders sets the headers on each HTTP request of a client built by ffresty, from the
+// correlation IDs of the context of the request
+func applyCorrelationHeaders(client *resty.Client, headers *correlationHeaders) {
+	if headers == nil {
+		return
+	}
+	client.OnBeforeRequest(func(_ *resty.Client, req *resty.Request) error {
+		cor := correlationFor(req.Context())
+		if cor.requestID != "" && headers.requestID != "" {
+			req.SetHeader(headers.requestID, cor.requestID)
+		}
+		if cor.operationID != "" && headers.operationID != "" {
+			req.SetHeader(headers.operationID, cor.operationID)
+		}
+		return nil
+	})
+}
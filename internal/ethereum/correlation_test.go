@@ -0,0 +1,91 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCorrelationIDs(t *testing.T) {
+	ctx := withCorrelationIDs(context.Background())
+	assert.Empty(t, correlationFor(ctx))
+	assert.Empty(t, correlationFor(context.Background()))
+
+	// The request ID of the API server is already a log field
+	ctx = context.WithValue(context.Background(), ffapi.CtxFFRequestIDKey{}, "req1")
+	ctx = log.WithLogField(ctx, "httpreq", "req1")
+	ctx = withCorrelationIDs(testSendRunContext(ctx, "ns1:op1"))
+	assert.Equal(t, &correlation{requestID: "req1", txID: "ns1:op1", operationID: "op1"}, correlationFor(ctx))
+	assert.Equal(t, "req1", log.L(ctx).Data["httpreq"])
+	assert.Equal(t, "op1", log.L(ctx).Data["opid"])
+	assert.Equal(t, "ns1:op1", sendRequestID(ctx))
+	assert.Equal(t, ctx, withCorrelationIDs(ctx))
+
+	// Without a namespace, the transaction ID is the operation ID
+	ctx = context.WithValue(context.Background(), ffapi.CtxFFRequestIDKey{}, "req2")
+	ctx = withCorrelationIDs(testSendRunContext(ctx, "tx1"))
+	assert.Equal(t, "req2", log.L(ctx).Data["httpreq"])
+	assert.Equal(t, "tx1", log.L(ctx).Data["opid"])
+}
+
+func TestCorrelationHeaders(t *testing.T) {
+	headers := make(chan http.Header, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers <- r.Header
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x1"}`))
+	}))
+	defer server.Close()
+
+	config.RootConfigReset()
+	conf := config.RootSection("unittest")
+	InitConfig(conf)
+	conf.Set(ffresty.HTTPConfigURL, server.URL)
+	conf.Set(CorrelationHeadersEnabled, true)
+	opts, err := newRPCClientOptions(context.Background(), conf)
+	assert.NoError(t, err)
+	client, err := newRPCClient(context.Background(), conf, opts)
+	assert.NoError(t, err)
+	mb := newManagedBackend(client, nil)
+
+	ctx := context.WithValue(context.Background(), ffapi.CtxFFRequestIDKey{}, "req1")
+	var result string
+	rpcErr := mb.CallRPC(testSendRunContext(ctx, "ns1:op1"), &result, "eth_blockNumber")
+	assert.Nil(t, rpcErr)
+	h := <-headers
+	assert.Equal(t, "req1", h.Get("X-Request-ID"))
+	assert.Equal(t, "req1", h.Get(ffapi.RequestIDHeader()))
+	assert.Equal(t, "op1", h.Get("X-FireFly-Operation-ID"))
+
+	rpcErr = mb.CallRPC(context.Background(), &result, "eth_blockNumber")
+	assert.Nil(t, rpcErr)
+	h = <-headers
+	assert.Empty(t, h.Get("X-Request-ID"))
+	assert.Empty(t, h.Get("X-FireFly-Operation-ID"))
+
+	conf.Set(CorrelationHeadersEnabled, false)
+	assert.Nil(t, newCorrelationHeaders(conf))
+}
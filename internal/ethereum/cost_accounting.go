@@ -0,0 +1,267 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+)
+
+// The classes of JSON/RPC method that requests are accounted under, by the kind of operation they are part of
+const (
+	CostClassTransactions = "transactions"
+	CostClassQueries      = "queries"
+	CostClassGas          = "gas"
+	CostClassEvents       = "events"
+	CostClassBlocks       = "blocks"
+	CostClassOther        = "other"
+)
+
+// defaultComputeUnits are an estimate of the relative cost of each method, in line with the compute units of the
+// common node providers. They are overridden by the costAccounting.computeUnits of the config, to match the pricing
+// of the provider in use.
+var defaultComputeUnits = map[string]int64{
+	"eth_blockNumber":                         10,
+	"eth_chainId":                             0,
+	"net_version":                             0,
+	"eth_getBlockByHash":                      16,
+	"eth_getBlockByNumber":                    16,
+	"eth_getBlockTransactionCountByNumber":    20,
+	"eth_getTransactionByBlockNumberAndIndex": 15,
+	"eth_getTransactionByHash":                17,
+	"eth_getTransactionReceipt":               15,
+	"eth_getTransactionCount":                 26,
+	"eth_getBalance":                          19,
+	"eth_getCode":                             26,
+	"eth_getStorageAt":                        17,
+	"eth_call":                                26,
+	"eth_estimateGas":                         87,
+	"eth_gasPrice":                            19,
+	"eth_maxPriorityFeePerGas":                19,
+	"eth_feeHistory":                          10,
+	"eth_sendTransaction":                     250,
+	"eth_sendRawTransaction":                  250,
+	"eth_getLogs":                             75,
+	"eth_newFilter":                           20,
+	"eth_newBlockFilter":                      20,
+	"eth_getFilterLogs":                       75,
+	"eth_getFilterChanges":                    20,
+	"eth_uninstallFilter":                     10,
+	"debug_traceTransaction":                  309,
+}
+
+// costClasses are the classes of the methods that are not classified by the form of their name
+var costClasses = map[string]string{
+	"eth_sendTransaction":                     CostClassTransactions,
+	"eth_sendRawTransaction":                  CostClassTransactions,
+	"eth_getTransactionByHash":                CostClassTransactions,
+	"eth_getTransactionReceipt":               CostClassTransactions,
+	"eth_getTransactionCount":                 CostClassTransactions,
+	"eth_call":                                CostClassQueries,
+	"eth_getBalance":                          CostClassQueries,
+	"eth_getCode":                             CostClassQueries,
+	"eth_getStorageAt":                        CostClassQueries,
+	"eth_estimateGas":                         CostClassGas,
+	"eth_gasPrice":                            CostClassGas,
+	"eth_maxPriorityFeePerGas":                CostClassGas,
+	"eth_feeHistory":                          CostClassGas,
+	"eth_getLogs":                             CostClassEvents,
+	"eth_blockNumber":                         CostClassBlocks,
+	"eth_getTransactionByBlockNumberAndIndex": CostClassBlocks,
+}
+
+// costClass returns the class a method is accounted under
+func costClass(method string) string {
+	if class, ok := costClasses[method]; ok {
+		return class
+	}
+	switch {
+	case strings.Contains(method, "Filter"):
+		return CostClassEvents
+	case strings.HasPrefix(method, "eth_getBlock"):
+		return CostClassBlocks
+	case strings.HasPrefix(method, "eth_send"), strings.HasPrefix(method, "priv_"), strings.HasPrefix(method, "eea_"):
+		return CostClassTransactions
+	default:
+		return CostClassOther
+	}
+}
+
+// CostTotals are the JSON/RPC requests made, and their estimated compute units
+type CostTotals struct {
+	Calls        int64 `json:"calls"`
+	Errors       int64 `json:"errors"`
+	ComputeUnits int64 `json:"computeUnits"`
+}
+
+// CostReport is the cost of the JSON/RPC requests made since the connector started, or since the report was last
+// reset, broken down by what they were made for
+type CostReport struct {
+	Since     *fftypes.FFTime        `json:"since"`
+	Total     CostTotals             `json:"total"`
+	Endpoints map[string]*CostTotals `json:"endpoints"`
+	Classes   map[string]*CostTotals `json:"classes"`
+	Methods   map[string]*CostTotals `json:"methods"`
+	Streams   map[string]*CostTotals `json:"streams"`
+	Listeners map[string]*CostTotals `json:"listeners"`
+}
+
+// costAttribution is the event stream and listener the requests made with a context are for
+type costAttribution struct {
+	stream   string
+	listener string
+}
+
+type costAttributionKey struct{}
+
+// withCostStream attributes the requests made with the context to an event stream
+func withCostStream(ctx context.Context, streamID *fftypes.UUID) context.Context {
+	return context.WithValue(ctx, costAttributionKey{}, &costAttribution{stream: streamID.String()})
+}
+
+// withCostListener attributes the requests made with the context to a listener, of the stream of the context
+func withCostListener(ctx context.Context, listenerID *fftypes.UUID) context.Context {
+	attribution := costAttribution{listener: listenerID.String()}
+	if parent, ok := ctx.Value(costAttributionKey{}).(*costAttribution); ok {
+		attribution.stream = parent.stream
+	}
+	return context.WithValue(ctx, costAttributionKey{}, &attribution)
+}
+
+// costTracker accumulates the cost of the requests to all the endpoints of the connector
+type costTracker struct {
+	computeUnits        map[string]int64
+	defaultComputeUnits int64
+
+	mux    sync.Mutex
+	report *CostReport
+}
+
+// newCostTracker returns nil if cost accounting is disabled
+func newCostTracker(conf config.Section) *costTracker {
+	if !conf.GetBool(CostAccountingEnabled) {
+		return nil
+	}
+	ct := &costTracker{
+		computeUnits:        make(map[string]int64, len(defaultComputeUnits)),
+		defaultComputeUnits: conf.GetInt64(CostAccountingDefaultComputeUnits),
+	}
+	// Keyed in lower case, as the keys of the config are not case sensitive
+	for method, units := range defaultComputeUnits {
+		ct.computeUnits[strings.ToLower(method)] = units
+	}
+	overrides := conf.GetObject(CostAccountingComputeUnits)
+	for method := range overrides {
+		ct.computeUnits[strings.ToLower(method)] = overrides.GetInt64(method)
+	}
+	ct.report = newCostReport()
+	return ct
+}
+
+func newCostReport() *CostReport {
+	return &CostReport{
+		Since:     fftypes.Now(),
+		Endpoints: make(map[string]*CostTotals),
+		Classes:   make(map[string]*CostTotals),
+		Methods:   make(map[string]*CostTotals),
+		Streams:   make(map[string]*CostTotals),
+		Listeners: make(map[string]*CostTotals),
+	}
+}
+
+func addCost(totals map[string]*CostTotals, key string, failed bool, units int64) {
+	if key == "" {
+		return
+	}
+	t := totals[key]
+	if t == nil {
+		t = &CostTotals{}
+		totals[key] = t
+	}
+	t.add(failed, units)
+}
+
+func (t *CostTotals) add(failed bool, units int64) {
+	t.Calls++
+	if failed {
+		t.Errors++
+	}
+	t.ComputeUnits += units
+}
+
+// record accounts for a request made to an endpoint, against everything it is attributed to
+func (ct *costTracker) record(ctx context.Context, endpoint, method string, failed bool) {
+	if ct == nil {
+		return
+	}
+	units, ok := ct.computeUnits[strings.ToLower(method)]
+	if !ok {
+		units = ct.defaultComputeUnits
+	}
+	var attribution costAttribution
+	if a, ok := ctx.Value(costAttributionKey{}).(*costAttribution); ok {
+		attribution = *a
+	}
+
+	ct.mux.Lock()
+	defer ct.mux.Unlock()
+	r := ct.report
+	r.Total.add(failed, units)
+	addCost(r.Endpoints, endpoint, failed, units)
+	addCost(r.Classes, costClass(method), failed, units)
+	addCost(r.Methods, method, failed, units)
+	addCost(r.Streams, attribution.stream, failed, units)
+	addCost(r.Listeners, attribution.listener, failed, units)
+}
+
+// CostReport returns the cost of the JSON/RPC requests made by the connector, optionally starting a new report
+func (c *ethConnector) CostReport(ctx context.Context, reset bool) (*CostReport, error) {
+	ct := c.costs
+	if ct == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgCostAccountingDisabled)
+	}
+	ct.mux.Lock()
+	defer ct.mux.Unlock()
+	report := &CostReport{
+		Since:     ct.report.Since,
+		Total:     ct.report.Total,
+		Endpoints: copyCostTotals(ct.report.Endpoints),
+		Classes:   copyCostTotals(ct.report.Classes),
+		Methods:   copyCostTotals(ct.report.Methods),
+		Streams:   copyCostTotals(ct.report.Streams),
+		Listeners: copyCostTotals(ct.report.Listeners),
+	}
+	if reset {
+		ct.report = newCostReport()
+	}
+	return report, nil
+}
+
+func copyCostTotals(totals map[string]*CostTotals) map[string]*CostTotals {
+	copied := make(map[string]*CostTotals, len(totals))
+	for k, t := range totals {
+		tc := *t
+		copied[k] = &tc
+	}
+	return copied
+}
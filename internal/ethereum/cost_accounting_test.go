@@ -0,0 +1,136 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func enableCostAccounting(conf config.Section) {
+	conf.Set(CostAccountingEnabled, true)
+	conf.Set(CostAccountingComputeUnits, map[string]interface{}{"eth_call": 50})
+}
+
+func TestCostAccounting(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t, enableCostAccounting)
+	defer done()
+	mb := newManagedBackend(mRPC, nil).withCosts(c.costs, "primary")
+
+	streamID := fftypes.NewUUID()
+	listenerID := fftypes.NewUUID()
+	streamCtx := withCostStream(ctx, streamID)
+	listenerCtx := withCostListener(streamCtx, listenerID)
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_call", mock.Anything).Return(nil).Once()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getLogs", mock.Anything).Return(nil).Once()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByHash", mock.Anything).Return(&rpcbackend.RPCError{Message: "pop"}).Once()
+	mRPC.On("SyncRequest", mock.Anything, mock.Anything).Return(&rpcbackend.RPCResponse{Error: &rpcbackend.RPCError{Message: "pop"}}, nil).Once()
+
+	assert.Nil(t, mb.CallRPC(ctx, nil, "eth_call", nil))
+	assert.Nil(t, mb.CallRPC(streamCtx, nil, "eth_getLogs", nil))
+	assert.NotNil(t, mb.CallRPC(listenerCtx, nil, "eth_getBlockByHash", nil))
+	_, err := mb.SyncRequest(ctx, &rpcbackend.RPCRequest{Method: "custom_method"})
+	assert.NoError(t, err)
+
+	report, err := c.CostReport(ctx, true)
+	assert.NoError(t, err)
+	assert.Equal(t, CostTotals{Calls: 4, Errors: 2, ComputeUnits: 50 + 75 + 16 + 20}, report.Total)
+	assert.Equal(t, int64(4), report.Endpoints["primary"].Calls)
+	assert.Equal(t, int64(50), report.Classes[CostClassQueries].ComputeUnits)
+	assert.Equal(t, int64(75), report.Classes[CostClassEvents].ComputeUnits)
+	assert.Equal(t, int64(1), report.Classes[CostClassBlocks].Errors)
+	assert.Equal(t, int64(20), report.Methods["custom_method"].ComputeUnits)
+	assert.Equal(t, CostTotals{Calls: 2, Errors: 1, ComputeUnits: 75 + 16}, *report.Streams[streamID.String()])
+	assert.Equal(t, CostTotals{Calls: 1, Errors: 1, ComputeUnits: 16}, *report.Listeners[listenerID.String()])
+
+	// The report is reset, and the returned report is not updated by later requests
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_blockNumber").Return(nil).Once()
+	assert.Nil(t, mb.CallRPC(ctx, nil, "eth_blockNumber"))
+	assert.Equal(t, int64(4), report.Total.Calls)
+	report2, err := c.CostReport(ctx, false)
+	assert.NoError(t, err)
+	assert.Equal(t, CostTotals{Calls: 1, ComputeUnits: 10}, report2.Total)
+	assert.Empty(t, report2.Streams)
+}
+
+func TestCostAccountingRoute(t *testing.T) {
+	_, c, _, done := newTestConnector(t, enableCostAccounting)
+	defer done()
+	url, close := newTestRouteServer(t, c)
+	defer close()
+
+	c.costs.record(context.Background(), "read", "eth_getBalance", false)
+
+	res, err := http.Get(url + "/admin/costs?reset=true")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var report CostReport
+	err = json.NewDecoder(res.Body).Decode(&report)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(19), report.Endpoints["read"].ComputeUnits)
+
+	res, err = http.Get(url + "/admin/costs")
+	assert.NoError(t, err)
+	report = CostReport{}
+	err = json.NewDecoder(res.Body).Decode(&report)
+	assert.NoError(t, err)
+	assert.Zero(t, report.Total.Calls)
+}
+
+func TestCostAccountingDisabled(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+	url, close := newTestRouteServer(t, c)
+	defer close()
+
+	assert.Nil(t, c.costs)
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_blockNumber").Return(nil).Once()
+	assert.Nil(t, newManagedBackend(mRPC, nil).withCosts(c.costs, "primary").CallRPC(ctx, nil, "eth_blockNumber"))
+
+	_, err := c.CostReport(ctx, false)
+	assert.Regexp(t, "FF23173", err)
+	res, err := http.Get(url + "/admin/costs")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+}
+
+func TestCostClass(t *testing.T) {
+	for method, class := range map[string]string{
+		"eth_sendRawTransaction":                  CostClassTransactions,
+		"eth_sendUserOperation":                   CostClassTransactions,
+		"priv_getTransactionCount":                CostClassTransactions,
+		"eth_getTransactionReceipt":               CostClassTransactions,
+		"eth_call":                                CostClassQueries,
+		"eth_feeHistory":                          CostClassGas,
+		"eth_getFilterChanges":                    CostClassEvents,
+		"eth_newBlockFilter":                      CostClassEvents,
+		"eth_getBlockByNumber":                    CostClassBlocks,
+		"eth_getTransactionByBlockNumberAndIndex": CostClassBlocks,
+		"debug_traceTransaction":                  CostClassOther,
+	} {
+		assert.Equal(t, class, costClass(method), method)
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+)
+
+const (
+	VerificationModeWarn  = "warn"
+	VerificationModeBlock = "block"
+)
+
+// crossVerifyLogs checks the logs returned by the primary endpoint against the receipts of their transactions on
+// the verification endpoint, so a single provider cannot fabricate, alter or omit the logs of those transactions.
+// In block mode an error is returned for any discrepancy, including the verification endpoint not yet having the
+// receipt, so the logs are queried again rather than being delivered.
+func (es *eventStream) crossVerifyLogs(ctx context.Context, ag *aggregatedListener, ethLogs []*logJSONRPC) error {
+	c := es.c
+	if c.verifyBackend == nil {
+		return nil
+	}
+	var txHashes []string
+	logsByTx := make(map[string][]*logJSONRPC)
+	for _, ethLog := range ethLogs {
+		if ethLog.Removed {
+			continue
+		}
+		txHash := ethLog.TransactionHash.String()
+		if _, ok := logsByTx[txHash]; !ok {
+			txHashes = append(txHashes, txHash)
+		}
+		logsByTx[txHash] = append(logsByTx[txHash], ethLog)
+	}
+	var mismatches []string
+	for _, txHash := range txHashes {
+		var receipt *txReceiptJSONRPC
+		if rpcErr := c.verifyBackend.CallRPC(ctx, &receipt, "eth_getTransactionReceipt", txHash); rpcErr != nil {
+			return c.verificationFailed(ctx, rpcErr.Error())
+		}
+		mismatches = append(mismatches, ag.verifyReceiptLogs(txHash, logsByTx[txHash], receipt)...)
+	}
+	return c.verificationMismatches(ctx, fftypes.JSONObject{"streamId": es.id.String()}, mismatches)
+}
+
+// crossVerifyReceipt checks a receipt from the primary endpoint against the same receipt on the verification
+// endpoint. In block mode a receipt the verification endpoint does not have is reported as not found, so the
+// transaction manager keeps polling for it rather than confirming the transaction.
+func (c *ethConnector) crossVerifyReceipt(ctx context.Context, txHash string, receipt *txReceiptJSONRPC) (ffcapi.ErrorReason, error) {
+	if c.verifyBackend == nil {
+		return "", nil
+	}
+	var verified *txReceiptJSONRPC
+	if rpcErr := c.verifyBackend.CallRPC(ctx, &verified, "eth_getTransactionReceipt", txHash); rpcErr != nil {
+		return "", c.verificationFailed(ctx, rpcErr.Error())
+	}
+	var mismatches []string
+	switch {
+	case verified == nil:
+		err := c.verificationMismatches(ctx, fftypes.JSONObject{"transactionHash": txHash}, []string{fmt.Sprintf("receipt of transaction %s is not available", txHash)})
+		if err != nil {
+			return ffcapi.ErrorReasonNotFound, err
+		}
+		return "", nil
+	case !bytes.Equal(receipt.BlockHash, verified.BlockHash):
+		mismatches = append(mismatches, fmt.Sprintf("receipt of transaction %s is in block %s, but in block %s on the verification endpoint", txHash, receipt.BlockHash, verified.BlockHash))
+	case !hexIntegerEqual(receipt.Status, verified.Status):
+		mismatches = append(mismatches, fmt.Sprintf("receipt of transaction %s has status %s, but %s on the verification endpoint", txHash, receipt.Status.BigInt(), verified.Status.BigInt()))
+	case len(receipt.Logs) != len(verified.Logs):
+		mismatches = append(mismatches, fmt.Sprintf("receipt of transaction %s has %d logs, but %d on the verification endpoint", txHash, len(receipt.Logs), len(verified.Logs)))
+	default:
+		for i := range receipt.Logs {
+			if !logContentEqual(receipt.Logs[i], verified.Logs[i]) {
+				mismatches = append(mismatches, fmt.Sprintf("log %d of the receipt of transaction %s does not match the verification endpoint", i, txHash))
+			}
+		}
+	}
+	return "", c.verificationMismatches(ctx, fftypes.JSONObject{"transactionHash": txHash}, mismatches)
+}
+
+func hexIntegerEqual(a, b *ethtypes.HexInteger) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.BigInt().Cmp(b.BigInt()) == 0
+}
+
+// verificationFailed handles a failure to query the verification endpoint, which only stops processing in block mode
+func (c *ethConnector) verificationFailed(ctx context.Context, err error) error {
+	log.L(ctx).Warnf("Unable to query the verification endpoint: %s", err)
+	if c.verifyMode == VerificationModeBlock {
+		return err
+	}
+	return nil
+}
+
+func (c *ethConnector) verificationMismatches(ctx context.Context, details fftypes.JSONObject, mismatches []string) error {
+	if len(mismatches) == 0 {
+		return nil
+	}
+	for _, m := range mismatches {
+		log.L(ctx).Errorf("Cross-verification failed: %s", m)
+	}
+	details["mismatches"] = mismatches
+	c.notifier.notify(ctx, NotificationVerificationMismatch, details, "%d results of the primary endpoint do not match the verification endpoint", len(mismatches))
+	if c.verifyMode == VerificationModeBlock {
+		return i18n.NewError(ctx, msgs.MsgVerificationMismatch, len(mismatches), mismatches[0])
+	}
+	return nil
+}
@@ -0,0 +1,227 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-evmconnect/mocks/rpcbackendmocks"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestCrossVerification(t *testing.T, mode string) (*eventStream, *aggregatedListener, *rpcbackendmocks.Backend, func()) {
+	ctx, c, _, done := newTestConnector(t)
+	mVerifyRPC := &rpcbackendmocks.Backend{}
+	c.verifyBackend = mVerifyRPC
+	c.verifyMode = mode
+	es := &eventStream{id: fftypes.NewUUID(), ctx: ctx, c: c}
+	return es, testBloomAggregatedListener(testVerifyTransferEvent), mVerifyRPC, func() {
+		done()
+		mVerifyRPC.AssertExpectations(t)
+	}
+}
+
+func mockVerifyEndpointReceipt(mVerifyRPC *rpcbackendmocks.Backend, receipt *txReceiptJSONRPC) {
+	mVerifyRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionReceipt", mock.Anything).
+		Run(func(args mock.Arguments) {
+			*(args[1].(**txReceiptJSONRPC)) = receipt
+		}).
+		Return(nil).Once()
+}
+
+func TestVerificationConfig(t *testing.T) {
+	_, c, _, done := newTestConnector(t, func(conf config.Section) {
+		conf.SubSection(VerificationEndpointConfig).Set(ffresty.HTTPConfigURL, "http://localhost:8547")
+		conf.Set(VerificationMode, "Block")
+	})
+	defer done()
+	assert.NotNil(t, c.verifyBackend)
+	assert.Equal(t, VerificationModeBlock, c.verifyMode)
+
+	config.RootConfigReset()
+	conf := config.RootSection("unittest")
+	InitConfig(conf)
+	conf.Set(ffresty.HTTPConfigURL, "http://localhost:8545")
+	conf.SubSection(VerificationEndpointConfig).Set(ffresty.HTTPConfigURL, "http://localhost:8547")
+	conf.Set(VerificationMode, "wrong")
+	_, err := NewEthereumConnector(context.Background(), conf)
+	assert.Regexp(t, "FF23145.*wrong", err)
+}
+
+func TestCrossVerifyLogsDisabled(t *testing.T) {
+	es, ag, _, done := newTestCrossVerification(t, VerificationModeBlock)
+	defer done()
+	es.c.verifyBackend = nil
+
+	err := es.crossVerifyLogs(context.Background(), ag, []*logJSONRPC{sampleTransferLog()})
+	assert.NoError(t, err)
+}
+
+func TestCrossVerifyLogsMatch(t *testing.T) {
+	es, ag, mVerifyRPC, done := newTestCrossVerification(t, VerificationModeBlock)
+	defer done()
+	mockVerifyEndpointReceipt(mVerifyRPC, testReceiptForLogs(sampleTransferLog()))
+
+	removed := sampleTransferLog()
+	removed.Removed = true
+	err := es.crossVerifyLogs(context.Background(), ag, []*logJSONRPC{sampleTransferLog(), removed})
+	assert.NoError(t, err)
+}
+
+func TestCrossVerifyLogsMismatchBlock(t *testing.T) {
+	es, ag, mVerifyRPC, done := newTestCrossVerification(t, VerificationModeBlock)
+	defer done()
+	mockVerifyEndpointReceipt(mVerifyRPC, nil)
+
+	err := es.crossVerifyLogs(context.Background(), ag, []*logJSONRPC{sampleTransferLog()})
+	assert.Regexp(t, "FF23146.*no receipt", err)
+}
+
+func TestCrossVerifyLogsMismatchWarn(t *testing.T) {
+	es, ag, mVerifyRPC, done := newTestCrossVerification(t, VerificationModeWarn)
+	defer done()
+	altered := sampleTransferLog()
+	altered.Data = ethtypes.MustNewHexBytes0xPrefix("0x00")
+	mockVerifyEndpointReceipt(mVerifyRPC, testReceiptForLogs(altered))
+
+	err := es.crossVerifyLogs(context.Background(), ag, []*logJSONRPC{sampleTransferLog()})
+	assert.NoError(t, err)
+}
+
+func TestCrossVerifyLogsQueryFail(t *testing.T) {
+	es, ag, mVerifyRPC, done := newTestCrossVerification(t, VerificationModeBlock)
+	defer done()
+	mVerifyRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionReceipt", mock.Anything).
+		Return(&rpcbackend.RPCError{Message: "pop"})
+
+	err := es.crossVerifyLogs(context.Background(), ag, []*logJSONRPC{sampleTransferLog()})
+	assert.Regexp(t, "pop", err)
+
+	es.c.verifyMode = VerificationModeWarn
+	err = es.crossVerifyLogs(context.Background(), ag, []*logJSONRPC{sampleTransferLog()})
+	assert.NoError(t, err)
+}
+
+func TestGetBlockRangeEventsCrossVerifyFail(t *testing.T) {
+	es, ag, mVerifyRPC, done := newTestCrossVerification(t, VerificationModeBlock)
+	defer done()
+	ag.listeners[0].config.options = &listenerOptions{}
+	es.c.backend.(*rpcbackendmocks.Backend).On("CallRPC", mock.Anything, mock.Anything, "eth_getLogs", mock.Anything).
+		Run(func(args mock.Arguments) {
+			*(args[1].(*[]*logJSONRPC)) = []*logJSONRPC{sampleTransferLog()}
+		}).
+		Return(nil)
+	mockVerifyEndpointReceipt(mVerifyRPC, nil)
+
+	_, err := es.getBlockRangeEvents(context.Background(), ag, 1024, 1024)
+	assert.Regexp(t, "FF23146", err)
+}
+
+func TestCrossVerifyReceipt(t *testing.T) {
+	es, _, mVerifyRPC, done := newTestCrossVerification(t, VerificationModeBlock)
+	defer done()
+	c := es.c
+	txHash := sampleTransferLog().TransactionHash.String()
+	receipt := func() *txReceiptJSONRPC {
+		r := testReceiptForLogs(sampleTransferLog())
+		r.Status = ethtypes.NewHexInteger64(1)
+		return r
+	}
+
+	mockVerifyEndpointReceipt(mVerifyRPC, receipt())
+	reason, err := c.crossVerifyReceipt(context.Background(), txHash, receipt())
+	assert.NoError(t, err)
+	assert.Empty(t, reason)
+
+	mockVerifyEndpointReceipt(mVerifyRPC, nil)
+	reason, err = c.crossVerifyReceipt(context.Background(), txHash, receipt())
+	assert.Regexp(t, "FF23146.*not available", err)
+	assert.Equal(t, ffcapi.ErrorReasonNotFound, reason)
+
+	otherBlock := receipt()
+	otherBlock.BlockHash = ethtypes.MustNewHexBytes0xPrefix("0x01")
+	mockVerifyEndpointReceipt(mVerifyRPC, otherBlock)
+	_, err = c.crossVerifyReceipt(context.Background(), txHash, receipt())
+	assert.Regexp(t, "FF23146.*in block 0x01", err)
+
+	failed := receipt()
+	failed.Status = ethtypes.NewHexInteger64(0)
+	mockVerifyEndpointReceipt(mVerifyRPC, failed)
+	_, err = c.crossVerifyReceipt(context.Background(), txHash, receipt())
+	assert.Regexp(t, "FF23146.*has status 1, but 0", err)
+
+	noLogs := receipt()
+	noLogs.Logs = nil
+	mockVerifyEndpointReceipt(mVerifyRPC, noLogs)
+	_, err = c.crossVerifyReceipt(context.Background(), txHash, receipt())
+	assert.Regexp(t, "FF23146.*has 1 logs, but 0", err)
+
+	altered := receipt()
+	altered.Logs[0].Data = ethtypes.MustNewHexBytes0xPrefix("0x00")
+	mockVerifyEndpointReceipt(mVerifyRPC, altered)
+	_, err = c.crossVerifyReceipt(context.Background(), txHash, receipt())
+	assert.Regexp(t, "FF23146.*log 0", err)
+
+	c.verifyMode = VerificationModeWarn
+	mockVerifyEndpointReceipt(mVerifyRPC, nil)
+	reason, err = c.crossVerifyReceipt(context.Background(), txHash, receipt())
+	assert.NoError(t, err)
+	assert.Empty(t, reason)
+
+	mVerifyRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionReceipt", mock.Anything).
+		Return(&rpcbackend.RPCError{Message: "pop"}).Once()
+	_, err = c.crossVerifyReceipt(context.Background(), txHash, receipt())
+	assert.NoError(t, err)
+
+	c.verifyBackend = nil
+	_, err = c.crossVerifyReceipt(context.Background(), txHash, receipt())
+	assert.NoError(t, err)
+}
+
+func TestGetReceiptCrossVerifyNotFound(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+	mVerifyRPC := &rpcbackendmocks.Backend{}
+	c.verifyBackend = mVerifyRPC
+	c.verifyMode = VerificationModeBlock
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionReceipt", mock.Anything).
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			err := json.Unmarshal([]byte(sampleJSONRPCReceipt), args[1])
+			assert.NoError(t, err)
+		})
+	mockVerifyEndpointReceipt(mVerifyRPC, nil)
+
+	var req ffcapi.TransactionReceiptRequest
+	err := json.Unmarshal([]byte(sampleGetReceipt), &req)
+	assert.NoError(t, err)
+	res, reason, err := c.TransactionReceipt(ctx, &req)
+	assert.Regexp(t, "FF23146", err)
+	assert.Equal(t, ffcapi.ErrorReasonNotFound, reason)
+	assert.Nil(t, res)
+	mVerifyRPC.AssertExpectations(t)
+}
@@ -0,0 +1,95 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+)
+
+// DecodeCallDataRequest supplies the input data to decode directly, or the hash of a transaction to fetch it from
+type DecodeCallDataRequest struct {
+	ABI             *fftypes.JSONAny          `json:"abi"`
+	Data            ethtypes.HexBytes0xPrefix `json:"data,omitempty"`
+	TransactionHash ethtypes.HexBytes0xPrefix `json:"transactionHash,omitempty"`
+}
+
+type DecodeCallDataResponse struct {
+	Method   string                    `json:"method"`
+	Selector ethtypes.HexBytes0xPrefix `json:"selector"`
+	Args     *fftypes.JSONAny          `json:"args"`
+}
+
+// DecodeCallData matches the function selector of transaction input data against the functions
+// in the supplied ABI, and decodes the arguments using the configured data format
+func (c *ethConnector) DecodeCallData(ctx context.Context, req *DecodeCallDataRequest) (*DecodeCallDataResponse, error) {
+	var a abi.ABI
+	if req.ABI == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidABI, "missing")
+	}
+	if err := json.Unmarshal(req.ABI.Bytes(), &a); err != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidABI, err)
+	}
+
+	data := req.Data
+	if len(req.TransactionHash) > 0 {
+		txInfo, err := c.getTransactionInfo(ctx, req.TransactionHash)
+		if err != nil {
+			return nil, err
+		}
+		if txInfo == nil {
+			return nil, i18n.NewError(ctx, msgs.MsgTransactionNotFound, req.TransactionHash)
+		}
+		data = txInfo.Input
+	}
+	if len(data) < 4 {
+		return nil, i18n.NewError(ctx, msgs.MsgCallDataMissingSelector)
+	}
+
+	selector := data[0:4]
+	var method *abi.Entry
+	for _, e := range a {
+		if e.IsFunction() && bytes.Equal(e.FunctionSelectorBytes(), selector) {
+			method = e
+			break
+		}
+	}
+	if method == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgNoMatchingABIMethod, selector)
+	}
+
+	v, err := method.DecodeCallDataCtx(ctx, data)
+	var b []byte
+	if err == nil {
+		b, err = c.serializer.SerializeJSONCtx(ctx, v)
+	}
+	if err != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgDecodeCallDataFailed, method.String(), err)
+	}
+	return &DecodeCallDataResponse{
+		Method:   method.String(),
+		Selector: selector,
+		Args:     fftypes.JSONAnyPtrBytes(b),
+	}, nil
+}
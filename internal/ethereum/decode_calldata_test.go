@@ -0,0 +1,170 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	sampleTransferABI = `[
+		{"type":"function","name":"approve","inputs":[{"name":"spender","type":"address"},{"name":"value","type":"uint256"}]},
+		{"type":"event","name":"Transfer","inputs":[{"name":"from","type":"address","indexed":true},{"name":"to","type":"address","indexed":true},{"name":"value","type":"uint256"}]},
+		{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"}]}
+	]`
+	sampleTransferCallData = "0xa9059cbb" +
+		"000000000000000000000000d0f2f5103fd050739a9fb567251bc460cc24d091" +
+		"00000000000000000000000000000000000000000000000000000000000003e8"
+)
+
+func TestDecodeCallDataOK(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t)
+	defer done()
+
+	res, err := c.DecodeCallData(ctx, &DecodeCallDataRequest{
+		ABI:  fftypes.JSONAnyPtr(sampleTransferABI),
+		Data: ethtypes.MustNewHexBytes0xPrefix(sampleTransferCallData),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "transfer(address,uint256)", res.Method)
+	assert.Equal(t, "0xa9059cbb", res.Selector.String())
+	assert.JSONEq(t, `{"to":"0xd0f2f5103fd050739a9fb567251bc460cc24d091","value":"1000"}`, res.Args.String())
+}
+
+func TestDecodeCallDataByTransactionHash(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionByHash", mock.Anything).
+		Run(func(args mock.Arguments) {
+			*(args[1].(**txInfoJSONRPC)) = &txInfoJSONRPC{
+				Input: ethtypes.MustNewHexBytes0xPrefix(sampleTransferCallData),
+			}
+		}).
+		Return(nil)
+
+	res, err := c.DecodeCallData(ctx, &DecodeCallDataRequest{
+		ABI:             fftypes.JSONAnyPtr(sampleTransferABI),
+		TransactionHash: ethtypes.MustNewHexBytes0xPrefix("0x7d48ae971faf089878b57e3c28e3035540d34f38af395958d2c73c36c57c83a2"),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "transfer(address,uint256)", res.Method)
+}
+
+func TestDecodeCallDataTransactionNotFound(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionByHash", mock.Anything).Return(nil)
+
+	_, err := c.DecodeCallData(ctx, &DecodeCallDataRequest{
+		ABI:             fftypes.JSONAnyPtr(sampleTransferABI),
+		TransactionHash: ethtypes.MustNewHexBytes0xPrefix("0x7d48ae971faf089878b57e3c28e3035540d34f38af395958d2c73c36c57c83a2"),
+	})
+	assert.Regexp(t, "FF23067", err)
+}
+
+func TestDecodeCallDataTransactionFail(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionByHash", mock.Anything).
+		Return(&rpcbackend.RPCError{Message: "pop"})
+
+	_, err := c.DecodeCallData(ctx, &DecodeCallDataRequest{
+		ABI:             fftypes.JSONAnyPtr(sampleTransferABI),
+		TransactionHash: ethtypes.MustNewHexBytes0xPrefix("0x7d48ae971faf089878b57e3c28e3035540d34f38af395958d2c73c36c57c83a2"),
+	})
+	assert.Regexp(t, "pop", err)
+}
+
+func TestDecodeCallDataBadABI(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t)
+	defer done()
+
+	_, err := c.DecodeCallData(ctx, &DecodeCallDataRequest{
+		Data: ethtypes.MustNewHexBytes0xPrefix(sampleTransferCallData),
+	})
+	assert.Regexp(t, "FF23066", err)
+
+	_, err = c.DecodeCallData(ctx, &DecodeCallDataRequest{
+		ABI:  fftypes.JSONAnyPtr(`{"not":"an array"}`),
+		Data: ethtypes.MustNewHexBytes0xPrefix(sampleTransferCallData),
+	})
+	assert.Regexp(t, "FF23066", err)
+}
+
+func TestDecodeCallDataNoSelector(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t)
+	defer done()
+
+	_, err := c.DecodeCallData(ctx, &DecodeCallDataRequest{
+		ABI:  fftypes.JSONAnyPtr(sampleTransferABI),
+		Data: ethtypes.MustNewHexBytes0xPrefix("0xa905"),
+	})
+	assert.Regexp(t, "FF23068", err)
+}
+
+func TestDecodeCallDataNoMatch(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t)
+	defer done()
+
+	_, err := c.DecodeCallData(ctx, &DecodeCallDataRequest{
+		ABI:  fftypes.JSONAnyPtr(sampleTransferABI),
+		Data: ethtypes.MustNewHexBytes0xPrefix("0x23b872dd"),
+	})
+	assert.Regexp(t, "FF23069.*0x23b872dd", err)
+}
+
+func TestDecodeCallDataBadArgs(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t)
+	defer done()
+
+	_, err := c.DecodeCallData(ctx, &DecodeCallDataRequest{
+		ABI:  fftypes.JSONAnyPtr(sampleTransferABI),
+		Data: ethtypes.MustNewHexBytes0xPrefix("0xa9059cbb0000"),
+	})
+	assert.Regexp(t, "FF23070", err)
+}
+
+func TestPostDecodeCallDataRoute(t *testing.T) {
+	_, c, _, done := newTestConnector(t)
+	defer done()
+	url, close := newTestRouteServer(t, c)
+	defer close()
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"abi":  json.RawMessage(sampleTransferABI),
+		"data": sampleTransferCallData,
+	})
+	res, err := http.Post(url+"/decode/calldata", "application/json", bytes.NewReader(body))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var decoded DecodeCallDataResponse
+	err = json.NewDecoder(res.Body).Decode(&decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, "transfer(address,uint256)", decoded.Method)
+}
@@ -0,0 +1,134 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-signer/pkg/ethsigner"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rlp"
+	"github.com/hyperledger/firefly-signer/pkg/secp256k1"
+)
+
+type DecodeTransactionRequest struct {
+	Transaction ethtypes.HexBytes0xPrefix `json:"transaction"`
+}
+
+// DecodedTransaction uses the field names of a transaction returned by the node, with the fee
+// fields that do not apply to the transaction type omitted
+type DecodedTransaction struct {
+	Type                 ethtypes.HexUint64          `json:"type"`
+	Encoding             string                      `json:"encoding"`
+	Hash                 ethtypes.HexBytes0xPrefix   `json:"hash"`
+	ChainID              *ethtypes.HexInteger        `json:"chainId,omitempty"` // omitted for legacy transactions without EIP-155 replay protection
+	From                 *ethtypes.Address0xHex      `json:"from"`
+	To                   *ethtypes.Address0xHex      `json:"to"` // null for contract deployment
+	Nonce                *ethtypes.HexInteger        `json:"nonce"`
+	Value                *ethtypes.HexInteger        `json:"value"`
+	Gas                  *ethtypes.HexInteger        `json:"gas"`
+	GasPrice             *ethtypes.HexInteger        `json:"gasPrice,omitempty"`
+	MaxPriorityFeePerGas *ethtypes.HexInteger        `json:"maxPriorityFeePerGas,omitempty"`
+	MaxFeePerGas         *ethtypes.HexInteger        `json:"maxFeePerGas,omitempty"`
+	MaxFeePerBlobGas     *ethtypes.HexInteger        `json:"maxFeePerBlobGas,omitempty"`
+	BlobVersionedHashes  []ethtypes.HexBytes0xPrefix `json:"blobVersionedHashes,omitempty"`
+	Input                ethtypes.HexBytes0xPrefix   `json:"input"`
+	SourceHash           ethtypes.HexBytes0xPrefix   `json:"sourceHash,omitempty"` // OP deposit transactions only
+	Mint                 *ethtypes.HexInteger        `json:"mint,omitempty"`
+	IsSystemTx           *bool                       `json:"isSystemTx,omitempty"`
+}
+
+// DecodeTransaction decodes a raw signed transaction, using the same checks as pre-signed transaction
+// submission, and recovers the signer from the signature over the transaction fields. OP deposit
+// transactions are not signed, so have the address they are from in their fields instead.
+func (c *ethConnector) DecodeTransaction(ctx context.Context, req *DecodeTransactionRequest) (*DecodedTransaction, error) {
+	fields, hashed, enc, err := parseSignedTx(ctx, req.Transaction)
+	if err != nil {
+		return nil, err
+	}
+	if enc == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgSignedTXUnsupportedType, req.Transaction[0])
+	}
+
+	named := make(map[string]rlp.Data, len(fields))
+	for i, field := range fields {
+		if !field.IsList() {
+			named[enc.fields[i]] = field.ToData()
+		}
+	}
+	if enc.txType == opDepositTxType {
+		return decodeDepositTx(ctx, named, hashed)
+	}
+	tx := &DecodedTransaction{
+		Type:     ethtypes.HexUint64(enc.txType),
+		Encoding: enc.name,
+		Hash:     keccak256(hashed),
+		To:       named["to"].Address(),
+		Nonce:    (*ethtypes.HexInteger)(named["nonce"].IntOrZero()),
+		Value:    (*ethtypes.HexInteger)(named["value"].IntOrZero()),
+		Gas:      (*ethtypes.HexInteger)(named["gasLimit"].IntOrZero()),
+		Input:    ethtypes.HexBytes0xPrefix(named["data"].BytesNotNil()),
+	}
+	for name, target := range map[string]**ethtypes.HexInteger{
+		"gasPrice":             &tx.GasPrice,
+		"maxPriorityFeePerGas": &tx.MaxPriorityFeePerGas,
+		"maxFeePerGas":         &tx.MaxFeePerGas,
+		"maxFeePerBlobGas":     &tx.MaxFeePerBlobGas,
+	} {
+		if value, ok := named[name]; ok {
+			*target = (*ethtypes.HexInteger)(value.IntOrZero())
+		}
+	}
+	if enc.txType == txType4844 {
+		for _, h := range fields[10].(rlp.List) {
+			tx.BlobVersionedHashes = append(tx.BlobVersionedHashes, ethtypes.HexBytes0xPrefix(h.ToData().BytesNotNil()))
+		}
+	}
+
+	// The signature is the last three fields, over the encoding of the fields before it
+	sigFields := len(fields) - 3
+	sig := &secp256k1.SignatureData{
+		V: fields[sigFields].ToData().IntOrZero(),
+		R: fields[sigFields+1].ToData().IntOrZero(),
+		S: fields[sigFields+2].ToData().IntOrZero(),
+	}
+	var message []byte
+	var chainID *big.Int
+	if enc.txType == ethsigner.TransactionTypeLegacy {
+		message = fields[0:sigFields].Encode()
+		if sig.V.Cmp(big.NewInt(35)) >= 0 {
+			// EIP-155 encodes the chain ID into V, and adds it to the signed fields
+			chainID = new(big.Int).Div(new(big.Int).Sub(sig.V, big.NewInt(35)), big.NewInt(2))
+			message = ethsigner.AddEIP155HashValuesToRLPList(append(rlp.List{}, fields[0:sigFields]...), chainID.Int64()).Encode()
+		}
+	} else {
+		chainID = named["chainId"].IntOrZero()
+		message = append([]byte{enc.txType}, fields[0:sigFields].Encode()...)
+	}
+	var chainID64 int64
+	if chainID != nil {
+		tx.ChainID = (*ethtypes.HexInteger)(chainID)
+		chainID64 = chainID.Int64()
+	}
+	if tx.From, err = sig.Recover(message, chainID64); err != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgSignedTXRecoverFailed, enc.name, err)
+	}
+	return tx, nil
+}
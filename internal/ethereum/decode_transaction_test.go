@@ -0,0 +1,178 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"testing"
+
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rlp"
+	"github.com/hyperledger/firefly-signer/pkg/secp256k1"
+	"github.com/stretchr/testify/assert"
+)
+
+const sampleSignedTXFrom = "0xdc6fd36d8b979ca40a8bf64e14ce5222e0192265"
+
+// testSignTypedTx replaces the placeholder signature of a typed transaction with a signature from the key
+func testSignTypedTx(t *testing.T, kp *secp256k1.KeyPair, txType byte, fields rlp.List) []byte {
+	unsigned := fields[0 : len(fields)-3]
+	sig, err := kp.Sign(append([]byte{txType}, unsigned.Encode()...))
+	assert.NoError(t, err)
+	signed := append(append(rlp.List{}, unsigned...),
+		rlp.WrapInt(new(big.Int).Sub(sig.V, big.NewInt(27))), rlp.WrapInt(sig.R), rlp.WrapInt(sig.S))
+	return testTypedTx(txType, signed)
+}
+
+// testHomesteadTx builds a contract deployment signed by the key without EIP-155 replay protection
+func testHomesteadTx(t *testing.T, kp *secp256k1.KeyPair) []byte {
+	unsigned := rlp.List{
+		rlp.WrapInt(big.NewInt(5)), rlp.WrapInt(big.NewInt(1000)), rlp.WrapInt(big.NewInt(21000)),
+		rlp.Data{}, rlp.WrapInt(big.NewInt(0)), rlp.MustWrapHex("0x60806040"),
+	}
+	sig, err := kp.Sign(unsigned.Encode())
+	assert.NoError(t, err)
+	return append(unsigned, rlp.WrapInt(sig.V), rlp.WrapInt(sig.R), rlp.WrapInt(sig.S)).Encode()
+}
+
+func TestDecodeTransactionLegacy(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t)
+	defer done()
+
+	tx, err := c.DecodeTransaction(ctx, &DecodeTransactionRequest{
+		Transaction: ethtypes.MustNewHexBytes0xPrefix(sampleSignedLegacyTX),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "legacy", tx.Encoding)
+	assert.Equal(t, sampleSignedLegacyHash, tx.Hash.String())
+	assert.Equal(t, sampleSignedTXFrom, tx.From.String())
+	assert.Equal(t, int64(1337), tx.ChainID.Int64())
+	assert.Equal(t, int64(111), tx.Nonce.Int64())
+	assert.Nil(t, tx.MaxFeePerGas)
+	assert.NotNil(t, tx.GasPrice)
+}
+
+func TestDecodeTransactionLegacyHomestead(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t)
+	defer done()
+
+	kp, err := secp256k1.GenerateSecp256k1KeyPair()
+	assert.NoError(t, err)
+
+	tx, err := c.DecodeTransaction(ctx, &DecodeTransactionRequest{Transaction: testHomesteadTx(t, kp)})
+	assert.NoError(t, err)
+	assert.Equal(t, kp.Address.String(), tx.From.String())
+	assert.Nil(t, tx.ChainID)
+	assert.Nil(t, tx.To)
+	assert.Equal(t, "0x60806040", tx.Input.String())
+}
+
+func TestDecodeTransaction1559(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t)
+	defer done()
+
+	tx, err := c.DecodeTransaction(ctx, &DecodeTransactionRequest{
+		Transaction: ethtypes.MustNewHexBytes0xPrefix(sampleSigned1559TX),
+	})
+	assert.NoError(t, err)
+	b, err := json.Marshal(tx)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "0x2",
+		"encoding": "EIP-1559",
+		"hash": "`+sampleSigned1559Hash+`",
+		"chainId": "0x539",
+		"from": "`+sampleSignedTXFrom+`",
+		"to": "0x497eedc4299dea2f2a364be10025d0ad0f702de3",
+		"nonce": "0x6f",
+		"value": "0x0",
+		"gas": "0x186a0",
+		"maxPriorityFeePerGas": "0x3b9aca00",
+		"maxFeePerGas": "0x4a817c800",
+		"input": "0x60fe47b100000000000000000000000000000000000000000000000000000000feedbeef"
+	}`, string(b))
+}
+
+func TestDecodeTransaction2930And4844(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t)
+	defer done()
+
+	kp, err := secp256k1.GenerateSecp256k1KeyPair()
+	assert.NoError(t, err)
+
+	tx, err := c.DecodeTransaction(ctx, &DecodeTransactionRequest{
+		Transaction: testSignTypedTx(t, kp, 0x01, test2930Fields()),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, ethtypes.HexUint64(1), tx.Type)
+	assert.Equal(t, kp.Address.String(), tx.From.String())
+	assert.Equal(t, int64(1000), tx.GasPrice.Int64())
+
+	rawTx := testSignTypedTx(t, kp, txType4844, test4844Fields())
+	tx, err = c.DecodeTransaction(ctx, &DecodeTransactionRequest{Transaction: rawTx})
+	assert.NoError(t, err)
+	assert.Equal(t, kp.Address.String(), tx.From.String())
+	assert.Equal(t, int64(1337), tx.ChainID.Int64())
+	assert.Equal(t, int64(3000), tx.MaxFeePerBlobGas.Int64())
+	assert.Equal(t, []ethtypes.HexBytes0xPrefix{ethtypes.MustNewHexBytes0xPrefix("0x01a915e4d060149eb4365960e6a7a45f334393093061116b197e3240065ff2d8")}, tx.BlobVersionedHashes)
+	assert.Equal(t, ethtypes.HexBytes0xPrefix(keccak256(rawTx)), tx.Hash)
+}
+
+func TestDecodeTransactionErrors(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t)
+	defer done()
+
+	_, err := c.DecodeTransaction(ctx, &DecodeTransactionRequest{Transaction: testTypedTx(0x04, test2930Fields())})
+	assert.Regexp(t, "FF23108.*0x04", err)
+
+	_, err = c.DecodeTransaction(ctx, &DecodeTransactionRequest{Transaction: testTypedTx(0x01, test2930Fields()[0:10])})
+	assert.Regexp(t, "FF23105", err)
+
+	// The placeholder signature of the test fields is not a valid signature
+	fields := test2930Fields()
+	fields[8] = rlp.WrapInt(big.NewInt(5))
+	_, err = c.DecodeTransaction(ctx, &DecodeTransactionRequest{Transaction: testTypedTx(0x01, fields)})
+	assert.Regexp(t, "FF23109.*EIP-2930", err)
+}
+
+func TestDecodeTransactionRoute(t *testing.T) {
+	_, c, _, done := newTestConnector(t)
+	defer done()
+	url, close := newTestRouteServer(t, c)
+	defer close()
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"transaction": sampleSignedLegacyTX,
+	})
+	res, err := http.Post(url+"/decode/transaction", "application/json", bytes.NewReader(body))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var decoded DecodedTransaction
+	err = json.NewDecoder(res.Body).Decode(&decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, sampleSignedTXFrom, decoded.From.String())
+
+	body, _ = json.Marshal(map[string]interface{}{
+		"transaction": "0x04",
+	})
+	res, err = http.Post(url+"/decode/transaction", "application/json", bytes.NewReader(body))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+}
@@ -21,6 +21,7 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"strings"
 
 	"github.com/hyperledger/firefly-common/pkg/i18n"
@@ -32,6 +33,8 @@ import (
 )
 
 func (c *ethConnector) DeployContractPrepare(ctx context.Context, req *ffcapi.ContractDeployPrepareRequest) (res *ffcapi.TransactionPrepareResponse, reason ffcapi.ErrorReason, err error) {
+	ctx = withCorrelationIDs(ctx)
+	defer func() { err = c.exposeErrorDetails(reason, err) }()
 
 	// Parse the input JSON data, to build the call data
 	callData, constructor, err := c.prepareDeployData(ctx, req)
@@ -65,9 +68,9 @@ func (c *ethConnector) DeployContractPrepare(ctx context.Context, req *ffcapi.Co
 
 func (c *ethConnector) prepareDeployData(ctx context.Context, req *ffcapi.ContractDeployPrepareRequest) ([]byte, *abi.Entry, error) {
 	// Parse the bytecode as a hex string, or fallback to Base64
-	var bytecodeString string
-	if err := req.Contract.Unmarshal(ctx, &bytecodeString); err != nil {
-		return nil, nil, i18n.NewError(ctx, msgs.MsgDecodeBytecodeFailed)
+	bytecodeString, err := parseDeployContract(ctx, req.Contract)
+	if err != nil {
+		return nil, nil, err
 	}
 	bytecode, err := hex.DecodeString(strings.TrimPrefix(bytecodeString, "0x"))
 	if err != nil {
@@ -97,7 +100,7 @@ func (c *ethConnector) prepareDeployData(ctx context.Context, req *ffcapi.Contra
 		if p != nil {
 			err := p.Unmarshal(ctx, &ethParams[i])
 			if err != nil {
-				return nil, nil, i18n.NewError(ctx, msgs.MsgUnmarshalParamFail, i, err)
+				return nil, nil, withErrorDetails(i18n.NewError(ctx, msgs.MsgUnmarshalParamFail, i, err), &ErrorDetails{Field: fmt.Sprintf("params[%d]", i)})
 			}
 		}
 	}
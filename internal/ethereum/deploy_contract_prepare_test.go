@@ -293,7 +293,7 @@ func TestDeployContractPrepareEstimateNoConstructor(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Empty(t, reason)
 
-	fGasEstimate, _ := c.gasEstimationFactor.Float64()
+	fGasEstimate, _ := c.gas().estimationFactor.Float64()
 	assert.Equal(t, int64(float64(12345)*fGasEstimate), res.Gas.Int64())
 
 	mRPC.AssertExpectations(t)
@@ -0,0 +1,78 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+)
+
+// maxRuntimeCodeSize is the EIP-170 limit on the size of the code of a deployed contract
+const maxRuntimeCodeSize = 24576
+
+// DeployDryRunResponse is the result of simulating the deployment of a contract, without submitting a transaction
+type DeployDryRunResponse struct {
+	Gas                  *fftypes.FFBigInt         `json:"gas"`
+	TransactionData      string                    `json:"transactionData"`
+	RuntimeCodeSize      int                       `json:"runtimeCodeSize"`
+	RuntimeCodeHash      ethtypes.HexBytes0xPrefix `json:"runtimeCodeHash"`
+	ExceedsCodeSizeLimit bool                      `json:"exceedsCodeSizeLimit"`
+}
+
+// DeployContractDryRun validates a deployment without submitting it. The constructor arguments are encoded as they
+// would be by DeployContractPrepare, then the constructor is executed with an eth_call of the deployment data, which
+// returns the runtime code the contract would have, and the gas of the deployment is estimated.
+func (c *ethConnector) DeployContractDryRun(ctx context.Context, req *ffcapi.ContractDeployPrepareRequest) (*DeployDryRunResponse, ffcapi.ErrorReason, error) {
+	ctx = withCorrelationIDs(ctx)
+
+	callData, constructor, err := c.prepareDeployData(ctx, req)
+	if err != nil {
+		return nil, ffcapi.ErrorReasonInvalidInputs, err
+	}
+	tx, err := c.buildTx(ctx, txTypeDeployContract, req.From, "", req.Nonce, req.Gas, req.Value, callData)
+	if err != nil {
+		return nil, ffcapi.ErrorReasonInvalidInputs, err
+	}
+	errors, err := buildErrorsABI(ctx, req.Errors)
+	if err != nil {
+		return nil, ffcapi.ErrorReasonInvalidInputs, err
+	}
+
+	// The output of executing init code is the runtime code, rather than ABI encoded data, so reverts are only
+	// detected from the error returned by the node
+	runtimeCode, reason, err := c.callRaw(ctx, tx, errors, nil, "")
+	if err != nil {
+		return nil, reason, err
+	}
+	gas, reason, err := c.ensureGasEstimate(ctx, tx, constructor, errors, req.Gas)
+	if err != nil {
+		return nil, reason, err
+	}
+	log.L(ctx).Infof("Dry run of deploy transaction dataLen=%d gas=%s runtimeCodeSize=%d", len(callData), gas.Int(), len(runtimeCode))
+
+	return &DeployDryRunResponse{
+		Gas:                  gas,
+		TransactionData:      ethtypes.HexBytes0xPrefix(callData).String(),
+		RuntimeCodeSize:      len(runtimeCode),
+		RuntimeCodeHash:      keccak256(runtimeCode),
+		ExceedsCodeSizeLimit: len(runtimeCode) > maxRuntimeCodeSize,
+	}, "", nil
+}
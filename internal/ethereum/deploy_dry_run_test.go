@@ -0,0 +1,135 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-signer/pkg/ethsigner"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDeployContractDryRunRoute(t *testing.T) {
+	_, c, mRPC, done := newTestConnector(t)
+	defer done()
+	url, close := newTestRouteServer(t, c)
+	defer close()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_call", mock.MatchedBy(func(tx *ethsigner.Transaction) bool {
+		return tx.To == nil && strings.HasPrefix(tx.Data.String(), "0xdeadbeef")
+	}), "latest").
+		Run(func(args mock.Arguments) {
+			*(args[1].(*ethtypes.HexBytes0xPrefix)) = ethtypes.MustNewHexBytes0xPrefix("0x6080604052")
+		}).
+		Return(nil).Once()
+
+	res, err := http.Post(url+"/deploy/dryrun", "application/json", strings.NewReader(samplePrepareDeployTX))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var dryRun DeployDryRunResponse
+	err = json.NewDecoder(res.Body).Decode(&dryRun)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1000000), dryRun.Gas.Int64())
+	assert.True(t, strings.HasPrefix(dryRun.TransactionData, "0xdeadbeef"))
+	assert.Equal(t, 5, dryRun.RuntimeCodeSize)
+	assert.Equal(t, ethtypes.HexBytes0xPrefix(keccak256([]byte{0x60, 0x80, 0x60, 0x40, 0x52})), dryRun.RuntimeCodeHash)
+	assert.False(t, dryRun.ExceedsCodeSizeLimit)
+	mRPC.AssertExpectations(t)
+}
+
+func TestDeployContractDryRunEstimateGas(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_call", mock.Anything, "latest").
+		Run(func(args mock.Arguments) {
+			// Runtime code that looks like revert data is not treated as a revert
+			*(args[1].(*ethtypes.HexBytes0xPrefix)) = make([]byte, maxRuntimeCodeSize+4)
+		}).
+		Return(nil).Once()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_estimateGas", mock.Anything).
+		Run(func(args mock.Arguments) {
+			*(args[1].(*ethtypes.HexInteger)) = *ethtypes.NewHexInteger64(200000)
+		}).
+		Return(nil).Once()
+
+	var req ffcapi.ContractDeployPrepareRequest
+	err := json.Unmarshal([]byte(samplePrepareDeployTX), &req)
+	assert.NoError(t, err)
+	req.Gas = nil
+	dryRun, _, err := c.DeployContractDryRun(ctx, &req)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(300000), dryRun.Gas.Int64())
+	assert.Equal(t, maxRuntimeCodeSize+4, dryRun.RuntimeCodeSize)
+	assert.True(t, dryRun.ExceedsCodeSizeLimit)
+	mRPC.AssertExpectations(t)
+}
+
+func TestDeployContractDryRunReverted(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_call", mock.Anything, "latest").
+		Return(&rpcbackend.RPCError{Code: 3, Message: "execution reverted", Data: *fftypes.JSONAnyPtr(`"0x08c379a0000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000000114d75707065747279206465746563746564000000000000000000000000000000"`)}).Once()
+
+	var req ffcapi.ContractDeployPrepareRequest
+	err := json.Unmarshal([]byte(samplePrepareDeployTX), &req)
+	assert.NoError(t, err)
+	_, reason, err := c.DeployContractDryRun(ctx, &req)
+	assert.Regexp(t, "FF23021.*Muppetry detected", err)
+	assert.Equal(t, ffcapi.ErrorReasonTransactionReverted, reason)
+
+	// A failed estimate falls back to the result of the call
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_call", mock.Anything, "latest").Return(nil)
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_estimateGas", mock.Anything).Return(&rpcbackend.RPCError{Message: "pop"}).Once()
+	req.Gas = nil
+	_, _, err = c.DeployContractDryRun(ctx, &req)
+	assert.Regexp(t, "pop", err)
+}
+
+func TestDeployContractDryRunBadInputs(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t)
+	defer done()
+
+	var req ffcapi.ContractDeployPrepareRequest
+	err := json.Unmarshal([]byte(samplePrepareDeployTX), &req)
+	assert.NoError(t, err)
+
+	badParams := req
+	badParams.Params = []*fftypes.JSONAny{fftypes.JSONAnyPtr(`"not a number"`)}
+	_, reason, err := c.DeployContractDryRun(ctx, &badParams)
+	assert.Regexp(t, "FF22037", err)
+	assert.Equal(t, ffcapi.ErrorReasonInvalidInputs, reason)
+
+	badFrom := req
+	badFrom.From = "wrong"
+	_, _, err = c.DeployContractDryRun(ctx, &badFrom)
+	assert.Regexp(t, "FF23019", err)
+
+	badErrors := req
+	badErrors.Errors = []*fftypes.JSONAny{fftypes.JSONAnyPtr(`!json`)}
+	_, _, err = c.DeployContractDryRun(ctx, &badErrors)
+	assert.Regexp(t, "FF23050", err)
+}
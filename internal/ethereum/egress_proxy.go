@@ -0,0 +1,50 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+)
+
+// egressProxySchemes are the proxy protocols supported by the Go HTTP transport. HTTP proxies tunnel requests to
+// https:// endpoints with CONNECT, and socks5h:// resolves the host name of the endpoint on the proxy.
+var egressProxySchemes = []string{"http", "https", "socks5", "socks5h"}
+
+// egressProxyURL validates the proxy.url of a JSON/RPC endpoint section, adding the credentials from
+// proxy.username and proxy.password so they do not need to be embedded in the URL
+func egressProxyURL(ctx context.Context, conf config.Section) (string, error) {
+	proxyURL := conf.GetString(ffresty.HTTPConfigProxyURL)
+	if proxyURL == "" {
+		return "", nil
+	}
+	u, err := url.Parse(proxyURL)
+	if err != nil || u.Host == "" || !slices.Contains(egressProxySchemes, strings.ToLower(u.Scheme)) {
+		return "", i18n.NewError(ctx, msgs.MsgInvalidEgressProxyURL, egressProxySchemes)
+	}
+	if username := conf.GetString(EgressProxyUsername); username != "" {
+		u.User = url.UserPassword(username, conf.GetString(EgressProxyPassword))
+	}
+	return u.String(), nil
+}
@@ -0,0 +1,169 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+)
+
+// The canonical ERC-4337 EntryPoint contracts, deployed at the same address on every chain
+var (
+	EntryPointV06Address = ethtypes.MustNewAddress("0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789")
+	EntryPointV07Address = ethtypes.MustNewAddress("0x0000000071727De22E5E9d8BAf0edAc6f37da032")
+)
+
+// The events of the EntryPoint, which are the same in v0.6 and v0.7
+var (
+	userOperationEvent = &abi.Entry{
+		Type: abi.Event,
+		Name: "UserOperationEvent",
+		Inputs: abi.ParameterArray{
+			{Name: "userOpHash", Type: "bytes32", Indexed: true},
+			{Name: "sender", Type: "address", Indexed: true},
+			{Name: "paymaster", Type: "address", Indexed: true},
+			{Name: "nonce", Type: "uint256"},
+			{Name: "success", Type: "bool"},
+			{Name: "actualGasCost", Type: "uint256"},
+			{Name: "actualGasUsed", Type: "uint256"},
+		},
+	}
+	accountDeployedEvent = &abi.Entry{
+		Type: abi.Event,
+		Name: "AccountDeployed",
+		Inputs: abi.ParameterArray{
+			{Name: "userOpHash", Type: "bytes32", Indexed: true},
+			{Name: "sender", Type: "address", Indexed: true},
+			{Name: "factory", Type: "address"},
+			{Name: "paymaster", Type: "address"},
+		},
+	}
+)
+
+type listenerPreset struct {
+	event   *abi.Entry
+	address *ethtypes.Address0xHex
+}
+
+// listenerPresets can be used in place of the event of a listener filter. The address of the filter
+// defaults to that of the preset, and can be set to listen to a different deployment.
+var listenerPresets = map[string]*listenerPreset{
+	"entryPointV06.UserOperationEvent": {event: userOperationEvent, address: EntryPointV06Address},
+	"entryPointV06.AccountDeployed":    {event: accountDeployedEvent, address: EntryPointV06Address},
+	"entryPointV07.UserOperationEvent": {event: userOperationEvent, address: EntryPointV07Address},
+	"entryPointV07.AccountDeployed":    {event: accountDeployedEvent, address: EntryPointV07Address},
+}
+
+func resolveListenerPreset(ctx context.Context, f *eventFilter) error {
+	preset := listenerPresets[f.Preset]
+	if preset == nil {
+		names := make([]string, 0, len(listenerPresets))
+		for name := range listenerPresets {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return i18n.NewError(ctx, msgs.MsgUnknownListenerPreset, f.Preset, strings.Join(names, ","))
+	}
+	f.Event = preset.event
+	if f.Address == nil {
+		f.Address = preset.address
+	}
+	return nil
+}
+
+// PackedUserOperation is an ERC-4337 v0.7 user operation, as submitted to handleOps on the EntryPoint
+type PackedUserOperation struct {
+	Sender             *ethtypes.Address0xHex    `json:"sender"`
+	Nonce              *ethtypes.HexInteger      `json:"nonce"`
+	InitCode           ethtypes.HexBytes0xPrefix `json:"initCode"`
+	CallData           ethtypes.HexBytes0xPrefix `json:"callData"`
+	AccountGasLimits   ethtypes.HexBytes0xPrefix `json:"accountGasLimits"` // verificationGasLimit and callGasLimit packed into a bytes32
+	PreVerificationGas *ethtypes.HexInteger      `json:"preVerificationGas"`
+	GasFees            ethtypes.HexBytes0xPrefix `json:"gasFees"` // maxPriorityFeePerGas and maxFeePerGas packed into a bytes32
+	PaymasterAndData   ethtypes.HexBytes0xPrefix `json:"paymasterAndData"`
+	Signature          ethtypes.HexBytes0xPrefix `json:"signature,omitempty"` // not part of the hash
+}
+
+// UserOpHashRequest defaults to the v0.7 EntryPoint, and the chain of the connected node
+type UserOpHashRequest struct {
+	UserOp     *PackedUserOperation   `json:"userOp"`
+	EntryPoint *ethtypes.Address0xHex `json:"entryPoint,omitempty"`
+	ChainID    *ethtypes.HexInteger   `json:"chainId,omitempty"`
+}
+
+type UserOpHashResponse struct {
+	UserOpHash ethtypes.HexBytes0xPrefix `json:"userOpHash"`
+	EntryPoint *ethtypes.Address0xHex    `json:"entryPoint"`
+	ChainID    *ethtypes.HexInteger      `json:"chainId"`
+}
+
+// UserOpHash computes the hash the EntryPoint emits as the userOpHash of the events of a packed user operation,
+// so an operation can be matched to its UserOperationEvent
+func (c *ethConnector) UserOpHash(ctx context.Context, req *UserOpHashRequest) (*UserOpHashResponse, error) {
+	op := req.UserOp
+	if op == nil || op.Sender == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgMissingUserOperation)
+	}
+	res := &UserOpHashResponse{EntryPoint: req.EntryPoint, ChainID: req.ChainID}
+	if res.EntryPoint == nil {
+		res.EntryPoint = EntryPointV07Address
+	}
+	if res.ChainID == nil {
+		chainID, err := c.connectedChainID(ctx)
+		if err != nil {
+			return nil, err
+		}
+		res.ChainID = (*ethtypes.HexInteger)(chainID)
+	}
+	words := map[string][]byte{
+		"nonce":              op.Nonce.BigInt().Bytes(),
+		"accountGasLimits":   op.AccountGasLimits,
+		"preVerificationGas": op.PreVerificationGas.BigInt().Bytes(),
+		"gasFees":            op.GasFees,
+		"chainId":            res.ChainID.BigInt().Bytes(),
+	}
+	for name, word := range words {
+		if len(word) > 32 {
+			return nil, i18n.NewError(ctx, msgs.MsgInvalidUserOperationField, name)
+		}
+	}
+	opHash := keccak256(
+		abiWord(op.Sender[:]),
+		abiWord(words["nonce"]),
+		keccak256(op.InitCode),
+		keccak256(op.CallData),
+		abiWord(words["accountGasLimits"]),
+		abiWord(words["preVerificationGas"]),
+		abiWord(words["gasFees"]),
+		keccak256(op.PaymasterAndData),
+	)
+	res.UserOpHash = keccak256(opHash, abiWord(res.EntryPoint[:]), abiWord(words["chainId"]))
+	return res, nil
+}
+
+// abiWord left pads a value of up to 32 bytes to a word of the ABI encoding
+func abiWord(b []byte) []byte {
+	word := make([]byte, 32)
+	copy(word[32-len(b):], b)
+	return word
+}
@@ -0,0 +1,135 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListenerPresets(t *testing.T) {
+	ctx := context.Background()
+	signature, filters, err := parseEventFilters(ctx, []fftypes.JSONAny{
+		*fftypes.JSONAnyPtr(`{"preset": "entryPointV07.UserOperationEvent"}`),
+		*fftypes.JSONAnyPtr(`{"preset": "entryPointV06.AccountDeployed", "address": "0x1234567890123456789012345678901234567890"}`),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "[0x0000000071727de22e5e9d8baf0edac6f37da032:UserOperationEvent(bytes32,address,address,uint256,bool,uint256,uint256),"+
+		"0x1234567890123456789012345678901234567890:AccountDeployed(bytes32,address,address,address)]", signature)
+	assert.Equal(t, "0x49628fd1471006c1482da88028e9ce4dbb080b815c9b0344d39e5a8e6ec1419f", filters[0].Topic0.String())
+	assert.Equal(t, "0xd51a9c61267aa6196961883ecf5ff2da6619c37dac0fa92122513fb32c032d2d", filters[1].Topic0.String())
+
+	// The preset is kept, so the resolved filter is still a preset
+	b, _ := json.Marshal(filters[0])
+	assert.Contains(t, string(b), `"preset":"entryPointV07.UserOperationEvent"`)
+
+	_, _, err = parseEventFilters(ctx, []fftypes.JSONAny{*fftypes.JSONAnyPtr(`{"preset": "wrong"}`)})
+	assert.Regexp(t, "FF23157.*wrong.*entryPointV06.AccountDeployed", err)
+}
+
+func TestUserOpHash(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+	url, close := newTestRouteServer(t, c)
+	defer close()
+
+	c.ethChainID.Store(nil)
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_chainId").
+		Run(func(args mock.Arguments) {
+			*(args[1].(*ethtypes.HexInteger)) = *ethtypes.NewHexInteger64(11155111)
+		}).
+		Return(nil).Once()
+
+	op := &PackedUserOperation{
+		Sender:             ethtypes.MustNewAddress("0x1234567890123456789012345678901234567890"),
+		Nonce:              ethtypes.NewHexInteger64(5),
+		InitCode:           ethtypes.MustNewHexBytes0xPrefix("0x"),
+		CallData:           ethtypes.MustNewHexBytes0xPrefix("0xb61d27f6"),
+		AccountGasLimits:   ethtypes.MustNewHexBytes0xPrefix("0x000000000000000000000000000186a0000000000000000000000000000f4240"),
+		PreVerificationGas: ethtypes.NewHexInteger64(50000),
+		GasFees:            ethtypes.MustNewHexBytes0xPrefix("0x0000000000000000000000003b9aca00000000000000000000000004a817c800"),
+		PaymasterAndData:   ethtypes.MustNewHexBytes0xPrefix("0x"),
+		Signature:          ethtypes.MustNewHexBytes0xPrefix("0xfeedbeef"),
+	}
+
+	// Compare against the ABI encoding of the fields, as the EntryPoint computes the hash
+	words := abi.ParameterArray{
+		{Type: "address"}, {Type: "uint256"}, {Type: "bytes32"}, {Type: "bytes32"},
+		{Type: "bytes32"}, {Type: "uint256"}, {Type: "bytes32"}, {Type: "bytes32"},
+	}
+	encoded, err := words.EncodeABIDataValuesCtx(ctx, []interface{}{
+		op.Sender.String(), big.NewInt(5), keccak256(op.InitCode), keccak256(op.CallData),
+		[]byte(op.AccountGasLimits), big.NewInt(50000), []byte(op.GasFees), keccak256(op.PaymasterAndData),
+	})
+	assert.NoError(t, err)
+	outer, err := abi.ParameterArray{{Type: "bytes32"}, {Type: "address"}, {Type: "uint256"}}.EncodeABIDataValuesCtx(ctx, []interface{}{
+		keccak256(encoded), EntryPointV07Address.String(), big.NewInt(11155111),
+	})
+	assert.NoError(t, err)
+	expected := ethtypes.HexBytes0xPrefix(keccak256(outer))
+
+	body, _ := json.Marshal(&UserOpHashRequest{UserOp: op})
+	res, err := http.Post(url+"/erc4337/userophash", "application/json", bytes.NewReader(body))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var hashRes UserOpHashResponse
+	err = json.NewDecoder(res.Body).Decode(&hashRes)
+	assert.NoError(t, err)
+	assert.Equal(t, expected.String(), hashRes.UserOpHash.String())
+	assert.Equal(t, int64(11155111), hashRes.ChainID.BigInt().Int64())
+	assert.Equal(t, EntryPointV07Address.String(), hashRes.EntryPoint.String())
+
+	// The signature is not part of the hash, and the chain can be supplied
+	op.Signature = nil
+	hashRes2, err := c.UserOpHash(ctx, &UserOpHashRequest{UserOp: op, ChainID: ethtypes.NewHexInteger64(11155111)})
+	assert.NoError(t, err)
+	assert.Equal(t, expected.String(), hashRes2.UserOpHash.String())
+	hashRes3, err := c.UserOpHash(ctx, &UserOpHashRequest{UserOp: op, ChainID: ethtypes.NewHexInteger64(11155111), EntryPoint: EntryPointV06Address})
+	assert.NoError(t, err)
+	assert.NotEqual(t, expected.String(), hashRes3.UserOpHash.String())
+	mRPC.AssertExpectations(t)
+}
+
+func TestUserOpHashErrors(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	_, err := c.UserOpHash(ctx, &UserOpHashRequest{})
+	assert.Regexp(t, "FF23158", err)
+
+	op := &PackedUserOperation{Sender: ethtypes.MustNewAddress("0x1234567890123456789012345678901234567890")}
+	c.ethChainID.Store(nil)
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_chainId").Return(&rpcbackend.RPCError{Message: "pop"}).Once()
+	_, err = c.UserOpHash(ctx, &UserOpHashRequest{UserOp: op})
+	assert.Regexp(t, "pop", err)
+
+	op.GasFees = ethtypes.MustNewHexBytes0xPrefix("0x" + strings.Repeat("ff", 33))
+	_, err = c.UserOpHash(ctx, &UserOpHashRequest{UserOp: op, ChainID: ethtypes.NewHexInteger64(1)})
+	assert.Regexp(t, "FF23159.*gasFees", err)
+}
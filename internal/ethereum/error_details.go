@@ -0,0 +1,233 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"regexp"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+)
+
+// The actions suggested to the consumer of an error, so it can decide what to do without parsing the message
+const (
+	SuggestedActionRetry             = "retry"
+	SuggestedActionFixInputs         = "fix_inputs"
+	SuggestedActionReviewRevert      = "review_revert"
+	SuggestedActionFundAccount       = "fund_account"
+	SuggestedActionResyncNonce       = "resync_nonce"
+	SuggestedActionIncreaseGasPrice  = "increase_gas_price"
+	SuggestedActionAwaitReceipt      = "await_receipt"
+	SuggestedActionRequestPermission = "request_permission"
+	SuggestedActionUpdatePolicy      = "update_policy"
+)
+
+// ErrorDetails is the machine-readable detail of an error, alongside the formatted message
+type ErrorDetails struct {
+	Code              string                    `json:"code,omitempty"`
+	Reason            ffcapi.ErrorReason        `json:"reason,omitempty"`
+	Retryable         bool                      `json:"retryable"`
+	SuggestedAction   string                    `json:"suggestedAction,omitempty"`
+	Field             string                    `json:"field,omitempty"`
+	ProviderErrorCode int64                     `json:"providerErrorCode,omitempty"`
+	RevertSelector    ethtypes.HexBytes0xPrefix `json:"revertSelector,omitempty"`
+	RevertError       string                    `json:"revertError,omitempty"`
+	RevertData        ethtypes.HexBytes0xPrefix `json:"revertData,omitempty"`
+}
+
+// errorCodeRegex extracts the FF23xxx (or other FireFly) code from the start of a formatted message
+var errorCodeRegex = regexp.MustCompile(`^(FF\d{5}):`)
+
+// inputFields are the request fields that the errors for invalid inputs relate to
+var inputFields = map[i18n.ErrorMessageKey]string{
+	msgs.MsgInvalidFromAddress:      "from",
+	msgs.MsgInvalidToAddress:        "to",
+	msgs.MsgInvalidTXData:           "transactionData",
+	msgs.MsgGasPriceError:           "gasPrice",
+	msgs.MsgInvalidGasPrice:         "gasPrice",
+	msgs.MsgInvalidTxConfirmations:  "gasPrice",
+	msgs.MsgUnmarshalABIMethodFail:  "method",
+	msgs.MsgInvalidMethodSelector:   "method",
+	msgs.MsgMethodNotInABI:          "method",
+	msgs.MsgAmbiguousMethod:         "method",
+	msgs.MsgUnmarshalABIErrorsFail:  "errors",
+	msgs.MsgDecodeBytecodeFailed:    "contract",
+	msgs.MsgTransactionTooLarge:     "transactionData",
+	msgs.MsgGasExceedsBlockGasLimit: "gas",
+}
+
+// detailedError carries the details gathered where an error occurred, up to the FFCAPI method that returns it
+type detailedError struct {
+	err     error
+	details *ErrorDetails
+}
+
+func (de *detailedError) Error() string {
+	return de.err.Error()
+}
+
+func (de *detailedError) Unwrap() error {
+	return de.err
+}
+
+// withErrorDetails attaches details to an error, merging them over any that are already attached
+func withErrorDetails(err error, details *ErrorDetails) error {
+	var de *detailedError
+	if errors.As(err, &de) {
+		merged := *de.details
+		mergeErrorDetails(&merged, details)
+		return &detailedError{err: de.err, details: &merged}
+	}
+	return &detailedError{err: err, details: details}
+}
+
+func mergeErrorDetails(target, from *ErrorDetails) {
+	if from.Field != "" {
+		target.Field = from.Field
+	}
+	if from.ProviderErrorCode != 0 {
+		target.ProviderErrorCode = from.ProviderErrorCode
+	}
+	if from.RevertSelector != nil {
+		target.RevertSelector = from.RevertSelector
+		target.RevertError = from.RevertError
+		target.RevertData = from.RevertData
+	}
+}
+
+// rpcErrorDetails are the details of an error returned by the node
+func rpcErrorDetails(rpcErr *rpcbackend.RPCError) *ErrorDetails {
+	return &ErrorDetails{ProviderErrorCode: rpcErr.Code}
+}
+
+// revertErrorDetails are the details of revert data, with the name of the error it was decoded with
+func revertErrorDetails(revertData []byte, errorAbis []*abi.Entry) *ErrorDetails {
+	details := &ErrorDetails{}
+	if len(revertData) < 4 {
+		return details
+	}
+	selector := revertData[0:4]
+	details.RevertSelector = ethtypes.HexBytes0xPrefix(selector)
+	details.RevertData = ethtypes.HexBytes0xPrefix(revertData)
+	switch {
+	case bytes.Equal(selector, defaultErrorID):
+		details.RevertError = defaultError.Name
+	case bytes.Equal(selector, defaultPanicID):
+		details.RevertError = defaultPanic.Name
+	default:
+		for _, e := range errorAbis {
+			if bytes.Equal(selector, e.FunctionSelectorBytes()) {
+				details.RevertError = e.Name
+				break
+			}
+		}
+	}
+	return details
+}
+
+// errorDetailsFor builds the full details of an error returned from an FFCAPI method
+func errorDetailsFor(reason ffcapi.ErrorReason, err error) *ErrorDetails {
+	details := &ErrorDetails{}
+	var de *detailedError
+	if errors.As(err, &de) {
+		*details = *de.details
+	}
+	var ffErr i18n.FFError
+	if details.Field == "" && errors.As(err, &ffErr) {
+		details.Field = inputFields[ffErr.MessageKey()]
+	}
+	return completeErrorDetails(details, reason, err.Error())
+}
+
+// receiptErrorDetails are the details of a failed transaction, from the return value and error message of the receipt
+func receiptErrorDetails(returnValue, errorMessage *string) *ErrorDetails {
+	var returnData []byte
+	if returnValue != nil {
+		returnData, _ = hex.DecodeString(padHexData(*returnValue))
+	}
+	var message string
+	if errorMessage != nil {
+		message = *errorMessage
+	}
+	return completeErrorDetails(revertErrorDetails(returnData, nil), ffcapi.ErrorReasonTransactionReverted, message)
+}
+
+func completeErrorDetails(details *ErrorDetails, reason ffcapi.ErrorReason, message string) *ErrorDetails {
+	if match := errorCodeRegex.FindStringSubmatch(message); match != nil {
+		details.Code = match[1]
+	}
+	details.Reason = reason
+	details.Retryable = errorRetryable(reason)
+	details.SuggestedAction = suggestedAction(reason)
+	return details
+}
+
+func suggestedAction(reason ffcapi.ErrorReason) string {
+	switch reason {
+	case ffcapi.ErrorReasonInvalidInputs:
+		return SuggestedActionFixInputs
+	case ffcapi.ErrorReasonTransactionReverted:
+		return SuggestedActionReviewRevert
+	case ffcapi.ErrorReasonInsufficientFunds:
+		return SuggestedActionFundAccount
+	case ffcapi.ErrorReasonNonceTooLow:
+		return SuggestedActionResyncNonce
+	case ffcapi.ErrorReasonTransactionUnderpriced:
+		return SuggestedActionIncreaseGasPrice
+	case ffcapi.ErrorKnownTransaction:
+		return SuggestedActionAwaitReceipt
+	case ErrorReasonNotPermitted:
+		return SuggestedActionRequestPermission
+	case ErrorReasonPolicyViolation:
+		return SuggestedActionUpdatePolicy
+	default:
+		return SuggestedActionRetry
+	}
+}
+
+// exposedError is an error with its details serialized after the message, as FFTM only passes the message of an
+// error through to the operation
+type exposedError struct {
+	err     error
+	details *ErrorDetails
+}
+
+func (ee *exposedError) Error() string {
+	b, _ := json.Marshal(ee.details)
+	return ee.err.Error() + " errorDetails=" + string(b)
+}
+
+func (ee *exposedError) Unwrap() error {
+	return ee.err
+}
+
+// exposeErrorDetails returns the error to pass back from an FFCAPI method, with its details appended to the
+// message when enabled
+func (c *ethConnector) exposeErrorDetails(reason ffcapi.ErrorReason, err error) error {
+	if err == nil || !c.errorDetails {
+		return err
+	}
+	return &exposedError{err: err, details: errorDetailsFor(reason, err)}
+}
@@ -0,0 +1,204 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func enableErrorDetails(conf config.Section) {
+	conf.Set(ErrorDetailsEnabled, true)
+}
+
+func parseErrorDetails(t *testing.T, err error) *ErrorDetails {
+	parts := strings.SplitN(err.Error(), " errorDetails=", 2)
+	assert.Len(t, parts, 2)
+	var details ErrorDetails
+	assert.NoError(t, json.Unmarshal([]byte(parts[1]), &details))
+	return &details
+}
+
+func TestErrorDetailsQueryRevert(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t, enableErrorDetails)
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_call", mock.Anything, "latest").
+		Run(func(args mock.Arguments) {
+			*(args[1].(*ethtypes.HexBytes0xPrefix)) = ethtypes.MustNewHexBytes0xPrefix("0x391ad4e000000000000000000000000000000000000000000000000000000000000000140000000000000000000000000000000000000000000000000000000000000014")
+		}).
+		Return(nil).Once()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_call", mock.Anything, "latest").
+		Return(&rpcbackend.RPCError{Code: 3, Message: "execution reverted", Data: *fftypes.JSONAnyPtr(`"0x08c379a00000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000001d5468652073746f7265642076616c756520697320746f6f20736d616c6c000000"`)}).Once()
+
+	var req ffcapi.QueryInvokeRequest
+	err := json.Unmarshal([]byte(sampleExecQuery), &req)
+	assert.NoError(t, err)
+
+	_, reason, err := c.QueryInvoke(ctx, &req)
+	assert.Equal(t, ffcapi.ErrorReasonTransactionReverted, reason)
+	assert.Regexp(t, `^FF23021: EVM reverted: GreaterThanTen\("20", "20"\) errorDetails=`, err)
+	details := parseErrorDetails(t, err)
+	assert.Equal(t, "FF23021", details.Code)
+	assert.Equal(t, ffcapi.ErrorReasonTransactionReverted, details.Reason)
+	assert.False(t, details.Retryable)
+	assert.Equal(t, SuggestedActionReviewRevert, details.SuggestedAction)
+	assert.Equal(t, "0x391ad4e0", details.RevertSelector.String())
+	assert.Equal(t, "GreaterThanTen", details.RevertError)
+	assert.Len(t, details.RevertData, 68)
+
+	_, _, err = c.QueryInvoke(ctx, &req)
+	details = parseErrorDetails(t, err)
+	assert.Equal(t, "0x08c379a0", details.RevertSelector.String())
+	assert.Equal(t, "Error", details.RevertError)
+	assert.Equal(t, int64(3), details.ProviderErrorCode)
+}
+
+func TestErrorDetailsGasEstimateProviderError(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t, enableErrorDetails)
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_estimateGas", mock.Anything).
+		Return(&rpcbackend.RPCError{Code: rpcCodeLimitExceeded, Message: "slow down"})
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_call", mock.Anything, "latest").
+		Return(&rpcbackend.RPCError{Code: rpcCodeLimitExceeded, Message: "slow down"})
+
+	_, reason, err := c.GasEstimate(ctx, &ffcapi.TransactionInput{
+		TransactionHeaders: ffcapi.TransactionHeaders{From: "0xb480F96c0a3d6E9e9a263e4665a39bFa6c4d01E8", To: "0xe1a078b9e2b145d0a7387f09277c6ae1d9470771"},
+	})
+	assert.Equal(t, ErrorReasonRateLimited, reason)
+	details := parseErrorDetails(t, err)
+	assert.Empty(t, details.Code)
+	assert.Equal(t, int64(rpcCodeLimitExceeded), details.ProviderErrorCode)
+	assert.True(t, details.Retryable)
+	assert.Equal(t, SuggestedActionRetry, details.SuggestedAction)
+}
+
+func TestErrorDetailsPrepareInvalidField(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t, enableErrorDetails)
+	defer done()
+
+	var req ffcapi.TransactionPrepareRequest
+	err := json.Unmarshal([]byte(samplePrepareTXBadTo), &req)
+	assert.NoError(t, err)
+	_, reason, err := c.TransactionPrepare(ctx, &req)
+	assert.Equal(t, ffcapi.ErrorReasonInvalidInputs, reason)
+	details := parseErrorDetails(t, err)
+	assert.Equal(t, "FF23020", details.Code)
+	assert.Equal(t, "to", details.Field)
+	assert.Equal(t, SuggestedActionFixInputs, details.SuggestedAction)
+}
+
+func TestErrorDetailsSend(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t, enableErrorDetails)
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_sendTransaction", mock.Anything).
+		Return(&rpcbackend.RPCError{Code: -32000, Message: "nonce too low"})
+
+	var req ffcapi.TransactionSendRequest
+	err := json.Unmarshal([]byte(sampleSendTX), &req)
+	assert.NoError(t, err)
+	_, reason, err := c.TransactionSend(ctx, &req)
+	assert.Equal(t, ffcapi.ErrorReasonNonceTooLow, reason)
+	details := parseErrorDetails(t, err)
+	assert.Equal(t, int64(-32000), details.ProviderErrorCode)
+	assert.Equal(t, SuggestedActionResyncNonce, details.SuggestedAction)
+}
+
+func TestErrorDetailsDisabled(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t)
+	defer done()
+
+	var req ffcapi.TransactionPrepareRequest
+	err := json.Unmarshal([]byte(samplePrepareTXBadTo), &req)
+	assert.NoError(t, err)
+	_, _, err = c.TransactionPrepare(ctx, &req)
+	assert.Regexp(t, "FF23020", err)
+	assert.NotContains(t, err.Error(), "errorDetails")
+}
+
+func TestWithErrorDetailsMerge(t *testing.T) {
+	base := errors.New("pop")
+	err := withErrorDetails(base, &ErrorDetails{ProviderErrorCode: -32000})
+	err = withErrorDetails(err, &ErrorDetails{Field: "params[1]"})
+	err = withErrorDetails(err, revertErrorDetails([]byte{0x01, 0x02, 0x03, 0x04}, nil))
+	assert.Equal(t, "pop", err.Error())
+	assert.ErrorIs(t, err, base)
+
+	details := errorDetailsFor(ffcapi.ErrorReasonInvalidInputs, err)
+	assert.Equal(t, int64(-32000), details.ProviderErrorCode)
+	assert.Equal(t, "params[1]", details.Field)
+	assert.Equal(t, "0x01020304", details.RevertSelector.String())
+	assert.Empty(t, details.RevertError)
+
+	assert.Nil(t, revertErrorDetails([]byte{0x01}, nil).RevertSelector)
+
+	exposed := &exposedError{err: base, details: details}
+	assert.ErrorIs(t, exposed, base)
+
+	for reason, action := range map[ffcapi.ErrorReason]string{
+		ffcapi.ErrorReasonInsufficientFunds:      SuggestedActionFundAccount,
+		ffcapi.ErrorReasonTransactionUnderpriced: SuggestedActionIncreaseGasPrice,
+		ffcapi.ErrorKnownTransaction:             SuggestedActionAwaitReceipt,
+		ErrorReasonNotPermitted:                  SuggestedActionRequestPermission,
+		ErrorReasonPolicyViolation:               SuggestedActionUpdatePolicy,
+		ErrorReasonTimeout:                       SuggestedActionRetry,
+	} {
+		assert.Equal(t, action, suggestedAction(reason))
+	}
+}
+
+func TestErrorDetailsReceipt(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionReceipt", mock.Anything).
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			err := json.Unmarshal([]byte(sampleJSONRPCReceiptFailedWithRevertReason), args[1])
+			assert.NoError(t, err)
+		})
+
+	var req ffcapi.TransactionReceiptRequest
+	err := json.Unmarshal([]byte(sampleGetReceipt), &req)
+	assert.NoError(t, err)
+	res, _, err := c.TransactionReceipt(ctx, &req)
+	assert.NoError(t, err)
+
+	var extraInfo receiptExtraInfo
+	err = json.Unmarshal(res.ExtraInfo.Bytes(), &extraInfo)
+	assert.NoError(t, err)
+	assert.Equal(t, ffcapi.ErrorReasonTransactionReverted, extraInfo.ErrorDetails.Reason)
+	assert.Equal(t, "0x08c379a0", extraInfo.ErrorDetails.RevertSelector.String())
+	assert.Equal(t, "Error", extraInfo.ErrorDetails.RevertError)
+	assert.Empty(t, extraInfo.ErrorDetails.Code)
+	assert.False(t, extraInfo.ErrorDetails.Retryable)
+
+	details := receiptErrorDetails(nil, nil)
+	assert.Nil(t, details.RevertSelector)
+}
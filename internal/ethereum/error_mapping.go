@@ -19,11 +19,135 @@ package ethereum
 import (
 	"strings"
 
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
 	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
 )
 
 type ethRPCMethodCategory int
 
+// ErrorReasonNotPermitted is returned when a permissioned network rejects a transaction, because the
+// sending account (or the node) is not permitted by the permissioning rules of the network. This is a
+// governance problem to be resolved by the network operators, rather than a problem with the transaction.
+const ErrorReasonNotPermitted ffcapi.ErrorReason = "not_permitted"
+
+// permissioningErrors are the messages Besu returns when local or onchain account and node permissioning
+// rejects a transaction, or the simulation of a transaction
+var permissioningErrors = []string{
+	"sender account not authorized to send transactions",
+	"sender not authorized to create contracts",
+	"account not authorized to create contracts",
+	"not permitted by permissioning",
+	"node not permitted",
+}
+
+// ErrorReasonPolicyViolation is returned when the connector rejects a transaction because it is not permitted by
+// the transaction policy in the connector configuration, before it is sent to the node. As with not_permitted,
+// the transaction manager retries the submission, so it proceeds if the policy is changed.
+const ErrorReasonPolicyViolation ffcapi.ErrorReason = "policy_violation"
+
+// ErrorReasonRateLimited is returned when the node, or the provider in front of it, has rejected the request
+// because a rate limit or quota is exceeded. The request can be retried unchanged once the limit resets.
+const ErrorReasonRateLimited ffcapi.ErrorReason = "rate_limited"
+
+// ErrorReasonTimeout is returned when no response was received in time. The request can be retried, but for
+// a submission the transaction might have been accepted by the node, so the retry must be idempotent.
+const ErrorReasonTimeout ffcapi.ErrorReason = "timeout"
+
+// ErrorReasonNotSent is returned when the connector gave up on a request before sending it to the node, because
+// the caller stopped waiting. Unlike a timeout, the node never saw the request, so a retry is always safe.
+const ErrorReasonNotSent ffcapi.ErrorReason = "not_sent"
+
+const (
+	rpcCodeInvalidParams = -32602
+	// rpcCodeLimitExceeded is the EIP-1474 code used by nodes and providers for rate limits
+	rpcCodeLimitExceeded = -32005
+)
+
+// filterNotFoundErrors are the errors of the clients for a filter that has expired, or was installed on another node
+var filterNotFoundErrors = []string{
+	"filter not found", // geth, besu
+	"does not exist",   // nethermind
+}
+
+var rateLimitErrors = []string{
+	"rate limit",
+	"too many requests",
+	"request count exceeded",
+	"exceeded its compute units",
+}
+
+var timeoutErrors = []string{
+	"timeout",
+	"timed out",
+	"deadline exceeded",
+	"context canceled", // the caller gave up, so a submission might still have been accepted
+}
+
+// connectorErrorReasons classifies the errors the connector returns in place of a response from the node, by the
+// code at the start of the message. The code is checked rather than the text, as the messages of these errors
+// include the context error, which would otherwise match the timeout errors.
+var connectorErrorReasons = map[string]ffcapi.ErrorReason{
+	string(msgs.MsgRPCSchedulingCancelled): ErrorReasonNotSent,
+	string(msgs.MsgRPCRequestAbandoned):    ErrorReasonNotSent,
+	string(msgs.MsgIPCConnectFailed):       ffcapi.ErrorReasonDownstreamDown,
+	string(msgs.MsgIPCConnectionLost):      ffcapi.ErrorReasonDownstreamDown,
+}
+
+// downstreamDownErrors are the transport errors from the HTTP and IPC clients, when the node could not be reached
+var downstreamDownErrors = []string{
+	"connection refused",
+	"connection reset",
+	"no such host",
+	"broken pipe",
+	"unexpected eof",
+	"502 bad gateway",
+	"503 service unavailable",
+}
+
+// invalidInputErrors are rejections of a request that will fail in the same way however many times it is retried
+var invalidInputErrors = []string{
+	"invalid argument",
+	"intrinsic gas too low",
+	"exceeds block gas limit",
+	"invalid sender",
+	"rlp: ",
+}
+
+func containsAny(errString string, msgs []string) bool {
+	for _, msg := range msgs {
+		if strings.Contains(errString, msg) {
+			return true
+		}
+	}
+	return false
+}
+
+// connectorErrorReason returns the reason for an error raised by the connector itself, from its error code
+func connectorErrorReason(errString string) ffcapi.ErrorReason {
+	code, _, found := strings.Cut(errString, ":")
+	if !found {
+		return ""
+	}
+	return connectorErrorReasons[code]
+}
+
+func isPermissioningError(errString string) bool {
+	return containsAny(errString, permissioningErrors)
+}
+
+// errorRetryable classifies a reason as retryable, where the same request might succeed later, or terminal.
+// This matches the classification of the transaction manager, which treats only the reasons in
+// ffcapi.MapSubmissionRejected as a rejection of a transaction, and retries everything else.
+func errorRetryable(reason ffcapi.ErrorReason) bool {
+	switch reason {
+	case ErrorReasonRateLimited, ErrorReasonTimeout, ErrorReasonNotSent, ffcapi.ErrorReasonDownstreamDown, "":
+		return true
+	default:
+		return !ffcapi.MapSubmissionRejected(reason)
+	}
+}
+
 const (
 	filterRPCMethods ethRPCMethodCategory = iota
 	sendRPCMethods
@@ -42,11 +166,49 @@ const (
 // deal with the differences between client implementations.
 func mapError(methodType ethRPCMethodCategory, err error) ffcapi.ErrorReason {
 
+	if reason := connectorErrorReason(err.Error()); reason != "" {
+		return reason
+	}
 	errString := strings.ToLower(err.Error())
+	if reason := mapMethodError(methodType, errString); reason != "" {
+		return reason
+	}
+
+	// Errors that are the same for every method, as they come from the transport or the node in general
+	switch {
+	case containsAny(errString, rateLimitErrors):
+		return ErrorReasonRateLimited
+	case containsAny(errString, downstreamDownErrors):
+		return ffcapi.ErrorReasonDownstreamDown
+	case containsAny(errString, timeoutErrors):
+		return ErrorReasonTimeout
+	case containsAny(errString, invalidInputErrors):
+		return ffcapi.ErrorReasonInvalidInputs
+	}
+
+	// Best default in FFCAPI is to provide no mapping
+	return ""
+}
+
+// mapRPCError uses the JSON/RPC error code where it gives a reason, as well as the message
+func mapRPCError(methodType ethRPCMethodCategory, rpcErr *rpcbackend.RPCError) ffcapi.ErrorReason {
+	reason := mapError(methodType, rpcErr.Error())
+	if reason == "" {
+		switch rpcErr.Code {
+		case rpcCodeLimitExceeded:
+			return ErrorReasonRateLimited
+		case int64(rpcbackend.RPCCodeInvalidRequest), rpcCodeInvalidParams:
+			return ffcapi.ErrorReasonInvalidInputs
+		}
+	}
+	return reason
+}
+
+func mapMethodError(methodType ethRPCMethodCategory, errString string) ffcapi.ErrorReason {
 
 	switch methodType {
 	case filterRPCMethods:
-		if strings.Contains(errString, "filter not found") {
+		if containsAny(errString, filterNotFoundErrors) {
 			return ffcapi.ErrorReasonNotFound
 		}
 	case sendRPCMethods:
@@ -61,10 +223,15 @@ func mapError(methodType ethRPCMethodCategory, err error) ffcapi.ErrorReason {
 			return ffcapi.ErrorKnownTransaction
 		case strings.Contains(errString, "already known"):
 			return ffcapi.ErrorKnownTransaction
+		case isPermissioningError(errString):
+			return ErrorReasonNotPermitted
 		}
 	case callRPCMethods:
-		if strings.Contains(errString, "execution reverted") {
+		switch {
+		case strings.Contains(errString, "execution reverted"):
 			return ffcapi.ErrorReasonTransactionReverted
+		case isPermissioningError(errString):
+			return ErrorReasonNotPermitted
 		}
 	case blockRPCMethods:
 		// https://docs.avax.network/quickstart/integrate-exchange-with-avalanche#determining-finality
@@ -76,7 +243,5 @@ func mapError(methodType ethRPCMethodCategory, err error) ffcapi.ErrorReason {
 			return ffcapi.ErrorReasonNotFound
 		}
 	}
-
-	// Best default in FFCAPI is to provide no mapping
 	return ""
 }
@@ -29,10 +29,17 @@ import (
 	"github.com/hyperledger/firefly-signer/pkg/abi"
 	"github.com/hyperledger/firefly-signer/pkg/ethsigner"
 	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
 	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
 )
 
-func (c *ethConnector) GasEstimate(ctx context.Context, transaction *ffcapi.TransactionInput) (*ffcapi.GasEstimateResponse, ffcapi.ErrorReason, error) {
+// gasEstimationOverrideBalance is the balance granted to the sender when estimating gas with
+// gasEstimationSpoofBalance enabled - large enough to cover any realistic value and gas cost
+var gasEstimationOverrideBalance = ethtypes.NewHexInteger(new(big.Int).Lsh(big.NewInt(1), 128))
+
+func (c *ethConnector) GasEstimate(ctx context.Context, transaction *ffcapi.TransactionInput) (_ *ffcapi.GasEstimateResponse, reason ffcapi.ErrorReason, err error) {
+	ctx = withCorrelationIDs(ctx)
+	defer func() { err = c.exposeErrorDetails(reason, err) }()
 
 	tx := &ethsigner.Transaction{
 		Nonce:    (*ethtypes.HexInteger)(transaction.Nonce),
@@ -69,26 +76,38 @@ func (c *ethConnector) gasEstimate(ctx context.Context, tx *ethsigner.Transactio
 
 	// Do the gas estimation
 	var gasEstimate ethtypes.HexInteger
-	rpcErr := c.backend.CallRPC(ctx, &gasEstimate, "eth_estimateGas", tx)
+	var rpcErr *rpcbackend.RPCError
+	var from string
+	if c.gas().spoofBalance && tx.From != nil && json.Unmarshal(tx.From, &from) == nil && from != "" {
+		// Use a state override to give the sender a large balance for the purposes of the estimation,
+		// so accounts that are funded just-in-time (or sponsored) do not fail with insufficient funds
+		rpcErr = c.backend.CallRPC(ctx, &gasEstimate, "eth_estimateGas", withFeeCurrency(tx, c.gas().feeCurrency), "latest", map[string]interface{}{
+			from: map[string]interface{}{
+				"balance": gasEstimationOverrideBalance,
+			},
+		})
+	} else {
+		rpcErr = c.backend.CallRPC(ctx, &gasEstimate, "eth_estimateGas", withFeeCurrency(tx, c.gas().feeCurrency))
+	}
 	if rpcErr != nil {
 		if reason, revertErr := c.attemptProcessingRevertData(ctx, errors, rpcErr); revertErr != nil {
 			return nil, reason, revertErr
 		}
 
 		// If it fails, fall back to an eth_call to see if we get a reverted reason
-		_, reason, errCall := c.callTransaction(ctx, tx, method, errors, nil)
+		_, reason, errCall := c.callTransaction(ctx, tx, method, errors, nil, "", c.serializer)
 		if reason == ffcapi.ErrorReasonTransactionReverted {
 			return nil, reason, errCall
 		}
 		log.L(ctx).Errorf("Gas estimation failed for a non-revert reason: %s (call result: %v)", rpcErr.Message, errCall)
 		// Return the original error - as the eth_call did not give us a revert result (it might even
 		// have succeeded). So we need to fall back to the original error.
-		return nil, mapError(callRPCMethods, rpcErr.Error()), rpcErr.Error()
+		return nil, mapRPCError(callRPCMethods, rpcErr), withErrorDetails(rpcErr.Error(), rpcErrorDetails(rpcErr))
 	}
 
 	// Multiply the gas estimate by the configured factor
 	fGasEstimate := new(big.Float).SetInt(gasEstimate.BigInt())
-	_ = fGasEstimate.Mul(fGasEstimate, c.gasEstimationFactor)
+	_ = fGasEstimate.Mul(fGasEstimate, c.gas().estimationFactor)
 	_, _ = fGasEstimate.Int(gasEstimate.BigInt())
 	return &gasEstimate, "", nil
 }
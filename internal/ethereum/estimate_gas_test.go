@@ -22,6 +22,7 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/hyperledger/firefly-common/pkg/config"
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly-signer/pkg/abi"
 	"github.com/hyperledger/firefly-signer/pkg/ethsigner"
@@ -69,6 +70,37 @@ func TestGasEstimateOK(t *testing.T) {
 
 }
 
+func TestGasEstimateSpoofBalanceOK(t *testing.T) {
+
+	ctx, c, mRPC, done := newTestConnector(t, func(conf config.Section) {
+		conf.Set(GasEstimationSpoofBalance, true)
+	})
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_estimateGas",
+		mock.Anything,
+		"latest",
+		mock.MatchedBy(func(overrides map[string]interface{}) bool {
+			b, _ := json.Marshal(overrides)
+			assert.JSONEq(t, `{"0x73bd8f17787a0f9774652075e2ba5ed381246bef":{"balance":"0x100000000000000000000000000000000"}}`, string(b))
+			return true
+		})).
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			args[1].(*ethtypes.HexInteger).BigInt().SetString("12345", 10)
+		})
+
+	var req ffcapi.TransactionInput
+	err := json.Unmarshal([]byte(sampleGasEstimate), &req)
+	assert.NoError(t, err)
+	res, reason, err := c.GasEstimate(ctx, &req)
+	assert.NoError(t, err)
+	assert.Empty(t, reason)
+
+	assert.Equal(t, int64(18517) /* 1.5 uplift */, res.GasEstimate.Int64())
+
+}
+
 func TestGasEstimateFail(t *testing.T) {
 
 	ctx, c, mRPC, done := newTestConnector(t)
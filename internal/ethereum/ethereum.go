@@ -21,11 +21,15 @@ import (
 	"fmt"
 	"math/big"
 	"regexp"
+	"slices"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
 	"github.com/hyperledger/firefly-common/pkg/ffresty"
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly-common/pkg/i18n"
@@ -40,8 +44,26 @@ import (
 
 type ethConnector struct {
 	backend                    rpcbackend.Backend
+	readOnlyBackend            rpcbackend.Backend
+	verifyBackend              rpcbackend.Backend
+	verifyMode                 string
+	simulator                  *simulator
+	rpcRecording               *rpcRecording
+	hedgedReadBackend          *hedgedRPC
+	stickyWindow               time.Duration
+	readMaxLagBlocks           int64
+	readLagCheckInterval       time.Duration
+	readLagMonitorDone         chan struct{}
 	serializer                 *abi.Serializer
-	gasEstimationFactor        *big.Float
+	dataFormat                 string
+	numberFormat               string
+	strictNumbers              bool
+	strictNumberStreams        map[string]bool
+	gasPolicy                  atomic.Pointer[gasPolicy]
+	txPolicy                   atomic.Pointer[txPolicy]
+	ethChainID                 atomic.Pointer[big.Int]
+	learnedMinGasPrice         atomic.Pointer[big.Int]
+	zeroGas                    atomic.Pointer[zeroGasNetwork]
 	catchupPageSize            int64
 	catchupThreshold           int64
 	catchupDownscaleRegex      *regexp.Regexp
@@ -50,17 +72,83 @@ type ethConnector struct {
 	eventBlockTimestamps       bool
 	blockListener              *blockListener
 	eventFilterPollingInterval time.Duration
+	eventPollingMode           string
+	bloomScreening             bool
+	bloomScreeningMaxSkip      time.Duration
+	logVerificationRate        float64
+	baseFeeWindowBlocks        int
+	blockTxPagingThreshold     int
+	blockTxBatchSize           int
+	logVerificationFail        bool
+	quarantineAttempts         int
+	deadLetterDir              string
+	deadLetterTimeout          time.Duration
+	deadLetterAttempts         int
+	deadLetterRetry            *retry.Retry
 	traceTXForRevertReason     bool
+	errorDetails               bool
+	legacyChain                bool
+	receiptContractMetadata    bool
+	receiptRetryables          bool
+	finality                   *finalityTracker
+	l1Inclusion                *l1InclusionTracker
+	sendDedupWindow            time.Duration
+	maxTxConfirmations         int64
+	receiptStatusFallback      string
+	proxyResolution            bool
+	proxyCacheTTL              time.Duration
+	implementationABIs         map[string]*implementationABI
 	chainID                    string
+	configMux                  sync.Mutex
+	configSnapshot             fftypes.JSONObject
+	auditLog                   *auditLog
+	costs                      *costTracker
+	gasUsage                   *gasUsageTracker
+	latencySLOs                *latencySLOTracker
+	notifier                   *notifier
+	sinks                      []*sinkPublisher
+	checkpointStore            checkpointStore
 
-	mux          sync.Mutex
-	eventStreams map[fftypes.UUID]*eventStream
-	txCache      *lru.Cache
+	mux            sync.Mutex
+	eventStreams   map[fftypes.UUID]*eventStream
+	txCache        *lru.Cache
+	routingMux     sync.Mutex
+	stickyTXs      map[string]time.Time
+	readBehind     bool
+	primaryStatus  endpointStatus
+	readStatus     endpointStatus
+	sendDedupMux   sync.Mutex
+	sendAttempts   map[string]*sendAttempt
+	valueMux       sync.Mutex
+	valueTransfers map[string][]*valueTransfer
+	proxyMux       sync.Mutex
+	proxyCache     map[string]*cachedProxyInfo
+	eventFailures  *lru.Cache
+	blockTSCache   *lru.Cache
+	txConfs        *lru.Cache
+	quarantineMux  sync.Mutex
+	quarantine     map[fftypes.UUID]*QuarantinedEvent
+	deadLetterMux  sync.Mutex
+	deadLetters    map[fftypes.UUID]*DeadLetterBatch
+
+	snapshotMux         sync.Mutex
+	restoredCheckpoints map[fftypes.UUID]*listenerCheckpoint
 }
 
 type Connector interface {
 	ffcapi.API
 	RPC() rpcbackend.RPC
+	Routes() []*ffapi.Route
+	ReloadConfig(ctx context.Context, conf config.Section) error
+}
+
+// defaultOutputName is the name of an unnamed parameter or tuple component in the decoded JSON, by its index
+func defaultOutputName(idx int) string {
+	name := "output"
+	if idx > 0 {
+		name = fmt.Sprintf("%s%v", name, idx)
+	}
+	return name
 }
 
 func NewEthereumConnector(ctx context.Context, conf config.Section) (cc Connector, err error) {
@@ -71,8 +159,37 @@ func NewEthereumConnector(ctx context.Context, conf config.Section) (cc Connecto
 		checkpointBlockGap:         conf.GetInt64(EventsCheckpointBlockGap),
 		eventBlockTimestamps:       conf.GetBool(EventsBlockTimestamps),
 		eventFilterPollingInterval: conf.GetDuration(EventsFilterPollingInterval),
+		eventPollingMode:           conf.GetString(EventsPollingMode),
+		bloomScreening:             conf.GetBool(EventsBloomScreening),
+		bloomScreeningMaxSkip:      conf.GetDuration(EventsBloomScreeningMaxSkip),
+		logVerificationRate:        conf.GetFloat64(EventsLogVerificationRate),
+		baseFeeWindowBlocks:        conf.GetInt(BaseFeeWindowBlocks),
+		blockTxPagingThreshold:     conf.GetInt(BlockTransactionsPagingThreshold),
+		blockTxBatchSize:           conf.GetInt(BlockTransactionsBatchSize),
+		logVerificationFail:        conf.GetBool(EventsLogVerificationFail),
+		quarantineAttempts:         conf.GetInt(EventsQuarantineAttempts),
+		quarantine:                 make(map[fftypes.UUID]*QuarantinedEvent),
+		restoredCheckpoints:        make(map[fftypes.UUID]*listenerCheckpoint),
 		traceTXForRevertReason:     conf.GetBool(TraceTXForRevertReason),
+		errorDetails:               conf.GetBool(ErrorDetailsEnabled),
+		legacyChain:                conf.GetBool(LegacyChainEnabled),
+		receiptContractMetadata:    conf.GetBool(ContractMetadataReceipts),
+		receiptRetryables:          conf.GetBool(ArbitrumRetryablesReceipts),
+		stickyWindow:               conf.GetDuration(ReadStickyWindow),
+		readMaxLagBlocks:           conf.GetInt64(ReadMaxLagBlocks),
+		readLagCheckInterval:       conf.GetDuration(ReadLagCheckInterval),
+		stickyTXs:                  make(map[string]time.Time),
+		sendDedupWindow:            conf.GetDuration(SendDeduplicationWindow),
+		maxTxConfirmations:         conf.GetInt64(ReceiptConfirmationsMax),
+		receiptStatusFallback:      conf.GetString(ReceiptStatusFallback),
+		sendAttempts:               make(map[string]*sendAttempt),
+		valueTransfers:             make(map[string][]*valueTransfer),
+		proxyResolution:            conf.GetBool(ProxyResolutionEnabled),
+		proxyCacheTTL:              conf.GetDuration(ProxyResolutionCacheTTL),
+		proxyCache:                 make(map[string]*cachedProxyInfo),
 		retry:                      &retry.Retry{},
+		costs:                      newCostTracker(conf),
+		configSnapshot:             redactedConfig(conf),
 	}
 
 	c.retry.InitialDelay = withDeprecatedConfFallback(conf, conf.GetDuration, DeprecatedRetryInitDelay, RetryInitDelay)
@@ -83,16 +200,58 @@ func NewEthereumConnector(ctx context.Context, conf config.Section) (cc Connecto
 		log.L(ctx).Warnf("Catchup threshold %d must be at least as large as the catchup page size %d (overridden to %d)", c.catchupThreshold, c.catchupPageSize, c.catchupPageSize)
 		c.catchupThreshold = c.catchupPageSize
 	}
+	if c.blockTxPagingThreshold < 0 || c.blockTxBatchSize <= 0 {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidBlockTxPaging, c.blockTxPagingThreshold, c.blockTxBatchSize)
+	}
+	if c.eventPollingMode != EventPollingModeFilters && c.eventPollingMode != EventPollingModeGetLogs {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidEventPollingMode, c.eventPollingMode, []string{EventPollingModeFilters, EventPollingModeGetLogs})
+	}
+	if !slices.Contains(receiptStatusFallbacks, c.receiptStatusFallback) {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidReceiptStatusFallback, c.receiptStatusFallback, receiptStatusFallbacks)
+	}
 
 	c.txCache, err = lru.New(conf.GetInt(TxCacheSize))
 	if err != nil {
 		return nil, i18n.WrapError(ctx, err, msgs.MsgCacheInitFail, "transaction")
 	}
+	c.eventFailures, _ = lru.New(eventFailureCacheSize)
+	c.blockTSCache, _ = lru.New(blockTimestampCacheSize)
+	c.txConfs, _ = lru.New(txConfirmationsCacheSize)
+	if err = c.loadDeadLetters(ctx, conf); err != nil {
+		return nil, err
+	}
+	if conf.GetBool(GasReportReceipts) {
+		c.gasUsage = newGasUsageTracker()
+	}
+	c.latencySLOs = newLatencySLOTracker(conf)
 
-	if conf.GetString(ffresty.HTTPConfigURL) == "" {
+	if c.simulator, err = newSimulator(ctx, conf); err != nil {
+		return nil, err
+	}
+	if c.simulator != nil {
+		// The clients are built from the config, and do not connect until the simulator has started
+		conf.Set(ffresty.HTTPConfigURL, c.simulator.url)
+	}
+	if c.rpcRecording, err = newRPCRecording(ctx, conf); err != nil {
+		return nil, err
+	}
+	// A replay answers all requests to the primary endpoint, so no URL is needed
+	if conf.GetString(ffresty.HTTPConfigURL) == "" && !c.rpcRecording.replaying() {
 		return nil, i18n.NewError(ctx, msgs.MsgMissingBackendURL)
 	}
-	c.gasEstimationFactor = big.NewFloat(conf.GetFloat64(ConfigGasEstimationFactor))
+	gp, err := newGasPolicy(ctx, conf, nil)
+	if err != nil {
+		return nil, err
+	}
+	c.gasPolicy.Store(gp)
+	tp, err := newTxPolicy(ctx, conf)
+	if err != nil {
+		return nil, err
+	}
+	c.txPolicy.Store(tp)
+	if c.implementationABIs, err = parseImplementationABIs(ctx, proxyABIsConfig(conf)); err != nil {
+		return nil, err
+	}
 
 	c.catchupDownscaleRegex, err = regexp.Compile(conf.GetString(EventsCatchupDownscaleRegex))
 	if err != nil {
@@ -100,25 +259,71 @@ func NewEthereumConnector(ctx context.Context, conf config.Section) (cc Connecto
 	}
 
 	var wsConf *wsclient.WSConfig
-	var httpConf *ffresty.Config
+	var primaryClient rpcbackend.Backend
 	if conf.GetBool(WebSocketsEnabled) {
 		// If websockets are enabled, then they are used selectively (block listening/query)
 		// not as a full replacement for HTTP.
 		wsConf, err = wsclient.GenerateConfig(ctx, conf)
+		if conf.GetString(ffresty.HTTPConfigProxyURL) != "" {
+			log.L(ctx).Warnf("The WebSocket connection of the block listener is not made through the configured proxy.url")
+		}
+	}
+	var clientOpts rpcClientOptions
+	if err == nil {
+		clientOpts, err = newRPCClientOptions(ctx, conf)
 	}
 	if err == nil {
-		httpConf, err = ffresty.GenerateConfig(ctx, conf)
+		primaryClient, err = newRPCClient(ctx, conf, clientOpts)
 	}
 	if err != nil {
 		return nil, err
 	}
-	httpClient := ffresty.NewWithConfig(ctx, *httpConf)
-	c.backend = rpcbackend.NewRPCClientWithOption(httpClient, rpcbackend.RPCClientOptions{
-		MaxConcurrentRequest: conf.GetInt64(MaxConcurrentRequests),
-	})
+	c.backend = newManagedBackend(c.rpcRecording.wrap(primaryClient), newRPCScheduler(conf, clientOpts)).withCosts(c.costs, "primary")
+
+	// An optional separate endpoint can be configured for read-heavy queries, such as replicas,
+	// with all writes (and anything dependent on node local state like filters) going to the primary
+	readConf := conf.SubSection(ReadEndpointConfig)
+	if readConf.GetString(ffresty.HTTPConfigURL) != "" {
+		readClient, err := newRPCClient(ctx, readConf, clientOpts)
+		if err != nil {
+			return nil, err
+		}
+		c.readOnlyBackend = newManagedBackend(readClient, newRPCScheduler(readConf, clientOpts)).withCosts(c.costs, "read")
+		if conf.GetBool(ReadHedgingEnabled) {
+			percentile := conf.GetFloat64(ReadHedgingPercentile)
+			if percentile <= 0 || percentile > 100 {
+				return nil, i18n.NewError(ctx, msgs.MsgInvalidHedgingPercentile, percentile)
+			}
+			c.hedgedReadBackend = newHedgedRPC(c.readOnlyBackend, c.backend, percentile, conf.GetDuration(ReadHedgingMinDelay))
+		}
+		if c.readLagCheckInterval > 0 {
+			c.readLagMonitorDone = make(chan struct{})
+		}
+	}
+
+	// An optional endpoint of an independent provider, that the logs and receipts of the primary are checked against
+	verifyConf := conf.SubSection(VerificationEndpointConfig)
+	if verifyConf.GetString(ffresty.HTTPConfigURL) != "" {
+		c.verifyMode = strings.ToLower(conf.GetString(VerificationMode))
+		if c.verifyMode != VerificationModeWarn && c.verifyMode != VerificationModeBlock {
+			return nil, i18n.NewError(ctx, msgs.MsgInvalidVerificationMode, c.verifyMode, []string{VerificationModeWarn, VerificationModeBlock})
+		}
+		verifyClient, err := newRPCClient(ctx, verifyConf, clientOpts)
+		if err != nil {
+			return nil, err
+		}
+		c.verifyBackend = newManagedBackend(verifyClient, newRPCScheduler(verifyConf, clientOpts)).withCosts(c.costs, "verify")
+	}
+	if c.finality, err = newFinalityTracker(ctx, conf, clientOpts, c.costs); err != nil {
+		return nil, err
+	}
+	if c.l1Inclusion, err = newL1InclusionTracker(ctx, conf, clientOpts, c.costs); err != nil {
+		return nil, err
+	}
 
 	c.serializer = abi.NewSerializer().SetByteSerializer(abi.HexByteSerializer0xPrefix)
-	switch conf.Get(ConfigDataFormat) {
+	c.dataFormat = conf.GetString(ConfigDataFormat)
+	switch c.dataFormat {
 	case "map":
 		c.serializer.SetFormattingMode(abi.FormatAsObjects)
 	case "flat_array":
@@ -128,18 +333,61 @@ func NewEthereumConnector(ctx context.Context, conf config.Section) (cc Connecto
 	default:
 		return nil, i18n.NewError(ctx, msgs.MsgBadDataFormat, conf.Get(ConfigDataFormat), "map,flat_array,self_describing")
 	}
-	c.serializer.SetDefaultNameGenerator(func(idx int) string {
-		name := "output"
-		if idx > 0 {
-			name = fmt.Sprintf("%s%v", name, idx)
-		}
-		return name
-	})
+	c.numberFormat = conf.GetString(ConfigNumberFormat)
+	is, err := intSerializer(ctx, c.numberFormat)
+	if err != nil {
+		return nil, err
+	}
+	c.serializer.SetIntSerializer(is)
+	c.strictNumbers = conf.GetBool(JSONNumbersStrict)
+	c.strictNumberStreams = make(map[string]bool)
+	for _, s := range conf.GetStringSlice(JSONNumbersEventStreams) {
+		c.strictNumberStreams[s] = true
+	}
+	c.serializer.SetDefaultNameGenerator(defaultOutputName)
 
 	if c.blockListener, err = newBlockListener(ctx, c, conf, wsConf); err != nil {
 		return nil, err
 	}
 
+	if c.notifier, err = newNotifier(ctx, conf); err != nil {
+		return nil, err
+	}
+	if c.sinks, err = newSinks(ctx, c, conf); err != nil {
+		return nil, err
+	}
+	if c.simulator != nil {
+		if err = c.simulator.start(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if c.checkpointStore, err = newCheckpointStore(ctx, conf); err != nil {
+		c.simulator.stop()
+		return nil, err
+	}
+	// Opened last, so the files and webhook loop are only started for a connector that is returned
+	err = c.rpcRecording.open(ctx)
+	if err == nil {
+		c.auditLog, err = newAuditLog(ctx, conf)
+	}
+	if err != nil {
+		c.rpcRecording.close()
+		if c.checkpointStore != nil {
+			c.checkpointStore.close()
+		}
+		c.simulator.stop()
+		return nil, err
+	}
+
+	// Started once nothing else can fail, so the loops are not left running for a connector that is not returned
+	c.notifier.start(ctx)
+	for _, sp := range c.sinks {
+		sp.start(ctx)
+	}
+	if c.readLagMonitorDone != nil {
+		go c.readLagMonitorLoop(ctx)
+	}
+
 	return c, nil
 }
 
@@ -152,9 +400,29 @@ func (c *ethConnector) WaitClosed() {
 	if c.blockListener != nil {
 		c.blockListener.waitClosed()
 	}
+	// Event streams are removed from the map by EventStreamStopped, which might be running concurrently
+	c.mux.Lock()
+	streams := make([]*eventStream, 0, len(c.eventStreams))
 	for _, s := range c.eventStreams {
+		streams = append(streams, s)
+	}
+	c.mux.Unlock()
+	for _, s := range streams {
 		<-s.streamLoopDone
 	}
+	if c.readLagMonitorDone != nil {
+		<-c.readLagMonitorDone
+	}
+	c.auditLog.waitClosed()
+	c.notifier.waitClosed()
+	for _, sp := range c.sinks {
+		sp.waitClosed()
+	}
+	if c.checkpointStore != nil {
+		c.checkpointStore.close()
+	}
+	c.simulator.waitClosed()
+	c.rpcRecording.close()
 }
 
 func withDeprecatedConfFallback[T any](conf config.Section, getter func(string) T, deprecatedKey, newKey string) T {
@@ -18,6 +18,7 @@ package ethereum
 
 import (
 	"context"
+	"math/big"
 	"testing"
 	"time"
 
@@ -65,6 +66,7 @@ func newTestConnectorWithNoBlockerFilterDefaultMocks(t *testing.T, confSetup ...
 	c := cc.(*ethConnector)
 	c.backend = mRPC
 	c.blockListener.backend = mRPC
+	c.ethChainID.Store(big.NewInt(1337)) // the chain ID of the signed transactions in the tests
 	return ctx, c, mRPC, func() {
 		done()
 		mRPC.AssertExpectations(t)
@@ -74,13 +76,12 @@ func newTestConnectorWithNoBlockerFilterDefaultMocks(t *testing.T, confSetup ...
 
 func conditionalMockOnce(call *mock.Call, predicate func() bool, thenRun func(args mock.Arguments)) {
 	call.Run(func(args mock.Arguments) {
-		if predicate() {
-			thenRun(args)
-		} else {
-			call.Run(func(args mock.Arguments) {
-				thenRun(args)
-			}).Once()
+		// Allow a short window for the predicate to become true, rather than relying on
+		// the scheduling of other goroutines between two consecutive calls
+		for i := 0; i < 100 && !predicate(); i++ {
+			time.Sleep(1 * time.Millisecond)
 		}
+		thenRun(args)
 	}).Once()
 }
 
@@ -156,6 +157,43 @@ func TestConnectorInit(t *testing.T) {
 	conf.Set(EventsCatchupDownscaleRegex, "[")
 	cc, err = NewEthereumConnector(context.Background(), conf)
 	assert.Regexp(t, "FF23051", err)
+
+	conf.Set(EventsCatchupDownscaleRegex, DefaultEventsCatchupDownscaleRegex)
+	conf.Set(CanonicalChainDepth, 0)
+	cc, err = NewEthereumConnector(context.Background(), conf)
+	assert.Regexp(t, "FF23058", err)
+
+	conf.Set(CanonicalChainDepth, 10)
+	conf.Set(PolicyPreSignedAllowedSigners, []string{"wrong"})
+	cc, err = NewEthereumConnector(context.Background(), conf)
+	assert.Regexp(t, "FF23110", err)
+
+	conf.Set(PolicyPreSignedAllowedSigners, []string{})
+	cc, err = NewEthereumConnector(context.Background(), conf)
+	assert.NoError(t, err)
+	assert.Equal(t, 10, cc.(*ethConnector).blockListener.unstableHeadLength)
+
+	conf.Set(ReadLagCheckInterval, "0")
+	readConf := conf.SubSection(ReadEndpointConfig)
+	readConf.Set(ffresty.HTTPConfigURL, "http://localhost:8546")
+	cc, err = NewEthereumConnector(context.Background(), conf)
+	assert.NoError(t, err)
+	assert.NotNil(t, cc.(*ethConnector).readOnlyBackend)
+	assert.NotEqual(t, cc.(*ethConnector).backend, cc.(*ethConnector).readBackend())
+	assert.Nil(t, cc.(*ethConnector).readLagMonitorDone)
+
+	conf.Set(ReadLagCheckInterval, "1h")
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	cc, err = NewEthereumConnector(ctx, conf)
+	assert.NoError(t, err)
+	cancelCtx()
+	cc.(*ethConnector).WaitClosed()
+
+	readTLSConf := readConf.SubSection("tls")
+	readTLSConf.Set(fftls.HTTPConfTLSEnabled, true)
+	readTLSConf.Set(fftls.HTTPConfTLSCAFile, "!!!badness")
+	cc, err = NewEthereumConnector(context.Background(), conf)
+	assert.Regexp(t, "FF00153", err)
 }
 
 // TODO: remove once deprecated fields are removed
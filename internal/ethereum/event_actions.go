@@ -37,10 +37,11 @@ func (c *ethConnector) EventStreamStart(ctx context.Context, req *ffcapi.EventSt
 	es = &eventStream{
 		id:             req.ID,
 		c:              c,
-		ctx:            req.StreamContext,
+		ctx:            withCostStream(req.StreamContext, req.ID),
 		events:         req.EventStream,
 		headBlock:      -1,
 		listeners:      make(map[fftypes.UUID]*listener),
+		preStartDone:   make(chan struct{}),
 		streamLoopDone: make(chan struct{}),
 	}
 
@@ -146,6 +147,7 @@ func (c *ethConnector) EventListenerRemove(ctx context.Context, req *ffcapi.Even
 		return nil, ffcapi.ErrorReasonNotFound, i18n.NewError(ctx, msgs.MsgStreamNotStarted, req.StreamID)
 	}
 	es.removeEventListener(req.ListenerID)
+	c.removeStoredCheckpoint(ctx, req.ListenerID)
 	return &ffcapi.EventListenerRemoveResponse{}, ffcapi.ErrorReason(""), nil
 }
 
@@ -162,3 +164,29 @@ func (c *ethConnector) EventListenerHWM(ctx context.Context, req *ffcapi.EventLi
 	}
 	return es.getListenerHWM(ctx, req.ListenerID)
 }
+
+// getEventStream finds a started event stream
+func (c *ethConnector) getEventStream(ctx context.Context, streamID *fftypes.UUID) (*eventStream, error) {
+	c.mux.Lock()
+	es := c.eventStreams[*streamID]
+	c.mux.Unlock()
+	if es == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgStreamNotStarted, streamID)
+	}
+	return es, nil
+}
+
+// getStreamListener finds a listener that is started on an event stream
+func (c *ethConnector) getStreamListener(ctx context.Context, streamID, listenerID *fftypes.UUID) (*eventStream, *listener, error) {
+	es, err := c.getEventStream(ctx, streamID)
+	if err != nil {
+		return nil, nil, err
+	}
+	es.mux.Lock()
+	l := es.listeners[*listenerID]
+	es.mux.Unlock()
+	if l == nil {
+		return nil, nil, i18n.NewError(ctx, msgs.MsgListenerNotStarted, listenerID, streamID)
+	}
+	return es, l, nil
+}
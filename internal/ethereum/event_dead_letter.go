@@ -0,0 +1,296 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-common/pkg/retry"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/apitypes"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+)
+
+const deadLetterFileSuffix = ".json"
+
+// DeadLetterBatch is the remainder of a batch of events that the transaction manager repeatedly failed
+// to accept, which was persisted so the event stream could move on. The checkpoint of the stream has
+// moved past the events, so the transaction manager does not receive them again, and they are returned by
+// replaying the batch.
+type DeadLetterBatch struct {
+	ID       *fftypes.UUID      `json:"id"`
+	StreamID *fftypes.UUID      `json:"streamId"`
+	Attempts int                `json:"attempts"`
+	Error    string             `json:"error"`
+	Created  *fftypes.FFTime    `json:"created"`
+	Events   []*DeadLetterEvent `json:"events"`
+}
+
+// DeadLetterEvent is a persisted event, with the fields of the event passed to the transaction manager
+type DeadLetterEvent struct {
+	Checkpoint *listenerCheckpoint `json:"checkpoint,omitempty"`
+	ID         ffcapi.EventID      `json:"id"`
+	Info       *eventInfo          `json:"info,omitempty"`
+	Data       *fftypes.JSONAny    `json:"data,omitempty"`
+	Removed    bool                `json:"removed,omitempty"`
+}
+
+type DeadLetterReplayResponse struct {
+	ID     *fftypes.UUID                `json:"id"`
+	Events []*apitypes.EventWithContext `json:"events"`
+}
+
+func newDeadLetterEvent(event *ffcapi.ListenerEvent) *DeadLetterEvent {
+	dle := &DeadLetterEvent{
+		ID:      event.Event.ID,
+		Data:    event.Event.Data,
+		Removed: event.Removed,
+	}
+	if event.Event.Info != nil {
+		dle.Info = event.Event.Info.(*eventInfo)
+	}
+	if event.Checkpoint != nil {
+		dle.Checkpoint = event.Checkpoint.(*listenerCheckpoint)
+	}
+	return dle
+}
+
+// loadDeadLetters reads the batches persisted by a previous run of the connector, when the dead letter
+// store is enabled. Files that cannot be parsed are left in place for an operator to inspect.
+func (c *ethConnector) loadDeadLetters(ctx context.Context, conf config.Section) error {
+	c.deadLetters = make(map[fftypes.UUID]*DeadLetterBatch)
+	c.deadLetterDir = conf.GetString(EventsDeadLetterDirectory)
+	c.deadLetterTimeout = conf.GetDuration(EventsDeadLetterTimeout)
+	c.deadLetterAttempts = conf.GetInt(EventsDeadLetterAttempts)
+	c.deadLetterRetry = &retry.Retry{
+		InitialDelay: conf.GetDuration(EventsDeadLetterInitDelay),
+		MaximumDelay: conf.GetDuration(EventsDeadLetterMaxDelay),
+		Factor:       conf.GetFloat64(EventsDeadLetterFactor),
+	}
+	if c.deadLetterDir == "" {
+		return nil
+	}
+
+	if err := os.MkdirAll(c.deadLetterDir, 0750); err != nil {
+		return i18n.WrapError(ctx, err, msgs.MsgDeadLetterStoreFailed, c.deadLetterDir)
+	}
+	entries, err := os.ReadDir(c.deadLetterDir)
+	if err != nil {
+		return i18n.WrapError(ctx, err, msgs.MsgDeadLetterStoreFailed, c.deadLetterDir)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), deadLetterFileSuffix) {
+			continue
+		}
+		b, err := os.ReadFile(filepath.Join(c.deadLetterDir, entry.Name()))
+		if err != nil {
+			return i18n.WrapError(ctx, err, msgs.MsgDeadLetterStoreFailed, c.deadLetterDir)
+		}
+		var batch DeadLetterBatch
+		if err := json.Unmarshal(b, &batch); err != nil || batch.ID == nil || batch.StreamID == nil {
+			log.L(ctx).Warnf("Ignoring invalid dead letter file '%s' (err=%v)", entry.Name(), err)
+			continue
+		}
+		c.deadLetters[*batch.ID] = &batch
+	}
+	if len(c.deadLetters) > 0 {
+		log.L(ctx).Warnf("Loaded %d dead letter batches from '%s'", len(c.deadLetters), c.deadLetterDir)
+	}
+	return nil
+}
+
+func (c *ethConnector) deadLetterFile(id *fftypes.UUID) string {
+	return filepath.Join(c.deadLetterDir, id.String()+deadLetterFileSuffix)
+}
+
+// persistDeadLetter writes a batch to the store, replacing any previous version of it. The file is
+// written under a temporary name and renamed, so a crash cannot leave a partial batch behind.
+func (c *ethConnector) persistDeadLetter(ctx context.Context, batch *DeadLetterBatch) error {
+	b, _ := json.Marshal(batch)
+	fileName := c.deadLetterFile(batch.ID)
+	err := os.WriteFile(fileName+".tmp", b, 0640)
+	if err == nil {
+		err = os.Rename(fileName+".tmp", fileName)
+	}
+	if err != nil {
+		return i18n.WrapError(ctx, err, msgs.MsgDeadLetterStoreFailed, c.deadLetterDir)
+	}
+	c.deadLetterMux.Lock()
+	c.deadLetters[*batch.ID] = batch
+	c.deadLetterMux.Unlock()
+	return nil
+}
+
+// offerEvent makes a single attempt to pass an event to the transaction manager, waiting up to the
+// delivery timeout of the stream for it to be accepted
+func (es *eventStream) offerEvent(ctx context.Context, event *ffcapi.ListenerEvent) error {
+	timeout := es.deliverySettings().timeout
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case es.events <- event:
+		return nil
+	case <-timer.C:
+		return i18n.NewError(ctx, msgs.MsgEventDeliveryTimeout, timeout)
+	case <-ctx.Done():
+		return i18n.NewError(ctx, i18n.MsgContextCanceled)
+	case <-es.ctx.Done():
+		return i18n.NewError(ctx, msgs.MsgStreamNotStarted, es.id)
+	}
+}
+
+// deliverEvent passes an event to the transaction manager. When the stream blocks on errors this waits until
+// the event is accepted or the stream stops. When it dead letters them, an error is returned once the event
+// has not been accepted within the delivery timeout on each of the attempts.
+func (es *eventStream) deliverEvent(event *ffcapi.ListenerEvent) error {
+	ds := es.deliverySettings()
+	if !ds.deadLetter {
+		select {
+		case es.events <- event:
+			return nil
+		case <-es.ctx.Done():
+			return i18n.NewError(es.ctx, i18n.MsgContextCanceled)
+		}
+	}
+	return ds.retry.Do(es.ctx, "event delivery", func(attempt int) (bool, error) {
+		err := es.offerEvent(es.ctx, event)
+		return err != nil && attempt < ds.attempts, err
+	})
+}
+
+// deadLetter persists the events from an event that could not be delivered to the end of its batch, so
+// the stream can move on past them. Persisting is retried until it succeeds, so events are never dropped.
+// Returns false if the stream stopped first.
+func (es *eventStream) deadLetter(listeners map[fftypes.UUID]*listener, events ffcapi.ListenerEvents, deliveryErr error) bool {
+	c := es.c
+	batch := &DeadLetterBatch{
+		ID:       fftypes.NewUUID(),
+		StreamID: es.id,
+		Attempts: es.deliverySettings().attempts,
+		Error:    deliveryErr.Error(),
+		Created:  fftypes.Now(),
+		Events:   []*DeadLetterEvent{newDeadLetterEvent(events[0])},
+	}
+	for _, event := range events[1:] {
+		if !inSequence(es.ctx, listeners, event) {
+			continue
+		}
+		// Sinks are independent of the transaction manager, so still receive the events as they are read
+		if !es.publishToSinks(event) {
+			return false
+		}
+		batch.Events = append(batch.Events, newDeadLetterEvent(event))
+	}
+
+	err := c.retry.Do(es.ctx, "dead letter persistence", func(_ int) (bool, error) {
+		return true, c.persistDeadLetter(es.ctx, batch)
+	})
+	if err != nil {
+		return false
+	}
+	log.L(es.ctx).Errorf("Persisted %d events that the transaction manager failed to accept to dead letter batch %s for stream %s: %s", len(batch.Events), batch.ID, es.id, batch.Error)
+	c.notifier.notify(es.ctx, NotificationEventsDeadLettered, fftypes.JSONObject{
+		"id":       batch.ID.String(),
+		"streamId": es.id.String(),
+		"events":   len(batch.Events),
+		"attempts": batch.Attempts,
+		"error":    batch.Error,
+	}, "%d events not accepted for stream %s persisted to the dead letter store", len(batch.Events), es.id)
+	return true
+}
+
+// DeadLetters lists the batches in the dead letter store, oldest first
+func (c *ethConnector) DeadLetters() []*DeadLetterBatch {
+	c.deadLetterMux.Lock()
+	defer c.deadLetterMux.Unlock()
+	batches := make([]*DeadLetterBatch, 0, len(c.deadLetters))
+	for _, batch := range c.deadLetters {
+		batches = append(batches, batch)
+	}
+	sort.Slice(batches, func(i, j int) bool {
+		return batches[i].Created.Time().Before(*batches[j].Created.Time())
+	})
+	return batches
+}
+
+// ReplayDeadLetter returns the events of a dead letter batch, in order and flagged as replays, and removes the
+// batch from the store. The checkpoints of the listeners have moved past the events, so the transaction manager
+// would discard them as events it has already seen if they were delivered on the event stream again. Instead
+// they are returned to the caller to process, in the same way as the events of a replay of a listener.
+func (c *ethConnector) ReplayDeadLetter(ctx context.Context, id *fftypes.UUID) (*DeadLetterReplayResponse, error) {
+	// The batch is removed from the store before it is returned, so concurrent calls cannot both return it
+	c.deadLetterMux.Lock()
+	defer c.deadLetterMux.Unlock()
+	batch := c.deadLetters[*id]
+	if batch == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgDeadLetterNotFound, id)
+	}
+	if err := os.Remove(c.deadLetterFile(id)); err != nil && !os.IsNotExist(err) {
+		return nil, i18n.WrapError(ctx, err, msgs.MsgDeadLetterStoreFailed, c.deadLetterDir)
+	}
+	delete(c.deadLetters, *id)
+
+	// The names of the listeners are only known while the stream is running
+	listenerNames := map[fftypes.UUID]string{}
+	c.mux.Lock()
+	es := c.eventStreams[*batch.StreamID]
+	c.mux.Unlock()
+	if es != nil {
+		es.mux.Lock()
+		for lID, l := range es.listeners {
+			listenerNames[lID] = l.config.name
+		}
+		es.mux.Unlock()
+	}
+
+	res := &DeadLetterReplayResponse{
+		ID:     id,
+		Events: make([]*apitypes.EventWithContext, 0, len(batch.Events)),
+	}
+	for _, dle := range batch.Events {
+		event := &ffcapi.Event{ID: dle.ID, Data: dle.Data}
+		if dle.Info != nil {
+			info := *dle.Info
+			info.Replay = true
+			event.Info = &info
+		}
+		ec := apitypes.EventContext{
+			StreamID:       batch.StreamID,
+			EthCompatSubID: dle.ID.ListenerID,
+			ListenerType:   apitypes.ListenerTypeEvents,
+		}
+		if dle.ID.ListenerID != nil {
+			ec.ListenerName = listenerNames[*dle.ID.ListenerID]
+		}
+		res.Events = append(res.Events, &apitypes.EventWithContext{
+			StandardContext: ec,
+			Event:           event,
+		})
+	}
+	log.L(ctx).Infof("Replayed %d events of dead letter batch %s for stream %s", len(res.Events), id, batch.StreamID)
+	return res, nil
+}
@@ -0,0 +1,316 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/apitypes"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+	"github.com/stretchr/testify/assert"
+)
+
+func enableDeadLetter(dir string) func(conf config.Section) {
+	return func(conf config.Section) {
+		conf.Set(EventsDeadLetterDirectory, dir)
+		conf.Set(EventsDeadLetterTimeout, "1ms")
+		conf.Set(EventsDeadLetterInitDelay, "1ms")
+		conf.Set(EventsDeadLetterMaxDelay, "1ms")
+	}
+}
+
+func newTestDeadLetterStream(t *testing.T) (*eventStream, *listener, chan *ffcapi.ListenerEvent, func()) {
+	ctx, c, mRPC, done := newTestConnector(t, enableDeadLetter(t.TempDir()))
+	mockStreamLoopEmpty(mRPC)
+	lID := fftypes.NewUUID()
+	es, events, _, done := testEventStreamExistingConnector(t, ctx, done, c, mRPC, &ffcapi.EventListenerAddRequest{
+		ListenerID: lID,
+		EventListenerOptions: ffcapi.EventListenerOptions{
+			Filters: []fftypes.JSONAny{
+				*fftypes.JSONAnyPtr(`{"address":"0x20355f3E852D4b6a9944AdA8d5399dDD3409A431","event":` + abiTransferEvent + `}`),
+			},
+			Options:   fftypes.JSONAnyPtr(`{}`),
+			FromBlock: strconv.Itoa(testHighBlock),
+		},
+	})
+	return es, es.listeners[*lID], events, done
+}
+
+func testDeadLetterEvent(l *listener, block int64) *ffcapi.ListenerEvent {
+	event := testDeliveredEvent(l, block, 0, 0)
+	event.Event.ID.BlockHash = "0x" + strconv.FormatInt(block, 16)
+	event.Event.Info = &eventInfo{Sequence: eventSequence(block, 0, 0)}
+	event.Event.Data = fftypes.JSONAnyPtr(`{"value":"` + strconv.FormatInt(block, 10) + `"}`)
+	return event
+}
+
+// deadLetterTestBatch dispatches a batch of which only the first event is accepted, so the rest are dead lettered
+func deadLetterTestBatch(t *testing.T, es *eventStream, l *listener, events chan *ffcapi.ListenerEvent) *DeadLetterBatch {
+	accepted := make(chan *ffcapi.ListenerEvent)
+	go func() { accepted <- <-events }()
+	ag := es.buildAggregatedListener([]*listener{l})
+	exiting := es.dispatchSetHWMCheckExit(ag, ffcapi.ListenerEvents{
+		testDeadLetterEvent(l, 1024),
+		testDeadLetterEvent(l, 1025),
+		testDeadLetterEvent(l, 1026),
+		testDeadLetterEvent(l, 1025), // a duplicate is dropped
+	}, testHighBlock+100)
+	assert.False(t, exiting)
+	assert.Equal(t, int64(1024), (<-accepted).Checkpoint.(*listenerCheckpoint).Block)
+
+	batches := es.c.DeadLetters()
+	assert.Len(t, batches, 1)
+	return batches[0]
+}
+
+func TestDeadLetterUndeliverableEvents(t *testing.T) {
+	es, l, events, done := newTestDeadLetterStream(t)
+	defer done()
+
+	batch := deadLetterTestBatch(t, es, l, events)
+	assert.Equal(t, es.id, batch.StreamID)
+	assert.Equal(t, 3, batch.Attempts)
+	assert.Regexp(t, "FF23208", batch.Error)
+	assert.Len(t, batch.Events, 2)
+	assert.Equal(t, int64(1025), batch.Events[0].Checkpoint.Block)
+	assert.Equal(t, int64(1026), batch.Events[1].Checkpoint.Block)
+	assert.Equal(t, `{"value":"1026"}`, batch.Events[1].Data.String())
+
+	// The stream moved on past the dead lettered events
+	assert.Equal(t, int64(testHighBlock+100), l.hwmBlock)
+
+	// The batch is loaded again on restart, ignoring invalid and temporary files
+	dir := es.c.deadLetterDir
+	err := os.WriteFile(filepath.Join(dir, "invalid.json"), []byte(`!json`), 0640)
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dir, "partial.json.tmp"), []byte(`{`), 0640)
+	assert.NoError(t, err)
+	_, c, _, done2 := newTestConnector(t, enableDeadLetter(dir))
+	defer done2()
+	loaded := c.DeadLetters()
+	assert.Len(t, loaded, 1)
+	assert.Equal(t, batch.ID, loaded[0].ID)
+	assert.Len(t, loaded[0].Events, 2)
+	assert.Equal(t, `{"value":"1025"}`, loaded[0].Events[0].Data.String())
+}
+
+func TestDeadLetterPersistRetriedUntilStopped(t *testing.T) {
+	_, c, _, done := newTestConnector(t, enableDeadLetter(t.TempDir()))
+	defer done()
+	c.deadLetterDir = filepath.Join(c.deadLetterDir, "missing")
+	c.retry.MaximumDelay = time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+	es := &eventStream{
+		id:     fftypes.NewUUID(),
+		ctx:    ctx,
+		c:      c,
+		events: make(chan<- *ffcapi.ListenerEvent),
+	}
+	exiting := es.dispatchSetHWMCheckExit(&aggregatedListener{}, ffcapi.ListenerEvents{
+		{Event: &ffcapi.Event{}},
+	}, -1)
+	assert.True(t, exiting)
+	assert.Empty(t, c.DeadLetters())
+}
+
+func TestDeadLetterStreamStoppedDuringDelivery(t *testing.T) {
+	_, c, _, done := newTestConnector(t, enableDeadLetter(t.TempDir()))
+	defer done()
+	c.deadLetterTimeout = time.Minute
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	es := &eventStream{
+		id:     fftypes.NewUUID(),
+		ctx:    ctx,
+		c:      c,
+		events: make(chan<- *ffcapi.ListenerEvent),
+	}
+	exiting := es.dispatchSetHWMCheckExit(&aggregatedListener{}, ffcapi.ListenerEvents{
+		{Event: &ffcapi.Event{}},
+	}, -1)
+	assert.True(t, exiting)
+	assert.Empty(t, c.DeadLetters())
+
+	err := es.offerEvent(context.Background(), &ffcapi.ListenerEvent{})
+	assert.Regexp(t, "FF23041", err)
+}
+
+func TestReplayDeadLetterOK(t *testing.T) {
+	es, l, events, done := newTestDeadLetterStream(t)
+	defer done()
+
+	batch := deadLetterTestBatch(t, es, l, events)
+
+	// The checkpoint of the listener has moved past the dead lettered events, so they are returned
+	// rather than delivered on the stream, where the transaction manager would discard them
+	cp := l.getHWMCheckpoint()
+	assert.Greater(t, cp.Block, batch.Events[1].Checkpoint.Block)
+	res, err := es.c.ReplayDeadLetter(es.ctx, batch.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, batch.ID, res.ID)
+	assert.Len(t, res.Events, 2)
+	assert.Equal(t, es.id, res.Events[0].StandardContext.StreamID)
+	assert.Equal(t, l.id, res.Events[0].StandardContext.EthCompatSubID)
+	assert.Equal(t, apitypes.ListenerTypeEvents, res.Events[0].StandardContext.ListenerType)
+	assert.Equal(t, "0x401", res.Events[0].Event.ID.BlockHash)
+	assert.Equal(t, `{"value":"1026"}`, res.Events[1].Event.Data.String())
+	assert.True(t, res.Events[1].Event.Info.(*eventInfo).Replay)
+	assert.False(t, batch.Events[1].Info.Replay)
+	assert.Empty(t, events)
+
+	assert.Empty(t, es.c.DeadLetters())
+	_, err = os.Stat(es.c.deadLetterFile(batch.ID))
+	assert.True(t, os.IsNotExist(err))
+
+	_, err = es.c.ReplayDeadLetter(es.ctx, batch.ID)
+	assert.Regexp(t, "FF23209", err)
+}
+
+func TestReplayDeadLetterAfterRestart(t *testing.T) {
+	es, l, events, done := newTestDeadLetterStream(t)
+	defer done()
+
+	batch := deadLetterTestBatch(t, es, l, events)
+
+	// The stream is not running in the restarted connector, so the name of the listener is not known
+	_, c, _, done2 := newTestConnector(t, enableDeadLetter(es.c.deadLetterDir))
+	defer done2()
+	res, err := c.ReplayDeadLetter(context.Background(), batch.ID)
+	assert.NoError(t, err)
+	assert.Len(t, res.Events, 2)
+	assert.Equal(t, l.id, res.Events[0].StandardContext.EthCompatSubID)
+	assert.Empty(t, res.Events[0].StandardContext.ListenerName)
+	assert.Equal(t, eventSequence(1026, 0, 0), res.Events[1].Event.Info.(*eventInfo).Sequence)
+	b, err := json.Marshal(res)
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), `"replay":true`)
+}
+
+func TestReplayDeadLetterRemoveFail(t *testing.T) {
+	es, l, events, done := newTestDeadLetterStream(t)
+	defer done()
+
+	batch := deadLetterTestBatch(t, es, l, events)
+
+	// A batch that cannot be removed from the store is kept, so its events are not returned twice
+	err := os.Remove(es.c.deadLetterFile(batch.ID))
+	assert.NoError(t, err)
+	err = os.MkdirAll(filepath.Join(es.c.deadLetterFile(batch.ID), "blocked"), 0750)
+	assert.NoError(t, err)
+	_, err = es.c.ReplayDeadLetter(es.ctx, batch.ID)
+	assert.Regexp(t, "FF23207", err)
+	assert.Len(t, es.c.DeadLetters(), 1)
+
+	// A batch with an event without info, or without a listener, is still returned
+	id := fftypes.NewUUID()
+	es.c.deadLetters[*id] = &DeadLetterBatch{ID: id, StreamID: es.id, Created: fftypes.Now(), Events: []*DeadLetterEvent{{}}}
+	res, err := es.c.ReplayDeadLetter(es.ctx, id)
+	assert.NoError(t, err)
+	assert.Nil(t, res.Events[0].Event.Info)
+	assert.Nil(t, res.Events[0].StandardContext.EthCompatSubID)
+}
+
+func TestDeadLettersSorted(t *testing.T) {
+	_, c, _, done := newTestConnector(t)
+	defer done()
+
+	first, second := fftypes.NewUUID(), fftypes.NewUUID()
+	c.deadLetters[*second] = &DeadLetterBatch{ID: second, Created: fftypes.UnixTime(2000)}
+	c.deadLetters[*first] = &DeadLetterBatch{ID: first, Created: fftypes.UnixTime(1000)}
+
+	batches := c.DeadLetters()
+	assert.Equal(t, first, batches[0].ID)
+	assert.Equal(t, second, batches[1].ID)
+}
+
+func TestLoadDeadLettersFail(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "file")
+	err := os.WriteFile(file, []byte{}, 0640)
+	assert.NoError(t, err)
+
+	config.RootConfigReset()
+	conf := config.RootSection("deadletter_test")
+	InitConfig(conf)
+	conf.Set("url", "http://localhost:8545")
+	enableDeadLetter(filepath.Join(file, "deadletter"))(conf)
+	_, err = NewEthereumConnector(context.Background(), conf)
+	assert.Regexp(t, "FF23207", err)
+
+	// Directories are skipped, but a file that cannot be read fails startup
+	dir := t.TempDir()
+	err = os.Mkdir(filepath.Join(dir, "subdir.json"), 0750)
+	assert.NoError(t, err)
+	err = os.Symlink(filepath.Join(dir, "missing.json"), filepath.Join(dir, "dangling.json"))
+	assert.NoError(t, err)
+	enableDeadLetter(dir)(conf)
+	c := &ethConnector{}
+	err = c.loadDeadLetters(context.Background(), conf)
+	assert.Regexp(t, "FF23207.*dangling", err)
+}
+
+func TestDeadLetterRoutes(t *testing.T) {
+	es, l, events, done := newTestDeadLetterStream(t)
+	defer done()
+	url, close := newTestRouteServer(t, es.c)
+	defer close()
+
+	batch := deadLetterTestBatch(t, es, l, events)
+
+	res, err := http.Get(url + "/deadletter")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var batches []*DeadLetterBatch
+	err = json.NewDecoder(res.Body).Decode(&batches)
+	assert.NoError(t, err)
+	assert.Len(t, batches, 1)
+	assert.Equal(t, batch.ID, batches[0].ID)
+
+	res, err = http.Post(url+"/deadletter/wrong/replay", "application/json", bytes.NewReader([]byte(`{}`)))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	res, err = http.Post(url+"/deadletter/"+batch.ID.String()+"/replay", "application/json", bytes.NewReader([]byte(`{}`)))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var replayed struct {
+		ID     *fftypes.UUID            `json:"id"`
+		Events []map[string]interface{} `json:"events"`
+	}
+	err = json.NewDecoder(res.Body).Decode(&replayed)
+	assert.NoError(t, err)
+	assert.Equal(t, batch.ID, replayed.ID)
+	assert.Len(t, replayed.Events, 2)
+	assert.Equal(t, true, replayed.Events[0]["replay"])
+	assert.Equal(t, l.id.String(), replayed.Events[0]["listenerId"])
+
+	res, err = http.Post(url+"/deadletter/"+batch.ID.String()+"/replay", "application/json", bytes.NewReader([]byte(`{}`)))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+}
@@ -30,8 +30,20 @@ import (
 )
 
 type eventEnricher struct {
-	connector     *ethConnector
-	extractSigner bool
+	connector                *ethConnector
+	extractSigner            bool
+	privacyGroupID           string
+	bridges                  bool
+	bridgeCounterpartChainID string
+	strictNumbers            bool
+	serializer               *abi.Serializer // the serializer for the number format of the listener, if it has one
+}
+
+func (ee *eventEnricher) outputSerializer() *abi.Serializer {
+	if ee.serializer != nil {
+		return ee.serializer
+	}
+	return ee.connector.serializer
 }
 
 func (ee *eventEnricher) filterEnrichEthLog(ctx context.Context, f *eventFilter, methods []*abi.Entry, ethLog *logJSONRPC) (_ *ffcapi.Event, matched bool, decoded bool, err error) {
@@ -43,16 +55,31 @@ func (ee *eventEnricher) filterEnrichEthLog(ctx context.Context, f *eventFilter,
 	protoID := getEventProtoID(blockNumber, transactionIndex, logIndex)
 
 	// Apply a post-filter check to the event
-	topicMatches := len(ethLog.Topics) > 0 && bytes.Equal(ethLog.Topics[0], f.Topic0)
+	topicMatches := len(ethLog.Topics) > 0 && bytes.Equal(ethLog.Topics[0], f.Topic0) && f.matchesTopics(ethLog.Topics)
 	addrMatches := f.Address == nil || bytes.Equal(ethLog.Address[:], f.Address[:])
-	if !topicMatches || !addrMatches {
-		log.L(ctx).Debugf("skipping event '%s' topicMatches=%t addrMatches=%t", protoID, topicMatches, addrMatches)
+	blockMatches := f.matchesBlock(blockNumber)
+	if !topicMatches || !addrMatches || !blockMatches {
+		log.L(ctx).Debugf("skipping event '%s' topicMatches=%t addrMatches=%t blockMatches=%t", protoID, topicMatches, addrMatches, blockMatches)
 		return nil, matched, decoded, nil
 	}
 	matched = true
 
 	log.L(ctx).Infof("detected event '%s'", protoID)
-	data, decoded := ee.decodeLogData(ctx, f.Event, ethLog.Topics, ethLog.Data)
+
+	var implementation *ethtypes.Address0xHex
+	event := f.Event
+	if ee.connector.proxyResolution && ethLog.Address != nil {
+		// The filter stays on the proxy address, but the event was emitted against the ABI of the implementation
+		// the proxy delegated to at the block of the event. Failing to resolve it only loses that enrichment.
+		implementation, err = ee.connector.proxyImplementation(ctx, ethLog.Address, ethLog.BlockNumber.String())
+		if err != nil {
+			log.L(ctx).Warnf("Failed to resolve proxy implementation for contract '%s' at block %d: %v", ethLog.Address, blockNumber, err)
+		}
+		if implEvent := ee.connector.implementationEvent(implementation, f.Topic0); implEvent != nil {
+			event = implEvent
+		}
+	}
+	data, decoded := ee.decodeLogData(ctx, event, ethLog.Topics, ethLog.Data)
 
 	if len(ee.connector.chainID) == 0 {
 		// by calling IsReady, ee.connector.chainID SHOULD be set to the chain ID when the connector is ready
@@ -68,8 +95,22 @@ func (ee *eventEnricher) filterEnrichEthLog(ctx context.Context, f *eventFilter,
 	}
 
 	info := eventInfo{
-		logJSONRPC: *ethLog,
-		ChainID:    ee.connector.chainID,
+		logJSONRPC:     *ethLog,
+		ChainID:        ee.connector.chainID,
+		PrivacyGroupID: ee.privacyGroupID,
+		Sequence:       eventSequence(blockNumber, transactionIndex, logIndex),
+		Implementation: implementation,
+		EventVersion:   f.Version,
+		strictNumbers:  ee.strictNumbers,
+	}
+	if ee.bridges {
+		info.Bridge = ee.bridgeMessage(ctx, ethLog)
+	}
+	if ee.connector.l1Inclusion != nil {
+		// The status is as of the delivery of the event, and failing to query L1 only loses the annotation
+		if info.L1Inclusion, err = ee.connector.l1InclusionOf(ctx, blockNumber); err != nil {
+			log.L(ctx).Warnf("Failed to get the L1 inclusion status of block %d: %v", blockNumber, err)
+		}
 	}
 
 	var timestamp *fftypes.FFTime
@@ -87,7 +128,11 @@ func (ee *eventEnricher) filterEnrichEthLog(ctx context.Context, f *eventFilter,
 	}
 
 	if len(methods) > 0 || ee.extractSigner {
-		txInfo, err := ee.connector.getTransactionInfo(ctx, ethLog.TransactionHash)
+		getTransactionInfo := ee.connector.getTransactionInfo
+		if ee.privacyGroupID != "" {
+			getTransactionInfo = ee.connector.getPrivateTransactionInfo
+		}
+		txInfo, err := getTransactionInfo(ctx, ethLog.TransactionHash)
 		if err != nil {
 			log.L(ctx).Errorf("Failed to get transaction info for transaction hash '%s': %v", ethLog.TransactionHash, err)
 			return nil, matched, decoded, err // This is an error condition, rather than just something we cannot enrich
@@ -120,7 +165,7 @@ func (ee *eventEnricher) filterEnrichEthLog(ctx context.Context, f *eventFilter,
 			Timestamp:        timestamp,
 		},
 		Info: &info,
-		Data: data,
+		Data: strictJSONAny(ee.strictNumbers, data),
 	}, matched, decoded, nil
 }
 
@@ -128,7 +173,7 @@ func (ee *eventEnricher) decodeLogData(ctx context.Context, event *abi.Entry, to
 	var b []byte
 	v, err := event.DecodeEventDataCtx(ctx, topics, data)
 	if err == nil {
-		b, err = ee.connector.serializer.SerializeJSONCtx(ctx, v)
+		b, err = ee.outputSerializer().SerializeJSONCtx(ctx, v)
 	}
 	if err != nil {
 		log.L(ctx).Errorf("Failed to process event log: %s", err)
@@ -158,7 +203,7 @@ func (ee *eventEnricher) matchMethod(ctx context.Context, methods []*abi.Entry,
 	v, err := method.DecodeCallDataCtx(ctx, txInfo.Input)
 	var b []byte
 	if err == nil {
-		b, err = ee.connector.serializer.SerializeJSONCtx(ctx, v)
+		b, err = ee.outputSerializer().SerializeJSONCtx(ctx, v)
 	}
 	if err != nil {
 		log.L(ctx).Warnf("Failed to decode input for TX '%s' using '%s'", txInfo.Hash, info.InputMethod)
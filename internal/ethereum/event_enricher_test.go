@@ -23,6 +23,7 @@ import (
 
 	"github.com/hyperledger/firefly-signer/pkg/abi"
 	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -30,6 +31,7 @@ import (
 func TestEventEnricher_FilterEnrichEthLog_BasicMatch(t *testing.T) {
 	_, conn, mRPC, done := newTestConnector(t)
 	defer done()
+	mockNodeInfoUnsupported(mRPC)
 	mRPC.On("CallRPC", mock.Anything, mock.Anything, "net_version", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
 		*args[1].(*string) = "1"
 	}).Maybe()
@@ -202,6 +204,7 @@ func TestEventEnricher_FilterEnrichEthLog_NoAddressFilter(t *testing.T) {
 	_, conn, mRPC, done := newTestConnector(t)
 	defer done()
 
+	mockNodeInfoUnsupported(mRPC)
 	mRPC.On("CallRPC", mock.Anything, mock.Anything, "net_version", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
 		*args[1].(*string) = "1"
 	}).Maybe()
@@ -268,6 +271,7 @@ func TestEventEnricher_FilterEnrichEthLog_ChainIDNotSet(t *testing.T) {
 	_, conn, mRPC, done := newTestConnector(t)
 	defer done()
 
+	mockNodeInfoUnsupported(mRPC)
 	mRPC.On("CallRPC", mock.Anything, mock.Anything, "net_version", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
 		*args[1].(*string) = "1"
 	}).Maybe()
@@ -329,3 +333,153 @@ func TestEventEnricher_FilterEnrichEthLog_ChainIDNotSet(t *testing.T) {
 	assert.True(t, matched)
 	assert.NotNil(t, ev)
 }
+
+func TestEventEnricher_FilterEnrichEthLog_ProxyResolution(t *testing.T) {
+	_, conn, mRPC, done := newTestConnector(t)
+	defer done()
+	conn.chainID = "1"
+	conn.eventBlockTimestamps = false
+	conn.proxyResolution = true
+
+	mockStorageSlot(mRPC, sampleContractAddress, eip1967ImplementationSlot, sampleImplementationWord, "0x64")
+	mockStorageSlot(mRPC, sampleContractAddress, eip1967AdminSlot, emptyStorageWord, "0x64")
+
+	ee := &eventEnricher{
+		connector: conn,
+	}
+
+	var eventABI *abi.Entry
+	err := json.Unmarshal([]byte(`{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "name": "from", "type": "address"},
+			{"indexed": true, "name": "to", "type": "address"},
+			{"indexed": false, "name": "value", "type": "uint256"}
+		],
+		"name": "Transfer",
+		"type": "event"
+	}`), &eventABI)
+	assert.NoError(t, err)
+
+	// The filter remains on the proxy address
+	topic0, err := eventABI.SignatureHashCtx(context.Background())
+	assert.NoError(t, err)
+	addr := ethtypes.MustNewAddress(sampleContractAddress)
+	filter := &eventFilter{
+		Topic0:  topic0,
+		Address: addr,
+		Event:   eventABI,
+	}
+
+	log := &logJSONRPC{
+		Address:          addr,
+		Topics:           []ethtypes.HexBytes0xPrefix{topic0},
+		Data:             []byte{},
+		BlockNumber:      ethtypes.NewHexInteger64(100),
+		TransactionIndex: ethtypes.NewHexInteger64(1),
+		LogIndex:         ethtypes.NewHexInteger64(0),
+		BlockHash:        ethtypes.HexBytes0xPrefix{},
+	}
+
+	ev, matched, _, err := ee.filterEnrichEthLog(context.Background(), filter, nil, log)
+	assert.NoError(t, err)
+	assert.True(t, matched)
+	assert.Equal(t, "0xaab1c59b2a5d6c0a5e3f7fd9d73d9b6e6b31fe32", ev.Info.(*eventInfo).Implementation.String())
+	assert.Equal(t, "100000001000000", ev.Info.(*eventInfo).Sequence.String())
+}
+
+func TestEventEnricher_FilterEnrichEthLog_ProxyResolutionFail(t *testing.T) {
+	_, conn, mRPC, done := newTestConnector(t)
+	defer done()
+	conn.chainID = "1"
+	conn.eventBlockTimestamps = false
+	conn.proxyResolution = true
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getStorageAt", mock.Anything, mock.Anything, "0x64").
+		Return(&rpcbackend.RPCError{Message: "pop"})
+
+	ee := &eventEnricher{
+		connector: conn,
+	}
+
+	topic0 := ethtypes.MustNewHexBytes0xPrefix("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+	addr := ethtypes.MustNewAddress(sampleContractAddress)
+	filter := &eventFilter{
+		Topic0:  topic0,
+		Address: addr,
+		Event:   &abi.Entry{Type: abi.Event, Name: "Transfer"},
+	}
+	log := &logJSONRPC{
+		Address:          addr,
+		Topics:           []ethtypes.HexBytes0xPrefix{topic0},
+		BlockNumber:      ethtypes.NewHexInteger64(100),
+		TransactionIndex: ethtypes.NewHexInteger64(1),
+		LogIndex:         ethtypes.NewHexInteger64(0),
+	}
+
+	// The event is still delivered, just without the implementation
+	ev, matched, _, err := ee.filterEnrichEthLog(context.Background(), filter, nil, log)
+	assert.NoError(t, err)
+	assert.True(t, matched)
+	assert.Nil(t, ev.Info.(*eventInfo).Implementation)
+}
+
+func TestEventEnricher_FilterEnrichEthLog_ProxyImplementationABI(t *testing.T) {
+	_, conn, mRPC, done := newTestConnector(t)
+	defer done()
+	conn.chainID = "1"
+	conn.eventBlockTimestamps = false
+	conn.proxyResolution = true
+	conn.implementationABIs = testImplementationABIs(t)
+
+	mockStorageSlot(mRPC, sampleContractAddress, eip1967ImplementationSlot, sampleImplementationWord, "0x64")
+	mockStorageSlot(mRPC, sampleContractAddress, eip1967AdminSlot, emptyStorageWord, "0x64")
+
+	ee := &eventEnricher{
+		connector: conn,
+	}
+
+	// The listener was created with the ABI of the proxy, which names the parameters differently
+	var eventABI *abi.Entry
+	err := json.Unmarshal([]byte(`{
+		"name": "Transfer",
+		"type": "event",
+		"inputs": [
+			{"indexed": true, "name": "from", "type": "address"},
+			{"indexed": true, "name": "to", "type": "address"},
+			{"indexed": false, "name": "value", "type": "uint256"}
+		]
+	}`), &eventABI)
+	assert.NoError(t, err)
+	topic0, err := eventABI.SignatureHashCtx(context.Background())
+	assert.NoError(t, err)
+	addr := ethtypes.MustNewAddress(sampleContractAddress)
+	filter := &eventFilter{
+		Topic0:  topic0,
+		Address: addr,
+		Event:   eventABI,
+	}
+	log := &logJSONRPC{
+		Address: addr,
+		Topics: []ethtypes.HexBytes0xPrefix{
+			topic0,
+			ethtypes.MustNewHexBytes0xPrefix("0x0000000000000000000000003968ef051b422d3d1cdc182a88bba8dd922e6fa4"),
+			ethtypes.MustNewHexBytes0xPrefix("0x000000000000000000000000d0f2f5103fd050739a9fb567251bc460cc24d091"),
+		},
+		Data:             ethtypes.MustNewHexBytes0xPrefix("0x0000000000000000000000000000000000000000000000000000000000000064"),
+		BlockNumber:      ethtypes.NewHexInteger64(100),
+		TransactionIndex: ethtypes.NewHexInteger64(1),
+		LogIndex:         ethtypes.NewHexInteger64(0),
+	}
+
+	ev, matched, decoded, err := ee.filterEnrichEthLog(context.Background(), filter, nil, log)
+	assert.NoError(t, err)
+	assert.True(t, matched)
+	assert.True(t, decoded)
+	assert.JSONEq(t, `{
+		"sender": "0x3968ef051b422d3d1cdc182a88bba8dd922e6fa4",
+		"recipient": "0xd0f2f5103fd050739a9fb567251bc460cc24d091",
+		"amount": "100"
+	}`, ev.Data.String())
+	assert.Equal(t, "0xaab1c59b2a5d6c0a5e3f7fd9d73d9b6e6b31fe32", ev.Info.(*eventInfo).Implementation.String())
+}
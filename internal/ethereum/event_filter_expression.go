@@ -0,0 +1,334 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+)
+
+// filterExpression is a boolean expression over the decoded fields of an event, such as
+// `value > 1000000 && to == '0xabc…'`, that selects the events a listener delivers.
+//
+// Fields are referenced by name, with `.` and `[n]` to reach the members of structs and arrays.
+// Strings that are decimal or 0x prefixed hex numbers compare as numbers, so integers compare
+// whatever the number format, and addresses compare regardless of their case.
+// A comparison with a field the event does not have, or with a value of a different type, is false.
+type filterExpression struct {
+	source string
+	root   filterNode
+}
+
+type filterNode interface {
+	evaluate(data interface{}) bool
+}
+
+type filterAnd struct{ left, right filterNode }
+
+type filterOr struct{ left, right filterNode }
+
+type filterNot struct{ operand filterNode }
+
+// filterOperand is a field or a literal, which is true on its own only if it is the boolean true
+type filterOperand struct {
+	path    []interface{} // field names and array indexes, for a field
+	literal interface{}
+}
+
+type filterComparison struct {
+	op          string
+	left, right *filterOperand
+}
+
+func (n *filterAnd) evaluate(data interface{}) bool {
+	return n.left.evaluate(data) && n.right.evaluate(data)
+}
+
+func (n *filterOr) evaluate(data interface{}) bool {
+	return n.left.evaluate(data) || n.right.evaluate(data)
+}
+
+func (n *filterNot) evaluate(data interface{}) bool {
+	return !n.operand.evaluate(data)
+}
+
+func (n *filterOperand) evaluate(data interface{}) bool {
+	v, ok := n.value(data)
+	return ok && v == true
+}
+
+func (n *filterOperand) value(data interface{}) (interface{}, bool) {
+	if n.path == nil {
+		return n.literal, true
+	}
+	v := data
+	for _, p := range n.path {
+		switch p := p.(type) {
+		case string:
+			m, ok := v.(map[string]interface{})
+			if !ok {
+				return nil, false
+			}
+			if v, ok = m[p]; !ok {
+				return nil, false
+			}
+		case int:
+			a, ok := v.([]interface{})
+			if !ok || p >= len(a) {
+				return nil, false
+			}
+			v = a[p]
+		}
+	}
+	return comparableValue(v), true
+}
+
+func (n *filterComparison) evaluate(data interface{}) bool {
+	left, ok := n.left.value(data)
+	if !ok {
+		return false
+	}
+	right, ok := n.right.value(data)
+	if !ok {
+		return false
+	}
+	var cmp int
+	switch l := left.(type) {
+	case *big.Rat:
+		r, ok := right.(*big.Rat)
+		if !ok {
+			return false
+		}
+		cmp = l.Cmp(r)
+	case string:
+		r, ok := right.(string)
+		if !ok {
+			return false
+		}
+		cmp = strings.Compare(l, r)
+	case bool:
+		r, ok := right.(bool)
+		if !ok || (n.op != "==" && n.op != "!=") {
+			return false
+		}
+		if l != r {
+			cmp = 1
+		}
+	default:
+		return false
+	}
+	switch n.op {
+	case "==":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	default: // ">="
+		return cmp >= 0
+	}
+}
+
+var decimalNumberRegex = regexp.MustCompile(`^-?\d+(\.\d+)?([eE][-+]?\d+)?$`)
+
+// comparableValue converts numbers, and strings holding numbers, to a *big.Rat
+func comparableValue(v interface{}) interface{} {
+	var s string
+	switch v := v.(type) {
+	case json.Number:
+		s = v.String()
+	case string:
+		s = v
+	default:
+		return v
+	}
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		if i, ok := new(big.Int).SetString(s[2:], 16); ok {
+			return new(big.Rat).SetInt(i)
+		}
+	} else if decimalNumberRegex.MatchString(s) {
+		if r, ok := new(big.Rat).SetString(s); ok {
+			return r
+		}
+	}
+	return s
+}
+
+// matches evaluates the expression against the decoded data of an event
+func (fe *filterExpression) matches(data *fftypes.JSONAny) bool {
+	var v interface{}
+	if data != nil {
+		d := json.NewDecoder(bytes.NewReader(data.Bytes()))
+		d.UseNumber()
+		_ = d.Decode(&v) // the data of an event that cannot be decoded has no fields to match
+	}
+	return fe.root.evaluate(v)
+}
+
+var filterTokenRegex = regexp.MustCompile(`^(\s+|&&|\|\||==|!=|<=|>=|[<>!()]|'[^']*'|"[^"]*"|-?[0-9][0-9a-zA-Z.+\-]*|[a-zA-Z_$][a-zA-Z0-9_$]*(\.[a-zA-Z_$][a-zA-Z0-9_$]*|\[[0-9]+\])*)`)
+
+type filterParser struct {
+	tokens []string
+	pos    int
+}
+
+func parseFilterExpression(ctx context.Context, source string) (*filterExpression, error) {
+	p := &filterParser{}
+	for remaining := source; remaining != ""; {
+		token := filterTokenRegex.FindString(remaining)
+		if token == "" {
+			return nil, i18n.NewError(ctx, msgs.MsgInvalidFilterExpression, source, fmt.Sprintf("unexpected character at position %d", len(source)-len(remaining)))
+		}
+		if strings.TrimSpace(token) != "" {
+			p.tokens = append(p.tokens, token)
+		}
+		remaining = remaining[len(token):]
+	}
+	root, err := p.parseOr()
+	if err == nil && p.pos < len(p.tokens) {
+		err = fmt.Errorf("unexpected '%s'", p.tokens[p.pos])
+	}
+	if err != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidFilterExpression, source, err.Error())
+	}
+	return &filterExpression{source: source, root: root}, nil
+}
+
+func (p *filterParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *filterParser) next() (string, error) {
+	if p.pos >= len(p.tokens) {
+		return "", fmt.Errorf("unexpected end of expression")
+	}
+	p.pos++
+	return p.tokens[p.pos-1], nil
+}
+
+func (p *filterParser) parseOr() (filterNode, error) {
+	left, err := p.parseAnd()
+	for err == nil && p.peek() == "||" {
+		p.pos++
+		var right filterNode
+		if right, err = p.parseAnd(); err == nil {
+			left = &filterOr{left: left, right: right}
+		}
+	}
+	return left, err
+}
+
+func (p *filterParser) parseAnd() (filterNode, error) {
+	left, err := p.parseUnary()
+	for err == nil && p.peek() == "&&" {
+		p.pos++
+		var right filterNode
+		if right, err = p.parseUnary(); err == nil {
+			left = &filterAnd{left: left, right: right}
+		}
+	}
+	return left, err
+}
+
+func (p *filterParser) parseUnary() (filterNode, error) {
+	switch p.peek() {
+	case "!":
+		p.pos++
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &filterNot{operand: operand}, nil
+	case "(":
+		p.pos++
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if t, err := p.next(); err != nil || t != ")" {
+			return nil, fmt.Errorf("missing ')'")
+		}
+		return n, nil
+	}
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	switch op := p.peek(); op {
+	case "==", "!=", "<", "<=", ">", ">=":
+		p.pos++
+		right, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		return &filterComparison{op: op, left: left, right: right}, nil
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseOperand() (*filterOperand, error) {
+	t, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case t == "true" || t == "false":
+		return &filterOperand{literal: t == "true"}, nil
+	case t[0] == '\'' || t[0] == '"':
+		return &filterOperand{literal: comparableValue(t[1 : len(t)-1])}, nil
+	case t[0] == '-' || (t[0] >= '0' && t[0] <= '9'):
+		v := comparableValue(t)
+		if _, ok := v.(*big.Rat); !ok {
+			return nil, fmt.Errorf("invalid number '%s'", t)
+		}
+		return &filterOperand{literal: v}, nil
+	case t[0] == '_' || t[0] == '$' || (t[0] >= 'a' && t[0] <= 'z') || (t[0] >= 'A' && t[0] <= 'Z'):
+		return &filterOperand{path: parseFilterPath(t)}, nil
+	}
+	return nil, fmt.Errorf("unexpected '%s'", t)
+}
+
+// parseFilterPath splits a tokenized field reference like `a.b[1].c` into its names and indexes
+func parseFilterPath(t string) []interface{} {
+	var path []interface{}
+	for _, name := range strings.Split(strings.ReplaceAll(t, "[", ".["), ".") {
+		if strings.HasPrefix(name, "[") {
+			idx, _ := strconv.Atoi(name[1 : len(name)-1]) // digits only, as matched by the token regex
+			path = append(path, idx)
+		} else {
+			path = append(path, name)
+		}
+	}
+	return path
+}
@@ -0,0 +1,130 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterExpressionEvaluate(t *testing.T) {
+	data := fftypes.JSONAnyPtr(`{
+		"from": "0x3968ef051b422d3d1cdc182a88bba8dd922e6fa4",
+		"to": "0xD0F2F5103fD050739A9fb567251BC460cc24d091",
+		"value": "1000",
+		"hexValue": "0x3e8",
+		"numeric": 1.5,
+		"name": "bob",
+		"approved": true,
+		"details": {"ids": ["1", "2"]}
+	}`)
+	for expression, expected := range map[string]bool{
+		`value > 999`:       true,
+		`value >= 1000.0`:   true,
+		`value < 1e3`:       false,
+		`value <= 0x3e8`:    true,
+		`hexValue == value`: true,
+		`value != 1000`:     false,
+		`numeric > 1`:       true,
+		`numeric == '1.5'`:  true,
+		`value > -1`:        true,
+		`to == '0xd0f2f5103fd050739a9fb567251bc460cc24d091'`:                  true,
+		`value > 100 && from == "0x3968EF051B422D3D1CDC182A88BBA8DD922E6FA4"`: true,
+		`value > 100 && from == '0xd0f2f5103fd050739a9fb567251bc460cc24d091'`: false,
+		`value > 10000 || (name == 'bob' && !(name == 'alice'))`:              true,
+		`name < 'carol'`:       true,
+		`name == 1`:            false,
+		`approved`:             true,
+		`!approved`:            false,
+		`approved == true`:     true,
+		`approved != false`:    true,
+		`approved > false`:     false,
+		`approved == 'true'`:   false,
+		`details.ids[1] == 2`:  true,
+		`details.ids[2] == 2`:  false,
+		`details.missing == 2`: false,
+		`details.ids.x == 2`:   false,
+		`value.x != 2`:         false,
+		`missing != 1`:         false,
+		`details == 1`:         false,
+		`value == name`:        false,
+		`value == missing`:     false,
+		`name`:                 false,
+		`'0xzz' == '0xzz'`:     true,
+	} {
+		fe, err := parseFilterExpression(context.Background(), expression)
+		assert.NoError(t, err, expression)
+		assert.Equal(t, expected, fe.matches(data), expression)
+	}
+
+	fe, err := parseFilterExpression(context.Background(), `value > 1`)
+	assert.NoError(t, err)
+	assert.False(t, fe.matches(nil))
+	assert.False(t, fe.matches(fftypes.JSONAnyPtr(`["0x10"]`)))
+	fe, err = parseFilterExpression(context.Background(), `[0] > 1`)
+	assert.Regexp(t, "FF23179", err)
+}
+
+func TestFilterExpressionInvalid(t *testing.T) {
+	for _, expression := range []string{
+		``,
+		`value >`,
+		`value > 1 &&`,
+		`(value > 1`,
+		`!`,
+		`(value >`,
+		`value == (`,
+		`value > 1)`,
+		`value # 1`,
+		`value == 'unterminated`,
+		`value > 1x`,
+		`== 1`,
+		`value > 1 value`,
+	} {
+		_, err := parseFilterExpression(context.Background(), expression)
+		assert.Regexp(t, "FF23179", err, expression)
+	}
+}
+
+func TestFilterExpressionListener(t *testing.T) {
+	l, _, cancel := newTestListener(t, false)
+	defer cancel()
+	l.c.chainID = "1"
+	l.c.eventBlockTimestamps = false
+
+	options, err := parseListenerOptions(context.Background(), fftypes.JSONAnyPtr(`{"filter":"value >= 1000"}`))
+	assert.NoError(t, err)
+	l.config.options = options
+	ev, ok, err := l.filterEnrichEthLog(context.Background(), l.config.filters[0], nil, sampleTransferLog())
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, l.id, ev.Event.ID.ListenerID)
+
+	options, err = parseListenerOptions(context.Background(), fftypes.JSONAnyPtr(`{"filter":"value > 1000"}`))
+	assert.NoError(t, err)
+	l.config.options = options
+	ev, ok, err = l.filterEnrichEthLog(context.Background(), l.config.filters[0], nil, sampleTransferLog())
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.Nil(t, ev)
+
+	_, err = parseListenerOptions(context.Background(), fftypes.JSONAnyPtr(`{"filter":"value >"}`))
+	assert.Regexp(t, "FF23179", err)
+}
@@ -20,8 +20,11 @@ import (
 	"context"
 	"encoding/json"
 	"math/big"
+	"strings"
 	"sync"
+	"time"
 
+	lru "github.com/hashicorp/golang-lru"
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly-common/pkg/i18n"
 	"github.com/hyperledger/firefly-common/pkg/log"
@@ -31,17 +34,28 @@ import (
 	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
 )
 
+// fromBlockPending starts a listener from the next block to be mined, in addition to the FFCAPI "earliest" and "latest"
+const fromBlockPending = "pending"
+
 // listenerCheckpoint is our Ethereum specific custom options that can be specified when creating a listener
 type listenerOptions struct {
-	Methods []*abi.Entry `json:"methods,omitempty"` // An optional array of ABI methods. If specified and the input data for a transaction matches, the decoded inputs will be included in the event
-	Signer  bool         `json:"signer,omitempty"`  // An optional boolean for whether to extract the signer of the transaction that emitted the event
+	Methods        []*abi.Entry `json:"methods,omitempty"`        // An optional array of ABI methods. If specified and the input data for a transaction matches, the decoded inputs will be included in the event
+	Signer         bool         `json:"signer,omitempty"`         // An optional boolean for whether to extract the signer of the transaction that emitted the event
+	PrivacyGroupID string       `json:"privacyGroupId,omitempty"` // An optional Besu privacy group, to listen to the private events of the group rather than public events
+	Bridges        bool         `json:"bridges,omitempty"`        // An optional boolean for whether to annotate the events of native L1<->L2 bridge contracts with their bridge message
+	BridgeChainID  string       `json:"bridgeChainId,omitempty"`  // The chain ID of the other side of the bridge, for the target chain of bridge messages initiated on this chain
+	NumberFormat   string       `json:"numberFormat,omitempty"`   // An optional format for the integers of decoded events and inputs, overriding the configured format
+	Filter         string       `json:"filter,omitempty"`         // An optional expression on the decoded fields of events, to only deliver the events that match it
+
+	filterExpression *filterExpression
 }
 
 // listenerCheckpoint is our Ethereum specific checkpoint structure
 type listenerCheckpoint struct {
-	Block            int64 `json:"block"`
-	TransactionIndex int64 `json:"transactionIndex"`
-	LogIndex         int64 `json:"logIndex"`
+	Block            int64  `json:"block"`
+	TransactionIndex int64  `json:"transactionIndex"`
+	LogIndex         int64  `json:"logIndex"`
+	PrivacyGroupID   string `json:"privacyGroupId,omitempty"` // set for listeners of a privacy group, as the checkpoint is a position in the private logs of the group
 }
 
 // listenerConfig is the configuration parsed from generic FFCAPI connector framework JSON, into our Ethereum specific options
@@ -55,16 +69,22 @@ type listenerConfig struct {
 
 // listener is the state we hold in memory for each individual listener that has been added
 type listener struct {
-	id              *fftypes.UUID
-	c               *ethConnector
-	es              *eventStream
-	ee              *eventEnricher
-	hwmMux          sync.Mutex // Protects checkpoint of an individual listener. May hold ES lock when taking this, must NOT attempt to obtain ES lock while holding this
-	hwmBlock        int64
-	config          listenerConfig
-	removed         bool
-	catchup         bool
-	catchupLoopDone chan struct{}
+	id               *fftypes.UUID
+	c                *ethConnector
+	es               *eventStream
+	ee               *eventEnricher
+	hwmMux           sync.Mutex // Protects checkpoint of an individual listener. May hold ES lock when taking this, must NOT attempt to obtain ES lock while holding this
+	hwmBlock         int64
+	resumeAfter      *listenerCheckpoint // the event checkpoint the listener was restored from, if any, up to which events are not redelivered
+	storedCheckpoint *listenerCheckpoint // the last checkpoint written to the checkpoint store, if there is one
+	config           listenerConfig
+	removed          bool
+	catchup          bool
+	paused           bool // set while delivery is paused through the API, under the lock of the event stream
+	catchupLoopDone  chan struct{}
+	seqMux           sync.Mutex // Protects the record of delivered events used to enforce ordering
+	lastSequence     *big.Int
+	deliveredBlocks  *lru.Cache
 }
 
 type logFilterJSONRPC struct {
@@ -94,14 +114,27 @@ func (cp *listenerCheckpoint) LessThan(b ffcapi.EventListenerCheckpoint) bool {
 				(cp.TransactionIndex == bcp.TransactionIndex && (cp.LogIndex < bcp.LogIndex))))
 }
 
+// getInitialBlock resolves the fromBlock instruction of a listener, which can be "earliest", "latest",
+// "pending" (the next block to be mined), a block number, a block hash, or an RFC3339 timestamp
 func (l *listener) getInitialBlock(ctx context.Context, fromBlockInstruction string) (int64, error) {
-	if fromBlockInstruction == ffcapi.FromBlockLatest || fromBlockInstruction == "" {
+	switch {
+	case fromBlockInstruction == ffcapi.FromBlockEarliest:
+		return 0, nil
+	case fromBlockInstruction == ffcapi.FromBlockLatest || fromBlockInstruction == "" || fromBlockInstruction == fromBlockPending:
 		// Get the latest block number of the chain
 		chainHead, ok := l.c.blockListener.getHighestBlock(ctx)
 		if !ok {
 			return -1, i18n.NewError(ctx, msgs.MsgTimedOutQueryingChainHead)
 		}
+		if fromBlockInstruction == fromBlockPending {
+			return chainHead + 1, nil
+		}
 		return chainHead, nil
+	case strings.HasPrefix(fromBlockInstruction, "0x") && len(fromBlockInstruction) == 66:
+		return l.c.blockNumberByHash(ctx, fromBlockInstruction)
+	}
+	if t, err := time.Parse(time.RFC3339Nano, fromBlockInstruction); err == nil {
+		return l.c.blockNumberAtOrAfter(ctx, t)
 	}
 	num, ok := new(big.Int).SetString(fromBlockInstruction, 0)
 	if !ok {
@@ -118,6 +151,23 @@ func parseListenerOptions(ctx context.Context, o *fftypes.JSONAny) (*listenerOpt
 			return nil, i18n.NewError(ctx, msgs.MsgInvalidListenerOptions, err)
 		}
 	}
+	if options.PrivacyGroupID != "" {
+		if err := validatePrivacyGroupID(ctx, options.PrivacyGroupID); err != nil {
+			return nil, err
+		}
+	}
+	if options.NumberFormat != "" {
+		if _, err := intSerializer(ctx, options.NumberFormat); err != nil {
+			return nil, err
+		}
+	}
+	if options.Filter != "" {
+		fe, err := parseFilterExpression(ctx, options.Filter)
+		if err != nil {
+			return nil, err
+		}
+		options.filterExpression = fe
+	}
 	return &options, nil
 }
 
@@ -161,6 +211,7 @@ func (l *listener) getHWMCheckpoint() *listenerCheckpoint {
 		Block:            l.hwmBlock,
 		TransactionIndex: -1,
 		LogIndex:         -1,
+		PrivacyGroupID:   l.config.options.PrivacyGroupID,
 	}
 }
 
@@ -179,8 +230,9 @@ func (l *listener) moveHWM(hwmBlock int64) {
 func (l *listener) listenerCatchupLoop() {
 	defer close(l.catchupLoopDone)
 
-	// Only filtering on a single listener
-	ctx := log.WithLogField(l.es.ctx, "listener", l.id.String())
+	// Only filtering on a single listener. Catch-up queries give way to transaction submission and
+	// confirmation when the node is busy.
+	ctx := withRPCPriority(withCostListener(log.WithLogField(l.es.ctx, "listener", l.id.String()), l.id), rpcPriorityBulk)
 	al := l.es.buildAggregatedListener([]*listener{l})
 
 	failCount := 0
@@ -195,7 +247,11 @@ func (l *listener) listenerCatchupLoop() {
 			log.L(ctx).Infof("Listener removed during catchup")
 			return
 		}
-		if readyForLead {
+		if l.isPaused() {
+			log.L(ctx).Infof("Listener paused during catchup")
+			return
+		}
+		if readyForLead && !l.private() {
 			// We're done with catchup for this listener - it can join the main group
 			l.es.rejoinLeadGroup(l)
 			log.L(ctx).Infof("Listener completed catchup, and rejoined lead group")
@@ -204,6 +260,23 @@ func (l *listener) listenerCatchupLoop() {
 
 		fromBlock := l.hwmBlock
 		toBlock := l.hwmBlock + l.c.catchupPageSize - 1
+		if l.private() {
+			// Private listeners stay in this loop for their whole life, so follow the head of the chain
+			chainHead, ok := l.c.blockListener.getHighestBlock(ctx)
+			if !ok {
+				log.L(ctx).Debugf("Listener catchup loop exiting (closed checking block height)")
+				return
+			}
+			if fromBlock > chainHead {
+				if l.es.waitFilterPollingInterval() {
+					return
+				}
+				continue
+			}
+			if toBlock > chainHead {
+				toBlock = chainHead
+			}
+		}
 		events, err := l.es.getBlockRangeEvents(ctx, al, fromBlock, toBlock)
 		if err != nil {
 			if l.c.catchupDownscaleRegex.String() != "" && l.c.catchupDownscaleRegex.MatchString(err.Error()) {
@@ -223,14 +296,22 @@ func (l *listener) listenerCatchupLoop() {
 		}
 		log.L(ctx).Infof("Listener catchup fromBlock=%d toBlock=%d events=%d", fromBlock, toBlock, len(events))
 
-		for _, event := range events {
+		for i, event := range events {
 			log.L(ctx).Debugf("Detected event %s (listener catchup)", event.Event)
-			select {
-			case l.es.events <- event:
-			case <-l.es.ctx.Done():
+			if !l.checkSequence(ctx, event) {
+				continue
+			}
+			if !l.es.publishToSinks(event) {
 				log.L(ctx).Infof("Listener catchup loop exiting as stream is stopping")
 				return
 			}
+			if err := l.es.deliverEvent(event); err != nil {
+				if l.es.ctx.Err() != nil || !l.es.deadLetter(map[fftypes.UUID]*listener{*l.id: l}, events[i:], err) {
+					log.L(ctx).Infof("Listener catchup loop exiting as stream is stopping")
+					return
+				}
+				break
+			}
 		}
 		l.hwmMux.Lock()
 		l.hwmBlock = toBlock + 1
@@ -249,19 +330,29 @@ func (l *listener) filterEnrichEthLog(ctx context.Context, f *eventFilter, metho
 		log.L(ctx).Debugf("Listener %s already delivered event '%s' hwm=%d", l.id, getEventProtoID(blockNumber, transactionIndex, logIndex), l.hwmBlock)
 		return nil, false, nil
 	}
+	checkpoint := &listenerCheckpoint{
+		Block:            blockNumber,
+		TransactionIndex: transactionIndex,
+		LogIndex:         logIndex,
+		PrivacyGroupID:   l.config.options.PrivacyGroupID,
+	}
+	if l.resumeAfter != nil && !l.resumeAfter.LessThan(checkpoint) {
+		log.L(ctx).Debugf("Listener %s already delivered event '%s' checkpoint=%+v", l.id, getEventProtoID(blockNumber, transactionIndex, logIndex), l.resumeAfter)
+		return nil, false, nil
+	}
 
-	e, matched, _, err := l.ee.filterEnrichEthLog(ctx, f, methods, ethLog)
+	e, matched, _, err := l.ee.filterEnrichEthLog(withCostListener(ctx, l.id), f, methods, ethLog)
 	if !matched || err != nil || e == nil {
 		return nil, false, err
 	}
 
+	if fe := l.config.options.filterExpression; fe != nil && !fe.matches(e.Data) {
+		log.L(ctx).Debugf("Listener %s skipping event '%s' not matching filter '%s'", l.id, getEventProtoID(blockNumber, transactionIndex, logIndex), fe.source)
+		return nil, false, nil
+	}
 	e.ID.ListenerID = l.id
 	return &ffcapi.ListenerEvent{
-		Checkpoint: &listenerCheckpoint{
-			Block:            blockNumber,
-			TransactionIndex: transactionIndex,
-			LogIndex:         logIndex,
-		},
-		Event: e,
+		Checkpoint: checkpoint,
+		Event:      e,
 	}, true, nil
 }
@@ -118,47 +118,96 @@ func newTestListener(t *testing.T, withMethods bool) (*listener, *rpcbackendmock
 
 func TestGetInitialBlockTimeout(t *testing.T) {
 
+	_, c, mRPC, done := newTestConnector(t)
+	// The timeout starts once the connector is created
 	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Millisecond)
 	defer cancel()
-
-	_, c, mRPC, done := newTestConnector(t)
-	defer done()
 	l := &listener{
 		c: c,
 	}
 
 	blockRPC := make(chan struct{})
+	rpcCalled := make(chan struct{}, 1)
 	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_blockNumber").Return(&rpcbackend.RPCError{Message: "pop"}).Run(func(args mock.Arguments) {
+		select {
+		case rpcCalled <- struct{}{}:
+		default:
+		}
 		<-blockRPC // make it timeout
 	})
 
 	_, err := l.getInitialBlock(ctx, "latest")
 	assert.Regexp(t, "FF23046", err)
 
+	<-rpcCalled // the block listener makes the call in the background, so it can start after the timeout
 	close(blockRPC)
+	done()
+	<-c.blockListener.listenLoopDone // the block listener retries in the background until it is closed
+
+}
+
+func TestGetInitialBlockInstructions(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+	l := &listener{
+		c: c,
+	}
+
+	mockChainHead(mRPC, 100)
+	mockBlocksByNumber(mRPC)
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByHash", testBlockHash(42), false).Return(nil).Run(func(args mock.Arguments) {
+		*(args[1].(**blockInfoJSONRPC)) = &blockInfoJSONRPC{
+			Number: ethtypes.NewHexInteger64(42),
+			Hash:   ethtypes.MustNewHexBytes0xPrefix(testBlockHash(42)),
+		}
+	})
+
+	for instruction, expected := range map[string]int64{
+		"earliest":             0,
+		"latest":               100,
+		"":                     100,
+		"pending":              101,
+		"12345":                12345,
+		"0x10":                 16,
+		testBlockHash(42):      42,
+		"1970-01-01T00:25:00Z": 50,
+	} {
+		n, err := l.getInitialBlock(ctx, instruction)
+		assert.NoError(t, err, instruction)
+		assert.Equal(t, expected, n, instruction)
+	}
 
+	_, err := l.getInitialBlock(ctx, "yesterday")
+	assert.Regexp(t, "FF23034", err)
 }
 
 func TestGetHWMNotInit(t *testing.T) {
 
+	_, c, mRPC, done := newTestConnector(t)
+	// The timeout starts once the connector is created
 	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Millisecond)
 	defer cancel()
-
-	_, c, mRPC, done := newTestConnector(t)
-	defer done()
 	l := &listener{
 		c: c,
 	}
 
 	blockRPC := make(chan struct{})
+	rpcCalled := make(chan struct{}, 1)
 	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_blockNumber").Return(&rpcbackend.RPCError{Message: "pop"}).Run(func(args mock.Arguments) {
+		select {
+		case rpcCalled <- struct{}{}:
+		default:
+		}
 		<-blockRPC // make it timeout
 	})
 
 	_, err := l.getInitialBlock(ctx, "latest")
 	assert.Regexp(t, "FF23046", err)
 
+	<-rpcCalled // the block listener makes the call in the background, so it can start after the timeout
 	close(blockRPC)
+	done()
+	<-c.blockListener.listenLoopDone // the block listener retries in the background until it is closed
 
 }
 
@@ -169,6 +218,7 @@ func TestListenerCatchupErrorsThenDeliveryExit(t *testing.T) {
 	l.catchupLoopDone = make(chan struct{})
 	l.hwmBlock = 0
 
+	mockNodeInfoUnsupported(mRPC)
 	mRPC.On("CallRPC", mock.Anything, mock.Anything, "net_version", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
 		l.ee.connector.chainID = "12345"
 	}).Once()
@@ -197,6 +247,7 @@ func TestListenerCatchupScalesBackOnExpectedError(t *testing.T) {
 	l.catchupLoopDone = make(chan struct{})
 	l.hwmBlock = 0
 
+	mockNodeInfoUnsupported(mRPC)
 	mRPC.On("CallRPC", mock.Anything, mock.Anything, "net_version", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
 		l.ee.connector.chainID = "12345"
 	}).Once()
@@ -227,6 +278,7 @@ func TestListenerCatchupScalesBackNTimesOnExpectedError(t *testing.T) {
 	l.catchupLoopDone = make(chan struct{})
 	l.hwmBlock = 0
 
+	mockNodeInfoUnsupported(mRPC)
 	mRPC.On("CallRPC", mock.Anything, mock.Anything, "net_version", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
 		l.ee.connector.chainID = "12345"
 	}).Once()
@@ -257,6 +309,7 @@ func TestListenerCatchupScalesBackToOne(t *testing.T) {
 	l.catchupLoopDone = make(chan struct{})
 	l.hwmBlock = 0
 
+	mockNodeInfoUnsupported(mRPC)
 	mRPC.On("CallRPC", mock.Anything, mock.Anything, "net_version", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
 		l.ee.connector.chainID = "12345"
 	}).Once()
@@ -287,6 +340,7 @@ func TestListenerNoCatchupScaleBackOnErrorMismatch(t *testing.T) {
 	l.catchupLoopDone = make(chan struct{})
 	l.hwmBlock = 0
 
+	mockNodeInfoUnsupported(mRPC)
 	mRPC.On("CallRPC", mock.Anything, mock.Anything, "net_version", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
 		l.ee.connector.chainID = "12345"
 	}).Once()
@@ -321,6 +375,7 @@ func TestListenerCatchupScalesBackCustomRegex(t *testing.T) {
 
 	assert.NoError(t, err)
 
+	mockNodeInfoUnsupported(mRPC)
 	mRPC.On("CallRPC", mock.Anything, mock.Anything, "net_version", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
 		l.ee.connector.chainID = "12345"
 	}).Once()
@@ -363,6 +418,7 @@ func TestListenerCatchupNoScaleBackEmptyRegex(t *testing.T) {
 		}
 	})
 
+	mockNodeInfoUnsupported(mRPC)
 	mRPC.On("CallRPC", mock.Anything, mock.Anything, "net_version", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
 		l.ee.connector.chainID = "12345"
 	}).Once()
@@ -475,6 +531,7 @@ func TestFilterEnrichEthLogMethodInputsOk(t *testing.T) {
 	err := json.Unmarshal([]byte(abiTransferEvent), &abiEvent)
 	assert.NoError(t, err)
 
+	mockNodeInfoUnsupported(mRPC)
 	mRPC.On("CallRPC", mock.Anything, mock.Anything, "net_version", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
 		l.ee.connector.chainID = "12345"
 	}).Once()
@@ -712,3 +769,31 @@ func TestFilterEnrichEthLogMethodBadInputABIData(t *testing.T) {
 	assert.Nil(t, ei.InputArgs)
 
 }
+
+func testDeliveredEvent(l *listener, block, transactionIndex, logIndex int64) *ffcapi.ListenerEvent {
+	return &ffcapi.ListenerEvent{
+		Checkpoint: &listenerCheckpoint{Block: block, TransactionIndex: transactionIndex, LogIndex: logIndex},
+		Event: &ffcapi.Event{
+			ID: ffcapi.EventID{ListenerID: l.id},
+		},
+	}
+}
+
+func TestFilterEnrichEthLogResumeAfterCheckpoint(t *testing.T) {
+	_, l, _, done := newTestReplayStream(t)
+	defer done()
+	ethLog := sampleTransferLog()
+	ethLog.BlockNumber = ethtypes.NewHexInteger64(testHighBlock)
+
+	// The log is at transaction 64, log 2 in the block, which was already processed before the restart
+	l.resumeAfter = &listenerCheckpoint{Block: testHighBlock, TransactionIndex: 64, LogIndex: 2}
+	_, matched, err := l.filterEnrichEthLog(l.es.ctx, l.config.filters[0], nil, ethLog)
+	assert.NoError(t, err)
+	assert.False(t, matched)
+
+	l.resumeAfter = &listenerCheckpoint{Block: testHighBlock, TransactionIndex: 64, LogIndex: 1}
+	ev, matched, err := l.filterEnrichEthLog(l.es.ctx, l.config.filters[0], nil, ethLog)
+	assert.NoError(t, err)
+	assert.True(t, matched)
+	assert.Equal(t, &listenerCheckpoint{Block: testHighBlock, TransactionIndex: 64, LogIndex: 2}, ev.Checkpoint)
+}
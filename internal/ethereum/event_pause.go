@@ -0,0 +1,95 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+)
+
+// ListenerPauseResponse is the state of a listener after it is paused or resumed, with the checkpoint delivery
+// stopped at or resumes from
+type ListenerPauseResponse struct {
+	Paused     bool                `json:"paused"`
+	Checkpoint *listenerCheckpoint `json:"checkpoint"`
+}
+
+// isPaused is true once the listener is paused, so its catchup loop exits
+func (l *listener) isPaused() bool {
+	l.es.mux.Lock()
+	defer l.es.mux.Unlock()
+	return l.paused
+}
+
+// PauseListener stops the delivery of the events of a listener, without removing it. A paused listener is left
+// out of the lead group of the stream, and its catchup loop exits, so its checkpoint does not move until it is
+// resumed. Events already queried for the listener before it was paused are still delivered.
+func (c *ethConnector) PauseListener(ctx context.Context, streamID, listenerID *fftypes.UUID) (*ListenerPauseResponse, error) {
+	es, l, err := c.getStreamListener(ctx, streamID, listenerID)
+	if err != nil {
+		return nil, err
+	}
+	es.mux.Lock()
+	if !l.paused {
+		l.paused = true
+		es.updateCount++
+		log.L(ctx).Infof("Listener '%s' paused", l.id)
+	}
+	es.mux.Unlock()
+	return &ListenerPauseResponse{Paused: true, Checkpoint: l.getHWMCheckpoint()}, nil
+}
+
+// ResumeListener restarts the delivery of the events of a paused listener from its checkpoint, catching up on
+// its own if the stream has moved too far ahead while it was paused
+func (c *ethConnector) ResumeListener(ctx context.Context, streamID, listenerID *fftypes.UUID) (*ListenerPauseResponse, error) {
+	es, l, err := c.getStreamListener(ctx, streamID, listenerID)
+	if err != nil {
+		return nil, err
+	}
+	es.mux.Lock()
+	paused := l.paused
+	catchupLoopDone := l.catchupLoopDone
+	es.mux.Unlock()
+	if paused {
+		// The catchup loop of the listener from before it was paused must have exited before another is started
+		if catchupLoopDone != nil {
+			select {
+			case <-catchupLoopDone:
+			case <-ctx.Done():
+				return nil, i18n.NewError(ctx, msgs.MsgListenerResumeTimeout, l.id)
+			}
+		}
+		es.mux.Lock()
+		if l.paused {
+			l.paused = false
+			readyForLead, removed := l.checkReadyForLeadPackOrRemoved(ctx)
+			l.catchup = !readyForLead || l.private()
+			if l.catchup && !removed {
+				l.catchupLoopDone = make(chan struct{})
+				go l.listenerCatchupLoop()
+			}
+			es.updateCount++
+			log.L(ctx).Infof("Listener '%s' resumed (catchup=%t)", l.id, l.catchup)
+		}
+		es.mux.Unlock()
+	}
+	return &ListenerPauseResponse{Paused: false, Checkpoint: l.getHWMCheckpoint()}, nil
+}
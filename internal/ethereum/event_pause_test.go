@@ -0,0 +1,138 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func leadGroupListeners(es *eventStream) []*listener {
+	var ag *aggregatedListener
+	lastUpdate := -1
+	es.buildReuseLeadGroupListener(&lastUpdate, &ag)
+	return ag.listeners
+}
+
+func TestPauseResumeListener(t *testing.T) {
+	es, l, _, done := newTestReplayStream(t)
+	defer done()
+
+	res, err := es.c.PauseListener(es.ctx, es.id, l.id)
+	assert.NoError(t, err)
+	assert.True(t, res.Paused)
+	assert.Equal(t, int64(testHighBlock), res.Checkpoint.Block)
+	assert.Empty(t, leadGroupListeners(es))
+	assert.True(t, es.status().Listeners[0].Paused)
+
+	// Pausing again has no effect
+	res, err = es.c.PauseListener(es.ctx, es.id, l.id)
+	assert.NoError(t, err)
+	assert.True(t, res.Paused)
+
+	// The listener is close enough to the head of the stream to rejoin the lead group
+	res, err = es.c.ResumeListener(es.ctx, es.id, l.id)
+	assert.NoError(t, err)
+	assert.False(t, res.Paused)
+	assert.Equal(t, int64(testHighBlock), res.Checkpoint.Block)
+	assert.Equal(t, []*listener{l}, leadGroupListeners(es))
+	assert.False(t, es.status().Listeners[0].Paused)
+
+	// Resuming a listener that is not paused has no effect
+	res, err = es.c.ResumeListener(es.ctx, es.id, l.id)
+	assert.NoError(t, err)
+	assert.False(t, res.Paused)
+}
+
+func TestResumeListenerCatchup(t *testing.T) {
+	es, l, mRPC, done := newTestReplayStream(t)
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getLogs", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		*args[1].(*[]*logJSONRPC) = []*logJSONRPC{}
+	}).Maybe()
+
+	_, err := es.c.PauseListener(es.ctx, es.id, l.id)
+	assert.NoError(t, err)
+
+	// The listener fell a long way behind the stream while it was paused, so catches up on its own
+	l.hwmMux.Lock()
+	l.hwmBlock = 0
+	l.hwmMux.Unlock()
+	_, err = es.c.ResumeListener(es.ctx, es.id, l.id)
+	assert.NoError(t, err)
+	es.mux.Lock()
+	assert.True(t, l.catchup)
+	catchupLoopDone := l.catchupLoopDone
+	es.mux.Unlock()
+	assert.Empty(t, leadGroupListeners(es))
+
+	// Pausing stops the catchup loop, without moving the checkpoint any further
+	res, err := es.c.PauseListener(es.ctx, es.id, l.id)
+	assert.NoError(t, err)
+	<-catchupLoopDone
+	assert.Equal(t, res.Checkpoint.Block, l.getHWMCheckpoint().Block)
+
+	// Resuming waits for the catchup loop to stop, or for the request to time out
+	es.mux.Lock()
+	l.catchupLoopDone = make(chan struct{})
+	es.mux.Unlock()
+	cancelledCtx, cancel := context.WithCancel(es.ctx)
+	cancel()
+	_, err = es.c.ResumeListener(cancelledCtx, es.id, l.id)
+	assert.Regexp(t, "FF23178", err)
+	assert.True(t, l.isPaused())
+	close(l.catchupLoopDone)
+}
+
+func TestPauseResumeListenerRoutes(t *testing.T) {
+	es, l, _, done := newTestReplayStream(t)
+	defer done()
+	url, close := newTestRouteServer(t, es.c)
+	defer close()
+
+	for _, action := range []string{"pause", "resume"} {
+		res, err := http.Post(url+"/eventstreams/"+es.id.String()+"/listeners/"+l.id.String()+"/"+action, "application/json", strings.NewReader("{}"))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		var pauseRes ListenerPauseResponse
+		err = json.NewDecoder(res.Body).Decode(&pauseRes)
+		assert.NoError(t, err)
+		assert.Equal(t, action == "pause", pauseRes.Paused)
+	}
+
+	for _, path := range []string{
+		"/eventstreams/wrong/listeners/" + l.id.String() + "/pause",
+		"/eventstreams/" + es.id.String() + "/listeners/wrong/resume",
+	} {
+		res, err := http.Post(url+path, "application/json", strings.NewReader("{}"))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	}
+
+	_, err := es.c.PauseListener(es.ctx, es.id, fftypes.NewUUID())
+	assert.Regexp(t, "FF23043", err)
+	_, err = es.c.ResumeListener(es.ctx, fftypes.NewUUID(), l.id)
+	assert.Regexp(t, "FF23041", err)
+}
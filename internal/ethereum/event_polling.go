@@ -0,0 +1,97 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"github.com/hyperledger/firefly-common/pkg/log"
+)
+
+// The strategies for polling for new events at the head of the chain, in the steady state of an event stream
+const (
+	// EventPollingModeFilters installs a filter on the node, and polls it for the logs that have arrived since the last poll
+	EventPollingModeFilters = "filters"
+	// EventPollingModeGetLogs queries the logs of the blocks that have arrived since the last poll, for nodes that do
+	// not support filters, or where they are more expensive than a ranged query (such as behind a load balancer)
+	EventPollingModeGetLogs = "getLogs"
+)
+
+// leadGroupSteadyStateGetLogs is the steady state of the lead group, polling the range of blocks from the last
+// block queried to the head of the chain with eth_getLogs, rather than through a filter installed on the node
+func (es *eventStream) leadGroupSteadyStateGetLogs() bool {
+	var ag *aggregatedListener
+	lastUpdate := -1
+	failCount := 0
+	nextBlock := int64(-1)
+	for {
+		if es.c.doFailureDelay(es.ctx, failCount) {
+			log.L(es.ctx).Debugf("Stream loop exiting")
+			return true
+		}
+
+		// The range is restarted from the checkpoints of the listeners, if they have changed
+		if es.buildReuseLeadGroupListener(&lastUpdate, &ag) {
+			nextBlock = -1
+		}
+
+		if len(ag.signatureSet) > 0 {
+			bh, _ := es.c.blockListener.getHighestBlock(es.ctx) /* note we know we're initialized here and will not block */
+			bh, err := es.finalizedHead(bh)
+			if err != nil {
+				log.L(es.ctx).Errorf("Failed to query the finalized block: %s", err)
+				failCount++
+				continue
+			}
+			hwmBlock := max(bh-es.c.checkpointBlockGap, 0)
+
+			if nextBlock < 0 {
+				for _, l := range ag.listeners {
+					if nextBlock < 0 || l.hwmBlock < nextBlock {
+						nextBlock = l.hwmBlock
+					}
+				}
+				if blockGapEstimate := bh - nextBlock; blockGapEstimate > es.c.catchupThreshold {
+					log.L(es.ctx).Warnf("Block gap estimate reached %d (above threshold of %d) - reverting to catchup mode", blockGapEstimate, es.c.catchupThreshold)
+					return false
+				}
+			}
+
+			if nextBlock <= bh {
+				events, err := es.getBlockRangeEvents(es.ctx, ag, nextBlock, bh)
+				if err != nil {
+					log.L(es.ctx).Errorf("Failed to query logs for blocks %d-%d: %s", nextBlock, bh, err)
+					failCount++
+					continue
+				}
+				if es.dispatchSetHWMCheckExit(ag, events, hwmBlock) {
+					log.L(es.ctx).Debugf("Stream loop exiting")
+					return true
+				}
+				nextBlock = bh + 1
+
+				es.mux.Lock()
+				es.headBlock = hwmBlock
+				es.mux.Unlock()
+				es.notifyLag(hwmBlock, bh)
+			}
+		}
+
+		failCount = 0
+		if es.waitFilterPollingInterval() {
+			return true
+		}
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func testTransferListener() *ffcapi.EventListenerAddRequest {
+	return &ffcapi.EventListenerAddRequest{
+		ListenerID: fftypes.NewUUID(),
+		EventListenerOptions: ffcapi.EventListenerOptions{
+			Filters: []fftypes.JSONAny{
+				*fftypes.JSONAnyPtr(`{"address":"0x20355f3E852D4b6a9944AdA8d5399dDD3409A431","event":` + abiTransferEvent + `}`),
+			},
+			Options:   fftypes.JSONAnyPtr(`{}`),
+			FromBlock: strconv.Itoa(testHighBlock),
+		},
+	}
+}
+
+func TestStreamLoopGetLogsMode(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t, func(conf config.Section) {
+		conf.Set(EventsPollingMode, EventPollingModeGetLogs)
+		conf.Set(EventsBlockTimestamps, false)
+	})
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_blockNumber").Return(nil).Run(func(args mock.Arguments) {
+		*args[1].(*ethtypes.HexInteger) = *ethtypes.NewHexInteger64(testHighBlock)
+	})
+	transferLog := sampleTransferLog()
+	transferLog.BlockNumber = ethtypes.NewHexInteger64(testHighBlock)
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getLogs", mock.MatchedBy(func(f *logFilterJSONRPC) bool {
+		// The query is restricted to the address of the only listener
+		return f.Address.String() == "0x20355f3e852d4b6a9944ada8d5399ddd3409a431" && f.ToBlock.BigInt().Int64() == testHighBlock
+	})).Return(nil).Run(func(args mock.Arguments) {
+		*args[1].(*[]*logJSONRPC) = []*logJSONRPC{transferLog}
+	}).Once()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getLogs", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		*args[1].(*[]*logJSONRPC) = []*logJSONRPC{}
+	}).Maybe()
+
+	_, events, _, done := testEventStreamExistingConnector(t, ctx, done, c, mRPC, testTransferListener())
+	defer done()
+
+	e := <-events
+	assert.Equal(t, uint64(testHighBlock), e.Event.ID.BlockNumber.Uint64())
+	mRPC.AssertNotCalled(t, "CallRPC", mock.Anything, mock.Anything, "eth_newFilter", mock.Anything)
+}
+
+func TestLeadGroupSteadyStateGetLogsErrors(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_blockNumber").Return(nil).Run(func(args mock.Arguments) {
+		*args[1].(*ethtypes.HexInteger) = *ethtypes.NewHexInteger64(testHighBlock)
+	})
+	c.retry.InitialDelay = time.Microsecond
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getLogs", mock.Anything).Return(&rpcbackend.RPCError{Message: "pop"}).Once()
+
+	ctx, cancel := context.WithCancel(ctx)
+	l := &listener{
+		id: fftypes.NewUUID(),
+		config: listenerConfig{
+			options: &listenerOptions{},
+			filters: []*eventFilter{{Topic0: ethtypes.MustNewHexBytes0xPrefix("0x01")}},
+		},
+	}
+	es := &eventStream{
+		id:             fftypes.NewUUID(),
+		c:              c,
+		ctx:            ctx,
+		headBlock:      -1,
+		listeners:      map[fftypes.UUID]*listener{*l.id: l},
+		streamLoopDone: make(chan struct{}),
+	}
+	l.es = es
+
+	// Listeners far behind the head of the chain go back to catchup mode
+	assert.False(t, es.leadGroupSteadyStateGetLogs())
+
+	// A failed query is retried until the stream stops
+	l.hwmBlock = testHighBlock
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getLogs", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		cancel()
+	}).Once()
+	assert.True(t, es.leadGroupSteadyStateGetLogs())
+}
+
+func TestStreamLoopFilterReinstalledImmediately(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	// A failure would delay the re-installation for longer than the test
+	c.retry.InitialDelay = time.Hour
+	reinstalledFilter := make(chan struct{})
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_blockNumber").Return(nil).Run(func(args mock.Arguments) {
+		*args[1].(*ethtypes.HexInteger) = *ethtypes.NewHexInteger64(testHighBlock)
+	})
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_newFilter", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		*args[1].(*string) = testLogsFilterID1
+	}).Once()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_newFilter", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		*args[1].(*string) = testLogsFilterID2
+		close(reinstalledFilter)
+	}).Once()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getFilterLogs", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		*args[1].(*[]*logJSONRPC) = []*logJSONRPC{}
+	}).Once()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getFilterChanges", mock.Anything).Return(&rpcbackend.RPCError{Message: "Filter with id: '0x1' does not exist."}).Once()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getFilterLogs", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		*args[1].(*[]*logJSONRPC) = []*logJSONRPC{}
+	}).Maybe()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getFilterChanges", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		*args[1].(*[]*logJSONRPC) = []*logJSONRPC{}
+	}).Maybe()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_uninstallFilter", mock.Anything).Return(nil).Maybe()
+
+	_, _, _, done = testEventStreamExistingConnector(t, ctx, done, c, mRPC, testTransferListener())
+	defer done()
+
+	select {
+	case <-reinstalledFilter:
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "filter was not re-installed")
+	}
+}
+
+func TestFilterNotFoundErrors(t *testing.T) {
+	for _, msg := range []string{"filter not found", "Filter not found", "Filter with id: '0x1' does not exist."} {
+		assert.Equal(t, ffcapi.ErrorReasonNotFound, mapError(filterRPCMethods, errors.New(msg)), msg)
+	}
+	assert.Empty(t, mapError(filterRPCMethods, errors.New("pop")))
+}
+
+func TestEventPollingModeConfig(t *testing.T) {
+	config.RootConfigReset()
+	conf := config.RootSection("unittest")
+	InitConfig(conf)
+	conf.Set(ffresty.HTTPConfigURL, "http://localhost:8545")
+	conf.Set(EventsPollingMode, "wrong")
+	_, err := NewEthereumConnector(context.Background(), conf)
+	assert.Regexp(t, "FF23172", err)
+}
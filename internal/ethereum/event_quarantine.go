@@ -0,0 +1,181 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/apitypes"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+)
+
+// eventFailureCacheSize bounds the number of logs we track failure counts for, before quarantine
+const eventFailureCacheSize = 1000
+
+// QuarantinedEvent is a log that repeatedly failed processing for a listener, and was skipped
+// so that the event stream could continue
+type QuarantinedEvent struct {
+	ID          *fftypes.UUID   `json:"id"`
+	StreamID    *fftypes.UUID   `json:"streamId"`
+	ListenerID  *fftypes.UUID   `json:"listenerId"`
+	Log         *logJSONRPC     `json:"log"`
+	Attempts    int             `json:"attempts"`
+	Error       string          `json:"error"`
+	Quarantined *fftypes.FFTime `json:"quarantined"`
+}
+
+func eventFailureKey(l *listener, ethLog *logJSONRPC) string {
+	return l.id.String() + "/" + ethLog.BlockHash.String() + "/" + ethLog.LogIndex.BigInt().String()
+}
+
+// quarantineOnFailure counts consecutive processing failures of a log for a listener. Once the configured
+// number of attempts is reached the log is quarantined, and an error event is returned to be dispatched
+// in its place. Returns nil if the failure should be retried, which is always the case when quarantine is disabled.
+func (es *eventStream) quarantineOnFailure(ctx context.Context, l *listener, f *eventFilter, ethLog *logJSONRPC, err error) *ffcapi.ListenerEvent {
+	c := es.c
+	if c.quarantineAttempts <= 0 {
+		return nil
+	}
+	key := eventFailureKey(l, ethLog)
+	attempts := 1
+	if previous, ok := c.eventFailures.Get(key); ok {
+		attempts += previous.(int)
+	}
+	if attempts < c.quarantineAttempts {
+		c.eventFailures.Add(key, attempts)
+		return nil
+	}
+	c.eventFailures.Remove(key)
+
+	qe := &QuarantinedEvent{
+		ID:          fftypes.NewUUID(),
+		StreamID:    es.id,
+		ListenerID:  l.id,
+		Log:         ethLog,
+		Attempts:    attempts,
+		Error:       err.Error(),
+		Quarantined: fftypes.Now(),
+	}
+	c.quarantineMux.Lock()
+	c.quarantine[*qe.ID] = qe
+	c.quarantineMux.Unlock()
+	log.L(ctx).Errorf("Quarantined event %s for listener %s after %d attempts (quarantine=%s): %s",
+		getEventProtoID(ethLog.BlockNumber.BigInt().Int64(), ethLog.TransactionIndex.BigInt().Int64(), ethLog.LogIndex.BigInt().Int64()), l.id, attempts, qe.ID, err)
+	c.notifier.notify(ctx, NotificationEventQuarantined, fftypes.JSONObject{
+		"id":          qe.ID.String(),
+		"streamId":    es.id.String(),
+		"listenerId":  l.id.String(),
+		"blockNumber": ethLog.BlockNumber.BigInt().Int64(),
+		"attempts":    attempts,
+		"error":       qe.Error,
+	}, "Event quarantined for listener %s after %d attempts", l.id, attempts)
+
+	return &ffcapi.ListenerEvent{
+		Checkpoint: &listenerCheckpoint{
+			Block:            ethLog.BlockNumber.BigInt().Int64(),
+			TransactionIndex: ethLog.TransactionIndex.BigInt().Int64(),
+			LogIndex:         ethLog.LogIndex.BigInt().Int64(),
+			PrivacyGroupID:   l.config.options.PrivacyGroupID,
+		},
+		Event: &ffcapi.Event{
+			ID: ffcapi.EventID{
+				ListenerID:       l.id,
+				Signature:        f.Signature,
+				BlockHash:        ethLog.BlockHash.String(),
+				TransactionHash:  ethLog.TransactionHash.String(),
+				BlockNumber:      fftypes.FFuint64(ethLog.BlockNumber.BigInt().Uint64()),
+				TransactionIndex: fftypes.FFuint64(ethLog.TransactionIndex.BigInt().Uint64()),
+				LogIndex:         fftypes.FFuint64(ethLog.LogIndex.BigInt().Uint64()),
+			},
+			Info: &eventInfo{
+				logJSONRPC:   *ethLog,
+				ChainID:      c.chainID,
+				QuarantineID: qe.ID,
+				Error:        qe.Error,
+				Sequence:     eventSequence(ethLog.BlockNumber.BigInt().Int64(), ethLog.TransactionIndex.BigInt().Int64(), ethLog.LogIndex.BigInt().Int64()),
+			},
+		},
+	}
+}
+
+// QuarantinedEvents lists the quarantined events, oldest first
+func (c *ethConnector) QuarantinedEvents() []*QuarantinedEvent {
+	c.quarantineMux.Lock()
+	defer c.quarantineMux.Unlock()
+	events := make([]*QuarantinedEvent, 0, len(c.quarantine))
+	for _, qe := range c.quarantine {
+		events = append(events, qe)
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Quarantined.Time().Before(*events[j].Quarantined.Time())
+	})
+	return events
+}
+
+// RetryQuarantinedEvent processes a quarantined event again for its listener. On success the event is
+// removed from the quarantine and returned to the caller, in the same format as event stream delivery,
+// as the checkpoint of the stream has already moved past it.
+func (c *ethConnector) RetryQuarantinedEvent(ctx context.Context, id *fftypes.UUID) (*apitypes.EventWithContext, error) {
+	c.quarantineMux.Lock()
+	qe := c.quarantine[*id]
+	c.quarantineMux.Unlock()
+	if qe == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgQuarantinedEventNotFound, id)
+	}
+
+	c.mux.Lock()
+	es := c.eventStreams[*qe.StreamID]
+	c.mux.Unlock()
+	if es == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgStreamNotStarted, qe.StreamID)
+	}
+	es.mux.Lock()
+	l := es.listeners[*qe.ListenerID]
+	es.mux.Unlock()
+	if l == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgListenerNotStarted, qe.ListenerID, qe.StreamID)
+	}
+
+	for _, f := range l.config.filters {
+		e, matched, _, err := l.ee.filterEnrichEthLog(ctx, f, l.config.options.Methods, qe.Log)
+		if err != nil {
+			return nil, err
+		}
+		if fe := l.config.options.filterExpression; matched && (fe == nil || fe.matches(e.Data)) {
+			c.quarantineMux.Lock()
+			delete(c.quarantine, *id)
+			c.quarantineMux.Unlock()
+			e.ID.ListenerID = l.id
+			log.L(ctx).Infof("Quarantined event %s processed successfully on retry", id)
+			return &apitypes.EventWithContext{
+				StandardContext: apitypes.EventContext{
+					StreamID:       es.id,
+					EthCompatSubID: l.id,
+					ListenerName:   l.config.name,
+					ListenerType:   apitypes.ListenerTypeEvents,
+				},
+				Event: e,
+			}, nil
+		}
+	}
+	return nil, i18n.NewError(ctx, msgs.MsgQuarantinedEventNoMatch, id, l.config.signature)
+}
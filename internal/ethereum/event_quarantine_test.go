@@ -0,0 +1,192 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestQuarantineDisabled(t *testing.T) {
+	l, _, cancel := newTestListener(t, false)
+	defer cancel()
+
+	lu := l.es.quarantineOnFailure(context.Background(), l, l.config.filters[0], sampleTransferLog(), fmt.Errorf("pop"))
+	assert.Nil(t, lu)
+	assert.Empty(t, l.c.QuarantinedEvents())
+}
+
+func TestQuarantineAfterAttempts(t *testing.T) {
+	l, mRPC, cancel := newTestListener(t, false)
+	defer cancel()
+	l.c.quarantineAttempts = 3
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByHash", mock.Anything, false).
+		Return(&rpcbackend.RPCError{Message: "pop"})
+
+	ag := l.es.buildAggregatedListener([]*listener{l})
+	for i := 0; i < 2; i++ {
+		_, err := l.es.filterEnrichSort(context.Background(), ag, []*logJSONRPC{sampleTransferLog()})
+		assert.Regexp(t, "pop", err)
+	}
+	events, err := l.es.filterEnrichSort(context.Background(), ag, []*logJSONRPC{sampleTransferLog()})
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, int64(1024), events[0].Checkpoint.(*listenerCheckpoint).Block)
+	assert.Equal(t, l.id, events[0].Event.ID.ListenerID)
+	info := events[0].Event.Info.(*eventInfo)
+	assert.Regexp(t, "pop", info.Error)
+	assert.Nil(t, events[0].Event.Data)
+
+	quarantined := l.c.QuarantinedEvents()
+	assert.Len(t, quarantined, 1)
+	assert.Equal(t, info.QuarantineID, quarantined[0].ID)
+	assert.Equal(t, 3, quarantined[0].Attempts)
+	assert.Equal(t, l.id, quarantined[0].ListenerID)
+
+	// The failure count is reset once quarantined
+	_, err = l.es.filterEnrichSort(context.Background(), ag, []*logJSONRPC{sampleTransferLog()})
+	assert.Regexp(t, "pop", err)
+}
+
+func TestQuarantinedEventsSorted(t *testing.T) {
+	_, c, _, done := newTestConnector(t)
+	defer done()
+
+	first, second := fftypes.NewUUID(), fftypes.NewUUID()
+	c.quarantine[*second] = &QuarantinedEvent{ID: second, Quarantined: fftypes.UnixTime(2000)}
+	c.quarantine[*first] = &QuarantinedEvent{ID: first, Quarantined: fftypes.UnixTime(1000)}
+
+	quarantined := c.QuarantinedEvents()
+	assert.Equal(t, first, quarantined[0].ID)
+	assert.Equal(t, second, quarantined[1].ID)
+}
+
+func addTestQuarantinedEvent(es *eventStream, l *listener, ethLog *logJSONRPC) *fftypes.UUID {
+	id := fftypes.NewUUID()
+	es.c.quarantine[*id] = &QuarantinedEvent{
+		ID:          id,
+		StreamID:    es.id,
+		ListenerID:  l.id,
+		Log:         ethLog,
+		Attempts:    3,
+		Error:       "pop",
+		Quarantined: fftypes.Now(),
+	}
+	return id
+}
+
+func TestRetryQuarantinedEventOK(t *testing.T) {
+	es, l, _, done := newTestReplayStream(t)
+	defer done()
+
+	id := addTestQuarantinedEvent(es, l, sampleTransferLog())
+	e, err := es.c.RetryQuarantinedEvent(es.ctx, id)
+	assert.NoError(t, err)
+	assert.Equal(t, l.id, e.Event.ID.ListenerID)
+	assert.Equal(t, es.id, e.StandardContext.StreamID)
+	assert.NotNil(t, e.Event.Data)
+	assert.Empty(t, es.c.QuarantinedEvents())
+}
+
+func TestRetryQuarantinedEventFail(t *testing.T) {
+	es, l, mRPC, done := newTestReplayStream(t)
+	defer done()
+	es.c.eventBlockTimestamps = false
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionByHash", mock.Anything).
+		Return(&rpcbackend.RPCError{Message: "pop"})
+	l.ee.extractSigner = true
+	id := addTestQuarantinedEvent(es, l, sampleTransferLog())
+
+	_, err := es.c.RetryQuarantinedEvent(es.ctx, id)
+	assert.Regexp(t, "pop", err)
+	assert.Len(t, es.c.QuarantinedEvents(), 1)
+}
+
+func TestRetryQuarantinedEventNoMatch(t *testing.T) {
+	es, l, _, done := newTestReplayStream(t)
+	defer done()
+
+	ethLog := sampleTransferLog()
+	ethLog.Address = ethtypes.MustNewAddress("0xc1552c7e527f8cb51bbca69c6849a192598fafe6")
+	id := addTestQuarantinedEvent(es, l, ethLog)
+
+	_, err := es.c.RetryQuarantinedEvent(es.ctx, id)
+	assert.Regexp(t, "FF23075", err)
+}
+
+func TestRetryQuarantinedEventNotFound(t *testing.T) {
+	es, l, _, done := newTestReplayStream(t)
+	defer done()
+
+	_, err := es.c.RetryQuarantinedEvent(es.ctx, fftypes.NewUUID())
+	assert.Regexp(t, "FF23074", err)
+
+	id := addTestQuarantinedEvent(es, l, sampleTransferLog())
+	es.c.quarantine[*id].ListenerID = fftypes.NewUUID()
+	_, err = es.c.RetryQuarantinedEvent(es.ctx, id)
+	assert.Regexp(t, "FF23043", err)
+
+	es.c.quarantine[*id].StreamID = fftypes.NewUUID()
+	_, err = es.c.RetryQuarantinedEvent(es.ctx, id)
+	assert.Regexp(t, "FF23041", err)
+}
+
+func TestQuarantineRoutes(t *testing.T) {
+	es, l, _, done := newTestReplayStream(t)
+	defer done()
+	url, close := newTestRouteServer(t, es.c)
+	defer close()
+
+	id := addTestQuarantinedEvent(es, l, sampleTransferLog())
+
+	res, err := http.Get(url + "/quarantine")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var quarantined []*QuarantinedEvent
+	err = json.NewDecoder(res.Body).Decode(&quarantined)
+	assert.NoError(t, err)
+	assert.Len(t, quarantined, 1)
+	assert.Equal(t, id, quarantined[0].ID)
+
+	res, err = http.Post(url+"/quarantine/wrong/retry", "application/json", bytes.NewReader([]byte(`{}`)))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	res, err = http.Post(url+"/quarantine/"+id.String()+"/retry", "application/json", bytes.NewReader([]byte(`{}`)))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var retried map[string]interface{}
+	err = json.NewDecoder(res.Body).Decode(&retried)
+	assert.NoError(t, err)
+	assert.Equal(t, l.id.String(), retried["listenerId"])
+
+	res, err = http.Post(url+"/quarantine/"+id.String()+"/retry", "application/json", bytes.NewReader([]byte(`{}`)))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+}
@@ -0,0 +1,101 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/apitypes"
+)
+
+type ReplayEventsRequest struct {
+	FromBlock *fftypes.FFBigInt `json:"fromBlock"`
+	ToBlock   *fftypes.FFBigInt `json:"toBlock,omitempty"`
+}
+
+// ReplayEventsResponse contains one page of replayed events, in the same format they are delivered on an
+// event stream. When the requested range is larger than the catchup page size, NextBlock is set to the
+// fromBlock to supply to fetch the next page.
+type ReplayEventsResponse struct {
+	FromBlock int64                        `json:"fromBlock"`
+	ToBlock   int64                        `json:"toBlock"`
+	NextBlock *int64                       `json:"nextBlock,omitempty"`
+	Events    []*apitypes.EventWithContext `json:"events"`
+}
+
+// ReplayEvents re-queries the historical events of a listener over a range of blocks, using a temporary copy
+// of the listener so that the checkpoint of the live listener is not affected. The events are returned
+// to the caller flagged as replays, rather than being dispatched on the event stream.
+func (c *ethConnector) ReplayEvents(ctx context.Context, streamID, listenerID *fftypes.UUID, req *ReplayEventsRequest) (*ReplayEventsResponse, error) {
+	es, l, err := c.getStreamListener(ctx, streamID, listenerID)
+	if err != nil {
+		return nil, err
+	}
+
+	chainHead, ok := c.blockListener.getHighestBlock(ctx)
+	if !ok {
+		return nil, i18n.NewError(ctx, msgs.MsgTimedOutQueryingChainHead)
+	}
+	toBlock := chainHead
+	if req.ToBlock != nil && req.ToBlock.Int64() < chainHead {
+		toBlock = req.ToBlock.Int64()
+	}
+	if req.FromBlock == nil || req.FromBlock.Int().Sign() < 0 || req.FromBlock.Int64() > toBlock {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidReplayRange, req.FromBlock, toBlock)
+	}
+	res := &ReplayEventsResponse{
+		FromBlock: req.FromBlock.Int64(),
+		ToBlock:   toBlock,
+		Events:    []*apitypes.EventWithContext{},
+	}
+	if pageEnd := res.FromBlock + c.catchupPageSize - 1; pageEnd < toBlock {
+		res.ToBlock = pageEnd
+		nextBlock := pageEnd + 1
+		res.NextBlock = &nextBlock
+	}
+
+	replay := &listener{
+		id:       l.id,
+		c:        c,
+		es:       es,
+		ee:       l.ee,
+		hwmBlock: res.FromBlock,
+		config:   l.config,
+	}
+	events, err := es.getBlockRangeEvents(ctx, es.buildAggregatedListener([]*listener{replay}), res.FromBlock, res.ToBlock)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range events {
+		e.Event.Info.(*eventInfo).Replay = true
+		res.Events = append(res.Events, &apitypes.EventWithContext{
+			StandardContext: apitypes.EventContext{
+				StreamID:       streamID,
+				EthCompatSubID: listenerID,
+				ListenerName:   l.config.name,
+				ListenerType:   apitypes.ListenerTypeEvents,
+			},
+			Event: e.Event,
+		})
+	}
+	log.L(ctx).Infof("Replayed events for listener %s fromBlock=%d toBlock=%d events=%d", listenerID, res.FromBlock, res.ToBlock, len(res.Events))
+	return res, nil
+}
@@ -0,0 +1,185 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-evmconnect/mocks/rpcbackendmocks"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestReplayStream(t *testing.T) (*eventStream, *listener, *rpcbackendmocks.Backend, func()) {
+	lID := fftypes.NewUUID()
+	es, _, mRPC, done := testEventStream(t, &ffcapi.EventListenerAddRequest{
+		ListenerID: lID,
+		EventListenerOptions: ffcapi.EventListenerOptions{
+			Filters: []fftypes.JSONAny{
+				*fftypes.JSONAnyPtr(`{"address":"0x20355f3E852D4b6a9944AdA8d5399dDD3409A431","event":` + abiTransferEvent + `}`),
+			},
+			Options:   fftypes.JSONAnyPtr(`{}`),
+			FromBlock: strconv.Itoa(testHighBlock),
+		},
+	})
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByHash", mock.Anything, false).Return(nil).Run(func(args mock.Arguments) {
+		*args[1].(**blockInfoJSONRPC) = &blockInfoJSONRPC{
+			Number:    ethtypes.NewHexInteger64(1024),
+			Timestamp: ethtypes.NewHexInteger64(1000000),
+		}
+	}).Maybe()
+	return es, es.listeners[*lID], mRPC, done
+}
+
+func TestReplayEventsOK(t *testing.T) {
+	es, l, mRPC, done := newTestReplayStream(t)
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getLogs", mock.MatchedBy(func(f *logFilterJSONRPC) bool {
+		return f.FromBlock.BigInt().Int64() == 1000 && f.ToBlock.BigInt().Int64() == 1100
+	})).Return(nil).Run(func(args mock.Arguments) {
+		*args[1].(*[]*logJSONRPC) = []*logJSONRPC{sampleTransferLog()}
+	}).Once()
+
+	res, err := es.c.ReplayEvents(es.ctx, es.id, l.id, &ReplayEventsRequest{
+		FromBlock: fftypes.NewFFBigInt(1000),
+		ToBlock:   fftypes.NewFFBigInt(1100),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1100), res.ToBlock)
+	assert.Nil(t, res.NextBlock)
+	assert.Len(t, res.Events, 1)
+	assert.Equal(t, l.id, res.Events[0].Event.ID.ListenerID)
+	assert.Equal(t, es.id, res.Events[0].StandardContext.StreamID)
+	assert.True(t, res.Events[0].Event.Info.(*eventInfo).Replay)
+	assert.JSONEq(t, `{"from":"0x3968ef051b422d3d1cdc182a88bba8dd922e6fa4","to":"0xd0f2f5103fd050739a9fb567251bc460cc24d091","value":"1000"}`, res.Events[0].Event.Data.String())
+
+	// The live checkpoint is unchanged
+	assert.Equal(t, int64(testHighBlock), l.getHWMCheckpoint().Block)
+}
+
+func TestReplayEventsPaged(t *testing.T) {
+	es, l, mRPC, done := newTestReplayStream(t)
+	defer done()
+	es.c.catchupPageSize = 10
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getLogs", mock.MatchedBy(func(f *logFilterJSONRPC) bool {
+		return f.FromBlock.BigInt().Int64() == 1000 && f.ToBlock.BigInt().Int64() == 1009
+	})).Return(nil).Run(func(args mock.Arguments) {
+		*args[1].(*[]*logJSONRPC) = []*logJSONRPC{}
+	}).Once()
+
+	res, err := es.c.ReplayEvents(es.ctx, es.id, l.id, &ReplayEventsRequest{
+		FromBlock: fftypes.NewFFBigInt(1000),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1009), res.ToBlock)
+	assert.Equal(t, int64(1010), *res.NextBlock)
+	assert.Empty(t, res.Events)
+}
+
+func TestReplayEventsQueryFail(t *testing.T) {
+	es, l, mRPC, done := newTestReplayStream(t)
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getLogs", mock.Anything).Return(&rpcbackend.RPCError{Message: "pop"})
+
+	_, err := es.c.ReplayEvents(es.ctx, es.id, l.id, &ReplayEventsRequest{
+		FromBlock: fftypes.NewFFBigInt(1000),
+	})
+	assert.Regexp(t, "pop", err)
+}
+
+func TestReplayEventsBadRange(t *testing.T) {
+	es, l, _, done := newTestReplayStream(t)
+	defer done()
+
+	_, err := es.c.ReplayEvents(es.ctx, es.id, l.id, &ReplayEventsRequest{})
+	assert.Regexp(t, "FF23073", err)
+
+	_, err = es.c.ReplayEvents(es.ctx, es.id, l.id, &ReplayEventsRequest{
+		FromBlock: fftypes.NewFFBigInt(-1),
+	})
+	assert.Regexp(t, "FF23073", err)
+
+	_, err = es.c.ReplayEvents(es.ctx, es.id, l.id, &ReplayEventsRequest{
+		FromBlock: fftypes.NewFFBigInt(1000),
+		ToBlock:   fftypes.NewFFBigInt(999),
+	})
+	assert.Regexp(t, "FF23073", err)
+}
+
+func TestReplayEventsNotFound(t *testing.T) {
+	es, _, _, done := newTestReplayStream(t)
+	defer done()
+
+	_, err := es.c.ReplayEvents(es.ctx, fftypes.NewUUID(), fftypes.NewUUID(), &ReplayEventsRequest{})
+	assert.Regexp(t, "FF23041", err)
+
+	_, err = es.c.ReplayEvents(es.ctx, es.id, fftypes.NewUUID(), &ReplayEventsRequest{})
+	assert.Regexp(t, "FF23043", err)
+}
+
+func TestPostReplayEventsRoute(t *testing.T) {
+	es, l, mRPC, done := newTestReplayStream(t)
+	defer done()
+	url, close := newTestRouteServer(t, es.c)
+	defer close()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getLogs", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		*args[1].(*[]*logJSONRPC) = []*logJSONRPC{sampleTransferLog()}
+	}).Once()
+
+	res, err := http.Post(url+"/eventstreams/"+es.id.String()+"/listeners/"+l.id.String()+"/replay", "application/json",
+		bytes.NewReader([]byte(`{"fromBlock":1000,"toBlock":1100}`)))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var replayed map[string]interface{}
+	err = json.NewDecoder(res.Body).Decode(&replayed)
+	assert.NoError(t, err)
+	events := replayed["events"].([]interface{})
+	assert.Len(t, events, 1)
+	assert.Equal(t, true, events[0].(map[string]interface{})["replay"])
+	assert.Equal(t, l.id.String(), events[0].(map[string]interface{})["listenerId"])
+}
+
+func TestPostReplayEventsRouteBadIDs(t *testing.T) {
+	es, _, _, done := newTestReplayStream(t)
+	defer done()
+	url, close := newTestRouteServer(t, es.c)
+	defer close()
+
+	res, err := http.Post(url+"/eventstreams/wrong/listeners/"+fftypes.NewUUID().String()+"/replay", "application/json", bytes.NewReader([]byte(`{}`)))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	res, err = http.Post(url+"/eventstreams/"+es.id.String()+"/listeners/wrong/replay", "application/json", bytes.NewReader([]byte(`{}`)))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	res, err = http.Post(url+"/eventstreams/"+fftypes.NewUUID().String()+"/listeners/"+fftypes.NewUUID().String()+"/replay", "application/json", bytes.NewReader([]byte(`{}`)))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+}
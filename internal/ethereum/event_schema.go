@@ -0,0 +1,198 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+)
+
+const jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// numberFormatPatterns are the patterns of the JSON strings of integers in each number format
+var numberFormatPatterns = map[string]string{
+	NumberFormatDecimal:    `^-?[0-9]+$`,
+	NumberFormatHex:        `^-?0x[0-9a-f]+$`,
+	NumberFormatScientific: `^-?[0-9](\.[0-9]+)?e\+[0-9]+$`,
+}
+
+// ListenerSchema is the JSON schema of the decoded data of the events of a listener, for each event it listens to
+type ListenerSchema struct {
+	ListenerID   *fftypes.UUID          `json:"listenerId"`
+	DataFormat   string                 `json:"dataFormat"`
+	NumberFormat string                 `json:"numberFormat"`
+	Events       []*ListenerEventSchema `json:"events"`
+}
+
+// ListenerEventSchema is the JSON schema of the decoded data of an event, or of one version of an event
+type ListenerEventSchema struct {
+	Signature string             `json:"signature"`
+	Version   string             `json:"version,omitempty"`
+	FromBlock *int64             `json:"fromBlock,omitempty"` // the first block of this version of the event, when the event has versions
+	Schema    fftypes.JSONObject `json:"schema"`
+}
+
+// eventSchemaBuilder builds the JSON schema of event data as it is serialized in a data format and number format
+type eventSchemaBuilder struct {
+	dataFormat    string
+	numberPattern string
+}
+
+// ListenerSchema returns the JSON schema of the data of the events of a listener, from the ABI of each of its events
+// in the data format of the connector and the number format of the listener
+func (c *ethConnector) ListenerSchema(ctx context.Context, streamID, listenerID *fftypes.UUID) (*ListenerSchema, error) {
+	_, l, err := c.getStreamListener(ctx, streamID, listenerID)
+	if err != nil {
+		return nil, err
+	}
+	numberFormat := l.config.options.NumberFormat
+	if numberFormat == "" {
+		numberFormat = c.numberFormat
+	}
+	sb := &eventSchemaBuilder{dataFormat: c.dataFormat, numberPattern: numberFormatPatterns[numberFormat]}
+
+	res := &ListenerSchema{ListenerID: l.id, DataFormat: c.dataFormat, NumberFormat: numberFormat, Events: []*ListenerEventSchema{}}
+	found := make(map[string]bool)
+	for _, f := range l.config.filters {
+		// Filters on different addresses share the schema of their event
+		key := f.Signature + "/" + f.Version
+		if found[key] {
+			continue
+		}
+		found[key] = true
+		schema, err := sb.eventSchema(ctx, f.Event)
+		if err != nil {
+			return nil, err
+		}
+		es := &ListenerEventSchema{Signature: f.Signature, Version: f.Version, Schema: schema}
+		if f.fromBlock > 0 {
+			fromBlock := f.fromBlock
+			es.FromBlock = &fromBlock
+		}
+		res.Events = append(res.Events, es)
+	}
+	return res, nil
+}
+
+func (sb *eventSchemaBuilder) eventSchema(ctx context.Context, event *abi.Entry) (fftypes.JSONObject, error) {
+	tc, err := event.Inputs.TypeComponentTreeCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	schema := sb.tupleSchema(tc.TupleChildren(), true)
+	schema["$schema"] = jsonSchemaDraft
+	schema["title"] = event.String()
+	return schema, nil
+}
+
+// componentSchema is the schema of a value, where topLevel is true for the parameters of the event, whose
+// topics only hold the hash of the value for indexed parameters that are not of a fixed size
+func (sb *eventSchemaBuilder) componentSchema(tc abi.TypeComponent, topLevel bool) fftypes.JSONObject {
+	if topLevel && tc.Parameter().Indexed && !(tc.ComponentType() == abi.ElementaryComponent && tc.ElementaryFixed()) {
+		return hexSchema(32, "the keccak256 hash of the indexed value")
+	}
+	switch tc.ComponentType() {
+	case abi.FixedArrayComponent:
+		return fftypes.JSONObject{"type": "array", "items": sb.componentSchema(tc.ArrayChild(), false), "minItems": tc.FixedArrayLen(), "maxItems": tc.FixedArrayLen()}
+	case abi.DynamicArrayComponent:
+		return fftypes.JSONObject{"type": "array", "items": sb.componentSchema(tc.ArrayChild(), false)}
+	case abi.TupleComponent:
+		return sb.tupleSchema(tc.TupleChildren(), false)
+	default:
+		return sb.elementarySchema(tc)
+	}
+}
+
+func (sb *eventSchemaBuilder) elementarySchema(tc abi.TypeComponent) fftypes.JSONObject {
+	switch tc.ElementaryType().BaseType() {
+	case abi.BaseTypeInt, abi.BaseTypeUInt:
+		return fftypes.JSONObject{"type": "string", "pattern": sb.numberPattern}
+	case abi.BaseTypeBool:
+		return fftypes.JSONObject{"type": "boolean"}
+	case abi.BaseTypeAddress:
+		return hexSchema(20, "")
+	case abi.BaseTypeFunction:
+		return hexSchema(24, "")
+	case abi.BaseTypeBytes:
+		if tc.ElementaryFixed() {
+			return hexSchema(int(tc.ElementaryM()), "")
+		}
+		return fftypes.JSONObject{"type": "string", "pattern": `^0x([0-9a-f]{2})*$`}
+	default:
+		// strings, and the decimal strings of fixed point numbers
+		return fftypes.JSONObject{"type": "string"}
+	}
+}
+
+// tupleSchema is the schema of the components of a tuple, or the parameters of the event, in the data format
+func (sb *eventSchemaBuilder) tupleSchema(children []abi.TypeComponent, topLevel bool) fftypes.JSONObject {
+	switch sb.dataFormat {
+	case "flat_array":
+		items := make([]interface{}, len(children))
+		for i, child := range children {
+			items[i] = sb.componentSchema(child, topLevel)
+		}
+		return fftypes.JSONObject{"type": "array", "prefixItems": items, "minItems": len(children), "maxItems": len(children)}
+	case "self_describing":
+		items := make([]interface{}, len(children))
+		for i, child := range children {
+			typeName := child.String()
+			if topLevel && child.Parameter().Indexed && !(child.ComponentType() == abi.ElementaryComponent && child.ElementaryFixed()) {
+				typeName = "bytes"
+			}
+			items[i] = fftypes.JSONObject{
+				"type": "object",
+				"properties": fftypes.JSONObject{
+					"name":  fftypes.JSONObject{"const": componentName(child, i)},
+					"type":  fftypes.JSONObject{"const": typeName},
+					"value": sb.componentSchema(child, topLevel),
+				},
+				"required":             []string{"name", "type", "value"},
+				"additionalProperties": false,
+			}
+		}
+		return fftypes.JSONObject{"type": "array", "prefixItems": items, "minItems": len(children), "maxItems": len(children)}
+	default:
+		properties := fftypes.JSONObject{}
+		required := make([]string, len(children))
+		for i, child := range children {
+			name := componentName(child, i)
+			properties[name] = sb.componentSchema(child, topLevel)
+			required[i] = name
+		}
+		return fftypes.JSONObject{"type": "object", "properties": properties, "required": required, "additionalProperties": false}
+	}
+}
+
+func componentName(tc abi.TypeComponent, idx int) string {
+	if name := tc.KeyName(); name != "" {
+		return name
+	}
+	return defaultOutputName(idx)
+}
+
+// hexSchema is the schema of 0x prefixed hex of a number of bytes
+func hexSchema(bytes int, description string) fftypes.JSONObject {
+	schema := fftypes.JSONObject{"type": "string", "pattern": fmt.Sprintf("^0x[0-9a-f]{%d}$", bytes*2)}
+	if description != "" {
+		schema["description"] = description
+	}
+	return schema
+}
@@ -0,0 +1,229 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+	"github.com/stretchr/testify/assert"
+)
+
+const abiDealEvent = `{
+	"type": "event",
+	"name": "Deal",
+	"inputs": [
+		{"name": "ref", "type": "string", "indexed": true},
+		{"name": "buyer", "type": "address", "indexed": true},
+		{"name": "items", "type": "tuple[2]", "components": [
+			{"name": "id", "type": "uint256"},
+			{"name": "tag", "type": "bytes32"},
+			{"name": "", "type": "bool"}
+		]},
+		{"name": "deltas", "type": "int8[]"},
+		{"name": "payload", "type": "bytes"},
+		{"name": "callback", "type": "function"},
+		{"name": "", "type": "string"},
+		{"name": "", "type": "uint256"}
+	]
+}`
+
+// validateSchema checks a decoded value against the subset of JSON schema generated for events
+func validateSchema(t *testing.T, path string, schema map[string]interface{}, v interface{}) {
+	if c, ok := schema["const"]; ok {
+		assert.Equal(t, c, v, path)
+		return
+	}
+	switch schema["type"] {
+	case "object":
+		obj, ok := v.(map[string]interface{})
+		if !assert.True(t, ok, path) {
+			return
+		}
+		properties := schema["properties"].(map[string]interface{})
+		for _, name := range schema["required"].([]interface{}) {
+			assert.Contains(t, obj, name, path)
+		}
+		for name, fv := range obj {
+			if assert.Contains(t, properties, name, path) {
+				validateSchema(t, path+"."+name, properties[name].(map[string]interface{}), fv)
+			}
+		}
+	case "array":
+		arr, ok := v.([]interface{})
+		if !assert.True(t, ok, path) {
+			return
+		}
+		if minItems, ok := schema["minItems"]; ok {
+			assert.GreaterOrEqual(t, float64(len(arr)), minItems, path)
+			assert.LessOrEqual(t, float64(len(arr)), schema["maxItems"], path)
+		}
+		for i, iv := range arr {
+			itemSchema, ok := schema["items"].(map[string]interface{})
+			if !ok {
+				itemSchema = schema["prefixItems"].([]interface{})[i].(map[string]interface{})
+			}
+			validateSchema(t, fmt.Sprintf("%s[%d]", path, i), itemSchema, iv)
+		}
+	case "boolean":
+		assert.IsType(t, true, v, path)
+	default:
+		s, ok := v.(string)
+		assert.True(t, ok, path)
+		if pattern, ok := schema["pattern"].(string); ok {
+			assert.Regexp(t, regexp.MustCompile(pattern), s, path)
+		}
+	}
+}
+
+func dealEventLog(t *testing.T) (*abi.Entry, []ethtypes.HexBytes0xPrefix, ethtypes.HexBytes0xPrefix) {
+	var event abi.Entry
+	err := json.Unmarshal([]byte(abiDealEvent), &event)
+	assert.NoError(t, err)
+	var data abi.ParameterArray
+	for _, p := range event.Inputs {
+		if !p.Indexed {
+			data = append(data, p)
+		}
+	}
+	encoded, err := data.EncodeABIDataValues([]interface{}{
+		[]interface{}{
+			[]interface{}{"1000000", "0x" + fmt.Sprintf("%064x", 1), true},
+			[]interface{}{"-0", "0x" + fmt.Sprintf("%064x", 2), false},
+		},
+		[]interface{}{-128, 5, 0},
+		"0xfeedbeef",
+		"0x" + fmt.Sprintf("%048x", 3),
+		"hello",
+		"12345678901234567890",
+	})
+	assert.NoError(t, err)
+	topic0, err := event.SignatureHash()
+	assert.NoError(t, err)
+	topics := []ethtypes.HexBytes0xPrefix{
+		topic0,
+		keccak256([]byte("ref-1")),
+		ethtypes.MustNewHexBytes0xPrefix("0x000000000000000000000000b480f96c0a3d6e9e9a263e4665a39bfa6c4d01e8"),
+	}
+	return &event, topics, encoded
+}
+
+func TestListenerSchemaMatchesDecodedEvents(t *testing.T) {
+	for _, dataFormat := range []string{"map", "flat_array", "self_describing"} {
+		for _, numberFormat := range numberFormats {
+			ctx, c, mRPC, cDone := newTestConnector(t, func(conf config.Section) {
+				conf.Set(ConfigDataFormat, dataFormat)
+			})
+			mockStreamLoopEmpty(mRPC)
+			lID := fftypes.NewUUID()
+			es, _, _, done := testEventStreamExistingConnector(t, ctx, cDone, c, mRPC, &ffcapi.EventListenerAddRequest{
+				ListenerID: lID,
+				EventListenerOptions: ffcapi.EventListenerOptions{
+					Filters: []fftypes.JSONAny{*fftypes.JSONAnyPtr(`{"event":` + abiDealEvent + `}`)},
+					Options: fftypes.JSONAnyPtr(`{"numberFormat":"` + numberFormat + `"}`),
+				},
+			})
+
+			res, err := c.ListenerSchema(ctx, es.id, lID)
+			assert.NoError(t, err)
+			assert.Equal(t, dataFormat, res.DataFormat)
+			assert.Equal(t, numberFormat, res.NumberFormat)
+			assert.Len(t, res.Events, 1)
+			schema := res.Events[0].Schema
+			assert.Equal(t, jsonSchemaDraft, schema["$schema"])
+			assert.Equal(t, "Deal(string,address,(uint256,bytes32,bool)[2],int8[],bytes,function,string,uint256)", res.Events[0].Signature)
+
+			event, topics, data := dealEventLog(t)
+			decoded, ok := es.listeners[*lID].ee.decodeLogData(ctx, event, topics, data)
+			assert.True(t, ok)
+			var schemaMap, value map[string]interface{}
+			var values interface{}
+			b, _ := json.Marshal(schema)
+			assert.NoError(t, json.Unmarshal(b, &schemaMap))
+			assert.NoError(t, decoded.Unmarshal(ctx, &values))
+			if value, ok = values.(map[string]interface{}); ok {
+				assert.Contains(t, value, "output6")
+				assert.Contains(t, value, "output7")
+			}
+			validateSchema(t, dataFormat+"/"+numberFormat, schemaMap, values)
+
+			done()
+		}
+	}
+}
+
+func TestListenerSchemaVersionsAndRoute(t *testing.T) {
+	lID := fftypes.NewUUID()
+	transferV2 := `{"type":"event","name":"Transfer","inputs":[{"name":"from","type":"address","indexed":true},{"name":"to","type":"address","indexed":true},{"name":"value","type":"uint256","indexed":true}]}`
+	es, _, _, done := testEventStream(t, &ffcapi.EventListenerAddRequest{
+		ListenerID: lID,
+		EventListenerOptions: ffcapi.EventListenerOptions{
+			Filters: []fftypes.JSONAny{
+				*fftypes.JSONAnyPtr(`{"address":"0x20355f3E852D4b6a9944AdA8d5399dDD3409A431","event":` + abiTransferEvent + `,"versions":[{"event":` + transferV2 + `,"fromBlock":1000,"version":"v2"}]}`),
+				*fftypes.JSONAnyPtr(`{"address":"0x30355f3E852D4b6a9944AdA8d5399dDD3409A431","event":` + abiTransferEvent + `}`),
+			},
+			Options: fftypes.JSONAnyPtr(`{}`),
+		},
+	})
+	defer done()
+	url, close := newTestRouteServer(t, es.c)
+	defer close()
+
+	res, err := http.Get(url + "/eventstreams/" + es.id.String() + "/listeners/" + lID.String() + "/schema")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var ls ListenerSchema
+	err = json.NewDecoder(res.Body).Decode(&ls)
+	assert.NoError(t, err)
+	assert.Equal(t, lID, ls.ListenerID)
+	assert.Equal(t, "map", ls.DataFormat)
+	assert.Equal(t, NumberFormatDecimal, ls.NumberFormat)
+	// The second filter shares the schema of the first version of the event
+	assert.Len(t, ls.Events, 2)
+	assert.Empty(t, ls.Events[0].Version)
+	assert.Nil(t, ls.Events[0].FromBlock)
+	assert.Equal(t, "v2", ls.Events[1].Version)
+	assert.Equal(t, int64(1000), *ls.Events[1].FromBlock)
+	assert.Equal(t, fftypes.JSONObject{"type": "string", "pattern": numberFormatPatterns[NumberFormatDecimal]},
+		ls.Events[0].Schema.GetObject("properties").GetObject("value"))
+	assert.Equal(t, fftypes.JSONObject{"type": "string", "pattern": "^0x[0-9a-f]{40}$"},
+		ls.Events[1].Schema.GetObject("properties").GetObject("from"))
+
+	for _, path := range []string{
+		"/eventstreams/wrong/listeners/" + lID.String() + "/schema",
+		"/eventstreams/" + es.id.String() + "/listeners/wrong/schema",
+	} {
+		res, err := http.Get(url + path)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	}
+
+	_, err = es.c.ListenerSchema(es.ctx, es.id, fftypes.NewUUID())
+	assert.Regexp(t, "FF23043", err)
+
+	es.listeners[*lID].config.filters = []*eventFilter{{Event: &abi.Entry{Type: abi.Event, Name: "Bad", Inputs: abi.ParameterArray{{Type: "wrong"}}}}}
+	_, err = es.c.ListenerSchema(es.ctx, es.id, lID)
+	assert.Regexp(t, "FF22025", err)
+}
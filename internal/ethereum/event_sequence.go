@@ -0,0 +1,89 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"math/big"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+)
+
+// deliveredBlocksCacheSize is the number of recent blocks per listener, from which events have been
+// delivered, that are remembered to detect redelivery of the same events
+const deliveredBlocksCacheSize = 100
+
+var (
+	sequenceTxFactor    = big.NewInt(1_000_000)
+	sequenceBlockFactor = big.NewInt(1_000_000_000_000)
+)
+
+// eventSequence is the sequence number of an event, which is the block number, transaction index and
+// log index combined into a single integer with the same digits as the protocol ID. So for example
+// event 000000001024/000005/000001 has sequence 1024000005000001.
+func eventSequence(blockNumber, transactionIndex, logIndex int64) *fftypes.FFBigInt {
+	seq := new(big.Int).Mul(big.NewInt(blockNumber), sequenceBlockFactor)
+	seq.Add(seq, new(big.Int).Mul(big.NewInt(transactionIndex), sequenceTxFactor))
+	seq.Add(seq, big.NewInt(logIndex))
+	return (*fftypes.FFBigInt)(seq)
+}
+
+// checkSequence enforces that the events of a listener are delivered in strictly increasing sequence,
+// across the catchup and live phases of the listener. An event at or before the last delivered sequence
+// is dropped as a duplicate if it is from a block that events have already been delivered from.
+// Otherwise it is from a block that has replaced one on the canonical chain, and is delivered.
+func (l *listener) checkSequence(ctx context.Context, event *ffcapi.ListenerEvent) (deliver bool) {
+	if event.Event == nil {
+		return true
+	}
+	info, ok := event.Event.Info.(*eventInfo)
+	if !ok || info.Sequence == nil {
+		return true
+	}
+	seq := info.Sequence.Int()
+	blockHash := event.Event.ID.BlockHash
+
+	l.seqMux.Lock()
+	defer l.seqMux.Unlock()
+	if l.deliveredBlocks == nil {
+		l.deliveredBlocks, _ = lru.New(deliveredBlocksCacheSize)
+	}
+	if l.lastSequence != nil && seq.Cmp(l.lastSequence) <= 0 {
+		if l.deliveredBlocks.Contains(blockHash) {
+			log.L(ctx).Warnf("Dropping duplicate event %s for listener %s (sequence=%s lastSequence=%s)", event.Event, l.id, seq, l.lastSequence)
+			return false
+		}
+		log.L(ctx).Infof("Delivering event %s for listener %s from replacement block %s (sequence=%s lastSequence=%s)", event.Event, l.id, blockHash, seq, l.lastSequence)
+	}
+	l.lastSequence = seq
+	l.deliveredBlocks.Add(blockHash, true)
+	return true
+}
+
+// inSequence checks the sequence of an event from the lead group of a stream, against its listener
+func inSequence(ctx context.Context, listeners map[fftypes.UUID]*listener, event *ffcapi.ListenerEvent) bool {
+	if event.Event == nil || event.Event.ID.ListenerID == nil {
+		return true
+	}
+	if l := listeners[*event.Event.ID.ListenerID]; l != nil {
+		return l.checkSequence(ctx, event)
+	}
+	return true
+}
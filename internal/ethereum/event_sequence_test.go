@@ -0,0 +1,85 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+	"github.com/stretchr/testify/assert"
+)
+
+func testSequencedEvent(lID *fftypes.UUID, blockHash string, blockNumber, transactionIndex, logIndex int64) *ffcapi.ListenerEvent {
+	return &ffcapi.ListenerEvent{
+		Event: &ffcapi.Event{
+			ID: ffcapi.EventID{
+				ListenerID: lID,
+				BlockHash:  blockHash,
+			},
+			Info: &eventInfo{
+				Sequence: eventSequence(blockNumber, transactionIndex, logIndex),
+			},
+		},
+	}
+}
+
+func TestEventSequence(t *testing.T) {
+	assert.Equal(t, "1024000005000001", eventSequence(1024, 5, 1).String())
+	assert.Equal(t, "21212100000000000000", eventSequence(21212100, 0, 0).String())
+	assert.Equal(t, -1, eventSequence(1024, 5, 1).Int().Cmp(eventSequence(1024, 6, 0).Int()))
+	assert.Equal(t, -1, eventSequence(1024, 999999, 999999).Int().Cmp(eventSequence(1025, 0, 0).Int()))
+}
+
+func TestCheckSequence(t *testing.T) {
+	ctx := context.Background()
+	lID := fftypes.NewUUID()
+	l := &listener{id: lID}
+	blockA := testBlockHash(0xaa)
+	blockB := testBlockHash(0xbb)
+
+	assert.True(t, l.checkSequence(ctx, testSequencedEvent(lID, blockA, 100, 0, 0)))
+	assert.True(t, l.checkSequence(ctx, testSequencedEvent(lID, blockA, 100, 0, 1)))
+
+	// Redelivery from the same block is dropped
+	assert.False(t, l.checkSequence(ctx, testSequencedEvent(lID, blockA, 100, 0, 1)))
+	assert.False(t, l.checkSequence(ctx, testSequencedEvent(lID, blockA, 100, 0, 0)))
+
+	// Events from a block that replaced one we delivered from are delivered
+	assert.True(t, l.checkSequence(ctx, testSequencedEvent(lID, blockB, 100, 0, 0)))
+	assert.True(t, l.checkSequence(ctx, testSequencedEvent(lID, blockB, 100, 0, 1)))
+	assert.False(t, l.checkSequence(ctx, testSequencedEvent(lID, blockB, 100, 0, 1)))
+
+	// Events without sequence information are always delivered
+	assert.True(t, l.checkSequence(ctx, &ffcapi.ListenerEvent{}))
+	assert.True(t, l.checkSequence(ctx, &ffcapi.ListenerEvent{Event: &ffcapi.Event{}}))
+}
+
+func TestInSequence(t *testing.T) {
+	ctx := context.Background()
+	lID := fftypes.NewUUID()
+	listeners := map[fftypes.UUID]*listener{
+		*lID: {id: lID},
+	}
+
+	assert.True(t, inSequence(ctx, listeners, testSequencedEvent(lID, testBlockHash(1), 100, 0, 0)))
+	assert.False(t, inSequence(ctx, listeners, testSequencedEvent(lID, testBlockHash(1), 100, 0, 0)))
+	// Unknown listeners, and events without a listener, are not checked
+	assert.True(t, inSequence(ctx, listeners, testSequencedEvent(fftypes.NewUUID(), testBlockHash(1), 100, 0, 0)))
+	assert.True(t, inSequence(ctx, listeners, &ffcapi.ListenerEvent{}))
+}
@@ -23,6 +23,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
@@ -31,24 +32,52 @@ import (
 	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
 	"github.com/hyperledger/firefly-signer/pkg/abi"
 	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
 	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
 )
 
 // eventFilter is our Ethereum specific filter options - an array of these can be configured on each listener
 type eventFilter struct {
-	Event     *abi.Entry                `json:"event"`             // The ABI spec of the event to listen to
-	Address   *ethtypes.Address0xHex    `json:"address,omitempty"` // An optional address to restrict the
-	Topic0    ethtypes.HexBytes0xPrefix `json:"topic0"`            // Topic 0 match
-	Signature string                    `json:"signature"`         // The cached signature of this event
+	Event     *abi.Entry                    `json:"event"`              // The ABI spec of the event to listen to
+	Address   *ethtypes.Address0xHex        `json:"address,omitempty"`  // An optional address to restrict the
+	Topic0    ethtypes.HexBytes0xPrefix     `json:"topic0"`             // Topic 0 match
+	Signature string                        `json:"signature"`          // The cached signature of this event
+	Preset    string                        `json:"preset,omitempty"`   // An optional preset, such as an event of the ERC-4337 EntryPoint, in place of the event
+	Version   string                        `json:"version,omitempty"`  // An optional label for the version of the event, when there are later versions
+	Versions  []*eventVersion               `json:"versions,omitempty"` // Optional later versions of the event, each decoding the events from its fromBlock
+	Indexed   map[string]*fftypes.JSONAny   `json:"indexed,omitempty"`  // Optional values of indexed parameters, by name, that the events must have, each a value or an array of alternatives
+	fromBlock int64                         // The first block of this version of the event
+	toBlock   int64                         // The block of the next version of the event, or zero for the latest version
+	topics    [][]ethtypes.HexBytes0xPrefix // The topics after topic 0 encoded from the indexed values, with nil for any topic
 }
 
 // eventInfo is the top-level structure we pass to applications for each event (through the FFCAPI framework)
 type eventInfo struct {
 	logJSONRPC
-	InputMethod string                 `json:"inputMethod,omitempty"` // the method invoked, if it matched one of the signatures in the listener definition
-	InputArgs   *fftypes.JSONAny       `json:"inputArgs,omitempty"`   // the method parameters, if the method matched one of the signatures in the listener definition
-	InputSigner *ethtypes.Address0xHex `json:"inputSigner,omitempty"` // the signing `from` address of the transaction
-	ChainID     string                 `json:"chainId,omitempty"`     // an identifier for the chain this event relates to
+	InputMethod    string                 `json:"inputMethod,omitempty"`    // the method invoked, if it matched one of the signatures in the listener definition
+	InputArgs      *fftypes.JSONAny       `json:"inputArgs,omitempty"`      // the method parameters, if the method matched one of the signatures in the listener definition
+	InputSigner    *ethtypes.Address0xHex `json:"inputSigner,omitempty"`    // the signing `from` address of the transaction
+	ChainID        string                 `json:"chainId,omitempty"`        // an identifier for the chain this event relates to
+	Implementation *ethtypes.Address0xHex `json:"implementation,omitempty"` // the implementation contract, if the event was emitted by a proxy and proxy resolution is enabled
+	Replay         bool                   `json:"replay,omitempty"`         // true if the event was redelivered by a replay request, rather than the live event stream
+	QuarantineID   *fftypes.UUID          `json:"quarantineId,omitempty"`   // set on the error event delivered in place of an event that was quarantined after repeated failures
+	Error          string                 `json:"error,omitempty"`          // the processing error that caused the event to be quarantined
+	PrivacyGroupID string                 `json:"privacyGroupId,omitempty"` // the Besu privacy group the event was emitted in, for private events
+	Bridge         *BridgeMessage         `json:"bridge,omitempty"`         // the message of a native L1<->L2 bridge event, if bridge annotation is enabled on the listener
+	EventVersion   string                 `json:"eventVersion,omitempty"`   // the label of the version of the event it was decoded with, for listeners with versions of an event
+	L1Inclusion    *L1Inclusion           `json:"l1Inclusion,omitempty"`    // the status of the inclusion of the block of the event in the state submitted to L1, if L1 inclusion tracking is enabled
+	Sequence       *fftypes.FFBigInt      `json:"sequence"`                 // strictly increasing for the events delivered for each listener, combining the block number, transaction index and log index
+
+	strictNumbers bool // every number is serialized as a string, for streams with strict JSON numbers
+}
+
+func (ei *eventInfo) MarshalJSON() ([]byte, error) {
+	type eventInfoJSON eventInfo
+	b, err := json.Marshal((*eventInfoJSON)(ei))
+	if err != nil || !ei.strictNumbers {
+		return b, err
+	}
+	return strictJSONNumbers(b), nil
 }
 
 // eventStream is the state we hold in memory for each eventStream
@@ -61,8 +90,11 @@ type eventStream struct {
 	updateCount    int
 	listeners      map[fftypes.UUID]*listener
 	headBlock      int64
+	preStartDone   chan struct{} // closed once the initial listeners are started by the stream loop
 	streamLoopDone chan struct{}
 	catchup        bool
+	lagNotified    bool
+	settings       atomic.Pointer[deliverySettings] // nil until the delivery settings are updated through the API
 }
 
 // aggregatedListener is a generated structure that allows use to query/filter logs efficiently across a large number of listeners,
@@ -79,25 +111,41 @@ func parseEventFilters(ctx context.Context, filters []fftypes.JSONAny) (string,
 	if len(filters) < 1 {
 		return "", nil, i18n.NewError(ctx, msgs.MsgMissingEventFilter)
 	}
-	ethFilters := make([]*eventFilter, len(filters))
+	ethFilters := make([]*eventFilter, 0, len(filters))
 	sigStrings := make([]string, len(filters))
 	for i, f := range filters {
-		err := json.Unmarshal(f.Bytes(), &ethFilters[i])
+		var ethFilter *eventFilter
+		err := json.Unmarshal(f.Bytes(), &ethFilter)
 		if err != nil {
 			return "", nil, i18n.NewError(ctx, msgs.MsgInvalidEventFilter, f.Bytes())
 		}
-		if ethFilters[i].Event == nil {
+		if ethFilter.Event == nil && ethFilter.Preset != "" {
+			if err := resolveListenerPreset(ctx, ethFilter); err != nil {
+				return "", nil, err
+			}
+		}
+		if ethFilter.Event == nil {
 			return "", nil, i18n.NewError(ctx, msgs.MsgMissingEventFilter)
 		}
-		ethFilters[i].Topic0, err = ethFilters[i].Event.SignatureHashCtx(ctx)
-		ethFilters[i].Signature = ethFilters[i].Event.String()
+		ethFilter.Topic0, err = ethFilter.Event.SignatureHashCtx(ctx)
+		ethFilter.Signature = ethFilter.Event.String()
 		if err != nil {
 			return "", nil, i18n.NewError(ctx, msgs.MsgInvalidEventFilter, err)
 		}
-		if ethFilters[i].Address != nil {
-			sigStrings[i] = ethFilters[i].Address.String() + ":" + ethFilters[i].Event.String()
+		versions, err := expandEventVersions(ctx, ethFilter)
+		if err != nil {
+			return "", nil, err
+		}
+		for _, v := range versions {
+			if v.topics, err = encodeIndexedTopics(ctx, v.Event, ethFilter.Indexed); err != nil {
+				return "", nil, err
+			}
+		}
+		ethFilters = append(ethFilters, versions...)
+		if ethFilter.Address != nil {
+			sigStrings[i] = ethFilter.Address.String() + ":" + versionedSignature(versions) + indexedSignature(ethFilter.Indexed)
 		} else {
-			sigStrings[i] = "*:" + ethFilters[i].Event.String()
+			sigStrings[i] = "*:" + versionedSignature(versions) + indexedSignature(ethFilter.Indexed)
 		}
 	}
 	var signature string
@@ -110,6 +158,16 @@ func parseEventFilters(ctx context.Context, filters []fftypes.JSONAny) (string,
 }
 
 func (es *eventStream) addEventListener(ctx context.Context, req *ffcapi.EventListenerAddRequest) (*listener, error) {
+	var checkpoint *listenerCheckpoint
+	if req.Checkpoint != nil {
+		checkpoint = req.Checkpoint.(*listenerCheckpoint)
+	}
+	// Looked up before taking the lock, as the checkpoint store might be remote
+	checkpoint, err := es.c.storedCheckpoint(ctx, req.ListenerID, checkpoint)
+	if err != nil {
+		return nil, err
+	}
+
 	es.mux.Lock()
 	defer es.mux.Unlock()
 	_, ok := es.listeners[*req.ListenerID]
@@ -117,11 +175,6 @@ func (es *eventStream) addEventListener(ctx context.Context, req *ffcapi.EventLi
 		return nil, i18n.NewError(ctx, msgs.MsgListenerAlreadyStarted, req.ListenerID)
 	}
 
-	var checkpoint *listenerCheckpoint
-	if req.Checkpoint != nil {
-		checkpoint = req.Checkpoint.(*listenerCheckpoint)
-	}
-
 	signature, filters, err := parseEventFilters(ctx, req.Filters)
 	if err != nil || req.Options == nil {
 		// Should not happen as we've previously been called with EventListenerVerifyOptions
@@ -147,11 +200,26 @@ func (es *eventStream) addEventListener(ctx context.Context, req *ffcapi.EventLi
 		},
 	}
 	l.ee = &eventEnricher{
-		connector:     l.c,
-		extractSigner: l.config.options.Signer,
+		connector:                l.c,
+		extractSigner:            l.config.options.Signer,
+		privacyGroupID:           l.config.options.PrivacyGroupID,
+		bridges:                  l.config.options.Bridges,
+		bridgeCounterpartChainID: l.config.options.BridgeChainID,
+		strictNumbers:            l.c.strictNumbersForStream(es.id),
+	}
+	if l.ee.serializer, err = l.c.serializerFor(ctx, options.NumberFormat); err != nil {
+		return nil, err
+	}
+	if checkpoint != nil && checkpoint.PrivacyGroupID != options.PrivacyGroupID {
+		log.L(ctx).Warnf("Ignoring checkpoint %+v of listener '%s' as it is not for privacy group '%s'", checkpoint, l.id, options.PrivacyGroupID)
+		checkpoint = nil
 	}
 	if checkpoint != nil {
 		l.hwmBlock = checkpoint.Block
+		if checkpoint.TransactionIndex >= 0 {
+			// The checkpoint is that of the last event processed in the block, rather than the start of the block
+			l.resumeAfter = checkpoint
+		}
 	}
 	if err := l.ensureHWM(ctx); err != nil {
 		return nil, err
@@ -165,8 +233,10 @@ func (es *eventStream) addEventListener(ctx context.Context, req *ffcapi.EventLi
 }
 
 func (es *eventStream) startEventListener(l *listener) {
+	es.mux.Lock()
+	defer es.mux.Unlock()
 	readyForLead, removed := l.checkReadyForLeadPackOrRemoved(es.ctx)
-	l.catchup = !readyForLead
+	l.catchup = !readyForLead || l.private()
 	if l.catchup && !removed {
 		l.catchupLoopDone = make(chan struct{})
 		go l.listenerCatchupLoop()
@@ -202,7 +272,7 @@ func (es *eventStream) buildReuseLeadGroupListener(lastUpdate *int, ag **aggrega
 	if *lastUpdate != es.updateCount {
 		listeners := make([]*listener, 0, len(es.listeners))
 		for _, l := range es.listeners {
-			if !l.catchup {
+			if !l.catchup && !l.paused {
 				listeners = append(listeners, l)
 			}
 		}
@@ -213,14 +283,20 @@ func (es *eventStream) buildReuseLeadGroupListener(lastUpdate *int, ag **aggrega
 	return listenerChanged
 }
 
+func (es *eventStream) setCatchup(catchup bool) {
+	es.mux.Lock()
+	defer es.mux.Unlock()
+	es.catchup = catchup
+}
+
 // leadGroupCatchup is called whenever the steam loop restarts, to see how far it is behind the head of the
 // chain and if it's a way behind then we catch up all this head group as one set (rather than with individual
 // catchup routines as is the case if one listener starts a way behind the pack)
 func (es *eventStream) leadGroupCatchup() bool {
 
 	// For API status, we keep a track of whether we're in catchup mode or not
-	es.catchup = true
-	defer func() { es.catchup = false }()
+	es.setCatchup(true)
+	defer es.setCatchup(false)
 
 	var ag *aggregatedListener
 	lastUpdate := -1
@@ -236,6 +312,12 @@ func (es *eventStream) leadGroupCatchup() bool {
 			log.L(es.ctx).Debugf("Stream catchup exiting (closed checking block height)")
 			return true
 		}
+		chainHeadBlock, err := es.finalizedHead(chainHeadBlock)
+		if err != nil {
+			log.L(es.ctx).Errorf("Failed to query the finalized block: %s", err)
+			failCount++
+			continue
+		}
 
 		// Build the aggregated listener list (doesn't matter if it's changed, as we build the list each time)
 		_ = es.buildReuseLeadGroupListener(&lastUpdate, &ag)
@@ -253,6 +335,8 @@ func (es *eventStream) leadGroupCatchup() bool {
 			}
 		}
 
+		es.notifyLag(fromBlock, chainHeadBlock)
+
 		// Check if we're ready to exit catchup mode
 		headGap := (chainHeadBlock - fromBlock)
 		if headGap < es.c.catchupThreshold {
@@ -260,9 +344,9 @@ func (es *eventStream) leadGroupCatchup() bool {
 			return false
 		}
 
-		// Poll in the range for events
+		// Poll in the range for events, giving way to transaction submission and confirmation
 		toBlock := fromBlock + es.c.catchupPageSize - 1
-		events, err := es.getBlockRangeEvents(es.ctx, ag, fromBlock, toBlock)
+		events, err := es.getBlockRangeEvents(withRPCPriority(es.ctx, rpcPriorityBulk), ag, fromBlock, toBlock)
 		if err != nil {
 			log.L(es.ctx).Errorf("Failed to query block range fromBlock=%d toBlock=%d headBlock=%d: %s", fromBlock, toBlock, chainHeadBlock, err)
 			failCount++
@@ -282,6 +366,25 @@ func (es *eventStream) leadGroupCatchup() bool {
 
 }
 
+// notifyLag raises a notification when the lead group of the stream falls too far behind the head of
+// the chain, and again once it is back within the threshold
+func (es *eventStream) notifyLag(fromBlock, chainHeadBlock int64) {
+	n := es.c.notifier
+	if n == nil || n.streamLagBlocks <= 0 {
+		return
+	}
+	lag := chainHeadBlock - fromBlock
+	details := fftypes.JSONObject{"streamId": es.id.String(), "lag": lag, "fromBlock": fromBlock, "headBlock": chainHeadBlock}
+	switch {
+	case lag > n.streamLagBlocks && !es.lagNotified:
+		es.lagNotified = true
+		n.notify(es.ctx, NotificationStreamLag, details, "Event stream %s is %d blocks behind the head of the chain", es.id, lag)
+	case lag <= n.streamLagBlocks && es.lagNotified:
+		es.lagNotified = false
+		n.notify(es.ctx, NotificationStreamLagRecovered, details, "Event stream %s has caught up to within %d blocks of the head of the chain", es.id, lag)
+	}
+}
+
 func (es *eventStream) uninstallFilter(filter *string) {
 	if *filter != "" {
 		var res bool
@@ -304,6 +407,9 @@ func (es *eventStream) leadGroupSteadyState() bool {
 	failCount := 0
 	filterResetRequired := false
 	filterRPCMethodToUse := ""
+	polledBlock := int64(-1)
+	reinstalled := false
+	var lastPoll time.Time
 	for {
 		if es.c.doFailureDelay(es.ctx, failCount) {
 			log.L(es.ctx).Debugf("Stream loop exiting")
@@ -363,20 +469,51 @@ func (es *eventStream) leadGroupSteadyState() bool {
 				}
 				log.L(es.ctx).Infof("Filter '%v' established", filter)
 			}
+			// Skip the poll if there has been no change to the filter, and none of the new blocks can contain our events
+			if filterRPCMethodToUse == "eth_getFilterChanges" && es.canSkipFilterPoll(es.ctx, ag, polledBlock, bh, lastPoll) {
+				failCount = 0
+				if es.waitFilterPollingInterval() {
+					return true
+				}
+				continue
+			}
+
 			// Get the next batch of logs
 			var ethLogs []*logJSONRPC
+			pollStart := time.Now()
 			rpcErr := es.c.backend.CallRPC(es.ctx, &ethLogs, filterRPCMethodToUse, filter)
+			es.c.latencySLOs.observe(LatencySLOLogPoll, pollStart)
 			// If we fail to query we just retry - setting filter to nil if not found
 			if rpcErr != nil {
 				if mapError(filterRPCMethods, rpcErr.Error()) == ffcapi.ErrorReasonNotFound {
 					log.L(es.ctx).Infof("Filter '%v' reset: %s", filter, rpcErr.Message)
 					filter = ""
+					// Nodes expire idle filters, and lose them on restart or behind a load balancer, so the
+					// filter is re-installed from the checkpoints straight away, unless it was only just installed
+					if !reinstalled {
+						reinstalled = true
+						continue
+					}
 				}
 				log.L(es.ctx).Errorf("Failed to query filter (%s): %s", filterRPCMethodToUse, rpcErr.Message)
 				failCount++
 				continue
 			}
+			reinstalled = false
+			// The range of blocks covered by a filter is not known, so only the receipts of the logs are verified
+			err := es.verifyLogs(es.ctx, ag, ethLogs, -1, -1)
+			if err == nil {
+				err = es.crossVerifyLogs(es.ctx, ag, ethLogs)
+			}
+			if err != nil {
+				log.L(es.ctx).Errorf("Failed to verify logs: %s", err)
+				// As with a failure to enrich, the filter is reset so the logs are returned again
+				filterResetRequired = true
+				failCount++
+				continue
+			}
 			filterRPCMethodToUse = "eth_getFilterChanges" // subsequent JSON/RPC calls after the initial fetch, this fetches only the new logs
+			polledBlock, lastPoll = bh, time.Now()
 			// Enrich the events
 			events, enrichErr := es.filterEnrichSort(es.ctx, ag, ethLogs)
 			if enrichErr != nil {
@@ -397,21 +534,29 @@ func (es *eventStream) leadGroupSteadyState() bool {
 			es.mux.Lock()
 			es.headBlock = hwmBlock
 			es.mux.Unlock()
+			es.notifyLag(hwmBlock, bh)
 		}
 
 		// Reset failure count if we reach here
 		failCount = 0
 
 		// Sleep for the polling interval
-		select {
-		case <-time.After(es.c.eventFilterPollingInterval):
-		case <-es.ctx.Done():
-			log.L(es.ctx).Debugf("Stream loop stopping")
+		if es.waitFilterPollingInterval() {
 			return true
 		}
 	}
 }
 
+func (es *eventStream) waitFilterPollingInterval() (exiting bool) {
+	select {
+	case <-time.After(es.c.eventFilterPollingInterval):
+		return false
+	case <-es.ctx.Done():
+		log.L(es.ctx).Debugf("Stream loop stopping")
+		return true
+	}
+}
+
 func (es *eventStream) preStartProcessing() {
 	ctx := es.ctx
 	chainHead, ok := es.c.blockListener.getHighestBlock(ctx)
@@ -440,6 +585,7 @@ func (es *eventStream) streamLoop() {
 	defer close(es.streamLoopDone)
 
 	es.preStartProcessing()
+	close(es.preStartDone)
 
 	for {
 		// When we first start, we might find our leading pack of listeners are all way behind
@@ -451,7 +597,12 @@ func (es *eventStream) streamLoop() {
 
 		// We then transition to our steady state, filtering from the front of the chain.
 		// But we might fall behind and need to go back to the catchup mode.
-		if es.leadGroupSteadyState() {
+		// Only the finalized blocks are polled when finality is enabled, which cannot be done with a filter
+		steadyState := es.leadGroupSteadyState
+		if es.c.eventPollingMode == EventPollingModeGetLogs || es.c.finality != nil {
+			steadyState = es.leadGroupSteadyStateGetLogs
+		}
+		if steadyState() {
 			return
 		}
 	}
@@ -468,13 +619,25 @@ func (es *eventStream) dispatchSetHWMCheckExit(ag *aggregatedListener, events ff
 		default:
 		}
 	} else {
-		for _, event := range events {
+		listeners := make(map[fftypes.UUID]*listener, len(ag.listeners))
+		for _, l := range ag.listeners {
+			listeners[*l.id] = l
+		}
+		for i, event := range events {
 			log.L(es.ctx).Debugf("Detected event %s", event.Event)
-			select {
-			case es.events <- event:
-			case <-es.ctx.Done():
+			if !inSequence(es.ctx, listeners, event) {
+				continue
+			}
+			if !es.publishToSinks(event) {
 				return true
 			}
+			if err := es.deliverEvent(event); err != nil {
+				// Unless the stream is stopping, the rest of the batch goes to the dead letter store
+				if es.ctx.Err() != nil || !es.deadLetter(listeners, events[i:], err) {
+					return true
+				}
+				break
+			}
 		}
 	}
 
@@ -499,7 +662,10 @@ func (es *eventStream) buildAggregatedListener(listeners []*listener) *aggregate
 			if !existing {
 				ag.signatureSet = append(ag.signatureSet, f.Topic0)
 			}
-			ag.listenersByTopic0[sigStr] = append(topicListeners, l)
+			// Versions of an event can share a signature, but the listener only needs checking once for each
+			if len(topicListeners) == 0 || topicListeners[len(topicListeners)-1] != l {
+				ag.listenersByTopic0[sigStr] = append(topicListeners, l)
+			}
 		}
 	}
 	return ag
@@ -517,7 +683,10 @@ func (es *eventStream) filterEnrichSort(ctx context.Context, ag *aggregatedListe
 			for _, f := range l.config.filters {
 				lu, matches, err := l.filterEnrichEthLog(ctx, f, l.config.options.Methods, ethLog)
 				if err != nil {
-					return nil, err
+					if lu = es.quarantineOnFailure(ctx, l, f, ethLog, err); lu == nil {
+						return nil, err
+					}
+					matches = true
 				}
 				if matches {
 					updates = append(updates, lu)
@@ -540,11 +709,31 @@ func (es *eventStream) getBlockRangeEvents(ctx context.Context, ag *aggregatedLi
 		},
 	}
 
-	if len(ag.listeners) == 1 && len(ag.listeners[0].config.filters) == 1 {
-		logFilterJSONRPCReq.Address = ag.listeners[0].config.filters[0].Address
+	if len(ag.listeners) == 1 {
+		logFilterJSONRPCReq.Address = ag.listeners[0].filterAddress()
+		logFilterJSONRPCReq.Topics = append(logFilterJSONRPCReq.Topics, ag.listeners[0].filterTopics()...)
 	}
 
-	rpcErr := es.c.backend.CallRPC(ctx, &ethLogs, "eth_getLogs", logFilterJSONRPCReq)
+	var rpcErr *rpcbackend.RPCError
+	pollStart := time.Now()
+	if len(ag.listeners) == 1 && ag.listeners[0].private() {
+		// Private logs are only available from a node that is a member of the privacy group
+		rpcErr = es.c.backend.CallRPC(ctx, &ethLogs, "priv_getLogs", ag.listeners[0].config.options.PrivacyGroupID, logFilterJSONRPCReq)
+		es.c.latencySLOs.observe(LatencySLOLogPoll, pollStart)
+	} else {
+		// The checkpoint of the listeners moves past the range once it is queried, so this must not go to
+		// a read endpoint that might not yet have the blocks, and return no logs for them
+		rpcErr = es.c.backend.CallRPC(ctx, &ethLogs, "eth_getLogs", logFilterJSONRPCReq)
+		es.c.latencySLOs.observe(LatencySLOLogPoll, pollStart)
+		if rpcErr == nil {
+			if err := es.verifyLogs(ctx, ag, ethLogs, fromBlock, toBlock); err != nil {
+				return nil, err
+			}
+			if err := es.crossVerifyLogs(ctx, ag, ethLogs); err != nil {
+				return nil, err
+			}
+		}
+	}
 	if rpcErr != nil {
 		return nil, rpcErr.Error()
 	}
@@ -554,12 +743,17 @@ func (es *eventStream) getBlockRangeEvents(ctx context.Context, ag *aggregatedLi
 func (es *eventStream) getListenerHWM(ctx context.Context, listenerID *fftypes.UUID) (*ffcapi.EventListenerHWMResponse, ffcapi.ErrorReason, error) {
 	es.mux.Lock()
 	l := es.listeners[*listenerID]
+	catchup := l != nil && (l.catchup || es.catchup) // whether the listener is in catchup, or the head group of the stream is in catchup
 	es.mux.Unlock()
 	if l == nil {
 		return nil, ffcapi.ErrorReasonNotFound, i18n.NewError(ctx, msgs.MsgListenerNotStarted, listenerID, es.id)
 	}
+	checkpoint := l.getHWMCheckpoint()
+	// The transaction manager only asks for the checkpoints of idle listeners, which is the only point we know
+	// nothing is waiting to be acknowledged, so the store is not written as events are delivered
+	l.storeCheckpoint(ctx, checkpoint)
 	return &ffcapi.EventListenerHWMResponse{
-		Checkpoint: l.getHWMCheckpoint(),
-		Catchup:    l.catchup || es.catchup, // dirty read of whether the listener is in catchup, or the head group of the stream is in catchup
+		Checkpoint: checkpoint,
+		Catchup:    catchup,
 	}, "", nil
 }
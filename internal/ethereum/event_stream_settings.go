@@ -0,0 +1,168 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-common/pkg/retry"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+)
+
+const (
+	// ErrorHandlingBlock waits for as long as it takes the transaction manager to accept each event
+	ErrorHandlingBlock = "block"
+	// ErrorHandlingDeadLetter persists events the transaction manager repeatedly fails to accept to the dead letter store
+	ErrorHandlingDeadLetter = "deadLetter"
+)
+
+var errorHandlingModes = []string{ErrorHandlingBlock, ErrorHandlingDeadLetter}
+
+// EventStreamSettings are the settings for the delivery of the events of a running stream to the transaction
+// manager. On an update, the fields that are not set keep their current value.
+type EventStreamSettings struct {
+	ErrorHandling   string                    `json:"errorHandling,omitempty"`
+	DeliveryTimeout *fftypes.FFDuration       `json:"deliveryTimeout,omitempty"`
+	MaxAttempts     *int                      `json:"maxAttempts,omitempty"`
+	Retry           *EventStreamRetrySettings `json:"retry,omitempty"`
+}
+
+type EventStreamRetrySettings struct {
+	InitialDelay *fftypes.FFDuration `json:"initialDelay,omitempty"`
+	MaxDelay     *fftypes.FFDuration `json:"maxDelay,omitempty"`
+	Factor       *float64            `json:"factor,omitempty"`
+}
+
+// deliverySettings are the settings used by a stream, which are replaced as a whole on an update
+type deliverySettings struct {
+	deadLetter bool
+	timeout    time.Duration
+	attempts   int
+	retry      *retry.Retry
+}
+
+// defaultDeliverySettings are the settings of a stream until they are updated, from the events.deadLetter config
+func (c *ethConnector) defaultDeliverySettings() *deliverySettings {
+	return &deliverySettings{
+		deadLetter: c.deadLetterDir != "",
+		timeout:    c.deadLetterTimeout,
+		attempts:   c.deadLetterAttempts,
+		retry:      c.deadLetterRetry,
+	}
+}
+
+func (es *eventStream) deliverySettings() *deliverySettings {
+	if ds := es.settings.Load(); ds != nil {
+		return ds
+	}
+	return es.c.defaultDeliverySettings()
+}
+
+func (ds *deliverySettings) apply(ctx context.Context, c *ethConnector, update *EventStreamSettings) (*deliverySettings, error) {
+	updated := *ds
+	r := *ds.retry
+	updated.retry = &r
+	switch update.ErrorHandling {
+	case "":
+	case ErrorHandlingBlock:
+		updated.deadLetter = false
+	case ErrorHandlingDeadLetter:
+		if c.deadLetterDir == "" {
+			return nil, i18n.NewError(ctx, msgs.MsgDeadLetterNotEnabled, update.ErrorHandling)
+		}
+		updated.deadLetter = true
+	default:
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidErrorHandling, update.ErrorHandling, errorHandlingModes)
+	}
+	if update.DeliveryTimeout != nil {
+		updated.timeout = time.Duration(*update.DeliveryTimeout)
+	}
+	if update.MaxAttempts != nil {
+		updated.attempts = *update.MaxAttempts
+	}
+	if update.Retry != nil {
+		if update.Retry.InitialDelay != nil {
+			r.InitialDelay = time.Duration(*update.Retry.InitialDelay)
+		}
+		if update.Retry.MaxDelay != nil {
+			r.MaximumDelay = time.Duration(*update.Retry.MaxDelay)
+		}
+		if update.Retry.Factor != nil {
+			r.Factor = *update.Retry.Factor
+		}
+	}
+	if updated.timeout <= 0 || updated.attempts < 1 || r.InitialDelay < 0 || r.MaximumDelay < 0 || r.Factor < 1 {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidDeliverySettings)
+	}
+	return &updated, nil
+}
+
+func (ds *deliverySettings) external() *EventStreamSettings {
+	errorHandling := ErrorHandlingBlock
+	if ds.deadLetter {
+		errorHandling = ErrorHandlingDeadLetter
+	}
+	timeout := fftypes.FFDuration(ds.timeout)
+	attempts := ds.attempts
+	initialDelay := fftypes.FFDuration(ds.retry.InitialDelay)
+	maxDelay := fftypes.FFDuration(ds.retry.MaximumDelay)
+	factor := ds.retry.Factor
+	return &EventStreamSettings{
+		ErrorHandling:   errorHandling,
+		DeliveryTimeout: &timeout,
+		MaxAttempts:     &attempts,
+		Retry: &EventStreamRetrySettings{
+			InitialDelay: &initialDelay,
+			MaxDelay:     &maxDelay,
+			Factor:       &factor,
+		},
+	}
+}
+
+// EventStreamSettings returns the delivery settings currently used by a running stream
+func (c *ethConnector) EventStreamSettings(ctx context.Context, streamID *fftypes.UUID) (*EventStreamSettings, error) {
+	es, err := c.getEventStream(ctx, streamID)
+	if err != nil {
+		return nil, err
+	}
+	return es.deliverySettings().external(), nil
+}
+
+// UpdateEventStreamSettings changes the delivery settings of a running stream, without restarting it. The new
+// settings are validated as a whole, and apply from the next event delivered. They last until the stream is
+// stopped, after which it starts again with the settings from the config.
+func (c *ethConnector) UpdateEventStreamSettings(ctx context.Context, streamID *fftypes.UUID, update *EventStreamSettings) (*EventStreamSettings, error) {
+	es, err := c.getEventStream(ctx, streamID)
+	if err != nil {
+		return nil, err
+	}
+	// Holding the stream lock serializes concurrent updates, so none are lost
+	es.mux.Lock()
+	defer es.mux.Unlock()
+	updated, err := es.deliverySettings().apply(ctx, c, update)
+	if err != nil {
+		return nil, err
+	}
+	es.settings.Store(updated)
+	settings := updated.external()
+	log.L(ctx).Infof("Updated delivery settings of event stream %s: errorHandling=%s deliveryTimeout=%s maxAttempts=%d", streamID, settings.ErrorHandling, settings.DeliveryTimeout, *settings.MaxAttempts)
+	return settings, nil
+}
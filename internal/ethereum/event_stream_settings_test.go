@@ -0,0 +1,199 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestEventStreamSettingsDefaults(t *testing.T) {
+	es, _, _, done := testEventStream(t)
+	defer done()
+
+	settings, err := es.c.EventStreamSettings(es.ctx, es.id)
+	assert.NoError(t, err)
+	assert.Equal(t, ErrorHandlingBlock, settings.ErrorHandling)
+	assert.Equal(t, "30s", settings.DeliveryTimeout.String())
+	assert.Equal(t, 3, *settings.MaxAttempts)
+	assert.Equal(t, "1s", settings.Retry.InitialDelay.String())
+	assert.Equal(t, 2.0, *settings.Retry.Factor)
+
+	dlES, _, _, dlDone := newTestDeadLetterStream(t)
+	defer dlDone()
+	settings, err = dlES.c.EventStreamSettings(dlES.ctx, dlES.id)
+	assert.NoError(t, err)
+	assert.Equal(t, ErrorHandlingDeadLetter, settings.ErrorHandling)
+	assert.Equal(t, "1ms", settings.DeliveryTimeout.String())
+
+	_, err = es.c.EventStreamSettings(es.ctx, fftypes.NewUUID())
+	assert.Regexp(t, "FF23041", err)
+}
+
+func TestUpdateEventStreamSettings(t *testing.T) {
+	es, l, events, done := newTestDeadLetterStream(t)
+	defer done()
+
+	// Streams can stop dead lettering, until the settings are changed back
+	settings, err := es.c.UpdateEventStreamSettings(es.ctx, es.id, &EventStreamSettings{ErrorHandling: ErrorHandlingBlock})
+	assert.NoError(t, err)
+	assert.Equal(t, ErrorHandlingBlock, settings.ErrorHandling)
+	assert.Equal(t, 3, *settings.MaxAttempts)
+	assert.False(t, es.deliverySettings().deadLetter)
+
+	attempts := 1
+	factor := 1.5
+	delay := fftypes.FFDuration(2 * time.Millisecond)
+	settings, err = es.c.UpdateEventStreamSettings(es.ctx, es.id, &EventStreamSettings{
+		ErrorHandling: ErrorHandlingDeadLetter,
+		MaxAttempts:   &attempts,
+		Retry: &EventStreamRetrySettings{
+			InitialDelay: &delay,
+			MaxDelay:     &delay,
+			Factor:       &factor,
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, ErrorHandlingDeadLetter, settings.ErrorHandling)
+	assert.Equal(t, "1ms", settings.DeliveryTimeout.String())
+	assert.Equal(t, "2ms", settings.Retry.MaxDelay.String())
+	assert.Equal(t, 1.5, *settings.Retry.Factor)
+
+	// The retry policy of the connector is not changed by an update to the stream
+	assert.Equal(t, 2.0, es.c.deadLetterRetry.Factor)
+
+	batch := deadLetterTestBatch(t, es, l, events)
+	assert.Equal(t, 1, batch.Attempts)
+
+	// A stream started again returns to the config settings
+	es.settings.Store(nil)
+	assert.Equal(t, 3, es.deliverySettings().attempts)
+}
+
+func TestUpdateEventStreamSettingsErrors(t *testing.T) {
+	es, _, _, done := testEventStream(t)
+	defer done()
+
+	_, err := es.c.UpdateEventStreamSettings(es.ctx, fftypes.NewUUID(), &EventStreamSettings{})
+	assert.Regexp(t, "FF23041", err)
+
+	_, err = es.c.UpdateEventStreamSettings(es.ctx, es.id, &EventStreamSettings{ErrorHandling: "wrong"})
+	assert.Regexp(t, "FF23210", err)
+
+	_, err = es.c.UpdateEventStreamSettings(es.ctx, es.id, &EventStreamSettings{ErrorHandling: ErrorHandlingDeadLetter})
+	assert.Regexp(t, "FF23211", err)
+
+	zero := fftypes.FFDuration(0)
+	_, err = es.c.UpdateEventStreamSettings(es.ctx, es.id, &EventStreamSettings{DeliveryTimeout: &zero})
+	assert.Regexp(t, "FF23212", err)
+
+	attempts := 0
+	_, err = es.c.UpdateEventStreamSettings(es.ctx, es.id, &EventStreamSettings{MaxAttempts: &attempts})
+	assert.Regexp(t, "FF23212", err)
+
+	factor := 0.5
+	_, err = es.c.UpdateEventStreamSettings(es.ctx, es.id, &EventStreamSettings{Retry: &EventStreamRetrySettings{Factor: &factor}})
+	assert.Regexp(t, "FF23212", err)
+
+	// Nothing is applied from an invalid update
+	assert.Nil(t, es.settings.Load())
+}
+
+func TestListenerCatchupDeadLetter(t *testing.T) {
+	l, mRPC, cancelCtx := newTestListener(t, false)
+	l.c.deadLetterDir = t.TempDir()
+	l.es.settings.Store(&deliverySettings{
+		deadLetter: true,
+		timeout:    time.Millisecond,
+		attempts:   1,
+		retry:      l.c.retry,
+	})
+
+	mockNodeInfoUnsupported(mRPC)
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "net_version", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		l.ee.connector.chainID = "12345"
+	}).Once()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByHash", mock.Anything, false).Return(nil).Run(func(args mock.Arguments) {
+		*args[1].(**blockInfoJSONRPC) = &blockInfoJSONRPC{
+			Number: ethtypes.NewHexInteger64(1024),
+		}
+	})
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getLogs", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		*args[1].(*[]*logJSONRPC) = []*logJSONRPC{sampleTransferLog()}
+	}).Once()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getLogs", mock.Anything).Return(&rpcbackend.RPCError{Message: "pop"}).Run(func(args mock.Arguments) {
+		cancelCtx()
+	})
+
+	l.listenerCatchupLoop()
+
+	batches := l.c.DeadLetters()
+	assert.Len(t, batches, 1)
+	assert.Len(t, batches[0].Events, 1)
+	assert.Equal(t, int64(1024), batches[0].Events[0].Checkpoint.Block)
+	assert.Equal(t, l.id, batches[0].Events[0].ID.ListenerID)
+}
+
+func TestEventStreamSettingsRoutes(t *testing.T) {
+	es, _, _, done := newTestDeadLetterStream(t)
+	defer done()
+	url, close := newTestRouteServer(t, es.c)
+	defer close()
+
+	res, err := http.Get(url + "/eventstreams/" + es.id.String() + "/settings")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var settings EventStreamSettings
+	err = json.NewDecoder(res.Body).Decode(&settings)
+	assert.NoError(t, err)
+	assert.Equal(t, ErrorHandlingDeadLetter, settings.ErrorHandling)
+
+	req, _ := http.NewRequest(http.MethodPatch, url+"/eventstreams/"+es.id.String()+"/settings", bytes.NewReader([]byte(`{"deliveryTimeout":"5s","retry":{"maxDelay":"10s"}}`)))
+	req.Header.Set("Content-Type", "application/json")
+	res, err = http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	settings = EventStreamSettings{}
+	err = json.NewDecoder(res.Body).Decode(&settings)
+	assert.NoError(t, err)
+	assert.Equal(t, "5s", settings.DeliveryTimeout.String())
+	assert.Equal(t, "10s", settings.Retry.MaxDelay.String())
+	assert.Equal(t, "1ms", settings.Retry.InitialDelay.String())
+
+	req, _ = http.NewRequest(http.MethodPatch, url+"/eventstreams/"+es.id.String()+"/settings", bytes.NewReader([]byte(`{"errorHandling":"wrong"}`)))
+	req.Header.Set("Content-Type", "application/json")
+	res, err = http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	for _, method := range []string{http.MethodGet, http.MethodPatch} {
+		req, _ = http.NewRequest(method, url+"/eventstreams/wrong/settings", bytes.NewReader([]byte(`{}`)))
+		req.Header.Set("Content-Type", "application/json")
+		res, err = http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	}
+}
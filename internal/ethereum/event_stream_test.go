@@ -40,6 +40,10 @@ func testEventStream(t *testing.T, listeners ...*ffcapi.EventListenerAddRequest)
 func testEventStreamExistingConnector(t *testing.T, ctx context.Context, done func(), c *ethConnector, mRPC *rpcbackendmocks.Backend, listeners ...*ffcapi.EventListenerAddRequest) (*eventStream, chan *ffcapi.ListenerEvent, *rpcbackendmocks.Backend, func()) {
 	events := make(chan *ffcapi.ListenerEvent)
 	esID := fftypes.NewUUID()
+	// Set before the stream starts, as the stream loop reads them
+	c.eventFilterPollingInterval = 1 * time.Millisecond
+	c.retry.MaximumDelay = 1 * time.Microsecond
+	c.chainID = "12345"
 	_, _, err := c.EventStreamStart(ctx, &ffcapi.EventStreamStartRequest{
 		ID:               esID,
 		StreamContext:    ctx,
@@ -49,12 +53,8 @@ func testEventStreamExistingConnector(t *testing.T, ctx context.Context, done fu
 	})
 	assert.NoError(t, err)
 	es := c.eventStreams[*esID]
-	es.c.eventFilterPollingInterval = 1 * time.Millisecond
-	es.c.retry.MaximumDelay = 1 * time.Microsecond
-	c.chainID = "12345"
 	assert.NotNil(t, es)
-
-	es.preStartProcessing()
+	<-es.preStartDone
 
 	return es, events, mRPC, func() {
 		done()
@@ -188,9 +188,14 @@ func TestStartHeadBlockLimitedByChainHead(t *testing.T) {
 		},
 	}
 
-	es, _, _, done := testEventStream(t, l1req)
+	ctx, c, mRPC, done := newTestConnector(t)
 	defer done()
+	mockStreamLoopEmpty(mRPC)
+	es := &eventStream{id: fftypes.NewUUID(), ctx: ctx, c: c, headBlock: -1, listeners: make(map[fftypes.UUID]*listener)}
+	_, err := es.addEventListener(ctx, l1req)
+	assert.NoError(t, err)
 
+	es.preStartProcessing()
 	assert.Equal(t, int64(testHighBlock), es.headBlock)
 }
 
@@ -264,8 +269,10 @@ func TestCatchupThenRejoinLeadGroup(t *testing.T) {
 
 	_, _, err := es.c.EventListenerAdd(es.ctx, l2req)
 	assert.NoError(t, err)
+	es.mux.Lock()
 	l := es.listeners[*l2req.ListenerID]
 	assert.True(t, l.catchup)
+	es.mux.Unlock()
 
 	e := <-events
 	assert.Equal(t, fftypes.FFuint64(1024), e.Event.ID.BlockNumber)
@@ -284,7 +291,10 @@ func TestCatchupThenRejoinLeadGroup(t *testing.T) {
 	// Confirm the listener joins the group
 	started := time.Now()
 	for {
-		t.Logf("Catchup=%t HeadBlock=%d", l.catchup, es.headBlock)
+		es.mux.Lock()
+		catchup, headBlock := l.catchup, es.headBlock
+		es.mux.Unlock()
+		t.Logf("Catchup=%t HeadBlock=%d", catchup, headBlock)
 		select {
 		case <-events:
 			t.Logf("Noting duplicate event detection of unconfirmed event after listener rejoined head group")
@@ -293,11 +303,11 @@ func TestCatchupThenRejoinLeadGroup(t *testing.T) {
 		if time.Since(started) > 1*time.Second {
 			assert.Fail(t, "Never exited catchup")
 		}
-		if l.catchup {
+		if catchup {
 			time.Sleep(1 * time.Millisecond)
 			continue
 		}
-		if es.headBlock != testHighBlock-es.c.checkpointBlockGap {
+		if headBlock != testHighBlock-es.c.checkpointBlockGap {
 			time.Sleep(1 * time.Millisecond)
 			continue
 		}
@@ -347,7 +357,7 @@ func TestExitDuringCatchup(t *testing.T) {
 		},
 	}
 
-	ctx, c, mRPC, done := newTestConnector(t)
+	ctx, c, mRPC, cDone := newTestConnector(t)
 	mockStreamLoopEmpty(mRPC)
 
 	completed := make(chan struct{})
@@ -358,7 +368,7 @@ func TestExitDuringCatchup(t *testing.T) {
 		switch fromBlock {
 		default:
 			go func() {
-				done()
+				cDone()
 				close(completed)
 			}()
 		}
@@ -366,7 +376,8 @@ func TestExitDuringCatchup(t *testing.T) {
 	}).Once()
 	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getLogs", mock.Anything).Return(nil).Maybe()
 
-	_, _, mRPC, done = testEventStreamExistingConnector(t, ctx, done, c, mRPC, l1req)
+	_, _, _, done := testEventStreamExistingConnector(t, ctx, cDone, c, mRPC, l1req)
+	defer done()
 
 	<-completed
 }
@@ -539,6 +550,7 @@ func TestLeadGroupCatchupExitWhenNoBlockHeightEstablished(t *testing.T) {
 	_, _, mRPC, done := testEventStreamExistingConnector(t, ctx, cDone, c, mRPC, l1req)
 	defer done()
 	<-attempted
+	<-c.blockListener.listenLoopDone // the block listener retries in the background until it is closed
 
 }
 
@@ -637,6 +649,7 @@ func TestStreamLoopChangeFilter(t *testing.T) {
 	ctx, c, mRPC, done := newTestConnector(t)
 
 	var es *eventStream
+	started := make(chan struct{})
 	reestablishedFilter := make(chan struct{})
 	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_blockNumber").Return(nil).Run(func(args mock.Arguments) {
 		hbh := args[1].(*ethtypes.HexInteger)
@@ -644,6 +657,7 @@ func TestStreamLoopChangeFilter(t *testing.T) {
 	})
 	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_newFilter", mock.Anything).Return(nil).
 		Run(func(args mock.Arguments) {
+			<-started
 			l2req.StreamID = es.id
 			_, _, err := c.EventListenerAdd(ctx, l2req)
 			assert.NoError(t, err)
@@ -666,6 +680,7 @@ func TestStreamLoopChangeFilter(t *testing.T) {
 
 	es, _, mRPC, done = testEventStreamExistingConnector(t, ctx, done, c, mRPC, l1req)
 	defer done()
+	close(started)
 
 	<-reestablishedFilter
 
@@ -782,6 +797,7 @@ func TestDispatchListenerDone(t *testing.T) {
 	cancel()
 	es := &eventStream{
 		ctx:    doneCtx,
+		c:      &ethConnector{},
 		events: make(chan<- *ffcapi.ListenerEvent),
 	}
 	exiting := es.dispatchSetHWMCheckExit(&aggregatedListener{}, ffcapi.ListenerEvents{
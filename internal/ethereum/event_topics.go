@@ -0,0 +1,184 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+)
+
+// encodeIndexedTopics encodes the values supplied for the indexed parameters of an event, such as addresses and
+// integers, into the 32 byte topics its logs must have. Each value can be an array of alternatives, and parameters
+// without a value match any topic. The result is in the order of the topics after topic 0, with nil for
+// the parameters that match any topic.
+func encodeIndexedTopics(ctx context.Context, event *abi.Entry, indexed map[string]*fftypes.JSONAny) ([][]ethtypes.HexBytes0xPrefix, error) {
+	if len(indexed) == 0 {
+		return nil, nil
+	}
+	for name := range indexed {
+		if !hasIndexedParam(event, name) {
+			return nil, i18n.NewError(ctx, msgs.MsgUnknownIndexedParam, name, event.String())
+		}
+	}
+	var topics [][]ethtypes.HexBytes0xPrefix
+	for _, param := range event.Inputs {
+		if !param.Indexed {
+			continue
+		}
+		var alternatives []ethtypes.HexBytes0xPrefix
+		if v, ok := indexed[param.Name]; ok {
+			var err error
+			if alternatives, err = encodeIndexedValues(ctx, param, v); err != nil {
+				return nil, err
+			}
+		}
+		topics = append(topics, alternatives)
+	}
+	for len(topics) > 0 && topics[len(topics)-1] == nil {
+		topics = topics[:len(topics)-1]
+	}
+	return topics, nil
+}
+
+func hasIndexedParam(event *abi.Entry, name string) bool {
+	for _, param := range event.Inputs {
+		if param.Indexed && param.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func encodeIndexedValues(ctx context.Context, param *abi.Parameter, v *fftypes.JSONAny) ([]ethtypes.HexBytes0xPrefix, error) {
+	tc, err := param.TypeComponentTreeCtx(ctx)
+	if err != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidIndexedValue, param.Name, err)
+	}
+	if tc.ComponentType() != abi.ElementaryComponent {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidIndexedValue, param.Name, tc.String())
+	}
+	var value interface{}
+	d := json.NewDecoder(bytes.NewReader(v.Bytes()))
+	d.UseNumber()
+	if err := d.Decode(&value); err != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidIndexedValue, param.Name, err)
+	}
+	values, isArray := value.([]interface{})
+	if !isArray {
+		values = []interface{}{value}
+	}
+	alternatives := make([]ethtypes.HexBytes0xPrefix, len(values))
+	for i, value := range values {
+		cv, err := tc.ParseExternalCtx(ctx, value)
+		if err != nil {
+			return nil, i18n.NewError(ctx, msgs.MsgInvalidIndexedValue, param.Name, err)
+		}
+		switch {
+		case tc.ElementaryType().BaseType() == abi.BaseTypeString:
+			// Dynamic types are indexed as the hash of their value
+			alternatives[i] = keccak256([]byte(cv.Value.(string)))
+		case tc.ElementaryType().BaseType() == abi.BaseTypeBytes && tc.ElementarySuffix() == "":
+			alternatives[i] = keccak256(cv.Value.([]byte))
+		default:
+			if alternatives[i], err = cv.EncodeABIDataCtx(ctx); err != nil {
+				return nil, i18n.NewError(ctx, msgs.MsgInvalidIndexedValue, param.Name, err)
+			}
+		}
+	}
+	return alternatives, nil
+}
+
+// indexedSignature is the part of the signature of a filter for the values of its indexed parameters, as listeners
+// with different values are different listeners
+func indexedSignature(indexed map[string]*fftypes.JSONAny) string {
+	if len(indexed) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(indexed))
+	for name := range indexed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for i, name := range names {
+		var b bytes.Buffer
+		if err := json.Compact(&b, indexed[name].Bytes()); err != nil {
+			b.Write(indexed[name].Bytes())
+		}
+		names[i] = name + "=" + b.String()
+	}
+	return "?" + strings.Join(names, "&")
+}
+
+// matchesTopics checks the topics of a log after topic 0 against the indexed parameter values of the filter
+func (f *eventFilter) matchesTopics(topics []ethtypes.HexBytes0xPrefix) bool {
+	for i, alternatives := range f.topics {
+		if alternatives == nil {
+			continue
+		}
+		if i+1 >= len(topics) || !containsTopic(alternatives, topics[i+1]) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsTopic(alternatives []ethtypes.HexBytes0xPrefix, topic ethtypes.HexBytes0xPrefix) bool {
+	for _, a := range alternatives {
+		if bytes.Equal(a, topic) {
+			return true
+		}
+	}
+	return false
+}
+
+// filterTopics are the topics after topic 0 shared by all the filters of a listener, so that the logs of a listener
+// can be queried for those topics alone. It is nil if any filter differs.
+func (l *listener) filterTopics() [][]ethtypes.HexBytes0xPrefix {
+	topics := l.config.filters[0].topics
+	for _, f := range l.config.filters[1:] {
+		if !topicsEqual(topics, f.topics) {
+			return nil
+		}
+	}
+	return topics
+}
+
+func topicsEqual(a, b [][]ethtypes.HexBytes0xPrefix) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if len(a[i]) != len(b[i]) || (a[i] == nil) != (b[i] == nil) {
+			return false
+		}
+		for j := range a[i] {
+			if !bytes.Equal(a[i][j], b[i][j]) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,155 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const abiIndexedTypesEvent = `{"type":"event","name":"Indexed","inputs":[
+	{"indexed":true,"name":"id","type":"int128"},
+	{"indexed":true,"name":"name","type":"string"},
+	{"indexed":true,"name":"data","type":"bytes"},
+	{"indexed":false,"name":"value","type":"uint256"}
+]}`
+
+func TestEncodeIndexedTopics(t *testing.T) {
+	var event *abi.Entry
+	err := json.Unmarshal([]byte(abiIndexedTypesEvent), &event)
+	assert.NoError(t, err)
+
+	topics, err := encodeIndexedTopics(context.Background(), event, map[string]*fftypes.JSONAny{
+		"id":   fftypes.JSONAnyPtr(`[-1, "0x10", 12345678901234567890]`),
+		"name": fftypes.JSONAnyPtr(`"bob"`),
+	})
+	assert.NoError(t, err)
+	assert.Len(t, topics, 2)
+	assert.Equal(t, []ethtypes.HexBytes0xPrefix{
+		ethtypes.MustNewHexBytes0xPrefix("0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"),
+		ethtypes.MustNewHexBytes0xPrefix("0x0000000000000000000000000000000000000000000000000000000000000010"),
+		ethtypes.MustNewHexBytes0xPrefix("0x000000000000000000000000000000000000000000000000ab54a98ceb1f0ad2"),
+	}, topics[0])
+	assert.Equal(t, []ethtypes.HexBytes0xPrefix{keccak256([]byte("bob"))}, topics[1])
+
+	// Dynamic bytes are hashed, and unset parameters before the last one match any topic
+	topics, err = encodeIndexedTopics(context.Background(), event, map[string]*fftypes.JSONAny{
+		"data": fftypes.JSONAnyPtr(`"0x0102"`),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]ethtypes.HexBytes0xPrefix{nil, nil, {keccak256([]byte{0x01, 0x02})}}, topics)
+
+	topics, err = encodeIndexedTopics(context.Background(), event, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, topics)
+
+	_, err = encodeIndexedTopics(context.Background(), event, map[string]*fftypes.JSONAny{"value": fftypes.JSONAnyPtr(`1`)})
+	assert.Regexp(t, "FF23180.*value", err)
+	_, err = encodeIndexedTopics(context.Background(), event, map[string]*fftypes.JSONAny{"id": fftypes.JSONAnyPtr(`"wrong"`)})
+	assert.Regexp(t, "FF23181.*id", err)
+	_, err = encodeIndexedTopics(context.Background(), event, map[string]*fftypes.JSONAny{"id": fftypes.JSONAnyPtr(`!json`)})
+	assert.Regexp(t, "FF23181.*id", err)
+
+	event = nil
+	err = json.Unmarshal([]byte(`{"type":"event","name":"Arrays","inputs":[
+		{"indexed":true,"name":"ids","type":"uint256[]"},
+		{"indexed":true,"name":"bad","type":"wrong"}
+	]}`), &event)
+	assert.NoError(t, err)
+	_, err = encodeIndexedTopics(context.Background(), event, map[string]*fftypes.JSONAny{"ids": fftypes.JSONAnyPtr(`[1]`)})
+	assert.Regexp(t, "FF23181.*uint256\\[\\]", err)
+	_, err = encodeIndexedTopics(context.Background(), event, map[string]*fftypes.JSONAny{"bad": fftypes.JSONAnyPtr(`1`)})
+	assert.Regexp(t, "FF23181.*bad", err)
+}
+
+func TestParseEventFiltersIndexed(t *testing.T) {
+	signature, filters, err := parseEventFilters(context.Background(), []fftypes.JSONAny{
+		*fftypes.JSONAnyPtr(`{"event": ` + abiTransferEvent + `, "indexed": {"to": "0xD0F2F5103fD050739A9fb567251BC460cc24d091", "from": [ "0x3968ef051b422d3d1cdc182a88bba8dd922e6fa4" ]}}`),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `*:Transfer(address,address,uint256)?from=["0x3968ef051b422d3d1cdc182a88bba8dd922e6fa4"]&to="0xD0F2F5103fD050739A9fb567251BC460cc24d091"`, signature)
+	ethLog := sampleTransferLog()
+	assert.Equal(t, [][]ethtypes.HexBytes0xPrefix{{ethLog.Topics[1]}, {ethLog.Topics[2]}}, filters[0].topics)
+	assert.True(t, filters[0].matchesTopics(ethLog.Topics))
+	assert.False(t, filters[0].matchesTopics(ethLog.Topics[:2]))
+	assert.False(t, filters[0].matchesTopics([]ethtypes.HexBytes0xPrefix{ethLog.Topics[0], ethLog.Topics[2], ethLog.Topics[1]}))
+
+	_, _, err = parseEventFilters(context.Background(), []fftypes.JSONAny{
+		*fftypes.JSONAnyPtr(`{"event": ` + abiTransferEvent + `, "indexed": {"value": 1}}`),
+	})
+	assert.Regexp(t, "FF23180", err)
+
+	assert.Equal(t, `?a=!json`, indexedSignature(map[string]*fftypes.JSONAny{"a": fftypes.JSONAnyPtr(`!json`)}))
+}
+
+func TestFilterTopics(t *testing.T) {
+	a := &eventFilter{topics: [][]ethtypes.HexBytes0xPrefix{nil, {ethtypes.MustNewHexBytes0xPrefix("0x01")}}}
+	l := &listener{config: listenerConfig{filters: []*eventFilter{a, a}}}
+	assert.Equal(t, a.topics, l.filterTopics())
+
+	for _, other := range [][][]ethtypes.HexBytes0xPrefix{
+		nil,
+		{{ethtypes.MustNewHexBytes0xPrefix("0x01")}, {ethtypes.MustNewHexBytes0xPrefix("0x01")}},
+		{nil, {ethtypes.MustNewHexBytes0xPrefix("0x02")}},
+		{nil, {ethtypes.MustNewHexBytes0xPrefix("0x01"), ethtypes.MustNewHexBytes0xPrefix("0x02")}},
+	} {
+		l.config.filters[1] = &eventFilter{topics: other}
+		assert.Nil(t, l.filterTopics())
+	}
+}
+
+func TestIndexedListenerQuery(t *testing.T) {
+	lID := fftypes.NewUUID()
+	es, _, mRPC, done := testEventStream(t, &ffcapi.EventListenerAddRequest{
+		ListenerID: lID,
+		EventListenerOptions: ffcapi.EventListenerOptions{
+			Filters: []fftypes.JSONAny{
+				*fftypes.JSONAnyPtr(`{"address":"0x20355f3E852D4b6a9944AdA8d5399dDD3409A431","event":` + abiTransferEvent + `,"indexed":{"to":"0xd0f2f5103fd050739a9fb567251bc460cc24d091"}}`),
+			},
+			Options:   fftypes.JSONAnyPtr(`{}`),
+			FromBlock: strconv.Itoa(testHighBlock),
+		},
+	})
+	done()
+	l := es.listeners[*lID]
+	l.c.chainID = "1"
+	l.c.eventBlockTimestamps = false
+	l.hwmBlock = 0
+
+	ethLog := sampleTransferLog()
+	otherLog := sampleTransferLog()
+	otherLog.Topics = []ethtypes.HexBytes0xPrefix{ethLog.Topics[0], ethLog.Topics[2], ethLog.Topics[1]}
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getLogs", mock.MatchedBy(func(req *logFilterJSONRPC) bool {
+		return req.FromBlock.Int64() == 1000 && len(req.Topics) == 3 && req.Topics[1] == nil && req.Topics[2][0].String() == ethLog.Topics[2].String()
+	})).Run(func(args mock.Arguments) {
+		*args[1].(*[]*logJSONRPC) = []*logJSONRPC{ethLog, otherLog}
+	}).Return(nil).Once()
+
+	events, err := es.getBlockRangeEvents(context.Background(), es.buildAggregatedListener([]*listener{l}), 1000, 1100)
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	mRPC.AssertExpectations(t)
+}
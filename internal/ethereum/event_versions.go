@@ -0,0 +1,95 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+)
+
+// eventVersion is a later version of the event of a filter, such as the event emitted after a contract upgrade
+// changed its parameters, which is used to decode the events from its block onwards
+type eventVersion struct {
+	Event     *abi.Entry       `json:"event"`             // The ABI spec of this version of the event
+	FromBlock fftypes.FFuint64 `json:"fromBlock"`         // The first block emitting this version of the event, such as the block of the upgrade
+	Version   string           `json:"version,omitempty"` // An optional label for this version, returned in the eventVersion of each event
+}
+
+// matchesBlock checks a block is within the range of blocks of this version of the event
+func (f *eventFilter) matchesBlock(blockNumber int64) bool {
+	return blockNumber >= f.fromBlock && (f.toBlock == 0 || blockNumber < f.toBlock)
+}
+
+// expandEventVersions returns a filter for each version of the event of a filter, restricted to the range of blocks
+// between its fromBlock and the fromBlock of the next version, so that each event is decoded with the ABI in effect
+// at its block. The first version is the event of the filter itself, from the start of the chain. A filter without
+// versions is returned unchanged, matching every block.
+func expandEventVersions(ctx context.Context, f *eventFilter) ([]*eventFilter, error) {
+	if len(f.Versions) == 0 {
+		return []*eventFilter{f}, nil
+	}
+	filters := []*eventFilter{f}
+	prevBlock := int64(0)
+	for i, v := range f.Versions {
+		fromBlock := int64(v.FromBlock)
+		if v.Event == nil || fromBlock <= prevBlock {
+			return nil, i18n.NewError(ctx, msgs.MsgInvalidEventVersion, i, fromBlock, prevBlock)
+		}
+		topic0, err := v.Event.SignatureHashCtx(ctx)
+		if err != nil {
+			return nil, i18n.NewError(ctx, msgs.MsgInvalidEventFilter, err)
+		}
+		filters[i].toBlock = fromBlock
+		filters = append(filters, &eventFilter{
+			Event:     v.Event,
+			Address:   f.Address,
+			Topic0:    topic0,
+			Signature: v.Event.String(),
+			Version:   v.Version,
+			fromBlock: fromBlock,
+		})
+		prevBlock = fromBlock
+	}
+	return filters, nil
+}
+
+// versionedSignature is the signature of a filter with versions, including the block each version is effective from
+func versionedSignature(filters []*eventFilter) string {
+	sig := filters[0].Signature
+	for _, f := range filters[1:] {
+		sig += fmt.Sprintf(";%s@%d", f.Signature, f.fromBlock)
+	}
+	return sig
+}
+
+// filterAddress is the address shared by all the filters of a listener, such as the versions of the event of a single
+// contract, so that the logs of a listener can be queried for that address alone. It is nil if any filter differs.
+func (l *listener) filterAddress() *ethtypes.Address0xHex {
+	addr := l.config.filters[0].Address
+	for _, f := range l.config.filters[1:] {
+		if addr == nil || f.Address == nil || *f.Address != *addr {
+			return nil
+		}
+	}
+	return addr
+}
@@ -0,0 +1,139 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/stretchr/testify/assert"
+)
+
+// The upgraded Transfer event has the same signature, but indexes the value
+var abiTransferEventIndexedValue = strings.Replace(abiTransferEvent, `"indexed": false`, `"indexed": true`, 1)
+
+const abiTransferEventWithMemo = `{"type":"event","name":"Transfer","inputs":[
+	{"indexed":true,"name":"from","type":"address"},
+	{"indexed":true,"name":"to","type":"address"},
+	{"indexed":false,"name":"value","type":"uint256"},
+	{"indexed":false,"name":"memo","type":"string"}
+]}`
+
+func versionedTransferFilter() fftypes.JSONAny {
+	return *fftypes.JSONAnyPtr(`{
+		"address": "0x20355f3E852D4b6a9944AdA8d5399dDD3409A431",
+		"event": ` + abiTransferEvent + `,
+		"version": "v1",
+		"versions": [
+			{"event": ` + abiTransferEventIndexedValue + `, "fromBlock": 2000, "version": "v2"},
+			{"event": ` + abiTransferEventWithMemo + `, "fromBlock": 3000, "version": "v3"}
+		]
+	}`)
+}
+
+func TestParseEventFiltersVersions(t *testing.T) {
+	signature, filters, err := parseEventFilters(context.Background(), []fftypes.JSONAny{versionedTransferFilter()})
+	assert.NoError(t, err)
+	assert.Equal(t, "0x20355f3e852d4b6a9944ada8d5399ddd3409a431:Transfer(address,address,uint256);Transfer(address,address,uint256)@2000;Transfer(address,address,uint256,string)@3000", signature)
+	assert.Len(t, filters, 3)
+
+	assert.Equal(t, "v1", filters[0].Version)
+	assert.True(t, filters[0].matchesBlock(0))
+	assert.True(t, filters[0].matchesBlock(1999))
+	assert.False(t, filters[0].matchesBlock(2000))
+
+	assert.Equal(t, "v2", filters[1].Version)
+	assert.Equal(t, filters[0].Topic0, filters[1].Topic0)
+	assert.Equal(t, filters[0].Address, filters[1].Address)
+	assert.False(t, filters[1].matchesBlock(1999))
+	assert.True(t, filters[1].matchesBlock(2999))
+	assert.False(t, filters[1].matchesBlock(3000))
+
+	assert.Equal(t, "v3", filters[2].Version)
+	assert.NotEqual(t, filters[0].Topic0, filters[2].Topic0)
+	assert.True(t, filters[2].matchesBlock(1000000))
+
+	// A listener is only checked once for the versions that share a signature
+	l := &listener{config: listenerConfig{filters: filters}}
+	ag := (&eventStream{}).buildAggregatedListener([]*listener{l})
+	assert.Len(t, ag.signatureSet, 2)
+	assert.Len(t, ag.listenersByTopic0[filters[0].Topic0.String()], 1)
+	assert.Equal(t, filters[0].Address, l.filterAddress())
+
+	l.config.filters = append(l.config.filters, &eventFilter{Address: ethtypes.MustNewAddress("0x112233445566778899aabbccddeeff0011223344")})
+	assert.Nil(t, l.filterAddress())
+	l.config.filters = append(l.config.filters, &eventFilter{})
+	assert.Nil(t, l.filterAddress())
+}
+
+func TestParseEventFiltersVersionsInvalid(t *testing.T) {
+	for _, versions := range []string{
+		`[{"fromBlock": 100}]`,
+		`[{"event": ` + abiTransferEvent + `, "fromBlock": 0}]`,
+		`[{"event": ` + abiTransferEvent + `, "fromBlock": 200}, {"event": ` + abiTransferEvent + `, "fromBlock": 100}]`,
+	} {
+		_, _, err := parseEventFilters(context.Background(), []fftypes.JSONAny{
+			*fftypes.JSONAnyPtr(`{"event": ` + abiTransferEvent + `, "versions": ` + versions + `}`),
+		})
+		assert.Regexp(t, "FF23171", err, versions)
+	}
+
+	_, _, err := parseEventFilters(context.Background(), []fftypes.JSONAny{
+		*fftypes.JSONAnyPtr(`{"event": ` + abiTransferEvent + `, "versions": [{"event": {"type":"event","name":"Bad","inputs":[{"type":"wrong"}]}, "fromBlock": 100}]}`),
+	})
+	assert.Regexp(t, "FF23036", err)
+}
+
+func TestFilterEnrichEthLogVersions(t *testing.T) {
+	_, c, _, done := newTestConnector(t)
+	defer done()
+	c.chainID = "1"
+	c.eventBlockTimestamps = false
+	ee := &eventEnricher{connector: c}
+
+	_, filters, err := parseEventFilters(context.Background(), []fftypes.JSONAny{versionedTransferFilter()})
+	assert.NoError(t, err)
+
+	decode := func(ethLog *logJSONRPC) (*eventInfo, fftypes.JSONObject) {
+		for _, f := range filters {
+			ev, matched, decoded, err := ee.filterEnrichEthLog(context.Background(), f, nil, ethLog)
+			assert.NoError(t, err)
+			if matched {
+				assert.True(t, decoded)
+				return ev.Info.(*eventInfo), ev.Data.JSONObject()
+			}
+		}
+		return nil, nil
+	}
+
+	// Before the upgrade the value is in the data
+	info, data := decode(sampleTransferLog())
+	assert.Equal(t, "v1", info.EventVersion)
+	assert.Equal(t, "1000", data.GetString("value"))
+
+	// After the upgrade the same signature has the value in the topics
+	upgraded := sampleTransferLog()
+	upgraded.BlockNumber = ethtypes.NewHexInteger64(2500)
+	upgraded.Topics = append(upgraded.Topics, upgraded.Data)
+	upgraded.Data = ethtypes.HexBytes0xPrefix{}
+	info, data = decode(upgraded)
+	assert.Equal(t, "v2", info.EventVersion)
+	assert.Equal(t, "1000", data.GetString("value"))
+}
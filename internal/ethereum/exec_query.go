@@ -21,6 +21,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math/big"
+	"time"
 
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly-common/pkg/i18n"
@@ -46,9 +48,59 @@ var (
 		},
 	}
 	defaultErrorID = defaultError.FunctionSelectorBytes()
+
+	// Solidity 0.8+ raises Panic(uint256) for failed asserts and built-in checks
+	// See https://docs.soliditylang.org/en/latest/control-structures.html#panic-via-assert-and-error-via-require
+	defaultPanic = &abi.Entry{
+		Type: abi.Error,
+		Name: "Panic",
+		Inputs: abi.ParameterArray{
+			{
+				Type: "uint256",
+			},
+		},
+	}
+	defaultPanicID = defaultPanic.FunctionSelectorBytes()
+
+	panicCodeDescriptions = map[int64]string{
+		0x00: "generic compiler inserted panic",
+		0x01: "assert failed",
+		0x11: "arithmetic overflow or underflow",
+		0x12: "division or modulo by zero",
+		0x21: "invalid enum value conversion",
+		0x22: "incorrectly encoded storage byte array",
+		0x31: "pop on empty array",
+		0x32: "array index out of bounds",
+		0x41: "too much memory allocated",
+		0x51: "call to zero-initialized internal function",
+	}
 )
 
+// QueryRequest is a query request with a number format for the integers of the outputs, overriding the configured format
+type QueryRequest struct {
+	NumberFormat string `json:"numberFormat,omitempty"`
+	ffcapi.QueryInvokeRequest
+}
+
+// FormattedQueryInvoke performs a query as QueryInvoke does, with the integers of the outputs in the requested format
+func (c *ethConnector) FormattedQueryInvoke(ctx context.Context, req *QueryRequest) (*ffcapi.QueryInvokeResponse, ffcapi.ErrorReason, error) {
+	ctx = withCorrelationIDs(ctx)
+	serializer, err := c.serializerFor(ctx, req.NumberFormat)
+	if err != nil {
+		return nil, ffcapi.ErrorReasonInvalidInputs, err
+	}
+	res, reason, err := c.queryInvoke(ctx, &req.QueryInvokeRequest, "", serializer)
+	return res, reason, c.exposeErrorDetails(reason, err)
+}
+
 func (c *ethConnector) QueryInvoke(ctx context.Context, req *ffcapi.QueryInvokeRequest) (*ffcapi.QueryInvokeResponse, ffcapi.ErrorReason, error) {
+	ctx = withCorrelationIDs(ctx)
+	res, reason, err := c.queryInvoke(ctx, req, "", c.serializer)
+	return res, reason, c.exposeErrorDetails(reason, err)
+}
+
+func (c *ethConnector) queryInvoke(ctx context.Context, req *ffcapi.QueryInvokeRequest, privacyGroupID string, serializer *abi.Serializer) (*ffcapi.QueryInvokeResponse, ffcapi.ErrorReason, error) {
+	defer c.latencySLOs.observe(LatencySLOQuery, time.Now())
 	// Parse the input JSON data, to build the call data
 	callData, method, err := c.prepareCallData(ctx, &req.TransactionInput)
 	if err != nil {
@@ -66,9 +118,10 @@ func (c *ethConnector) QueryInvoke(ctx context.Context, req *ffcapi.QueryInvokeR
 	if err != nil {
 		return nil, ffcapi.ErrorReasonInvalidInputs, err
 	}
+	errors = c.withImplementationErrors(ctx, tx.To, req.BlockNumber, errors)
 
 	// Do the call, with processing of revert reasons
-	outputs, reason, err := c.callTransaction(ctx, tx, method, errors, req.BlockNumber)
+	outputs, reason, err := c.callTransaction(ctx, tx, method, errors, req.BlockNumber, privacyGroupID, serializer)
 	if err != nil {
 		return nil, reason, err
 	}
@@ -92,32 +145,22 @@ func (c *ethConnector) attemptProcessingRevertData(ctx context.Context, errors [
 		} else {
 			revertReason := processRevertReason(ctx, revertData, errors)
 			if revertReason != "" {
-				return ffcapi.ErrorReasonTransactionReverted, i18n.NewError(ctx, msgs.MsgReverted, revertReason)
+				details := revertErrorDetails(revertData, errors)
+				details.ProviderErrorCode = rpcErr.Code
+				return ffcapi.ErrorReasonTransactionReverted, withErrorDetails(i18n.NewError(ctx, msgs.MsgReverted, revertReason), details)
 			}
 		}
 	}
 	return "", nil
 }
 
-func (c *ethConnector) callTransaction(ctx context.Context, tx *ethsigner.Transaction, method *abi.Entry, errors []*abi.Entry, blockNumber *string) (*fftypes.JSONAny, ffcapi.ErrorReason, error) {
+// callTransaction performs an eth_call, or a priv_call against the private state of a Besu privacy group if one is supplied,
+// decoding the outputs with the supplied serializer
+func (c *ethConnector) callTransaction(ctx context.Context, tx *ethsigner.Transaction, method *abi.Entry, errors []*abi.Entry, blockNumber *string, privacyGroupID string, serializer *abi.Serializer) (*fftypes.JSONAny, ffcapi.ErrorReason, error) {
 
 	// Do the raw call
-	var outputData ethtypes.HexBytes0xPrefix
-	blockNumberStr := "latest"
-	if blockNumber != nil {
-		blockNumberStr = *blockNumber
-	}
-	rpcErr := c.backend.CallRPC(ctx, &outputData, "eth_call", tx, blockNumberStr)
-	if rpcErr != nil {
-		if reason, revertErr := c.attemptProcessingRevertData(ctx, errors, rpcErr); revertErr != nil {
-			return nil, reason, revertErr
-		}
-
-		reason := mapError(callRPCMethods, rpcErr.Error())
-		err := rpcErr.Error()
-		if reason == ffcapi.ErrorReasonTransactionReverted {
-			err = i18n.NewError(ctx, msgs.MsgReverted, rpcErr.Error())
-		}
+	outputData, reason, err := c.callRaw(ctx, tx, errors, blockNumber, privacyGroupID)
+	if err != nil {
 		return nil, reason, err
 	}
 
@@ -130,7 +173,7 @@ func (c *ethConnector) callTransaction(ctx context.Context, tx *ethsigner.Transa
 	// check the output to see if there are error data and return proper errors
 	revertReason := processRevertReason(ctx, outputData, errors)
 	if revertReason != "" {
-		return nil, ffcapi.ErrorReasonTransactionReverted, i18n.NewError(ctx, msgs.MsgReverted, revertReason)
+		return nil, ffcapi.ErrorReasonTransactionReverted, withErrorDetails(i18n.NewError(ctx, msgs.MsgReverted, revertReason), revertErrorDetails(outputData, errors))
 	}
 
 	if method == nil {
@@ -144,7 +187,7 @@ func (c *ethConnector) callTransaction(ctx context.Context, tx *ethsigner.Transa
 	outputValueTree, err := method.Outputs.DecodeABIDataCtx(ctx, outputData, 0)
 	if err == nil {
 		// Serialize down to JSON, and wrap in a JSONAny
-		jsonData, err = c.serializer.SerializeJSONCtx(ctx, outputValueTree)
+		jsonData, err = serializer.SerializeJSONCtx(ctx, outputValueTree)
 	}
 	if err != nil {
 		log.L(ctx).Warnf("Invalid return data: %s", outputData)
@@ -153,6 +196,59 @@ func (c *ethConnector) callTransaction(ctx context.Context, tx *ethsigner.Transa
 	return fftypes.JSONAnyPtrBytes(jsonData), "", nil
 }
 
+// callRaw performs the call for callTransaction, returning the raw output data. Reverts returned in the error from
+// the node are returned as errors, with the revert reason decoded against the errors ABI.
+func (c *ethConnector) callRaw(ctx context.Context, tx *ethsigner.Transaction, errors []*abi.Entry, blockNumber *string, privacyGroupID string) (ethtypes.HexBytes0xPrefix, ffcapi.ErrorReason, error) {
+	var outputData ethtypes.HexBytes0xPrefix
+	blockNumberStr := "latest"
+	if blockNumber != nil {
+		blockNumberStr = *blockNumber
+	}
+	var rpcErr *rpcbackend.RPCError
+	if privacyGroupID != "" {
+		rpcErr = c.backend.CallRPC(ctx, &outputData, "priv_call", privacyGroupID, tx, blockNumberStr)
+	} else {
+		rpcErr = c.readBackend().CallRPC(ctx, &outputData, "eth_call", tx, blockNumberStr)
+	}
+	if rpcErr != nil {
+		if reason, revertErr := c.attemptProcessingRevertData(ctx, errors, rpcErr); revertErr != nil {
+			return nil, reason, revertErr
+		}
+
+		reason := mapRPCError(callRPCMethods, rpcErr)
+		err := rpcErr.Error()
+		if reason == ffcapi.ErrorReasonTransactionReverted {
+			err = i18n.NewError(ctx, msgs.MsgReverted, rpcErr.Error())
+		}
+		return nil, reason, withErrorDetails(err, rpcErrorDetails(rpcErr))
+	}
+	return outputData, "", nil
+}
+
+// callContractIgnoringReverts performs a raw eth_call against the given block (or latest if empty), for the
+// built-in queries where a revert (such as an unimplemented optional function) is an expected answer.
+// Reverts return nil output data with no error.
+func (c *ethConnector) callContractIgnoringReverts(ctx context.Context, address *ethtypes.Address0xHex, callData []byte, gasLimit *ethtypes.HexInteger, blockNumber string) (ethtypes.HexBytes0xPrefix, error) {
+	if blockNumber == "" {
+		blockNumber = "latest"
+	}
+	tx := &ethsigner.Transaction{
+		To:       address,
+		GasLimit: gasLimit,
+		Data:     callData,
+	}
+	var outputData ethtypes.HexBytes0xPrefix
+	rpcErr := c.readBackend().CallRPC(ctx, &outputData, "eth_call", tx, blockNumber)
+	if rpcErr != nil {
+		if mapError(callRPCMethods, rpcErr.Error()) == ffcapi.ErrorReasonTransactionReverted || rpcErr.Data != "" {
+			log.L(ctx).Debugf("Call to %s reverted: %s", address, rpcErr.Message)
+			return nil, nil
+		}
+		return nil, rpcErr.Error()
+	}
+	return outputData, nil
+}
+
 // processRevertReason returns under 3 different circumstances:
 // 1. non-empty string - parsed by us: valid reason has been successfully parsed
 // 2. non-empty string - assumed to already be parsed by node: error detail was present but failed to parse, string was raw data
@@ -172,6 +268,11 @@ func processRevertReason(ctx context.Context, outputData ethtypes.HexBytes0xPref
 				}
 			}
 			log.L(ctx).Warnf("Invalid revert data: %s", outputData)
+		} else if bytes.Equal(signature, defaultPanicID) {
+			if panicReason := formatPanic(ctx, outputData); panicReason != "" {
+				return panicReason
+			}
+			log.L(ctx).Warnf("Invalid panic data: %s", outputData)
 		} else if len(errorAbis) > 0 {
 			// check if the signature matches any of the declared custom error definitions
 			for _, e := range errorAbis {
@@ -194,6 +295,24 @@ func processRevertReason(ctx context.Context, outputData ethtypes.HexBytes0xPref
 	return ""
 }
 
+// formatPanic decodes Panic(uint256) revert data into the code and a human readable category
+func formatPanic(ctx context.Context, outputData []byte) string {
+	panicInfo, err := defaultPanic.DecodeCallDataCtx(ctx, outputData)
+	if err != nil || len(panicInfo.Children) != 1 {
+		return ""
+	}
+	code, ok := panicInfo.Children[0].Value.(*big.Int)
+	if !ok {
+		return ""
+	}
+	if code.IsInt64() {
+		if description, known := panicCodeDescriptions[code.Int64()]; known {
+			return fmt.Sprintf("Panic(0x%02x): %s", code.Int64(), description)
+		}
+	}
+	return fmt.Sprintf("Panic(0x%s)", code.Text(16))
+}
+
 func formatCustomError(ctx context.Context, e *abi.Entry, outputData ethtypes.HexBytes0xPrefix) string {
 	errorInfo, err := e.DecodeCallDataCtx(ctx, outputData)
 	if err == nil {
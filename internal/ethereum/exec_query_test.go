@@ -190,6 +190,42 @@ func TestExecQueryCustomErrorRevertDataExceedsBalance(t *testing.T) {
 
 }
 
+func TestExecQueryPanicRevertData(t *testing.T) {
+
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	panicData := []string{
+		"0x4e487b710000000000000000000000000000000000000000000000000000000000000011",
+		"0x4e487b710000000000000000000000000000000000000000000000000000000000000099",
+		"0x4e487b71ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		"0x4e487b71",
+	}
+	for _, d := range panicData {
+		data := d
+		mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_call", mock.Anything, "latest").
+			Run(func(args mock.Arguments) {
+				*(args[1].(*ethtypes.HexBytes0xPrefix)) = ethtypes.MustNewHexBytes0xPrefix(data)
+			}).
+			Return(nil).Once()
+	}
+
+	var req ffcapi.QueryInvokeRequest
+	err := json.Unmarshal([]byte(sampleExecQuery), &req)
+	assert.NoError(t, err)
+	for _, expected := range []string{
+		"Panic(0x11): arithmetic overflow or underflow",
+		"Panic(0x99)",
+		"Panic(0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff)",
+		"0x4e487b71",
+	} {
+		_, reason, err := c.QueryInvoke(ctx, &req)
+		assert.Equal(t, ffcapi.ErrorReasonTransactionReverted, reason)
+		assert.Equal(t, i18n.NewError(ctx, msgs.MsgReverted, expected).Error(), err.Error())
+	}
+
+}
+
 func TestExecQueryCustomErrorRevertDataNotEnoughEther(t *testing.T) {
 
 	ctx, c, mRPC, done := newTestConnector(t)
@@ -0,0 +1,170 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+)
+
+// faultRule is the faults injected into requests for a set of methods. A single random draw per request decides
+// between an error, a dropped response and a malformed response, so the rates are exclusive.
+type faultRule struct {
+	methods      map[string]bool // all methods when empty
+	latency      time.Duration
+	errorRate    float64
+	errorCode    int64
+	errorMessage string
+	dropRate     float64
+	malformRate  float64
+}
+
+// faultInjector applies the first matching rule to each request of the JSON/RPC clients it wraps, for testing
+// the resilience of a deployment to an unreliable node. It must never be enabled in production.
+type faultInjector struct {
+	rules []*faultRule
+	roll  func() float64
+}
+
+// newFaultInjector returns nil if fault injection is not enabled
+func newFaultInjector(ctx context.Context, conf config.Section) (*faultInjector, error) {
+	if !conf.GetBool(FaultInjectionEnabled) {
+		return nil, nil
+	}
+	fi := &faultInjector{roll: faultRoll}
+	rulesConf := faultInjectionRulesConfig(conf)
+	for i := 0; i < rulesConf.ArraySize(); i++ {
+		entry := rulesConf.ArrayEntry(i)
+		rule := &faultRule{
+			methods:      make(map[string]bool),
+			latency:      entry.GetDuration(FaultInjectionRulesLatency),
+			errorRate:    entry.GetFloat64(FaultInjectionRulesErrorRate),
+			errorCode:    entry.GetInt64(FaultInjectionRulesErrorCode),
+			errorMessage: entry.GetString(FaultInjectionRulesErrorMessage),
+			dropRate:     entry.GetFloat64(FaultInjectionRulesDropRate),
+			malformRate:  entry.GetFloat64(FaultInjectionRulesMalformedRate),
+		}
+		for _, rate := range []float64{rule.errorRate, rule.dropRate, rule.malformRate} {
+			if rate < 0 || rate > 1 {
+				return nil, i18n.NewError(ctx, msgs.MsgInvalidFaultInjectionRule, i, rate)
+			}
+		}
+		if total := rule.errorRate + rule.dropRate + rule.malformRate; total > 1 {
+			return nil, i18n.NewError(ctx, msgs.MsgInvalidFaultInjectionRule, i, total)
+		}
+		for _, method := range entry.GetStringSlice(FaultInjectionRulesMethods) {
+			rule.methods[method] = true
+		}
+		fi.rules = append(fi.rules, rule)
+	}
+	log.L(ctx).Warnf("JSON/RPC fault injection is enabled with %d rules. This must not be used in production", len(fi.rules))
+	return fi, nil
+}
+
+// faultRoll returns a random number in the range [0,1)
+func faultRoll() float64 {
+	var b [8]byte
+	_, _ = rand.Read(b[:])
+	return float64(binary.BigEndian.Uint64(b[:])>>11) / (1 << 53)
+}
+
+func (fi *faultInjector) ruleFor(method string) *faultRule {
+	for _, rule := range fi.rules {
+		if len(rule.methods) == 0 || rule.methods[method] {
+			return rule
+		}
+	}
+	return nil
+}
+
+// wrap returns the client with faults injected. A dropped response holds the caller for the request timeout of
+// the endpoint, as it would if the node never responded.
+func (fi *faultInjector) wrap(client rpcbackend.Backend, requestTimeout time.Duration) rpcbackend.Backend {
+	if fi == nil {
+		return client
+	}
+	return &faultClient{client: client, injector: fi, requestTimeout: requestTimeout}
+}
+
+type faultClient struct {
+	client         rpcbackend.Backend
+	injector       *faultInjector
+	requestTimeout time.Duration
+}
+
+func (fc *faultClient) CallRPC(ctx context.Context, result interface{}, method string, params ...interface{}) *rpcbackend.RPCError {
+	return callRPCWithSyncRequest(ctx, fc, result, method, params)
+}
+
+func (fc *faultClient) SyncRequest(ctx context.Context, rpcReq *rpcbackend.RPCRequest) (*rpcbackend.RPCResponse, error) {
+	rule := fc.injector.ruleFor(rpcReq.Method)
+	if rule == nil {
+		return fc.client.SyncRequest(ctx, rpcReq)
+	}
+	if rule.latency > 0 {
+		log.L(ctx).Warnf("Injecting %s latency into %s request", rule.latency, rpcReq.Method)
+		select {
+		case <-time.After(rule.latency):
+		case <-ctx.Done():
+			err := i18n.NewError(ctx, msgs.MsgRPCRequestAbandoned, rpcReq.Method, ctx.Err())
+			return rpcbackend.RPCErrorResponse(err, rpcReq.ID, rpcbackend.RPCCodeInternalError), err
+		}
+	}
+	roll := fc.injector.roll()
+	switch {
+	case roll < rule.errorRate:
+		log.L(ctx).Warnf("Injecting error into %s request", rpcReq.Method)
+		rpcErr := &rpcbackend.RPCError{Code: rule.errorCode, Message: rule.errorMessage}
+		return &rpcbackend.RPCResponse{JSONRpc: "2.0", ID: rpcReq.ID, Error: rpcErr}, errors.New(rpcErr.Message)
+	case roll < rule.errorRate+rule.dropRate:
+		// The request is still sent, so it might have taken effect on the node when the response is lost
+		_, _ = fc.client.SyncRequest(ctx, rpcReq)
+		log.L(ctx).Warnf("Dropping response to %s request", rpcReq.Method)
+		var timeout <-chan time.Time
+		if fc.requestTimeout > 0 {
+			timer := time.NewTimer(fc.requestTimeout)
+			defer timer.Stop()
+			timeout = timer.C
+		}
+		select {
+		case <-timeout:
+		case <-ctx.Done():
+		}
+		err := i18n.NewError(ctx, msgs.MsgInjectedResponseDropped, rpcReq.Method)
+		return rpcbackend.RPCErrorResponse(err, rpcReq.ID, rpcbackend.RPCCodeInternalError), err
+	case roll < rule.errorRate+rule.dropRate+rule.malformRate:
+		res, err := fc.client.SyncRequest(ctx, rpcReq)
+		if err != nil {
+			return res, err
+		}
+		log.L(ctx).Warnf("Injecting malformed response to %s request", rpcReq.Method)
+		res.Result = fftypes.JSONAnyPtr(`{"malformed":`)
+		return res, nil
+	default:
+		return fc.client.SyncRequest(ctx, rpcReq)
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-evmconnect/mocks/rpcbackendmocks"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func testFaultInjectionConf(rules ...map[string]interface{}) config.Section {
+	config.RootConfigReset()
+	conf := config.RootSection("unittest")
+	InitConfig(conf)
+	conf.Set(FaultInjectionEnabled, true)
+	for i, rule := range rules {
+		entry := faultInjectionRulesConfig(conf).ArrayEntry(i)
+		for k, v := range rule {
+			entry.Set(k, v)
+		}
+	}
+	return conf
+}
+
+func newTestFaultClient(t *testing.T, roll float64, requestTimeout time.Duration, rules ...map[string]interface{}) (rpcbackend.Backend, *rpcbackendmocks.Backend) {
+	fi, err := newFaultInjector(context.Background(), testFaultInjectionConf(rules...))
+	assert.NoError(t, err)
+	fi.roll = func() float64 { return roll }
+	mRPC := &rpcbackendmocks.Backend{}
+	return fi.wrap(mRPC, requestTimeout), mRPC
+}
+
+func TestFaultInjectionConfig(t *testing.T) {
+	config.RootConfigReset()
+	conf := config.RootSection("unittest")
+	InitConfig(conf)
+	fi, err := newFaultInjector(context.Background(), conf)
+	assert.NoError(t, err)
+	assert.Nil(t, fi)
+	client := &rpcbackendmocks.Backend{}
+	assert.Equal(t, client, fi.wrap(client, time.Second))
+
+	_, err = newFaultInjector(context.Background(), testFaultInjectionConf(map[string]interface{}{"errorRate": 1.5}))
+	assert.Regexp(t, "FF23153.*1.5", err)
+	_, err = newFaultInjector(context.Background(), testFaultInjectionConf(map[string]interface{}{"errorRate": 0.6, "dropRate": 0.6}))
+	assert.Regexp(t, "FF23153.*1.2", err)
+
+	_, err = newRPCClientOptions(context.Background(), testFaultInjectionConf(map[string]interface{}{"dropRate": -1}))
+	assert.Regexp(t, "FF23153", err)
+
+	conf = testFaultInjectionConf(map[string]interface{}{"dropRate": -1})
+	conf.Set(ffresty.HTTPConfigURL, "http://localhost:8545")
+	_, err = NewEthereumConnector(context.Background(), conf)
+	assert.Regexp(t, "FF23153", err)
+}
+
+func TestFaultRoll(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		roll := faultRoll()
+		assert.GreaterOrEqual(t, roll, float64(0))
+		assert.Less(t, roll, float64(1))
+	}
+}
+
+func TestFaultInjectionMethods(t *testing.T) {
+	client, mRPC := newTestFaultClient(t, 0, time.Second,
+		map[string]interface{}{"methods": []string{"eth_getLogs"}, "errorRate": 1, "errorCode": -32005, "errorMessage": "limit exceeded"},
+		map[string]interface{}{"methods": []string{"eth_getLogs", "eth_blockNumber"}, "errorRate": 1},
+	)
+	mRPC.On("SyncRequest", mock.Anything, mock.Anything).Return(&rpcbackend.RPCResponse{Result: fftypes.JSONAnyPtr(`"0x1"`)}, nil).Once()
+
+	var result ethtypes.HexInteger
+	rpcErr := client.CallRPC(context.Background(), &result, "eth_getLogs")
+	assert.Equal(t, int64(-32005), rpcErr.Code)
+	assert.Equal(t, "limit exceeded", rpcErr.Message)
+	rpcErr = client.CallRPC(context.Background(), &result, "eth_blockNumber")
+	assert.Equal(t, int64(-32000), rpcErr.Code)
+	assert.Equal(t, "injected fault", rpcErr.Message)
+
+	// No rule for the method
+	rpcErr = client.CallRPC(context.Background(), &result, "eth_chainId")
+	assert.Nil(t, rpcErr)
+	assert.Equal(t, int64(1), result.BigInt().Int64())
+	mRPC.AssertExpectations(t)
+}
+
+func TestFaultInjectionLatency(t *testing.T) {
+	client, mRPC := newTestFaultClient(t, 0.5, time.Second, map[string]interface{}{"latency": "10ms", "errorRate": 0.1})
+	mRPC.On("SyncRequest", mock.Anything, mock.Anything).Return(&rpcbackend.RPCResponse{Result: fftypes.JSONAnyPtr(`"0x1"`)}, nil).Once()
+
+	var result ethtypes.HexInteger
+	start := time.Now()
+	rpcErr := client.CallRPC(context.Background(), &result, "eth_blockNumber")
+	assert.Nil(t, rpcErr)
+	assert.GreaterOrEqual(t, time.Since(start), 10*time.Millisecond)
+
+	client, _ = newTestFaultClient(t, 0.5, time.Second, map[string]interface{}{"latency": "1h"})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	rpcErr = client.CallRPC(ctx, &result, "eth_blockNumber")
+	assert.Regexp(t, "FF23102", rpcErr.Message)
+	mRPC.AssertExpectations(t)
+}
+
+func TestFaultInjectionDrop(t *testing.T) {
+	client, mRPC := newTestFaultClient(t, 0.3, 10*time.Millisecond, map[string]interface{}{"errorRate": 0.2, "dropRate": 0.2})
+	mRPC.On("SyncRequest", mock.Anything, mock.Anything).Return(&rpcbackend.RPCResponse{Result: fftypes.JSONAnyPtr(`"0x1"`)}, nil).Once()
+
+	var result ethtypes.HexInteger
+	rpcErr := client.CallRPC(context.Background(), &result, "eth_sendRawTransaction", "0x1234")
+	assert.Regexp(t, "FF23154.*eth_sendRawTransaction", rpcErr.Message)
+	mRPC.AssertExpectations(t)
+
+	// Without a request timeout, the response is dropped until the caller gives up
+	client, mRPC = newTestFaultClient(t, 0.3, 0, map[string]interface{}{"dropRate": 1})
+	mRPC.On("SyncRequest", mock.Anything, mock.Anything).Return(&rpcbackend.RPCResponse{Result: fftypes.JSONAnyPtr(`"0x1"`)}, nil).Once()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	rpcErr = client.CallRPC(ctx, &result, "eth_blockNumber")
+	assert.Regexp(t, "FF23154", rpcErr.Message)
+	mRPC.AssertExpectations(t)
+}
+
+func TestFaultInjectionMalformed(t *testing.T) {
+	client, mRPC := newTestFaultClient(t, 0.5, time.Second, map[string]interface{}{"dropRate": 0.2, "malformedRate": 0.4})
+	mRPC.On("SyncRequest", mock.Anything, mock.Anything).Return(&rpcbackend.RPCResponse{Result: fftypes.JSONAnyPtr(`"0x1"`)}, nil).Once()
+	mRPC.On("SyncRequest", mock.Anything, mock.Anything).Return(&rpcbackend.RPCResponse{}, errors.New("pop")).Once()
+
+	var result ethtypes.HexInteger
+	rpcErr := client.CallRPC(context.Background(), &result, "eth_blockNumber")
+	assert.Equal(t, int64(rpcbackend.RPCCodeParseError), rpcErr.Code)
+
+	// Errors from the node are returned as they are
+	rpcErr = client.CallRPC(context.Background(), &result, "eth_blockNumber")
+	assert.Regexp(t, "pop", rpcErr.Message)
+	mRPC.AssertExpectations(t)
+}
+
+func TestFaultInjectionRPCClient(t *testing.T) {
+	conf := testFaultInjectionConf(map[string]interface{}{"errorRate": 1})
+	conf.Set(ffresty.HTTPConfigURL, "http://localhost:8545")
+	opts, err := newRPCClientOptions(context.Background(), conf)
+	assert.NoError(t, err)
+	client, err := newRPCClient(context.Background(), conf, opts)
+	assert.NoError(t, err)
+	assert.IsType(t, &faultClient{}, client)
+
+	conf.Set(ffresty.HTTPConfigURL, "unix:///tmp/node.ipc")
+	client, err = newRPCClient(context.Background(), conf, opts)
+	assert.NoError(t, err)
+	assert.IsType(t, &faultClient{}, client)
+	var result string
+	rpcErr := client.CallRPC(context.Background(), &result, "eth_chainId")
+	assert.Equal(t, "injected fault", rpcErr.Message)
+}
+
+func TestFaultInjectionReloadConfig(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t)
+	defer done()
+	err := c.ReloadConfig(ctx, testFaultInjectionConf(map[string]interface{}{"errorRate": 2}))
+	assert.Regexp(t, "FF23153", err)
+}
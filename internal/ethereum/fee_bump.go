@@ -0,0 +1,135 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-signer/pkg/ethsigner"
+)
+
+// FeeBumpRequest is an in-flight transaction to suggest replacement fees for, with its current gas price in the
+// form it was submitted with, and how long it has been waiting
+type FeeBumpRequest struct {
+	GasPrice *fftypes.JSONAny    `json:"gasPrice"`
+	Age      *fftypes.FFDuration `json:"age,omitempty"`
+}
+
+// FeeBumpResponse is the suggested gas price of the replacement transaction, in the same form as the current gas
+// price so it can be submitted unmodified, including any other fields of the gas price object
+type FeeBumpResponse struct {
+	GasPrice     *fftypes.JSONAny `json:"gasPrice"`
+	PriceBump    int64            `json:"priceBump"`
+	Urgent       bool             `json:"urgent"`
+	MarketRaised bool             `json:"marketRaised"`
+}
+
+// bumpFee returns the minimum fee of a replacement for a transaction with the given fee, rounding up, and always
+// more than the current fee as nodes reject replacements that do not pay more
+func bumpFee(current *big.Int, percent int64) *big.Int {
+	bumped := new(big.Int).Mul(current, big.NewInt(100+percent))
+	bumped.Add(bumped, big.NewInt(99))
+	bumped.Div(bumped, big.NewInt(100))
+	if bumped.Cmp(current) <= 0 {
+		bumped.Add(current, big.NewInt(1))
+	}
+	return bumped
+}
+
+// FeeBump suggests the fees to replace an in-flight transaction with. Each fee is bumped by the percentage the node
+// requires of a replacement, which is the blob price bump for blob-carrying transactions, and raised to the current
+// market fee if that is higher. The market fee of a legacy transaction is the gas price of the node, and that of an
+// EIP-1559 transaction is the medium fee suggestion of the recent fee history, or the high suggestion once the
+// transaction is older than the configured urgency age.
+func (c *ethConnector) FeeBump(ctx context.Context, req *FeeBumpRequest) (*FeeBumpResponse, error) {
+	if req.GasPrice == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgGasPriceError, "")
+	}
+	var current ethsigner.Transaction
+	if err := c.mapGasPrice(ctx, req.GasPrice, &current); err != nil {
+		return nil, err
+	}
+	gp := c.gas()
+	gasPriceObject := req.GasPrice.JSONObjectNowarn()
+	maxFeePerBlobGas := gasPriceObject.GetInteger("maxFeePerBlobGas")
+	res := &FeeBumpResponse{
+		PriceBump: gp.priceBump,
+		Urgent:    req.Age != nil && time.Duration(*req.Age) >= gp.urgentAfter,
+	}
+	if maxFeePerBlobGas.Sign() > 0 {
+		res.PriceBump = gp.blobPriceBump
+	}
+	withMarket := func(minimum, market *big.Int) *big.Int {
+		if market != nil && market.Cmp(minimum) > 0 {
+			res.MarketRaised = true
+			return market
+		}
+		return minimum
+	}
+
+	bumped := map[string]*big.Int{}
+	if current.MaxFeePerGas != nil {
+		var market *FeeSuggestion
+		// The fee history is in the native currency, so is not used for transactions that pay in a fee currency
+		if feeCurrency, err := c.feeCurrency(ctx, req.GasPrice); err == nil && feeCurrency == nil {
+			if suggestions := c.feeSuggestions(ctx, gp.suggestionBlocks); suggestions != nil {
+				market = suggestions.Medium
+				if res.Urgent {
+					market = suggestions.High
+				}
+			}
+		}
+		var marketPriorityFee, marketMaxFee *big.Int
+		if market != nil {
+			marketPriorityFee, marketMaxFee = market.MaxPriorityFeePerGas.Int(), market.MaxFeePerGas.Int()
+		}
+		priorityFee := withMarket(bumpFee(current.MaxPriorityFeePerGas.BigInt(), res.PriceBump), marketPriorityFee)
+		maxFee := withMarket(bumpFee(current.MaxFeePerGas.BigInt(), res.PriceBump), marketMaxFee)
+		if maxFee.Cmp(priorityFee) < 0 {
+			maxFee = priorityFee
+		}
+		bumped["maxPriorityFeePerGas"] = priorityFee
+		bumped["maxFeePerGas"] = maxFee
+	} else {
+		nodeGasPrice, rpcErr := c.nodeGasPrice(ctx)
+		if rpcErr != nil {
+			return nil, rpcErr.Error()
+		}
+		bumped["gasPrice"] = withMarket(bumpFee(current.GasPrice.BigInt(), res.PriceBump), nodeGasPrice.BigInt())
+	}
+	if maxFeePerBlobGas.Sign() > 0 {
+		bumped["maxFeePerBlobGas"] = bumpFee(maxFeePerBlobGas, res.PriceBump)
+	}
+
+	if _, isObject := req.GasPrice.JSONObjectOk(true); !isObject {
+		res.GasPrice = fftypes.JSONAnyPtr(fmt.Sprintf(`"%s"`, bumped["gasPrice"].Text(10)))
+		return res, nil
+	}
+	for field, fee := range bumped {
+		gasPriceObject[field] = fee.Text(10)
+	}
+	b, _ := json.Marshal(gasPriceObject)
+	res.GasPrice = fftypes.JSONAnyPtrBytes(b)
+	return res, nil
+}
@@ -0,0 +1,196 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-evmconnect/mocks/rpcbackendmocks"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func mockFeeBumpFeeHistory(mRPC *rpcbackendmocks.Backend) {
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_feeHistory", mock.Anything, "latest", mock.Anything).
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			// The same history as the gas price suggestions test, with medium 6/1429 and high 10/1135
+			*(args[1].(**feeHistoryJSONRPC)) = &feeHistoryJSONRPC{
+				BaseFeePerGas: hexIntegers(900, 950, 900, 950, 1000),
+				GasUsedRatio:  []float64{0.5, 0, 0.5, 0.5},
+				Reward: [][]*ethtypes.HexInteger{
+					hexIntegers(1, 5, 9),
+					hexIntegers(0, 0, 0),
+					hexIntegers(2, 6, 10),
+					hexIntegers(3, 7, 11),
+				},
+			}
+		})
+}
+
+func feeBumpAge(d time.Duration) *fftypes.FFDuration {
+	fd := fftypes.FFDuration(d)
+	return &fd
+}
+
+func TestBumpFee(t *testing.T) {
+	assert.Equal(t, int64(110), bumpFee(big.NewInt(100), 10).Int64())
+	assert.Equal(t, int64(6), bumpFee(big.NewInt(5), 10).Int64())
+	assert.Equal(t, int64(1), bumpFee(big.NewInt(0), 10).Int64())
+	assert.Equal(t, int64(101), bumpFee(big.NewInt(100), 0).Int64())
+	assert.Equal(t, int64(200), bumpFee(big.NewInt(100), 100).Int64())
+}
+
+func TestFeeBumpLegacy(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_gasPrice").
+		Run(func(args mock.Arguments) {
+			*(args[1].(*ethtypes.HexInteger)) = *ethtypes.NewHexInteger64(150)
+		}).
+		Return(nil)
+
+	res, err := c.FeeBump(ctx, &FeeBumpRequest{GasPrice: fftypes.JSONAnyPtr(`"100"`)})
+	assert.NoError(t, err)
+	assert.Equal(t, `"150"`, res.GasPrice.String())
+	assert.Equal(t, int64(10), res.PriceBump)
+	assert.True(t, res.MarketRaised)
+	assert.False(t, res.Urgent)
+
+	// Other fields of a gas price object are kept, so it can be submitted unmodified
+	res, err = c.FeeBump(ctx, &FeeBumpRequest{
+		GasPrice: fftypes.JSONAnyPtr(`{"gasPrice": "140", "confirmations": 3}`),
+		Age:      feeBumpAge(time.Hour),
+	})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"gasPrice": "154", "confirmations": 3}`, res.GasPrice.String())
+	assert.False(t, res.MarketRaised)
+	assert.True(t, res.Urgent)
+}
+
+func TestFeeBumpEIP1559(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t, func(conf config.Section) {
+		conf.Set(GasPriceFeeHistory, 4)
+	})
+	defer done()
+	mockFeeBumpFeeHistory(mRPC)
+
+	gasPrice := fftypes.JSONAnyPtr(`{"maxPriorityFeePerGas": "5", "maxFeePerGas": "1000"}`)
+	res, err := c.FeeBump(ctx, &FeeBumpRequest{GasPrice: gasPrice, Age: feeBumpAge(time.Minute)})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"maxPriorityFeePerGas": "6", "maxFeePerGas": "1429"}`, res.GasPrice.String())
+	assert.True(t, res.MarketRaised)
+
+	res, err = c.FeeBump(ctx, &FeeBumpRequest{GasPrice: gasPrice, Age: feeBumpAge(10 * time.Minute)})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"maxPriorityFeePerGas": "10", "maxFeePerGas": "1135"}`, res.GasPrice.String())
+	assert.True(t, res.Urgent)
+
+	// The max fee is never less than the priority fee
+	res, err = c.FeeBump(ctx, &FeeBumpRequest{GasPrice: fftypes.JSONAnyPtr(`{"maxPriorityFeePerGas": "2000", "maxFeePerGas": "1"}`)})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"maxPriorityFeePerGas": "2200", "maxFeePerGas": "2200"}`, res.GasPrice.String())
+}
+
+func TestFeeBumpBlobNoFeeHistory(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_feeHistory", mock.Anything, "latest", mock.Anything).
+		Return(&rpcbackend.RPCError{Message: "the method eth_feeHistory does not exist/is not available"})
+
+	res, err := c.FeeBump(ctx, &FeeBumpRequest{
+		GasPrice: fftypes.JSONAnyPtr(`{"maxPriorityFeePerGas": "5", "maxFeePerGas": "1000", "maxFeePerBlobGas": "30"}`),
+	})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"maxPriorityFeePerGas": "10", "maxFeePerGas": "2000", "maxFeePerBlobGas": "60"}`, res.GasPrice.String())
+	assert.Equal(t, int64(100), res.PriceBump)
+	assert.False(t, res.MarketRaised)
+}
+
+func TestFeeBumpFeeCurrency(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t)
+	defer done()
+
+	// The fee history is in the native currency, so is not queried
+	res, err := c.FeeBump(ctx, &FeeBumpRequest{
+		GasPrice: fftypes.JSONAnyPtr(`{"maxPriorityFeePerGas": "5", "maxFeePerGas": "1000", "feeCurrency": "` + testFeeCurrency + `"}`),
+	})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"maxPriorityFeePerGas": "6", "maxFeePerGas": "1100", "feeCurrency": "`+testFeeCurrency+`"}`, res.GasPrice.String())
+}
+
+func TestFeeBumpErrors(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	_, err := c.FeeBump(ctx, &FeeBumpRequest{})
+	assert.Regexp(t, "FF23015", err)
+
+	_, err = c.FeeBump(ctx, &FeeBumpRequest{GasPrice: fftypes.JSONAnyPtr(`"wrong"`)})
+	assert.Regexp(t, "FF23015", err)
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_gasPrice").Return(&rpcbackend.RPCError{Message: "pop"})
+	_, err = c.FeeBump(ctx, &FeeBumpRequest{GasPrice: fftypes.JSONAnyPtr(`"100"`)})
+	assert.Regexp(t, "pop", err)
+}
+
+func TestFeeBumpConfig(t *testing.T) {
+	config.RootConfigReset()
+	conf := config.RootSection("unittest")
+	InitConfig(conf)
+	gp, err := newGasPolicy(context.Background(), conf, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), gp.priceBump)
+	assert.Equal(t, int64(100), gp.blobPriceBump)
+	assert.Equal(t, 5*time.Minute, gp.urgentAfter)
+
+	conf.Set(FeeBumpBlobPriceBump, -1)
+	_, err = newGasPolicy(context.Background(), conf, nil)
+	assert.Regexp(t, "FF23185", err)
+}
+
+func TestFeeBumpRoute(t *testing.T) {
+	_, c, mRPC, done := newTestConnector(t)
+	defer done()
+	url, close := newTestRouteServer(t, c)
+	defer close()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_gasPrice").
+		Run(func(args mock.Arguments) {
+			*(args[1].(*ethtypes.HexInteger)) = *ethtypes.NewHexInteger64(50)
+		}).
+		Return(nil)
+
+	res, err := http.Post(url+"/gas/feebump", "application/json", strings.NewReader(`{"gasPrice": "100", "age": "30s"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var feeBump FeeBumpResponse
+	err = json.NewDecoder(res.Body).Decode(&feeBump)
+	assert.NoError(t, err)
+	assert.Equal(t, `"110"`, feeBump.GasPrice.String())
+}
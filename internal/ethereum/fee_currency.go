@@ -0,0 +1,74 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-signer/pkg/ethsigner"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+)
+
+// feeCurrencyTx is the eth_sendTransaction and eth_estimateGas payload of a transaction that pays its fees in an
+// ERC-20 fee currency, on chains such as Celo
+type feeCurrencyTx struct {
+	*ethsigner.Transaction
+	FeeCurrency *ethtypes.Address0xHex `json:"feeCurrency"`
+}
+
+// withFeeCurrency returns the JSON/RPC payload of a transaction, which is the transaction itself if it pays its
+// fees in the native currency
+func withFeeCurrency(tx *ethsigner.Transaction, feeCurrency *ethtypes.Address0xHex) interface{} {
+	if feeCurrency == nil {
+		return tx
+	}
+	return &feeCurrencyTx{Transaction: tx, FeeCurrency: feeCurrency}
+}
+
+// feeCurrency returns the fee currency of a submission, which is the feeCurrency of the gas price object if it has
+// one, or otherwise the configured fee currency
+func (c *ethConnector) feeCurrency(ctx context.Context, gasPrice *fftypes.JSONAny) (*ethtypes.Address0xHex, error) {
+	if gasPrice != nil {
+		if s, ok := gasPrice.JSONObjectNowarn().GetStringOk("feeCurrency"); ok && s != "" {
+			feeCurrency, err := ethtypes.NewAddress(s)
+			if err != nil {
+				return nil, i18n.NewError(ctx, msgs.MsgInvalidFeeCurrency, s, err)
+			}
+			return feeCurrency, nil
+		}
+	}
+	return c.gas().feeCurrency, nil
+}
+
+// nodeGasPrice queries the gas price of the node, which is in the configured fee currency if there is one
+func (c *ethConnector) nodeGasPrice(ctx context.Context) (*ethtypes.HexInteger, *rpcbackend.RPCError) {
+	var gasPrice ethtypes.HexInteger
+	var rpcErr *rpcbackend.RPCError
+	if feeCurrency := c.gas().feeCurrency; feeCurrency != nil {
+		rpcErr = c.backend.CallRPC(ctx, &gasPrice, "eth_gasPrice", feeCurrency)
+	} else {
+		rpcErr = c.backend.CallRPC(ctx, &gasPrice, "eth_gasPrice")
+	}
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	return &gasPrice, nil
+}
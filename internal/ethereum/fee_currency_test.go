@@ -0,0 +1,139 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-evmconnect/mocks/rpcbackendmocks"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	testFeeCurrency      = "0x765de816845861e75a25fca122bb6898b8b1282a"
+	testOtherFeeCurrency = "0x2f25deb3848c207fc8e0c34035b3ba7fc157602b"
+)
+
+func newTestFeeCurrencyConnector(t *testing.T) (context.Context, *ethConnector, *rpcbackendmocks.Backend, func()) {
+	return newTestConnector(t, func(conf config.Section) {
+		conf.Set(FeeCurrencyAddress, testFeeCurrency)
+		conf.Set(GasPriceSuggestions, true)
+	})
+}
+
+func TestFeeCurrencyGasPriceEstimate(t *testing.T) {
+	ctx, c, mRPC, done := newTestFeeCurrencyConnector(t)
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_gasPrice", ethtypes.MustNewAddress(testFeeCurrency)).
+		Run(func(args mock.Arguments) {
+			*(args[1].(*ethtypes.HexInteger)) = *ethtypes.NewHexInteger64(12345)
+		}).
+		Return(nil)
+
+	// Suggestions from the fee history are not returned for a fee currency
+	res, _, err := c.GasPriceEstimate(ctx, &ffcapi.GasPriceEstimateRequest{})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"gasPrice": "12345", "feeCurrency": "`+testFeeCurrency+`"}`, res.GasPrice.String())
+	mRPC.AssertExpectations(t)
+}
+
+func TestFeeCurrencySend(t *testing.T) {
+	ctx, c, mRPC, done := newTestFeeCurrencyConnector(t)
+	defer done()
+
+	sent := []string{}
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_sendTransaction", mock.Anything).
+		Run(func(args mock.Arguments) {
+			b, _ := json.Marshal(args[3])
+			sent = append(sent, fftypes.JSONAnyPtrBytes(b).JSONObject().GetString("feeCurrency"))
+			*(args[1].(*ethtypes.HexBytes0xPrefix)) = ethtypes.MustNewHexBytes0xPrefix("0x3e2398ff4a875a8b9f87a6eeaaa41a139a68adeb509731300d4b90d1bdc1c4fc")
+		}).
+		Return(nil)
+
+	var req ffcapi.TransactionSendRequest
+	err := json.Unmarshal([]byte(sampleSendTX), &req)
+	assert.NoError(t, err)
+
+	// The configured fee currency is used by default
+	req.GasPrice = fftypes.JSONAnyPtr(`"12345"`)
+	_, _, err = c.TransactionSend(ctx, &req)
+	assert.NoError(t, err)
+
+	// That of the gas price object overrides it
+	req.GasPrice = fftypes.JSONAnyPtr(`{"gasPrice": "12345", "feeCurrency": "` + testOtherFeeCurrency + `"}`)
+	_, _, err = c.TransactionSend(ctx, &req)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{testFeeCurrency, testOtherFeeCurrency}, sent)
+
+	req.GasPrice = fftypes.JSONAnyPtr(`{"gasPrice": "12345", "feeCurrency": "wrong"}`)
+	_, reason, err := c.TransactionSend(ctx, &req)
+	assert.Regexp(t, "FF23164.*wrong", err)
+	assert.Equal(t, ffcapi.ErrorReasonInvalidInputs, reason)
+}
+
+func TestFeeCurrencyGasEstimate(t *testing.T) {
+	ctx, c, mRPC, done := newTestFeeCurrencyConnector(t)
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_estimateGas", mock.MatchedBy(func(tx *feeCurrencyTx) bool {
+		return tx.FeeCurrency.String() == testFeeCurrency
+	})).
+		Run(func(args mock.Arguments) {
+			*(args[1].(*ethtypes.HexInteger)) = *ethtypes.NewHexInteger64(50000)
+		}).
+		Return(nil)
+
+	res, _, err := c.GasEstimate(ctx, &ffcapi.TransactionInput{
+		TransactionHeaders: ffcapi.TransactionHeaders{From: "0xb480F96c0a3d6E9e9a263e4665a39bFa6c4d01E8", To: "0xe1a078b9e2b145d0a7387f09277c6ae1d9470771"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(75000), res.GasEstimate.Int64())
+}
+
+func TestFeeCurrencyNodeGasPriceFail(t *testing.T) {
+	ctx, c, mRPC, done := newTestFeeCurrencyConnector(t)
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_gasPrice", mock.Anything).
+		Return(&rpcbackend.RPCError{Message: "pop"})
+
+	_, _, err := c.GasPriceEstimate(ctx, &ffcapi.GasPriceEstimateRequest{})
+	assert.Regexp(t, "pop", err)
+}
+
+func TestFeeCurrencyConfig(t *testing.T) {
+	config.RootConfigReset()
+	conf := config.RootSection("unittest")
+	InitConfig(conf)
+	gp, err := newGasPolicy(context.Background(), conf, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, gp.feeCurrency)
+
+	conf.Set(FeeCurrencyAddress, "wrong")
+	_, err = newGasPolicy(context.Background(), conf, nil)
+	assert.Regexp(t, "FF23164", err)
+}
@@ -0,0 +1,404 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+)
+
+// The JSON types of the schemas of FFI parameters
+const (
+	ffiTypeBoolean = "boolean"
+	ffiTypeInteger = "integer"
+	ffiTypeNumber  = "number"
+	ffiTypeString  = "string"
+	ffiTypeArray   = "array"
+	ffiTypeObject  = "object"
+)
+
+// arrayDimensionRegex matches the last dimension of an ABI array type, such as the "[2]" of "uint8[][2]"
+var arrayDimensionRegex = regexp.MustCompile(`\[([0-9]*)\]$`)
+
+// ffiParamDetails are the Ethereum details of an FFI parameter, or of a property of a parameter that is an object
+type ffiParamDetails struct {
+	Type         string `json:"type,omitempty"`
+	InternalType string `json:"internalType,omitempty"`
+	Indexed      bool   `json:"indexed,omitempty"`
+	Index        *int   `json:"index,omitempty"`    // The position of a property in its tuple
+	ByteSize     int    `json:"byteSize,omitempty"` // A hint of the size of an "int", "uint" or "bytes" type, or of an integer or string without a type
+}
+
+// ffiSchema is the JSON schema of an FFI parameter, with the Ethereum details of its ABI type. The details are on
+// the parameter and on the properties of objects, while the items of arrays are described by the details above them.
+type ffiSchema struct {
+	Type        string                `json:"type,omitempty"`
+	OneOf       []ffiSchemaType       `json:"oneOf,omitempty"`
+	Details     *ffiParamDetails      `json:"details,omitempty"`
+	Properties  map[string]*ffiSchema `json:"properties,omitempty"`
+	Items       *ffiSchema            `json:"items,omitempty"`
+	MinItems    *int                  `json:"minItems,omitempty"`
+	MaxItems    *int                  `json:"maxItems,omitempty"`
+	Description string                `json:"description,omitempty"`
+}
+
+type ffiSchemaType struct {
+	Type string `json:"type"`
+}
+
+// jsonType is the type of the schema, or the type other than a string when it has alternatives
+func (s *ffiSchema) jsonType() string {
+	for _, t := range s.OneOf {
+		if t.Type != ffiTypeString {
+			return t.Type
+		}
+	}
+	if s.Type == "" && len(s.OneOf) > 0 {
+		return ffiTypeString
+	}
+	return s.Type
+}
+
+// FFIToABI converts a FireFly Interface (FFI) to an ABI, with a function for each method, an event for each event
+// and an error for each error of the interface
+func (c *ethConnector) FFIToABI(ctx context.Context, ffi *fftypes.FFI) (abi.ABI, error) {
+	a := abi.ABI{}
+	for _, m := range ffi.Methods {
+		inputs, err := ffiParamsToABI(ctx, m.Name, m.Params)
+		if err != nil {
+			return nil, err
+		}
+		outputs, err := ffiParamsToABI(ctx, m.Name, m.Returns)
+		if err != nil {
+			return nil, err
+		}
+		e := &abi.Entry{Type: abi.Function, Name: m.Name, Inputs: inputs, Outputs: outputs}
+		e.StateMutability = abi.StateMutability(m.Details.GetString("stateMutability"))
+		e.Payable = m.Details.GetBool("payable")
+		e.Constant = m.Details.GetBool("constant")
+		a = append(a, e)
+	}
+	for _, ev := range ffi.Events {
+		inputs, err := ffiParamsToABI(ctx, ev.Name, ev.Params)
+		if err != nil {
+			return nil, err
+		}
+		a = append(a, &abi.Entry{Type: abi.Event, Name: ev.Name, Inputs: inputs, Anonymous: ev.Details.GetBool("anonymous")})
+	}
+	for _, er := range ffi.Errors {
+		inputs, err := ffiParamsToABI(ctx, er.Name, er.Params)
+		if err != nil {
+			return nil, err
+		}
+		a = append(a, &abi.Entry{Type: abi.Error, Name: er.Name, Inputs: inputs})
+	}
+	return a, nil
+}
+
+func ffiParamsToABI(ctx context.Context, entryName string, params fftypes.FFIParams) (abi.ParameterArray, error) {
+	parameters := make(abi.ParameterArray, len(params))
+	for i, p := range params {
+		path := entryName + "." + p.Name
+		var s *ffiSchema
+		if p.Schema == nil {
+			return nil, i18n.NewError(ctx, msgs.MsgInvalidFFISchema, path, "missing")
+		}
+		if err := json.Unmarshal(p.Schema.Bytes(), &s); err != nil {
+			return nil, i18n.NewError(ctx, msgs.MsgInvalidFFISchema, path, err)
+		}
+		param, err := ffiSchemaToABIParameter(ctx, path, p.Name, s)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := param.TypeComponentTreeCtx(ctx); err != nil {
+			return nil, i18n.NewError(ctx, msgs.MsgInvalidFFISchema, path, err)
+		}
+		parameters[i] = param
+	}
+	return parameters, nil
+}
+
+// ffiSchemaToABIParameter builds the ABI parameter of a schema, with its type built from the structure of the
+// schema so that nested objects and arrays keep their components and dimensions
+func ffiSchemaToABIParameter(ctx context.Context, path, name string, s *ffiSchema) (*abi.Parameter, error) {
+	details := s.Details
+	if details == nil {
+		details = &ffiParamDetails{}
+	}
+	param := &abi.Parameter{Name: name, InternalType: details.InternalType, Indexed: details.Indexed}
+	var err error
+	param.Type, param.Components, err = ffiSchemaToABIType(ctx, path, s, details)
+	if err != nil {
+		return nil, err
+	}
+	return param, nil
+}
+
+// ffiSchemaToABIType returns the ABI type of a schema, and the components of the tuple within it if any, using the
+// details of the parameter or property it is part of
+func ffiSchemaToABIType(ctx context.Context, path string, s *ffiSchema, details *ffiParamDetails) (string, abi.ParameterArray, error) {
+	jsonType := s.jsonType()
+	switch jsonType {
+	case ffiTypeArray:
+		if s.Items == nil {
+			return "", nil, i18n.NewError(ctx, msgs.MsgInvalidFFISchema, path, "no items")
+		}
+		// The last dimension of the type in the details applies to this array, and the rest to its items
+		dimension := "[]"
+		itemDetails := details
+		if match := arrayDimensionRegex.FindStringSubmatch(details.Type); match != nil {
+			dimension = match[0]
+			d := *details
+			d.Type = details.Type[:len(details.Type)-len(match[0])]
+			itemDetails = &d
+		} else if s.MinItems != nil && s.MaxItems != nil && *s.MinItems == *s.MaxItems {
+			dimension = fmt.Sprintf("[%d]", *s.MinItems)
+		}
+		if s.Items.Details != nil {
+			itemDetails = s.Items.Details
+		}
+		itemType, components, err := ffiSchemaToABIType(ctx, path+"[]", s.Items, itemDetails)
+		if err != nil {
+			return "", nil, err
+		}
+		return itemType + dimension, components, nil
+	case ffiTypeObject:
+		components, err := ffiPropertiesToABI(ctx, path, s.Properties)
+		return "tuple", components, err
+	default:
+		abiType := ffiElementaryType(jsonType, details)
+		tc, err := (&abi.Parameter{Type: abiType}).TypeComponentTreeCtx(ctx)
+		if err != nil {
+			return "", nil, i18n.NewError(ctx, msgs.MsgInvalidFFISchema, path, err)
+		}
+		if tc.ComponentType() != abi.ElementaryComponent || !ffiTypeMatches(jsonType, tc) {
+			return "", nil, i18n.NewError(ctx, msgs.MsgFFITypeMismatch, path, jsonType, abiType)
+		}
+		return abiType, nil, nil
+	}
+}
+
+// ffiElementaryType is the type in the details, sized by any byte size hint, or the type implied by the JSON type
+// when there is no type in the details
+func ffiElementaryType(jsonType string, details *ffiParamDetails) string {
+	abiType := details.Type
+	if abiType == "" {
+		switch jsonType {
+		case ffiTypeBoolean:
+			return "bool"
+		case ffiTypeInteger:
+			abiType = "uint"
+		case ffiTypeString:
+			if details.ByteSize == 0 {
+				return "string"
+			}
+			abiType = "bytes"
+		}
+	}
+	if details.ByteSize > 0 {
+		switch abiType {
+		case "int", "uint":
+			return abiType + strconv.Itoa(details.ByteSize*8)
+		case "bytes":
+			return abiType + strconv.Itoa(details.ByteSize)
+		}
+	}
+	return abiType
+}
+
+// ffiTypeMatches checks the JSON type of a schema can hold the values of an elementary ABI type
+func ffiTypeMatches(jsonType string, tc abi.TypeComponent) bool {
+	switch jsonType {
+	case ffiTypeBoolean:
+		return tc.ElementaryType().JSONEncodingType() == abi.JSONEncodingTypeBool
+	case ffiTypeInteger:
+		return tc.ElementaryType().JSONEncodingType() == abi.JSONEncodingTypeInteger
+	case ffiTypeNumber:
+		return tc.ElementaryType().JSONEncodingType() == abi.JSONEncodingTypeFloat
+	default:
+		// Strings are valid for all elementary types
+		return jsonType == ffiTypeString
+	}
+}
+
+// ffiPropertiesToABI returns the components of a tuple, in the order of the index in the details of each property
+func ffiPropertiesToABI(ctx context.Context, path string, properties map[string]*ffiSchema) (abi.ParameterArray, error) {
+	components := make(abi.ParameterArray, len(properties))
+	for name, ps := range properties {
+		if ps.Details == nil || ps.Details.Index == nil || *ps.Details.Index < 0 || *ps.Details.Index >= len(properties) || components[*ps.Details.Index] != nil {
+			return nil, i18n.NewError(ctx, msgs.MsgInvalidFFIPropertyIndex, path+"."+name, len(properties)-1)
+		}
+		component, err := ffiSchemaToABIParameter(ctx, path+"."+name, name, ps)
+		if err != nil {
+			return nil, err
+		}
+		component.Indexed = false
+		components[*ps.Details.Index] = component
+	}
+	return components, nil
+}
+
+// ABIToFFI generates a FireFly Interface (FFI) from an ABI, supplied as the input of the request either directly or as
+// the "abi" of the output of a compiler, inferring the schema of each parameter from its ABI type
+func (c *ethConnector) ABIToFFI(ctx context.Context, req *fftypes.FFIGenerationRequest) (*fftypes.FFI, error) {
+	if req.Input == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidABI, "missing")
+	}
+	var a abi.ABI
+	var err error
+	if input := bytes.TrimSpace(req.Input.Bytes()); len(input) > 0 && input[0] == '{' {
+		var compiled struct {
+			ABI abi.ABI `json:"abi"`
+		}
+		err = json.Unmarshal(input, &compiled)
+		a = compiled.ABI
+	} else {
+		err = json.Unmarshal(input, &a)
+	}
+	if err != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidABI, err)
+	}
+
+	ffi := &fftypes.FFI{
+		Namespace:   req.Namespace,
+		Name:        req.Name,
+		Description: req.Description,
+		Version:     req.Version,
+		Methods:     []*fftypes.FFIMethod{},
+		Events:      []*fftypes.FFIEvent{},
+		Errors:      []*fftypes.FFIError{},
+	}
+	// Constructors, fallback and receive functions cannot be invoked by name, so are not part of the interface
+	for _, e := range a {
+		params, err := abiToFFIParams(ctx, e.Inputs)
+		if err != nil {
+			return nil, i18n.NewError(ctx, msgs.MsgInvalidABI, err)
+		}
+		switch e.Type {
+		case abi.Function:
+			returns, err := abiToFFIParams(ctx, e.Outputs)
+			if err != nil {
+				return nil, i18n.NewError(ctx, msgs.MsgInvalidABI, err)
+			}
+			details := fftypes.JSONObject{}
+			if e.StateMutability != "" {
+				details["stateMutability"] = string(e.StateMutability)
+			}
+			if e.Payable {
+				details["payable"] = true
+			}
+			if e.Constant {
+				details["constant"] = true
+			}
+			ffi.Methods = append(ffi.Methods, &fftypes.FFIMethod{Name: e.Name, Params: params, Returns: returns, Details: details})
+		case abi.Event:
+			details := fftypes.JSONObject{}
+			if e.Anonymous {
+				details["anonymous"] = true
+			}
+			ffi.Events = append(ffi.Events, &fftypes.FFIEvent{
+				Signature:          e.String(),
+				FFIEventDefinition: fftypes.FFIEventDefinition{Name: e.Name, Params: params, Details: details},
+			})
+		case abi.Error:
+			ffi.Errors = append(ffi.Errors, &fftypes.FFIError{
+				Signature:          e.String(),
+				FFIErrorDefinition: fftypes.FFIErrorDefinition{Name: e.Name, Params: params},
+			})
+		}
+	}
+	return ffi, nil
+}
+
+// abiToFFIParams converts the parameters of an ABI entry to FFI parameters, with the ABI type of each in the details
+// of its schema
+func abiToFFIParams(ctx context.Context, params abi.ParameterArray) (fftypes.FFIParams, error) {
+	ffiParams := make(fftypes.FFIParams, len(params))
+	for i, p := range params {
+		tc, err := p.TypeComponentTreeCtx(ctx)
+		if err != nil {
+			return nil, err
+		}
+		s := abiToFFISchema(ctx, tc)
+		b, _ := json.Marshal(s)
+		ffiParams[i] = &fftypes.FFIParam{Name: p.Name, Schema: fftypes.JSONAnyPtrBytes(b)}
+	}
+	return ffiParams, nil
+}
+
+// abiToFFISchema is the schema of a parameter or tuple component. Arrays are described by the details above them, so
+// the items of an array only have details for the properties of the tuples within them.
+func abiToFFISchema(ctx context.Context, tc abi.TypeComponent) *ffiSchema {
+	s := abiToFFIValueSchema(ctx, tc)
+	s.Details = &ffiParamDetails{
+		Type:         tc.Parameter().Type,
+		InternalType: tc.Parameter().InternalType,
+		Indexed:      tc.Parameter().Indexed,
+	}
+	return s
+}
+
+func abiToFFIValueSchema(ctx context.Context, tc abi.TypeComponent) *ffiSchema {
+	s := &ffiSchema{}
+	switch tc.ComponentType() {
+	case abi.FixedArrayComponent, abi.DynamicArrayComponent:
+		s.Type = ffiTypeArray
+		s.Items = abiToFFIValueSchema(ctx, tc.ArrayChild())
+		if tc.ComponentType() == abi.FixedArrayComponent {
+			length := tc.FixedArrayLen()
+			s.MinItems = &length
+			s.MaxItems = &length
+		}
+	case abi.TupleComponent:
+		s.Type = ffiTypeObject
+		s.Properties = make(map[string]*ffiSchema, len(tc.TupleChildren()))
+		for i, child := range tc.TupleChildren() {
+			cs := abiToFFISchema(ctx, child)
+			index := i
+			cs.Details.Index = &index
+			cs.Details.Indexed = false
+			s.Properties[child.KeyName()] = cs
+		}
+	default:
+		switch tc.ElementaryType().JSONEncodingType() {
+		case abi.JSONEncodingTypeInteger:
+			s.OneOf = []ffiSchemaType{{Type: ffiTypeString}, {Type: ffiTypeInteger}}
+			s.Description = i18n.Expand(ctx, msgs.FFIIntegerDescription)
+		case abi.JSONEncodingTypeFloat:
+			s.OneOf = []ffiSchemaType{{Type: ffiTypeString}, {Type: ffiTypeNumber}}
+			s.Description = i18n.Expand(ctx, msgs.FFIFloatDescription)
+		case abi.JSONEncodingTypeBool:
+			s.OneOf = []ffiSchemaType{{Type: ffiTypeString}, {Type: ffiTypeBoolean}}
+			s.Description = i18n.Expand(ctx, msgs.FFIBoolDescription)
+		case abi.JSONEncodingTypeBytes:
+			s.Type = ffiTypeString
+			s.Description = i18n.Expand(ctx, msgs.FFIHexDescription)
+		default:
+			s.Type = ffiTypeString
+		}
+	}
+	return s
+}
@@ -0,0 +1,282 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/stretchr/testify/assert"
+)
+
+const testNestedABI = `[
+	{
+		"type": "function",
+		"name": "settle",
+		"stateMutability": "payable",
+		"payable": true,
+		"inputs": [
+			{"name": "orders", "type": "tuple[2][]", "internalType": "struct Market.Order[2][]", "components": [
+				{"name": "id", "type": "uint64", "internalType": "uint64"},
+				{"name": "ref", "type": "bytes32", "internalType": "bytes32"},
+				{"name": "legs", "type": "tuple[]", "internalType": "struct Market.Leg[]", "components": [
+					{"name": "price", "type": "int128", "internalType": "int128"},
+					{"name": "ratio", "type": "fixed128x18", "internalType": "fixed128x18"},
+					{"name": "parties", "type": "address[3]", "internalType": "address[3]"}
+				]},
+				{"name": "meta", "type": "tuple", "internalType": "struct Market.Meta", "components": [
+					{"name": "tags", "type": "string[]", "internalType": "string[]"},
+					{"name": "active", "type": "bool", "internalType": "bool"}
+				]}
+			]},
+			{"name": "grid", "type": "uint8[2][][3]", "internalType": "uint8[2][][3]"},
+			{"name": "data", "type": "bytes", "internalType": "bytes"}
+		],
+		"outputs": [
+			{"name": "", "type": "bytes4", "internalType": "bytes4"}
+		]
+	},
+	{
+		"type": "event",
+		"name": "Settled",
+		"anonymous": true,
+		"inputs": [
+			{"name": "ref", "type": "bytes32", "indexed": true},
+			{"name": "legs", "type": "tuple[]", "components": [
+				{"name": "price", "type": "int128"},
+				{"name": "ratio", "type": "fixed128x18"},
+				{"name": "parties", "type": "address[3]"}
+			]}
+		]
+	},
+	{
+		"type": "error",
+		"name": "Rejected",
+		"inputs": [
+			{"name": "reason", "type": "string"}
+		]
+	}
+]`
+
+func TestFFIToABIRoundTripNestedTypes(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t)
+	defer done()
+
+	var a abi.ABI
+	err := json.Unmarshal([]byte(testNestedABI), &a)
+	assert.NoError(t, err)
+	ffi, err := c.ABIToFFI(ctx, &fftypes.FFIGenerationRequest{Input: fftypes.JSONAnyPtr(testNestedABI)})
+	assert.NoError(t, err)
+
+	// Arrays of objects keep the details of each property, and fixed arrays their length
+	orders := fftypes.JSONObject{}
+	err = json.Unmarshal(ffi.Methods[0].Params[0].Schema.Bytes(), &orders)
+	assert.NoError(t, err)
+	assert.Equal(t, "tuple[2][]", orders.GetObject("details").GetString("type"))
+	inner := orders.GetObject("items")
+	assert.Equal(t, float64(2), inner["minItems"])
+	assert.Equal(t, float64(2), inner["maxItems"])
+	legs := inner.GetObject("items").GetObject("properties").GetObject("legs")
+	assert.Equal(t, "tuple[]", legs.GetObject("details").GetString("type"))
+	assert.Equal(t, float64(2), legs.GetObject("details")["index"])
+	parties := legs.GetObject("items").GetObject("properties").GetObject("parties")
+	assert.Equal(t, "address[3]", parties.GetObject("details").GetString("type"))
+	assert.Equal(t, "string", parties.GetObject("items").GetString("type"))
+
+	res, err := c.FFIToABI(ctx, ffi)
+	assert.NoError(t, err)
+	expected, _ := json.Marshal(a)
+	actual, _ := json.Marshal(res)
+	assert.JSONEq(t, string(expected), string(actual))
+}
+
+func TestFFIToABIHints(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t)
+	defer done()
+
+	ffi := &fftypes.FFI{
+		Methods: []*fftypes.FFIMethod{{
+			Name: "store",
+			Params: fftypes.FFIParams{
+				{Name: "small", Schema: fftypes.JSONAnyPtr(`{"type":"integer","details":{"type":"int","byteSize":2}}`)},
+				{Name: "count", Schema: fftypes.JSONAnyPtr(`{"type":"integer"}`)},
+				{Name: "hash", Schema: fftypes.JSONAnyPtr(`{"type":"string","details":{"type":"bytes","byteSize":32}}`)},
+				{Name: "salt", Schema: fftypes.JSONAnyPtr(`{"type":"string","details":{"byteSize":4}}`)},
+				{Name: "label", Schema: fftypes.JSONAnyPtr(`{"type":"string"}`)},
+				{Name: "note", Schema: fftypes.JSONAnyPtr(`{"oneOf":[{"type":"string"}],"details":{"type":"string"}}`)},
+				{Name: "flags", Schema: fftypes.JSONAnyPtr(`{"type":"array","minItems":4,"maxItems":4,"items":{"type":"boolean"}}`)},
+				{Name: "entries", Schema: fftypes.JSONAnyPtr(`{"type":"array","items":{"type":"object","properties":{
+					"key": {"type":"string","details":{"type":"bytes","byteSize":8,"index":0}},
+					"amounts": {"type":"array","details":{"index":1},"items":{"type":"integer","details":{"type":"uint","byteSize":16}}}
+				}}}`)},
+			},
+		}},
+	}
+	res, err := c.FFIToABI(ctx, ffi)
+	assert.NoError(t, err)
+	assert.Equal(t, "store(int16,uint256,bytes32,bytes4,string,string,bool[4],(bytes8,uint128[])[])", res[0].String())
+	assert.Equal(t, "amounts", res[0].Inputs[7].Components[1].Name)
+}
+
+func TestFFIToABIErrors(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t)
+	defer done()
+
+	for schema, errRegex := range map[string]string{
+		``: "FF23201.*m.p.*missing",
+		`{"type":"array","items":{"type":"boolean"},"details":{"type":"bool[99999999999999999999]"}}`: "FF23201.*m.p",
+		`!json`:            "FF23201.*m.p",
+		`{"type":"array"}`: "FF23201.*m.p.*no items",
+		`{"type":"string","details":{"type":"wrong"}}`:                                                                             "FF23201.*m.p",
+		`{"type":"integer","details":{"type":"bool"}}`:                                                                             "FF23202.*m.p.*integer.*bool",
+		`{"type":"boolean","details":{"type":"uint8"}}`:                                                                            "FF23202.*m.p.*boolean",
+		`{"type":"number","details":{"type":"uint8"}}`:                                                                             "FF23202.*m.p.*number",
+		`{"type":"string","details":{"type":"uint8[]"}}`:                                                                           "FF23202.*m.p",
+		`{"type":"array","items":{"type":"integer","details":{"type":"string"}}}`:                                                  "FF23202.*m.p\\[\\]",
+		`{"type":"object","properties":{"a":{"type":"string"}}}`:                                                                   "FF23203.*m.p.a.*0",
+		`{"type":"object","properties":{"a":{"type":"string","details":{"index":1}}}}`:                                             "FF23203.*m.p.a",
+		`{"type":"object","properties":{"a":{"type":"string","details":{"index":0}},"b":{"type":"string","details":{"index":0}}}}`: "FF23203",
+		`{"type":"object","properties":{"a":{"type":"foo","details":{"index":0}}}}`:                                                "FF23201.*m.p.a",
+	} {
+		param := &fftypes.FFIParam{Name: "p"}
+		if schema != "" {
+			param.Schema = fftypes.JSONAnyPtr(schema)
+		}
+		for _, ffi := range []*fftypes.FFI{
+			{Methods: []*fftypes.FFIMethod{{Name: "m", Params: fftypes.FFIParams{param}}}},
+			{Methods: []*fftypes.FFIMethod{{Name: "m", Returns: fftypes.FFIParams{param}}}},
+			{Events: []*fftypes.FFIEvent{{FFIEventDefinition: fftypes.FFIEventDefinition{Name: "m", Params: fftypes.FFIParams{param}}}}},
+			{Errors: []*fftypes.FFIError{{FFIErrorDefinition: fftypes.FFIErrorDefinition{Name: "m", Params: fftypes.FFIParams{param}}}}},
+		} {
+			_, err := c.FFIToABI(ctx, ffi)
+			assert.Regexp(t, errRegex, err, schema)
+		}
+	}
+
+	_, err := abiToFFIParams(ctx, abi.ParameterArray{{Type: "wrong"}})
+	assert.Regexp(t, "FF22025", err)
+}
+
+func TestFFIToABIRoute(t *testing.T) {
+	_, c, _, done := newTestConnector(t)
+	defer done()
+	url, close := newTestRouteServer(t, c)
+	defer close()
+
+	res, err := http.Post(url+"/ffi/abi", "application/json", strings.NewReader(`{
+		"name": "market",
+		"version": "v1",
+		"errors": [{"name": "Rejected", "params": [{"name": "reason", "schema": {"type": "string", "details": {"type": "string"}}}]}]
+	}`))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var a abi.ABI
+	err = json.NewDecoder(res.Body).Decode(&a)
+	assert.NoError(t, err)
+	assert.Equal(t, "Rejected(string)", a[0].String())
+
+	res, err = http.Post(url+"/ffi/abi", "application/json", strings.NewReader(`{"methods": [{"name": "m", "params": [{"name": "p", "schema": {"type": "array"}}]}]}`))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+}
+
+func TestABIToFFI(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t)
+	defer done()
+
+	ffi, err := c.ABIToFFI(ctx, &fftypes.FFIGenerationRequest{
+		Namespace:   "ns1",
+		Name:        "erc20",
+		Description: "A token",
+		Version:     "v1",
+		Input: fftypes.JSONAnyPtr(` {"abi": [
+			{"type": "constructor", "inputs": [{"name": "supply", "type": "uint256"}]},
+			{"type": "function", "name": "totalSupply", "stateMutability": "view", "constant": true, "outputs": [{"name": "", "type": "uint256"}]},
+			{"type": "function", "name": "transfer", "inputs": [{"name": "to", "type": "address"}, {"name": "value", "type": "uint256"}], "outputs": [{"name": "", "type": "bool"}]},
+			{"type": "event", "name": "Transfer", "inputs": [{"name": "from", "type": "address", "indexed": true}, {"name": "to", "type": "address", "indexed": true}, {"name": "value", "type": "uint256"}]},
+			{"type": "error", "name": "Insufficient", "inputs": [{"name": "needed", "type": "uint256"}]},
+			{"type": "receive", "stateMutability": "payable"}
+		], "bytecode": "0x00"}`),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "ns1", ffi.Namespace)
+	assert.Equal(t, "erc20", ffi.Name)
+	assert.Equal(t, "A token", ffi.Description)
+	assert.Equal(t, "v1", ffi.Version)
+	assert.Len(t, ffi.Methods, 2)
+	assert.Equal(t, fftypes.JSONObject{"stateMutability": "view", "constant": true}, ffi.Methods[0].Details)
+	assert.Empty(t, ffi.Methods[1].Details)
+	assert.JSONEq(t, `{"type":"string","details":{"type":"address"},"description":"A hex encoded set of bytes, with an optional '0x' prefix"}`, ffi.Methods[1].Params[0].Schema.String())
+	assert.JSONEq(t, `{"oneOf":[{"type":"string"},{"type":"integer"}],"details":{"type":"uint256"},"description":"An integer. You are recommended to use a JSON string. A JSON number can be used for values up to the safe maximum."}`, ffi.Methods[1].Params[1].Schema.String())
+	assert.JSONEq(t, `{"oneOf":[{"type":"string"},{"type":"boolean"}],"details":{"type":"bool"},"description":"A boolean. You can use a boolean or a string true/false as input"}`, ffi.Methods[1].Returns[0].Schema.String())
+	assert.Len(t, ffi.Events, 1)
+	assert.Equal(t, "Transfer(address,address,uint256)", ffi.Events[0].Signature)
+	assert.JSONEq(t, `{"type":"string","details":{"type":"address","indexed":true},"description":"A hex encoded set of bytes, with an optional '0x' prefix"}`, ffi.Events[0].Params[0].Schema.String())
+	assert.Len(t, ffi.Errors, 1)
+	assert.Equal(t, "Insufficient(uint256)", ffi.Errors[0].Signature)
+
+	// The FFI converts back to the same functions, events and errors
+	a, err := c.FFIToABI(ctx, ffi)
+	assert.NoError(t, err)
+	assert.Len(t, a, 4)
+	assert.Equal(t, "totalSupply()", a[0].String())
+	assert.Equal(t, abi.StateMutability("view"), a[0].StateMutability)
+	assert.Equal(t, "Transfer(address,address,uint256)", a[2].String())
+	assert.True(t, a[2].Inputs[1].Indexed)
+}
+
+func TestABIToFFIErrors(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t)
+	defer done()
+
+	_, err := c.ABIToFFI(ctx, &fftypes.FFIGenerationRequest{})
+	assert.Regexp(t, "FF23066.*missing", err)
+
+	for _, input := range []string{
+		`!json`,
+		`{"abi": false}`,
+		`[{"type": "function", "name": "f", "inputs": [{"type": "wrong"}]}]`,
+		`[{"type": "function", "name": "f", "outputs": [{"type": "wrong"}]}]`,
+	} {
+		_, err = c.ABIToFFI(ctx, &fftypes.FFIGenerationRequest{Input: fftypes.JSONAnyPtr(input)})
+		assert.Regexp(t, "FF23066", err, input)
+	}
+}
+
+func TestABIToFFIRoute(t *testing.T) {
+	_, c, _, done := newTestConnector(t)
+	defer done()
+	url, close := newTestRouteServer(t, c)
+	defer close()
+
+	res, err := http.Post(url+"/abi/ffi", "application/json", strings.NewReader(`{"name": "market", "version": "v1", "input": `+testNestedABI+`}`))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var ffi fftypes.FFI
+	err = json.NewDecoder(res.Body).Decode(&ffi)
+	assert.NoError(t, err)
+	assert.Equal(t, "settle", ffi.Methods[0].Name)
+	assert.Equal(t, "Settled", ffi.Events[0].Name)
+
+	res, err = http.Post(url+"/abi/ffi", "application/json", strings.NewReader(`{"name": "market", "version": "v1", "input": "wrong"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+}
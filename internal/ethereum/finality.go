@@ -0,0 +1,163 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-signer/pkg/ethsigner"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+)
+
+// The chain profiles of zkEVM rollups, on which a block is only final once it is proven on L1
+const (
+	ChainProfileLinea        = "linea"
+	ChainProfileScroll       = "scroll"
+	ChainProfilePolygonZkEVM = "polygonZkEVM"
+	ChainProfileZkSync       = "zkSync"
+)
+
+// finalityL1Methods are the view functions of the rollup contract on L1 that return the last finalized L2 block, by
+// profile. The rollup contracts of the other profiles track batches rather than blocks, so need a method to be set to
+// use an L1 endpoint, and otherwise use the finalized block tag of the L2 node, which they resolve from L1.
+var finalityL1Methods = map[string]string{
+	ChainProfileLinea:        "currentL2BlockNumber()",
+	ChainProfileScroll:       "",
+	ChainProfilePolygonZkEVM: "",
+	ChainProfileZkSync:       "",
+}
+
+// finalityTracker holds back receipts and events until their block is finalized, caching the finalized block
+type finalityTracker struct {
+	l1Backend      rpcbackend.Backend // nil to use the finalized block tag of the L2 node
+	rollupContract *ethtypes.Address0xHex
+	l1CallData     ethtypes.HexBytes0xPrefix
+	cacheTTL       time.Duration
+
+	mux       sync.Mutex
+	finalized int64
+	fetched   time.Time
+}
+
+func newFinalityTracker(ctx context.Context, conf config.Section, clientOpts rpcClientOptions, costs *costTracker) (*finalityTracker, error) {
+	if !conf.GetBool(FinalityEnabled) {
+		return nil, nil
+	}
+	profile := conf.GetString(FinalityProfile)
+	method, ok := finalityL1Methods[profile]
+	if !ok && profile != "" {
+		profiles := make([]string, 0, len(finalityL1Methods))
+		for p := range finalityL1Methods {
+			profiles = append(profiles, p)
+		}
+		sort.Strings(profiles)
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidChainProfile, profile, profiles)
+	}
+	ft := &finalityTracker{cacheTTL: conf.GetDuration(FinalityCacheTTL)}
+
+	l1Conf := conf.SubSection(FinalityL1Config)
+	if l1Conf.GetString(ffresty.HTTPConfigURL) == "" {
+		return ft, nil
+	}
+	if m := l1Conf.GetString(FinalityL1Method); m != "" {
+		method = m
+	}
+	rollupContract := l1Conf.GetString(FinalityL1RollupContract)
+	address, err := ethtypes.NewAddress(rollupContract)
+	if err != nil || method == "" {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidFinalityL1Config, rollupContract, profile)
+	}
+	l1Client, err := newRPCClient(ctx, l1Conf, clientOpts)
+	if err != nil {
+		return nil, err
+	}
+	ft.l1Backend = newManagedBackend(l1Client, newRPCScheduler(l1Conf, clientOpts)).withCosts(costs, "l1")
+	ft.rollupContract = address
+	ft.l1CallData = keccak256([]byte(method))[0:4]
+	return ft, nil
+}
+
+// finalizedBlock returns the latest finalized block, from the rollup contract at the finalized block of L1 when an L1
+// endpoint is configured, otherwise from the finalized block tag of the node
+func (c *ethConnector) finalizedBlock(ctx context.Context) (int64, error) {
+	ft := c.finality
+	ft.mux.Lock()
+	defer ft.mux.Unlock()
+	if !ft.fetched.IsZero() && time.Since(ft.fetched) < ft.cacheTTL {
+		return ft.finalized, nil
+	}
+
+	var finalized *big.Int
+	if ft.l1Backend != nil {
+		var result ethtypes.HexBytes0xPrefix
+		if rpcErr := ft.l1Backend.CallRPC(ctx, &result, "eth_call", &ethsigner.Transaction{To: ft.rollupContract, Data: ft.l1CallData}, "finalized"); rpcErr != nil {
+			return 0, rpcErr.Error()
+		}
+		if len(result) != 32 {
+			return 0, i18n.NewError(ctx, msgs.MsgInvalidFinalizedBlockResult, result)
+		}
+		finalized = new(big.Int).SetBytes(result)
+	} else {
+		var header *BlockHeader
+		if rpcErr := c.readBackend().CallRPC(ctx, &header, "eth_getBlockByNumber", "finalized", false); rpcErr != nil {
+			return 0, rpcErr.Error()
+		}
+		if header == nil || header.Number == nil {
+			return 0, i18n.NewError(ctx, msgs.MsgFinalizedBlockNotAvailable)
+		}
+		finalized = header.Number.BigInt()
+	}
+	ft.finalized, ft.fetched = finalized.Int64(), time.Now()
+	return ft.finalized, nil
+}
+
+// checkReceiptFinality returns a receipt as not found until its block is finalized
+func (c *ethConnector) checkReceiptFinality(ctx context.Context, txHash string, receipt *txReceiptJSONRPC) (ffcapi.ErrorReason, error) {
+	if c.finality == nil || receipt.BlockNumber == nil {
+		return "", nil
+	}
+	finalized, err := c.finalizedBlock(ctx)
+	if err != nil {
+		return "", err
+	}
+	if block := receipt.BlockNumber.BigInt().Int64(); block > finalized {
+		return ffcapi.ErrorReasonNotFound, i18n.NewError(ctx, msgs.MsgReceiptAwaitingFinality, txHash, block, finalized)
+	}
+	return "", nil
+}
+
+// finalizedHead caps the head of the chain that an event stream delivers events up to at the finalized block
+func (es *eventStream) finalizedHead(chainHeadBlock int64) (int64, error) {
+	if es.c.finality == nil {
+		return chainHeadBlock, nil
+	}
+	finalized, err := es.c.finalizedBlock(es.ctx)
+	if err != nil {
+		return 0, err
+	}
+	return min(chainHeadBlock, finalized), nil
+}
@@ -0,0 +1,251 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-evmconnect/mocks/rpcbackendmocks"
+	"github.com/hyperledger/firefly-signer/pkg/ethsigner"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func enableFinality(conf config.Section) {
+	conf.Set(FinalityEnabled, true)
+	conf.Set(FinalityCacheTTL, 0)
+}
+
+func mockFinalizedBlock(mRPC *rpcbackendmocks.Backend, n int64) *mock.Call {
+	return mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", "finalized", false).
+		Run(func(args mock.Arguments) {
+			*(args[1].(**BlockHeader)) = &BlockHeader{Number: ethtypes.NewHexInteger64(n)}
+		}).
+		Return(nil)
+}
+
+func TestFinalityConfig(t *testing.T) {
+	ctx := context.Background()
+	config.RootConfigReset()
+	conf := config.RootSection("unittest")
+	InitConfig(conf)
+
+	ft, err := newFinalityTracker(ctx, conf, rpcClientOptions{}, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, ft)
+
+	conf.Set(FinalityEnabled, true)
+	conf.Set(FinalityProfile, "wrong")
+	_, err = newFinalityTracker(ctx, conf, rpcClientOptions{}, nil)
+	assert.Regexp(t, "FF23194.*linea polygonZkEVM scroll zkSync", err)
+
+	// The finalized block tag of the L2 node is used without an L1 endpoint
+	conf.Set(FinalityProfile, ChainProfileScroll)
+	ft, err = newFinalityTracker(ctx, conf, rpcClientOptions{}, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, ft.l1Backend)
+
+	l1Conf := conf.SubSection(FinalityL1Config)
+	l1Conf.Set(ffresty.HTTPConfigURL, "http://localhost:8546")
+	l1Conf.Set(FinalityL1RollupContract, "0xd19d4B5d358258f05D7B411E21A1460D11B0876F")
+	_, err = newFinalityTracker(ctx, conf, rpcClientOptions{}, nil)
+	assert.Regexp(t, "FF23195.*scroll", err)
+
+	conf.Set(FinalityProfile, ChainProfileLinea)
+	ft, err = newFinalityTracker(ctx, conf, rpcClientOptions{}, nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, ft.l1Backend)
+	assert.Equal(t, ethtypes.HexBytes0xPrefix(keccak256([]byte("currentL2BlockNumber()"))[0:4]), ft.l1CallData)
+
+	l1Conf.Set(FinalityL1Method, "lastFinalizedBlock()")
+	ft, err = newFinalityTracker(ctx, conf, rpcClientOptions{}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, ethtypes.HexBytes0xPrefix(keccak256([]byte("lastFinalizedBlock()"))[0:4]), ft.l1CallData)
+
+	l1Conf.Set(ffresty.HTTPConfigProxyURL, "ftp://proxy.example.com")
+	_, err = newFinalityTracker(ctx, conf, rpcClientOptions{}, nil)
+	assert.Regexp(t, "FF23182", err)
+
+	l1Conf.Set(FinalityL1RollupContract, "wrong")
+	_, err = newFinalityTracker(ctx, conf, rpcClientOptions{}, nil)
+	assert.Regexp(t, "FF23195", err)
+
+	conf.Set(ffresty.HTTPConfigURL, "http://localhost:8545")
+	_, err = NewEthereumConnector(ctx, conf)
+	assert.Regexp(t, "FF23195", err)
+}
+
+func TestFinalizedBlockTag(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t, enableFinality)
+	defer done()
+	c.finality.cacheTTL = time.Hour
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", "finalized", false).
+		Return(&rpcbackend.RPCError{Message: "pop"}).Once()
+	_, err := c.finalizedBlock(ctx)
+	assert.Regexp(t, "pop", err)
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", "finalized", false).
+		Return(nil).Once()
+	_, err = c.finalizedBlock(ctx)
+	assert.Regexp(t, "FF23196", err)
+
+	// The finalized block is cached
+	mockFinalizedBlock(mRPC, 1000).Once()
+	finalized, err := c.finalizedBlock(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1000), finalized)
+	finalized, err = c.finalizedBlock(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1000), finalized)
+}
+
+func TestFinalizedBlockL1(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t, enableFinality)
+	defer done()
+	mL1 := &rpcbackendmocks.Backend{}
+	c.finality.l1Backend = mL1
+	c.finality.rollupContract = ethtypes.MustNewAddress("0xd19d4B5d358258f05D7B411E21A1460D11B0876F")
+	c.finality.l1CallData = keccak256([]byte("currentL2BlockNumber()"))[0:4]
+
+	l1Call := func(result string, rpcErr *rpcbackend.RPCError) {
+		mL1.On("CallRPC", mock.Anything, mock.Anything, "eth_call", mock.MatchedBy(func(tx *ethsigner.Transaction) bool {
+			return tx.To.String() == "0xd19d4b5d358258f05d7b411e21a1460d11b0876f" && tx.Data.String() == "0x695378f5"
+		}), "finalized").
+			Run(func(args mock.Arguments) {
+				*(args[1].(*ethtypes.HexBytes0xPrefix)) = ethtypes.MustNewHexBytes0xPrefix(result)
+			}).
+			Return(rpcErr).Once()
+	}
+	l1Call("0x", &rpcbackend.RPCError{Message: "pop"})
+	_, err := c.finalizedBlock(ctx)
+	assert.Regexp(t, "pop", err)
+
+	l1Call("0x01", nil)
+	_, err = c.finalizedBlock(ctx)
+	assert.Regexp(t, "FF23197", err)
+
+	l1Call("0x0000000000000000000000000000000000000000000000000000000000001234", nil)
+	finalized, err := c.finalizedBlock(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0x1234), finalized)
+	mL1.AssertExpectations(t)
+}
+
+func TestReceiptAwaitingFinality(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t, enableFinality)
+	defer done()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionReceipt", mock.Anything).
+		Run(func(args mock.Arguments) {
+			err := json.Unmarshal([]byte(sampleJSONRPCReceipt), args[1])
+			assert.NoError(t, err)
+		}).
+		Return(nil)
+
+	var req ffcapi.TransactionReceiptRequest
+	err := json.Unmarshal([]byte(sampleGetReceipt), &req)
+	assert.NoError(t, err)
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", "finalized", false).
+		Return(&rpcbackend.RPCError{Message: "pop"}).Once()
+	_, _, err = c.TransactionReceipt(ctx, &req)
+	assert.Regexp(t, "pop", err)
+
+	// The receipt is in block 1977
+	mockFinalizedBlock(mRPC, 1976).Once()
+	_, reason, err := c.TransactionReceipt(ctx, &req)
+	assert.Regexp(t, "FF23198.*1,977.*1,976", err)
+	assert.Equal(t, ffcapi.ErrorReasonNotFound, reason)
+
+	mockFinalizedBlock(mRPC, 1977).Once()
+	res, _, err := c.TransactionReceipt(ctx, &req)
+	assert.NoError(t, err)
+	assert.True(t, res.Success)
+}
+
+func TestStreamLoopFinalizedBlocks(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t, enableFinality, func(conf config.Section) {
+		conf.Set(EventsBlockTimestamps, false)
+	})
+	c.retry.InitialDelay = time.Microsecond
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_blockNumber").Return(nil).Run(func(args mock.Arguments) {
+		*args[1].(*ethtypes.HexInteger) = *ethtypes.NewHexInteger64(testHighBlock + 10)
+	})
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", "finalized", false).
+		Return(&rpcbackend.RPCError{Message: "pop"}).Once()
+	mockFinalizedBlock(mRPC, testHighBlock)
+	transferLog := sampleTransferLog()
+	transferLog.BlockNumber = ethtypes.NewHexInteger64(testHighBlock)
+	// Events are only queried up to the finalized block, with eth_getLogs rather than a filter
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getLogs", mock.MatchedBy(func(f *logFilterJSONRPC) bool {
+		return f.ToBlock.BigInt().Int64() == testHighBlock
+	})).Return(nil).Run(func(args mock.Arguments) {
+		*args[1].(*[]*logJSONRPC) = []*logJSONRPC{transferLog}
+	}).Once()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getLogs", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		*args[1].(*[]*logJSONRPC) = []*logJSONRPC{}
+	}).Maybe()
+
+	_, events, _, done := testEventStreamExistingConnector(t, ctx, done, c, mRPC, testTransferListener())
+	defer done()
+
+	e := <-events
+	assert.Equal(t, uint64(testHighBlock), e.Event.ID.BlockNumber.Uint64())
+	mRPC.AssertNotCalled(t, "CallRPC", mock.Anything, mock.Anything, "eth_newFilter", mock.Anything)
+}
+
+func TestLeadGroupSteadyStateGetLogsFinalityError(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t, enableFinality)
+	defer done()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_blockNumber").Return(nil).Run(func(args mock.Arguments) {
+		*args[1].(*ethtypes.HexInteger) = *ethtypes.NewHexInteger64(testHighBlock)
+	})
+	c.retry.InitialDelay = time.Microsecond
+
+	ctx, cancel := context.WithCancel(ctx)
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", "finalized", false).
+		Return(&rpcbackend.RPCError{Message: "pop"}).Run(func(args mock.Arguments) {
+		cancel() // the retry can race the cancel, and call again
+	})
+	l := &listener{
+		id: fftypes.NewUUID(),
+		config: listenerConfig{
+			options: &listenerOptions{},
+			filters: []*eventFilter{{Topic0: ethtypes.MustNewHexBytes0xPrefix("0x01")}},
+		},
+		hwmBlock: testHighBlock,
+	}
+	es := &eventStream{
+		id:             fftypes.NewUUID(),
+		c:              c,
+		ctx:            ctx,
+		headBlock:      -1,
+		listeners:      map[fftypes.UUID]*listener{*l.id: l},
+		streamLoopDone: make(chan struct{}),
+	}
+	l.es = es
+	assert.True(t, es.leadGroupSteadyStateGetLogs())
+}
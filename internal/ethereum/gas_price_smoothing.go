@@ -0,0 +1,77 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"math/big"
+	"sync"
+
+	"github.com/hyperledger/firefly-common/pkg/log"
+)
+
+// gasPriceSmoother maintains an exponentially weighted moving average (EWMA) of the gas price
+// reported by the node, so that a single outlier (such as a block during a popular NFT mint)
+// does not immediately feed through to the gas price recommended to the policy engine.
+// Each sample is capped at a multiple of the current average before it is blended in.
+type gasPriceSmoother struct {
+	mux      sync.Mutex
+	alpha    *big.Float
+	spikeCap *big.Float
+	average  *big.Float
+}
+
+func newGasPriceSmoother(alpha, spikeCap float64) *gasPriceSmoother {
+	return &gasPriceSmoother{
+		alpha:    big.NewFloat(alpha),
+		spikeCap: big.NewFloat(spikeCap),
+	}
+}
+
+func (s *gasPriceSmoother) currentAverage() *big.Float {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return s.average
+}
+
+// sample blends the latest gas price into the moving average, and returns the smoothed price
+func (s *gasPriceSmoother) sample(ctx context.Context, gasPrice *big.Int) *big.Int {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	price := new(big.Float).SetInt(gasPrice)
+	if s.average == nil {
+		s.average = price
+	} else {
+		limit := new(big.Float).Mul(s.average, s.spikeCap)
+		if price.Cmp(limit) > 0 {
+			log.L(ctx).Warnf("Gas price %s exceeds %s times the smoothed average %s - capped to %s", gasPrice, s.spikeCap.Text('g', -1), s.average.Text('f', 0), limit.Text('f', 0))
+			price = limit
+		}
+		// average = alpha*price + (1-alpha)*average
+		weighted := new(big.Float).Mul(s.alpha, price)
+		remainder := new(big.Float).Sub(big.NewFloat(1), s.alpha)
+		s.average = weighted.Add(weighted, remainder.Mul(remainder, s.average))
+	}
+
+	// Round up to the nearest wei, so the result is never below a stable price
+	smoothed, accuracy := s.average.Int(nil)
+	if accuracy == big.Below {
+		smoothed.Add(smoothed, big.NewInt(1))
+	}
+	return smoothed
+}
@@ -0,0 +1,92 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGasPriceSmootherSpikeCapped(t *testing.T) {
+	s := newGasPriceSmoother(0.5, 2)
+	ctx := context.Background()
+
+	assert.Equal(t, int64(100), s.sample(ctx, big.NewInt(100)).Int64())
+	// A 10x spike is capped at 2x the average, before being blended in
+	assert.Equal(t, int64(150), s.sample(ctx, big.NewInt(1000)).Int64())
+	assert.Equal(t, int64(125), s.sample(ctx, big.NewInt(100)).Int64())
+	// Fractional averages are rounded up
+	assert.Equal(t, int64(113), s.sample(ctx, big.NewInt(100)).Int64())
+}
+
+func TestGasPriceSmootherNoSmoothing(t *testing.T) {
+	s := newGasPriceSmoother(1, 10)
+	ctx := context.Background()
+
+	assert.Equal(t, int64(100), s.sample(ctx, big.NewInt(100)).Int64())
+	assert.Equal(t, int64(900), s.sample(ctx, big.NewInt(900)).Int64())
+	assert.Equal(t, int64(50), s.sample(ctx, big.NewInt(50)).Int64())
+}
+
+func TestGetGasPriceSmoothed(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t, func(conf config.Section) {
+		conf.Set(GasPriceSmoothing, true)
+		conf.Set(GasPriceSmoothingAlpha, 0.5)
+		conf.Set(GasPriceSpikeCap, 2)
+	})
+	defer done()
+
+	for _, price := range []int64{100, 1000} {
+		mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_gasPrice").
+			Return(nil).
+			Run(func(args mock.Arguments) {
+				*(args[1].(*ethtypes.HexInteger)) = *ethtypes.NewHexInteger64(price)
+			}).Once()
+	}
+
+	res, _, err := c.GasPriceEstimate(ctx, &ffcapi.GasPriceEstimateRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, `"100"`, res.GasPrice.String())
+
+	res, _, err = c.GasPriceEstimate(ctx, &ffcapi.GasPriceEstimateRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, `"150"`, res.GasPrice.String())
+}
+
+func TestGasPriceSmoothingBadConfig(t *testing.T) {
+	config.RootConfigReset()
+	conf := config.RootSection("unittest")
+	InitConfig(conf)
+	conf.Set(ffresty.HTTPConfigURL, "http://localhost:8545")
+	conf.Set(GasPriceSmoothing, true)
+	conf.Set(GasPriceSmoothingAlpha, 0)
+	_, err := NewEthereumConnector(context.Background(), conf)
+	assert.Regexp(t, "FF23078", err)
+
+	conf.Set(GasPriceSmoothingAlpha, 0.5)
+	conf.Set(GasPriceSpikeCap, 0.5)
+	_, err = NewEthereumConnector(context.Background(), conf)
+	assert.Regexp(t, "FF23078", err)
+}
@@ -0,0 +1,129 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"math/big"
+	"sort"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+)
+
+// feeBands are the percentiles of the priority fees paid in recent blocks used for each suggestion,
+// with the number of blocks within which a transaction at that fee would be expected to be included
+var feeBands = []struct {
+	percentile   float64
+	targetBlocks int
+}{
+	{percentile: 10, targetBlocks: 10}, // low
+	{percentile: 50, targetBlocks: 3},  // medium
+	{percentile: 90, targetBlocks: 1},  // high
+}
+
+// The base fee can rise by at most 12.5% per block (EIP-1559)
+var maxBaseFeeIncrease = big.NewFloat(1.125)
+
+type feeHistoryJSONRPC struct {
+	OldestBlock   *ethtypes.HexInteger     `json:"oldestBlock"`
+	BaseFeePerGas []*ethtypes.HexInteger   `json:"baseFeePerGas"`
+	GasUsedRatio  []float64                `json:"gasUsedRatio"`
+	Reward        [][]*ethtypes.HexInteger `json:"reward"`
+}
+
+// FeeSuggestion is an EIP-1559 gas price, in the form accepted on submission of a transaction
+type FeeSuggestion struct {
+	MaxPriorityFeePerGas *fftypes.FFBigInt `json:"maxPriorityFeePerGas"`
+	MaxFeePerGas         *fftypes.FFBigInt `json:"maxFeePerGas"`
+	TargetBlocks         int               `json:"targetBlocks"`
+	MaxFeePerBlobGas     *fftypes.FFBigInt `json:"maxFeePerBlobGas,omitempty"`
+}
+
+type FeeSuggestions struct {
+	Low    *FeeSuggestion `json:"low"`
+	Medium *FeeSuggestion `json:"medium"`
+	High   *FeeSuggestion `json:"high"`
+}
+
+// GasPriceWithSuggestions is returned as the gas price estimate when suggestions or blob gas estimation
+// are enabled. The legacy gasPrice is still used if the object is passed back unmodified on submission.
+type GasPriceWithSuggestions struct {
+	GasPrice         *fftypes.FFBigInt      `json:"gasPrice"`
+	MinGasPrice      *fftypes.FFBigInt      `json:"minGasPrice,omitempty"`
+	Suggestions      *FeeSuggestions        `json:"suggestions,omitempty"`
+	BlobBaseFee      *fftypes.FFBigInt      `json:"blobBaseFee,omitempty"`
+	MaxFeePerBlobGas *fftypes.FFBigInt      `json:"maxFeePerBlobGas,omitempty"`
+	FeeCurrency      *ethtypes.Address0xHex `json:"feeCurrency,omitempty"`
+}
+
+// feeSuggestions uses eth_feeHistory to build low/medium/high EIP-1559 fee suggestions. The priority fee
+// of each is the median across recent blocks of the band percentile, and the max fee allows for the
+// base fee rising at the maximum rate for each block until the target inclusion block.
+// Returns nil if the node does not support fee history, such as on chains without EIP-1559.
+func (c *ethConnector) feeSuggestions(ctx context.Context, blockCount int64) *FeeSuggestions {
+	percentiles := make([]float64, len(feeBands))
+	for i, b := range feeBands {
+		percentiles[i] = b.percentile
+	}
+	var history *feeHistoryJSONRPC
+	rpcErr := c.backend.CallRPC(ctx, &history, "eth_feeHistory", ethtypes.NewHexInteger64(blockCount), "latest", percentiles)
+	if rpcErr != nil || history == nil || len(history.BaseFeePerGas) == 0 {
+		log.L(ctx).Warnf("Unable to build gas price suggestions from fee history: %v", rpcErr)
+		return nil
+	}
+	// The last base fee is that of the next block
+	nextBaseFee := history.BaseFeePerGas[len(history.BaseFeePerGas)-1].BigInt()
+
+	suggestions := make([]*FeeSuggestion, len(feeBands))
+	for i, b := range feeBands {
+		rewards := []*big.Int{}
+		for blockIdx, blockRewards := range history.Reward {
+			// Skip empty blocks, as they report zero rewards
+			if blockIdx < len(history.GasUsedRatio) && history.GasUsedRatio[blockIdx] == 0 {
+				continue
+			}
+			if i < len(blockRewards) && blockRewards[i] != nil {
+				rewards = append(rewards, blockRewards[i].BigInt())
+			}
+		}
+		priorityFee := big.NewInt(0)
+		if len(rewards) > 0 {
+			sort.Slice(rewards, func(x, y int) bool { return rewards[x].Cmp(rewards[y]) < 0 })
+			priorityFee = rewards[len(rewards)/2]
+		}
+
+		baseFee := new(big.Float).SetInt(nextBaseFee)
+		for n := 0; n < b.targetBlocks; n++ {
+			baseFee.Mul(baseFee, maxBaseFeeIncrease)
+		}
+		maxFee, _ := baseFee.Int(nil)
+		maxFee.Add(maxFee, priorityFee)
+
+		suggestions[i] = &FeeSuggestion{
+			MaxPriorityFeePerGas: (*fftypes.FFBigInt)(priorityFee),
+			MaxFeePerGas:         (*fftypes.FFBigInt)(maxFee),
+			TargetBlocks:         b.targetBlocks,
+		}
+	}
+	return &FeeSuggestions{
+		Low:    suggestions[0],
+		Medium: suggestions[1],
+		High:   suggestions[2],
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-evmconnect/mocks/rpcbackendmocks"
+	"github.com/hyperledger/firefly-signer/pkg/ethsigner"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func hexIntegers(values ...int64) []*ethtypes.HexInteger {
+	hi := make([]*ethtypes.HexInteger, len(values))
+	for i, v := range values {
+		hi[i] = ethtypes.NewHexInteger64(v)
+	}
+	return hi
+}
+
+func newTestSuggestionsConnector(t *testing.T) (context.Context, *ethConnector, *rpcbackendmocks.Backend, func()) {
+	ctx, c, mRPC, done := newTestConnector(t, func(conf config.Section) {
+		conf.Set(GasPriceSuggestions, true)
+		conf.Set(GasPriceFeeHistory, 4)
+	})
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_gasPrice").
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			*(args[1].(*ethtypes.HexInteger)) = *ethtypes.NewHexInteger64(12345)
+		})
+	return ctx, c, mRPC, done
+}
+
+func TestGetGasPriceSuggestions(t *testing.T) {
+	ctx, c, mRPC, done := newTestSuggestionsConnector(t)
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_feeHistory", ethtypes.NewHexInteger64(4), "latest", []float64{10, 50, 90}).
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			*(args[1].(**feeHistoryJSONRPC)) = &feeHistoryJSONRPC{
+				OldestBlock:   ethtypes.NewHexInteger64(100),
+				BaseFeePerGas: hexIntegers(900, 950, 900, 950, 1000),
+				GasUsedRatio:  []float64{0.5, 0, 0.5, 0.5},
+				Reward: [][]*ethtypes.HexInteger{
+					hexIntegers(1, 5, 9),
+					hexIntegers(0, 0, 0), // empty block
+					hexIntegers(2, 6, 10),
+					hexIntegers(3, 7, 11),
+				},
+			}
+		})
+
+	res, reason, err := c.GasPriceEstimate(ctx, &ffcapi.GasPriceEstimateRequest{})
+	assert.NoError(t, err)
+	assert.Empty(t, reason)
+	assert.JSONEq(t, `{
+		"gasPrice": "12345",
+		"suggestions": {
+			"low": {"maxPriorityFeePerGas": "2", "maxFeePerGas": "3249", "targetBlocks": 10},
+			"medium": {"maxPriorityFeePerGas": "6", "maxFeePerGas": "1429", "targetBlocks": 3},
+			"high": {"maxPriorityFeePerGas": "10", "maxFeePerGas": "1135", "targetBlocks": 1}
+		}
+	}`, res.GasPrice.String())
+
+	// The response is accepted as the gas price of a transaction, using the legacy gas price
+	tx := &ethsigner.Transaction{}
+	err = c.mapGasPrice(ctx, res.GasPrice, tx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12345), tx.GasPrice.BigInt().Int64())
+
+	// As is any of the suggestions
+	var withSuggestions GasPriceWithSuggestions
+	err = json.Unmarshal(res.GasPrice.Bytes(), &withSuggestions)
+	assert.NoError(t, err)
+	b, _ := json.Marshal(withSuggestions.Suggestions.Medium)
+	tx = &ethsigner.Transaction{}
+	err = c.mapGasPrice(ctx, fftypes.JSONAnyPtrBytes(b), tx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(6), tx.MaxPriorityFeePerGas.BigInt().Int64())
+	assert.Equal(t, int64(1429), tx.MaxFeePerGas.BigInt().Int64())
+}
+
+func TestGetGasPriceSuggestionsNoFeeHistory(t *testing.T) {
+	ctx, c, mRPC, done := newTestSuggestionsConnector(t)
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_feeHistory", mock.Anything, "latest", mock.Anything).
+		Return(&rpcbackend.RPCError{Message: "the method eth_feeHistory does not exist/is not available"})
+
+	res, _, err := c.GasPriceEstimate(ctx, &ffcapi.GasPriceEstimateRequest{})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"gasPrice": "12345"}`, res.GasPrice.String())
+}
@@ -0,0 +1,171 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"math/big"
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/log"
+)
+
+// gasReportCacheSize bounds the number of transactions remembered as already counted in the gas usage, as the
+// receipt of a transaction can be requested more than once
+const gasReportCacheSize = 1000
+
+// ReceiptGasReport compares the gas limit and maximum fee of a mined transaction with the gas it used and the fee
+// it paid
+type ReceiptGasReport struct {
+	GasLimit          *fftypes.FFBigInt `json:"gasLimit"`
+	GasUsed           *fftypes.FFBigInt `json:"gasUsed"`
+	GasUnused         *fftypes.FFBigInt `json:"gasUnused"`
+	GasUsedRatio      float64           `json:"gasUsedRatio"`
+	MaxGasPrice       *fftypes.FFBigInt `json:"maxGasPrice,omitempty"` // the gasPrice, or maxFeePerGas of an EIP-1559 transaction
+	EffectiveGasPrice *fftypes.FFBigInt `json:"effectiveGasPrice,omitempty"`
+	MaxFee            *fftypes.FFBigInt `json:"maxFee,omitempty"`
+	FeePaid           *fftypes.FFBigInt `json:"feePaid,omitempty"`
+	FeeUnspent        *fftypes.FFBigInt `json:"feeUnspent,omitempty"`
+}
+
+// GasUsageStats are the totals of the gas reports of the receipts of successful transactions
+type GasUsageStats struct {
+	Transactions              int64             `json:"transactions"`
+	GasLimit                  *fftypes.FFBigInt `json:"gasLimit"`
+	GasUsed                   *fftypes.FFBigInt `json:"gasUsed"`
+	FeePaid                   *fftypes.FFBigInt `json:"feePaid"`
+	AverageGasUsedRatio       float64           `json:"averageGasUsedRatio"`
+	MaxGasUsedRatio           float64           `json:"maxGasUsedRatio"`
+	SuggestedEstimationFactor float64           `json:"suggestedEstimationFactor"`
+}
+
+// gasUsageTracker accumulates the gas reports of receipts, counting each transaction once
+type gasUsageTracker struct {
+	mux      sync.Mutex
+	counted  *lru.Cache
+	count    int64
+	gasLimit *big.Int
+	gasUsed  *big.Int
+	feePaid  *big.Int
+	ratioSum float64
+	ratioMax float64
+}
+
+func newGasUsageTracker() *gasUsageTracker {
+	counted, _ := lru.New(gasReportCacheSize)
+	return &gasUsageTracker{
+		counted:  counted,
+		gasLimit: new(big.Int),
+		gasUsed:  new(big.Int),
+		feePaid:  new(big.Int),
+	}
+}
+
+// newReceiptGasReport builds the gas report of a receipt, from the gas limit and price of its transaction. The
+// receipts of chains before the London fork do not have an effectiveGasPrice, so the gasPrice of the transaction
+// is the price paid.
+func newReceiptGasReport(receipt *txReceiptJSONRPC, txInfo *txInfoJSONRPC) *ReceiptGasReport {
+	if txInfo.Gas == nil || receipt.GasUsed == nil || txInfo.Gas.BigInt().Sign() <= 0 {
+		return nil
+	}
+	gasLimit, gasUsed := txInfo.Gas.BigInt(), receipt.GasUsed.BigInt()
+	ratio, _ := new(big.Float).Quo(new(big.Float).SetInt(gasUsed), new(big.Float).SetInt(gasLimit)).Float64()
+	report := &ReceiptGasReport{
+		GasLimit:     (*fftypes.FFBigInt)(gasLimit),
+		GasUsed:      (*fftypes.FFBigInt)(gasUsed),
+		GasUnused:    (*fftypes.FFBigInt)(new(big.Int).Sub(gasLimit, gasUsed)),
+		GasUsedRatio: ratio,
+	}
+
+	maxGasPrice := txInfo.MaxFeePerGas
+	if maxGasPrice == nil {
+		maxGasPrice = txInfo.GasPrice
+	}
+	effectiveGasPrice := receipt.EffectiveGasPrice
+	if effectiveGasPrice == nil {
+		effectiveGasPrice = txInfo.GasPrice
+	}
+	if maxGasPrice != nil {
+		report.MaxGasPrice = (*fftypes.FFBigInt)(maxGasPrice.BigInt())
+		report.MaxFee = (*fftypes.FFBigInt)(new(big.Int).Mul(gasLimit, maxGasPrice.BigInt()))
+	}
+	if effectiveGasPrice != nil {
+		report.EffectiveGasPrice = (*fftypes.FFBigInt)(effectiveGasPrice.BigInt())
+		report.FeePaid = (*fftypes.FFBigInt)(new(big.Int).Mul(gasUsed, effectiveGasPrice.BigInt()))
+	}
+	if report.MaxFee != nil && report.FeePaid != nil {
+		report.FeeUnspent = (*fftypes.FFBigInt)(new(big.Int).Sub(report.MaxFee.Int(), report.FeePaid.Int()))
+	}
+	return report
+}
+
+// receiptGasReport returns the gas report of a receipt, adding it to the gas usage if the transaction succeeded.
+// The report is omitted if the transaction cannot be queried, as it is not needed to return the receipt.
+func (c *ethConnector) receiptGasReport(ctx context.Context, receipt *txReceiptJSONRPC, success bool) *ReceiptGasReport {
+	txInfo, err := c.getTransactionInfo(ctx, receipt.TransactionHash)
+	if err != nil || txInfo == nil {
+		log.L(ctx).Warnf("Unable to query transaction %s for its gas report: %v", receipt.TransactionHash, err)
+		return nil
+	}
+	report := newReceiptGasReport(receipt, txInfo)
+	if report != nil && success {
+		c.gasUsage.add(receipt.TransactionHash.String(), report)
+	}
+	return report
+}
+
+func (gt *gasUsageTracker) add(txHash string, report *ReceiptGasReport) {
+	gt.mux.Lock()
+	defer gt.mux.Unlock()
+	if ok, _ := gt.counted.ContainsOrAdd(txHash, true); ok {
+		return
+	}
+	gt.count++
+	gt.gasLimit.Add(gt.gasLimit, report.GasLimit.Int())
+	gt.gasUsed.Add(gt.gasUsed, report.GasUsed.Int())
+	if report.FeePaid != nil {
+		gt.feePaid.Add(gt.feePaid, report.FeePaid.Int())
+	}
+	gt.ratioSum += report.GasUsedRatio
+	gt.ratioMax = max(gt.ratioMax, report.GasUsedRatio)
+}
+
+// stats returns the gas usage so far. The suggested estimation factor is the one that would have given the
+// transaction with the highest ratio of gas used a limit of exactly the gas it used, assuming the gas limits
+// were all estimated by the connector with the current factor.
+func (gt *gasUsageTracker) stats(estimationFactor *big.Float) *GasUsageStats {
+	if gt == nil {
+		return nil
+	}
+	gt.mux.Lock()
+	defer gt.mux.Unlock()
+	stats := &GasUsageStats{
+		Transactions:    gt.count,
+		GasLimit:        (*fftypes.FFBigInt)(new(big.Int).Set(gt.gasLimit)),
+		GasUsed:         (*fftypes.FFBigInt)(new(big.Int).Set(gt.gasUsed)),
+		FeePaid:         (*fftypes.FFBigInt)(new(big.Int).Set(gt.feePaid)),
+		MaxGasUsedRatio: gt.ratioMax,
+	}
+	if gt.count > 0 {
+		factor, _ := estimationFactor.Float64()
+		stats.AverageGasUsedRatio = gt.ratioSum / float64(gt.count)
+		stats.SuggestedEstimationFactor = max(1, factor*gt.ratioMax)
+	}
+	return stats
+}
@@ -0,0 +1,149 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func enableGasReport(conf config.Section) {
+	conf.Set(GasReportReceipts, true)
+}
+
+func mockGasReportReceipt(t *testing.T, mRPC *mock.Mock, effectiveGasPrice *ethtypes.HexInteger) {
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionReceipt", mock.Anything).
+		Run(func(args mock.Arguments) {
+			err := json.Unmarshal([]byte(sampleJSONRPCReceipt), args[1])
+			assert.NoError(t, err)
+			(*args[1].(**txReceiptJSONRPC)).EffectiveGasPrice = effectiveGasPrice
+		}).
+		Return(nil)
+}
+
+func TestReceiptGasReport(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t, enableGasReport)
+	defer done()
+
+	mockGasReportReceipt(t, &mRPC.Mock, ethtypes.NewHexInteger64(50))
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionByHash", mock.Anything).
+		Run(func(args mock.Arguments) {
+			*(args[1].(**txInfoJSONRPC)) = &txInfoJSONRPC{
+				Gas:          ethtypes.NewHexInteger64(50000),
+				GasPrice:     ethtypes.NewHexInteger64(50),
+				MaxFeePerGas: ethtypes.NewHexInteger64(100),
+			}
+		}).
+		Return(nil).Once()
+
+	var req ffcapi.TransactionReceiptRequest
+	err := json.Unmarshal([]byte(sampleGetReceipt), &req)
+	assert.NoError(t, err)
+
+	// The same transaction is only counted once in the gas usage
+	for i := 0; i < 2; i++ {
+		res, _, err := c.TransactionReceipt(ctx, &req)
+		assert.NoError(t, err)
+		var extraInfo receiptExtraInfo
+		err = json.Unmarshal(res.ExtraInfo.Bytes(), &extraInfo)
+		assert.NoError(t, err)
+		report := extraInfo.GasReport
+		assert.Equal(t, int64(50000), report.GasLimit.Int64())
+		assert.Equal(t, int64(33812), report.GasUsed.Int64())
+		assert.Equal(t, int64(16188), report.GasUnused.Int64())
+		assert.InDelta(t, 0.67624, report.GasUsedRatio, 0.00001)
+		assert.Equal(t, int64(100), report.MaxGasPrice.Int64())
+		assert.Equal(t, int64(50), report.EffectiveGasPrice.Int64())
+		assert.Equal(t, int64(5000000), report.MaxFee.Int64())
+		assert.Equal(t, int64(1690600), report.FeePaid.Int64())
+		assert.Equal(t, int64(3309400), report.FeeUnspent.Int64())
+	}
+
+	mockNodeInfoUnsupported(mRPC)
+	stats := c.Status(ctx).GasUsage
+	assert.Equal(t, int64(1), stats.Transactions)
+	assert.Equal(t, int64(33812), stats.GasUsed.Int64())
+	assert.Equal(t, int64(1690600), stats.FeePaid.Int64())
+	assert.InDelta(t, 0.67624, stats.AverageGasUsedRatio, 0.00001)
+	assert.InDelta(t, 1.5*0.67624, stats.SuggestedEstimationFactor, 0.00001)
+}
+
+func TestReceiptGasReportLegacy(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t, enableGasReport)
+	defer done()
+
+	// Receipts before the London fork do not have an effective gas price
+	mockGasReportReceipt(t, &mRPC.Mock, nil)
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionByHash", mock.Anything).
+		Return(&rpcbackend.RPCError{Message: "pop"}).Once()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionByHash", mock.Anything).
+		Run(func(args mock.Arguments) {
+			*(args[1].(**txInfoJSONRPC)) = &txInfoJSONRPC{
+				Gas:      ethtypes.NewHexInteger64(33812),
+				GasPrice: ethtypes.NewHexInteger64(10),
+			}
+		}).
+		Return(nil).Once()
+
+	var req ffcapi.TransactionReceiptRequest
+	err := json.Unmarshal([]byte(sampleGetReceipt), &req)
+	assert.NoError(t, err)
+
+	// The receipt is returned without the report if the transaction cannot be queried
+	res, _, err := c.TransactionReceipt(ctx, &req)
+	assert.NoError(t, err)
+	assert.NotContains(t, res.ExtraInfo.String(), "gasReport")
+
+	res, _, err = c.TransactionReceipt(ctx, &req)
+	assert.NoError(t, err)
+	var extraInfo receiptExtraInfo
+	err = json.Unmarshal(res.ExtraInfo.Bytes(), &extraInfo)
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, extraInfo.GasReport.GasUsedRatio)
+	assert.Equal(t, int64(338120), extraInfo.GasReport.FeePaid.Int64())
+	assert.Zero(t, extraInfo.GasReport.FeeUnspent.Int64())
+}
+
+func TestGasUsageStats(t *testing.T) {
+	var gt *gasUsageTracker
+	assert.Nil(t, gt.stats(big.NewFloat(1.5)))
+
+	gt = newGasUsageTracker()
+	stats := gt.stats(big.NewFloat(1.5))
+	assert.Zero(t, stats.Transactions)
+	assert.Zero(t, stats.SuggestedEstimationFactor)
+
+	// The suggested factor is never below 1
+	gt.add("0x01", newReceiptGasReport(
+		&txReceiptJSONRPC{GasUsed: ethtypes.NewHexInteger64(10000)},
+		&txInfoJSONRPC{Gas: ethtypes.NewHexInteger64(40000)},
+	))
+	stats = gt.stats(big.NewFloat(1.5))
+	assert.Equal(t, 0.25, stats.MaxGasUsedRatio)
+	assert.Equal(t, 1.0, stats.SuggestedEstimationFactor)
+	assert.Zero(t, stats.FeePaid.Int64())
+
+	assert.Nil(t, newReceiptGasReport(&txReceiptJSONRPC{GasUsed: ethtypes.NewHexInteger64(10000)}, &txInfoJSONRPC{}))
+}
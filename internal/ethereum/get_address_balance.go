@@ -25,13 +25,14 @@ import (
 )
 
 func (c *ethConnector) AddressBalance(ctx context.Context, req *ffcapi.AddressBalanceRequest) (*ffcapi.AddressBalanceResponse, ffcapi.ErrorReason, error) {
+	ctx = withCorrelationIDs(ctx)
 
 	var addressBalance ethtypes.HexInteger
 	var blockTag = req.BlockTag
 	if blockTag == "" {
 		blockTag = "latest"
 	}
-	rpcErr := c.backend.CallRPC(ctx, &addressBalance, "eth_getBalance", req.Address, blockTag)
+	rpcErr := c.readBackend().CallRPC(ctx, &addressBalance, "eth_getBalance", req.Address, blockTag)
 	if rpcErr != nil {
 		return nil, "", rpcErr.Error()
 	}
@@ -41,3 +42,28 @@ func (c *ethConnector) AddressBalance(ctx context.Context, req *ffcapi.AddressBa
 	}, "", nil
 
 }
+
+// AccountBalance is the balance of an account, in a number format
+type AccountBalance struct {
+	Address string      `json:"address"`
+	Balance interface{} `json:"balance"`
+}
+
+// FormattedAddressBalance gets the balance of an account as AddressBalance does, in the requested number format
+func (c *ethConnector) FormattedAddressBalance(ctx context.Context, address, blockTag, numberFormat string) (*AccountBalance, ffcapi.ErrorReason, error) {
+	if numberFormat == "" {
+		numberFormat = c.numberFormat
+	}
+	is, err := intSerializer(ctx, numberFormat)
+	if err != nil {
+		return nil, ffcapi.ErrorReasonInvalidInputs, err
+	}
+	res, reason, err := c.AddressBalance(ctx, &ffcapi.AddressBalanceRequest{Address: address, BlockTag: blockTag})
+	if err != nil {
+		return nil, reason, err
+	}
+	return &AccountBalance{
+		Address: address,
+		Balance: is(res.Balance.Int()),
+	}, "", nil
+}
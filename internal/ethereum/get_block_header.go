@@ -0,0 +1,109 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+)
+
+// BlockHeader is the full header of a block as returned by the node, for audit tooling that
+// needs to verify the roots and bloom, with the fields added by later hard forks where present
+type BlockHeader struct {
+	Number                *ethtypes.HexInteger        `json:"number"`
+	Hash                  ethtypes.HexBytes0xPrefix   `json:"hash"`
+	ParentHash            ethtypes.HexBytes0xPrefix   `json:"parentHash"`
+	Nonce                 ethtypes.HexBytes0xPrefix   `json:"nonce,omitempty"`
+	SHA3Uncles            ethtypes.HexBytes0xPrefix   `json:"sha3Uncles"`
+	LogsBloom             ethtypes.HexBytes0xPrefix   `json:"logsBloom"`
+	TransactionsRoot      ethtypes.HexBytes0xPrefix   `json:"transactionsRoot"`
+	StateRoot             ethtypes.HexBytes0xPrefix   `json:"stateRoot"`
+	ReceiptsRoot          ethtypes.HexBytes0xPrefix   `json:"receiptsRoot"`
+	Miner                 *ethtypes.Address0xHex      `json:"miner,omitempty"`
+	Difficulty            *ethtypes.HexInteger        `json:"difficulty,omitempty"`
+	TotalDifficulty       *ethtypes.HexInteger        `json:"totalDifficulty,omitempty"`
+	ExtraData             ethtypes.HexBytes0xPrefix   `json:"extraData"`
+	Size                  *ethtypes.HexInteger        `json:"size,omitempty"`
+	GasLimit              *ethtypes.HexInteger        `json:"gasLimit"`
+	GasUsed               *ethtypes.HexInteger        `json:"gasUsed"`
+	Timestamp             *ethtypes.HexInteger        `json:"timestamp"`
+	MixHash               ethtypes.HexBytes0xPrefix   `json:"mixHash,omitempty"`
+	BaseFeePerGas         *ethtypes.HexInteger        `json:"baseFeePerGas,omitempty"`
+	WithdrawalsRoot       ethtypes.HexBytes0xPrefix   `json:"withdrawalsRoot,omitempty"`
+	BlobGasUsed           *ethtypes.HexInteger        `json:"blobGasUsed,omitempty"`
+	ExcessBlobGas         *ethtypes.HexInteger        `json:"excessBlobGas,omitempty"`
+	ParentBeaconBlockRoot ethtypes.HexBytes0xPrefix   `json:"parentBeaconBlockRoot,omitempty"`
+	Transactions          []ethtypes.HexBytes0xPrefix `json:"transactions"`
+	Uncles                []ethtypes.HexBytes0xPrefix `json:"uncles"`
+	Withdrawals           []*Withdrawal               `json:"withdrawals,omitempty"`
+}
+
+// Withdrawal is a validator withdrawal from the beacon chain (EIP-4895)
+type Withdrawal struct {
+	Index          *ethtypes.HexInteger   `json:"index"`
+	ValidatorIndex *ethtypes.HexInteger   `json:"validatorIndex"`
+	Address        *ethtypes.Address0xHex `json:"address"`
+	Amount         *ethtypes.HexInteger   `json:"amount"`
+}
+
+type BlockHeaderResponse struct {
+	BlockHeader
+	UncleHeaders     []*BlockHeader     `json:"uncleHeaders,omitempty"`
+	FullTransactions []*fftypes.JSONAny `json:"fullTransactions,omitempty"`
+}
+
+// BlockHeaderByHash returns the full header of a block, optionally with the headers of each of its uncles (ommers),
+// and the full objects of its transactions
+func (c *ethConnector) BlockHeaderByHash(ctx context.Context, blockHash string, includeUncles, includeTransactions bool) (*BlockHeaderResponse, error) {
+	hash, err := ethtypes.NewHexBytes0xPrefix(blockHash)
+	if err != nil || len(hash) != 32 {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidBlockHash, blockHash)
+	}
+
+	var header *BlockHeader
+	if rpcErr := c.readBackend().CallRPC(ctx, &header, "eth_getBlockByHash", hash, false); rpcErr != nil {
+		return nil, rpcErr.Error()
+	}
+	if header == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgBlockNotFound, blockHash)
+	}
+
+	res := &BlockHeaderResponse{BlockHeader: *header}
+	if includeUncles {
+		res.UncleHeaders = make([]*BlockHeader, len(header.Uncles))
+		for i := range header.Uncles {
+			var uncle *BlockHeader
+			if rpcErr := c.readBackend().CallRPC(ctx, &uncle, "eth_getUncleByBlockHashAndIndex", hash, ethtypes.NewHexInteger64(int64(i))); rpcErr != nil {
+				return nil, rpcErr.Error()
+			}
+			if uncle == nil {
+				return nil, i18n.NewError(ctx, msgs.MsgBlockNotFound, header.Uncles[i])
+			}
+			res.UncleHeaders[i] = uncle
+		}
+	}
+	if includeTransactions {
+		if res.FullTransactions, err = c.blockTransactions(ctx, header); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,186 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hyperledger/firefly-evmconnect/mocks/rpcbackendmocks"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	sampleBlockHash = "0x6b012339fbb85b70c58ecfd97b31950c4a28bcef5226e12dbe551cb1abaf3b4c"
+	sampleUncleHash = "0x2a9ba4c2cd23ffe343111089a8a3f984b777b2d9b24d47dbd2e2bb7c953a3e10"
+
+	sampleBlockHeaderJSON = `{
+		"number": "0x1b4",
+		"hash": "0x6b012339fbb85b70c58ecfd97b31950c4a28bcef5226e12dbe551cb1abaf3b4c",
+		"parentHash": "0x9ae0a5b9c3c8a4f2e3a17c02f1b2d2e6e8eb8f1f32d5fa1e3c7b2a1d0e9f8c7b",
+		"sha3Uncles": "0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347",
+		"logsBloom": "0x00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		"transactionsRoot": "0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421",
+		"stateRoot": "0xd7f8974fb5ac78d9ac099b9ad5018bedc2ce0a72dad1827a1709da30580f0544",
+		"receiptsRoot": "0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421",
+		"miner": "0x4e65fda2159562a496f9f3522f89122a3088497a",
+		"difficulty": "0x0",
+		"extraData": "0x",
+		"gasLimit": "0x1c9c380",
+		"gasUsed": "0x0",
+		"timestamp": "0x6553f100",
+		"baseFeePerGas": "0x7",
+		"withdrawalsRoot": "0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421",
+		"transactions": [],
+		"uncles": ["0x2a9ba4c2cd23ffe343111089a8a3f984b777b2d9b24d47dbd2e2bb7c953a3e10"],
+		"withdrawals": [
+			{"index": "0x1", "validatorIndex": "0x2", "address": "0xd0f2f5103fd050739a9fb567251bc460cc24d091", "amount": "0x3e8"}
+		]
+	}`
+)
+
+func mockBlockHeader(mRPC *rpcbackendmocks.Backend, headerJSON string) *mock.Call {
+	return mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByHash", mock.MatchedBy(func(h ethtypes.HexBytes0xPrefix) bool {
+		return h.String() == sampleBlockHash
+	}), false).
+		Run(func(args mock.Arguments) {
+			err := json.Unmarshal([]byte(headerJSON), args[1])
+			if err != nil {
+				panic(err)
+			}
+		}).
+		Return(nil)
+}
+
+func mockUncleHeader(mRPC *rpcbackendmocks.Backend, index int64, headerJSON string) *mock.Call {
+	return mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getUncleByBlockHashAndIndex", mock.Anything, mock.MatchedBy(func(i *ethtypes.HexInteger) bool {
+		return i.BigInt().Int64() == index
+	})).
+		Run(func(args mock.Arguments) {
+			err := json.Unmarshal([]byte(headerJSON), args[1])
+			if err != nil {
+				panic(err)
+			}
+		}).
+		Return(nil)
+}
+
+func TestBlockHeaderByHashOK(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mockBlockHeader(mRPC, sampleBlockHeaderJSON)
+
+	res, err := c.BlockHeaderByHash(ctx, sampleBlockHash, false, false)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(436), res.Number.BigInt().Int64())
+	assert.Equal(t, "0xd7f8974fb5ac78d9ac099b9ad5018bedc2ce0a72dad1827a1709da30580f0544", res.StateRoot.String())
+	assert.Equal(t, "0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421", res.ReceiptsRoot.String())
+	assert.Len(t, res.LogsBloom, 256)
+	assert.Equal(t, []ethtypes.HexBytes0xPrefix{ethtypes.MustNewHexBytes0xPrefix(sampleUncleHash)}, res.Uncles)
+	assert.Len(t, res.Withdrawals, 1)
+	assert.Equal(t, int64(1000), res.Withdrawals[0].Amount.BigInt().Int64())
+	assert.Nil(t, res.UncleHeaders)
+}
+
+func TestBlockHeaderByHashWithUncles(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mockBlockHeader(mRPC, sampleBlockHeaderJSON)
+	mockUncleHeader(mRPC, 0, `{"number":"0x1b3","hash":"`+sampleUncleHash+`","uncles":[]}`)
+
+	res, err := c.BlockHeaderByHash(ctx, sampleBlockHash, true, false)
+	assert.NoError(t, err)
+	assert.Len(t, res.UncleHeaders, 1)
+	assert.Equal(t, sampleUncleHash, res.UncleHeaders[0].Hash.String())
+}
+
+func TestBlockHeaderByHashUncleNotFound(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mockBlockHeader(mRPC, sampleBlockHeaderJSON)
+	mockUncleHeader(mRPC, 0, `null`)
+
+	_, err := c.BlockHeaderByHash(ctx, sampleBlockHash, true, false)
+	assert.Regexp(t, "FF23072", err)
+}
+
+func TestBlockHeaderByHashUncleFail(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mockBlockHeader(mRPC, sampleBlockHeaderJSON)
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getUncleByBlockHashAndIndex", mock.Anything, mock.Anything).
+		Return(&rpcbackend.RPCError{Message: "pop"})
+
+	_, err := c.BlockHeaderByHash(ctx, sampleBlockHash, true, false)
+	assert.Regexp(t, "pop", err)
+}
+
+func TestBlockHeaderByHashNotFound(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mockBlockHeader(mRPC, `null`)
+
+	_, err := c.BlockHeaderByHash(ctx, sampleBlockHash, false, false)
+	assert.Regexp(t, "FF23072", err)
+}
+
+func TestBlockHeaderByHashFail(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByHash", mock.Anything, false).
+		Return(&rpcbackend.RPCError{Message: "pop"})
+
+	_, err := c.BlockHeaderByHash(ctx, sampleBlockHash, false, false)
+	assert.Regexp(t, "pop", err)
+}
+
+func TestBlockHeaderByHashBadHash(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t)
+	defer done()
+
+	_, err := c.BlockHeaderByHash(ctx, "0x1234", false, false)
+	assert.Regexp(t, "FF23071", err)
+}
+
+func TestGetBlockByHashRoute(t *testing.T) {
+	_, c, mRPC, done := newTestConnector(t)
+	defer done()
+	url, close := newTestRouteServer(t, c)
+	defer close()
+
+	mockBlockHeader(mRPC, sampleBlockHeaderJSON)
+	mockUncleHeader(mRPC, 0, `{"number":"0x1b3","hash":"`+sampleUncleHash+`","uncles":[]}`)
+
+	res, err := http.Get(url + "/blocks/" + sampleBlockHash + "?includeUncles=true")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var header BlockHeaderResponse
+	err = json.NewDecoder(res.Body).Decode(&header)
+	assert.NoError(t, err)
+	assert.Equal(t, sampleBlockHash, header.Hash.String())
+	assert.Len(t, header.UncleHeaders, 1)
+}
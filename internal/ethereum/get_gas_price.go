@@ -18,25 +18,67 @@ package ethereum
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
-	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
 	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
 )
 
 func (c *ethConnector) GasPriceEstimate(ctx context.Context, _ *ffcapi.GasPriceEstimateRequest) (*ffcapi.GasPriceEstimateResponse, ffcapi.ErrorReason, error) {
+	ctx = withCorrelationIDs(ctx)
 
 	// Note we use simple (pre London fork) gas fee approach.
 	// See https://github.com/ethereum/pm/issues/328#issuecomment-853234014 for a bit of color
-	var gasPrice ethtypes.HexInteger
-	rpcErr := c.backend.CallRPC(ctx, &gasPrice, "eth_gasPrice")
+	gasPrice, rpcErr := c.nodeGasPrice(ctx)
 	if rpcErr != nil {
 		return nil, "", rpcErr.Error()
 	}
 
+	price := gasPrice.BigInt()
+	minGasPrice := c.minGasPrice()
+	if minGasPrice == nil && c.detectZeroGas(ctx, price) {
+		// A free gas network has no fees to smooth or suggest
+		return &ffcapi.GasPriceEstimateResponse{
+			GasPrice: fftypes.JSONAnyPtr(`"0"`),
+		}, "", nil
+	}
+	gp := c.gas()
+	if gp.smoother != nil {
+		price = gp.smoother.sample(ctx, price)
+	}
+	// The estimate is never below the minimum gas price of the node, as the node would reject the transaction
+	if minGasPrice != nil && price.Cmp(minGasPrice) < 0 {
+		price = minGasPrice
+	}
+
+	if gp.suggestions || gp.blobGas != nil || gp.feeCurrency != nil || minGasPrice != nil {
+		// Low/medium/high EIP-1559 suggestions, the EIP-4844 blob gas fees, and the minimum gas price of the node,
+		// are returned alongside the legacy gas price, in an object that is still accepted as the gas price of a
+		// transaction submission.
+		// The fee history is in the native currency, so suggestions are not returned for a fee currency.
+		withSuggestions := &GasPriceWithSuggestions{
+			GasPrice:    (*fftypes.FFBigInt)(price),
+			MinGasPrice: (*fftypes.FFBigInt)(minGasPrice),
+			FeeCurrency: gp.feeCurrency,
+		}
+		if gp.suggestions && gp.feeCurrency == nil {
+			withSuggestions.Suggestions = c.feeSuggestions(ctx, gp.suggestionBlocks)
+			if minGasPrice != nil {
+				applyMinGasPrice(withSuggestions.Suggestions, minGasPrice)
+			}
+		}
+		if gp.blobGas != nil {
+			c.addBlobGasFees(ctx, gp.blobGas, withSuggestions)
+		}
+		b, _ := json.Marshal(withSuggestions)
+		return &ffcapi.GasPriceEstimateResponse{
+			GasPrice: fftypes.JSONAnyPtrBytes(b),
+		}, "", nil
+	}
+
 	return &ffcapi.GasPriceEstimateResponse{
-		GasPrice: fftypes.JSONAnyPtr(fmt.Sprintf(`"%s"`, gasPrice.BigInt().Text(10))),
+		GasPrice: fftypes.JSONAnyPtr(fmt.Sprintf(`"%s"`, price.Text(10))),
 	}, "", nil
 
 }
@@ -25,6 +25,7 @@ import (
 )
 
 func (c *ethConnector) NextNonceForSigner(ctx context.Context, req *ffcapi.NextNonceForSignerRequest) (*ffcapi.NextNonceForSignerResponse, ffcapi.ErrorReason, error) {
+	ctx = withCorrelationIDs(ctx)
 
 	var txnCount ethtypes.HexInteger
 	rpcErr := c.backend.CallRPC(ctx, &txnCount, "eth_getTransactionCount", req.Signer, "pending")
@@ -30,23 +30,30 @@ import (
 	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
 	"github.com/hyperledger/firefly-signer/pkg/abi"
 	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
 	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
 )
 
 // txReceiptJSONRPC is the receipt obtained over JSON/RPC from the ethereum client, with gas used, logs and contract address
 type txReceiptJSONRPC struct {
-	BlockHash         ethtypes.HexBytes0xPrefix  `json:"blockHash"`
-	BlockNumber       *ethtypes.HexInteger       `json:"blockNumber"`
-	ContractAddress   *ethtypes.Address0xHex     `json:"contractAddress"`
-	CumulativeGasUsed *ethtypes.HexInteger       `json:"cumulativeGasUsed"`
-	From              *ethtypes.Address0xHex     `json:"from"`
-	GasUsed           *ethtypes.HexInteger       `json:"gasUsed"`
-	Logs              []*logJSONRPC              `json:"logs"`
-	Status            *ethtypes.HexInteger       `json:"status"`
-	To                *ethtypes.Address0xHex     `json:"to"`
-	TransactionHash   ethtypes.HexBytes0xPrefix  `json:"transactionHash"`
-	TransactionIndex  *ethtypes.HexInteger       `json:"transactionIndex"`
-	RevertReason      *ethtypes.HexBytes0xPrefix `json:"revertReason"`
+	BlockHash             ethtypes.HexBytes0xPrefix  `json:"blockHash"`
+	BlockNumber           *ethtypes.HexInteger       `json:"blockNumber"`
+	ContractAddress       *ethtypes.Address0xHex     `json:"contractAddress"`
+	CumulativeGasUsed     *ethtypes.HexInteger       `json:"cumulativeGasUsed"`
+	EffectiveGasPrice     *ethtypes.HexInteger       `json:"effectiveGasPrice,omitempty"`
+	From                  *ethtypes.Address0xHex     `json:"from"`
+	GasUsed               *ethtypes.HexInteger       `json:"gasUsed"`
+	Logs                  []*logJSONRPC              `json:"logs"`
+	Status                *ethtypes.HexInteger       `json:"status"`
+	To                    *ethtypes.Address0xHex     `json:"to"`
+	TransactionHash       ethtypes.HexBytes0xPrefix  `json:"transactionHash"`
+	TransactionIndex      *ethtypes.HexInteger       `json:"transactionIndex"`
+	RevertReason          *ethtypes.HexBytes0xPrefix `json:"revertReason"`
+	Type                  *ethtypes.HexInteger       `json:"type,omitempty"`
+	LogsBloom             ethtypes.HexBytes0xPrefix  `json:"logsBloom,omitempty"`
+	Root                  ethtypes.HexBytes0xPrefix  `json:"root,omitempty"`                  // pre-Byzantium post-transaction state root
+	DepositNonce          *ethtypes.HexInteger       `json:"depositNonce,omitempty"`          // OP deposit transactions from Regolith
+	DepositReceiptVersion *ethtypes.HexInteger       `json:"depositReceiptVersion,omitempty"` // OP deposit transactions from Canyon
 }
 
 // receiptExtraInfo is the version of the receipt we store under the TX.
@@ -60,8 +67,14 @@ type receiptExtraInfo struct {
 	To                *ethtypes.Address0xHex `json:"to"`
 	GasUsed           *fftypes.FFBigInt      `json:"gasUsed"`
 	Status            *fftypes.FFBigInt      `json:"status"`
+	StatusFallback    string                 `json:"statusFallback,omitempty"`
 	ErrorMessage      *string                `json:"errorMessage"`
 	ReturnValue       *string                `json:"returnValue,omitempty"`
+	ErrorDetails      *ErrorDetails          `json:"errorDetails,omitempty"`
+	ContractMetadata  *ContractMetadata      `json:"contractMetadata,omitempty"`
+	GasReport         *ReceiptGasReport      `json:"gasReport,omitempty"`
+	Confirmations     *ReceiptConfirmations  `json:"confirmations,omitempty"`
+	Retryables        []*RetryableTicket     `json:"retryables,omitempty"`
 }
 
 // txInfoJSONRPC is the transaction info obtained over JSON/RPC from the ethereum client, with input data
@@ -71,6 +84,7 @@ type txInfoJSONRPC struct {
 	From             *ethtypes.Address0xHex    `json:"from"`
 	Gas              *ethtypes.HexInteger      `json:"gas"`
 	GasPrice         *ethtypes.HexInteger      `json:"gasPrice"`
+	MaxFeePerGas     *ethtypes.HexInteger      `json:"maxFeePerGas,omitempty"`
 	Hash             ethtypes.HexBytes0xPrefix `json:"hash"`
 	Input            ethtypes.HexBytes0xPrefix `json:"input"`
 	R                *ethtypes.HexInteger      `json:"r"`
@@ -79,6 +93,7 @@ type txInfoJSONRPC struct {
 	TransactionIndex *ethtypes.HexInteger      `json:"transactionIndex"` // null if pending
 	V                *ethtypes.HexInteger      `json:"v"`
 	Value            *ethtypes.HexInteger      `json:"value"`
+	RequestID        ethtypes.HexBytes0xPrefix `json:"requestId,omitempty"` // Arbitrum transactions from L1 messages, such as retryable tickets
 }
 
 type StructLog struct {
@@ -105,7 +120,7 @@ func (c *ethConnector) getTransactionInfo(ctx context.Context, hash ethtypes.Hex
 		return cached.(*txInfoJSONRPC), nil
 	}
 
-	rpcErr := c.backend.CallRPC(ctx, &txInfo, "eth_getTransactionByHash", hash)
+	rpcErr := c.readBackendForTx(hash.String()).CallRPC(ctx, &txInfo, "eth_getTransactionByHash", hash)
 	var err error
 	if rpcErr != nil {
 		err = rpcErr.Error()
@@ -140,7 +155,7 @@ func (c *ethConnector) getErrorInfo(ctx context.Context, transactionHash string,
 			log.L(ctx).Trace("No revert reason for the failed transaction found in the receipt. Calling debug_traceTransaction to retrieve it.")
 			// Attempt to get the return value of the transaction - not possible on all RPC endpoints
 			var debugTrace *txDebugTrace
-			traceErr := c.backend.CallRPC(ctx, &debugTrace, "debug_traceTransaction", transactionHash)
+			traceErr := c.readBackendForTx(transactionHash).CallRPC(ctx, &debugTrace, "debug_traceTransaction", transactionHash)
 			if traceErr != nil {
 				msg := i18n.NewError(ctx, msgs.MsgUnableToCallDebug, traceErr).Error()
 				return nil, &msg
@@ -171,6 +186,8 @@ func (c *ethConnector) getErrorInfo(ctx context.Context, transactionHash string,
 		if err == nil {
 			errorMessage = value.Children[0].Value.(string)
 		}
+	} else if len(returnDataBytes) > 4 && bytes.Equal(returnDataBytes[0:4], defaultPanicID) {
+		errorMessage = formatPanic(ctx, returnDataBytes)
 	}
 
 	// Otherwise we can't decode it, so put it directly in the error
@@ -184,7 +201,12 @@ func (c *ethConnector) getErrorInfo(ctx context.Context, transactionHash string,
 	return &revertReason, &errorMessage
 }
 
-func (c *ethConnector) TransactionReceipt(ctx context.Context, req *ffcapi.TransactionReceiptRequest) (_ *ffcapi.TransactionReceiptResponse, _ ffcapi.ErrorReason, err error) {
+func (c *ethConnector) TransactionReceipt(ctx context.Context, req *ffcapi.TransactionReceiptRequest) (*ffcapi.TransactionReceiptResponse, ffcapi.ErrorReason, error) {
+	ctx = withCorrelationIDs(ctx)
+	return c.transactionReceipt(ctx, req, false)
+}
+
+func (c *ethConnector) transactionReceipt(ctx context.Context, req *ffcapi.TransactionReceiptRequest, private bool) (_ *ffcapi.TransactionReceiptResponse, _ ffcapi.ErrorReason, err error) {
 
 	var filters []*eventFilter
 	var methods []*abi.Entry
@@ -207,20 +229,54 @@ func (c *ethConnector) TransactionReceipt(ctx context.Context, req *ffcapi.Trans
 
 	// Get the receipt in the back-end JSON/RPC format
 	var ethReceipt *txReceiptJSONRPC
-	rpcErr := c.backend.CallRPC(ctx, &ethReceipt, "eth_getTransactionReceipt", req.TransactionHash)
+	var rpcErr *rpcbackend.RPCError
+	if private {
+		rpcErr = c.backend.CallRPC(ctx, &ethReceipt, "eth_getPrivateTransactionReceipt", req.TransactionHash)
+	} else {
+		rpcErr = c.readBackendForTx(req.TransactionHash).CallRPC(ctx, &ethReceipt, "eth_getTransactionReceipt", req.TransactionHash)
+	}
 	if rpcErr != nil {
 		return nil, "", rpcErr.Error()
 	}
 	if ethReceipt == nil {
 		return nil, ffcapi.ErrorReasonNotFound, i18n.NewError(ctx, msgs.MsgReceiptNotAvailable, req.TransactionHash)
 	}
-	isSuccess := (ethReceipt.Status != nil && ethReceipt.Status.BigInt().Int64() > 0)
+	var confirmations *ReceiptConfirmations
+	if !private {
+		var reason ffcapi.ErrorReason
+		if confirmations, reason, err = c.checkReceiptConfirmations(ctx, req.TransactionHash, ethReceipt); err != nil {
+			return nil, reason, err
+		}
+		if reason, err := c.checkReceiptFinality(ctx, req.TransactionHash, ethReceipt); err != nil {
+			return nil, reason, err
+		}
+		if reason, err := c.crossVerifyReceipt(ctx, req.TransactionHash, ethReceipt); err != nil {
+			return nil, reason, err
+		}
+	}
+	isSuccess, statusFallback, err := c.receiptSuccess(ctx, req.TransactionHash, ethReceipt)
+	if err != nil {
+		return nil, "", err
+	}
 
 	var returnDataString *string
 	var transactionErrorMessage *string
+	var errorDetails *ErrorDetails
+	var contractMetadata *ContractMetadata
+	var gasReport *ReceiptGasReport
+	var retryables []*RetryableTicket
 
 	if !isSuccess {
 		returnDataString, transactionErrorMessage = c.getErrorInfo(ctx, req.TransactionHash, ethReceipt.RevertReason)
+		errorDetails = receiptErrorDetails(returnDataString, transactionErrorMessage)
+	} else if !private {
+		contractMetadata = c.deployedContractMetadata(ctx, ethReceipt)
+	}
+	if c.gasUsage != nil && !private {
+		gasReport = c.receiptGasReport(ctx, ethReceipt, isSuccess)
+	}
+	if c.receiptRetryables && !private {
+		retryables = c.receiptRetryableTickets(ctx, ethReceipt)
 	}
 
 	fullReceipt, _ := json.Marshal(&receiptExtraInfo{
@@ -230,8 +286,14 @@ func (c *ethConnector) TransactionReceipt(ctx context.Context, req *ffcapi.Trans
 		To:                ethReceipt.To,
 		GasUsed:           (*fftypes.FFBigInt)(ethReceipt.GasUsed),
 		Status:            (*fftypes.FFBigInt)(ethReceipt.Status),
+		StatusFallback:    statusFallback,
 		ReturnValue:       returnDataString,
 		ErrorMessage:      transactionErrorMessage,
+		ErrorDetails:      errorDetails,
+		ContractMetadata:  contractMetadata,
+		GasReport:         gasReport,
+		Confirmations:     confirmations,
+		Retryables:        retryables,
 	})
 
 	var txIndex int64
@@ -246,7 +308,7 @@ func (c *ethConnector) TransactionReceipt(ctx context.Context, req *ffcapi.Trans
 			BlockHash:        ethReceipt.BlockHash.String(),
 			Success:          isSuccess,
 			ProtocolID:       ProtocolIDForReceipt((*fftypes.FFBigInt)(ethReceipt.BlockNumber), fftypes.NewFFBigInt(txIndex)),
-			ExtraInfo:        fftypes.JSONAnyPtrBytes(fullReceipt),
+			ExtraInfo:        strictJSONAny(c.strictNumbers, fftypes.JSONAnyPtrBytes(fullReceipt)),
 		},
 	}
 	if req.IncludeLogs {
@@ -261,6 +323,7 @@ func (c *ethConnector) TransactionReceipt(ctx context.Context, req *ffcapi.Trans
 		ee := &eventEnricher{
 			connector:     c,
 			extractSigner: req.ExtractSigner,
+			strictNumbers: c.strictNumbers,
 		}
 		for _, ethLog := range ethReceipt.Logs {
 			var bestMatch *ffcapi.Event
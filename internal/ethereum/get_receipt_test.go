@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-evmconnect/mocks/rpcbackendmocks"
 	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
 	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
 	"github.com/stretchr/testify/assert"
@@ -120,6 +121,14 @@ const sampleTransactionTraceNoReturnValue = `{
 }
 `
 
+const sampleTransactionTraceGethPanic = `{
+	"gas": 23512,
+	"failed": true,
+	"returnValue": "4e487b710000000000000000000000000000000000000000000000000000000000000012",
+	"structLogs": []
+}
+`
+
 const sampleTransactionTraceGethInvalidHex = `{
 	"gas": 23512,
 	"failed": true,
@@ -248,6 +257,32 @@ func TestGetReceiptOkSuccess(t *testing.T) {
 
 }
 
+func TestGetReceiptUsesReadBackend(t *testing.T) {
+
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+	mReadRPC := &rpcbackendmocks.Backend{}
+	c.readOnlyBackend = mReadRPC
+
+	mReadRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionReceipt", "0x7d48ae971faf089878b57e3c28e3035540d34f38af395958d2c73c36c57c83a2").
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			err := json.Unmarshal([]byte(sampleJSONRPCReceipt), args[1])
+			assert.NoError(t, err)
+		})
+
+	var req ffcapi.TransactionReceiptRequest
+	err := json.Unmarshal([]byte(sampleGetReceipt), &req)
+	assert.NoError(t, err)
+	res, reason, err := c.TransactionReceipt(ctx, &req)
+	assert.NoError(t, err)
+	assert.Empty(t, reason)
+	assert.True(t, res.Success)
+
+	mReadRPC.AssertExpectations(t)
+	mRPC.AssertNotCalled(t, "CallRPC", mock.Anything, mock.Anything, "eth_getTransactionReceipt", mock.Anything)
+}
+
 func TestGetReceiptNotFound(t *testing.T) {
 
 	ctx, c, mRPC, done := newTestConnector(t)
@@ -326,6 +361,36 @@ func TestGetReceiptErrorReasonGeth(t *testing.T) {
 
 }
 
+func TestGetReceiptErrorReasonGethPanic(t *testing.T) {
+
+	ctx, c, mRPC, done := newTestConnector(t)
+	c.traceTXForRevertReason = true
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionReceipt", mock.Anything).
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			err := json.Unmarshal([]byte(sampleJSONRPCReceiptFailed), args[1])
+			assert.NoError(t, err)
+		})
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "debug_traceTransaction", mock.Anything).
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			err := json.Unmarshal([]byte(sampleTransactionTraceGethPanic), args[1])
+			assert.NoError(t, err)
+		})
+	var req ffcapi.TransactionReceiptRequest
+	err := json.Unmarshal([]byte(sampleGetReceipt), &req)
+	assert.NoError(t, err)
+	res, reason, err := c.TransactionReceipt(ctx, &req)
+	assert.NoError(t, err)
+	assert.Empty(t, reason)
+
+	assert.False(t, res.Success)
+	assert.Contains(t, res.ExtraInfo.String(), "Panic(0x12): division or modulo by zero")
+
+}
+
 func TestGetReceiptErrorReasonBesu(t *testing.T) {
 
 	ctx, c, mRPC, done := newTestConnector(t)
@@ -572,6 +637,7 @@ func TestGetReceiptEventDecodeOK(t *testing.T) {
 	ctx, c, mRPC, done := newTestConnector(t)
 	defer done()
 
+	mockNodeInfoUnsupported(mRPC)
 	mRPC.On("CallRPC", mock.Anything, mock.Anything, "net_version", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
 		c.chainID = "12345"
 	}).Once()
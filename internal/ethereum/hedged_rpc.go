@@ -0,0 +1,155 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+)
+
+const hedgeLatencySamples = 100
+
+// hedgedRPC sends each request to the first backend, and if no response has been received
+// within a delay derived from the recent latency of that backend, sends a duplicate to
+// the second backend. The first successful response is returned.
+type hedgedRPC struct {
+	first      rpcbackend.RPC
+	second     rpcbackend.RPC
+	percentile float64
+	minDelay   time.Duration
+
+	mux       sync.Mutex
+	latencies []time.Duration
+	nextIdx   int
+}
+
+type hedgedAttempt struct {
+	result json.RawMessage
+	err    *rpcbackend.RPCError
+}
+
+func newHedgedRPC(first, second rpcbackend.RPC, percentile float64, minDelay time.Duration) *hedgedRPC {
+	return &hedgedRPC{
+		first:      first,
+		second:     second,
+		percentile: percentile,
+		minDelay:   minDelay,
+		latencies:  make([]time.Duration, 0, hedgeLatencySamples),
+	}
+}
+
+func (h *hedgedRPC) recordLatency(d time.Duration) {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+	if len(h.latencies) < hedgeLatencySamples {
+		h.latencies = append(h.latencies, d)
+	} else {
+		h.latencies[h.nextIdx] = d
+	}
+	h.nextIdx = (h.nextIdx + 1) % hedgeLatencySamples
+}
+
+// hedgeDelay returns the configured percentile of the recent latencies of the first backend,
+// with the minimum delay as a floor
+func (h *hedgedRPC) hedgeDelay() time.Duration {
+	h.mux.Lock()
+	sorted := make([]time.Duration, len(h.latencies))
+	copy(sorted, h.latencies)
+	h.mux.Unlock()
+	if len(sorted) == 0 {
+		return h.minDelay
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	idx := int(float64(len(sorted)-1) * h.percentile / 100)
+	if sorted[idx] > h.minDelay {
+		return sorted[idx]
+	}
+	return h.minDelay
+}
+
+func (h *hedgedRPC) CallRPC(ctx context.Context, result interface{}, method string, params ...interface{}) *rpcbackend.RPCError {
+	// The losing attempt is cancelled once we have an answer
+	attemptCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// The latency of the first backend is sampled whatever the outcome. When it loses to the hedge, or the
+	// caller gives up, the time so far is recorded as a lower bound for the sample, so slow responses still
+	// raise the delay rather than only the fast ones being counted.
+	var recordOnce sync.Once
+	startTime := time.Now()
+	recordFirst := func() {
+		recordOnce.Do(func() { h.recordLatency(time.Since(startTime)) })
+	}
+	defer recordFirst()
+
+	results := make(chan *hedgedAttempt, 2)
+	go func() {
+		a := &hedgedAttempt{}
+		a.err = h.first.CallRPC(attemptCtx, &a.result, method, params...)
+		recordFirst()
+		results <- a
+	}()
+
+	delay := h.hedgeDelay()
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	var a *hedgedAttempt
+	select {
+	case a = <-results:
+		// Errors from the first backend before the hedge fires are returned directly,
+		// as they are most likely genuine answers (such as reverts)
+		return h.completeCall(a, result)
+	case <-timer.C:
+		log.L(ctx).Debugf("Hedging %s call to second endpoint after %s", method, delay)
+		go func() {
+			a := &hedgedAttempt{}
+			a.err = h.second.CallRPC(attemptCtx, &a.result, method, params...)
+			results <- a
+		}()
+	case <-ctx.Done():
+		return &rpcbackend.RPCError{Message: ctx.Err().Error()}
+	}
+
+	for i := 0; i < 2; i++ {
+		select {
+		case a = <-results:
+			if a.err == nil {
+				return h.completeCall(a, result)
+			}
+		case <-ctx.Done():
+			return &rpcbackend.RPCError{Message: ctx.Err().Error()}
+		}
+	}
+	return a.err
+}
+
+func (h *hedgedRPC) completeCall(a *hedgedAttempt, result interface{}) *rpcbackend.RPCError {
+	if a.err != nil {
+		return a.err
+	}
+	if err := json.Unmarshal(a.result, result); err != nil {
+		return &rpcbackend.RPCError{Code: int64(rpcbackend.RPCCodeParseError), Message: err.Error()}
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-evmconnect/mocks/rpcbackendmocks"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func mockRawResult(m *rpcbackendmocks.Backend, method, result string) *mock.Call {
+	return m.On("CallRPC", mock.Anything, mock.Anything, method, mock.Anything).
+		Run(func(args mock.Arguments) {
+			*(args[1].(*json.RawMessage)) = json.RawMessage(result)
+		}).
+		Return(nil)
+}
+
+func TestHedgedRPCFirstRespondsQuickly(t *testing.T) {
+	mFirst, mSecond := &rpcbackendmocks.Backend{}, &rpcbackendmocks.Backend{}
+	h := newHedgedRPC(mFirst, mSecond, 95, 1*time.Hour)
+
+	mockRawResult(mFirst, "eth_call", `"0x1234"`)
+
+	var res ethtypes.HexBytes0xPrefix
+	err := h.CallRPC(context.Background(), &res, "eth_call", "latest")
+	assert.Nil(t, err)
+	assert.Equal(t, "0x1234", res.String())
+	assert.Len(t, h.latencies, 1)
+
+	mFirst.AssertExpectations(t)
+	mSecond.AssertExpectations(t)
+}
+
+func TestHedgedRPCSecondWins(t *testing.T) {
+	mFirst, mSecond := &rpcbackendmocks.Backend{}, &rpcbackendmocks.Backend{}
+	h := newHedgedRPC(mFirst, mSecond, 95, 1*time.Millisecond)
+
+	release := make(chan time.Time)
+	defer close(release)
+	mockRawResult(mFirst, "eth_call", `"0x1111"`).WaitUntil(release)
+	mockRawResult(mSecond, "eth_call", `"0x2222"`)
+
+	var res ethtypes.HexBytes0xPrefix
+	err := h.CallRPC(context.Background(), &res, "eth_call", "latest")
+	assert.Nil(t, err)
+	assert.Equal(t, "0x2222", res.String())
+
+	// The first attempt lost, but is sampled with the time it had taken when the second won
+	assert.Len(t, h.latencies, 1)
+	assert.GreaterOrEqual(t, h.latencies[0], 1*time.Millisecond)
+}
+
+func TestHedgedRPCFirstSucceedsAfterSecondFails(t *testing.T) {
+	mFirst, mSecond := &rpcbackendmocks.Backend{}, &rpcbackendmocks.Backend{}
+	h := newHedgedRPC(mFirst, mSecond, 95, 1*time.Millisecond)
+
+	secondDone := make(chan time.Time)
+	mockRawResult(mFirst, "eth_call", `"0x1111"`).WaitUntil(secondDone)
+	mSecond.On("CallRPC", mock.Anything, mock.Anything, "eth_call", mock.Anything).
+		Run(func(args mock.Arguments) { close(secondDone) }).
+		Return(&rpcbackend.RPCError{Message: "pop"})
+
+	var res ethtypes.HexBytes0xPrefix
+	err := h.CallRPC(context.Background(), &res, "eth_call", "latest")
+	assert.Nil(t, err)
+	assert.Equal(t, "0x1111", res.String())
+}
+
+func TestHedgedRPCBothFail(t *testing.T) {
+	mFirst, mSecond := &rpcbackendmocks.Backend{}, &rpcbackendmocks.Backend{}
+	h := newHedgedRPC(mFirst, mSecond, 95, 1*time.Millisecond)
+
+	mFirst.On("CallRPC", mock.Anything, mock.Anything, "eth_call", mock.Anything).
+		After(10 * time.Millisecond).
+		Return(&rpcbackend.RPCError{Message: "pop1"})
+	mSecond.On("CallRPC", mock.Anything, mock.Anything, "eth_call", mock.Anything).
+		Return(&rpcbackend.RPCError{Message: "pop2"})
+
+	var res ethtypes.HexBytes0xPrefix
+	err := h.CallRPC(context.Background(), &res, "eth_call", "latest")
+	assert.Regexp(t, "pop", err.Error())
+	assert.Len(t, h.latencies, 1)
+	assert.GreaterOrEqual(t, h.latencies[0], 10*time.Millisecond)
+}
+
+func TestHedgedRPCFirstFailsBeforeHedge(t *testing.T) {
+	mFirst, mSecond := &rpcbackendmocks.Backend{}, &rpcbackendmocks.Backend{}
+	h := newHedgedRPC(mFirst, mSecond, 95, 1*time.Hour)
+
+	mFirst.On("CallRPC", mock.Anything, mock.Anything, "eth_call", mock.Anything).
+		Return(&rpcbackend.RPCError{Message: "execution reverted"})
+
+	var res ethtypes.HexBytes0xPrefix
+	err := h.CallRPC(context.Background(), &res, "eth_call", "latest")
+	assert.Regexp(t, "execution reverted", err.Error())
+	mSecond.AssertNotCalled(t, "CallRPC", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestHedgedRPCBadResult(t *testing.T) {
+	mFirst, mSecond := &rpcbackendmocks.Backend{}, &rpcbackendmocks.Backend{}
+	h := newHedgedRPC(mFirst, mSecond, 95, 1*time.Hour)
+
+	mockRawResult(mFirst, "eth_call", `{"not":"bytes"}`)
+
+	var res ethtypes.HexBytes0xPrefix
+	err := h.CallRPC(context.Background(), &res, "eth_call", "latest")
+	assert.Equal(t, int64(rpcbackend.RPCCodeParseError), err.Code)
+}
+
+func TestHedgedRPCContextCancelled(t *testing.T) {
+	mFirst, mSecond := &rpcbackendmocks.Backend{}, &rpcbackendmocks.Backend{}
+	h := newHedgedRPC(mFirst, mSecond, 95, 1*time.Hour)
+
+	release := make(chan time.Time)
+	defer close(release)
+	mockRawResult(mFirst, "eth_call", `"0x1111"`).WaitUntil(release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var res ethtypes.HexBytes0xPrefix
+	err := h.CallRPC(ctx, &res, "eth_call", "latest")
+	assert.Regexp(t, "canceled", err.Error())
+	assert.Len(t, h.latencies, 1)
+
+	h.minDelay = 1 * time.Millisecond
+	mockRawResult(mSecond, "eth_call", `"0x2222"`).WaitUntil(release)
+	ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err = h.CallRPC(ctx, &res, "eth_call", "latest")
+	assert.Regexp(t, "deadline", err.Error())
+}
+
+func TestHedgedRPCDelayPercentile(t *testing.T) {
+	h := newHedgedRPC(nil, nil, 90, 5*time.Millisecond)
+	assert.Equal(t, 5*time.Millisecond, h.hedgeDelay())
+
+	for i := 1; i <= hedgeLatencySamples+10; i++ {
+		h.recordLatency(time.Duration(i) * time.Millisecond)
+	}
+	assert.Len(t, h.latencies, hedgeLatencySamples)
+	// samples 11ms..110ms retained
+	assert.Equal(t, 100*time.Millisecond, h.hedgeDelay())
+
+	h.minDelay = 1 * time.Second
+	assert.Equal(t, 1*time.Second, h.hedgeDelay())
+}
+
+func TestReadBackendHedging(t *testing.T) {
+	_, c, mRPC, done := newTestConnector(t, func(conf config.Section) {
+		conf.Set(ReadLagCheckInterval, "0")
+		conf.Set(ReadHedgingEnabled, true)
+		conf.SubSection(ReadEndpointConfig).Set(ffresty.HTTPConfigURL, "http://localhost:8546")
+	})
+	defer done()
+
+	assert.NotNil(t, c.hedgedReadBackend)
+	assert.Equal(t, c.hedgedReadBackend, c.readBackend())
+	assert.Equal(t, c.hedgedReadBackend, c.readBackendForTx("0xaaaa"))
+	c.readBehind = true
+	assert.Equal(t, mRPC, c.readBackend())
+}
+
+func TestReadBackendHedgingBadPercentile(t *testing.T) {
+	config.RootConfigReset()
+	conf := config.RootSection("unittest")
+	InitConfig(conf)
+	conf.Set(ffresty.HTTPConfigURL, "http://localhost:8545")
+	conf.SubSection(ReadEndpointConfig).Set(ffresty.HTTPConfigURL, "http://localhost:8546")
+	conf.Set(ReadHedgingEnabled, true)
+	conf.Set(ReadHedgingPercentile, 101)
+	_, err := NewEthereumConnector(context.Background(), conf)
+	assert.Regexp(t, "FF23059", err)
+}
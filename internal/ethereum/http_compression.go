@@ -0,0 +1,84 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// compressionOptions control gzip on the HTTP JSON/RPC transport. Response compression is negotiated
+// with Accept-Encoding, and only used if the node supports it. Request compression cannot be negotiated,
+// so is only enabled for nodes (or gateways in front of them) known to accept gzip request bodies.
+type compressionOptions struct {
+	responses      bool
+	requests       bool
+	requestMinSize int64
+}
+
+// gzipRequestTransport compresses request bodies of at least the minimum size, as small requests
+// are not worth the CPU cost - and can be larger once compressed
+type gzipRequestTransport struct {
+	next    http.RoundTripper
+	minSize int64
+}
+
+func (t *gzipRequestTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body == nil || req.Header.Get("Content-Encoding") != "" || req.ContentLength < t.minSize {
+		return t.next.RoundTrip(req)
+	}
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	_, _ = gz.Write(body) // writes to a bytes.Buffer cannot fail
+	_ = gz.Close()
+
+	// Clone rather than modify the request, as required of a RoundTripper
+	gzReq := req.Clone(req.Context())
+	gzReq.Header.Set("Content-Encoding", "gzip")
+	gzReq.ContentLength = int64(compressed.Len())
+	gzReq.Body = io.NopCloser(&compressed)
+	gzReq.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(compressed.Bytes())), nil
+	}
+	return t.next.RoundTrip(gzReq)
+}
+
+// applyCompression configures the transport of a client built by ffresty. The standard transport
+// adds Accept-Encoding: gzip to each request, and transparently decompresses the response, unless
+// compression is disabled.
+func applyCompression(client *resty.Client, opts compressionOptions) {
+	httpClient := client.GetClient()
+	if transport, ok := httpClient.Transport.(*http.Transport); ok {
+		transport.DisableCompression = !opts.responses
+	}
+	if opts.requests {
+		next := httpClient.Transport
+		if next == nil {
+			next = http.DefaultTransport
+		}
+		httpClient.Transport = &gzipRequestTransport{next: next, minSize: opts.requestMinSize}
+	}
+}
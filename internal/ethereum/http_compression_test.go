@@ -0,0 +1,152 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/stretchr/testify/assert"
+)
+
+type testGzipRequest struct {
+	acceptEncoding  string
+	contentEncoding string
+	body            *rpcbackend.RPCRequest
+}
+
+// newTestGzipNode is a JSON/RPC server that accepts gzip request bodies, and gzips its responses
+// when the client accepts them. The result of each request is a string of its first parameter.
+func newTestGzipNode(t *testing.T) (string, chan *testGzipRequest) {
+	requests := make(chan *testGzipRequest, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := &testGzipRequest{
+			acceptEncoding:  r.Header.Get("Accept-Encoding"),
+			contentEncoding: r.Header.Get("Content-Encoding"),
+		}
+		var body io.Reader = r.Body
+		if req.contentEncoding == "gzip" {
+			gzr, err := gzip.NewReader(r.Body)
+			assert.NoError(t, err)
+			body = gzr
+		}
+		assert.NoError(t, json.NewDecoder(body).Decode(&req.body))
+		requests <- req
+
+		w.Header().Set("Content-Type", "application/json")
+		var out io.Writer = w
+		if strings.Contains(req.acceptEncoding, "gzip") {
+			w.Header().Set("Content-Encoding", "gzip")
+			gzw := gzip.NewWriter(w)
+			defer gzw.Close()
+			out = gzw
+		}
+		fmt.Fprintf(out, `{"jsonrpc":"2.0","id":%s,"result":%s}`, req.body.ID, req.body.Params[0])
+	}))
+	t.Cleanup(server.Close)
+	return server.URL, requests
+}
+
+func newTestCompressionClient(t *testing.T, url string, opts compressionOptions) rpcbackend.Backend {
+	conf := config.RootSection("compression_test")
+	ffresty.InitConfig(conf)
+	conf.Set(ffresty.HTTPConfigURL, url)
+	client, err := newRPCClient(context.Background(), conf, rpcClientOptions{compression: opts})
+	assert.NoError(t, err)
+	return client
+}
+
+func TestCompressionResponses(t *testing.T) {
+	url, requests := newTestGzipNode(t)
+	client := newTestCompressionClient(t, url, compressionOptions{responses: true})
+
+	var result string
+	rpcErr := client.CallRPC(context.Background(), &result, "echo", strings.Repeat("a", 2048))
+	assert.Nil(t, rpcErr)
+	assert.Equal(t, strings.Repeat("a", 2048), result)
+
+	req := <-requests
+	assert.Equal(t, "gzip", req.acceptEncoding)
+	assert.Empty(t, req.contentEncoding)
+}
+
+func TestCompressionDisabled(t *testing.T) {
+	url, requests := newTestGzipNode(t)
+	client := newTestCompressionClient(t, url, compressionOptions{})
+
+	var result string
+	rpcErr := client.CallRPC(context.Background(), &result, "echo", "hello")
+	assert.Nil(t, rpcErr)
+	assert.Equal(t, "hello", result)
+
+	req := <-requests
+	assert.Empty(t, req.acceptEncoding)
+	assert.Empty(t, req.contentEncoding)
+}
+
+func TestCompressionRequests(t *testing.T) {
+	url, requests := newTestGzipNode(t)
+	client := newTestCompressionClient(t, url, compressionOptions{responses: true, requests: true, requestMinSize: 1024})
+
+	// Small requests are sent uncompressed
+	var result string
+	rpcErr := client.CallRPC(context.Background(), &result, "echo", "hello")
+	assert.Nil(t, rpcErr)
+	assert.Equal(t, "hello", result)
+	req := <-requests
+	assert.Empty(t, req.contentEncoding)
+
+	large := strings.Repeat("0123456789", 200)
+	rpcErr = client.CallRPC(context.Background(), &result, "echo", large)
+	assert.Nil(t, rpcErr)
+	assert.Equal(t, large, result)
+	req = <-requests
+	assert.Equal(t, "gzip", req.contentEncoding)
+	assert.Equal(t, "gzip", req.acceptEncoding)
+}
+
+type errorReader struct{}
+
+func (errorReader) Read([]byte) (int, error) { return 0, errors.New("pop") }
+
+func TestGzipRequestTransportReadFail(t *testing.T) {
+	transport := &gzipRequestTransport{next: http.DefaultTransport}
+	req, err := http.NewRequest(http.MethodPost, "http://localhost", io.NopCloser(errorReader{}))
+	assert.NoError(t, err)
+	_, err = transport.RoundTrip(req)
+	assert.Regexp(t, "pop", err)
+}
+
+func TestNewRPCClientOptions(t *testing.T) {
+	conf := config.RootSection("compression_opts_test")
+	InitConfig(conf)
+	opts, err := newRPCClientOptions(context.Background(), conf)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(50), opts.maxConcurrentRequests)
+	assert.Equal(t, compressionOptions{responses: true, requests: false, requestMinSize: 1024}, opts.compression)
+}
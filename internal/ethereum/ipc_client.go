@@ -0,0 +1,232 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+)
+
+// IPCURLScheme is the URL scheme that selects the IPC transport, with the path of the node's IPC socket.
+// For example "unix:///var/run/geth/geth.ipc"
+const IPCURLScheme = "unix"
+
+// ipcClient is a JSON/RPC client over the IPC socket of a co-located node. A single connection carries
+// all requests, with responses matched back to requests by ID. The connection is established on first
+// use, and re-established on the next request after it fails.
+type ipcClient struct {
+	path           string
+	dialTimeout    time.Duration
+	requestTimeout time.Duration
+	requestCounter atomic.Int64
+
+	mux     sync.Mutex
+	conn    net.Conn
+	pending map[string]chan *rpcbackend.RPCResponse
+	closed  bool
+}
+
+func newIPCClient(path string, dialTimeout, requestTimeout time.Duration) *ipcClient {
+	return &ipcClient{
+		path:           path,
+		dialTimeout:    dialTimeout,
+		requestTimeout: requestTimeout,
+		pending:        make(map[string]chan *rpcbackend.RPCResponse),
+	}
+}
+
+func (ic *ipcClient) CallRPC(ctx context.Context, result interface{}, method string, params ...interface{}) *rpcbackend.RPCError {
+	rpcReq := &rpcbackend.RPCRequest{
+		JSONRpc: "2.0",
+		Method:  method,
+		Params:  make([]*fftypes.JSONAny, len(params)),
+	}
+	for i, param := range params {
+		b, err := json.Marshal(param)
+		if err != nil {
+			return rpcbackend.NewRPCError(ctx, rpcbackend.RPCCodeInvalidRequest, msgs.MsgIPCInvalidParam, i, method, err)
+		}
+		rpcReq.Params[i] = fftypes.JSONAnyPtrBytes(b)
+	}
+	res, err := ic.SyncRequest(ctx, rpcReq)
+	if err != nil {
+		if res != nil && res.Error != nil && res.Error.Code != 0 {
+			return res.Error
+		}
+		return &rpcbackend.RPCError{Code: int64(rpcbackend.RPCCodeInternalError), Message: err.Error()}
+	}
+	if err := json.Unmarshal(res.Result.Bytes(), &result); err != nil {
+		return rpcbackend.NewRPCError(ctx, rpcbackend.RPCCodeParseError, msgs.MsgIPCResultParseFailed, result, err)
+	}
+	return nil
+}
+
+// SyncRequest sends a request over the IPC connection, and waits for the response with the same ID.
+// As with the HTTP client, the response is populated on all return paths.
+func (ic *ipcClient) SyncRequest(ctx context.Context, rpcReq *rpcbackend.RPCRequest) (*rpcbackend.RPCResponse, error) {
+	// The back-end request ID is always our own, as front-end IDs from concurrent callers might clash
+	beReq := *rpcReq
+	beReq.JSONRpc = "2.0"
+	reqID := fmt.Sprintf(`"%.9d"`, ic.requestCounter.Add(1))
+	beReq.ID = fftypes.JSONAnyPtr(reqID)
+
+	log.L(ctx).Debugf("RPC[%s] --> %s (ipc)", reqID, rpcReq.Method)
+	startTime := time.Now()
+	resChan, err := ic.send(ctx, reqID, &beReq)
+	if err != nil {
+		log.L(ctx).Errorf("RPC[%s] <-- ERROR: %s", reqID, err)
+		return rpcbackend.RPCErrorResponse(err, rpcReq.ID, rpcbackend.RPCCodeInternalError), err
+	}
+
+	var timeout <-chan time.Time
+	if ic.requestTimeout > 0 {
+		timer := time.NewTimer(ic.requestTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	var rpcRes *rpcbackend.RPCResponse
+	select {
+	case rpcRes = <-resChan:
+	case <-timeout:
+		err = i18n.NewError(ctx, msgs.MsgIPCRequestTimeout, ic.requestTimeout)
+	case <-ctx.Done():
+		err = i18n.NewError(ctx, msgs.MsgIPCRequestFailed, ctx.Err())
+	}
+	if err == nil && rpcRes == nil {
+		err = i18n.NewError(ctx, msgs.MsgIPCConnectionLost, ic.path)
+	}
+	if err != nil {
+		ic.cancel(reqID)
+		log.L(ctx).Errorf("RPC[%s] <-- ERROR: %s", reqID, err)
+		return rpcbackend.RPCErrorResponse(err, rpcReq.ID, rpcbackend.RPCCodeInternalError), err
+	}
+
+	// Restore the original ID
+	rpcRes.ID = rpcReq.ID
+	if rpcRes.Error != nil && rpcRes.Error.Code != 0 {
+		log.L(ctx).Errorf("RPC[%s] <-- %s", reqID, rpcRes.Message())
+		return rpcRes, errors.New(rpcRes.Message())
+	}
+	log.L(ctx).Infof("RPC[%s] <-- %s OK (%.2fms)", reqID, rpcReq.Method, float64(time.Since(startTime))/float64(time.Millisecond))
+	if rpcRes.Result == nil {
+		rpcRes.Result = fftypes.JSONAnyPtr(fftypes.NullString)
+	}
+	return rpcRes, nil
+}
+
+// send registers the request as pending and writes it to the connection, connecting first if required
+func (ic *ipcClient) send(ctx context.Context, reqID string, rpcReq *rpcbackend.RPCRequest) (chan *rpcbackend.RPCResponse, error) {
+	b, err := json.Marshal(rpcReq)
+	if err != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgIPCRequestFailed, err)
+	}
+
+	ic.mux.Lock()
+	defer ic.mux.Unlock()
+	if ic.conn == nil {
+		conn, err := net.DialTimeout("unix", ic.path, ic.dialTimeout)
+		if err != nil {
+			return nil, i18n.NewError(ctx, msgs.MsgIPCConnectFailed, ic.path, err)
+		}
+		ic.conn = conn
+		go ic.readLoop(conn)
+	}
+	resChan := make(chan *rpcbackend.RPCResponse, 1)
+	ic.pending[reqID] = resChan
+	if _, err := ic.conn.Write(b); err != nil {
+		delete(ic.pending, reqID)
+		ic.disconnect(ic.conn)
+		return nil, i18n.NewError(ctx, msgs.MsgIPCRequestFailed, err)
+	}
+	return resChan, nil
+}
+
+func (ic *ipcClient) cancel(reqID string) {
+	ic.mux.Lock()
+	defer ic.mux.Unlock()
+	delete(ic.pending, reqID)
+	ic.closeIfIdle()
+}
+
+// readLoop dispatches responses to pending requests until the connection fails. Messages
+// that do not match a pending request (such as subscription notifications) are discarded.
+func (ic *ipcClient) readLoop(conn net.Conn) {
+	decoder := json.NewDecoder(conn)
+	for {
+		var rpcRes *rpcbackend.RPCResponse
+		if err := decoder.Decode(&rpcRes); err != nil {
+			log.L(context.Background()).Debugf("IPC connection to %s closed: %s", ic.path, err)
+			ic.mux.Lock()
+			ic.disconnect(conn)
+			ic.mux.Unlock()
+			return
+		}
+		if rpcRes == nil || rpcRes.ID == nil {
+			continue
+		}
+		ic.mux.Lock()
+		if resChan, ok := ic.pending[rpcRes.ID.String()]; ok {
+			delete(ic.pending, rpcRes.ID.String())
+			resChan <- rpcRes
+		}
+		ic.closeIfIdle()
+		ic.mux.Unlock()
+	}
+}
+
+// disconnect must be called holding the mutex. All requests pending on the connection fail, and the
+// next request makes a new connection.
+func (ic *ipcClient) disconnect(conn net.Conn) {
+	_ = conn.Close()
+	if ic.conn != conn {
+		return
+	}
+	ic.conn = nil
+	for reqID, resChan := range ic.pending {
+		close(resChan)
+		delete(ic.pending, reqID)
+	}
+}
+
+// closeIfIdle must be called holding the mutex, and completes a close once the last pending request is done
+func (ic *ipcClient) closeIfIdle() {
+	if ic.closed && ic.conn != nil && len(ic.pending) == 0 {
+		ic.disconnect(ic.conn)
+	}
+}
+
+// close is called when the client has been replaced by a configuration reload. Requests already
+// in-flight complete before the connection is closed, including those that had not yet been written
+// to the connection when the client was replaced.
+func (ic *ipcClient) close() {
+	ic.mux.Lock()
+	defer ic.mux.Unlock()
+	ic.closed = true
+	ic.closeIfIdle()
+}
@@ -0,0 +1,310 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/stretchr/testify/assert"
+)
+
+type testIPCNode struct {
+	path     string
+	listener net.Listener
+	mux      sync.Mutex
+	conns    []net.Conn
+	requests chan *rpcbackend.RPCRequest
+}
+
+// newTestIPCNode listens on a unix socket, passing each request to the handler. The handler writes
+// the response - or not - so tests can reorder and drop responses.
+func newTestIPCNode(t *testing.T, handler func(conn net.Conn, req *rpcbackend.RPCRequest)) *testIPCNode {
+	node := &testIPCNode{
+		path:     filepath.Join(t.TempDir(), "geth.ipc"),
+		requests: make(chan *rpcbackend.RPCRequest, 100),
+	}
+	var err error
+	node.listener, err = net.Listen("unix", node.path)
+	assert.NoError(t, err)
+	go func() {
+		for {
+			conn, err := node.listener.Accept()
+			if err != nil {
+				return
+			}
+			node.mux.Lock()
+			node.conns = append(node.conns, conn)
+			node.mux.Unlock()
+			go func() {
+				decoder := json.NewDecoder(conn)
+				for {
+					var req *rpcbackend.RPCRequest
+					if err := decoder.Decode(&req); err != nil {
+						return
+					}
+					node.requests <- req
+					handler(conn, req)
+				}
+			}()
+		}
+	}()
+	t.Cleanup(node.close)
+	return node
+}
+
+func (node *testIPCNode) close() {
+	_ = node.listener.Close()
+	node.mux.Lock()
+	defer node.mux.Unlock()
+	for _, conn := range node.conns {
+		_ = conn.Close()
+	}
+}
+
+func (node *testIPCNode) connCount() int {
+	node.mux.Lock()
+	defer node.mux.Unlock()
+	return len(node.conns)
+}
+
+func writeIPCResponse(conn net.Conn, id *fftypes.JSONAny, result string) {
+	_, _ = fmt.Fprintf(conn, `{"jsonrpc":"2.0","id":%s,"result":%s}`, id, result)
+}
+
+func TestIPCClientCallRPC(t *testing.T) {
+	node := newTestIPCNode(t, func(conn net.Conn, req *rpcbackend.RPCRequest) {
+		switch req.Method {
+		case "eth_blockNumber":
+			writeIPCResponse(conn, req.ID, `"0x3039"`)
+		case "eth_getTransactionByHash":
+			writeIPCResponse(conn, req.ID, `null`)
+		default:
+			_, _ = fmt.Fprintf(conn, `{"jsonrpc":"2.0","id":%s,"error":{"code":-32601,"message":"the method %s does not exist"}}`, req.ID, req.Method)
+		}
+	})
+	ic := newIPCClient(node.path, time.Second, time.Second)
+	defer ic.close()
+
+	var blockNumber string
+	rpcErr := ic.CallRPC(context.Background(), &blockNumber, "eth_blockNumber")
+	assert.Nil(t, rpcErr)
+	assert.Equal(t, "0x3039", blockNumber)
+
+	var tx map[string]interface{}
+	rpcErr = ic.CallRPC(context.Background(), &tx, "eth_getTransactionByHash", "0x12345")
+	assert.Nil(t, rpcErr)
+	assert.Nil(t, tx)
+
+	rpcErr = ic.CallRPC(context.Background(), &tx, "eth_unknown")
+	assert.Equal(t, int64(-32601), rpcErr.Code)
+	assert.Regexp(t, "the method eth_unknown does not exist", rpcErr.Message)
+
+	// All requests are on the one connection
+	assert.Equal(t, 1, node.connCount())
+}
+
+func TestIPCClientSyncRequestRestoresID(t *testing.T) {
+	node := newTestIPCNode(t, func(conn net.Conn, req *rpcbackend.RPCRequest) {
+		writeIPCResponse(conn, req.ID, `"0x1"`)
+	})
+	ic := newIPCClient(node.path, time.Second, time.Second)
+	defer ic.close()
+
+	res, err := ic.SyncRequest(context.Background(), &rpcbackend.RPCRequest{
+		ID:     fftypes.JSONAnyPtr(`"my-id"`),
+		Method: "eth_chainId",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `"my-id"`, res.ID.String())
+	assert.Equal(t, `"0x1"`, res.Result.String())
+
+	backendReq := <-node.requests
+	assert.Equal(t, "2.0", backendReq.JSONRpc)
+	assert.Equal(t, `"000000001"`, backendReq.ID.String())
+}
+
+func TestIPCClientOutOfOrderResponses(t *testing.T) {
+	var held []*rpcbackend.RPCRequest
+	var mux sync.Mutex
+	node := newTestIPCNode(t, func(conn net.Conn, req *rpcbackend.RPCRequest) {
+		mux.Lock()
+		defer mux.Unlock()
+		held = append(held, req)
+		if len(held) == 3 {
+			// Notifications without an ID are ignored
+			_, _ = fmt.Fprint(conn, `{"jsonrpc":"2.0","method":"eth_subscription","params":{}}`)
+			for i := len(held) - 1; i >= 0; i-- {
+				writeIPCResponse(conn, held[i].ID, held[i].Params[0].String())
+			}
+		}
+	})
+	ic := newIPCClient(node.path, time.Second, time.Second)
+	defer ic.close()
+
+	// The node holds the responses until all three requests are received, then responds in reverse
+	results := make(chan string, 3)
+	call := func(param string) {
+		var result string
+		rpcErr := ic.CallRPC(context.Background(), &result, "echo", param)
+		assert.Nil(t, rpcErr)
+		results <- fmt.Sprintf("%s=%s", param, result)
+	}
+	for _, p := range []string{"a", "b", "c"} {
+		go call(p)
+	}
+	received := map[string]bool{}
+	for i := 0; i < 3; i++ {
+		received[<-results] = true
+	}
+	assert.Equal(t, map[string]bool{"a=a": true, "b=b": true, "c=c": true}, received)
+}
+
+func TestIPCClientConnectFail(t *testing.T) {
+	ic := newIPCClient(filepath.Join(t.TempDir(), "missing.ipc"), time.Second, time.Second)
+
+	rpcErr := ic.CallRPC(context.Background(), nil, "eth_blockNumber")
+	assert.Equal(t, int64(rpcbackend.RPCCodeInternalError), rpcErr.Code)
+	assert.Regexp(t, "FF23093", rpcErr.Message)
+}
+
+func TestIPCClientTimeout(t *testing.T) {
+	node := newTestIPCNode(t, func(conn net.Conn, req *rpcbackend.RPCRequest) {})
+	ic := newIPCClient(node.path, time.Second, 10*time.Millisecond)
+
+	_, err := ic.SyncRequest(context.Background(), &rpcbackend.RPCRequest{Method: "eth_blockNumber"})
+	assert.Regexp(t, "FF23095", err)
+	ic.mux.Lock()
+	assert.Empty(t, ic.pending)
+	ic.mux.Unlock()
+}
+
+func TestIPCClientContextCancelled(t *testing.T) {
+	node := newTestIPCNode(t, func(conn net.Conn, req *rpcbackend.RPCRequest) {})
+	ic := newIPCClient(node.path, time.Second, 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-node.requests
+		cancel()
+	}()
+	_, err := ic.SyncRequest(ctx, &rpcbackend.RPCRequest{Method: "eth_blockNumber"})
+	assert.Regexp(t, "FF23094.*canceled", err)
+}
+
+func TestIPCClientReconnect(t *testing.T) {
+	var dropped atomic.Bool
+	node := newTestIPCNode(t, func(conn net.Conn, req *rpcbackend.RPCRequest) {
+		if !dropped.Swap(true) {
+			_ = conn.Close()
+			return
+		}
+		writeIPCResponse(conn, req.ID, `"0x1"`)
+	})
+	ic := newIPCClient(node.path, time.Second, time.Second)
+	defer ic.close()
+
+	// The connection is lost with the request pending, which fails
+	_, err := ic.SyncRequest(context.Background(), &rpcbackend.RPCRequest{Method: "eth_chainId"})
+	assert.Regexp(t, "FF23096", err)
+
+	// The next request makes a new connection
+	res, err := ic.SyncRequest(context.Background(), &rpcbackend.RPCRequest{Method: "eth_chainId"})
+	assert.NoError(t, err)
+	assert.Equal(t, `"0x1"`, res.Result.String())
+	assert.Equal(t, 2, node.connCount())
+}
+
+func TestIPCClientBadParamAndResult(t *testing.T) {
+	node := newTestIPCNode(t, func(conn net.Conn, req *rpcbackend.RPCRequest) {
+		writeIPCResponse(conn, req.ID, `"not a number"`)
+	})
+	ic := newIPCClient(node.path, time.Second, time.Second)
+	defer ic.close()
+
+	rpcErr := ic.CallRPC(context.Background(), nil, "eth_call", map[bool]bool{false: true})
+	assert.Equal(t, int64(rpcbackend.RPCCodeInvalidRequest), rpcErr.Code)
+	assert.Regexp(t, "FF23097", rpcErr.Message)
+
+	var result int
+	rpcErr = ic.CallRPC(context.Background(), &result, "eth_blockNumber")
+	assert.Equal(t, int64(rpcbackend.RPCCodeParseError), rpcErr.Code)
+	assert.Regexp(t, "FF23098", rpcErr.Message)
+}
+
+func TestIPCClientCloseWaitsForInFlight(t *testing.T) {
+	release := make(chan struct{})
+	node := newTestIPCNode(t, func(conn net.Conn, req *rpcbackend.RPCRequest) {
+		go func() {
+			<-release
+			writeIPCResponse(conn, req.ID, `"0x1"`)
+		}()
+	})
+	ic := newIPCClient(node.path, time.Second, time.Second)
+	mb := newManagedBackend(ic, nil)
+
+	done := make(chan error)
+	go func() {
+		_, err := mb.SyncRequest(context.Background(), &rpcbackend.RPCRequest{Method: "eth_chainId"})
+		done <- err
+	}()
+	<-node.requests
+
+	// Swapping the client closes the IPC connection only once the in-flight request completes
+	ic2 := newIPCClient(node.path, time.Second, time.Second)
+	mb.swap(ic2, nil)
+	ic.mux.Lock()
+	assert.True(t, ic.closed)
+	assert.NotNil(t, ic.conn)
+	ic.mux.Unlock()
+
+	close(release)
+	assert.NoError(t, <-done)
+	ic.mux.Lock()
+	assert.Nil(t, ic.conn)
+	ic.mux.Unlock()
+}
+
+func TestNewRPCClientIPC(t *testing.T) {
+	conf := config.RootSection("ipc_test")
+	ffresty.InitConfig(conf)
+	conf.Set(ffresty.HTTPConfigURL, "unix:///var/run/geth/geth.ipc")
+	conf.Set(ffresty.HTTPConfigRequestTimeout, "5s")
+
+	client, err := newRPCClient(context.Background(), conf, rpcClientOptions{maxConcurrentRequests: 10})
+	assert.NoError(t, err)
+	ic := client.(*ipcClient)
+	assert.Equal(t, "/var/run/geth/geth.ipc", ic.path)
+	assert.Equal(t, 5*time.Second, ic.requestTimeout)
+
+	conf.Set(ffresty.HTTPConfigURL, "http://localhost:8545")
+	client, err = newRPCClient(context.Background(), conf, rpcClientOptions{maxConcurrentRequests: 10})
+	assert.NoError(t, err)
+	_, isIPC := client.(*ipcClient)
+	assert.False(t, isIPC)
+}
@@ -0,0 +1,265 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+)
+
+// The status of the inclusion of an L2 block in the state submitted to L1
+const (
+	L1InclusionStatusPending   = "pending"   // no submission covering the block has been found on L1
+	L1InclusionStatusSubmitted = "submitted" // covered by a submission in an L1 block that is not yet finalized
+	L1InclusionStatusFinalized = "finalized" // covered by a submission in a finalized L1 block
+)
+
+// ChainProfileOPStack is the chain profile of OP-stack rollups, which propose their output roots to the L2OutputOracle
+const ChainProfileOPStack = "opStack"
+
+// maxL1Submissions is the number of submissions held in memory, beyond which the blocks covered by the oldest are
+// reported as finalized without the details of their submission
+const maxL1Submissions = 1000
+
+// l1InclusionEvent is the event the rollup contract emits on L1 for each submission of the state of L2, with the
+// indexes of the topics that hold the last L2 block it covers and the root it commits to
+type l1InclusionEvent struct {
+	signature      string
+	l2BlockTopic   int
+	stateRootTopic int
+}
+
+var l1InclusionEvents = map[string]l1InclusionEvent{
+	ChainProfileOPStack: {signature: "OutputProposed(bytes32,uint256,uint256,uint256)", l2BlockTopic: 3, stateRootTopic: 1},
+	ChainProfileLinea:   {signature: "DataFinalized(uint256,bytes32,bytes32,bool)", l2BlockTopic: 1, stateRootTopic: 3},
+}
+
+// L1Inclusion is the status of the inclusion of an L2 block in the state submitted to L1, with the details of the
+// first submission that covers it
+type L1Inclusion struct {
+	Status            string                    `json:"status"`
+	SubmittedL2Block  *ethtypes.HexInteger      `json:"submittedL2Block,omitempty"` // the last L2 block covered by the submission
+	StateRoot         ethtypes.HexBytes0xPrefix `json:"stateRoot,omitempty"`
+	L1BlockNumber     *ethtypes.HexInteger      `json:"l1BlockNumber,omitempty"`
+	L1BlockHash       ethtypes.HexBytes0xPrefix `json:"l1BlockHash,omitempty"`
+	L1TransactionHash ethtypes.HexBytes0xPrefix `json:"l1TransactionHash,omitempty"`
+}
+
+// BlockL1Inclusion is the L1 inclusion status of a block, by number or tag
+type BlockL1Inclusion struct {
+	Block       string               `json:"block"`
+	BlockNumber *ethtypes.HexInteger `json:"blockNumber"`
+	L1Inclusion
+}
+
+type l1Submission struct {
+	l2Block   int64
+	stateRoot ethtypes.HexBytes0xPrefix
+	l1Block   int64
+	l1Hash    ethtypes.HexBytes0xPrefix
+	l1TxHash  ethtypes.HexBytes0xPrefix
+}
+
+// l1InclusionTracker follows the submissions of the rollup contract on L1, scanning the blocks since the last
+// finalized L1 block again on each refresh, so that submissions lost to a reorganization of L1 are dropped
+type l1InclusionTracker struct {
+	l1Backend      rpcbackend.Backend
+	rollupContract *ethtypes.Address0xHex
+	event          l1InclusionEvent
+	topic0         ethtypes.HexBytes0xPrefix
+	cacheTTL       time.Duration
+	lookbackBlocks int64
+
+	mux           sync.Mutex
+	submissions   []*l1Submission // in L1 order, which is the order of the L2 blocks they cover
+	prunedL2Block int64           // the last L2 block covered by the submissions dropped from memory
+	l1Finalized   int64
+	fetched       time.Time
+}
+
+func newL1InclusionTracker(ctx context.Context, conf config.Section, clientOpts rpcClientOptions, costs *costTracker) (*l1InclusionTracker, error) {
+	if !conf.GetBool(L1InclusionEnabled) {
+		return nil, nil
+	}
+	profile := conf.GetString(L1InclusionProfile)
+	event, ok := l1InclusionEvents[profile]
+	if !ok && profile != "" {
+		profiles := make([]string, 0, len(l1InclusionEvents))
+		for p := range l1InclusionEvents {
+			profiles = append(profiles, p)
+		}
+		sort.Strings(profiles)
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidChainProfile, profile, profiles)
+	}
+
+	l1Conf := conf.SubSection(L1InclusionL1Config)
+	if s := l1Conf.GetString(L1InclusionL1Event); s != "" {
+		event.signature = s
+	}
+	if i := l1Conf.GetInt(L1InclusionL1BlockTopic); i != 0 {
+		event.l2BlockTopic = i
+	}
+	if i := l1Conf.GetInt(L1InclusionL1StateRootTopic); i != 0 {
+		event.stateRootTopic = i
+	}
+	rollupContract := l1Conf.GetString(L1InclusionL1RollupContract)
+	address, err := ethtypes.NewAddress(rollupContract)
+	if err != nil || l1Conf.GetString(ffresty.HTTPConfigURL) == "" || event.signature == "" ||
+		event.l2BlockTopic < 1 || event.l2BlockTopic > 3 || event.stateRootTopic < 0 || event.stateRootTopic > 3 {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidL1InclusionConfig, rollupContract, profile)
+	}
+	l1Client, err := newRPCClient(ctx, l1Conf, clientOpts)
+	if err != nil {
+		return nil, err
+	}
+	return &l1InclusionTracker{
+		l1Backend:      newManagedBackend(l1Client, newRPCScheduler(l1Conf, clientOpts)).withCosts(costs, "l1"),
+		rollupContract: address,
+		event:          event,
+		topic0:         eventTopic(event.signature),
+		cacheTTL:       conf.GetDuration(L1InclusionCacheTTL),
+		lookbackBlocks: conf.GetInt64(L1InclusionLookbackBlocks),
+	}, nil
+}
+
+// refresh scans L1 for new submissions, when they have not been scanned within the cache TTL
+func (lt *l1InclusionTracker) refresh(ctx context.Context) error {
+	if !lt.fetched.IsZero() && time.Since(lt.fetched) < lt.cacheTTL {
+		return nil
+	}
+	var head ethtypes.HexInteger
+	if rpcErr := lt.l1Backend.CallRPC(ctx, &head, "eth_blockNumber"); rpcErr != nil {
+		return rpcErr.Error()
+	}
+	var finalized *BlockHeader
+	if rpcErr := lt.l1Backend.CallRPC(ctx, &finalized, "eth_getBlockByNumber", "finalized", false); rpcErr != nil {
+		return rpcErr.Error()
+	}
+	if finalized == nil || finalized.Number == nil {
+		return i18n.NewError(ctx, msgs.MsgFinalizedBlockNotAvailable)
+	}
+
+	fromBlock := max(lt.l1Finalized+1, head.BigInt().Int64()-lt.lookbackBlocks, 0)
+	var logs []*logJSONRPC
+	if rpcErr := lt.l1Backend.CallRPC(ctx, &logs, "eth_getLogs", &logFilterJSONRPC{
+		FromBlock: ethtypes.NewHexInteger64(fromBlock),
+		ToBlock:   &head,
+		Address:   lt.rollupContract,
+		Topics:    [][]ethtypes.HexBytes0xPrefix{{lt.topic0}},
+	}); rpcErr != nil {
+		return rpcErr.Error()
+	}
+
+	// The submissions after the previous finalized block are replaced by those found in the scan
+	kept := lt.submissions[:0]
+	for _, s := range lt.submissions {
+		if s.l1Block < fromBlock {
+			kept = append(kept, s)
+		}
+	}
+	lt.submissions = kept
+	for _, l := range logs {
+		if l.Removed || l.BlockNumber == nil || len(l.Topics) <= max(lt.event.l2BlockTopic, lt.event.stateRootTopic) {
+			log.L(ctx).Warnf("Ignoring L1 submission log in transaction %s without the expected topics", l.TransactionHash)
+			continue
+		}
+		s := &l1Submission{
+			l2Block:  new(big.Int).SetBytes(l.Topics[lt.event.l2BlockTopic]).Int64(),
+			l1Block:  l.BlockNumber.BigInt().Int64(),
+			l1Hash:   l.BlockHash,
+			l1TxHash: l.TransactionHash,
+		}
+		if lt.event.stateRootTopic > 0 {
+			s.stateRoot = l.Topics[lt.event.stateRootTopic]
+		}
+		lt.submissions = append(lt.submissions, s)
+	}
+	if excess := len(lt.submissions) - maxL1Submissions; excess > 0 {
+		lt.prunedL2Block = max(lt.prunedL2Block, lt.submissions[excess-1].l2Block)
+		lt.submissions = append([]*l1Submission(nil), lt.submissions[excess:]...)
+	}
+	lt.l1Finalized, lt.fetched = finalized.Number.BigInt().Int64(), time.Now()
+	return nil
+}
+
+// l1InclusionOf returns the L1 inclusion status of an L2 block, from the first submission that covers it
+func (c *ethConnector) l1InclusionOf(ctx context.Context, l2Block int64) (*L1Inclusion, error) {
+	lt := c.l1Inclusion
+	lt.mux.Lock()
+	defer lt.mux.Unlock()
+	if err := lt.refresh(ctx); err != nil {
+		return nil, err
+	}
+	if l2Block <= lt.prunedL2Block {
+		return &L1Inclusion{Status: L1InclusionStatusFinalized}, nil
+	}
+	i := sort.Search(len(lt.submissions), func(i int) bool { return lt.submissions[i].l2Block >= l2Block })
+	if i == len(lt.submissions) {
+		return &L1Inclusion{Status: L1InclusionStatusPending}, nil
+	}
+	s := lt.submissions[i]
+	status := L1InclusionStatusSubmitted
+	if s.l1Block <= lt.l1Finalized {
+		status = L1InclusionStatusFinalized
+	}
+	return &L1Inclusion{
+		Status:            status,
+		SubmittedL2Block:  ethtypes.NewHexInteger64(s.l2Block),
+		StateRoot:         s.stateRoot,
+		L1BlockNumber:     ethtypes.NewHexInteger64(s.l1Block),
+		L1BlockHash:       s.l1Hash,
+		L1TransactionHash: s.l1TxHash,
+	}, nil
+}
+
+// BlockL1Inclusion returns the L1 inclusion status of a block, by number or tag
+func (c *ethConnector) BlockL1Inclusion(ctx context.Context, block string) (*BlockL1Inclusion, error) {
+	if c.l1Inclusion == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgL1InclusionDisabled)
+	}
+	blockNumber, err := blockNumberParam(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+	number, isNumber := blockNumber.(*ethtypes.HexInteger)
+	if !isNumber {
+		var header *BlockHeader
+		if rpcErr := c.readBackend().CallRPC(ctx, &header, "eth_getBlockByNumber", blockNumber, false); rpcErr != nil {
+			return nil, rpcErr.Error()
+		}
+		if header == nil || header.Number == nil {
+			return nil, i18n.NewError(ctx, msgs.MsgBlockNotAvailable)
+		}
+		number = header.Number
+	}
+	inclusion, err := c.l1InclusionOf(ctx, number.BigInt().Int64())
+	if err != nil {
+		return nil, err
+	}
+	return &BlockL1Inclusion{Block: block, BlockNumber: number, L1Inclusion: *inclusion}, nil
+}
@@ -0,0 +1,323 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-evmconnect/mocks/rpcbackendmocks"
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testRollupContract = "0xdfe97868233d1aa22e815a266982f2cf17685a27"
+
+func enableL1Inclusion(conf config.Section) {
+	conf.Set(L1InclusionEnabled, true)
+	conf.Set(L1InclusionProfile, ChainProfileOPStack)
+	conf.Set(L1InclusionCacheTTL, 0)
+	l1Conf := conf.SubSection(L1InclusionL1Config)
+	l1Conf.Set(ffresty.HTTPConfigURL, "http://localhost:8546")
+	l1Conf.Set(L1InclusionL1RollupContract, testRollupContract)
+}
+
+func newTestL1Inclusion(t *testing.T, confFns ...func(conf config.Section)) (context.Context, *ethConnector, *rpcbackendmocks.Backend, *rpcbackendmocks.Backend, func()) {
+	ctx, c, mRPC, done := newTestConnector(t, append([]func(conf config.Section){enableL1Inclusion}, confFns...)...)
+	mL1 := &rpcbackendmocks.Backend{}
+	c.l1Inclusion.l1Backend = mL1
+	return ctx, c, mRPC, mL1, done
+}
+
+// testOutputProposedLog is an OutputProposed event of the L2OutputOracle, proposing the output of an L2 block in an L1 block
+func testOutputProposedLog(l2Block, l1Block int64) *logJSONRPC {
+	return &logJSONRPC{
+		Address:         ethtypes.MustNewAddress(testRollupContract),
+		BlockNumber:     ethtypes.NewHexInteger64(l1Block),
+		BlockHash:       keccak256([]byte(fmt.Sprintf("block %d", l1Block))),
+		TransactionHash: keccak256([]byte(fmt.Sprintf("tx %d", l1Block))),
+		Topics: []ethtypes.HexBytes0xPrefix{
+			eventTopic("OutputProposed(bytes32,uint256,uint256,uint256)"),
+			keccak256([]byte(fmt.Sprintf("output %d", l2Block))),
+			abiWord(big.NewInt(l2Block / 100).Bytes()),
+			abiWord(big.NewInt(l2Block).Bytes()),
+		},
+	}
+}
+
+func mockL1Scan(mL1 *rpcbackendmocks.Backend, head, finalized, fromBlock int64, logs ...*logJSONRPC) {
+	mL1.On("CallRPC", mock.Anything, mock.Anything, "eth_blockNumber").
+		Run(func(args mock.Arguments) {
+			*args[1].(*ethtypes.HexInteger) = *ethtypes.NewHexInteger64(head)
+		}).
+		Return(nil).Once()
+	mL1.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", "finalized", false).
+		Run(func(args mock.Arguments) {
+			*(args[1].(**BlockHeader)) = &BlockHeader{Number: ethtypes.NewHexInteger64(finalized)}
+		}).
+		Return(nil).Once()
+	mL1.On("CallRPC", mock.Anything, mock.Anything, "eth_getLogs", mock.MatchedBy(func(f *logFilterJSONRPC) bool {
+		return f.FromBlock.BigInt().Int64() == fromBlock && f.ToBlock.BigInt().Int64() == head &&
+			f.Address.String() == testRollupContract && f.Topics[0][0].String() == "0xa7aaf2512769da4e444e3de247be2564225c2e7a8f74cfe528e46e17d24868e2"
+	})).
+		Run(func(args mock.Arguments) {
+			*args[1].(*[]*logJSONRPC) = logs
+		}).
+		Return(nil).Once()
+}
+
+func TestL1InclusionConfig(t *testing.T) {
+	ctx := context.Background()
+	config.RootConfigReset()
+	conf := config.RootSection("unittest")
+	InitConfig(conf)
+
+	lt, err := newL1InclusionTracker(ctx, conf, rpcClientOptions{}, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, lt)
+
+	conf.Set(L1InclusionEnabled, true)
+	conf.Set(L1InclusionProfile, "wrong")
+	_, err = newL1InclusionTracker(ctx, conf, rpcClientOptions{}, nil)
+	assert.Regexp(t, "FF23194.*linea opStack", err)
+
+	conf.Set(L1InclusionProfile, ChainProfileLinea)
+	_, err = newL1InclusionTracker(ctx, conf, rpcClientOptions{}, nil)
+	assert.Regexp(t, "FF23199.*linea", err)
+
+	l1Conf := conf.SubSection(L1InclusionL1Config)
+	l1Conf.Set(ffresty.HTTPConfigURL, "http://localhost:8546")
+	l1Conf.Set(L1InclusionL1RollupContract, "0xd19d4B5d358258f05D7B411E21A1460D11B0876F")
+	lt, err = newL1InclusionTracker(ctx, conf, rpcClientOptions{}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, l1InclusionEvents[ChainProfileLinea], lt.event)
+	assert.Equal(t, eventTopic("DataFinalized(uint256,bytes32,bytes32,bool)"), lt.topic0)
+	assert.Equal(t, int64(7200), lt.lookbackBlocks)
+
+	// A custom event of a rollup without a profile
+	conf.Set(L1InclusionProfile, "")
+	l1Conf.Set(L1InclusionL1Event, "StateBatchAppended(uint256,bytes32,uint256)")
+	l1Conf.Set(L1InclusionL1BlockTopic, 1)
+	l1Conf.Set(L1InclusionL1StateRootTopic, 2)
+	lt, err = newL1InclusionTracker(ctx, conf, rpcClientOptions{}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, l1InclusionEvent{signature: "StateBatchAppended(uint256,bytes32,uint256)", l2BlockTopic: 1, stateRootTopic: 2}, lt.event)
+
+	l1Conf.Set(L1InclusionL1BlockTopic, 4)
+	_, err = newL1InclusionTracker(ctx, conf, rpcClientOptions{}, nil)
+	assert.Regexp(t, "FF23199", err)
+
+	l1Conf.Set(L1InclusionL1BlockTopic, 1)
+	l1Conf.Set(ffresty.HTTPConfigProxyURL, "ftp://proxy.example.com")
+	_, err = newL1InclusionTracker(ctx, conf, rpcClientOptions{}, nil)
+	assert.Regexp(t, "FF23182", err)
+
+	l1Conf.Set(L1InclusionL1Event, "")
+	conf.Set(ffresty.HTTPConfigURL, "http://localhost:8545")
+	_, err = NewEthereumConnector(ctx, conf)
+	assert.Regexp(t, "FF23199", err)
+}
+
+func TestL1InclusionStatus(t *testing.T) {
+	ctx, c, _, mL1, done := newTestL1Inclusion(t)
+	defer done()
+
+	tooFewTopics := testOutputProposedLog(300, 996)
+	tooFewTopics.Topics = tooFewTopics.Topics[0:3]
+	removed := testOutputProposedLog(300, 997)
+	removed.Removed = true
+	mockL1Scan(mL1, 1000, 990, 1, testOutputProposedLog(100, 980), testOutputProposedLog(200, 995), tooFewTopics, removed)
+
+	inclusion, err := c.l1InclusionOf(ctx, 50)
+	assert.NoError(t, err)
+	assert.Equal(t, L1InclusionStatusFinalized, inclusion.Status)
+	assert.Equal(t, int64(100), inclusion.SubmittedL2Block.BigInt().Int64())
+	assert.Equal(t, int64(980), inclusion.L1BlockNumber.BigInt().Int64())
+	assert.Equal(t, ethtypes.HexBytes0xPrefix(keccak256([]byte("output 100"))), inclusion.StateRoot)
+	assert.Equal(t, ethtypes.HexBytes0xPrefix(keccak256([]byte("tx 980"))), inclusion.L1TransactionHash)
+	assert.Equal(t, ethtypes.HexBytes0xPrefix(keccak256([]byte("block 980"))), inclusion.L1BlockHash)
+
+	// Only the blocks since the last finalized block are scanned again, and submissions that were not final are
+	// replaced, as L1 might have reorganized them
+	mockL1Scan(mL1, 1010, 1000, 991, testOutputProposedLog(200, 996))
+	inclusion, err = c.l1InclusionOf(ctx, 101)
+	assert.NoError(t, err)
+	assert.Equal(t, L1InclusionStatusFinalized, inclusion.Status)
+	assert.Equal(t, int64(996), inclusion.L1BlockNumber.BigInt().Int64())
+
+	mockL1Scan(mL1, 1020, 1005, 1001, testOutputProposedLog(300, 1010))
+	inclusion, err = c.l1InclusionOf(ctx, 300)
+	assert.NoError(t, err)
+	assert.Equal(t, L1InclusionStatusSubmitted, inclusion.Status)
+	assert.Equal(t, int64(300), inclusion.SubmittedL2Block.BigInt().Int64())
+
+	mockL1Scan(mL1, 1030, 1005, 1006, testOutputProposedLog(300, 1010))
+	inclusion, err = c.l1InclusionOf(ctx, 301)
+	assert.NoError(t, err)
+	assert.Equal(t, &L1Inclusion{Status: L1InclusionStatusPending}, inclusion)
+
+	// The submissions are cached
+	c.l1Inclusion.cacheTTL = time.Hour
+	inclusion, err = c.l1InclusionOf(ctx, 300)
+	assert.NoError(t, err)
+	assert.Equal(t, L1InclusionStatusSubmitted, inclusion.Status)
+	mL1.AssertExpectations(t)
+}
+
+func TestL1InclusionPruned(t *testing.T) {
+	ctx, c, _, mL1, done := newTestL1Inclusion(t)
+	defer done()
+	c.l1Inclusion.cacheTTL = time.Hour
+
+	logs := make([]*logJSONRPC, maxL1Submissions+2)
+	for i := range logs {
+		logs[i] = testOutputProposedLog(int64(i+1)*10, int64(i+1))
+	}
+	mockL1Scan(mL1, 5000, 5000, 1, logs...)
+
+	// The blocks of the submissions dropped from memory are finalized, without the details of the submission
+	inclusion, err := c.l1InclusionOf(ctx, 20)
+	assert.NoError(t, err)
+	assert.Equal(t, &L1Inclusion{Status: L1InclusionStatusFinalized}, inclusion)
+	assert.Len(t, c.l1Inclusion.submissions, maxL1Submissions)
+
+	inclusion, err = c.l1InclusionOf(ctx, 21)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(30), inclusion.SubmittedL2Block.BigInt().Int64())
+}
+
+func TestL1InclusionErrors(t *testing.T) {
+	ctx, c, _, mL1, done := newTestL1Inclusion(t)
+	defer done()
+
+	mL1.On("CallRPC", mock.Anything, mock.Anything, "eth_blockNumber").Return(&rpcbackend.RPCError{Message: "pop"}).Once()
+	_, err := c.l1InclusionOf(ctx, 1)
+	assert.Regexp(t, "pop", err)
+
+	mL1.On("CallRPC", mock.Anything, mock.Anything, "eth_blockNumber").Return(nil)
+	mL1.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", "finalized", false).Return(&rpcbackend.RPCError{Message: "pop"}).Once()
+	_, err = c.l1InclusionOf(ctx, 1)
+	assert.Regexp(t, "pop", err)
+
+	mL1.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", "finalized", false).Return(nil).Once()
+	_, err = c.l1InclusionOf(ctx, 1)
+	assert.Regexp(t, "FF23196", err)
+
+	mL1.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", "finalized", false).
+		Run(func(args mock.Arguments) {
+			*(args[1].(**BlockHeader)) = &BlockHeader{Number: ethtypes.NewHexInteger64(0)}
+		}).
+		Return(nil)
+	mL1.On("CallRPC", mock.Anything, mock.Anything, "eth_getLogs", mock.Anything).Return(&rpcbackend.RPCError{Message: "pop"})
+	_, err = c.l1InclusionOf(ctx, 1)
+	assert.Regexp(t, "pop", err)
+}
+
+func TestBlockL1InclusionRoute(t *testing.T) {
+	_, c, mRPC, mL1, done := newTestL1Inclusion(t)
+	defer done()
+	url, close := newTestRouteServer(t, c)
+	defer close()
+	c.l1Inclusion.cacheTTL = time.Hour
+
+	mockL1Scan(mL1, 1000, 990, 1, testOutputProposedLog(100, 980), testOutputProposedLog(200, 995))
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", "latest", false).
+		Run(func(args mock.Arguments) {
+			*(args[1].(**BlockHeader)) = &BlockHeader{Number: ethtypes.NewHexInteger64(150)}
+		}).
+		Return(nil)
+
+	res, err := http.Get(url + "/blocks/number/100/l1inclusion")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var inclusion BlockL1Inclusion
+	err = json.NewDecoder(res.Body).Decode(&inclusion)
+	assert.NoError(t, err)
+	assert.Equal(t, "100", inclusion.Block)
+	assert.Equal(t, int64(100), inclusion.BlockNumber.BigInt().Int64())
+	assert.Equal(t, L1InclusionStatusFinalized, inclusion.Status)
+
+	res, err = http.Get(url + "/blocks/number/latest/l1inclusion")
+	assert.NoError(t, err)
+	inclusion = BlockL1Inclusion{}
+	err = json.NewDecoder(res.Body).Decode(&inclusion)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(150), inclusion.BlockNumber.BigInt().Int64())
+	assert.Equal(t, L1InclusionStatusSubmitted, inclusion.Status)
+	assert.Equal(t, int64(995), inclusion.L1BlockNumber.BigInt().Int64())
+
+	res, err = http.Get(url + "/blocks/number/wrong/l1inclusion")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+}
+
+func TestBlockL1InclusionErrors(t *testing.T) {
+	ctx, c, mRPC, mL1, done := newTestL1Inclusion(t)
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", "safe", false).Return(&rpcbackend.RPCError{Message: "pop"}).Once()
+	_, err := c.BlockL1Inclusion(ctx, "safe")
+	assert.Regexp(t, "pop", err)
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", "safe", false).Return(nil).Once()
+	_, err = c.BlockL1Inclusion(ctx, "safe")
+	assert.Regexp(t, "FF23011", err)
+
+	mL1.On("CallRPC", mock.Anything, mock.Anything, "eth_blockNumber").Return(&rpcbackend.RPCError{Message: "pop"}).Once()
+	_, err = c.BlockL1Inclusion(ctx, "1")
+	assert.Regexp(t, "pop", err)
+
+	c.l1Inclusion = nil
+	_, err = c.BlockL1Inclusion(ctx, "1")
+	assert.Regexp(t, "FF23200", err)
+}
+
+func TestFilterEnrichEthLogL1Inclusion(t *testing.T) {
+	ctx, c, _, mL1, done := newTestL1Inclusion(t, func(conf config.Section) {
+		conf.Set(EventsBlockTimestamps, false)
+	})
+	defer done()
+	c.chainID = "10"
+	ee := &eventEnricher{connector: c}
+	var event *abi.Entry
+	err := json.Unmarshal([]byte(abiTransferEvent), &event)
+	assert.NoError(t, err)
+	ethLog := sampleTransferLog()
+	filter := &eventFilter{Event: event, Topic0: ethLog.Topics[0]}
+
+	mockL1Scan(mL1, 1000, 990, 1, testOutputProposedLog(2000, 995))
+	ev, matched, _, err := ee.filterEnrichEthLog(ctx, filter, nil, ethLog)
+	assert.NoError(t, err)
+	assert.True(t, matched)
+	assert.Equal(t, L1InclusionStatusSubmitted, ev.Info.(*eventInfo).L1Inclusion.Status)
+
+	// Failing to query L1 only loses the annotation
+	mL1.On("CallRPC", mock.Anything, mock.Anything, "eth_blockNumber").Return(&rpcbackend.RPCError{Message: "pop"}).Once()
+	ev, _, _, err = ee.filterEnrichEthLog(ctx, filter, nil, ethLog)
+	assert.NoError(t, err)
+	assert.Nil(t, ev.Info.(*eventInfo).L1Inclusion)
+}
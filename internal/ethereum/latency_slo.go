@@ -0,0 +1,147 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+)
+
+// The operations that latency SLOs are tracked for
+const (
+	LatencySLOSend    = "send"
+	LatencySLOQuery   = "query"
+	LatencySLOLogPoll = "logPoll"
+)
+
+// The readiness statuses reported in the details of the ready response, when latency SLOs are enabled
+const (
+	ReadyStatusOK       = "ok"
+	ReadyStatusDegraded = "degraded"
+)
+
+// latencySLOMinSamples is the number of samples needed before an SLO can be breached, so that a single slow
+// request after a restart does not count as a breach
+const latencySLOMinSamples = 10
+
+// LatencySLOStatus is the latency of an operation against its SLO
+type LatencySLOStatus struct {
+	Target        fftypes.FFDuration `json:"target"`
+	Latency       fftypes.FFDuration `json:"latency"` // the configured percentile of the recent latencies
+	Samples       int                `json:"samples"`
+	BreachedSince *fftypes.FFTime    `json:"breachedSince,omitempty"`
+	Degraded      bool               `json:"degraded"`
+}
+
+type latencySLO struct {
+	target        time.Duration
+	latencies     []time.Duration
+	nextIdx       int
+	latency       time.Duration
+	breachedSince *time.Time
+}
+
+// latencySLOTracker tracks a percentile of the recent latencies of each operation against its target.
+// An SLO that stays breached for the degradeAfter duration degrades the readiness of the connector.
+type latencySLOTracker struct {
+	mux          sync.Mutex
+	percentile   float64
+	window       int
+	degradeAfter time.Duration
+	slos         map[string]*latencySLO
+}
+
+func newLatencySLOTracker(conf config.Section) *latencySLOTracker {
+	if !conf.GetBool(LatencySLOEnabled) {
+		return nil
+	}
+	t := &latencySLOTracker{
+		percentile:   conf.GetFloat64(LatencySLOPercentile),
+		window:       max(conf.GetInt(LatencySLOWindow), latencySLOMinSamples),
+		degradeAfter: conf.GetDuration(LatencySLODegradeAfter),
+		slos:         make(map[string]*latencySLO),
+	}
+	for op, key := range map[string]string{
+		LatencySLOSend:    LatencySLOSendTarget,
+		LatencySLOQuery:   LatencySLOQueryTarget,
+		LatencySLOLogPoll: LatencySLOLogPollTarget,
+	} {
+		if target := conf.GetDuration(key); target > 0 {
+			t.slos[op] = &latencySLO{target: target}
+		}
+	}
+	return t
+}
+
+// observe records the latency of an operation that started at the given time, and is intended to be deferred
+func (t *latencySLOTracker) observe(op string, startTime time.Time) {
+	if t == nil {
+		return
+	}
+	d := time.Since(startTime)
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	slo := t.slos[op]
+	if slo == nil {
+		return
+	}
+	if len(slo.latencies) < t.window {
+		slo.latencies = append(slo.latencies, d)
+	} else {
+		slo.latencies[slo.nextIdx] = d
+	}
+	slo.nextIdx = (slo.nextIdx + 1) % t.window
+
+	sorted := make([]time.Duration, len(slo.latencies))
+	copy(sorted, slo.latencies)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	slo.latency = sorted[int(float64(len(sorted)-1)*t.percentile/100)]
+	switch {
+	case len(sorted) < latencySLOMinSamples || slo.latency <= slo.target:
+		slo.breachedSince = nil
+	case slo.breachedSince == nil:
+		now := time.Now()
+		slo.breachedSince = &now
+	}
+}
+
+// status returns the SLOs of each operation, and whether any of them has been breached for long enough to
+// degrade the readiness of the connector
+func (t *latencySLOTracker) status() (map[string]*LatencySLOStatus, bool) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	statuses := make(map[string]*LatencySLOStatus, len(t.slos))
+	degraded := false
+	for op, slo := range t.slos {
+		s := &LatencySLOStatus{
+			Target:  fftypes.FFDuration(slo.target),
+			Latency: fftypes.FFDuration(slo.latency),
+			Samples: len(slo.latencies),
+		}
+		if slo.breachedSince != nil {
+			s.BreachedSince = (*fftypes.FFTime)(slo.breachedSince)
+			s.Degraded = time.Since(*slo.breachedSince) >= t.degradeAfter
+			degraded = degraded || s.Degraded
+		}
+		statuses[op] = s
+	}
+	return statuses, degraded
+}
@@ -0,0 +1,129 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func enableLatencySLOs(conf config.Section) {
+	conf.Set(LatencySLOEnabled, true)
+	conf.Set(LatencySLODegradeAfter, "0s")
+	conf.Set(LatencySLOQueryTarget, "1s")
+	conf.Set(LatencySLOLogPollTarget, "0s")
+}
+
+func TestLatencySLOTracker(t *testing.T) {
+	_, c, _, done := newTestConnector(t, enableLatencySLOs)
+	defer done()
+	slos := c.latencySLOs
+	assert.Len(t, slos.slos, 2)
+
+	// Slow requests only breach the SLO once there are enough samples
+	slow := time.Now().Add(-2 * time.Second)
+	for i := 0; i < latencySLOMinSamples-1; i++ {
+		slos.observe(LatencySLOQuery, slow)
+	}
+	status, degraded := slos.status()
+	assert.False(t, degraded)
+	assert.Nil(t, status[LatencySLOQuery].BreachedSince)
+	assert.Equal(t, latencySLOMinSamples-1, status[LatencySLOQuery].Samples)
+
+	slos.observe(LatencySLOQuery, slow)
+	status, degraded = slos.status()
+	assert.True(t, degraded)
+	assert.True(t, status[LatencySLOQuery].Degraded)
+	breachedSince := status[LatencySLOQuery].BreachedSince
+	assert.NotNil(t, breachedSince)
+	assert.GreaterOrEqual(t, time.Duration(status[LatencySLOQuery].Latency), 2*time.Second)
+	assert.Equal(t, time.Second, time.Duration(status[LatencySLOQuery].Target))
+	assert.False(t, status[LatencySLOSend].Degraded)
+
+	// The breach continues from when it started, and the SLO is only degraded once breached for long enough
+	slos.observe(LatencySLOQuery, slow)
+	status, _ = slos.status()
+	assert.Equal(t, breachedSince, status[LatencySLOQuery].BreachedSince)
+	slos.degradeAfter = time.Hour
+	_, degraded = slos.status()
+	assert.False(t, degraded)
+
+	// Fast requests fill the window, pushing out the slow ones
+	for i := 0; i < slos.window; i++ {
+		slos.observe(LatencySLOQuery, time.Now())
+	}
+	status, _ = slos.status()
+	assert.Nil(t, status[LatencySLOQuery].BreachedSince)
+	assert.Equal(t, slos.window, status[LatencySLOQuery].Samples)
+
+	// Operations without an SLO are ignored
+	slos.observe(LatencySLOLogPoll, slow)
+	status, _ = slos.status()
+	_, ok := status[LatencySLOLogPoll]
+	assert.False(t, ok)
+
+	var disabled *latencySLOTracker
+	disabled.observe(LatencySLOQuery, slow)
+}
+
+func TestLatencySLOReady(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t, enableLatencySLOs)
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "net_version").
+		Run(func(args mock.Arguments) {
+			*(args[1].(*string)) = "80001"
+		}).
+		Return(nil)
+	mockNodeInfoUnsupported(mRPC)
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_call", mock.Anything, "latest").
+		Run(func(args mock.Arguments) {
+			*(args[1].(*ethtypes.HexBytes0xPrefix)) = ethtypes.MustNewHexBytes0xPrefix("0x00000000000000000000000000000000000000000000000000000000baadf00d0000000000000000000000000000000000000000000000000000000000000040000000000000000000000000000000000000000000000000000000000000000b68656c6c6f20776f726c64000000000000000000000000000000000000000000")
+		}).
+		Return(nil)
+
+	var req ffcapi.QueryInvokeRequest
+	err := json.Unmarshal([]byte(sampleExecQuery), &req)
+	assert.NoError(t, err)
+	_, _, err = c.QueryInvoke(ctx, &req)
+	assert.NoError(t, err)
+
+	status, _, err := c.IsReady(ctx)
+	assert.NoError(t, err)
+	assert.True(t, status.Ready)
+	details := status.DownstreamDetails.JSONObject()
+	assert.Equal(t, ReadyStatusOK, details.GetString("status"))
+	assert.Equal(t, int64(1), details.GetObject("latencySLOs").GetObject(LatencySLOQuery).GetInt64("samples"))
+
+	slow := time.Now().Add(-2 * time.Second)
+	for i := 0; i < latencySLOMinSamples; i++ {
+		c.latencySLOs.observe(LatencySLOQuery, slow)
+	}
+	status, _, err = c.IsReady(ctx)
+	assert.NoError(t, err)
+	assert.True(t, status.Ready)
+	details = status.DownstreamDetails.JSONObject()
+	assert.Equal(t, ReadyStatusDegraded, details.GetString("status"))
+	assert.True(t, details.GetObject("latencySLOs").GetObject(LatencySLOQuery).GetBool("degraded"))
+}
@@ -0,0 +1,64 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-signer/pkg/ethsigner"
+)
+
+// checkLegacyChainConfig rejects the options that rely on JSON/RPC methods or transaction types that a legacy chain,
+// without EIP-155 replay protection, does not have
+func checkLegacyChainConfig(ctx context.Context, conf config.Section) error {
+	if !conf.GetBool(LegacyChainEnabled) {
+		return nil
+	}
+	for _, key := range []string{GasPriceSuggestions, BlobGasEnabled} {
+		if conf.GetBool(key) {
+			return i18n.NewError(ctx, msgs.MsgLegacyChainConfigConflict, key)
+		}
+	}
+	return nil
+}
+
+// legacyChainID returns the network ID of a legacy chain as its chain ID, as eth_chainId (EIP-695) was added
+// after EIP-155, and is not implemented by the nodes of chains that predate it
+func (c *ethConnector) legacyChainID(ctx context.Context) (*big.Int, error) {
+	var networkID string
+	if rpcErr := c.backend.CallRPC(ctx, &networkID, "net_version"); rpcErr != nil {
+		return nil, rpcErr.Error()
+	}
+	chainID, ok := new(big.Int).SetString(networkID, 0)
+	if !ok {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidNetworkID, networkID)
+	}
+	return chainID, nil
+}
+
+// checkLegacyChainTx only accepts legacy transactions signed without EIP-155 replay protection, as the nodes of a
+// legacy chain reject both typed transactions, and signatures that encode a chain ID in V
+func checkLegacyChainTx(ctx context.Context, tx *DecodedTransaction) error {
+	if byte(tx.Type) != ethsigner.TransactionTypeLegacy || tx.ChainID != nil {
+		return i18n.NewError(ctx, msgs.MsgLegacyChainTXNotSupported, tx.Encoding, tx.Hash)
+	}
+	return nil
+}
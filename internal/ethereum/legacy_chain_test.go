@@ -0,0 +1,138 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-signer/pkg/ethsigner"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/hyperledger/firefly-signer/pkg/secp256k1"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func enableLegacyChain(conf config.Section) {
+	conf.Set(LegacyChainEnabled, true)
+}
+
+func TestLegacyChainPreSigned(t *testing.T) {
+	kp, err := secp256k1.GenerateSecp256k1KeyPair()
+	assert.NoError(t, err)
+	rawTx := ethtypes.HexBytes0xPrefix(testHomesteadTx(t, kp))
+
+	ctx, c, mRPC, done := newTestConnector(t, enableLegacyChain)
+	defer done()
+
+	// Unprotected transactions are accepted without allowUnprotected, and the chain ID is not queried
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_sendRawTransaction", rawTx.String()).
+		Run(func(args mock.Arguments) {
+			*(args[1].(*ethtypes.HexBytes0xPrefix)) = keccak256(rawTx)
+		}).
+		Return(nil)
+	_, _, err = c.TransactionSend(ctx, &ffcapi.TransactionSendRequest{
+		PreSigned:       true,
+		TransactionData: rawTx.String(),
+	})
+	assert.NoError(t, err)
+
+	for _, signed := range []string{sampleSignedLegacyTX, sampleSigned1559TX} {
+		_, reason, err := c.TransactionSend(ctx, &ffcapi.TransactionSendRequest{
+			PreSigned:       true,
+			TransactionData: signed,
+		})
+		assert.Regexp(t, "FF23175", err)
+		assert.Equal(t, ffcapi.ErrorReasonInvalidInputs, reason)
+	}
+}
+
+func TestLegacyChainPreSignedNoPolicy(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t, enableLegacyChain, func(conf config.Section) {
+		conf.Set(PolicyPreSignedVerifyChainID, false)
+	})
+	defer done()
+
+	// Transactions are decoded to check them, even when no policy needs them
+	_, _, err := c.TransactionSend(ctx, &ffcapi.TransactionSendRequest{
+		PreSigned:       true,
+		TransactionData: sampleSigned1559TX,
+	})
+	assert.Regexp(t, "FF23175.*EIP-1559", err)
+}
+
+func TestLegacyChainEIP1559Fees(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t, enableLegacyChain)
+	defer done()
+
+	tx := &ethsigner.Transaction{}
+	err := c.mapGasPrice(ctx, fftypes.JSONAnyPtr(`{"maxFeePerGas": "2000", "maxPriorityFeePerGas": "100"}`), tx)
+	assert.Regexp(t, "FF23176", err)
+
+	err = c.mapGasPrice(ctx, fftypes.JSONAnyPtr(`{"gasPrice": "1000"}`), tx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1000), tx.GasPrice.Int64())
+}
+
+func TestLegacyChainID(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t, enableLegacyChain)
+	defer done()
+	c.ethChainID.Store(nil)
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "net_version").
+		Return(&rpcbackend.RPCError{Message: "pop"}).Once()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "net_version").
+		Run(func(args mock.Arguments) {
+			*(args[1].(*string)) = "wrong"
+		}).
+		Return(nil).Once()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "net_version").
+		Run(func(args mock.Arguments) {
+			*(args[1].(*string)) = "2018"
+		}).
+		Return(nil).Once()
+
+	_, err := c.connectedChainID(ctx)
+	assert.Regexp(t, "pop", err)
+	_, err = c.connectedChainID(ctx)
+	assert.Regexp(t, "FF23177.*wrong", err)
+
+	// The network ID is queried once, then cached
+	for i := 0; i < 2; i++ {
+		chainID, err := c.connectedChainID(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2018), chainID.Int64())
+	}
+}
+
+func TestLegacyChainConfigConflict(t *testing.T) {
+	for _, key := range []string{GasPriceSuggestions, BlobGasEnabled} {
+		config.RootConfigReset()
+		conf := config.RootSection("unittest")
+		InitConfig(conf)
+		conf.Set(ffresty.HTTPConfigURL, "http://localhost:8545")
+		conf.Set(LegacyChainEnabled, true)
+		conf.Set(key, true)
+		_, err := NewEthereumConnector(context.Background(), conf)
+		assert.Regexp(t, "FF23174.*"+key, err)
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+)
+
+// libraryPlaceholderLength is the length of the placeholder solc leaves in unlinked bytecode for the 20 byte
+// address of a library
+const libraryPlaceholderLength = 40
+
+// deployContract is the object form of the contract of a deploy request, for bytecode with libraries to link.
+// The libraries are keyed by their fully qualified name of <source>:<library>, or by the library name alone
+// for bytecode from compilers that used legacy placeholders.
+type deployContract struct {
+	Bytecode  string            `json:"bytecode"`
+	Libraries map[string]string `json:"libraries"`
+}
+
+// parseDeployContract returns the bytecode string of the contract of a deploy request, linked with the
+// libraries of the object form
+func parseDeployContract(ctx context.Context, contract *fftypes.JSONAny) (string, error) {
+	var bytecode string
+	libraries := map[string]string{}
+	if err := contract.Unmarshal(ctx, &bytecode); err != nil {
+		var dc deployContract
+		if err := json.Unmarshal(contract.Bytes(), &dc); err != nil || dc.Bytecode == "" {
+			return "", i18n.NewError(ctx, msgs.MsgDecodeBytecodeFailed)
+		}
+		bytecode, libraries = dc.Bytecode, dc.Libraries
+	}
+	if len(libraries) == 0 && !strings.Contains(bytecode, "__") {
+		// Nothing to link, and the bytecode might be Base64
+		return bytecode, nil
+	}
+	return linkLibraries(ctx, bytecode, libraries)
+}
+
+// libraryPlaceholders returns the placeholders solc uses for a library, which are the first 17 bytes of the hash of
+// the fully qualified name, and the legacy form of the name truncated to 36 characters and padded with underscores
+func libraryPlaceholders(name string) []string {
+	legacyName := name
+	if len(legacyName) > libraryPlaceholderLength-4 {
+		legacyName = legacyName[:libraryPlaceholderLength-4]
+	}
+	return []string{
+		fmt.Sprintf("__$%s$__", hex.EncodeToString(keccak256([]byte(name)))[:34]),
+		"__" + legacyName + strings.Repeat("_", libraryPlaceholderLength-4-len(legacyName)) + "__",
+	}
+}
+
+// linkLibraries substitutes the address of each library for its placeholders in the bytecode, and checks that no
+// placeholders remain
+func linkLibraries(ctx context.Context, bytecode string, libraries map[string]string) (string, error) {
+	for name, addrString := range libraries {
+		addr, err := ethtypes.NewAddress(addrString)
+		if err != nil {
+			return "", i18n.NewError(ctx, msgs.MsgInvalidLibraryAddress, name, addrString, err)
+		}
+		addrHex := hex.EncodeToString(addr[:])
+		for _, placeholder := range libraryPlaceholders(name) {
+			bytecode = strings.ReplaceAll(bytecode, placeholder, addrHex)
+		}
+	}
+	// Placeholders of either form start with two underscores, which cannot otherwise be in hex bytecode
+	if i := strings.Index(bytecode, "__"); i >= 0 {
+		return "", i18n.NewError(ctx, msgs.MsgUnlinkedLibrary, bytecode[i:min(i+libraryPlaceholderLength, len(bytecode))])
+	}
+	return bytecode, nil
+}
@@ -0,0 +1,104 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+	"github.com/stretchr/testify/assert"
+)
+
+const testLibraryAddress = "0x5f906824E562B6a0F278D910D388728b833a43bB"
+
+func TestLibraryPlaceholders(t *testing.T) {
+	placeholders := libraryPlaceholders("contracts/Math.sol:Math")
+	assert.Equal(t, "__$"+hex.EncodeToString(keccak256([]byte("contracts/Math.sol:Math")))[:34]+"$__", placeholders[0])
+	assert.Equal(t, "__contracts/Math.sol:Math_______________", placeholders[1])
+	for _, p := range placeholders {
+		assert.Len(t, p, libraryPlaceholderLength)
+	}
+	// Legacy placeholders truncate long names
+	assert.Equal(t, "__contracts/some/deep/path/Library.sol__", libraryPlaceholders("contracts/some/deep/path/Library.sol:Library")[1])
+}
+
+func TestDeployContractPrepareLinkedLibraries(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t)
+	defer done()
+
+	current := libraryPlaceholders("contracts/Math.sol:Math")[0]
+	legacy := libraryPlaceholders("Strings")[1]
+	contract, _ := json.Marshal(map[string]interface{}{
+		"bytecode": "0x6080" + current + "6000" + legacy + current,
+		"libraries": map[string]string{
+			"contracts/Math.sol:Math": testLibraryAddress,
+			"Strings":                 "0x0000000000000000000000000000000000000001",
+		},
+	})
+
+	var req ffcapi.ContractDeployPrepareRequest
+	err := json.Unmarshal([]byte(samplePrepareDeployTX), &req)
+	assert.NoError(t, err)
+	req.Contract = fftypes.JSONAnyPtrBytes(contract)
+	res, _, err := c.DeployContractPrepare(ctx, &req)
+	assert.NoError(t, err)
+	mathAddr := strings.ToLower(strings.TrimPrefix(testLibraryAddress, "0x"))
+	assert.True(t, strings.HasPrefix(res.TransactionData, "0x6080"+mathAddr+"6000"+"0000000000000000000000000000000000000001"+mathAddr))
+}
+
+func TestDeployContractPrepareUnlinkedLibrary(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t)
+	defer done()
+
+	var req ffcapi.ContractDeployPrepareRequest
+	err := json.Unmarshal([]byte(samplePrepareDeployTX), &req)
+	assert.NoError(t, err)
+
+	// A string contract with placeholders cannot be linked
+	placeholder := libraryPlaceholders("contracts/Math.sol:Math")[0]
+	req.Contract = fftypes.JSONAnyPtr(fmt.Sprintf(`"0x6080%s6000"`, placeholder))
+	_, reason, err := c.DeployContractPrepare(ctx, &req)
+	assert.Regexp(t, "FF23161.*"+regexp.QuoteMeta(placeholder), err)
+	assert.Equal(t, ffcapi.ErrorReasonInvalidInputs, reason)
+
+	// A library that is not supplied is reported
+	req.Contract = fftypes.JSONAnyPtr(fmt.Sprintf(`{"bytecode":"0x6080%s", "libraries": {"Other": "%s"}}`, placeholder[:20], testLibraryAddress))
+	_, _, err = c.DeployContractPrepare(ctx, &req)
+	assert.Regexp(t, "FF23161", err)
+}
+
+func TestParseDeployContractErrors(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := parseDeployContract(ctx, fftypes.JSONAnyPtr(`{"libraries": {}}`))
+	assert.Regexp(t, "FF23047", err)
+
+	_, err = parseDeployContract(ctx, fftypes.JSONAnyPtr(`{"bytecode": "0x00", "libraries": {"Math": "wrong"}}`))
+	assert.Regexp(t, "FF23160.*Math.*wrong", err)
+
+	// Base64 bytecode without libraries is passed through
+	bytecode, err := parseDeployContract(ctx, fftypes.JSONAnyPtr(`{"bytecode": "3q2+7w=="}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "3q2+7w==", bytecode)
+}
@@ -0,0 +1,205 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+)
+
+// logVerificationSampled selects a sample of the values passed, based on their hash rather than at random, so
+// the same transactions and blocks are verified again when a range is queried again after a mismatch
+func logVerificationSampled(rate float64, value []byte) bool {
+	if rate >= 1 {
+		return true
+	}
+	return float64(binary.BigEndian.Uint64(keccak256(value)[:8]))/math.MaxUint64 < rate
+}
+
+// matchesAnyFilter checks whether a log is one that must have been returned for the aggregated listener
+func (ag *aggregatedListener) matchesAnyFilter(ethLog *logJSONRPC) bool {
+	if len(ethLog.Topics) == 0 {
+		return false
+	}
+	for _, l := range ag.listeners {
+		for _, f := range l.config.filters {
+			if bytes.Equal(ethLog.Topics[0], f.Topic0) && f.matchesTopics(ethLog.Topics) && (f.Address == nil || (ethLog.Address != nil && *ethLog.Address == *f.Address)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// verifyLogs cross-checks a sample of the logs returned by the node against the receipts of their transactions,
+// to detect a provider that fabricates, alters or omits logs. The logs of each sampled transaction must match
+// its receipt exactly, and the receipt must not contain any matching logs that were not returned. When the range
+// of blocks queried is known, a sample of the blocks with no logs returned is also checked, where the logsBloom of
+// the block shows it might contain matching logs. Discrepancies are logged and notified, and only stop the logs
+// being delivered if the connector is configured to fail on a mismatch.
+func (es *eventStream) verifyLogs(ctx context.Context, ag *aggregatedListener, ethLogs []*logJSONRPC, fromBlock, toBlock int64) error {
+	rate := es.c.logVerificationRate
+	if rate <= 0 {
+		return nil
+	}
+	var txHashes []string
+	logsByTx := make(map[string][]*logJSONRPC)
+	blocksWithLogs := make(map[int64]bool)
+	for _, ethLog := range ethLogs {
+		if ethLog.Removed {
+			continue
+		}
+		txHash := ethLog.TransactionHash.String()
+		if _, ok := logsByTx[txHash]; !ok {
+			txHashes = append(txHashes, txHash)
+		}
+		logsByTx[txHash] = append(logsByTx[txHash], ethLog)
+		if ethLog.BlockNumber != nil {
+			blocksWithLogs[ethLog.BlockNumber.Int64()] = true
+		}
+	}
+
+	var mismatches []string
+	var verifyErr error
+	for _, txHash := range txHashes {
+		if !logVerificationSampled(rate, []byte(txHash)) {
+			continue
+		}
+		var receipt *txReceiptJSONRPC
+		if rpcErr := es.c.readBackend().CallRPC(ctx, &receipt, "eth_getTransactionReceipt", txHash); rpcErr != nil {
+			verifyErr = rpcErr.Error()
+			break
+		}
+		mismatches = append(mismatches, ag.verifyReceiptLogs(txHash, logsByTx[txHash], receipt)...)
+	}
+	for blockNumber := fromBlock; verifyErr == nil && fromBlock >= 0 && blockNumber <= toBlock; blockNumber++ {
+		if blocksWithLogs[blockNumber] || !logVerificationSampled(rate, []byte(strconv.FormatInt(blockNumber, 10))) {
+			continue
+		}
+		var blockMismatches []string
+		blockMismatches, verifyErr = es.verifyBlockWithoutLogs(ctx, ag, blockNumber)
+		mismatches = append(mismatches, blockMismatches...)
+	}
+
+	if verifyErr != nil {
+		// The logs cannot be trusted without verifying them, if the connector is configured to fail on a mismatch
+		log.L(ctx).Warnf("Unable to verify logs against receipts: %s", verifyErr)
+		if es.c.logVerificationFail {
+			return verifyErr
+		}
+	}
+	if len(mismatches) == 0 {
+		return nil
+	}
+	for _, m := range mismatches {
+		log.L(ctx).Errorf("Log verification failed for stream '%s': %s", es.id, m)
+	}
+	es.c.notifier.notify(ctx, NotificationLogMismatch, fftypes.JSONObject{
+		"streamId":   es.id.String(),
+		"fromBlock":  fromBlock,
+		"toBlock":    toBlock,
+		"mismatches": mismatches,
+	}, "%d logs returned by the node for stream %s do not match the transaction receipts", len(mismatches), es.id)
+	if es.c.logVerificationFail {
+		return i18n.NewError(ctx, msgs.MsgLogVerificationFailed, len(mismatches), mismatches[0])
+	}
+	return nil
+}
+
+func (ag *aggregatedListener) verifyReceiptLogs(txHash string, ethLogs []*logJSONRPC, receipt *txReceiptJSONRPC) []string {
+	if receipt == nil {
+		return []string{fmt.Sprintf("transaction %s of %d logs has no receipt", txHash, len(ethLogs))}
+	}
+	var mismatches []string
+	receiptLogs := make(map[int64]*logJSONRPC, len(receipt.Logs))
+	for _, rl := range receipt.Logs {
+		if rl.LogIndex != nil {
+			receiptLogs[rl.LogIndex.Int64()] = rl
+		}
+	}
+	returned := make(map[int64]bool, len(ethLogs))
+	for _, ethLog := range ethLogs {
+		if ethLog.LogIndex == nil {
+			mismatches = append(mismatches, fmt.Sprintf("log of transaction %s has no log index", txHash))
+			continue
+		}
+		logIndex := ethLog.LogIndex.Int64()
+		returned[logIndex] = true
+		rl := receiptLogs[logIndex]
+		switch {
+		case !bytes.Equal(ethLog.BlockHash, receipt.BlockHash):
+			mismatches = append(mismatches, fmt.Sprintf("log %d of transaction %s is in block %s, but the receipt is in block %s", logIndex, txHash, ethLog.BlockHash, receipt.BlockHash))
+		case rl == nil:
+			mismatches = append(mismatches, fmt.Sprintf("log %d of transaction %s is not in the receipt", logIndex, txHash))
+		case !logContentEqual(ethLog, rl):
+			mismatches = append(mismatches, fmt.Sprintf("log %d of transaction %s does not match the receipt", logIndex, txHash))
+		}
+	}
+	for _, rl := range receipt.Logs {
+		if rl.LogIndex != nil && !returned[rl.LogIndex.Int64()] && ag.matchesAnyFilter(rl) {
+			mismatches = append(mismatches, fmt.Sprintf("log %d of transaction %s is in the receipt, but was not returned", rl.LogIndex.Int64(), txHash))
+		}
+	}
+	return mismatches
+}
+
+func logContentEqual(a, b *logJSONRPC) bool {
+	if (a.Address == nil) != (b.Address == nil) || (a.Address != nil && *a.Address != *b.Address) ||
+		!bytes.Equal(a.Data, b.Data) || len(a.Topics) != len(b.Topics) {
+		return false
+	}
+	for i := range a.Topics {
+		if !bytes.Equal(a.Topics[i], b.Topics[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// verifyBlockWithoutLogs checks the receipts of a block that no logs were returned for, unless its logsBloom shows
+// it cannot contain any matching logs
+func (es *eventStream) verifyBlockWithoutLogs(ctx context.Context, ag *aggregatedListener, blockNumber int64) ([]string, error) {
+	bi, _, err := es.c.blockListener.getBlockInfoByNumber(ctx, blockNumber, true, "")
+	if err != nil {
+		return nil, err
+	}
+	if bi == nil || (len(bi.LogsBloom) == bloomLength && !ag.bloomMayMatch(bi.LogsBloom)) || len(bi.Transactions) == 0 {
+		return nil, nil
+	}
+	receipts, err := es.c.getBlockReceipts(ctx, &BlockHeader{Hash: bi.Hash, Transactions: bi.Transactions})
+	if err != nil {
+		return nil, err
+	}
+	var mismatches []string
+	for _, receipt := range receipts {
+		for _, rl := range receipt.Logs {
+			if rl.LogIndex != nil && ag.matchesAnyFilter(rl) {
+				mismatches = append(mismatches, fmt.Sprintf("log %d of transaction %s in block %d is in the receipt, but was not returned", rl.LogIndex.Int64(), receipt.TransactionHash, blockNumber))
+			}
+		}
+	}
+	return mismatches, nil
+}
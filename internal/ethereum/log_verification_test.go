@@ -0,0 +1,285 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-evmconnect/mocks/rpcbackendmocks"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var testVerifyTransferEvent = &eventFilter{
+	Topic0:  testTransferTopic0,
+	Address: ethtypes.MustNewAddress("0x20355f3E852D4b6a9944AdA8d5399dDD3409A431"),
+}
+
+func newTestLogVerification(t *testing.T, failOnMismatch bool) (*eventStream, *aggregatedListener, *rpcbackendmocks.Backend, func()) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	c.logVerificationRate = 1
+	c.logVerificationFail = failOnMismatch
+	es := &eventStream{id: fftypes.NewUUID(), ctx: ctx, c: c}
+	return es, testBloomAggregatedListener(testVerifyTransferEvent), mRPC, done
+}
+
+func testReceiptForLogs(logs ...*logJSONRPC) *txReceiptJSONRPC {
+	receipt := &txReceiptJSONRPC{
+		BlockHash:       sampleTransferLog().BlockHash,
+		TransactionHash: sampleTransferLog().TransactionHash,
+	}
+	for _, l := range logs {
+		copied := *l
+		receipt.Logs = append(receipt.Logs, &copied)
+	}
+	return receipt
+}
+
+func mockVerificationReceipt(mRPC *rpcbackendmocks.Backend, receipt *txReceiptJSONRPC) {
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionReceipt", sampleTransferLog().TransactionHash.String()).
+		Run(func(args mock.Arguments) {
+			*(args[1].(**txReceiptJSONRPC)) = receipt
+		}).
+		Return(nil).Once()
+}
+
+func TestLogVerificationSampled(t *testing.T) {
+	selected := 0
+	for i := 0; i < 1000; i++ {
+		value := []byte(strconv.Itoa(i))
+		if logVerificationSampled(0.25, value) {
+			selected++
+		}
+		assert.Equal(t, logVerificationSampled(0.25, value), logVerificationSampled(0.25, value))
+		assert.True(t, logVerificationSampled(1, value))
+		assert.False(t, logVerificationSampled(0, value))
+	}
+	assert.InDelta(t, 250, selected, 50)
+}
+
+func TestVerifyLogsDisabled(t *testing.T) {
+	es, ag, _, done := newTestLogVerification(t, true)
+	defer done()
+	es.c.logVerificationRate = 0
+
+	err := es.verifyLogs(context.Background(), ag, []*logJSONRPC{sampleTransferLog()}, 1000, 1100)
+	assert.NoError(t, err)
+}
+
+func TestVerifyLogsMatch(t *testing.T) {
+	es, ag, mRPC, done := newTestLogVerification(t, true)
+	defer done()
+
+	l1, l2 := sampleTransferLog(), sampleTransferLog()
+	l2.LogIndex = ethtypes.NewHexInteger64(3)
+	other := sampleTransferLog()
+	other.LogIndex = ethtypes.NewHexInteger64(4)
+	other.Topics[0] = testApprovalTopic0
+	mockVerificationReceipt(mRPC, testReceiptForLogs(l1, l2, other))
+
+	// Block 1025 cannot contain a matching log, and block 1026 might but does not
+	es.c.blockListener.addToBlockCache(&blockInfoJSONRPC{
+		Number:       ethtypes.NewHexInteger64(1025),
+		Hash:         ethtypes.MustNewHexBytes0xPrefix(fmt.Sprintf("0x%064x", 1025)),
+		LogsBloom:    testBloom(testApprovalTopic0, testVerifyTransferEvent.Address[:]),
+		Transactions: []ethtypes.HexBytes0xPrefix{ethtypes.MustNewHexBytes0xPrefix("0x01")},
+	})
+	es.c.blockListener.addToBlockCache(&blockInfoJSONRPC{
+		Number:       ethtypes.NewHexInteger64(1026),
+		Hash:         ethtypes.MustNewHexBytes0xPrefix(fmt.Sprintf("0x%064x", 1026)),
+		LogsBloom:    testBloom(testTransferTopic0, testVerifyTransferEvent.Address[:]),
+		Transactions: []ethtypes.HexBytes0xPrefix{ethtypes.MustNewHexBytes0xPrefix("0x02")},
+	})
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockReceipts", mock.Anything).
+		Run(func(args mock.Arguments) {
+			*(args[1].(*[]*txReceiptJSONRPC)) = []*txReceiptJSONRPC{testReceiptForLogs(other)}
+		}).
+		Return(nil).Once()
+
+	removed := sampleTransferLog()
+	removed.Removed = true
+	err := es.verifyLogs(context.Background(), ag, []*logJSONRPC{l1, l2, removed}, 1024, 1026)
+	assert.NoError(t, err)
+}
+
+func TestVerifyLogsMismatches(t *testing.T) {
+	es, ag, mRPC, done := newTestLogVerification(t, true)
+	defer done()
+
+	l1, l2, l3, missing := sampleTransferLog(), sampleTransferLog(), sampleTransferLog(), sampleTransferLog()
+	l2.LogIndex = ethtypes.NewHexInteger64(3)
+	l3.LogIndex = ethtypes.NewHexInteger64(5)
+	missing.LogIndex = ethtypes.NewHexInteger64(4)
+	altered := *l2
+	altered.Data = ethtypes.MustNewHexBytes0xPrefix("0x00")
+	mockVerificationReceipt(mRPC, testReceiptForLogs(l1, &altered, missing))
+	es.c.blockListener.addToBlockCache(&blockInfoJSONRPC{
+		Number:       ethtypes.NewHexInteger64(1025),
+		Hash:         ethtypes.MustNewHexBytes0xPrefix(fmt.Sprintf("0x%064x", 1025)),
+		Transactions: []ethtypes.HexBytes0xPrefix{ethtypes.MustNewHexBytes0xPrefix("0x02")},
+	})
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockReceipts", mock.Anything).
+		Run(func(args mock.Arguments) {
+			*(args[1].(*[]*txReceiptJSONRPC)) = []*txReceiptJSONRPC{testReceiptForLogs(missing)}
+		}).
+		Return(nil).Once()
+
+	err := es.verifyLogs(context.Background(), ag, []*logJSONRPC{l1, l2, l3}, 1024, 1025)
+	assert.Regexp(t, "FF23144.*4 logs", err)
+}
+
+func TestVerifyReceiptLogs(t *testing.T) {
+	ag := testBloomAggregatedListener(testVerifyTransferEvent)
+	txHash := sampleTransferLog().TransactionHash.String()
+
+	mismatches := ag.verifyReceiptLogs(txHash, []*logJSONRPC{sampleTransferLog()}, nil)
+	assert.Len(t, mismatches, 1)
+	assert.Regexp(t, "has no receipt", mismatches[0])
+
+	otherBlock := sampleTransferLog()
+	otherBlock.BlockHash = ethtypes.MustNewHexBytes0xPrefix("0x01")
+	noIndex := sampleTransferLog()
+	noIndex.LogIndex = nil
+	mismatches = ag.verifyReceiptLogs(txHash, []*logJSONRPC{otherBlock, noIndex}, testReceiptForLogs(sampleTransferLog()))
+	assert.Len(t, mismatches, 2)
+	assert.Regexp(t, "is in block 0x01", mismatches[0])
+	assert.Regexp(t, "no log index", mismatches[1])
+
+	otherAddress := sampleTransferLog()
+	otherAddress.Address = testBloomOtherAddress
+	assert.False(t, ag.matchesAnyFilter(otherAddress))
+	assert.False(t, ag.matchesAnyFilter(&logJSONRPC{}))
+	assert.False(t, logContentEqual(sampleTransferLog(), otherAddress))
+	fewerTopics := sampleTransferLog()
+	fewerTopics.Topics = fewerTopics.Topics[:2]
+	assert.False(t, logContentEqual(sampleTransferLog(), fewerTopics))
+	otherTopic := sampleTransferLog()
+	otherTopic.Topics[2] = otherTopic.Topics[1]
+	assert.False(t, logContentEqual(sampleTransferLog(), otherTopic))
+}
+
+func TestVerifyLogsMismatchNotFailing(t *testing.T) {
+	es, ag, mRPC, done := newTestLogVerification(t, false)
+	defer done()
+	mockVerificationReceipt(mRPC, nil)
+
+	err := es.verifyLogs(context.Background(), ag, []*logJSONRPC{sampleTransferLog()}, -1, -1)
+	assert.NoError(t, err)
+}
+
+func TestVerifyLogsReceiptFail(t *testing.T) {
+	es, ag, mRPC, done := newTestLogVerification(t, true)
+	defer done()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionReceipt", mock.Anything).
+		Return(&rpcbackend.RPCError{Message: "pop"})
+
+	err := es.verifyLogs(context.Background(), ag, []*logJSONRPC{sampleTransferLog()}, 1024, 1024)
+	assert.Regexp(t, "pop", err)
+
+	es.c.logVerificationFail = false
+	err = es.verifyLogs(context.Background(), ag, []*logJSONRPC{sampleTransferLog()}, 1024, 1024)
+	assert.NoError(t, err)
+}
+
+func TestVerifyLogsBlockFail(t *testing.T) {
+	es, ag, mRPC, done := newTestLogVerification(t, true)
+	defer done()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", mock.Anything, false).
+		Return(&rpcbackend.RPCError{Message: "pop"})
+
+	err := es.verifyLogs(context.Background(), ag, []*logJSONRPC{}, 1024, 1024)
+	assert.Regexp(t, "pop", err)
+}
+
+func TestVerifyLogsBlockReceiptsFail(t *testing.T) {
+	es, ag, mRPC, done := newTestLogVerification(t, true)
+	defer done()
+	es.c.blockListener.addToBlockCache(&blockInfoJSONRPC{
+		Number:       ethtypes.NewHexInteger64(1024),
+		Hash:         ethtypes.MustNewHexBytes0xPrefix(fmt.Sprintf("0x%064x", 1024)),
+		Transactions: []ethtypes.HexBytes0xPrefix{ethtypes.MustNewHexBytes0xPrefix("0x02")},
+	})
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockReceipts", mock.Anything).
+		Return(&rpcbackend.RPCError{Message: "unsupported"})
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionReceipt", mock.Anything).
+		Return(&rpcbackend.RPCError{Message: "pop"})
+
+	err := es.verifyLogs(context.Background(), ag, []*logJSONRPC{}, 1024, 1024)
+	assert.Regexp(t, "pop", err)
+}
+
+func TestGetBlockRangeEventsVerifyFail(t *testing.T) {
+	es, ag, mRPC, done := newTestLogVerification(t, true)
+	defer done()
+	ag.listeners[0].config.options = &listenerOptions{}
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getLogs", mock.Anything).
+		Run(func(args mock.Arguments) {
+			*(args[1].(*[]*logJSONRPC)) = []*logJSONRPC{sampleTransferLog()}
+		}).
+		Return(nil)
+	mockVerificationReceipt(mRPC, nil)
+
+	_, err := es.getBlockRangeEvents(context.Background(), ag, -1, -1)
+	assert.Regexp(t, "FF23144", err)
+}
+
+func TestStreamLoopVerifyFailResetsFilter(t *testing.T) {
+	l1req := &ffcapi.EventListenerAddRequest{
+		ListenerID: fftypes.NewUUID(),
+		EventListenerOptions: ffcapi.EventListenerOptions{
+			Filters: []fftypes.JSONAny{
+				*fftypes.JSONAnyPtr(`{"address":"0x20355f3E852D4b6a9944AdA8d5399dDD3409A431","event":` + abiTransferEvent + `}`),
+			},
+			Options:   fftypes.JSONAnyPtr(`{}`),
+			FromBlock: strconv.Itoa(testHighBlock),
+		},
+	}
+	ctx, c, mRPC, done := newTestConnector(t)
+	c.logVerificationRate = 1
+	c.logVerificationFail = true
+
+	refetched := make(chan struct{})
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_blockNumber").Return(nil).Run(func(args mock.Arguments) {
+		hbh := args[1].(*ethtypes.HexInteger)
+		*hbh = *ethtypes.NewHexInteger64(testHighBlock)
+	})
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_newFilter", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		*args[1].(*string) = testLogsFilterID1
+	})
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getFilterLogs", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		*args[1].(*[]*logJSONRPC) = []*logJSONRPC{sampleTransferLog()}
+	}).Once()
+	mockVerificationReceipt(mRPC, nil)
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getFilterLogs", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		*args[1].(*[]*logJSONRPC) = make([]*logJSONRPC, 0)
+		close(refetched)
+	}).Once()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getFilterChanges", mock.Anything).Return(nil).Maybe()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_uninstallFilter", mock.Anything).Return(nil).Maybe()
+
+	_, _, _, done = testEventStreamExistingConnector(t, ctx, done, c, mRPC, l1req)
+	defer done()
+
+	<-refetched
+}
@@ -0,0 +1,188 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"math/big"
+	"sort"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+)
+
+type MempoolStatus string
+
+const (
+	// MempoolStatusPending transactions are executable, and waiting to be mined
+	MempoolStatusPending MempoolStatus = "pending"
+	// MempoolStatusQueued transactions cannot be mined until the transactions with lower nonces are
+	MempoolStatusQueued MempoolStatus = "queued"
+	// MempoolStatusMined transactions are no longer in the mempool, as they are in a block
+	MempoolStatusMined MempoolStatus = "mined"
+	// MempoolStatusUnknown transactions are not known to the node
+	MempoolStatusUnknown MempoolStatus = "unknown"
+)
+
+// MempoolTransaction is a transaction as returned by the txpool and eth_getTransactionByHash methods
+type MempoolTransaction struct {
+	Hash                 ethtypes.HexBytes0xPrefix `json:"hash"`
+	From                 *ethtypes.Address0xHex    `json:"from"`
+	To                   *ethtypes.Address0xHex    `json:"to"`
+	Nonce                *ethtypes.HexInteger      `json:"nonce"`
+	Gas                  *ethtypes.HexInteger      `json:"gas"`
+	GasPrice             *ethtypes.HexInteger      `json:"gasPrice,omitempty"`
+	MaxFeePerGas         *ethtypes.HexInteger      `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *ethtypes.HexInteger      `json:"maxPriorityFeePerGas,omitempty"`
+	Value                *ethtypes.HexInteger      `json:"value"`
+	Input                ethtypes.HexBytes0xPrefix `json:"input"`
+	BlockNumber          *ethtypes.HexInteger      `json:"blockNumber,omitempty"` // null if in the mempool
+}
+
+type MempoolSignerResponse struct {
+	Signer  string                `json:"signer"`
+	Pending []*MempoolTransaction `json:"pending"`
+	Queued  []*MempoolTransaction `json:"queued"`
+}
+
+type MempoolTransactionResponse struct {
+	TransactionHash string              `json:"transactionHash"`
+	Status          MempoolStatus       `json:"status"`
+	Transaction     *MempoolTransaction `json:"transaction,omitempty"`
+}
+
+// txPoolContent is the result of the geth txpool_contentFrom method, with transactions keyed by nonce
+type txPoolContent struct {
+	Pending map[string]*MempoolTransaction `json:"pending"`
+	Queued  map[string]*MempoolTransaction `json:"queued"`
+}
+
+// txPoolContentAll is the result of the geth txpool_content method, with transactions keyed by sender then nonce
+type txPoolContentAll struct {
+	Pending map[string]map[string]*MempoolTransaction `json:"pending"`
+	Queued  map[string]map[string]*MempoolTransaction `json:"queued"`
+}
+
+func filterTxPoolContent(all map[string]map[string]*MempoolTransaction, signer *ethtypes.Address0xHex) map[string]*MempoolTransaction {
+	for addrStr, txs := range all {
+		if addr, err := ethtypes.NewAddress(addrStr); err == nil && *addr == *signer {
+			return txs
+		}
+	}
+	return map[string]*MempoolTransaction{}
+}
+
+// txPoolContentFrom gets the transactions of a signer in the txpool of the node, using txpool_contentFrom
+// and falling back to filtering the whole txpool for nodes that do not support it. The txpool is local to
+// the node, so this always uses the primary endpoint.
+func (c *ethConnector) txPoolContentFrom(ctx context.Context, signer *ethtypes.Address0xHex) (*txPoolContent, *rpcbackend.RPCError) {
+	var content *txPoolContent
+	rpcErr := c.backend.CallRPC(ctx, &content, "txpool_contentFrom", signer)
+	if rpcErr == nil && content != nil {
+		return content, nil
+	}
+	log.L(ctx).Debugf("txpool_contentFrom not available, filtering txpool_content: %v", rpcErr)
+	var all *txPoolContentAll
+	if rpcErr = c.backend.CallRPC(ctx, &all, "txpool_content"); rpcErr != nil {
+		return nil, rpcErr
+	}
+	if all == nil {
+		all = &txPoolContentAll{}
+	}
+	return &txPoolContent{
+		Pending: filterTxPoolContent(all.Pending, signer),
+		Queued:  filterTxPoolContent(all.Queued, signer),
+	}, nil
+}
+
+// sortedByNonce returns the transactions in nonce order, using the nonce keys of the txpool content
+func sortedByNonce(txs map[string]*MempoolTransaction) []*MempoolTransaction {
+	type nonceTx struct {
+		nonce *big.Int
+		tx    *MempoolTransaction
+	}
+	sorted := make([]*nonceTx, 0, len(txs))
+	for nonceStr, tx := range txs {
+		if nonce, ok := new(big.Int).SetString(nonceStr, 0); ok {
+			sorted = append(sorted, &nonceTx{nonce: nonce, tx: tx})
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].nonce.Cmp(sorted[j].nonce) < 0 })
+	result := make([]*MempoolTransaction, len(sorted))
+	for i, ntx := range sorted {
+		result[i] = ntx.tx
+	}
+	return result
+}
+
+// MempoolForSigner lists the pending and queued transactions of a signer in the txpool of the node
+func (c *ethConnector) MempoolForSigner(ctx context.Context, signerString string) (*MempoolSignerResponse, error) {
+	signer, err := ethtypes.NewAddress(signerString)
+	if err != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidFromAddress, signerString, err)
+	}
+	content, rpcErr := c.txPoolContentFrom(ctx, signer)
+	if rpcErr != nil {
+		return nil, rpcErr.Error()
+	}
+	return &MempoolSignerResponse{
+		Signer:  signer.String(),
+		Pending: sortedByNonce(content.Pending),
+		Queued:  sortedByNonce(content.Queued),
+	}, nil
+}
+
+// MempoolTransactionStatus checks whether a transaction is known to the node, and if it is still in the
+// mempool whether it is pending or queued behind a nonce gap. The transaction cache is not used, as the
+// status is expected to change.
+func (c *ethConnector) MempoolTransactionStatus(ctx context.Context, txHashString string) (*MempoolTransactionResponse, error) {
+	txHash, err := ethtypes.NewHexBytes0xPrefix(txHashString)
+	if err != nil || len(txHash) != 32 {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidTransactionHash, txHashString)
+	}
+	res := &MempoolTransactionResponse{
+		TransactionHash: txHash.String(),
+		Status:          MempoolStatusUnknown,
+	}
+	if rpcErr := c.backend.CallRPC(ctx, &res.Transaction, "eth_getTransactionByHash", txHash); rpcErr != nil {
+		return nil, rpcErr.Error()
+	}
+	switch {
+	case res.Transaction == nil:
+		return res, nil
+	case res.Transaction.BlockNumber != nil:
+		res.Status = MempoolStatusMined
+		return res, nil
+	}
+
+	// The transaction is in the mempool, and can only be found to be queued if the txpool API is available
+	res.Status = MempoolStatusPending
+	if res.Transaction.From != nil && res.Transaction.Nonce != nil {
+		content, rpcErr := c.txPoolContentFrom(ctx, res.Transaction.From)
+		if rpcErr != nil {
+			log.L(ctx).Warnf("Unable to check if transaction %s is queued: %s", txHash, rpcErr.Message)
+			return res, nil
+		}
+		if queued := content.Queued[res.Transaction.Nonce.BigInt().String()]; queued != nil && queued.Hash.Equals(txHash) {
+			res.Status = MempoolStatusQueued
+		}
+	}
+	return res, nil
+}
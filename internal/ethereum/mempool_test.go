@@ -0,0 +1,234 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hyperledger/firefly-evmconnect/mocks/rpcbackendmocks"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const sampleMempoolTxHash = "0x7d48ae971faf089878b57e3c28e3035540d34f38af395958d2c73c36c57c83a2"
+
+const sampleTxPoolContentFrom = `{
+	"pending": {
+		"11": {"hash": "0x2222222222222222222222222222222222222222222222222222222222222222", "nonce": "0xb", "from": "0xd0f2f5103fd050739a9fb567251bc460cc24d091"},
+		"10": {"hash": "0x1111111111111111111111111111111111111111111111111111111111111111", "nonce": "0xa", "from": "0xd0f2f5103fd050739a9fb567251bc460cc24d091"}
+	},
+	"queued": {
+		"13": {"hash": "0x7d48ae971faf089878b57e3c28e3035540d34f38af395958d2c73c36c57c83a2", "nonce": "0xd", "from": "0xd0f2f5103fd050739a9fb567251bc460cc24d091", "maxFeePerGas": "0x3b9aca00"}
+	}
+}`
+
+func mockGetMempoolTx(mRPC *rpcbackendmocks.Backend, tx string) {
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionByHash", mock.Anything).
+		Run(func(args mock.Arguments) {
+			err := json.Unmarshal([]byte(tx), args[1])
+			if err != nil {
+				panic(err)
+			}
+		}).
+		Return(nil)
+}
+
+func TestMempoolForSigner(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mockTxPoolContentFrom(mRPC, sampleTxPoolContentFrom)
+
+	res, err := c.MempoolForSigner(ctx, sampleSigner)
+	assert.NoError(t, err)
+	assert.Equal(t, sampleSigner, res.Signer)
+	assert.Len(t, res.Pending, 2)
+	assert.Equal(t, int64(10), res.Pending[0].Nonce.BigInt().Int64())
+	assert.Equal(t, int64(11), res.Pending[1].Nonce.BigInt().Int64())
+	assert.Len(t, res.Queued, 1)
+	assert.Equal(t, sampleMempoolTxHash, res.Queued[0].Hash.String())
+	assert.Equal(t, int64(1000000000), res.Queued[0].MaxFeePerGas.BigInt().Int64())
+}
+
+func TestMempoolForSignerTxPoolContentFallback(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "txpool_contentFrom", mock.Anything).
+		Return(&rpcbackend.RPCError{Message: "the method txpool_contentFrom does not exist/is not available"})
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "txpool_content").
+		Run(func(args mock.Arguments) {
+			err := json.Unmarshal([]byte(`{
+				"pending": {
+					"0xD0f2f5103fd050739A9fb567251bC460cc24d091": {"3": {"nonce": "0x3"}},
+					"0x4a8c8f1717570f9774652075e249ded38124d708": {"7": {"nonce": "0x7"}}
+				},
+				"queued": {
+					"not an address": {"1": {"nonce": "0x1"}}
+				}
+			}`), args[1])
+			assert.NoError(t, err)
+		}).
+		Return(nil)
+
+	res, err := c.MempoolForSigner(ctx, sampleSigner)
+	assert.NoError(t, err)
+	assert.Len(t, res.Pending, 1)
+	assert.Equal(t, int64(3), res.Pending[0].Nonce.BigInt().Int64())
+	assert.Empty(t, res.Queued)
+}
+
+func TestMempoolForSignerTxPoolContentNull(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "txpool_contentFrom", mock.Anything).Return(nil)
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "txpool_content").Return(nil)
+
+	res, err := c.MempoolForSigner(ctx, sampleSigner)
+	assert.NoError(t, err)
+	assert.Empty(t, res.Pending)
+	assert.Empty(t, res.Queued)
+}
+
+func TestMempoolForSignerFail(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "txpool_contentFrom", mock.Anything).Return(&rpcbackend.RPCError{Message: "pop"})
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "txpool_content").Return(&rpcbackend.RPCError{Message: "pop"})
+
+	_, err := c.MempoolForSigner(ctx, sampleSigner)
+	assert.Regexp(t, "pop", err)
+}
+
+func TestMempoolForSignerBadSigner(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t)
+	defer done()
+
+	_, err := c.MempoolForSigner(ctx, "wrong")
+	assert.Regexp(t, "FF23019", err)
+}
+
+func TestMempoolTransactionQueued(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mockGetMempoolTx(mRPC, `{"hash": "`+sampleMempoolTxHash+`", "nonce": "0xd", "from": "`+sampleSigner+`", "blockNumber": null}`)
+	mockTxPoolContentFrom(mRPC, sampleTxPoolContentFrom)
+
+	res, err := c.MempoolTransactionStatus(ctx, sampleMempoolTxHash)
+	assert.NoError(t, err)
+	assert.Equal(t, MempoolStatusQueued, res.Status)
+	assert.Equal(t, sampleMempoolTxHash, res.TransactionHash)
+}
+
+func TestMempoolTransactionPending(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mockGetMempoolTx(mRPC, `{"hash": "`+sampleMempoolTxHash+`", "nonce": "0xa", "from": "`+sampleSigner+`"}`)
+	mockTxPoolContentFrom(mRPC, sampleTxPoolContentFrom)
+
+	res, err := c.MempoolTransactionStatus(ctx, sampleMempoolTxHash)
+	assert.NoError(t, err)
+	assert.Equal(t, MempoolStatusPending, res.Status)
+}
+
+func TestMempoolTransactionPendingNoTxPool(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mockGetMempoolTx(mRPC, `{"hash": "`+sampleMempoolTxHash+`", "nonce": "0xd", "from": "`+sampleSigner+`"}`)
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "txpool_contentFrom", mock.Anything).Return(&rpcbackend.RPCError{Message: "pop"})
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "txpool_content").Return(&rpcbackend.RPCError{Message: "pop"})
+
+	res, err := c.MempoolTransactionStatus(ctx, sampleMempoolTxHash)
+	assert.NoError(t, err)
+	assert.Equal(t, MempoolStatusPending, res.Status)
+}
+
+func TestMempoolTransactionMined(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mockGetMempoolTx(mRPC, `{"hash": "`+sampleMempoolTxHash+`", "nonce": "0xd", "from": "`+sampleSigner+`", "blockNumber": "0x7b"}`)
+
+	res, err := c.MempoolTransactionStatus(ctx, sampleMempoolTxHash)
+	assert.NoError(t, err)
+	assert.Equal(t, MempoolStatusMined, res.Status)
+	assert.Equal(t, int64(123), res.Transaction.BlockNumber.BigInt().Int64())
+}
+
+func TestMempoolTransactionUnknown(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionByHash", mock.Anything).Return(nil)
+
+	res, err := c.MempoolTransactionStatus(ctx, sampleMempoolTxHash)
+	assert.NoError(t, err)
+	assert.Equal(t, MempoolStatusUnknown, res.Status)
+	assert.Nil(t, res.Transaction)
+}
+
+func TestMempoolTransactionFail(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionByHash", mock.Anything).Return(&rpcbackend.RPCError{Message: "pop"})
+
+	_, err := c.MempoolTransactionStatus(ctx, sampleMempoolTxHash)
+	assert.Regexp(t, "pop", err)
+}
+
+func TestMempoolTransactionBadHash(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t)
+	defer done()
+
+	_, err := c.MempoolTransactionStatus(ctx, "0x1234")
+	assert.Regexp(t, "FF23089", err)
+}
+
+func TestGetMempoolRoutes(t *testing.T) {
+	_, c, mRPC, done := newTestConnector(t)
+	defer done()
+	url, close := newTestRouteServer(t, c)
+	defer close()
+
+	mockTxPoolContentFrom(mRPC, sampleTxPoolContentFrom)
+	mockGetMempoolTx(mRPC, `{"hash": "`+sampleMempoolTxHash+`", "nonce": "0xd", "from": "`+sampleSigner+`"}`)
+
+	res, err := http.Get(url + "/signers/" + sampleSigner + "/mempool")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var signerRes MempoolSignerResponse
+	err = json.NewDecoder(res.Body).Decode(&signerRes)
+	assert.NoError(t, err)
+	assert.Len(t, signerRes.Pending, 2)
+
+	res, err = http.Get(url + "/transactions/" + sampleMempoolTxHash + "/mempool")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var txRes MempoolTransactionResponse
+	err = json.NewDecoder(res.Body).Decode(&txRes)
+	assert.NoError(t, err)
+	assert.Equal(t, MempoolStatusQueued, txRes.Status)
+}
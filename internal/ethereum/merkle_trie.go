@@ -0,0 +1,202 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/hyperledger/firefly-signer/pkg/rlp"
+)
+
+// merkleTrie is an in-memory Merkle Patricia Trie, built in one pass from a complete set of keys and values,
+// as used for the transactions and receipts roots of a block. It only supports building the trie, and
+// generating inclusion proofs from it - not updates.
+type merkleTrie struct {
+	root *trieNode
+}
+
+type trieNodeType int
+
+const (
+	trieLeaf trieNodeType = iota
+	trieExtension
+	trieBranch
+)
+
+type trieNode struct {
+	nodeType trieNodeType
+	path     []byte // nibbles, for leaf and extension nodes
+	value    []byte // for leaf nodes, and branch nodes that terminate a key
+	child    *trieNode
+	children [16]*trieNode
+	encoded  []byte
+}
+
+type trieEntry struct {
+	nibbles []byte
+	value   []byte
+}
+
+func toNibbles(key []byte) []byte {
+	nibbles := make([]byte, len(key)*2)
+	for i, b := range key {
+		nibbles[i*2] = b >> 4
+		nibbles[i*2+1] = b & 0x0f
+	}
+	return nibbles
+}
+
+// hexPrefix is the compact encoding of a nibble path, with a flag for whether the node is a leaf
+func hexPrefix(nibbles []byte, leaf bool) []byte {
+	flag := byte(0)
+	if leaf {
+		flag = 2
+	}
+	var b []byte
+	if len(nibbles)%2 == 1 {
+		b = append(b, (flag+1)<<4|nibbles[0])
+		nibbles = nibbles[1:]
+	} else {
+		b = append(b, flag<<4)
+	}
+	for i := 0; i < len(nibbles); i += 2 {
+		b = append(b, nibbles[i]<<4|nibbles[i+1])
+	}
+	return b
+}
+
+func newMerkleTrie(keys, values [][]byte) *merkleTrie {
+	entries := make([]*trieEntry, len(keys))
+	for i := range keys {
+		entries[i] = &trieEntry{nibbles: toNibbles(keys[i]), value: values[i]}
+	}
+	sort.Slice(entries, func(i, j int) bool { return bytes.Compare(entries[i].nibbles, entries[j].nibbles) < 0 })
+	t := &merkleTrie{}
+	if len(entries) > 0 {
+		t.root = buildTrieNode(entries, 0)
+	}
+	return t
+}
+
+// buildTrieNode builds the node for a sorted set of entries, that all share the first depth nibbles
+func buildTrieNode(entries []*trieEntry, depth int) *trieNode {
+	if len(entries) == 1 {
+		return &trieNode{nodeType: trieLeaf, path: entries[0].nibbles[depth:], value: entries[0].value}
+	}
+
+	// As the entries are sorted, the prefix common to all is the prefix common to the first and last
+	first, last := entries[0].nibbles, entries[len(entries)-1].nibbles
+	prefixLen := 0
+	for depth+prefixLen < len(first) && depth+prefixLen < len(last) && first[depth+prefixLen] == last[depth+prefixLen] {
+		prefixLen++
+	}
+	if prefixLen > 0 {
+		return &trieNode{nodeType: trieExtension, path: first[depth : depth+prefixLen], child: buildTrieNode(entries, depth+prefixLen)}
+	}
+
+	n := &trieNode{nodeType: trieBranch}
+	start := 0
+	if len(first) == depth {
+		// Only the first of the sorted entries can terminate here
+		n.value = entries[0].value
+		start = 1
+	}
+	for start < len(entries) {
+		nibble := entries[start].nibbles[depth]
+		end := start + 1
+		for end < len(entries) && entries[end].nibbles[depth] == nibble {
+			end++
+		}
+		n.children[nibble] = buildTrieNode(entries[start:end], depth+1)
+		start = end
+	}
+	return n
+}
+
+func (n *trieNode) encode() []byte {
+	if n.encoded == nil {
+		var l rlp.List
+		switch n.nodeType {
+		case trieLeaf:
+			l = rlp.List{rlp.Data(hexPrefix(n.path, true)), rlp.Data(n.value)}
+		case trieExtension:
+			l = rlp.List{rlp.Data(hexPrefix(n.path, false)), n.child.reference()}
+		default:
+			l = make(rlp.List, 17)
+			for i, c := range n.children {
+				if c == nil {
+					l[i] = rlp.Data{}
+				} else {
+					l[i] = c.reference()
+				}
+			}
+			l[16] = rlp.Data(n.value)
+		}
+		n.encoded = l.Encode()
+	}
+	return n.encoded
+}
+
+// embedded nodes are those with an encoding shorter than a hash, which are included directly in their parent
+func (n *trieNode) embedded() bool {
+	return len(n.encode()) < 32
+}
+
+func (n *trieNode) reference() rlp.Element {
+	if n.embedded() {
+		decoded, _, _ := rlp.Decode(n.encode())
+		return decoded
+	}
+	return rlp.Data(keccak256(n.encode()))
+}
+
+// rootHash is the keccak256 hash of the root node, which is always hashed even when it is short
+func (t *merkleTrie) rootHash() []byte {
+	if t.root == nil {
+		return keccak256(rlp.Data{}.Encode())
+	}
+	return keccak256(t.root.encode())
+}
+
+// proof returns the encoded nodes on the path to a key, starting at the root. Embedded nodes are not returned
+// separately, as they are contained in the encoding of their parent. Returns false if the key is not in the trie.
+func (t *merkleTrie) proof(key []byte) ([][]byte, bool) {
+	nibbles := toNibbles(key)
+	var proof [][]byte
+	for n := t.root; n != nil; {
+		if n == t.root || !n.embedded() {
+			proof = append(proof, n.encode())
+		}
+		switch n.nodeType {
+		case trieLeaf:
+			return proof, bytes.Equal(n.path, nibbles)
+		case trieExtension:
+			if !bytes.HasPrefix(nibbles, n.path) {
+				return nil, false
+			}
+			nibbles = nibbles[len(n.path):]
+			n = n.child
+		default:
+			if len(nibbles) == 0 {
+				return proof, n.value != nil
+			}
+			n, nibbles = n.children[nibbles[0]], nibbles[1:]
+		}
+	}
+	return nil, false
+}
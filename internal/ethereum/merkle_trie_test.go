@@ -0,0 +1,117 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rlp"
+	"github.com/stretchr/testify/assert"
+)
+
+func testTrie(kvs ...string) *merkleTrie {
+	keys := make([][]byte, 0, len(kvs)/2)
+	values := make([][]byte, 0, len(kvs)/2)
+	for i := 0; i < len(kvs); i += 2 {
+		keys = append(keys, []byte(kvs[i]))
+		values = append(values, []byte(kvs[i+1]))
+	}
+	return newMerkleTrie(keys, values)
+}
+
+// verifyTrieProof walks a proof from the root hash, as an independent verifier would
+func verifyTrieProof(t *testing.T, rootHash, key []byte, proof [][]byte) []byte {
+	nibbles := toNibbles(key)
+	expectedHash := rootHash
+	var node rlp.Element
+	for i := 0; ; {
+		if node == nil {
+			assert.Less(t, i, len(proof))
+			assert.Equal(t, ethtypes.HexBytes0xPrefix(expectedHash), ethtypes.HexBytes0xPrefix(keccak256(proof[i])))
+			node, _, _ = rlp.Decode(proof[i])
+			i++
+		}
+		l := node.(rlp.List)
+		var next rlp.Element
+		if len(l) == 17 {
+			if len(nibbles) == 0 {
+				return []byte(l[16].ToData())
+			}
+			next, nibbles = l[nibbles[0]], nibbles[1:]
+		} else {
+			path := l[0].ToData()
+			isLeaf := path[0]>>4 >= 2
+			pathNibbles := toNibbles(path)[2-(path[0]>>4)%2:]
+			assert.True(t, bytes.HasPrefix(nibbles, pathNibbles))
+			nibbles = nibbles[len(pathNibbles):]
+			if isLeaf {
+				assert.Empty(t, nibbles)
+				return []byte(l[1].ToData())
+			}
+			next = l[1]
+		}
+		if next.IsList() {
+			node = next
+		} else {
+			expectedHash, node = next.ToData(), nil
+		}
+	}
+}
+
+func TestMerkleTrieKnownRoots(t *testing.T) {
+	assert.Equal(t, "0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421",
+		ethtypes.HexBytes0xPrefix(testTrie().rootHash()).String())
+
+	assert.Equal(t, "0x5991bb8c6514148a29db676a14ac506cd2cd5775ace63c30a4fe457715e9ac84",
+		ethtypes.HexBytes0xPrefix(testTrie("do", "verb", "horse", "stallion", "doge", "coin", "dog", "puppy").rootHash()).String())
+
+	assert.Equal(t, "0x17beaa1648bafa633cda809c90c04af50fc8aed3cb40d16efbddee6fdf63c4c3",
+		ethtypes.HexBytes0xPrefix(testTrie("foo", "bar", "food", "bass").rootHash()).String())
+}
+
+func TestMerkleTrieProofs(t *testing.T) {
+	trie := testTrie("do", "verb", "horse", "stallion", "doge", "coin", "dog", "puppy")
+	for _, kv := range [][]string{{"do", "verb"}, {"horse", "stallion"}, {"doge", "coin"}, {"dog", "puppy"}} {
+		proof, ok := trie.proof([]byte(kv[0]))
+		assert.True(t, ok)
+		assert.Equal(t, kv[1], string(verifyTrieProof(t, trie.rootHash(), []byte(kv[0]), proof)))
+	}
+
+	for _, missing := range []string{"d", "hors", "cat", "doges"} {
+		_, ok := trie.proof([]byte(missing))
+		assert.False(t, ok, missing)
+	}
+}
+
+func TestMerkleTrieReceiptKeys(t *testing.T) {
+	// Receipt tries are keyed by the RLP encoding of the transaction index, which gives
+	// a mix of hashed and embedded nodes once there are enough entries
+	var keys, values [][]byte
+	for i := int64(0); i < 300; i++ {
+		keys = append(keys, rlp.WrapInt(big.NewInt(i)).Encode())
+		values = append(values, big.NewInt(i*7+1).Bytes())
+	}
+	trie := newMerkleTrie(keys, values)
+	for i, k := range keys {
+		proof, ok := trie.proof(k)
+		assert.True(t, ok)
+		assert.Equal(t, values[i], verifyTrieProof(t, trie.rootHash(), k, proof))
+	}
+}
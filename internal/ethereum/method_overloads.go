@@ -0,0 +1,90 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+)
+
+// abiMethodSelection is the form of a method that selects one function from an ABI, such as one of the overloads of
+// a function, in place of the ABI of the function itself
+type abiMethodSelection struct {
+	ABI    abi.ABI `json:"abi"`
+	Method string  `json:"method"` // The name of the function, its full signature such as "transfer(address,uint256)", or its 0x prefixed selector
+}
+
+// parseABIMethod parses the method of a request, which is either the ABI of a function, or an ABI with the function
+// to select from it
+func parseABIMethod(ctx context.Context, method *fftypes.JSONAny) (*abi.Entry, error) {
+	var selection *abiMethodSelection
+	var entry *abi.Entry
+	b := method.Bytes()
+	if err := json.Unmarshal(b, &selection); err != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgUnmarshalABIMethodFail, err)
+	}
+	if selection == nil || selection.ABI == nil {
+		if err := json.Unmarshal(b, &entry); err != nil {
+			return nil, i18n.NewError(ctx, msgs.MsgUnmarshalABIMethodFail, err)
+		}
+		return entry, nil
+	}
+	return selectFunction(ctx, selection.ABI, selection.Method)
+}
+
+// selectFunction returns the function of the ABI matching a name, signature or selector, which must be unique. The
+// signatures of the candidates are listed when a name matches more than one overload.
+func selectFunction(ctx context.Context, a abi.ABI, method string) (*abi.Entry, error) {
+	match := func(e *abi.Entry) bool { return e.Name == method }
+	switch {
+	case strings.Contains(method, "("):
+		signature := strings.Join(strings.Fields(method), "")
+		match = func(e *abi.Entry) bool { return e.String() == signature }
+	case strings.HasPrefix(method, "0x"):
+		selector, err := ethtypes.NewHexBytes0xPrefix(method)
+		if err != nil || len(selector) != 4 {
+			return nil, i18n.NewError(ctx, msgs.MsgInvalidMethodSelector, method)
+		}
+		match = func(e *abi.Entry) bool { return bytes.Equal(e.FunctionSelectorBytes(), selector) }
+	}
+	var candidates []*abi.Entry
+	for _, e := range a {
+		if e.IsFunction() && match(e) {
+			candidates = append(candidates, e)
+		}
+	}
+	switch len(candidates) {
+	case 0:
+		return nil, i18n.NewError(ctx, msgs.MsgMethodNotInABI, method)
+	case 1:
+		return candidates[0], nil
+	default:
+		signatures := make([]string, len(candidates))
+		for i, e := range candidates {
+			signatures[i] = e.String()
+		}
+		return nil, i18n.NewError(ctx, msgs.MsgAmbiguousMethod, method, strings.Join(signatures, ", "))
+	}
+}
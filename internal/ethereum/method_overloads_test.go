@@ -0,0 +1,111 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+	"github.com/stretchr/testify/assert"
+)
+
+const testOverloadedABI = `[
+	{"type": "function", "name": "safeTransferFrom", "inputs": [{"name": "from", "type": "address"}, {"name": "to", "type": "address"}, {"name": "tokenId", "type": "uint256"}]},
+	{"type": "function", "name": "safeTransferFrom", "inputs": [{"name": "from", "type": "address"}, {"name": "to", "type": "address"}, {"name": "tokenId", "type": "uint256"}, {"name": "data", "type": "bytes"}]},
+	{"type": "event", "name": "approve", "inputs": []},
+	{"type": "function", "name": "approve", "inputs": [{"name": "to", "type": "address"}, {"name": "tokenId", "type": "uint256"}]}
+]`
+
+func TestSelectFunction(t *testing.T) {
+	ctx := context.Background()
+	var a abi.ABI
+	err := json.Unmarshal([]byte(testOverloadedABI), &a)
+	assert.NoError(t, err)
+
+	// A unique name selects the function, and not an event of the same name
+	e, err := selectFunction(ctx, a, "approve")
+	assert.NoError(t, err)
+	assert.Equal(t, "approve(address,uint256)", e.String())
+
+	e, err = selectFunction(ctx, a, "safeTransferFrom(address, address, uint256, bytes)")
+	assert.NoError(t, err)
+	assert.Len(t, e.Inputs, 4)
+
+	e, err = selectFunction(ctx, a, "0x42842e0e")
+	assert.NoError(t, err)
+	assert.Equal(t, "safeTransferFrom(address,address,uint256)", e.String())
+
+	_, err = selectFunction(ctx, a, "safeTransferFrom")
+	assert.Regexp(t, `FF23206.*safeTransferFrom\(address,address,uint256\), safeTransferFrom\(address,address,uint256,bytes\)`, err)
+
+	_, err = selectFunction(ctx, a, "transfer")
+	assert.Regexp(t, "FF23205.*transfer", err)
+	_, err = selectFunction(ctx, a, "approve(address)")
+	assert.Regexp(t, "FF23205", err)
+
+	for _, selector := range []string{"0xzz", "0x1234"} {
+		_, err = selectFunction(ctx, a, selector)
+		assert.Regexp(t, "FF23204", err)
+	}
+}
+
+func TestParseABIMethod(t *testing.T) {
+	ctx := context.Background()
+
+	e, err := parseABIMethod(ctx, fftypes.JSONAnyPtr(`{"type": "function", "name": "do", "inputs": []}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "do()", e.String())
+
+	e, err = parseABIMethod(ctx, fftypes.JSONAnyPtr(`{"abi": `+testOverloadedABI+`, "method": "safeTransferFrom(address,address,uint256)"}`))
+	assert.NoError(t, err)
+	assert.Len(t, e.Inputs, 3)
+
+	_, err = parseABIMethod(ctx, fftypes.JSONAnyPtr(`{"abi": "wrong"}`))
+	assert.Regexp(t, "FF23013", err)
+	_, err = parseABIMethod(ctx, fftypes.JSONAnyPtr(`{"inputs": false}`))
+	assert.Regexp(t, "FF23013", err)
+}
+
+func TestPrepareTransactionOverloadedMethod(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t, enableErrorDetails)
+	defer done()
+
+	var req ffcapi.TransactionPrepareRequest
+	err := json.Unmarshal([]byte(samplePrepareTXWithGas), &req)
+	assert.NoError(t, err)
+	req.Method = fftypes.JSONAnyPtr(`{"abi": ` + testOverloadedABI + `, "method": "safeTransferFrom(address,address,uint256,bytes)"}`)
+	req.Params = []*fftypes.JSONAny{
+		fftypes.JSONAnyPtr(`"0xb480F96c0a3d6E9e9a263e4665a39bFa6c4d01E8"`),
+		fftypes.JSONAnyPtr(`"0xe1a078b9e2b145d0a7387f09277c6ae1d9470771"`),
+		fftypes.JSONAnyPtr(`"1"`),
+		fftypes.JSONAnyPtr(`"0xfeed"`),
+	}
+	res, _, err := c.TransactionPrepare(ctx, &req)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(res.TransactionData, "0xb88d4fde"))
+
+	req.Method = fftypes.JSONAnyPtr(`{"abi": ` + testOverloadedABI + `, "method": "safeTransferFrom"}`)
+	_, reason, err := c.TransactionPrepare(ctx, &req)
+	assert.Regexp(t, "FF23206", err)
+	assert.Equal(t, ffcapi.ErrorReasonInvalidInputs, reason)
+	assert.Equal(t, "method", parseErrorDetails(t, err).Field)
+}
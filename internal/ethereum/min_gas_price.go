@@ -0,0 +1,72 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"math/big"
+	"regexp"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/log"
+)
+
+// minGasPriceErrorRegex extracts the minimum of the node from the error geth returns for a transaction below its
+// txpool.pricelimit, such as "transaction underpriced: gas tip cap 1, minimum needed 1000000000"
+var minGasPriceErrorRegex = regexp.MustCompile(`minimum needed (\d+)`)
+
+// learnMinGasPrice records the minimum gas price the node reported when it rejected a transaction as underpriced.
+// The latest report replaces any earlier one, so a floor that is lowered on the node is learned too.
+func (c *ethConnector) learnMinGasPrice(ctx context.Context, errMessage string) {
+	if !c.gas().learnMinGasPrice {
+		return
+	}
+	match := minGasPriceErrorRegex.FindStringSubmatch(errMessage)
+	if match == nil {
+		return
+	}
+	minGasPrice, _ := new(big.Int).SetString(match[1], 10)
+	if previous := c.learnedMinGasPrice.Swap(minGasPrice); previous == nil || previous.Cmp(minGasPrice) != 0 {
+		log.L(ctx).Infof("Learned minimum gas price %s of the node from an underpriced transaction", minGasPrice)
+	}
+}
+
+// minGasPrice returns the minimum gas price of the node, which is the higher of the configured and learned
+// minimums, or nil if neither is known
+func (c *ethConnector) minGasPrice() *big.Int {
+	gp := c.gas()
+	minGasPrice := gp.minGasPrice
+	if learned := c.learnedMinGasPrice.Load(); gp.learnMinGasPrice && learned != nil && (minGasPrice == nil || learned.Cmp(minGasPrice) > 0) {
+		minGasPrice = learned
+	}
+	return minGasPrice
+}
+
+// applyMinGasPrice raises the priority fees of the suggestions to the minimum gas price, which nodes such as geth
+// apply to the priority fee of EIP-1559 transactions, increasing the max fees by the same amount
+func applyMinGasPrice(suggestions *FeeSuggestions, minGasPrice *big.Int) {
+	if suggestions == nil {
+		return
+	}
+	for _, s := range []*FeeSuggestion{suggestions.Low, suggestions.Medium, suggestions.High} {
+		priorityFee := s.MaxPriorityFeePerGas.Int()
+		if shortfall := new(big.Int).Sub(minGasPrice, priorityFee); shortfall.Sign() > 0 {
+			s.MaxPriorityFeePerGas = (*fftypes.FFBigInt)(new(big.Int).Set(minGasPrice))
+			s.MaxFeePerGas = (*fftypes.FFBigInt)(new(big.Int).Add(s.MaxFeePerGas.Int(), shortfall))
+		}
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-evmconnect/mocks/rpcbackendmocks"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const sampleUnderpricedError = "transaction underpriced: gas tip cap 1, minimum needed 500"
+
+func mockNodeGasPrice(mRPC *rpcbackendmocks.Backend, gasPrice int64) {
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_gasPrice").
+		Run(func(args mock.Arguments) {
+			*(args[1].(*ethtypes.HexInteger)) = *ethtypes.NewHexInteger64(gasPrice)
+		}).
+		Return(nil)
+}
+
+func sendUnderpriced(t *testing.T, ctx context.Context, c *ethConnector, mRPC *rpcbackendmocks.Backend, message string) {
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_sendTransaction", mock.Anything).
+		Return(&rpcbackend.RPCError{Code: -32000, Message: message}).Once()
+	var req ffcapi.TransactionSendRequest
+	err := json.Unmarshal([]byte(sampleSendTX), &req)
+	assert.NoError(t, err)
+	_, reason, err := c.TransactionSend(ctx, &req)
+	assert.Regexp(t, "underpriced", err)
+	assert.Equal(t, ffcapi.ErrorReasonTransactionUnderpriced, reason)
+}
+
+func TestGasPriceEstimateConfiguredMinGasPrice(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t, func(conf config.Section) {
+		conf.Set(MinGasPriceValue, "1000")
+	})
+	defer done()
+	mockNodeGasPrice(mRPC, 100)
+
+	res, _, err := c.GasPriceEstimate(ctx, &ffcapi.GasPriceEstimateRequest{})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"gasPrice": "1000", "minGasPrice": "1000"}`, res.GasPrice.String())
+
+	// A lower learned minimum does not replace the configured one
+	sendUnderpriced(t, ctx, c, mRPC, sampleUnderpricedError)
+	res, _, err = c.GasPriceEstimate(ctx, &ffcapi.GasPriceEstimateRequest{})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"gasPrice": "1000", "minGasPrice": "1000"}`, res.GasPrice.String())
+}
+
+func TestGasPriceEstimateLearnedMinGasPrice(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+	mockNodeGasPrice(mRPC, 100)
+
+	// Errors without the minimum of the node are ignored
+	sendUnderpriced(t, ctx, c, mRPC, "transaction underpriced")
+	res, _, err := c.GasPriceEstimate(ctx, &ffcapi.GasPriceEstimateRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, `"100"`, res.GasPrice.String())
+
+	sendUnderpriced(t, ctx, c, mRPC, sampleUnderpricedError)
+	sendUnderpriced(t, ctx, c, mRPC, sampleUnderpricedError)
+	res, _, err = c.GasPriceEstimate(ctx, &ffcapi.GasPriceEstimateRequest{})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"gasPrice": "500", "minGasPrice": "500"}`, res.GasPrice.String())
+}
+
+func TestGasPriceEstimateLearnMinGasPriceDisabled(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t, func(conf config.Section) {
+		conf.Set(MinGasPriceLearn, false)
+	})
+	defer done()
+	mockNodeGasPrice(mRPC, 100)
+
+	sendUnderpriced(t, ctx, c, mRPC, sampleUnderpricedError)
+	assert.Nil(t, c.learnedMinGasPrice.Load())
+	res, _, err := c.GasPriceEstimate(ctx, &ffcapi.GasPriceEstimateRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, `"100"`, res.GasPrice.String())
+}
+
+func TestGasPriceSuggestionsMinGasPrice(t *testing.T) {
+	ctx, c, mRPC, done := newTestSuggestionsConnector(t)
+	defer done()
+	mockFeeBumpFeeHistory(mRPC)
+	c.learnedMinGasPrice.Store(ethtypes.NewHexInteger64(8).BigInt())
+
+	res, _, err := c.GasPriceEstimate(ctx, &ffcapi.GasPriceEstimateRequest{})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"gasPrice": "12345",
+		"minGasPrice": "8",
+		"suggestions": {
+			"low": {"maxPriorityFeePerGas": "8", "maxFeePerGas": "3255", "targetBlocks": 10},
+			"medium": {"maxPriorityFeePerGas": "8", "maxFeePerGas": "1431", "targetBlocks": 3},
+			"high": {"maxPriorityFeePerGas": "10", "maxFeePerGas": "1135", "targetBlocks": 1}
+		}
+	}`, res.GasPrice.String())
+
+	applyMinGasPrice(nil, ethtypes.NewHexInteger64(8).BigInt())
+}
+
+func TestMinGasPriceConfig(t *testing.T) {
+	config.RootConfigReset()
+	conf := config.RootSection("unittest")
+	InitConfig(conf)
+	conf.Set(MinGasPriceValue, "0x3e8")
+	gp, err := newGasPolicy(context.Background(), conf, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1000), gp.minGasPrice.Int64())
+
+	for _, invalid := range []string{"lots", "-1", "1.5"} {
+		conf.Set(MinGasPriceValue, invalid)
+		_, err = newGasPolicy(context.Background(), conf, nil)
+		assert.Regexp(t, "FF23186", err, invalid)
+	}
+}
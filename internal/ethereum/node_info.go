@@ -0,0 +1,53 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+)
+
+// NodeInfo identifies the build of the node that answered, which might be any one of the nodes behind a
+// load balancer. Each field is omitted if the node does not support, or has disabled, the method it comes from.
+type NodeInfo struct {
+	ClientVersion   string `json:"clientVersion,omitempty"`
+	ProtocolVersion string `json:"protocolVersion,omitempty"`
+	PeerCount       *int64 `json:"peerCount,omitempty"`
+}
+
+// nodeInfo queries the details of the node on a best-effort basis, as many nodes disable the net and
+// web3 namespaces, and eth_protocolVersion has been removed from recent clients
+func nodeInfo(ctx context.Context, backend rpcbackend.RPC) *NodeInfo {
+	info := &NodeInfo{}
+	if rpcErr := backend.CallRPC(ctx, &info.ClientVersion, "web3_clientVersion"); rpcErr != nil {
+		log.L(ctx).Debugf("Client version could not be obtained: %s", rpcErr.Message)
+	}
+	if rpcErr := backend.CallRPC(ctx, &info.ProtocolVersion, "eth_protocolVersion"); rpcErr != nil {
+		log.L(ctx).Debugf("Protocol version could not be obtained: %s", rpcErr.Message)
+	}
+	var peerCount ethtypes.HexInteger
+	if rpcErr := backend.CallRPC(ctx, &peerCount, "net_peerCount"); rpcErr != nil {
+		log.L(ctx).Debugf("Peer count could not be obtained: %s", rpcErr.Message)
+	} else {
+		count := peerCount.BigInt().Int64()
+		info.PeerCount = &count
+	}
+	return info
+}
@@ -0,0 +1,102 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-evmconnect/mocks/rpcbackendmocks"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func mockNodeInfo(m *rpcbackendmocks.Backend, clientVersion string, peerCount int64) {
+	m.On("CallRPC", mock.Anything, mock.Anything, "web3_clientVersion").
+		Run(func(args mock.Arguments) {
+			*(args[1].(*string)) = clientVersion
+		}).
+		Return(nil)
+	m.On("CallRPC", mock.Anything, mock.Anything, "eth_protocolVersion").
+		Run(func(args mock.Arguments) {
+			*(args[1].(*string)) = "0x41"
+		}).
+		Return(nil)
+	m.On("CallRPC", mock.Anything, mock.Anything, "net_peerCount").
+		Run(func(args mock.Arguments) {
+			*(args[1].(*ethtypes.HexInteger)) = *ethtypes.NewHexInteger64(peerCount)
+		}).
+		Return(nil)
+}
+
+func mockNodeInfoUnsupported(m *rpcbackendmocks.Backend) {
+	for _, method := range []string{"web3_clientVersion", "eth_protocolVersion", "net_peerCount"} {
+		m.On("CallRPC", mock.Anything, mock.Anything, method).
+			Return(&rpcbackend.RPCError{Message: "the method " + method + " does not exist/is not available"}).Maybe()
+	}
+}
+
+func TestNodeInfo(t *testing.T) {
+	mRPC := &rpcbackendmocks.Backend{}
+	mockNodeInfo(mRPC, "Geth/v1.14.12-stable/linux-amd64/go1.23.4", 25)
+
+	info := nodeInfo(context.Background(), mRPC)
+	assert.Equal(t, "Geth/v1.14.12-stable/linux-amd64/go1.23.4", info.ClientVersion)
+	assert.Equal(t, "0x41", info.ProtocolVersion)
+	assert.Equal(t, int64(25), *info.PeerCount)
+}
+
+func TestNodeInfoUnsupported(t *testing.T) {
+	mRPC := &rpcbackendmocks.Backend{}
+	mockNodeInfoUnsupported(mRPC)
+
+	info := nodeInfo(context.Background(), mRPC)
+	b, err := json.Marshal(info)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{}`, string(b))
+}
+
+func TestNodeInfoAdminStatus(t *testing.T) {
+	_, c, mRPC, done := newTestConnector(t, func(conf config.Section) {
+		conf.SubSection(ReadEndpointConfig).Set(ffresty.HTTPConfigURL, "http://localhost:8546")
+		conf.Set(ReadLagCheckInterval, "0")
+	})
+	defer done()
+	url, close := newTestRouteServer(t, c)
+	defer close()
+	mReadRPC := &rpcbackendmocks.Backend{}
+	c.readOnlyBackend = mReadRPC
+	mockNodeInfo(mRPC, "besu/v24.12.2/linux-x86_64/openjdk-java-21", 4)
+	mockNodeInfoUnsupported(mReadRPC)
+
+	res, err := http.Get(url + "/admin/status")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var status ConnectorStatus
+	err = json.NewDecoder(res.Body).Decode(&status)
+	assert.NoError(t, err)
+	assert.Equal(t, "besu/v24.12.2/linux-x86_64/openjdk-java-21", status.Endpoints["primary"].Node.ClientVersion)
+	assert.Equal(t, int64(4), *status.Endpoints["primary"].Node.PeerCount)
+	assert.Empty(t, status.Endpoints["read"].Node.ClientVersion)
+	assert.Nil(t, status.Endpoints["read"].Node.PeerCount)
+}
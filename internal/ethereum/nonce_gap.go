@@ -0,0 +1,204 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"math/big"
+	"sort"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+)
+
+// DefaultNonceGapMaxFill is the maximum number of fill transactions submitted by one request, unless overridden
+const DefaultNonceGapMaxFill = 50
+
+// fillTransactionGas is the gas of a plain value transfer, which is all a fill transaction needs
+const fillTransactionGas = 21000
+
+type NonceGapRequest struct {
+	Signer   string           `json:"signer"`
+	Fill     bool             `json:"fill,omitempty"`
+	GasPrice *fftypes.JSONAny `json:"gasPrice,omitempty"`
+	MaxFill  int              `json:"maxFill,omitempty"`
+}
+
+// NonceGapResponse is the nonce state of a signer on the node. Transactions queued in the txpool with
+// nonces above a missing nonce cannot be mined until the gap is filled.
+type NonceGapResponse struct {
+	Signer          string                `json:"signer"`
+	LatestNonce     *fftypes.FFBigInt     `json:"latestNonce"`
+	PendingNonce    *fftypes.FFBigInt     `json:"pendingNonce"`
+	TxPoolAvailable bool                  `json:"txpoolAvailable"`
+	QueuedNonces    []*fftypes.FFBigInt   `json:"queuedNonces"`
+	MissingNonces   []*fftypes.FFBigInt   `json:"missingNonces"`
+	Fills           []*NonceGapFillResult `json:"fills,omitempty"`
+}
+
+type NonceGapFillResult struct {
+	Nonce           *fftypes.FFBigInt `json:"nonce"`
+	TransactionHash string            `json:"transactionHash,omitempty"`
+	Error           string            `json:"error,omitempty"`
+}
+
+// queuedNonces gets the nonces of the transactions of the signer that are queued in the txpool of the node,
+// which are the transactions that cannot be mined yet because of a nonce gap. Returns false if the node
+// does not support the txpool API.
+func (c *ethConnector) queuedNonces(ctx context.Context, signer *ethtypes.Address0xHex) ([]*big.Int, bool) {
+	content, rpcErr := c.txPoolContentFrom(ctx, signer)
+	if rpcErr != nil {
+		log.L(ctx).Warnf("Unable to query the txpool for signer %s: %s", signer, rpcErr.Message)
+		return nil, false
+	}
+	nonces := make([]*big.Int, 0, len(content.Queued))
+	for nonceStr := range content.Queued {
+		if nonce, ok := new(big.Int).SetString(nonceStr, 0); ok {
+			nonces = append(nonces, nonce)
+		}
+	}
+	sort.Slice(nonces, func(i, j int) bool { return nonces[i].Cmp(nonces[j]) < 0 })
+	return nonces, true
+}
+
+// NonceGap inspects the latest and pending nonces of a signer, and the transactions queued in the txpool,
+// to find the missing nonces that are blocking queued transactions. With fill set, a zero value transfer
+// from the signer to itself is submitted at each missing nonce, which requires the node to sign for the signer.
+func (c *ethConnector) NonceGap(ctx context.Context, req *NonceGapRequest) (*NonceGapResponse, error) {
+	signer, err := ethtypes.NewAddress(req.Signer)
+	if err != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidFromAddress, req.Signer, err)
+	}
+
+	// The nonce state is local to the node that the transactions were submitted to, so always use the primary
+	var latestNonce, pendingNonce ethtypes.HexInteger
+	if rpcErr := c.backend.CallRPC(ctx, &latestNonce, "eth_getTransactionCount", signer, "latest"); rpcErr != nil {
+		return nil, rpcErr.Error()
+	}
+	if rpcErr := c.backend.CallRPC(ctx, &pendingNonce, "eth_getTransactionCount", signer, "pending"); rpcErr != nil {
+		return nil, rpcErr.Error()
+	}
+	res := &NonceGapResponse{
+		Signer:        signer.String(),
+		LatestNonce:   (*fftypes.FFBigInt)(&latestNonce),
+		PendingNonce:  (*fftypes.FFBigInt)(&pendingNonce),
+		QueuedNonces:  []*fftypes.FFBigInt{},
+		MissingNonces: []*fftypes.FFBigInt{},
+	}
+
+	var queued []*big.Int
+	queued, res.TxPoolAvailable = c.queuedNonces(ctx, signer)
+	if !res.TxPoolAvailable {
+		if req.Fill {
+			return nil, i18n.NewError(ctx, msgs.MsgTxPoolNotAvailable, signer)
+		}
+		return res, nil
+	}
+
+	// Every nonce from the pending nonce up to the highest queued nonce, that is not itself queued, is missing
+	next := new(big.Int).Set(pendingNonce.BigInt())
+	for _, nonce := range queued {
+		res.QueuedNonces = append(res.QueuedNonces, (*fftypes.FFBigInt)(nonce))
+		for ; next.Cmp(nonce) < 0; next.Add(next, big.NewInt(1)) {
+			res.MissingNonces = append(res.MissingNonces, (*fftypes.FFBigInt)(new(big.Int).Set(next)))
+		}
+		if next.Cmp(nonce) == 0 {
+			next.Add(next, big.NewInt(1))
+		}
+	}
+
+	if req.Fill && len(res.MissingNonces) > 0 {
+		if err := c.fillNonceGaps(ctx, req, res); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (c *ethConnector) fillNonceGaps(ctx context.Context, req *NonceGapRequest, res *NonceGapResponse) error {
+	maxFill := req.MaxFill
+	if maxFill <= 0 {
+		maxFill = DefaultNonceGapMaxFill
+	}
+	if len(res.MissingNonces) > maxFill {
+		return i18n.NewError(ctx, msgs.MsgNonceGapTooLarge, len(res.MissingNonces), maxFill)
+	}
+
+	gasPrice := req.GasPrice
+	if gasPrice == nil {
+		nodeGasPrice, rpcErr := c.nodeGasPrice(ctx)
+		if rpcErr != nil {
+			return rpcErr.Error()
+		}
+		gasPrice = fftypes.JSONAnyPtr(`"` + nodeGasPrice.BigInt().String() + `"`)
+	}
+
+	feeCurrency, err := c.feeCurrency(ctx, gasPrice)
+	if err != nil {
+		return err
+	}
+	// Every fill is a plain value transfer with no data, so is checked against the limits once
+	if _, err := c.checkTxLimits(ctx, 0, ethtypes.NewHexInteger64(fillTransactionGas)); err != nil {
+		return err
+	}
+
+	// Each fill is submitted independently, so the result reports which gaps remain if some fail
+	res.Fills = make([]*NonceGapFillResult, len(res.MissingNonces))
+	for i, nonce := range res.MissingNonces {
+		fill := &NonceGapFillResult{Nonce: nonce}
+		res.Fills[i] = fill
+		tx, _ := c.buildTx(ctx, txTypePrePrepared, res.Signer, res.Signer, nonce, fftypes.NewFFBigInt(fillTransactionGas), fftypes.NewFFBigInt(0), nil) // signer already validated
+		if err := c.mapGasPrice(ctx, gasPrice, tx); err != nil {
+			// The gas price is the same for every fill, so this is always before the first submission
+			return err
+		}
+		var txHash ethtypes.HexBytes0xPrefix
+		rpcErr := c.backend.CallRPC(ctx, &txHash, "eth_sendTransaction", withFeeCurrency(tx, feeCurrency))
+		c.auditFill(ctx, res.Signer, nonce, txHash, rpcErr)
+		if rpcErr != nil {
+			log.L(ctx).Errorf("Failed to fill nonce gap %s for signer %s: %s", nonce, res.Signer, rpcErr.Message)
+			fill.Error = rpcErr.Message
+			continue
+		}
+		log.L(ctx).Infof("Filled nonce gap %s for signer %s with transaction %s", nonce, res.Signer, txHash)
+		fill.TransactionHash = txHash.String()
+	}
+	return nil
+}
+
+// auditFill records the submission of a fill transaction in the audit log, as a transaction the signer sends to itself
+func (c *ethConnector) auditFill(ctx context.Context, signer string, nonce *fftypes.FFBigInt, txHash ethtypes.HexBytes0xPrefix, rpcErr *rpcbackend.RPCError) {
+	req := &ffcapi.TransactionSendRequest{
+		TransactionHeaders: ffcapi.TransactionHeaders{
+			From:  signer,
+			To:    signer,
+			Nonce: nonce,
+			Gas:   fftypes.NewFFBigInt(fillTransactionGas),
+			Value: fftypes.NewFFBigInt(0),
+		},
+	}
+	if rpcErr != nil {
+		c.auditSubmission(ctx, auditOperationFillNonceGap, req, nil, nil, mapRPCError(sendRPCMethods, rpcErr), rpcErr.Error())
+		return
+	}
+	c.auditSubmission(ctx, auditOperationFillNonceGap, req, nil, &ffcapi.TransactionSendResponse{TransactionHash: txHash.String()}, "", nil)
+}
@@ -0,0 +1,296 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-evmconnect/mocks/rpcbackendmocks"
+	"github.com/hyperledger/firefly-signer/pkg/ethsigner"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const sampleSigner = "0xd0f2f5103fd050739a9fb567251bc460cc24d091"
+
+func mockNonces(mRPC *rpcbackendmocks.Backend, latest, pending int64) {
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionCount", mock.Anything, "latest").
+		Run(func(args mock.Arguments) {
+			*(args[1].(*ethtypes.HexInteger)) = *ethtypes.NewHexInteger64(latest)
+		}).
+		Return(nil)
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionCount", mock.Anything, "pending").
+		Run(func(args mock.Arguments) {
+			*(args[1].(*ethtypes.HexInteger)) = *ethtypes.NewHexInteger64(pending)
+		}).
+		Return(nil)
+}
+
+func mockTxPoolContentFrom(mRPC *rpcbackendmocks.Backend, content string) {
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "txpool_contentFrom", mock.Anything).
+		Run(func(args mock.Arguments) {
+			err := json.Unmarshal([]byte(content), args[1])
+			if err != nil {
+				panic(err)
+			}
+		}).
+		Return(nil)
+}
+
+func nonceList(nonces []*fftypes.FFBigInt) []int64 {
+	l := make([]int64, len(nonces))
+	for i, n := range nonces {
+		l[i] = n.Int64()
+	}
+	return l
+}
+
+func TestNonceGapPlan(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mockNonces(mRPC, 10, 12)
+	mockTxPoolContentFrom(mRPC, `{
+		"pending": {"10": {}, "11": {}},
+		"queued": {"17": {}, "14": {}, "15": {}}
+	}`)
+
+	res, err := c.NonceGap(ctx, &NonceGapRequest{Signer: sampleSigner})
+	assert.NoError(t, err)
+	assert.Equal(t, sampleSigner, res.Signer)
+	assert.Equal(t, int64(10), res.LatestNonce.Int64())
+	assert.Equal(t, int64(12), res.PendingNonce.Int64())
+	assert.True(t, res.TxPoolAvailable)
+	assert.Equal(t, []int64{14, 15, 17}, nonceList(res.QueuedNonces))
+	assert.Equal(t, []int64{12, 13, 16}, nonceList(res.MissingNonces))
+	assert.Nil(t, res.Fills)
+
+	mRPC.AssertExpectations(t)
+}
+
+func TestNonceGapNoGap(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mockNonces(mRPC, 5, 5)
+	mockTxPoolContentFrom(mRPC, `{"pending": {}, "queued": {}}`)
+
+	res, err := c.NonceGap(ctx, &NonceGapRequest{Signer: sampleSigner, Fill: true})
+	assert.NoError(t, err)
+	assert.Empty(t, res.MissingNonces)
+	assert.Nil(t, res.Fills)
+}
+
+func TestNonceGapFill(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mockNonces(mRPC, 3, 3)
+	mockTxPoolContentFrom(mRPC, `{"pending": {}, "queued": {"5": {}}}`)
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_gasPrice").
+		Run(func(args mock.Arguments) {
+			*(args[1].(*ethtypes.HexInteger)) = *ethtypes.NewHexInteger64(1000)
+		}).
+		Return(nil)
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_sendTransaction", mock.MatchedBy(func(tx *ethsigner.Transaction) bool {
+		return tx.Nonce.BigInt().Int64() == 3
+	})).
+		Run(func(args mock.Arguments) {
+			tx := args[3].(*ethsigner.Transaction)
+			assert.Equal(t, sampleSigner, tx.To.String())
+			assert.JSONEq(t, `"`+sampleSigner+`"`, string(tx.From))
+			assert.Equal(t, int64(fillTransactionGas), tx.GasLimit.BigInt().Int64())
+			assert.Equal(t, int64(0), tx.Value.BigInt().Int64())
+			assert.Equal(t, int64(1000), tx.GasPrice.BigInt().Int64())
+			*(args[1].(*ethtypes.HexBytes0xPrefix)) = ethtypes.MustNewHexBytes0xPrefix("0x7d48ae971faf089878b57e3c28e3035540d34f38af395958d2c73c36c57c83a2")
+		}).
+		Return(nil)
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_sendTransaction", mock.MatchedBy(func(tx *ethsigner.Transaction) bool {
+		return tx.Nonce.BigInt().Int64() == 4
+	})).
+		Return(&rpcbackend.RPCError{Message: "pop"})
+
+	res, err := c.NonceGap(ctx, &NonceGapRequest{Signer: sampleSigner, Fill: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{3, 4}, nonceList(res.MissingNonces))
+	assert.Len(t, res.Fills, 2)
+	assert.Equal(t, "0x7d48ae971faf089878b57e3c28e3035540d34f38af395958d2c73c36c57c83a2", res.Fills[0].TransactionHash)
+	assert.Empty(t, res.Fills[0].Error)
+	assert.Equal(t, int64(4), res.Fills[1].Nonce.Int64())
+	assert.Equal(t, "pop", res.Fills[1].Error)
+
+	mRPC.AssertExpectations(t)
+}
+
+func TestNonceGapFillSuppliedGasPrice(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mockNonces(mRPC, 3, 3)
+	mockTxPoolContentFrom(mRPC, `{"pending": {}, "queued": {"4": {}}}`)
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_sendTransaction", mock.MatchedBy(func(tx *ethsigner.Transaction) bool {
+		return tx.MaxFeePerGas.BigInt().Int64() == 200 && tx.MaxPriorityFeePerGas.BigInt().Int64() == 100
+	})).
+		Return(nil)
+
+	res, err := c.NonceGap(ctx, &NonceGapRequest{
+		Signer:   sampleSigner,
+		Fill:     true,
+		GasPrice: fftypes.JSONAnyPtr(`{"maxFeePerGas":"200","maxPriorityFeePerGas":"100"}`),
+	})
+	assert.NoError(t, err)
+	assert.Len(t, res.Fills, 1)
+
+	mRPC.AssertExpectations(t)
+}
+
+func TestNonceGapFillBadGasPrice(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mockNonces(mRPC, 3, 3)
+	mockTxPoolContentFrom(mRPC, `{"pending": {}, "queued": {"4": {}}}`)
+
+	_, err := c.NonceGap(ctx, &NonceGapRequest{
+		Signer:   sampleSigner,
+		Fill:     true,
+		GasPrice: fftypes.JSONAnyPtr(`false`),
+	})
+	assert.Regexp(t, "FF23015", err)
+}
+
+func TestNonceGapFillBadFeeCurrency(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mockNonces(mRPC, 3, 3)
+	mockTxPoolContentFrom(mRPC, `{"pending": {}, "queued": {"4": {}}}`)
+
+	_, err := c.NonceGap(ctx, &NonceGapRequest{
+		Signer:   sampleSigner,
+		Fill:     true,
+		GasPrice: fftypes.JSONAnyPtr(`{"gasPrice":"100","feeCurrency":"wrong"}`),
+	})
+	assert.Regexp(t, "FF23164", err)
+}
+
+func TestNonceGapFillGasPriceFail(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mockNonces(mRPC, 3, 3)
+	mockTxPoolContentFrom(mRPC, `{"pending": {}, "queued": {"4": {}}}`)
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_gasPrice").Return(&rpcbackend.RPCError{Message: "pop"})
+
+	_, err := c.NonceGap(ctx, &NonceGapRequest{Signer: sampleSigner, Fill: true})
+	assert.Regexp(t, "pop", err)
+}
+
+func TestNonceGapFillExceedsBlockGasLimit(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t, enableBlockGasLimitCheck)
+	defer done()
+
+	mockNonces(mRPC, 3, 3)
+	mockTxPoolContentFrom(mRPC, `{"pending": {}, "queued": {"4": {}}}`)
+	mockLatestBlockGasLimit(mRPC, 20000)
+
+	_, err := c.NonceGap(ctx, &NonceGapRequest{
+		Signer:   sampleSigner,
+		Fill:     true,
+		GasPrice: fftypes.JSONAnyPtr(`"100"`),
+	})
+	assert.Regexp(t, "FF23188.*21000.*20000", err)
+	mRPC.AssertNotCalled(t, "CallRPC", mock.Anything, mock.Anything, "eth_sendTransaction", mock.Anything)
+}
+
+func TestNonceGapFillTooLarge(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mockNonces(mRPC, 3, 3)
+	mockTxPoolContentFrom(mRPC, `{"pending": {}, "queued": {"10": {}}}`)
+
+	_, err := c.NonceGap(ctx, &NonceGapRequest{Signer: sampleSigner, Fill: true, MaxFill: 5})
+	assert.Regexp(t, "FF23100", err)
+}
+
+func TestNonceGapTxPoolNotAvailable(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mockNonces(mRPC, 3, 3)
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "txpool_contentFrom", mock.Anything).
+		Return(&rpcbackend.RPCError{Message: "the method txpool_contentFrom does not exist/is not available"})
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "txpool_content").
+		Return(&rpcbackend.RPCError{Message: "the method txpool_content does not exist/is not available"})
+
+	res, err := c.NonceGap(ctx, &NonceGapRequest{Signer: sampleSigner})
+	assert.NoError(t, err)
+	assert.False(t, res.TxPoolAvailable)
+	assert.Empty(t, res.MissingNonces)
+
+	_, err = c.NonceGap(ctx, &NonceGapRequest{Signer: sampleSigner, Fill: true})
+	assert.Regexp(t, "FF23099", err)
+}
+
+func TestNonceGapBadSigner(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t)
+	defer done()
+
+	_, err := c.NonceGap(ctx, &NonceGapRequest{Signer: "wrong"})
+	assert.Regexp(t, "FF23019", err)
+}
+
+func TestNonceGapNonceFail(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionCount", mock.Anything, "latest").
+		Return(&rpcbackend.RPCError{Message: "pop"}).Once()
+	_, err := c.NonceGap(ctx, &NonceGapRequest{Signer: sampleSigner})
+	assert.Regexp(t, "pop", err)
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionCount", mock.Anything, "latest").Return(nil)
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionCount", mock.Anything, "pending").
+		Return(&rpcbackend.RPCError{Message: "pop"})
+	_, err = c.NonceGap(ctx, &NonceGapRequest{Signer: sampleSigner})
+	assert.Regexp(t, "pop", err)
+}
+
+func TestPostNonceGapRoute(t *testing.T) {
+	_, c, mRPC, done := newTestConnector(t)
+	defer done()
+	url, close := newTestRouteServer(t, c)
+	defer close()
+
+	mockNonces(mRPC, 1, 1)
+	mockTxPoolContentFrom(mRPC, `{"pending": {}, "queued": {"2": {}}}`)
+
+	res, err := http.Post(url+"/signers/"+sampleSigner+"/noncegap", "application/json", bytes.NewReader([]byte(`{}`)))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var gap NonceGapResponse
+	err = json.NewDecoder(res.Body).Decode(&gap)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1}, nonceList(gap.MissingNonces))
+}
@@ -0,0 +1,169 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+)
+
+type NotificationType string
+
+const (
+	NotificationReadEndpointFailover NotificationType = "read_endpoint_failover"
+	NotificationReadEndpointRestored NotificationType = "read_endpoint_restored"
+	NotificationEndpointChanged      NotificationType = "endpoint_changed"
+	NotificationStreamLag            NotificationType = "stream_lag"
+	NotificationStreamLagRecovered   NotificationType = "stream_lag_recovered"
+	NotificationReorg                NotificationType = "reorg"
+	NotificationEventQuarantined     NotificationType = "event_quarantined"
+	NotificationEventsDeadLettered   NotificationType = "events_dead_lettered"
+	NotificationChainIDMismatch      NotificationType = "chain_id_mismatch"
+	NotificationLogMismatch          NotificationType = "log_mismatch"
+	NotificationVerificationMismatch NotificationType = "verification_mismatch"
+	NotificationWebSocketReconnected NotificationType = "websocket_reconnected"
+)
+
+var notificationTypes = []NotificationType{
+	NotificationReadEndpointFailover,
+	NotificationReadEndpointRestored,
+	NotificationEndpointChanged,
+	NotificationStreamLag,
+	NotificationStreamLagRecovered,
+	NotificationReorg,
+	NotificationEventQuarantined,
+	NotificationEventsDeadLettered,
+	NotificationChainIDMismatch,
+	NotificationLogMismatch,
+	NotificationVerificationMismatch,
+	NotificationWebSocketReconnected,
+}
+
+// Notification is posted to the webhook for significant events in the connector, that need the
+// attention of an operator
+type Notification struct {
+	Type    NotificationType   `json:"type"`
+	Time    *fftypes.FFTime    `json:"time"`
+	Message string             `json:"message"`
+	Details fftypes.JSONObject `json:"details,omitempty"`
+}
+
+// notifier posts notifications to a webhook from a background loop. Notifications are raised from the
+// block listener and event stream loops, so are dropped rather than blocking those when the queue is full.
+type notifier struct {
+	webhook         *resty.Client
+	types           map[NotificationType]bool
+	streamLagBlocks int64
+	reorgDepth      int
+	queue           chan *Notification
+	done            chan struct{}
+}
+
+// newNotifier returns nil if no webhook is configured. The webhook loop is not running until start is called.
+func newNotifier(ctx context.Context, conf config.Section) (*notifier, error) {
+	webhookConf := conf.SubSection(NotificationsWebhookConfig)
+	if webhookConf.GetString(ffresty.HTTPConfigURL) == "" {
+		return nil, nil
+	}
+	n := &notifier{
+		types:           make(map[NotificationType]bool),
+		streamLagBlocks: conf.GetInt64(NotificationsStreamLagBlocks),
+		reorgDepth:      conf.GetInt(NotificationsReorgDepth),
+		queue:           make(chan *Notification, conf.GetInt(NotificationsQueueSize)),
+		done:            make(chan struct{}),
+	}
+	enabled := conf.GetStringSlice(NotificationsEvents)
+	if len(enabled) == 0 {
+		for _, t := range notificationTypes {
+			n.types[t] = true
+		}
+	}
+	for _, s := range enabled {
+		known := false
+		for _, t := range notificationTypes {
+			if strings.EqualFold(s, string(t)) {
+				n.types[t], known = true, true
+			}
+		}
+		if !known {
+			return nil, i18n.NewError(ctx, msgs.MsgUnknownNotificationType, s, notificationTypes)
+		}
+	}
+	httpConf, err := ffresty.GenerateConfig(ctx, webhookConf)
+	if err != nil {
+		return nil, err
+	}
+	n.webhook = ffresty.NewWithConfig(ctx, *httpConf)
+	return n, nil
+}
+
+// start runs the webhook loop, once the connector can no longer fail to be created
+func (n *notifier) start(ctx context.Context) {
+	if n != nil {
+		go n.webhookLoop(ctx)
+	}
+}
+
+func (n *notifier) notify(ctx context.Context, notificationType NotificationType, details fftypes.JSONObject, message string, args ...interface{}) {
+	if n == nil || !n.types[notificationType] {
+		return
+	}
+	notification := &Notification{
+		Type:    notificationType,
+		Time:    fftypes.Now(),
+		Message: fmt.Sprintf(message, args...),
+		Details: details,
+	}
+	select {
+	case n.queue <- notification:
+	default:
+		log.L(ctx).Warnf("Notification queue full - dropped %s notification: %s", notificationType, notification.Message)
+	}
+}
+
+func (n *notifier) webhookLoop(ctx context.Context) {
+	defer close(n.done)
+	for {
+		select {
+		case notification := <-n.queue:
+			res, err := n.webhook.R().SetContext(ctx).SetBody(notification).Post("")
+			if err == nil && res.IsError() {
+				err = i18n.NewError(ctx, msgs.MsgNotificationWebhookFailed, res.Status())
+			}
+			if err != nil {
+				log.L(ctx).Errorf("Failed to deliver %s notification to webhook: %s", notification.Type, err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (n *notifier) waitClosed() {
+	if n != nil {
+		<-n.done
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+)
+
+// The formats integer values are rendered in, in decoded events, query outputs and balances. All are JSON strings, as
+// 256-bit values cannot be represented exactly by the JSON numbers of many consumers.
+const (
+	NumberFormatDecimal    = "decimal"    // "1234500"
+	NumberFormatHex        = "hex"        // "0x12d644"
+	NumberFormatScientific = "scientific" // "1.2345e+6"
+)
+
+var numberFormats = []string{NumberFormatDecimal, NumberFormatHex, NumberFormatScientific}
+
+// intSerializer returns the serializer for integer values in a number format
+func intSerializer(ctx context.Context, numberFormat string) (abi.IntSerializer, error) {
+	switch numberFormat {
+	case NumberFormatDecimal:
+		return abi.Base10StringIntSerializer, nil
+	case NumberFormatHex:
+		return abi.HexIntSerializer0xPrefix, nil
+	case NumberFormatScientific:
+		return scientificIntSerializer, nil
+	default:
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidNumberFormat, numberFormat, numberFormats)
+	}
+}
+
+// scientificIntSerializer renders an integer exactly in scientific notation, with the trailing zeros of the
+// significand removed
+func scientificIntSerializer(i *big.Int) interface{} {
+	digits := new(big.Int).Abs(i).String()
+	sign := ""
+	if i.Sign() < 0 {
+		sign = "-"
+	}
+	fraction := strings.TrimRight(digits[1:], "0")
+	if fraction != "" {
+		fraction = "." + fraction
+	}
+	return sign + digits[0:1] + fraction + "e+" + strconv.Itoa(len(digits)-1)
+}
+
+// serializerFor returns the serializer of outputs with integers in a number format, or with the configured format if
+// none is requested
+func (c *ethConnector) serializerFor(ctx context.Context, numberFormat string) (*abi.Serializer, error) {
+	if numberFormat == "" {
+		return c.serializer, nil
+	}
+	is, err := intSerializer(ctx, numberFormat)
+	if err != nil {
+		return nil, err
+	}
+	s := *c.serializer
+	return s.SetIntSerializer(is), nil
+}
+
+// strictNumbersForStream returns whether the events of a stream have every JSON number as a string
+func (c *ethConnector) strictNumbersForStream(streamID *fftypes.UUID) bool {
+	return c.strictNumbers && (len(c.strictNumberStreams) == 0 || c.strictNumberStreams[streamID.String()])
+}
+
+// strictJSONNumbers rewrites every number in valid JSON as a string of its exact text, preserving the order of the
+// fields. Integers of 2^53 and above lose precision in consumers that parse JSON numbers as floating point.
+func strictJSONNumbers(b []byte) []byte {
+	out := make([]byte, 0, len(b))
+	inString, escaped := false, false
+	for i := 0; i < len(b); i++ {
+		ch := b[i]
+		switch {
+		case inString:
+			switch {
+			case escaped:
+				escaped = false
+			case ch == '\\':
+				escaped = true
+			case ch == '"':
+				inString = false
+			}
+		case ch == '"':
+			inString = true
+		case ch == '-' || (ch >= '0' && ch <= '9'):
+			end := i + 1
+			for end < len(b) && strings.IndexByte("+-.eE0123456789", b[end]) >= 0 {
+				end++
+			}
+			out = append(out, '"')
+			out = append(out, b[i:end]...)
+			ch = '"'
+			i = end - 1
+		}
+		out = append(out, ch)
+	}
+	return out
+}
+
+// strictJSONAny rewrites the numbers of a JSON value as strings, when strict
+func strictJSONAny(strict bool, v *fftypes.JSONAny) *fftypes.JSONAny {
+	if !strict || v == nil {
+		return v
+	}
+	return fftypes.JSONAnyPtrBytes(strictJSONNumbers(v.Bytes()))
+}
@@ -0,0 +1,295 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestScientificIntSerializer(t *testing.T) {
+	large, _ := new(big.Int).SetString("115792089237316195423570985008687907853269984665640564039457584007913129639935", 10)
+	for _, tc := range []struct {
+		value    *big.Int
+		expected string
+	}{
+		{big.NewInt(0), "0e+0"},
+		{big.NewInt(7), "7e+0"},
+		{big.NewInt(1000), "1e+3"},
+		{big.NewInt(1234500), "1.2345e+6"},
+		{big.NewInt(-1234500), "-1.2345e+6"},
+		{large, "1.15792089237316195423570985008687907853269984665640564039457584007913129639935e+77"},
+	} {
+		assert.Equal(t, tc.expected, scientificIntSerializer(tc.value))
+	}
+}
+
+func TestIntSerializerInvalid(t *testing.T) {
+	_, err := intSerializer(context.Background(), "octal")
+	assert.Regexp(t, "FF23165.*octal", err)
+}
+
+func TestNumberFormatConfig(t *testing.T) {
+	_, c, _, done := newTestConnector(t, func(conf config.Section) {
+		conf.Set(ConfigNumberFormat, NumberFormatHex)
+	})
+	defer done()
+
+	params := &abi.ParameterArray{{Name: "x", Type: "uint256"}}
+	cv, err := params.ParseJSON([]byte(`{"x":1000}`))
+	assert.NoError(t, err)
+	jv, err := c.serializer.SerializeJSON(cv)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"x":"0x3e8"}`, string(jv))
+
+	// A requested format overrides the configured one, without changing it
+	s, err := c.serializerFor(context.Background(), NumberFormatScientific)
+	assert.NoError(t, err)
+	jv, err = s.SerializeJSON(cv)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"x":"1e+3"}`, string(jv))
+	jv, err = c.serializer.SerializeJSON(cv)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"x":"0x3e8"}`, string(jv))
+
+	config.RootConfigReset()
+	conf := config.RootSection("unittest")
+	InitConfig(conf)
+	conf.Set(ffresty.HTTPConfigURL, "http://localhost:8545")
+	conf.Set(ConfigNumberFormat, "wrong")
+	_, err = NewEthereumConnector(context.Background(), conf)
+	assert.Regexp(t, "FF23165.*wrong", err)
+}
+
+func TestNumberFormatListener(t *testing.T) {
+	lID := fftypes.NewUUID()
+	es, _, _, done := testEventStream(t, &ffcapi.EventListenerAddRequest{
+		ListenerID: lID,
+		EventListenerOptions: ffcapi.EventListenerOptions{
+			Filters: []fftypes.JSONAny{
+				*fftypes.JSONAnyPtr(`{"address":"0x20355f3E852D4b6a9944AdA8d5399dDD3409A431","event":` + abiTransferEvent + `}`),
+			},
+			Options:   fftypes.JSONAnyPtr(`{"numberFormat":"hex"}`),
+			FromBlock: strconv.Itoa(testHighBlock),
+		},
+	})
+	done()
+
+	var abiEvent *abi.Entry
+	err := json.Unmarshal([]byte(abiTransferEvent), &abiEvent)
+	assert.NoError(t, err)
+	ethLog := sampleTransferLog()
+	data, decoded := es.listeners[*lID].ee.decodeLogData(context.Background(), abiEvent, ethLog.Topics, ethLog.Data)
+	assert.True(t, decoded)
+	assert.Equal(t, "0x3e8", data.JSONObject().GetString("value"))
+
+	_, err = parseListenerOptions(context.Background(), fftypes.JSONAnyPtr(`{"numberFormat":"wrong"}`))
+	assert.Regexp(t, "FF23165", err)
+}
+
+func TestNumberFormatQueryRoute(t *testing.T) {
+	_, c, mRPC, done := newTestConnector(t)
+	defer done()
+	url, close := newTestRouteServer(t, c)
+	defer close()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_call", mock.Anything, "latest").
+		Run(func(args mock.Arguments) {
+			*(args[1].(*ethtypes.HexBytes0xPrefix)) = ethtypes.MustNewHexBytes0xPrefix("0x00000000000000000000000000000000000000000000000000000000baadf00d0000000000000000000000000000000000000000000000000000000000000040000000000000000000000000000000000000000000000000000000000000000b68656c6c6f20776f726c64000000000000000000000000000000000000000000")
+		}).
+		Return(nil)
+
+	var req QueryRequest
+	err := json.Unmarshal([]byte(sampleExecQuery), &req)
+	assert.NoError(t, err)
+	req.NumberFormat = NumberFormatHex
+	body, _ := json.Marshal(&req)
+
+	res, err := http.Post(url+"/query", "application/json", strings.NewReader(string(body)))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var queryRes ffcapi.QueryInvokeResponse
+	err = json.NewDecoder(res.Body).Decode(&queryRes)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"output": "0xbaadf00d", "output1":"hello world"}`, queryRes.Outputs.String())
+
+	req.NumberFormat = "wrong"
+	_, reason, err := c.FormattedQueryInvoke(context.Background(), &req)
+	assert.Regexp(t, "FF23165", err)
+	assert.Equal(t, ffcapi.ErrorReasonInvalidInputs, reason)
+
+	_, _, err = c.PrivateQueryInvoke(context.Background(), testPrivacyGroupID, &req.QueryInvokeRequest, "wrong")
+	assert.Regexp(t, "FF23165", err)
+
+	var prepareReq PrepareTransactionRequest
+	err = json.Unmarshal([]byte(sampleExecQuery), &prepareReq)
+	assert.NoError(t, err)
+	prepareReq.PreflightCall = true
+	prepareReq.NumberFormat = "wrong"
+	_, _, err = c.PrepareTransactionPreflight(context.Background(), &prepareReq)
+	assert.Regexp(t, "FF23165", err)
+}
+
+func TestNumberFormatBalanceRoute(t *testing.T) {
+	_, c, mRPC, done := newTestConnector(t)
+	defer done()
+	url, close := newTestRouteServer(t, c)
+	defer close()
+
+	address := "0x4a8c8f1717570f9774652075e249ded38124d708"
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBalance", address, "latest").
+		Run(func(args mock.Arguments) {
+			args[1].(*ethtypes.HexInteger).BigInt().SetString("2500000000000000000", 10)
+		}).
+		Return(nil).Once()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBalance", address, "0x10").
+		Run(func(args mock.Arguments) {
+			args[1].(*ethtypes.HexInteger).BigInt().SetString("999", 10)
+		}).
+		Return(nil).Once()
+
+	res, err := http.Get(url + "/accounts/" + address + "/balance?numberFormat=scientific")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var balance AccountBalance
+	err = json.NewDecoder(res.Body).Decode(&balance)
+	assert.NoError(t, err)
+	assert.Equal(t, address, balance.Address)
+	assert.Equal(t, "2.5e+18", balance.Balance)
+
+	// The configured format is the default
+	res, err = http.Get(url + "/accounts/" + address + "/balance?blockTag=0x10")
+	assert.NoError(t, err)
+	err = json.NewDecoder(res.Body).Decode(&balance)
+	assert.NoError(t, err)
+	assert.Equal(t, "999", balance.Balance)
+
+	res, err = http.Get(url + "/accounts/" + address + "/balance?numberFormat=wrong")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBalance", address, "latest").
+		Return(&rpcbackend.RPCError{Message: "pop"}).Once()
+	_, _, err = c.FormattedAddressBalance(context.Background(), address, "", "")
+	assert.Regexp(t, "pop", err)
+	mRPC.AssertExpectations(t)
+}
+
+func TestStrictJSONNumbers(t *testing.T) {
+	assert.Equal(t,
+		`{"a":"1","b":["-2.5e+3",true,null,"12345678901234567890"],"c":"x\"1\\","d":{"e":"0"}}`,
+		string(strictJSONNumbers([]byte(`{"a":1,"b":[-2.5e+3,true,null,12345678901234567890],"c":"x\"1\\","d":{"e":0}}`))))
+
+	v := fftypes.JSONAnyPtr(`{"a":1}`)
+	assert.Equal(t, v, strictJSONAny(false, v))
+	assert.Nil(t, strictJSONAny(true, nil))
+	assert.Equal(t, `{"a":"1"}`, strictJSONAny(true, v).String())
+}
+
+func TestStrictNumbersEventInfo(t *testing.T) {
+	info := &eventInfo{
+		logJSONRPC: *sampleTransferLog(),
+		InputArgs:  fftypes.JSONAnyPtr(`{"amount":12345678901234567890}`),
+		Sequence:   eventSequence(1024, 64, 2),
+	}
+	b, err := json.Marshal(info)
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), `"inputArgs":{"amount":12345678901234567890}`)
+
+	info.strictNumbers = true
+	b, err = json.Marshal(info)
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), `"inputArgs":{"amount":"12345678901234567890"}`)
+	assert.Contains(t, string(b), `"logIndex":"0x2"`)
+}
+
+func TestStrictNumbersStreams(t *testing.T) {
+	streamID := fftypes.NewUUID()
+	_, c, _, done := newTestConnector(t, func(conf config.Section) {
+		conf.Set(JSONNumbersStrict, true)
+	})
+	defer done()
+	assert.True(t, c.strictNumbersForStream(streamID))
+
+	_, c, mRPC, done := newTestConnector(t, func(conf config.Section) {
+		conf.Set(JSONNumbersStrict, true)
+		conf.Set(JSONNumbersEventStreams, []string{streamID.String()})
+	})
+	defer done()
+	mockBlockNumber(mRPC, testHighBlock).Maybe()
+	assert.True(t, c.strictNumbersForStream(streamID))
+	assert.False(t, c.strictNumbersForStream(fftypes.NewUUID()))
+
+	l, err := (&eventStream{id: streamID, c: c, ctx: context.Background(), listeners: map[fftypes.UUID]*listener{}}).addEventListener(context.Background(), &ffcapi.EventListenerAddRequest{
+		ListenerID: fftypes.NewUUID(),
+		EventListenerOptions: ffcapi.EventListenerOptions{
+			Filters: []fftypes.JSONAny{*fftypes.JSONAnyPtr(`{"event":` + abiTransferEvent + `}`)},
+			Options: fftypes.JSONAnyPtr(`{}`),
+		},
+	})
+	assert.NoError(t, err)
+	assert.True(t, l.ee.strictNumbers)
+
+	c.chainID = "1"
+	c.eventBlockTimestamps = false
+	ethLog := sampleTransferLog()
+	ev, _, _, err := l.ee.filterEnrichEthLog(context.Background(), l.config.filters[0], nil, ethLog)
+	assert.NoError(t, err)
+	assert.True(t, ev.Info.(*eventInfo).strictNumbers)
+	assert.Equal(t, `{"from":"0x3968ef051b422d3d1cdc182a88bba8dd922e6fa4","to":"0xd0f2f5103fd050739a9fb567251bc460cc24d091","value":"1000"}`, ev.Data.String())
+}
+
+func TestStrictNumbersReceipt(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t, func(conf config.Section) {
+		conf.Set(JSONNumbersStrict, true)
+	})
+	defer done()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionReceipt", mock.Anything).
+		Run(func(args mock.Arguments) {
+			err := json.Unmarshal([]byte(sampleJSONRPCReceipt), args[1])
+			assert.NoError(t, err)
+		}).
+		Return(nil)
+	mockBlockNumber(mRPC, sampleReceiptBlock+1).Maybe()
+
+	var req ffcapi.TransactionReceiptRequest
+	err := json.Unmarshal([]byte(sampleGetReceipt), &req)
+	assert.NoError(t, err)
+	c.recordTxConfirmations(req.TransactionHash, 1)
+	c.blockListener.mux.Lock()
+	c.blockListener.highestBlock = sampleReceiptBlock + 1
+	c.blockListener.mux.Unlock()
+
+	res, _, err := c.TransactionReceipt(ctx, &req)
+	assert.NoError(t, err)
+	assert.Contains(t, res.ExtraInfo.String(), `"confirmations":{"required":"1","confirmations":"1"}`)
+}
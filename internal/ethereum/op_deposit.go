@@ -0,0 +1,73 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rlp"
+)
+
+// depositTxEncoding is the layout of the deposit transactions of OP-stack chains, such as Optimism and Base. Deposits
+// are derived from L1 by the rollup node, rather than signed and submitted, so they have the address they are from,
+// and the ETH minted on L2, in place of a signature.
+var depositTxEncoding = &signedTxEncoding{
+	txType: opDepositTxType,
+	name:   "OP deposit",
+	fields: []string{"sourceHash", "from", "to", "mint", "value", "gas", "isSystemTx", "data"},
+}
+
+// decodeDepositTx builds the decoded form of a deposit transaction from its named fields, with the hash of the
+// whole encoding as there is no signature to exclude
+func decodeDepositTx(ctx context.Context, named map[string]rlp.Data, hashed []byte) (*DecodedTransaction, error) {
+	hash := ethtypes.HexBytes0xPrefix(keccak256(hashed))
+	from := named["from"].Address()
+	if from == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgDepositTXMissingFrom, hash)
+	}
+	isSystemTx := named["isSystemTx"].IntOrZero().Sign() != 0
+	return &DecodedTransaction{
+		Type:       ethtypes.HexUint64(opDepositTxType),
+		Encoding:   depositTxEncoding.name,
+		Hash:       hash,
+		SourceHash: ethtypes.HexBytes0xPrefix(named["sourceHash"].BytesNotNil()),
+		From:       from,
+		To:         named["to"].Address(),
+		Mint:       (*ethtypes.HexInteger)(named["mint"].IntOrZero()),
+		Value:      (*ethtypes.HexInteger)(named["value"].IntOrZero()),
+		Gas:        (*ethtypes.HexInteger)(named["gas"].IntOrZero()),
+		IsSystemTx: &isSystemTx,
+		Input:      ethtypes.HexBytes0xPrefix(named["data"].BytesNotNil()),
+	}, nil
+}
+
+// appendDepositReceiptFields adds the nonce of the sender, and the version of the receipt, to the consensus encoding
+// of the receipt of a deposit transaction. These were added by the Regolith and Canyon upgrades, so are only encoded
+// when the node returns them.
+func appendDepositReceiptFields(fields rlp.List, r *txReceiptJSONRPC) rlp.List {
+	if r.Type == nil || r.Type.BigInt().Int64() != int64(opDepositTxType) || r.DepositNonce == nil {
+		return fields
+	}
+	fields = append(fields, rlp.WrapInt(r.DepositNonce.BigInt()))
+	if r.DepositReceiptVersion != nil {
+		fields = append(fields, rlp.WrapInt(r.DepositReceiptVersion.BigInt()))
+	}
+	return fields
+}
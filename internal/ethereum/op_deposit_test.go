@@ -0,0 +1,126 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rlp"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testDepositSourceHash = "0x5d3f4f2f8ce7b8d7ad4fb7c0b4c4e2a1a1f6f7d3e5c4b2a19181716151413121"
+	testDepositor         = "0xdeaddeaddeaddeaddeaddeaddeaddeaddead0001"
+)
+
+// testDepositTx builds an L1 attributes deposit, from the depositor account to the L1 block contract
+func testDepositTx(from rlp.Data, mint int64) []byte {
+	return testTypedTx(opDepositTxType, rlp.List{
+		rlp.MustWrapHex(testDepositSourceHash),
+		from,
+		rlp.MustWrapHex("0x4200000000000000000000000000000000000015"),
+		rlp.WrapInt(big.NewInt(mint)),
+		rlp.WrapInt(big.NewInt(0)),
+		rlp.WrapInt(big.NewInt(1000000)),
+		rlp.WrapInt(big.NewInt(0)),
+		rlp.MustWrapHex("0x440a5e20"),
+	})
+}
+
+func TestDecodeTransactionDeposit(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t)
+	defer done()
+
+	rawTx := testDepositTx(rlp.MustWrapHex(testDepositor), 1000)
+	tx, err := c.DecodeTransaction(ctx, &DecodeTransactionRequest{Transaction: rawTx})
+	assert.NoError(t, err)
+	assert.Equal(t, "OP deposit", tx.Encoding)
+	assert.Equal(t, ethtypes.HexUint64(0x7e), tx.Type)
+	assert.Equal(t, ethtypes.HexBytes0xPrefix(keccak256(rawTx)), tx.Hash)
+	assert.Equal(t, testDepositSourceHash, tx.SourceHash.String())
+	assert.Equal(t, testDepositor, tx.From.String())
+	assert.Equal(t, "0x4200000000000000000000000000000000000015", tx.To.String())
+	assert.Equal(t, int64(1000), tx.Mint.Int64())
+	assert.Equal(t, int64(1000000), tx.Gas.Int64())
+	assert.False(t, *tx.IsSystemTx)
+	assert.Equal(t, "0x440a5e20", tx.Input.String())
+	assert.Nil(t, tx.Nonce)
+	assert.Nil(t, tx.ChainID)
+
+	b, err := json.Marshal(tx)
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), `"isSystemTx":false`)
+	assert.NotContains(t, string(b), "gasPrice")
+
+	// A deposit must have the address it is from, as there is no signature to recover it from
+	_, err = c.DecodeTransaction(ctx, &DecodeTransactionRequest{Transaction: testDepositTx(rlp.Data{}, 0)})
+	assert.Regexp(t, "FF23192", err)
+
+	_, err = c.DecodeTransaction(ctx, &DecodeTransactionRequest{Transaction: testTypedTx(opDepositTxType, rlp.List{rlp.Data{}})})
+	assert.Regexp(t, "FF23105.*OP deposit", err)
+}
+
+func TestSendPreSignedTransactionDeposit(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t)
+	defer done()
+
+	rawTx := testDepositTx(rlp.MustWrapHex(testDepositor), 0)
+	_, reason, err := c.TransactionSend(ctx, &ffcapi.TransactionSendRequest{
+		PreSigned:       true,
+		TransactionData: ethtypes.HexBytes0xPrefix(rawTx).String(),
+	})
+	assert.Regexp(t, "FF23193.*"+ethtypes.HexBytes0xPrefix(keccak256(rawTx)).String(), err)
+	assert.Equal(t, ffcapi.ErrorReasonInvalidInputs, reason)
+}
+
+func TestEncodeReceiptDeposit(t *testing.T) {
+	receipt := testBlockReceipts(1)[0]
+	receipt.Type = ethtypes.NewHexInteger64(int64(opDepositTxType))
+
+	// Receipts before Regolith have no deposit fields
+	encoded := encodeReceipt(receipt)
+	assert.Equal(t, opDepositTxType, encoded[0])
+	decoded, _, err := rlp.Decode(encoded[1:])
+	assert.NoError(t, err)
+	assert.Len(t, decoded.(rlp.List), 4)
+
+	receipt.DepositNonce = ethtypes.NewHexInteger64(0)
+	decoded, _, err = rlp.Decode(encodeReceipt(receipt)[1:])
+	assert.NoError(t, err)
+	assert.Len(t, decoded.(rlp.List), 5)
+	assert.Empty(t, decoded.(rlp.List)[4].ToData())
+
+	receipt.DepositNonce = ethtypes.NewHexInteger64(42)
+	receipt.DepositReceiptVersion = ethtypes.NewHexInteger64(1)
+	decoded, _, err = rlp.Decode(encodeReceipt(receipt)[1:])
+	assert.NoError(t, err)
+	l := decoded.(rlp.List)
+	assert.Len(t, l, 6)
+	assert.Equal(t, int64(42), l[4].ToData().Int().Int64())
+	assert.Equal(t, int64(1), l[5].ToData().Int().Int64())
+
+	// The fields are only encoded for deposits
+	receipt.Type = ethtypes.NewHexInteger64(2)
+	decoded, _, err = rlp.Decode(encodeReceipt(receipt)[1:])
+	assert.NoError(t, err)
+	assert.Len(t, decoded.(rlp.List), 4)
+}
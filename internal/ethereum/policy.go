@@ -0,0 +1,273 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+)
+
+// txPolicy is the set of checks applied to transactions before submission, which is replaced as a whole on reload
+type txPolicy struct {
+	verifyChainID       bool
+	allowUnprotected    bool
+	allowedSigners      map[string]bool
+	allowedDestinations []string
+	deniedDestinations  []string
+	allowedMethods      map[string]map[string]bool // selectors by contract address
+	maxValuePerTx       *big.Int
+	maxValuePerWindow   *big.Int
+	valueWindow         time.Duration
+	maxTxSize           int64
+	checkBlockGasLimit  bool
+}
+
+// newTxPolicy validates and builds the transaction policy from config
+func newTxPolicy(ctx context.Context, conf config.Section) (*txPolicy, error) {
+	tp := &txPolicy{
+		verifyChainID:      conf.GetBool(PolicyPreSignedVerifyChainID),
+		allowUnprotected:   conf.GetBool(PolicyPreSignedAllowUnprotected),
+		valueWindow:        conf.GetDuration(PolicyValueWindow),
+		maxTxSize:          conf.GetInt64(TxLimitsMaxSize),
+		checkBlockGasLimit: conf.GetBool(TxLimitsCheckBlockGasLimit),
+	}
+	if tp.maxTxSize < 0 {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidTxLimits, tp.maxTxSize)
+	}
+	for _, s := range conf.GetStringSlice(PolicyPreSignedAllowedSigners) {
+		addr, err := ethtypes.NewAddress(s)
+		if err != nil {
+			return nil, i18n.NewError(ctx, msgs.MsgInvalidPolicyAddress, s, PolicyPreSignedAllowedSigners, err)
+		}
+		if tp.allowedSigners == nil {
+			tp.allowedSigners = make(map[string]bool)
+		}
+		tp.allowedSigners[addr.String()] = true
+	}
+	var err error
+	if tp.allowedDestinations, err = addressPrefixes(ctx, conf, PolicyDestinationsAllowed); err != nil {
+		return nil, err
+	}
+	if tp.deniedDestinations, err = addressPrefixes(ctx, conf, PolicyDestinationsDenied); err != nil {
+		return nil, err
+	}
+	if tp.allowedMethods, err = methodSelectors(ctx, policyMethodsConfig(conf)); err != nil {
+		return nil, err
+	}
+	if tp.maxValuePerTx, err = policyValue(ctx, conf, PolicyValueMaxPerTransaction); err != nil {
+		return nil, err
+	}
+	if tp.maxValuePerWindow, err = policyValue(ctx, conf, PolicyValueMaxPerWindow); err != nil {
+		return nil, err
+	}
+	if tp.maxValuePerWindow != nil && tp.valueWindow <= 0 {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidPolicyValue, conf.GetString(PolicyValueWindow), PolicyValueWindow)
+	}
+	return tp, nil
+}
+
+// policyValue parses an optional amount of the native token in wei, as a decimal or 0x prefixed hex integer
+func policyValue(ctx context.Context, conf config.Section, key string) (*big.Int, error) {
+	s := conf.GetString(key)
+	if s == "" {
+		return nil, nil
+	}
+	value, ok := new(big.Int).SetString(s, 0)
+	if !ok || value.Sign() < 0 {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidPolicyValue, s, key)
+	}
+	return value, nil
+}
+
+// methodSelectors parses the allowed methods of each contract into hex function selectors. Entries for the
+// same contract are combined.
+func methodSelectors(ctx context.Context, methodsConf config.ArraySection) (map[string]map[string]bool, error) {
+	var allowed map[string]map[string]bool
+	for i := 0; i < methodsConf.ArraySize(); i++ {
+		entry := methodsConf.ArrayEntry(i)
+		contract, err := ethtypes.NewAddress(entry.GetString(PolicyMethodsContract))
+		if err != nil {
+			return nil, i18n.NewError(ctx, msgs.MsgInvalidPolicyAddress, entry.GetString(PolicyMethodsContract), "policy.methods[].contract", err)
+		}
+		if allowed == nil {
+			allowed = make(map[string]map[string]bool)
+		}
+		selectors := allowed[contract.String()]
+		if selectors == nil {
+			selectors = make(map[string]bool)
+			allowed[contract.String()] = selectors
+		}
+		for _, method := range entry.GetStringSlice(PolicyMethodsSelectors) {
+			selector, err := ethtypes.NewHexBytes0xPrefix(method)
+			switch {
+			case err == nil && len(selector) == 4:
+			case err != nil && strings.Contains(method, "(") && strings.HasSuffix(method, ")"):
+				selector = keccak256([]byte(strings.ReplaceAll(method, " ", "")))[0:4]
+			default:
+				return nil, i18n.NewError(ctx, msgs.MsgInvalidPolicySelector, method, contract)
+			}
+			selectors[selector.String()] = true
+		}
+	}
+	return allowed, nil
+}
+
+// addressPrefixes parses a list of addresses, or hex prefixes of addresses, into lower case hex without the 0x
+func addressPrefixes(ctx context.Context, conf config.Section, key string) ([]string, error) {
+	var prefixes []string
+	for _, s := range conf.GetStringSlice(key) {
+		prefix := strings.TrimPrefix(strings.ToLower(s), "0x")
+		if len(prefix) == 0 || len(prefix) > 40 || strings.Trim(prefix, "0123456789abcdef") != "" {
+			return nil, i18n.NewError(ctx, msgs.MsgInvalidPolicyAddress, s, key, "must be an address, or the hex prefix of an address")
+		}
+		prefixes = append(prefixes, prefix)
+	}
+	return prefixes, nil
+}
+
+func matchesAddressPrefix(addr *ethtypes.Address0xHex, prefixes []string) bool {
+	hexAddr := strings.TrimPrefix(addr.String(), "0x")
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(hexAddr, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// decodePreSigned is true if a check needs the fields of pre-signed transactions
+func (tp *txPolicy) decodePreSigned() bool {
+	return tp.verifyChainID || tp.allowedSigners != nil || tp.allowedDestinations != nil || tp.deniedDestinations != nil || tp.allowedMethods != nil ||
+		tp.maxValuePerTx != nil || tp.maxValuePerWindow != nil || tp.checkBlockGasLimit
+}
+
+func (c *ethConnector) policy() *txPolicy {
+	return c.txPolicy.Load()
+}
+
+// connectedChainID returns the chain ID of the node, which is queried once and cached until the
+// endpoints are reloaded
+func (c *ethConnector) connectedChainID(ctx context.Context) (*big.Int, error) {
+	if chainID := c.ethChainID.Load(); chainID != nil {
+		return chainID, nil
+	}
+	if c.legacyChain {
+		chainID, err := c.legacyChainID(ctx)
+		if err == nil {
+			c.ethChainID.Store(chainID)
+		}
+		return chainID, err
+	}
+	var chainID ethtypes.HexInteger
+	if rpcErr := c.backend.CallRPC(ctx, &chainID, "eth_chainId"); rpcErr != nil {
+		return nil, rpcErr.Error()
+	}
+	c.ethChainID.Store(chainID.BigInt())
+	return chainID.BigInt(), nil
+}
+
+// checkTx applies the checks that are common to transactions built by the connector and pre-signed
+// transactions. A nil destination is a contract deployment.
+func (tp *txPolicy) checkTx(ctx context.Context, to *ethtypes.Address0xHex, value *ethtypes.HexInteger, data []byte) (ffcapi.ErrorReason, error) {
+	if tp.maxValuePerTx != nil && value.BigInt().Cmp(tp.maxValuePerTx) > 0 {
+		return ErrorReasonPolicyViolation, i18n.NewError(ctx, msgs.MsgValueExceedsTransactionLimit, value.BigInt(), tp.maxValuePerTx)
+	}
+	if to == nil {
+		return "", nil
+	}
+	if matchesAddressPrefix(to, tp.deniedDestinations) || (tp.allowedDestinations != nil && !matchesAddressPrefix(to, tp.allowedDestinations)) {
+		return ErrorReasonPolicyViolation, i18n.NewError(ctx, msgs.MsgDestinationNotAllowed, to)
+	}
+	if selectors, restricted := tp.allowedMethods[to.String()]; restricted {
+		selector := "none"
+		if len(data) >= 4 {
+			selector = ethtypes.HexBytes0xPrefix(data[0:4]).String()
+		}
+		if !selectors[selector] {
+			allowed := make([]string, 0, len(selectors))
+			for s := range selectors {
+				allowed = append(allowed, s)
+			}
+			sort.Strings(allowed)
+			return ErrorReasonPolicyViolation, i18n.NewError(ctx, msgs.MsgMethodNotAllowed, selector, to, strings.Join(allowed, ","))
+		}
+	}
+	return "", nil
+}
+
+// checkPreSignedPolicy decodes a pre-signed transaction to check it was signed for the connected chain,
+// by an allowed signer, so a transaction signed for another network is not replayed on this one, then
+// applies the common checks to the decoded fields. The decoded transaction is returned, unless no
+// policy needs it.
+func (c *ethConnector) checkPreSignedPolicy(ctx context.Context, rawTx ethtypes.HexBytes0xPrefix) (*DecodedTransaction, ffcapi.ErrorReason, error) {
+	tp := c.policy()
+	if !tp.decodePreSigned() && !c.legacyChain {
+		return nil, "", nil
+	}
+	tx, err := c.DecodeTransaction(ctx, &DecodeTransactionRequest{Transaction: rawTx})
+	if err != nil {
+		return nil, ffcapi.ErrorReasonInvalidInputs, err
+	}
+	if c.legacyChain {
+		if err := checkLegacyChainTx(ctx, tx); err != nil {
+			return nil, ffcapi.ErrorReasonInvalidInputs, err
+		}
+	}
+	if reason, err := tp.checkTx(ctx, tx.To, tx.Value, tx.Input); err != nil {
+		return nil, reason, err
+	}
+
+	if tp.allowedSigners != nil && !tp.allowedSigners[tx.From.String()] {
+		return nil, ffcapi.ErrorReasonInvalidInputs, i18n.NewError(ctx, msgs.MsgSignerNotAllowed, tx.From, tx.Hash)
+	}
+
+	if tp.verifyChainID {
+		if tx.ChainID == nil {
+			// All transactions are unprotected on a legacy chain
+			if !tp.allowUnprotected && !c.legacyChain {
+				return nil, ffcapi.ErrorReasonInvalidInputs, i18n.NewError(ctx, msgs.MsgSignedTXUnprotected, tx.Hash)
+			}
+			return tx, "", nil
+		}
+		chainID, err := c.connectedChainID(ctx)
+		if err != nil {
+			log.L(ctx).Errorf("Failed to query the chain ID to verify signed transaction %s: %s", tx.Hash, err)
+			return nil, "", err
+		}
+		if tx.ChainID.BigInt().Cmp(chainID) != 0 {
+			c.notifier.notify(ctx, NotificationChainIDMismatch, fftypes.JSONObject{
+				"transactionHash": tx.Hash,
+				"from":            tx.From,
+				"chainId":         tx.ChainID.BigInt().String(),
+				"nodeChainId":     chainID.String(),
+			}, "Rejected signed transaction for chain ID %s, as the node is on chain ID %s", tx.ChainID.BigInt(), chainID)
+			return nil, ffcapi.ErrorReasonInvalidInputs, i18n.NewError(ctx, msgs.MsgSignedTXChainIDMismatch, tx.Hash, tx.ChainID.BigInt(), chainID)
+		}
+	}
+	return tx, "", nil
+}
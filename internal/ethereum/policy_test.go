@@ -0,0 +1,426 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rlp"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/hyperledger/firefly-signer/pkg/secp256k1"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNewTxPolicyInvalidSigner(t *testing.T) {
+	conf := config.RootSection("policy_test")
+	InitConfig(conf)
+	conf.Set(PolicyPreSignedAllowedSigners, []string{"0x12345"})
+	_, err := newTxPolicy(context.Background(), conf)
+	assert.Regexp(t, "FF23110.*0x12345.*policy.preSigned.allowedSigners", err)
+}
+
+func TestConnectedChainID(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+	c.ethChainID.Store(nil)
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_chainId").
+		Return(&rpcbackend.RPCError{Message: "pop"}).Once()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_chainId").
+		Run(func(args mock.Arguments) {
+			*(args[1].(*ethtypes.HexInteger)) = *ethtypes.NewHexInteger64(1337)
+		}).
+		Return(nil).Once()
+
+	_, err := c.connectedChainID(ctx)
+	assert.Regexp(t, "pop", err)
+
+	// The chain ID is queried once, then cached
+	for i := 0; i < 2; i++ {
+		chainID, err := c.connectedChainID(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1337), chainID.Int64())
+	}
+}
+
+func TestPreSignedPolicyChainIDMismatch(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t)
+	defer done()
+	c.ethChainID.Store(big.NewInt(1))
+
+	_, reason, err := c.TransactionSend(ctx, &ffcapi.TransactionSendRequest{
+		PreSigned:       true,
+		TransactionData: sampleSigned1559TX,
+	})
+	assert.Regexp(t, "FF23113.*"+sampleSigned1559Hash+".*chain ID 1337.*chain ID 1", err)
+	assert.Equal(t, ffcapi.ErrorReasonInvalidInputs, reason)
+}
+
+func TestPreSignedPolicyChainIDQueryFailed(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+	c.ethChainID.Store(nil)
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_chainId").
+		Return(&rpcbackend.RPCError{Message: "pop"})
+
+	_, reason, err := c.TransactionSend(ctx, &ffcapi.TransactionSendRequest{
+		PreSigned:       true,
+		TransactionData: sampleSigned1559TX,
+	})
+	assert.Regexp(t, "pop", err)
+	assert.Empty(t, reason)
+}
+
+func TestPreSignedPolicyUnprotected(t *testing.T) {
+	kp, err := secp256k1.GenerateSecp256k1KeyPair()
+	assert.NoError(t, err)
+	rawTx := ethtypes.HexBytes0xPrefix(testHomesteadTx(t, kp))
+
+	ctx, c, mRPC, done := newTestConnector(t)
+	_, reason, err := c.TransactionSend(ctx, &ffcapi.TransactionSendRequest{
+		PreSigned:       true,
+		TransactionData: rawTx.String(),
+	})
+	assert.Regexp(t, "FF23112", err)
+	assert.Equal(t, ffcapi.ErrorReasonInvalidInputs, reason)
+	done()
+
+	ctx, c, mRPC, done = newTestConnector(t, func(conf config.Section) {
+		conf.Set(PolicyPreSignedAllowUnprotected, true)
+	})
+	defer done()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_sendRawTransaction", rawTx.String()).
+		Run(func(args mock.Arguments) {
+			*(args[1].(*ethtypes.HexBytes0xPrefix)) = keccak256(rawTx)
+		}).
+		Return(nil)
+	_, _, err = c.TransactionSend(ctx, &ffcapi.TransactionSendRequest{
+		PreSigned:       true,
+		TransactionData: rawTx.String(),
+	})
+	assert.NoError(t, err)
+}
+
+func TestPreSignedPolicyUnknownType(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t)
+	defer done()
+
+	// Transactions that cannot be decoded cannot be verified
+	_, reason, err := c.TransactionSend(ctx, &ffcapi.TransactionSendRequest{
+		PreSigned:       true,
+		TransactionData: ethtypes.HexBytes0xPrefix(testTypedTx(0x04, test2930Fields())).String(),
+	})
+	assert.Regexp(t, "FF23108", err)
+	assert.Equal(t, ffcapi.ErrorReasonInvalidInputs, reason)
+}
+
+func TestPreSignedPolicyAllowedSigners(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t, func(conf config.Section) {
+		conf.Set(PolicyPreSignedVerifyChainID, false)
+		conf.Set(PolicyPreSignedAllowedSigners, []string{"0xDC6FD36D8B979CA40A8BF64E14CE5222E0192265"})
+	})
+	defer done()
+	c.ethChainID.Store(big.NewInt(1))
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_sendRawTransaction", sampleSigned1559TX).
+		Run(func(args mock.Arguments) {
+			*(args[1].(*ethtypes.HexBytes0xPrefix)) = ethtypes.MustNewHexBytes0xPrefix(sampleSigned1559Hash)
+		}).
+		Return(nil)
+	_, _, err := c.TransactionSend(ctx, &ffcapi.TransactionSendRequest{
+		PreSigned:       true,
+		TransactionData: sampleSigned1559TX,
+	})
+	assert.NoError(t, err)
+
+	kp, err := secp256k1.GenerateSecp256k1KeyPair()
+	assert.NoError(t, err)
+	_, reason, err := c.TransactionSend(ctx, &ffcapi.TransactionSendRequest{
+		PreSigned:       true,
+		TransactionData: ethtypes.HexBytes0xPrefix(testSignTypedTx(t, kp, 0x01, test2930Fields())).String(),
+	})
+	assert.Regexp(t, "FF23111.*"+kp.Address.String(), err)
+	assert.Equal(t, ffcapi.ErrorReasonInvalidInputs, reason)
+}
+
+func TestPreSignedPolicyDisabled(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t, func(conf config.Section) {
+		conf.Set(PolicyPreSignedVerifyChainID, false)
+	})
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_sendRawTransaction", "0x1234").
+		Return(&rpcbackend.RPCError{Message: "pop"})
+	_, _, err := c.TransactionSend(ctx, &ffcapi.TransactionSendRequest{
+		PreSigned:       true,
+		TransactionData: "0x1234",
+	})
+	assert.Regexp(t, "pop", err)
+}
+
+func TestNewTxPolicyInvalidDestination(t *testing.T) {
+	conf := config.RootSection("policy_test")
+	InitConfig(conf)
+	for _, s := range []string{"0x", "0xzz", "0x497eedc4299dea2f2a364be10025d0ad0f702de300"} {
+		conf.Set(PolicyDestinationsDenied, []string{s})
+		_, err := newTxPolicy(context.Background(), conf)
+		assert.Regexp(t, "FF23110.*policy.destinations.denied", err)
+	}
+	conf.Set(PolicyDestinationsDenied, []string{})
+	conf.Set(PolicyDestinationsAllowed, []string{"wrong"})
+	_, err := newTxPolicy(context.Background(), conf)
+	assert.Regexp(t, "FF23110.*wrong.*policy.destinations.allowed", err)
+}
+
+func TestDestinationPolicy(t *testing.T) {
+	ctx := context.Background()
+	conf := config.RootSection("policy_test")
+	InitConfig(conf)
+	conf.Set(PolicyDestinationsAllowed, []string{"0xE1A078", "497eedc4299dea2f2a364be10025d0ad0f702de3"})
+	conf.Set(PolicyDestinationsDenied, []string{"0xe1a078b9e2b145d0a7387f09277c6ae1d9470772"})
+	tp, err := newTxPolicy(ctx, conf)
+	assert.NoError(t, err)
+
+	for addr, allowed := range map[string]bool{
+		"0xe1a078b9e2b145d0a7387f09277c6ae1d9470771": true,  // matches the allowed prefix
+		"0x497EEDC4299DEA2F2A364BE10025D0AD0F702DE3": true,  // matches the allowed address
+		"0xe1a078b9e2b145d0a7387f09277c6ae1d9470772": false, // denied, even though it matches the allowed prefix
+		"0x497eedc4299dea2f2a364be10025d0ad0f702de4": false, // not allowed
+	} {
+		reason, err := tp.checkTx(ctx, ethtypes.MustNewAddress(addr), nil, nil)
+		if allowed {
+			assert.NoError(t, err, addr)
+		} else {
+			assert.Regexp(t, "FF23114", err, addr)
+			assert.Equal(t, ErrorReasonPolicyViolation, reason)
+		}
+	}
+
+	// Contract deployments have no destination
+	_, err = tp.checkTx(ctx, nil, nil, nil)
+	assert.NoError(t, err)
+}
+
+func TestDestinationPolicySend(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t, func(conf config.Section) {
+		conf.Set(PolicyDestinationsDenied, []string{"0xe1a078b9e2b145d0a7387f09277c6ae1d9470771", "0x497eedc4"})
+	})
+	defer done()
+
+	var req ffcapi.TransactionSendRequest
+	err := json.Unmarshal([]byte(sampleSendTX), &req)
+	assert.NoError(t, err)
+	_, reason, err := c.TransactionSend(ctx, &req)
+	assert.Regexp(t, "FF23114.*0xe1a078b9e2b145d0a7387f09277c6ae1d9470771", err)
+	assert.Equal(t, ErrorReasonPolicyViolation, reason)
+
+	// The destination of pre-signed transactions is decoded
+	_, reason, err = c.TransactionSend(ctx, &ffcapi.TransactionSendRequest{
+		PreSigned:       true,
+		TransactionData: sampleSigned1559TX,
+	})
+	assert.Regexp(t, "FF23114.*0x497eedc4299dea2f2a364be10025d0ad0f702de3", err)
+	assert.Equal(t, ErrorReasonPolicyViolation, reason)
+
+	var prepareReq ffcapi.TransactionPrepareRequest
+	err = json.Unmarshal([]byte(samplePrepareTXWithGas), &prepareReq)
+	assert.NoError(t, err)
+	_, reason, err = c.TransactionPrepare(ctx, &prepareReq)
+	assert.Regexp(t, "FF23114", err)
+	assert.Equal(t, ErrorReasonPolicyViolation, reason)
+
+	_, reason, err = c.PrivateTransactionSend(ctx, testPrivateSendRequest(t, sampleSendTX))
+	assert.Regexp(t, "FF23114", err)
+	assert.Equal(t, ErrorReasonPolicyViolation, reason)
+
+	privateReq := testPrivateSendRequest(t, sampleSendRawTX)
+	privateReq.TransactionData = testSignPrivateTx(t).String()
+	_, reason, err = c.PrivateTransactionSend(ctx, privateReq)
+	assert.Regexp(t, "FF23114", err)
+	assert.Equal(t, ErrorReasonPolicyViolation, reason)
+}
+
+func setTestMethodPolicy(conf config.Section, i int, contract string, selectors ...string) {
+	entry := policyMethodsConfig(conf).ArrayEntry(i)
+	entry.Set(PolicyMethodsContract, contract)
+	entry.Set(PolicyMethodsSelectors, selectors)
+}
+
+func TestNewTxPolicyInvalidMethods(t *testing.T) {
+	config.RootConfigReset()
+	conf := config.RootSection("policy_test")
+	InitConfig(conf)
+	setTestMethodPolicy(conf, 0, "wrong")
+	_, err := newTxPolicy(context.Background(), conf)
+	assert.Regexp(t, "FF23110.*wrong.*policy.methods", err)
+
+	for _, s := range []string{"0xa9059c", "transfer", "zz"} {
+		setTestMethodPolicy(conf, 0, "0xe1a078b9e2b145d0a7387f09277c6ae1d9470771", s)
+		_, err := newTxPolicy(context.Background(), conf)
+		assert.Regexp(t, "FF23116.*"+s, err)
+	}
+}
+
+func TestMethodPolicy(t *testing.T) {
+	ctx := context.Background()
+	config.RootConfigReset()
+	conf := config.RootSection("policy_test")
+	InitConfig(conf)
+	setTestMethodPolicy(conf, 0, "0xe1a078b9e2b145d0a7387f09277c6ae1d9470771", "transfer(address, uint256)")
+	setTestMethodPolicy(conf, 1, "0xE1A078B9E2B145D0A7387F09277C6AE1D9470771", "0x095EA7B3")
+	tp, err := newTxPolicy(ctx, conf)
+	assert.NoError(t, err)
+	assert.True(t, tp.decodePreSigned())
+
+	restricted := ethtypes.MustNewAddress("0xe1a078b9e2b145d0a7387f09277c6ae1d9470771")
+	_, err = tp.checkTx(ctx, restricted, nil, ethtypes.MustNewHexBytes0xPrefix(sampleTransferCallData))
+	assert.NoError(t, err)
+	_, err = tp.checkTx(ctx, restricted, nil, ethtypes.MustNewHexBytes0xPrefix("0x095ea7b3"))
+	assert.NoError(t, err)
+
+	reason, err := tp.checkTx(ctx, restricted, nil, ethtypes.MustNewHexBytes0xPrefix("0x60fe47b100"))
+	assert.Regexp(t, "FF23115.*0x60fe47b1.*0xe1a078b9e2b145d0a7387f09277c6ae1d9470771.*0x095ea7b3,0xa9059cbb", err)
+	assert.Equal(t, ErrorReasonPolicyViolation, reason)
+
+	// Value transfers and fallback calls do not have a selector
+	_, err = tp.checkTx(ctx, restricted, nil, nil)
+	assert.Regexp(t, "FF23115.*none", err)
+
+	// Other contracts are not restricted
+	_, err = tp.checkTx(ctx, ethtypes.MustNewAddress("0x497eedc4299dea2f2a364be10025d0ad0f702de3"), nil, nil)
+	assert.NoError(t, err)
+}
+
+func TestMethodPolicySend(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t, func(conf config.Section) {
+		setTestMethodPolicy(conf, 0, "0xe1a078b9e2b145d0a7387f09277c6ae1d9470771", "transfer(address,uint256)")
+		setTestMethodPolicy(conf, 1, "0x497eedc4299dea2f2a364be10025d0ad0f702de3", "transfer(address,uint256)")
+	})
+	defer done()
+
+	var req ffcapi.TransactionSendRequest
+	err := json.Unmarshal([]byte(sampleSendTX), &req)
+	assert.NoError(t, err)
+	_, reason, err := c.TransactionSend(ctx, &req)
+	assert.Regexp(t, "FF23115.*0x60fe47b1", err)
+	assert.Equal(t, ErrorReasonPolicyViolation, reason)
+
+	_, _, err = c.TransactionSend(ctx, &ffcapi.TransactionSendRequest{
+		PreSigned:       true,
+		TransactionData: sampleSigned1559TX,
+	})
+	assert.Regexp(t, "FF23115.*0x60fe47b1", err)
+
+	var prepareReq ffcapi.TransactionPrepareRequest
+	err = json.Unmarshal([]byte(samplePrepareTXWithGas), &prepareReq)
+	assert.NoError(t, err)
+	_, _, err = c.TransactionPrepare(ctx, &prepareReq)
+	assert.Regexp(t, "FF23115", err)
+
+	privateReq := testPrivateSendRequest(t, sampleSendRawTX)
+	privateReq.TransactionData = testSignPrivateTx(t).String()
+	_, _, err = c.PrivateTransactionSend(ctx, privateReq)
+	assert.Regexp(t, "FF23115.*0x7d48ae97", err)
+}
+
+func TestNewTxPolicyInvalidValue(t *testing.T) {
+	config.RootConfigReset()
+	conf := config.RootSection("policy_test")
+	InitConfig(conf)
+	for _, v := range []string{"-1", "lots"} {
+		conf.Set(PolicyValueMaxPerTransaction, v)
+		_, err := newTxPolicy(context.Background(), conf)
+		assert.Regexp(t, "FF23117.*"+v+".*policy.value.maxPerTransaction", err)
+	}
+
+	conf.Set(PolicyValueMaxPerTransaction, "")
+	conf.Set(PolicyValueMaxPerWindow, "lots")
+	_, err := newTxPolicy(context.Background(), conf)
+	assert.Regexp(t, "FF23117.*policy.value.maxPerWindow", err)
+
+	conf.Set(PolicyValueMaxPerWindow, "1000")
+	conf.Set(PolicyValueWindow, "0")
+	_, err = newTxPolicy(context.Background(), conf)
+	assert.Regexp(t, "FF23117.*policy.value.window", err)
+}
+
+func TestValuePolicy(t *testing.T) {
+	ctx := context.Background()
+	config.RootConfigReset()
+	conf := config.RootSection("policy_test")
+	InitConfig(conf)
+	conf.Set(PolicyValueMaxPerTransaction, "0x64")
+	tp, err := newTxPolicy(ctx, conf)
+	assert.NoError(t, err)
+	assert.True(t, tp.decodePreSigned())
+
+	to := ethtypes.MustNewAddress("0xe1a078b9e2b145d0a7387f09277c6ae1d9470771")
+	_, err = tp.checkTx(ctx, to, ethtypes.NewHexInteger64(100), nil)
+	assert.NoError(t, err)
+	_, err = tp.checkTx(ctx, to, nil, nil)
+	assert.NoError(t, err)
+
+	reason, err := tp.checkTx(ctx, to, ethtypes.NewHexInteger64(101), nil)
+	assert.Regexp(t, "FF23118.*101.*100", err)
+	assert.Equal(t, ErrorReasonPolicyViolation, reason)
+
+	// Value sent to a contract deployment is also limited
+	_, err = tp.checkTx(ctx, nil, ethtypes.NewHexInteger64(101), nil)
+	assert.Regexp(t, "FF23118", err)
+}
+
+func TestValuePolicySend(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t, func(conf config.Section) {
+		conf.Set(PolicyValueMaxPerTransaction, "1000")
+	})
+	defer done()
+
+	var req ffcapi.TransactionSendRequest
+	err := json.Unmarshal([]byte(sampleSendTX), &req)
+	assert.NoError(t, err)
+	_, reason, err := c.TransactionSend(ctx, &req)
+	assert.Regexp(t, "FF23118.*12345678901234567890123456789", err)
+	assert.Equal(t, ErrorReasonPolicyViolation, reason)
+
+	kp, err := secp256k1.GenerateSecp256k1KeyPair()
+	assert.NoError(t, err)
+	fields := test2930Fields()
+	fields[5] = rlp.WrapInt(big.NewInt(1001))
+	_, _, err = c.TransactionSend(ctx, &ffcapi.TransactionSendRequest{
+		PreSigned:       true,
+		TransactionData: ethtypes.HexBytes0xPrefix(testSignTypedTx(t, kp, 0x01, fields)).String(),
+	})
+	assert.Regexp(t, "FF23118.*1001", err)
+
+	var prepareReq ffcapi.TransactionPrepareRequest
+	err = json.Unmarshal([]byte(samplePrepareTXWithGas), &prepareReq)
+	assert.NoError(t, err)
+	_, _, err = c.TransactionPrepare(ctx, &prepareReq)
+	assert.Regexp(t, "FF23118", err)
+
+	privateReq := testPrivateSendRequest(t, sampleSendTX)
+	_, _, err = c.PrivateTransactionSend(ctx, privateReq)
+	assert.Regexp(t, "FF23118", err)
+}
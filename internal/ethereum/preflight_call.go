@@ -0,0 +1,61 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+)
+
+// PrepareTransactionRequest is a TransactionPrepare request, with the option to also run a pre-flight call of it
+type PrepareTransactionRequest struct {
+	ffcapi.TransactionPrepareRequest
+	PreflightCall bool   `json:"preflightCall,omitempty"`
+	NumberFormat  string `json:"numberFormat,omitempty"`
+}
+
+// PrepareTransactionResponse is the prepared transaction, with the decoded outputs of the pre-flight call if one was run
+type PrepareTransactionResponse struct {
+	ffcapi.TransactionPrepareResponse
+	Outputs *fftypes.JSONAny `json:"outputs,omitempty"`
+}
+
+// PrepareTransactionPreflight prepares an invocation as TransactionPrepare does. With a pre-flight call requested, the
+// prepared transaction is also executed with an eth_call, with the prepared gas, so the return value a state-changing
+// method would give can be seen, and a revert is detected, before the transaction is submitted.
+func (c *ethConnector) PrepareTransactionPreflight(ctx context.Context, req *PrepareTransactionRequest) (res *PrepareTransactionResponse, reason ffcapi.ErrorReason, err error) {
+	ctx = withCorrelationIDs(ctx)
+	defer func() { err = c.exposeErrorDetails(reason, err) }()
+
+	var preflight *abi.Serializer
+	if req.PreflightCall {
+		if preflight, err = c.serializerFor(ctx, req.NumberFormat); err != nil {
+			return nil, ffcapi.ErrorReasonInvalidInputs, err
+		}
+	}
+	prepared, outputs, reason, err := c.prepareTransaction(ctx, &req.TransactionPrepareRequest, preflight)
+	if err != nil {
+		return nil, reason, err
+	}
+	return &PrepareTransactionResponse{
+		TransactionPrepareResponse: *prepared,
+		Outputs:                    outputs,
+	}, "", nil
+}
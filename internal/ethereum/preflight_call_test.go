@@ -0,0 +1,114 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-signer/pkg/ethsigner"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPrepareTransactionPreflightRoute(t *testing.T) {
+	_, c, mRPC, done := newTestConnector(t)
+	defer done()
+	url, close := newTestRouteServer(t, c)
+	defer close()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_estimateGas", mock.Anything).
+		Run(func(args mock.Arguments) {
+			*(args[1].(*ethtypes.HexInteger)) = *ethtypes.NewHexInteger64(100000)
+		}).
+		Return(nil).Once()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_call", mock.MatchedBy(func(tx *ethsigner.Transaction) bool {
+		// The call is made with the prepared gas
+		return tx.GasLimit.Int64() == 150000
+	}), "latest").
+		Run(func(args mock.Arguments) {
+			*(args[1].(*ethtypes.HexBytes0xPrefix)) = ethtypes.MustNewHexBytes0xPrefix("0x00000000000000000000000000000000000000000000000000000000baadf00d0000000000000000000000000000000000000000000000000000000000000040000000000000000000000000000000000000000000000000000000000000000b68656c6c6f20776f726c64000000000000000000000000000000000000000000")
+		}).
+		Return(nil).Once()
+
+	var req PrepareTransactionRequest
+	err := json.Unmarshal([]byte(sampleExecQuery), &req)
+	assert.NoError(t, err)
+	req.PreflightCall = true
+	body, _ := json.Marshal(&req)
+
+	res, err := http.Post(url+"/transactions/prepare", "application/json", strings.NewReader(string(body)))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var prepared PrepareTransactionResponse
+	err = json.NewDecoder(res.Body).Decode(&prepared)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(150000), prepared.Gas.Int64())
+	assert.Equal(t, "0x60fe47b100000000000000000000000000000000000000000000000000000000feedbeef", prepared.TransactionData)
+	assert.JSONEq(t, `{"output": "3131961357", "output1":"hello world"}`, prepared.Outputs.String())
+	mRPC.AssertExpectations(t)
+}
+
+func TestPrepareTransactionPreflightNotRequested(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	var req PrepareTransactionRequest
+	err := json.Unmarshal([]byte(samplePrepareTXWithGas), &req)
+	assert.NoError(t, err)
+	res, _, err := c.PrepareTransactionPreflight(ctx, &req)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1000000), res.Gas.Int64())
+	assert.Nil(t, res.Outputs)
+	mRPC.AssertNotCalled(t, "CallRPC", mock.Anything, mock.Anything, "eth_call", mock.Anything, mock.Anything)
+}
+
+func TestPrepareTransactionPreflightReverted(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_call", mock.Anything, "latest").
+		Return(&rpcbackend.RPCError{Code: 3, Message: "execution reverted", Data: *fftypes.JSONAnyPtr(`"0x391ad4e000000000000000000000000000000000000000000000000000000000000000140000000000000000000000000000000000000000000000000000000000000014"`)}).Once()
+
+	var req PrepareTransactionRequest
+	err := json.Unmarshal([]byte(sampleExecQuery), &req)
+	assert.NoError(t, err)
+	req.Gas = fftypes.NewFFBigInt(100000)
+	req.PreflightCall = true
+	_, reason, err := c.PrepareTransactionPreflight(ctx, &req)
+	assert.Regexp(t, `FF23021.*GreaterThanTen\("20", "20"\)`, err)
+	assert.Equal(t, ffcapi.ErrorReasonTransactionReverted, reason)
+}
+
+func TestPrepareTransactionPreflightBadInputs(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t)
+	defer done()
+
+	var req PrepareTransactionRequest
+	err := json.Unmarshal([]byte(samplePrepareTXBadTo), &req)
+	assert.NoError(t, err)
+	req.PreflightCall = true
+	_, reason, err := c.PrepareTransactionPreflight(ctx, &req)
+	assert.Regexp(t, "FF23020", err)
+	assert.Equal(t, ffcapi.ErrorReasonInvalidInputs, reason)
+}
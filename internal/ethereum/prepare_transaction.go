@@ -41,34 +41,53 @@ const (
 )
 
 func (c *ethConnector) TransactionPrepare(ctx context.Context, req *ffcapi.TransactionPrepareRequest) (res *ffcapi.TransactionPrepareResponse, reason ffcapi.ErrorReason, err error) {
+	ctx = withCorrelationIDs(ctx)
+	defer func() { err = c.exposeErrorDetails(reason, err) }()
+	res, _, reason, err = c.prepareTransaction(ctx, req, nil)
+	return res, reason, err
+}
 
+// prepareTransaction prepares an invocation, and if a serializer is supplied for the outputs performs a pre-flight
+// eth_call of it with the prepared gas, returning the decoded outputs of the method
+func (c *ethConnector) prepareTransaction(ctx context.Context, req *ffcapi.TransactionPrepareRequest, preflight *abi.Serializer) (res *ffcapi.TransactionPrepareResponse, outputs *fftypes.JSONAny, reason ffcapi.ErrorReason, err error) {
 	// Parse the input JSON data, to build the call data
 	callData, method, err := c.prepareCallData(ctx, &req.TransactionInput)
 	if err != nil {
-		return nil, ffcapi.ErrorReasonInvalidInputs, err
+		return nil, nil, ffcapi.ErrorReasonInvalidInputs, err
 	}
 
 	// Build the base transaction object
 	tx, err := c.buildTx(ctx, txTypeInvokeContract, req.From, req.To, req.Nonce, req.Gas, req.Value, callData)
 	if err != nil {
-		return nil, ffcapi.ErrorReasonInvalidInputs, err
+		return nil, nil, ffcapi.ErrorReasonInvalidInputs, err
+	}
+	if reason, err := c.policy().checkTx(ctx, tx.To, tx.Value, tx.Data); err != nil {
+		return nil, nil, reason, err
 	}
 
 	// Parse the optional errors JSON spec, if available
 	errors, err := buildErrorsABI(ctx, req.TransactionInput.Errors)
 	if err != nil {
-		return nil, ffcapi.ErrorReasonInvalidInputs, err
+		return nil, nil, ffcapi.ErrorReasonInvalidInputs, err
 	}
+	errors = c.withImplementationErrors(ctx, tx.To, nil, errors)
 
 	if req.Gas, reason, err = c.ensureGasEstimate(ctx, tx, method, errors, req.Gas); err != nil {
-		return nil, reason, err
+		return nil, nil, reason, err
 	}
 	log.L(ctx).Infof("Prepared transaction method=%s dataLen=%d gas=%s", method.String(), len(callData), req.Gas.Int())
 
+	if preflight != nil {
+		tx.GasLimit = (*ethtypes.HexInteger)(req.Gas)
+		if outputs, reason, err = c.callTransaction(ctx, tx, method, errors, nil, "", preflight); err != nil {
+			return nil, nil, reason, err
+		}
+	}
+
 	return &ffcapi.TransactionPrepareResponse{
 		Gas:             req.Gas,
 		TransactionData: ethtypes.HexBytes0xPrefix(callData).String(),
-	}, "", nil
+	}, outputs, "", nil
 
 }
 
@@ -85,6 +104,10 @@ func buildErrorsABI(ctx context.Context, errorSpecs []*fftypes.JSONAny) ([]*abi.
 
 func (c *ethConnector) ensureGasEstimate(ctx context.Context, tx *ethsigner.Transaction, method *abi.Entry, errors []*abi.Entry, gasRequest *fftypes.FFBigInt) (*fftypes.FFBigInt, ffcapi.ErrorReason, error) {
 	if gasRequest == nil || gasRequest.Int().Sign() == 0 {
+		if gasLimit := c.zeroGasLimit(); gasLimit != nil {
+			log.L(ctx).Debugf("Using gas limit %s of the zero gas network", gasLimit)
+			return (*fftypes.FFBigInt)(gasLimit), "", nil
+		}
 		// If a value for gas has not been supplied, do a gas estimate
 		gas, reason, err := c.gasEstimate(ctx, tx, method, errors)
 		if err != nil {
@@ -97,11 +120,10 @@ func (c *ethConnector) ensureGasEstimate(ctx context.Context, tx *ethsigner.Tran
 
 func (c *ethConnector) prepareCallData(ctx context.Context, req *ffcapi.TransactionInput) ([]byte, *abi.Entry, error) {
 
-	// Parse the method ABI
-	var method *abi.Entry
-	err := json.Unmarshal(req.Method.Bytes(), &method)
+	// Parse the method ABI, selecting the function from an ABI if supplied with one
+	method, err := parseABIMethod(ctx, req.Method)
 	if err != nil {
-		return nil, nil, i18n.NewError(ctx, msgs.MsgUnmarshalABIMethodFail, err)
+		return nil, nil, err
 	}
 
 	// Parse the params into the standard semantics of Go JSON unmarshalling, with []interface{}
@@ -110,7 +132,7 @@ func (c *ethConnector) prepareCallData(ctx context.Context, req *ffcapi.Transact
 		if p != nil {
 			err := p.Unmarshal(ctx, &ethParams[i])
 			if err != nil {
-				return nil, nil, i18n.NewError(ctx, msgs.MsgUnmarshalParamFail, i, err)
+				return nil, nil, withErrorDetails(i18n.NewError(ctx, msgs.MsgUnmarshalParamFail, i, err), &ErrorDetails{Field: fmt.Sprintf("params[%d]", i)})
 			}
 		}
 	}
@@ -0,0 +1,81 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"encoding/base64"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+)
+
+// PrivateQueryRequest is a query request with the Besu privacy group to query. The privacy group is in the
+// body rather than the path as base64 IDs can contain '/'
+type PrivateQueryRequest struct {
+	PrivacyGroupID string `json:"privacyGroupId"`
+	NumberFormat   string `json:"numberFormat,omitempty"`
+	ffcapi.QueryInvokeRequest
+}
+
+// validatePrivacyGroupID checks a Besu privacy group ID, which is the base64 encoding of 32 bytes
+func validatePrivacyGroupID(ctx context.Context, privacyGroupID string) error {
+	b, err := base64.StdEncoding.DecodeString(privacyGroupID)
+	if err != nil || len(b) != 32 {
+		return i18n.NewError(ctx, msgs.MsgInvalidPrivacyGroupID, privacyGroupID)
+	}
+	return nil
+}
+
+// private is true for listeners that read the private logs of a Besu privacy group. These never join the
+// lead group of the event stream, as the filters of the lead group only see public state.
+func (l *listener) private() bool {
+	return l.config.options.PrivacyGroupID != ""
+}
+
+// getPrivateTransactionInfo gets the private transaction wrapped by a privacy marker transaction.
+// Private state is only available from a node that is a member of the privacy group, so the primary
+// endpoint is always used.
+func (c *ethConnector) getPrivateTransactionInfo(ctx context.Context, hash ethtypes.HexBytes0xPrefix) (*txInfoJSONRPC, error) {
+	cacheKey := "priv:" + hash.String()
+	cached, ok := c.txCache.Get(cacheKey)
+	if ok {
+		return cached.(*txInfoJSONRPC), nil
+	}
+
+	var txInfo *txInfoJSONRPC
+	rpcErr := c.backend.CallRPC(ctx, &txInfo, "priv_getTransaction", hash)
+	if rpcErr != nil {
+		return nil, rpcErr.Error()
+	}
+	c.txCache.Add(cacheKey, txInfo)
+	return txInfo, nil
+}
+
+// PrivateQueryInvoke performs a query against the private state of a Besu privacy group, using priv_call
+func (c *ethConnector) PrivateQueryInvoke(ctx context.Context, privacyGroupID string, req *ffcapi.QueryInvokeRequest, numberFormat string) (*ffcapi.QueryInvokeResponse, ffcapi.ErrorReason, error) {
+	if err := validatePrivacyGroupID(ctx, privacyGroupID); err != nil {
+		return nil, ffcapi.ErrorReasonInvalidInputs, err
+	}
+	serializer, err := c.serializerFor(ctx, numberFormat)
+	if err != nil {
+		return nil, ffcapi.ErrorReasonInvalidInputs, err
+	}
+	return c.queryInvoke(ctx, req, privacyGroupID, serializer)
+}
@@ -0,0 +1,208 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-signer/pkg/ethsigner"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testPrivacyGroupID = "A1aVtMxLCUHmBVHXoZzzBgPbW/wj5axDpW9X8l91SGo="
+
+func TestValidatePrivacyGroupID(t *testing.T) {
+	assert.NoError(t, validatePrivacyGroupID(context.Background(), testPrivacyGroupID))
+	assert.Regexp(t, "FF23082", validatePrivacyGroupID(context.Background(), "!!"))
+	assert.Regexp(t, "FF23082", validatePrivacyGroupID(context.Background(), "AAAA"))
+}
+
+func TestPrivateQueryInvokeOK(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "priv_call", testPrivacyGroupID,
+		mock.MatchedBy(func(tx *ethsigner.Transaction) bool {
+			return tx.Data.String() == "0x60fe47b100000000000000000000000000000000000000000000000000000000feedbeef"
+		}),
+		"latest").
+		Run(func(args mock.Arguments) {
+			*(args[1].(*ethtypes.HexBytes0xPrefix)) = ethtypes.MustNewHexBytes0xPrefix("0x00000000000000000000000000000000000000000000000000000000baadf00d0000000000000000000000000000000000000000000000000000000000000040000000000000000000000000000000000000000000000000000000000000000b68656c6c6f20776f726c64000000000000000000000000000000000000000000")
+		}).
+		Return(nil)
+
+	var req ffcapi.QueryInvokeRequest
+	err := json.Unmarshal([]byte(sampleExecQuery), &req)
+	assert.NoError(t, err)
+
+	res, reason, err := c.PrivateQueryInvoke(ctx, testPrivacyGroupID, &req, "")
+	assert.NoError(t, err)
+	assert.Empty(t, reason)
+	assert.JSONEq(t, `{"output": "3131961357", "output1":"hello world"}`, res.Outputs.String())
+}
+
+func TestPrivateQueryInvokeBadPrivacyGroup(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t)
+	defer done()
+
+	var req ffcapi.QueryInvokeRequest
+	err := json.Unmarshal([]byte(sampleExecQuery), &req)
+	assert.NoError(t, err)
+
+	_, reason, err := c.PrivateQueryInvoke(ctx, "wrong", &req, "")
+	assert.Regexp(t, "FF23082", err)
+	assert.Equal(t, ffcapi.ErrorReasonInvalidInputs, reason)
+}
+
+func TestPrivateQueryRoute(t *testing.T) {
+	_, c, mRPC, done := newTestConnector(t)
+	defer done()
+	url, close := newTestRouteServer(t, c)
+	defer close()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "priv_call", testPrivacyGroupID, mock.Anything, "latest").
+		Return(&rpcbackend.RPCError{Message: "pop"})
+
+	req := &PrivateQueryRequest{PrivacyGroupID: testPrivacyGroupID}
+	err := json.Unmarshal([]byte(sampleExecQuery), &req.QueryInvokeRequest)
+	assert.NoError(t, err)
+	b, _ := json.Marshal(req)
+
+	res, err := http.Post(url+"/privacy/query", "application/json", bytes.NewReader(b))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+
+	req.PrivacyGroupID = "wrong"
+	b, _ = json.Marshal(req)
+	res, err = http.Post(url+"/privacy/query", "application/json", bytes.NewReader(b))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+}
+
+func TestPrivateListenerFollowsHead(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	mockStreamLoopEmpty(mRPC)
+
+	ethLog := sampleTransferLog()
+	ethLog.BlockNumber = ethtypes.NewHexInteger64(testHighBlock - 2)
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "priv_getLogs", testPrivacyGroupID, mock.MatchedBy(func(f *logFilterJSONRPC) bool {
+		return f.FromBlock.BigInt().Int64() == testHighBlock-5 && f.ToBlock.BigInt().Int64() == testHighBlock
+	})).Return(nil).Run(func(args mock.Arguments) {
+		*args[1].(*[]*logJSONRPC) = []*logJSONRPC{ethLog}
+	}).Once()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByHash", mock.Anything, false).Return(nil).Run(func(args mock.Arguments) {
+		*args[1].(**blockInfoJSONRPC) = &blockInfoJSONRPC{
+			Number:    ethtypes.NewHexInteger64(testHighBlock - 2),
+			Timestamp: ethtypes.NewHexInteger64(1000000),
+		}
+	}).Maybe()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "priv_getTransaction", ethLog.TransactionHash).Return(nil).Run(func(args mock.Arguments) {
+		*args[1].(**txInfoJSONRPC) = &txInfoJSONRPC{
+			From: ethtypes.MustNewAddress("0x3968ef051b422d3d1cdc182a88bba8dd922e6fa4"),
+		}
+	}).Once()
+
+	lID := fftypes.NewUUID()
+	es, events, _, done := testEventStreamExistingConnector(t, ctx, done, c, mRPC, &ffcapi.EventListenerAddRequest{
+		ListenerID: lID,
+		EventListenerOptions: ffcapi.EventListenerOptions{
+			Filters: []fftypes.JSONAny{
+				*fftypes.JSONAnyPtr(`{"event":` + abiTransferEvent + `}`),
+			},
+			Options:   fftypes.JSONAnyPtr(`{"signer":true,"privacyGroupId":"` + testPrivacyGroupID + `"}`),
+			FromBlock: strconv.Itoa(testHighBlock - 5),
+		},
+	})
+	defer done()
+
+	ev := <-events
+	info := ev.Event.Info.(*eventInfo)
+	assert.Equal(t, testPrivacyGroupID, info.PrivacyGroupID)
+	assert.Equal(t, "0x3968ef051b422d3d1cdc182a88bba8dd922e6fa4", info.InputSigner.String())
+	assert.Equal(t, &listenerCheckpoint{
+		Block:            testHighBlock - 2,
+		TransactionIndex: 64,
+		LogIndex:         2,
+		PrivacyGroupID:   testPrivacyGroupID,
+	}, ev.Checkpoint)
+
+	// The listener stays out of the lead group, and waits for new blocks at the head
+	l := es.listeners[*lID]
+	assert.Eventually(t, func() bool { return l.getHWMCheckpoint().Block == testHighBlock+1 }, 5*time.Second, time.Millisecond)
+	assert.True(t, l.catchup)
+	assert.Equal(t, testPrivacyGroupID, l.getHWMCheckpoint().PrivacyGroupID)
+}
+
+func TestPrivateListenerIgnoresPublicCheckpoint(t *testing.T) {
+	es, _, mRPC, done := testEventStream(t)
+	defer done()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "priv_getLogs", testPrivacyGroupID, mock.Anything).Return(nil).Maybe()
+
+	l, err := es.addEventListener(es.ctx, &ffcapi.EventListenerAddRequest{
+		ListenerID: fftypes.NewUUID(),
+		EventListenerOptions: ffcapi.EventListenerOptions{
+			Filters: []fftypes.JSONAny{
+				*fftypes.JSONAnyPtr(`{"event":` + abiTransferEvent + `}`),
+			},
+			Options:   fftypes.JSONAnyPtr(`{"privacyGroupId":"` + testPrivacyGroupID + `"}`),
+			FromBlock: "1000",
+		},
+		Checkpoint: &listenerCheckpoint{Block: 2000, TransactionIndex: 1, LogIndex: 1},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1000), l.hwmBlock)
+	assert.Nil(t, l.resumeAfter)
+}
+
+func TestPrivateListenerBadPrivacyGroup(t *testing.T) {
+	_, err := parseListenerOptions(context.Background(), fftypes.JSONAnyPtr(`{"privacyGroupId":"wrong"}`))
+	assert.Regexp(t, "FF23082", err)
+}
+
+func TestGetPrivateTransactionInfo(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	hash := ethtypes.MustNewHexBytes0xPrefix("0x1a1f797ee000c529b6a2dd330cedd0d081417a30d16a4eecb3f863ab4657246f")
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "priv_getTransaction", hash).Return(&rpcbackend.RPCError{Message: "pop"}).Once()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "priv_getTransaction", hash).Return(nil).Run(func(args mock.Arguments) {
+		*args[1].(**txInfoJSONRPC) = &txInfoJSONRPC{Hash: hash}
+	}).Once()
+
+	_, err := c.getPrivateTransactionInfo(ctx, hash)
+	assert.Regexp(t, "pop", err)
+
+	txInfo, err := c.getPrivateTransactionInfo(ctx, hash)
+	assert.NoError(t, err)
+	assert.Equal(t, hash, txInfo.Hash)
+
+	// Served from the cache
+	txInfo, err = c.getPrivateTransactionInfo(ctx, hash)
+	assert.NoError(t, err)
+	assert.Equal(t, hash, txInfo.Hash)
+}
@@ -0,0 +1,256 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+)
+
+const (
+	ProxyTypeEIP1967 = "eip1967"
+	ProxyTypeBeacon  = "beacon"
+	ProxyTypeEIP1822 = "eip1822"
+
+	beaconImplementationSelector = "5c60da1b" // implementation()
+)
+
+var (
+	// See https://eips.ethereum.org/EIPS/eip-1822 - the original UUPS storage slot, keccak256("PROXIABLE")
+	eip1822ProxiableSlot = keccak256([]byte("PROXIABLE"))
+
+	beaconImplementationGasLimit = ethtypes.NewHexInteger64(30000)
+)
+
+// ProxyInfo describes the proxy standard a contract follows (if any), and the address of the
+// implementation contract that calls are delegated to
+type ProxyInfo struct {
+	Address        string                 `json:"address"`
+	IsProxy        bool                   `json:"isProxy"`
+	Type           string                 `json:"type,omitempty"`
+	Implementation *ethtypes.Address0xHex `json:"implementation,omitempty"`
+	Beacon         *ethtypes.Address0xHex `json:"beacon,omitempty"`
+	Admin          *ethtypes.Address0xHex `json:"admin,omitempty"`
+}
+
+type cachedProxyInfo struct {
+	info    *ProxyInfo
+	expires time.Time
+}
+
+// implementationABI holds the configured ABI of an implementation contract, indexed for decoding the
+// events and reverts of the proxies that delegate to it
+type implementationABI struct {
+	events map[string]*abi.Entry // by topic0 signature hash
+	errors []*abi.Entry
+}
+
+// ResolveProxy checks the standard proxy storage slots of a contract at the latest block, in order:
+//   - the EIP-1967 implementation slot (also used by UUPS proxies)
+//   - the EIP-1967 beacon slot, calling implementation() on the beacon
+//   - the original EIP-1822 UUPS slot
+func (c *ethConnector) ResolveProxy(ctx context.Context, addressStr string) (*ProxyInfo, error) {
+	address, err := ethtypes.NewAddress(addressStr)
+	if err != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidContractAddress, addressStr, err)
+	}
+	return c.resolveProxy(ctx, address, "latest")
+}
+
+func (c *ethConnector) resolveProxy(ctx context.Context, address *ethtypes.Address0xHex, blockNumber string) (info *ProxyInfo, err error) {
+	info = &ProxyInfo{Address: address.String()}
+
+	if info.Implementation, err = c.getStorageAddress(ctx, address, eip1967ImplementationSlot, blockNumber); err != nil {
+		return nil, err
+	}
+	if info.Implementation != nil {
+		info.Type = ProxyTypeEIP1967
+	} else {
+		if info.Beacon, err = c.getStorageAddress(ctx, address, eip1967BeaconSlot, blockNumber); err != nil {
+			return nil, err
+		}
+		if info.Beacon != nil {
+			info.Type = ProxyTypeBeacon
+			if info.Implementation, err = c.getBeaconImplementation(ctx, info.Beacon, blockNumber); err != nil {
+				return nil, err
+			}
+		} else {
+			if info.Implementation, err = c.getStorageAddress(ctx, address, eip1822ProxiableSlot, blockNumber); err != nil {
+				return nil, err
+			}
+			if info.Implementation != nil {
+				info.Type = ProxyTypeEIP1822
+			}
+		}
+	}
+	if info.Type == "" {
+		return info, nil
+	}
+	info.IsProxy = true
+	if info.Admin, err = c.getStorageAddress(ctx, address, eip1967AdminSlot, blockNumber); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// proxyImplementation returns the implementation address for a proxy contract at a block, or nil if the
+// contract was not a proxy. Results are cached per block, so events from the same block share a lookup,
+// and events from before an upgrade resolve to the implementation that emitted them.
+func (c *ethConnector) proxyImplementation(ctx context.Context, address *ethtypes.Address0xHex, blockNumber string) (*ethtypes.Address0xHex, error) {
+	key := address.String() + "@" + blockNumber
+	c.proxyMux.Lock()
+	cached, ok := c.proxyCache[key]
+	c.proxyMux.Unlock()
+	if ok && time.Now().Before(cached.expires) {
+		return cached.info.Implementation, nil
+	}
+
+	info, err := c.resolveProxy(ctx, address, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	log.L(ctx).Debugf("Resolved proxy contract %s at block %s implementation=%s", address, blockNumber, info.Implementation)
+
+	c.proxyMux.Lock()
+	defer c.proxyMux.Unlock()
+	now := time.Now()
+	for k, p := range c.proxyCache {
+		if now.After(p.expires) {
+			delete(c.proxyCache, k)
+		}
+	}
+	c.proxyCache[key] = &cachedProxyInfo{
+		info:    info,
+		expires: now.Add(c.proxyCacheTTL),
+	}
+	return info.Implementation, nil
+}
+
+// implementationEvent returns the event with the given signature hash from the configured ABI of an implementation
+func (c *ethConnector) implementationEvent(implementation *ethtypes.Address0xHex, topic0 []byte) *abi.Entry {
+	if implementation == nil {
+		return nil
+	}
+	if implABI := c.implementationABIs[implementation.String()]; implABI != nil {
+		return implABI.events[ethtypes.HexBytes0xPrefix(topic0).String()]
+	}
+	return nil
+}
+
+// withImplementationErrors adds the errors from the configured ABI of the implementation behind a proxy to
+// those supplied with a request, so reverts that bubble up from the implementation can be decoded.
+// The request is not failed if the proxy cannot be resolved, as the errors are only used for decoding.
+func (c *ethConnector) withImplementationErrors(ctx context.Context, to *ethtypes.Address0xHex, blockNumber *string, errors []*abi.Entry) []*abi.Entry {
+	if !c.proxyResolution || len(c.implementationABIs) == 0 || to == nil {
+		return errors
+	}
+	block := "latest"
+	if blockNumber != nil {
+		block = *blockNumber
+	}
+	implementation, err := c.proxyImplementation(ctx, to, block)
+	if err != nil {
+		log.L(ctx).Warnf("Failed to resolve proxy implementation for contract '%s' to decode reverts: %s", to, err)
+		return errors
+	}
+	if implementation != nil {
+		if implABI := c.implementationABIs[implementation.String()]; implABI != nil {
+			return append(append([]*abi.Entry{}, errors...), implABI.errors...)
+		}
+	}
+	return errors
+}
+
+// parseImplementationABIs reads the configured ABIs of implementation contracts, which can be supplied
+// either inline in the config or as a JSON string
+func parseImplementationABIs(ctx context.Context, abisConf config.ArraySection) (map[string]*implementationABI, error) {
+	implementationABIs := make(map[string]*implementationABI)
+	for i := 0; i < abisConf.ArraySize(); i++ {
+		entry := abisConf.ArrayEntry(i)
+		implementation, err := ethtypes.NewAddress(entry.GetString(ProxyResolutionABIsImplementation))
+		if err != nil {
+			return nil, i18n.NewError(ctx, msgs.MsgInvalidImplementationABI, entry.GetString(ProxyResolutionABIsImplementation), err)
+		}
+		var abiBytes []byte
+		switch v := entry.Get(ProxyResolutionABIsABI).(type) {
+		case string:
+			abiBytes = []byte(v)
+		default:
+			abiBytes, err = json.Marshal(v)
+		}
+		var a abi.ABI
+		if err == nil {
+			err = json.Unmarshal(abiBytes, &a)
+		}
+		if err != nil {
+			return nil, i18n.NewError(ctx, msgs.MsgInvalidImplementationABI, implementation, err)
+		}
+		implABI := &implementationABI{events: make(map[string]*abi.Entry)}
+		for _, e := range a {
+			switch e.Type {
+			case abi.Event:
+				id, err := e.SignatureHashCtx(ctx)
+				if err != nil {
+					return nil, i18n.NewError(ctx, msgs.MsgInvalidImplementationABI, implementation, err)
+				}
+				implABI.events[id.String()] = e
+			case abi.Error:
+				implABI.errors = append(implABI.errors, e)
+			}
+		}
+		implementationABIs[implementation.String()] = implABI
+	}
+	return implementationABIs, nil
+}
+
+// getStorageAddress reads an address stored in a slot at a block, returning nil for an empty slot
+func (c *ethConnector) getStorageAddress(ctx context.Context, address *ethtypes.Address0xHex, slot []byte, blockNumber string) (*ethtypes.Address0xHex, error) {
+	value, err := c.getStorageAt(ctx, address, slot, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	return addressFromWord(value), nil
+}
+
+func (c *ethConnector) getBeaconImplementation(ctx context.Context, beacon *ethtypes.Address0xHex, blockNumber string) (*ethtypes.Address0xHex, error) {
+	outputData, err := c.callContractIgnoringReverts(ctx, beacon, ethtypes.MustNewHexBytes0xPrefix(beaconImplementationSelector), beaconImplementationGasLimit, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	return addressFromWord(outputData), nil
+}
+
+// addressFromWord extracts the address from the low 20 bytes of a 32 byte word, returning nil if it is zero
+func addressFromWord(word []byte) *ethtypes.Address0xHex {
+	if len(word) != 32 {
+		return nil
+	}
+	var address ethtypes.Address0xHex
+	copy(address[:], word[12:])
+	if address == (ethtypes.Address0xHex{}) {
+		return nil
+	}
+	return &address
+}
@@ -0,0 +1,383 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-evmconnect/mocks/rpcbackendmocks"
+	"github.com/hyperledger/firefly-signer/pkg/ethsigner"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	sampleImplementationWord = "0x000000000000000000000000aab1c59b2a5d6c0a5e3f7fd9d73d9b6e6b31fe32"
+	sampleBeaconWord         = "0x0000000000000000000000005e6f3e0ab5a44ffcb9e89fb4cacb4c0f0e4b8621"
+	sampleAdminWord          = "0x0000000000000000000000003f1d8c2b2d70fd9b1a6450f0c4af3c5e9e8ea7a3"
+	emptyStorageWord         = "0x0000000000000000000000000000000000000000000000000000000000000000"
+
+	sampleImplementationABI = `[
+		{
+			"type": "event",
+			"name": "Transfer",
+			"inputs": [
+				{"indexed": true, "name": "sender", "type": "address"},
+				{"indexed": true, "name": "recipient", "type": "address"},
+				{"indexed": false, "name": "amount", "type": "uint256"}
+			]
+		},
+		{
+			"type": "error",
+			"name": "GreaterThanTen",
+			"inputs": [
+				{"name": "x", "type": "uint256"},
+				{"name": "y", "type": "uint256"}
+			]
+		},
+		{
+			"type": "function",
+			"name": "set",
+			"inputs": [{"name": "x", "type": "uint256"}]
+		}
+	]`
+)
+
+func testImplementationABIs(t *testing.T) map[string]*implementationABI {
+	config.RootConfigReset()
+	conf := config.RootSection("proxy_test")
+	InitConfig(conf)
+	entry := proxyABIsConfig(conf).ArrayEntry(0)
+	entry.Set(ProxyResolutionABIsImplementation, "0xaab1c59b2a5d6c0a5e3f7fd9d73d9b6e6b31fe32")
+	entry.Set(ProxyResolutionABIsABI, sampleImplementationABI)
+	implementationABIs, err := parseImplementationABIs(context.Background(), proxyABIsConfig(conf))
+	assert.NoError(t, err)
+	return implementationABIs
+}
+
+func mockStorageSlot(mRPC *rpcbackendmocks.Backend, address string, slot []byte, value, blockNumber string) *mock.Call {
+	return mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getStorageAt",
+		mock.MatchedBy(func(addr *ethtypes.Address0xHex) bool {
+			return addr.String() == address
+		}),
+		mock.MatchedBy(func(s ethtypes.HexBytes0xPrefix) bool {
+			return s.String() == ethtypes.HexBytes0xPrefix(slot).String()
+		}),
+		blockNumber).
+		Run(func(args mock.Arguments) {
+			*(args[1].(*ethtypes.HexBytes0xPrefix)) = ethtypes.MustNewHexBytes0xPrefix(value)
+		}).
+		Return(nil)
+}
+
+func mockBeaconImplementation(mRPC *rpcbackendmocks.Backend, beacon, result, blockNumber string) *mock.Call {
+	return mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_call",
+		mock.MatchedBy(func(tx *ethsigner.Transaction) bool {
+			return tx.To.String() == beacon && tx.Data.String() == "0x5c60da1b"
+		}),
+		blockNumber).
+		Run(func(args mock.Arguments) {
+			*(args[1].(*ethtypes.HexBytes0xPrefix)) = ethtypes.MustNewHexBytes0xPrefix(result)
+		}).
+		Return(nil)
+}
+
+func TestResolveProxyEIP1967(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mockStorageSlot(mRPC, sampleContractAddress, eip1967ImplementationSlot, sampleImplementationWord, "latest")
+	mockStorageSlot(mRPC, sampleContractAddress, eip1967AdminSlot, sampleAdminWord, "latest")
+
+	info, err := c.ResolveProxy(ctx, sampleContractAddress)
+	assert.NoError(t, err)
+	assert.True(t, info.IsProxy)
+	assert.Equal(t, ProxyTypeEIP1967, info.Type)
+	assert.Equal(t, "0xaab1c59b2a5d6c0a5e3f7fd9d73d9b6e6b31fe32", info.Implementation.String())
+	assert.Equal(t, "0x3f1d8c2b2d70fd9b1a6450f0c4af3c5e9e8ea7a3", info.Admin.String())
+	assert.Nil(t, info.Beacon)
+}
+
+func TestResolveProxyBeacon(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mockStorageSlot(mRPC, sampleContractAddress, eip1967ImplementationSlot, emptyStorageWord, "latest")
+	mockStorageSlot(mRPC, sampleContractAddress, eip1967BeaconSlot, sampleBeaconWord, "latest")
+	mockBeaconImplementation(mRPC, "0x5e6f3e0ab5a44ffcb9e89fb4cacb4c0f0e4b8621", sampleImplementationWord, "latest")
+	mockStorageSlot(mRPC, sampleContractAddress, eip1967AdminSlot, emptyStorageWord, "latest")
+
+	info, err := c.ResolveProxy(ctx, sampleContractAddress)
+	assert.NoError(t, err)
+	assert.True(t, info.IsProxy)
+	assert.Equal(t, ProxyTypeBeacon, info.Type)
+	assert.Equal(t, "0x5e6f3e0ab5a44ffcb9e89fb4cacb4c0f0e4b8621", info.Beacon.String())
+	assert.Equal(t, "0xaab1c59b2a5d6c0a5e3f7fd9d73d9b6e6b31fe32", info.Implementation.String())
+	assert.Nil(t, info.Admin)
+}
+
+func TestResolveProxyEIP1822(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mockStorageSlot(mRPC, sampleContractAddress, eip1967ImplementationSlot, emptyStorageWord, "latest")
+	mockStorageSlot(mRPC, sampleContractAddress, eip1967BeaconSlot, emptyStorageWord, "latest")
+	mockStorageSlot(mRPC, sampleContractAddress, eip1822ProxiableSlot, sampleImplementationWord, "latest")
+	mockStorageSlot(mRPC, sampleContractAddress, eip1967AdminSlot, emptyStorageWord, "latest")
+
+	info, err := c.ResolveProxy(ctx, sampleContractAddress)
+	assert.NoError(t, err)
+	assert.True(t, info.IsProxy)
+	assert.Equal(t, ProxyTypeEIP1822, info.Type)
+	assert.Equal(t, "0xaab1c59b2a5d6c0a5e3f7fd9d73d9b6e6b31fe32", info.Implementation.String())
+}
+
+func TestResolveProxyNotProxy(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mockStorageSlot(mRPC, sampleContractAddress, eip1967ImplementationSlot, emptyStorageWord, "latest")
+	mockStorageSlot(mRPC, sampleContractAddress, eip1967BeaconSlot, emptyStorageWord, "latest")
+	mockStorageSlot(mRPC, sampleContractAddress, eip1822ProxiableSlot, emptyStorageWord, "latest")
+
+	info, err := c.ResolveProxy(ctx, sampleContractAddress)
+	assert.NoError(t, err)
+	assert.False(t, info.IsProxy)
+	assert.Empty(t, info.Type)
+	assert.Nil(t, info.Implementation)
+}
+
+func TestResolveProxyBadAddress(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t)
+	defer done()
+
+	_, err := c.ResolveProxy(ctx, "wrong")
+	assert.Regexp(t, "FF23060", err)
+}
+
+func TestResolveProxyStorageFail(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getStorageAt", mock.Anything, mock.Anything, "latest").
+		Return(&rpcbackend.RPCError{Message: "pop"})
+
+	_, err := c.ResolveProxy(ctx, sampleContractAddress)
+	assert.Regexp(t, "pop", err)
+}
+
+func TestResolveProxyBeaconFail(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mockStorageSlot(mRPC, sampleContractAddress, eip1967ImplementationSlot, emptyStorageWord, "latest")
+	mockStorageSlot(mRPC, sampleContractAddress, eip1967BeaconSlot, sampleBeaconWord, "latest")
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_call", mock.Anything, "latest").
+		Return(&rpcbackend.RPCError{Message: "pop"})
+
+	_, err := c.ResolveProxy(ctx, sampleContractAddress)
+	assert.Regexp(t, "pop", err)
+}
+
+func TestResolveProxyAdminFail(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mockStorageSlot(mRPC, sampleContractAddress, eip1967ImplementationSlot, sampleImplementationWord, "latest")
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getStorageAt", mock.Anything, mock.Anything, "latest").
+		Return(&rpcbackend.RPCError{Message: "pop"})
+
+	_, err := c.ResolveProxy(ctx, sampleContractAddress)
+	assert.Regexp(t, "pop", err)
+}
+
+func TestProxyImplementationCached(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+	c.proxyCacheTTL = 1 * time.Hour
+	c.proxyCache["0xd9b1e7de5ab8dea8d6f0b4a0069dd5ea00a262fe@latest"] = &cachedProxyInfo{
+		info:    &ProxyInfo{},
+		expires: time.Now().Add(-1 * time.Second),
+	}
+
+	mockStorageSlot(mRPC, sampleContractAddress, eip1967ImplementationSlot, sampleImplementationWord, "latest").Once()
+	mockStorageSlot(mRPC, sampleContractAddress, eip1967AdminSlot, emptyStorageWord, "latest").Once()
+
+	for i := 0; i < 2; i++ {
+		impl, err := c.proxyImplementation(ctx, ethtypes.MustNewAddress(sampleContractAddress), "latest")
+		assert.NoError(t, err)
+		assert.Equal(t, "0xaab1c59b2a5d6c0a5e3f7fd9d73d9b6e6b31fe32", impl.String())
+	}
+	assert.Len(t, c.proxyCache, 1)
+}
+
+func TestProxyImplementationFail(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getStorageAt", mock.Anything, mock.Anything, "latest").
+		Return(&rpcbackend.RPCError{Message: "pop"})
+
+	_, err := c.proxyImplementation(ctx, ethtypes.MustNewAddress(sampleContractAddress), "latest")
+	assert.Regexp(t, "pop", err)
+	assert.Empty(t, c.proxyCache)
+}
+
+func TestProxyImplementationAtBlock(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	// The beacon pointed to a different implementation before an upgrade, so each block is resolved separately
+	mockStorageSlot(mRPC, sampleContractAddress, eip1967ImplementationSlot, emptyStorageWord, "0x64").Once()
+	mockStorageSlot(mRPC, sampleContractAddress, eip1967BeaconSlot, sampleBeaconWord, "0x64").Once()
+	mockBeaconImplementation(mRPC, "0x5e6f3e0ab5a44ffcb9e89fb4cacb4c0f0e4b8621", sampleAdminWord, "0x64").Once()
+	mockStorageSlot(mRPC, sampleContractAddress, eip1967AdminSlot, emptyStorageWord, "0x64").Once()
+	mockStorageSlot(mRPC, sampleContractAddress, eip1967ImplementationSlot, emptyStorageWord, "0xc8").Once()
+	mockStorageSlot(mRPC, sampleContractAddress, eip1967BeaconSlot, sampleBeaconWord, "0xc8").Once()
+	mockBeaconImplementation(mRPC, "0x5e6f3e0ab5a44ffcb9e89fb4cacb4c0f0e4b8621", sampleImplementationWord, "0xc8").Once()
+	mockStorageSlot(mRPC, sampleContractAddress, eip1967AdminSlot, emptyStorageWord, "0xc8").Once()
+
+	address := ethtypes.MustNewAddress(sampleContractAddress)
+	impl, err := c.proxyImplementation(ctx, address, "0x64")
+	assert.NoError(t, err)
+	assert.Equal(t, "0x3f1d8c2b2d70fd9b1a6450f0c4af3c5e9e8ea7a3", impl.String())
+	impl, err = c.proxyImplementation(ctx, address, "0xc8")
+	assert.NoError(t, err)
+	assert.Equal(t, "0xaab1c59b2a5d6c0a5e3f7fd9d73d9b6e6b31fe32", impl.String())
+	impl, err = c.proxyImplementation(ctx, address, "0x64")
+	assert.NoError(t, err)
+	assert.Equal(t, "0x3f1d8c2b2d70fd9b1a6450f0c4af3c5e9e8ea7a3", impl.String())
+	mRPC.AssertExpectations(t)
+}
+
+func TestParseImplementationABIs(t *testing.T) {
+	implementationABIs := testImplementationABIs(t)
+	implABI := implementationABIs["0xaab1c59b2a5d6c0a5e3f7fd9d73d9b6e6b31fe32"]
+	assert.NotNil(t, implABI)
+	assert.Equal(t, "Transfer", implABI.events["0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"].Name)
+	assert.Len(t, implABI.errors, 1)
+	assert.Equal(t, "GreaterThanTen", implABI.errors[0].Name)
+}
+
+func TestParseImplementationABIsInline(t *testing.T) {
+	config.RootConfigReset()
+	conf := config.RootSection("proxy_test")
+	InitConfig(conf)
+	entry := proxyABIsConfig(conf).ArrayEntry(0)
+	entry.Set(ProxyResolutionABIsImplementation, "0xaab1c59b2a5d6c0a5e3f7fd9d73d9b6e6b31fe32")
+	var inline []interface{}
+	err := json.Unmarshal([]byte(sampleImplementationABI), &inline)
+	assert.NoError(t, err)
+	entry.Set(ProxyResolutionABIsABI, inline)
+
+	implementationABIs, err := parseImplementationABIs(context.Background(), proxyABIsConfig(conf))
+	assert.NoError(t, err)
+	assert.Len(t, implementationABIs["0xaab1c59b2a5d6c0a5e3f7fd9d73d9b6e6b31fe32"].events, 1)
+}
+
+func TestParseImplementationABIsBadConfig(t *testing.T) {
+	config.RootConfigReset()
+	conf := config.RootSection("proxy_test")
+	InitConfig(conf)
+	entry := proxyABIsConfig(conf).ArrayEntry(0)
+	entry.Set(ProxyResolutionABIsImplementation, "wrong")
+	_, err := parseImplementationABIs(context.Background(), proxyABIsConfig(conf))
+	assert.Regexp(t, "FF23127.*wrong", err)
+
+	entry.Set(ProxyResolutionABIsImplementation, "0xaab1c59b2a5d6c0a5e3f7fd9d73d9b6e6b31fe32")
+	entry.Set(ProxyResolutionABIsABI, "!json")
+	_, err = parseImplementationABIs(context.Background(), proxyABIsConfig(conf))
+	assert.Regexp(t, "FF23127.*0xaab1c59b2a5d6c0a5e3f7fd9d73d9b6e6b31fe32", err)
+
+	entry.Set(ProxyResolutionABIsABI, `[{"type":"event","name":"Bad","inputs":[{"name":"x","type":"wrong"}]}]`)
+	_, err = parseImplementationABIs(context.Background(), proxyABIsConfig(conf))
+	assert.Regexp(t, "FF23127.*0xaab1c59b2a5d6c0a5e3f7fd9d73d9b6e6b31fe32", err)
+}
+
+func TestExecQueryProxyImplementationErrors(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+	c.proxyResolution = true
+	c.implementationABIs = testImplementationABIs(t)
+
+	mockStorageSlot(mRPC, "0xe1a078b9e2b145d0a7387f09277c6ae1d9470771", eip1967ImplementationSlot, sampleImplementationWord, "latest")
+	mockStorageSlot(mRPC, "0xe1a078b9e2b145d0a7387f09277c6ae1d9470771", eip1967AdminSlot, emptyStorageWord, "latest")
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_call", mock.Anything, "latest").
+		Run(func(args mock.Arguments) {
+			*(args[1].(*ethtypes.HexBytes0xPrefix)) = ethtypes.MustNewHexBytes0xPrefix("0x391ad4e000000000000000000000000000000000000000000000000000000000000000140000000000000000000000000000000000000000000000000000000000000014")
+		}).
+		Return(nil)
+
+	// The caller only supplies the ABI of the proxy, so the revert is decoded with the errors of the implementation
+	var req ffcapi.QueryInvokeRequest
+	err := json.Unmarshal([]byte(sampleExecQuery), &req)
+	assert.NoError(t, err)
+	req.Errors = []*fftypes.JSONAny{}
+	_, reason, err := c.QueryInvoke(ctx, &req)
+	assert.Equal(t, ffcapi.ErrorReasonTransactionReverted, reason)
+	expectedError := i18n.NewError(ctx, msgs.MsgReverted, `GreaterThanTen("20", "20")`)
+	assert.Equal(t, expectedError.Error(), err.Error())
+}
+
+func TestWithImplementationErrorsResolveFail(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+	c.proxyResolution = true
+	c.implementationABIs = testImplementationABIs(t)
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getStorageAt", mock.Anything, mock.Anything, "0x12345").
+		Return(&rpcbackend.RPCError{Message: "pop"})
+
+	errors := c.withImplementationErrors(ctx, ethtypes.MustNewAddress(sampleContractAddress), strPtr("0x12345"), nil)
+	assert.Empty(t, errors)
+}
+
+func TestAddressFromWord(t *testing.T) {
+	assert.Nil(t, addressFromWord(nil))
+	assert.Nil(t, addressFromWord(ethtypes.MustNewHexBytes0xPrefix(emptyStorageWord)))
+	assert.Equal(t, "0xaab1c59b2a5d6c0a5e3f7fd9d73d9b6e6b31fe32", addressFromWord(ethtypes.MustNewHexBytes0xPrefix(sampleImplementationWord)).String())
+}
+
+func TestGetProxyInfoRoute(t *testing.T) {
+	_, c, mRPC, done := newTestConnector(t)
+	defer done()
+	url, close := newTestRouteServer(t, c)
+	defer close()
+
+	mockStorageSlot(mRPC, sampleContractAddress, eip1967ImplementationSlot, sampleImplementationWord, "latest")
+	mockStorageSlot(mRPC, sampleContractAddress, eip1967AdminSlot, emptyStorageWord, "latest")
+
+	res, err := http.Get(url + "/contracts/" + sampleContractAddress + "/proxy")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var info ProxyInfo
+	err = json.NewDecoder(res.Body).Decode(&info)
+	assert.NoError(t, err)
+	assert.True(t, info.IsProxy)
+	assert.Equal(t, "0xaab1c59b2a5d6c0a5e3f7fd9d73d9b6e6b31fe32", info.Implementation.String())
+}
@@ -0,0 +1,111 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/log"
+)
+
+// defaultRateLimitPause is how long requests are paused for when the remaining requests are exhausted, if the
+// provider does not say when the limit resets
+const defaultRateLimitPause = 1 * time.Second
+
+// rateLimitHeaders are the headers of the responses of a provider, that say when to slow down before requests are
+// rejected with a 429
+type rateLimitHeaders struct {
+	remaining    string
+	reset        string
+	minRemaining float64
+	maxPause     time.Duration
+}
+
+type rpcSchedulerKey struct{}
+
+// newRateLimitHeaders returns nil if the headers of the responses are not to be used for scheduling
+func newRateLimitHeaders(conf config.Section) *rateLimitHeaders {
+	if !conf.GetBool(RateLimitHeadersEnabled) {
+		return nil
+	}
+	return &rateLimitHeaders{
+		remaining:    conf.GetString(RateLimitHeadersRemaining),
+		reset:        conf.GetString(RateLimitHeadersReset),
+		minRemaining: conf.GetFloat64(RateLimitHeadersMinRemaining),
+		maxPause:     conf.GetDuration(RateLimitHeadersMaxPause),
+	}
+}
+
+// withRPCScheduler adds the scheduler of the endpoint a request is sent to to the context, so the headers of the
+// response can pause it
+func withRPCScheduler(ctx context.Context, scheduler *rpcScheduler) context.Context {
+	return context.WithValue(ctx, rpcSchedulerKey{}, scheduler)
+}
+
+// applyRateLimitHeaders checks the headers of each HTTP response of a client built by ffresty, pausing the
+// scheduler of the request until the provider is ready for more requests
+func applyRateLimitHeaders(client *resty.Client, headers *rateLimitHeaders) {
+	if headers == nil {
+		return
+	}
+	client.OnAfterResponse(func(_ *resty.Client, res *resty.Response) error {
+		scheduler, ok := res.Request.Context().Value(rpcSchedulerKey{}).(*rpcScheduler)
+		if ok && scheduler != nil {
+			if until := headers.pauseUntil(res.Header(), time.Now()); !until.IsZero() {
+				log.L(res.Request.Context()).Warnf("Pausing JSON/RPC requests until %s, as requested by the provider (status=%d)", until.Format(time.RFC3339Nano), res.StatusCode())
+				scheduler.pause(until)
+			}
+		}
+		return nil
+	})
+}
+
+// pauseUntil returns the time requests should be paused until, or the zero time if they can continue. A Retry-After
+// header is always honored. Otherwise requests are paused once the remaining requests (or compute units) of the
+// provider are at the minimum, until the limit resets.
+func (h *rateLimitHeaders) pauseUntil(header http.Header, now time.Time) time.Time {
+	var until time.Time
+	if retryAfter := header.Get("Retry-After"); retryAfter != "" {
+		if secs, err := strconv.ParseFloat(retryAfter, 64); err == nil {
+			until = now.Add(time.Duration(secs * float64(time.Second)))
+		} else if t, err := http.ParseTime(retryAfter); err == nil {
+			until = t
+		}
+	} else if remaining, err := strconv.ParseFloat(header.Get(h.remaining), 64); err == nil && remaining <= h.minRemaining {
+		until = now.Add(defaultRateLimitPause)
+		if reset, err := strconv.ParseFloat(header.Get(h.reset), 64); err == nil {
+			if reset > 1e9 {
+				// A reset this large is a time in seconds since the epoch, rather than the seconds until the reset
+				until = time.Unix(0, int64(reset*float64(time.Second)))
+			} else {
+				until = now.Add(time.Duration(reset * float64(time.Second)))
+			}
+		}
+	}
+	if until.IsZero() || !until.After(now) {
+		return time.Time{}
+	}
+	if maxUntil := now.Add(h.maxPause); until.After(maxUntil) {
+		until = maxUntil
+	}
+	return until
+}
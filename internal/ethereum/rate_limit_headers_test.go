@@ -0,0 +1,109 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitHeadersPauseUntil(t *testing.T) {
+	h := &rateLimitHeaders{
+		remaining:    "X-RateLimit-Remaining",
+		reset:        "X-RateLimit-Reset",
+		minRemaining: 10,
+		maxPause:     time.Minute,
+	}
+	now := time.Unix(1700000000, 0)
+	header := func(kvs ...string) http.Header {
+		header := http.Header{}
+		for i := 0; i < len(kvs); i += 2 {
+			header.Set(kvs[i], kvs[i+1])
+		}
+		return header
+	}
+
+	assert.Equal(t, now.Add(1500*time.Millisecond), h.pauseUntil(header("Retry-After", "1.5"), now))
+	assert.True(t, now.Add(30*time.Second).Equal(h.pauseUntil(header("Retry-After", now.Add(30*time.Second).UTC().Format(http.TimeFormat)), now)))
+	assert.True(t, h.pauseUntil(header("Retry-After", "wrong"), now).IsZero())
+	assert.True(t, h.pauseUntil(header("Retry-After", "0"), now).IsZero())
+
+	// The reset of the rate limit window is in seconds from now, or seconds since the epoch
+	assert.Equal(t, now.Add(2*time.Second), h.pauseUntil(header("X-RateLimit-Remaining", "10", "X-RateLimit-Reset", "2"), now))
+	assert.True(t, now.Add(5*time.Second).Equal(h.pauseUntil(header("X-RateLimit-Remaining", "0", "X-RateLimit-Reset", strconv.FormatInt(now.Unix()+5, 10)), now)))
+	assert.Equal(t, now.Add(defaultRateLimitPause), h.pauseUntil(header("X-RateLimit-Remaining", "0"), now))
+	assert.True(t, h.pauseUntil(header("X-RateLimit-Remaining", "11", "X-RateLimit-Reset", "2"), now).IsZero())
+	assert.True(t, h.pauseUntil(header(), now).IsZero())
+
+	// The pause is capped
+	assert.Equal(t, now.Add(time.Minute), h.pauseUntil(header("Retry-After", "3600"), now))
+}
+
+func TestRPCSchedulerPause(t *testing.T) {
+	s := &rpcScheduler{}
+	until := time.Now().Add(50 * time.Millisecond)
+	s.pause(until)
+	s.pause(time.Now()) // an earlier pause does not shorten it
+
+	err := s.acquire(context.Background(), rpcPriorityNormal, "eth_blockNumber")
+	assert.NoError(t, err)
+	assert.False(t, time.Now().Before(until))
+	s.release()
+}
+
+func TestRateLimitHeadersSlowDown(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", "0.1")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x1"}`))
+	}))
+	defer server.Close()
+
+	config.RootConfigReset()
+	conf := config.RootSection("unittest")
+	InitConfig(conf)
+	conf.Set(ffresty.HTTPConfigURL, server.URL)
+	conf.Set(RateLimitHeadersEnabled, true)
+	opts, err := newRPCClientOptions(context.Background(), conf)
+	assert.NoError(t, err)
+	client, err := newRPCClient(context.Background(), conf, opts)
+	assert.NoError(t, err)
+	scheduler := newRPCScheduler(conf, opts)
+	assert.NotNil(t, scheduler)
+	mb := newManagedBackend(client, scheduler)
+
+	var result string
+	rpcErr := mb.CallRPC(context.Background(), &result, "eth_blockNumber")
+	assert.Nil(t, rpcErr)
+
+	// The next request waits for the rate limit window to reset
+	start := time.Now()
+	_, err = mb.SyncRequest(context.Background(), &rpcbackend.RPCRequest{ID: fftypes.JSONAnyPtr("1"), Method: "eth_blockNumber"})
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+}
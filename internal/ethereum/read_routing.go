@@ -0,0 +1,163 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+)
+
+type endpointStatus struct {
+	BlockNumber int64 `json:"blockNumber"`
+	Lag         int64 `json:"lag"`
+}
+
+// readBackend returns the backend to use for read-heavy queries, which is the
+// separately configured read endpoint if there is one and it is keeping up
+// with the chain head, or the primary backend
+func (c *ethConnector) readBackend() rpcbackend.RPC {
+	if c.readOnlyBackend == nil {
+		return c.backend
+	}
+	c.routingMux.Lock()
+	defer c.routingMux.Unlock()
+	if c.readBehind {
+		return c.backend
+	}
+	return c.readEndpointBackend()
+}
+
+// readEndpointBackend must be called holding the routing mutex, when the read endpoint is in use
+func (c *ethConnector) readEndpointBackend() rpcbackend.RPC {
+	if c.hedgedReadBackend != nil {
+		return c.hedgedReadBackend
+	}
+	return c.readOnlyBackend
+}
+
+// readBackendForTx returns the backend to use for queries that depend on a transaction
+// hash. Transactions recently submitted through this connector stick to the primary
+// for the configured window, as the read replica might not have seen them yet.
+// Note block queries made by the block listener always go to the primary.
+func (c *ethConnector) readBackendForTx(txHash string) rpcbackend.RPC {
+	if c.readOnlyBackend == nil {
+		return c.backend
+	}
+	c.routingMux.Lock()
+	defer c.routingMux.Unlock()
+	if c.readBehind {
+		return c.backend
+	}
+	if expiry, ok := c.stickyTXs[strings.ToLower(txHash)]; ok {
+		if time.Now().Before(expiry) {
+			return c.backend
+		}
+		delete(c.stickyTXs, strings.ToLower(txHash))
+	}
+	return c.readEndpointBackend()
+}
+
+// recordStickyTx is called after a successful submission to the primary
+func (c *ethConnector) recordStickyTx(txHash string) {
+	if c.readOnlyBackend == nil || c.stickyWindow <= 0 {
+		return
+	}
+	c.routingMux.Lock()
+	defer c.routingMux.Unlock()
+	now := time.Now()
+	for h, expiry := range c.stickyTXs {
+		if now.After(expiry) {
+			delete(c.stickyTXs, h)
+		}
+	}
+	c.stickyTXs[strings.ToLower(txHash)] = now.Add(c.stickyWindow)
+}
+
+func (c *ethConnector) readLagMonitorLoop(ctx context.Context) {
+	defer close(c.readLagMonitorDone)
+	ticker := time.NewTicker(c.readLagCheckInterval)
+	defer ticker.Stop()
+	for {
+		c.checkReadEndpointLag(ctx)
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			log.L(ctx).Debugf("Read endpoint lag monitor exiting")
+			return
+		}
+	}
+}
+
+// checkReadEndpointLag compares the block height of the read endpoint with the primary,
+// and stops routing queries to the read endpoint while it is too far behind (or failing)
+func (c *ethConnector) checkReadEndpointLag(ctx context.Context) {
+	var primaryHead, readHead ethtypes.HexInteger
+	if rpcErr := c.backend.CallRPC(ctx, &primaryHead, "eth_blockNumber"); rpcErr != nil {
+		log.L(ctx).Warnf("Failed to query block height of primary endpoint: %s", rpcErr.Message)
+		return
+	}
+	readErr := c.readOnlyBackend.CallRPC(ctx, &readHead, "eth_blockNumber")
+
+	c.routingMux.Lock()
+	defer c.routingMux.Unlock()
+	bestHead := primaryHead.BigInt().Int64()
+	if readErr == nil && readHead.BigInt().Int64() > bestHead {
+		bestHead = readHead.BigInt().Int64()
+	}
+	c.primaryStatus = endpointStatus{BlockNumber: primaryHead.BigInt().Int64(), Lag: bestHead - primaryHead.BigInt().Int64()}
+	wasBehind := c.readBehind
+	if readErr != nil {
+		log.L(ctx).Warnf("Failed to query block height of read endpoint: %s", readErr.Message)
+		c.readBehind = true
+	} else {
+		c.readStatus = endpointStatus{BlockNumber: readHead.BigInt().Int64(), Lag: bestHead - readHead.BigInt().Int64()}
+		c.readBehind = c.readStatus.Lag > c.readMaxLagBlocks
+	}
+	if c.readBehind != wasBehind {
+		details := fftypes.JSONObject{"lag": c.readStatus.Lag, "maxLag": c.readMaxLagBlocks}
+		if c.readBehind {
+			log.L(ctx).Warnf("Read endpoint unavailable or behind (lag=%d blocks, max=%d) - routing queries to primary", c.readStatus.Lag, c.readMaxLagBlocks)
+			if readErr != nil {
+				details["error"] = readErr.Message
+			}
+			c.notifier.notify(ctx, NotificationReadEndpointFailover, details, "Read endpoint unavailable or behind - routing queries to primary")
+		} else {
+			log.L(ctx).Infof("Read endpoint caught up (lag=%d blocks) - routing queries to read endpoint", c.readStatus.Lag)
+			c.notifier.notify(ctx, NotificationReadEndpointRestored, details, "Read endpoint caught up - routing queries to read endpoint")
+		}
+	}
+}
+
+// readEndpointDetails returns the per-endpoint block height and lag, for the readiness details
+func (c *ethConnector) readEndpointDetails() fftypes.JSONObject {
+	c.routingMux.Lock()
+	defer c.routingMux.Unlock()
+	return fftypes.JSONObject{
+		"primary": c.primaryStatus,
+		"read": fftypes.JSONObject{
+			"blockNumber": c.readStatus.BlockNumber,
+			"lag":         c.readStatus.Lag,
+			"inUse":       !c.readBehind,
+		},
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-evmconnect/mocks/rpcbackendmocks"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReadBackendDefaultsToPrimary(t *testing.T) {
+	_, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	assert.Equal(t, mRPC, c.readBackend())
+	assert.Equal(t, mRPC, c.readBackendForTx("0x1234"))
+	c.recordStickyTx("0x1234")
+	assert.Empty(t, c.stickyTXs)
+}
+
+func TestReceiptAfterSendSticksToPrimary(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+	mReadRPC := &rpcbackendmocks.Backend{}
+	c.readOnlyBackend = mReadRPC
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_sendTransaction", mock.Anything).
+		Run(func(args mock.Arguments) {
+			*(args[1].(*ethtypes.HexBytes0xPrefix)) = ethtypes.MustNewHexBytes0xPrefix("0x7D48AE971FAF089878B57E3C28E3035540D34F38AF395958D2C73C36C57C83A2")
+		}).
+		Return(nil)
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionReceipt", "0x7d48ae971faf089878b57e3c28e3035540d34f38af395958d2c73c36c57c83a2").
+		Run(func(args mock.Arguments) {
+			err := json.Unmarshal([]byte(sampleJSONRPCReceipt), args[1])
+			assert.NoError(t, err)
+		}).
+		Return(nil).Once()
+
+	var sendReq ffcapi.TransactionSendRequest
+	err := json.Unmarshal([]byte(sampleSendTX), &sendReq)
+	assert.NoError(t, err)
+	_, _, err = c.TransactionSend(ctx, &sendReq)
+	assert.NoError(t, err)
+
+	var req ffcapi.TransactionReceiptRequest
+	err = json.Unmarshal([]byte(sampleGetReceipt), &req)
+	assert.NoError(t, err)
+	res, _, err := c.TransactionReceipt(ctx, &req)
+	assert.NoError(t, err)
+	assert.True(t, res.Success)
+
+	mReadRPC.AssertExpectations(t)
+}
+
+func TestStickyTxExpires(t *testing.T) {
+	_, c, mRPC, done := newTestConnector(t)
+	defer done()
+	mReadRPC := &rpcbackendmocks.Backend{}
+	c.readOnlyBackend = mReadRPC
+
+	c.stickyWindow = 1 * time.Hour
+	c.recordStickyTx("0xAAAA")
+	assert.Equal(t, mRPC, c.readBackendForTx("0xaaaa"))
+
+	c.stickyTXs["0xaaaa"] = time.Now().Add(-1 * time.Second)
+	assert.Equal(t, mReadRPC, c.readBackendForTx("0xaaaa"))
+	assert.Empty(t, c.stickyTXs)
+
+	// Expired entries are pruned on the next submission
+	c.stickyTXs["0xbbbb"] = time.Now().Add(-1 * time.Second)
+	c.recordStickyTx("0xcccc")
+	assert.Len(t, c.stickyTXs, 1)
+
+	c.stickyWindow = 0
+	c.recordStickyTx("0xdddd")
+	assert.Len(t, c.stickyTXs, 1)
+}
+
+func mockBlockNumber(m *rpcbackendmocks.Backend, blockNumber int64) *mock.Call {
+	return m.On("CallRPC", mock.Anything, mock.Anything, "eth_blockNumber").
+		Run(func(args mock.Arguments) {
+			*(args[1].(*ethtypes.HexInteger)) = *ethtypes.NewHexInteger64(blockNumber)
+		}).
+		Return(nil)
+}
+
+func TestReadEndpointLagRouting(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+	mReadRPC := &rpcbackendmocks.Backend{}
+	c.readOnlyBackend = mReadRPC
+	c.readMaxLagBlocks = 10
+
+	mockBlockNumber(mRPC, 100).Once()
+	mockBlockNumber(mReadRPC, 95).Once()
+	c.checkReadEndpointLag(ctx)
+	assert.Equal(t, mReadRPC, c.readBackend())
+
+	mockBlockNumber(mRPC, 100).Once()
+	mockBlockNumber(mReadRPC, 89).Once()
+	c.checkReadEndpointLag(ctx)
+	assert.Equal(t, mRPC, c.readBackend())
+	assert.Equal(t, mRPC, c.readBackendForTx("0xaaaa"))
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "net_version").Return(nil).Run(func(args mock.Arguments) {
+		*(args[1].(*string)) = "12345"
+	})
+	mockNodeInfoUnsupported(mRPC)
+	res, _, err := c.IsReady(ctx)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"chainID": "12345",
+		"node": {},
+		"endpoints": {
+			"primary": {"blockNumber": 100, "lag": 0},
+			"read": {"blockNumber": 89, "lag": 11, "inUse": false}
+		}
+	}`, res.DownstreamDetails.String())
+
+	// Read endpoint ahead of the primary
+	mockBlockNumber(mRPC, 100).Once()
+	mockBlockNumber(mReadRPC, 102).Once()
+	c.checkReadEndpointLag(ctx)
+	assert.Equal(t, mReadRPC, c.readBackend())
+	assert.Equal(t, int64(2), c.primaryStatus.Lag)
+
+	// Read endpoint failing
+	mockBlockNumber(mRPC, 100).Once()
+	mReadRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_blockNumber").Return(&rpcbackend.RPCError{Message: "pop"}).Once()
+	c.checkReadEndpointLag(ctx)
+	assert.Equal(t, mRPC, c.readBackend())
+
+	// Primary failing leaves the routing unchanged
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_blockNumber").Return(&rpcbackend.RPCError{Message: "pop"}).Once()
+	c.checkReadEndpointLag(ctx)
+	assert.Equal(t, mRPC, c.readBackend())
+
+	mReadRPC.AssertExpectations(t)
+}
+
+func TestReadLagMonitorLoop(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	mReadRPC := &rpcbackendmocks.Backend{}
+	c.readOnlyBackend = mReadRPC
+	c.readLagCheckInterval = 1 * time.Millisecond
+	c.readLagMonitorDone = make(chan struct{})
+
+	checked := make(chan struct{})
+	mockBlockNumber(mRPC, 100)
+	mockBlockNumber(mReadRPC, 100).Once().Run(func(args mock.Arguments) {
+		*(args[1].(*ethtypes.HexInteger)) = *ethtypes.NewHexInteger64(100)
+		close(checked)
+	})
+	mockBlockNumber(mReadRPC, 100).Maybe()
+
+	go c.readLagMonitorLoop(ctx)
+	<-checked
+	done()
+}
+
+func TestEventStreamLogQueriesUsePrimary(t *testing.T) {
+	l, mRPC, cancel := newTestListener(t, false)
+	defer cancel()
+	mReadRPC := &rpcbackendmocks.Backend{}
+	l.c.readOnlyBackend = mReadRPC
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getLogs", mock.Anything).Return(nil)
+
+	ag := l.es.buildAggregatedListener([]*listener{l})
+	events, err := l.es.getBlockRangeEvents(l.es.ctx, ag, 1000, 1099)
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+	mReadRPC.AssertNotCalled(t, "CallRPC", mock.Anything, mock.Anything, "eth_getLogs", mock.Anything)
+}
@@ -0,0 +1,86 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+)
+
+// txConfirmationsCacheSize bounds the submissions we remember the required confirmations of. A transaction that is
+// aged out, or was submitted before a restart, falls back to the confirmations required by the transaction manager.
+const txConfirmationsCacheSize = 10000
+
+// ReceiptConfirmations is the progress of a transaction towards the confirmations required by its submission
+type ReceiptConfirmations struct {
+	Required      int64 `json:"required"`
+	Confirmations int64 `json:"confirmations"`
+}
+
+// txConfirmations returns the confirmations required by a submission, from the confirmations of the gas price
+// object. In the same way as a feeCurrency, this is set by the policy engine of the transaction manager, or is
+// passed through by it from the gas price of the transaction request.
+func (c *ethConnector) txConfirmations(ctx context.Context, gasPrice *fftypes.JSONAny) (int64, error) {
+	if gasPrice == nil {
+		return 0, nil
+	}
+	value, ok := gasPrice.JSONObjectNowarn()["confirmations"]
+	if !ok || value == nil {
+		return 0, nil
+	}
+	// A number, or a string as the transaction manager passes large numbers through
+	s := fmt.Sprint(value)
+	confirmations, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || confirmations < 0 || confirmations > c.maxTxConfirmations {
+		return 0, i18n.NewError(ctx, msgs.MsgInvalidTxConfirmations, s, c.maxTxConfirmations)
+	}
+	return confirmations, nil
+}
+
+// recordTxConfirmations is called after a successful submission that requires its own confirmations
+func (c *ethConnector) recordTxConfirmations(txHash string, confirmations int64) {
+	if confirmations > 0 {
+		c.txConfs.Add(strings.ToLower(txHash), confirmations)
+	}
+}
+
+// checkReceiptConfirmations holds back the receipt of a transaction that requires its own confirmations, by
+// reporting it as not found until enough blocks have been built on the block it was mined in. The transaction
+// manager keeps polling for the receipt, and then applies its own confirmations on top.
+func (c *ethConnector) checkReceiptConfirmations(ctx context.Context, txHash string, receipt *txReceiptJSONRPC) (*ReceiptConfirmations, ffcapi.ErrorReason, error) {
+	cached, ok := c.txConfs.Get(strings.ToLower(txHash))
+	if !ok || receipt.BlockNumber == nil {
+		return nil, "", nil
+	}
+	required := cached.(int64)
+	highestBlock, ok := c.blockListener.getHighestBlock(ctx)
+	if !ok {
+		return nil, ffcapi.ErrorReasonNotFound, i18n.NewError(ctx, msgs.MsgReceiptAwaitingConfirmations, txHash, 0, required)
+	}
+	confirmations := max(highestBlock-receipt.BlockNumber.BigInt().Int64(), 0)
+	if confirmations < required {
+		return nil, ffcapi.ErrorReasonNotFound, i18n.NewError(ctx, msgs.MsgReceiptAwaitingConfirmations, txHash, confirmations, required)
+	}
+	return &ReceiptConfirmations{Required: required, Confirmations: confirmations}, "", nil
+}
@@ -0,0 +1,146 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const sampleReceiptBlock = 0x7b9 // the block of sampleJSONRPCReceipt
+
+func TestTxConfirmations(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t)
+	defer done()
+
+	for gasPrice, expected := range map[string]int64{
+		`"12345"`:               0,
+		`{"gasPrice": "12345"}`: 0,
+		`{"gasPrice": "12345", "confirmations": null}`:                                 0,
+		`{"gasPrice": "12345", "confirmations": 30}`:                                   30,
+		`{"gasPrice": "12345", "confirmations": "1"}`:                                  1,
+		`{"maxFeePerGas": "12345", "maxPriorityFeePerGas": "1", "confirmations": 100}`: 100,
+	} {
+		confirmations, err := c.txConfirmations(ctx, fftypes.JSONAnyPtr(gasPrice))
+		assert.NoError(t, err, gasPrice)
+		assert.Equal(t, expected, confirmations, gasPrice)
+	}
+	confirmations, err := c.txConfirmations(ctx, nil)
+	assert.NoError(t, err)
+	assert.Zero(t, confirmations)
+
+	for _, invalid := range []string{`-1`, `101`, `1.5`, `"lots"`, `{}`} {
+		_, err := c.txConfirmations(ctx, fftypes.JSONAnyPtr(`{"gasPrice": "12345", "confirmations": `+invalid+`}`))
+		assert.Regexp(t, "FF23183", err, invalid)
+	}
+}
+
+func TestSendTransactionRequiredConfirmations(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	txHash := ethtypes.MustNewHexBytes0xPrefix("0x7d48ae971faf089878b57e3c28e3035540d34f38af395958d2c73c36c57c83a2")
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_sendTransaction", mock.Anything).
+		Run(func(args mock.Arguments) {
+			*(args[1].(*ethtypes.HexBytes0xPrefix)) = txHash
+		}).
+		Return(nil)
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionReceipt", mock.Anything).
+		Run(func(args mock.Arguments) {
+			err := json.Unmarshal([]byte(sampleJSONRPCReceipt), args[1])
+			assert.NoError(t, err)
+		}).
+		Return(nil)
+	mockBlockNumber(mRPC, sampleReceiptBlock+1).Maybe()
+
+	var sendReq ffcapi.TransactionSendRequest
+	err := json.Unmarshal([]byte(sampleSendTX), &sendReq)
+	assert.NoError(t, err)
+	sendReq.GasPrice = fftypes.JSONAnyPtr(`{"gasPrice": "100", "confirmations": 3}`)
+	_, _, err = c.TransactionSend(ctx, &sendReq)
+	assert.NoError(t, err)
+
+	var req ffcapi.TransactionReceiptRequest
+	err = json.Unmarshal([]byte(sampleGetReceipt), &req)
+	assert.NoError(t, err)
+	_, ok := c.blockListener.getHighestBlock(ctx)
+	assert.True(t, ok)
+	setHighestBlock := func(blockNumber int64) {
+		c.blockListener.mux.Lock()
+		c.blockListener.highestBlock = blockNumber
+		c.blockListener.mux.Unlock()
+	}
+
+	// The receipt is held back until the block it is in has three blocks on top of it
+	setHighestBlock(sampleReceiptBlock + 2)
+	_, reason, err := c.TransactionReceipt(ctx, &req)
+	assert.Regexp(t, "FF23184.*2 of the 3", err)
+	assert.Equal(t, ffcapi.ErrorReasonNotFound, reason)
+
+	setHighestBlock(sampleReceiptBlock + 3)
+	res, _, err := c.TransactionReceipt(ctx, &req)
+	assert.NoError(t, err)
+	var extraInfo receiptExtraInfo
+	err = json.Unmarshal(res.ExtraInfo.Bytes(), &extraInfo)
+	assert.NoError(t, err)
+	assert.Equal(t, &ReceiptConfirmations{Required: 3, Confirmations: 3}, extraInfo.Confirmations)
+
+	// Other transactions are returned straight away, with the confirmations left to the transaction manager
+	c.txConfs.Purge()
+	setHighestBlock(sampleReceiptBlock)
+	res, _, err = c.TransactionReceipt(ctx, &req)
+	assert.NoError(t, err)
+	assert.NotContains(t, res.ExtraInfo.String(), "confirmations")
+}
+
+func TestSendTransactionInvalidConfirmations(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t)
+	defer done()
+
+	var req ffcapi.TransactionSendRequest
+	err := json.Unmarshal([]byte(sampleSendTX), &req)
+	assert.NoError(t, err)
+	req.GasPrice = fftypes.JSONAnyPtr(`{"gasPrice": "100", "confirmations": 1000}`)
+	_, reason, err := c.TransactionSend(ctx, &req)
+	assert.Regexp(t, "FF23183", err)
+	assert.Equal(t, ffcapi.ErrorReasonInvalidInputs, reason)
+}
+
+func TestReceiptConfirmationsNoBlockHeight(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+	mockBlockNumber(mRPC, 1).Maybe()
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+
+	c.recordTxConfirmations("0xAAAA", 1)
+	_, reason, err := c.checkReceiptConfirmations(cancelled, "0xaaaa", &txReceiptJSONRPC{BlockNumber: ethtypes.NewHexInteger64(1)})
+	assert.Regexp(t, "FF23184.*0 of the 1", err)
+	assert.Equal(t, ffcapi.ErrorReasonNotFound, reason)
+
+	// Pending receipts without a block number are not held back
+	confirmations, _, err := c.checkReceiptConfirmations(ctx, "0xaaaa", &txReceiptJSONRPC{})
+	assert.NoError(t, err)
+	assert.Nil(t, confirmations)
+}
@@ -0,0 +1,185 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rlp"
+)
+
+type ReceiptProofRequest struct {
+	TransactionHash string `json:"transactionHash"`
+	LogIndex        string `json:"logIndex,omitempty"`
+}
+
+// ReceiptProofResponse is a Merkle Patricia inclusion proof of a receipt in the receipts root of its block.
+// The proof nodes are in order from the root, and the key is the RLP encoded transaction index.
+type ReceiptProofResponse struct {
+	BlockNumber      *fftypes.FFBigInt           `json:"blockNumber"`
+	BlockHash        ethtypes.HexBytes0xPrefix   `json:"blockHash"`
+	ReceiptsRoot     ethtypes.HexBytes0xPrefix   `json:"receiptsRoot"`
+	TransactionIndex *fftypes.FFBigInt           `json:"transactionIndex"`
+	Key              ethtypes.HexBytes0xPrefix   `json:"key"`
+	Receipt          ethtypes.HexBytes0xPrefix   `json:"receipt"`
+	Proof            []ethtypes.HexBytes0xPrefix `json:"proof"`
+	LogPosition      *int                        `json:"logPosition,omitempty"`
+}
+
+// encodeReceipt gives the consensus encoding of a receipt, as stored in the receipts trie. Receipts before
+// Byzantium have a post-transaction state root in place of the status, and typed (EIP-2718) receipts are
+// prefixed with their type. The receipts of OP deposit transactions have additional fields.
+func encodeReceipt(r *txReceiptJSONRPC) []byte {
+	logs := make(rlp.List, len(r.Logs))
+	for i, l := range r.Logs {
+		topics := make(rlp.List, len(l.Topics))
+		for j, t := range l.Topics {
+			topics[j] = rlp.Data(t)
+		}
+		logs[i] = rlp.List{rlp.WrapAddress(l.Address), topics, rlp.Data(l.Data)}
+	}
+	var statusOrRoot rlp.Data
+	if r.Root != nil {
+		statusOrRoot = rlp.Data(r.Root)
+	} else {
+		statusOrRoot = rlp.WrapInt(r.Status.BigInt())
+	}
+	fields := rlp.List{
+		statusOrRoot,
+		rlp.WrapInt(r.CumulativeGasUsed.BigInt()),
+		rlp.Data(r.LogsBloom),
+		logs,
+	}
+	fields = appendDepositReceiptFields(fields, r)
+	encoded := fields.Encode()
+	if txType := r.Type.BigInt().Int64(); txType > 0 {
+		encoded = append([]byte{byte(txType)}, encoded...)
+	}
+	return encoded
+}
+
+// getBlockReceipts gets all the receipts of a block with eth_getBlockReceipts, falling back to requesting
+// each receipt in turn for nodes that do not support it
+func (c *ethConnector) getBlockReceipts(ctx context.Context, header *BlockHeader) ([]*txReceiptJSONRPC, error) {
+	var receipts []*txReceiptJSONRPC
+	rpcErr := c.readBackend().CallRPC(ctx, &receipts, "eth_getBlockReceipts", header.Hash)
+	if rpcErr == nil && len(receipts) == len(header.Transactions) {
+		return receipts, nil
+	}
+	if rpcErr != nil {
+		log.L(ctx).Debugf("eth_getBlockReceipts failed, fetching %d receipts individually: %s", len(header.Transactions), rpcErr.Message)
+	}
+	receipts = make([]*txReceiptJSONRPC, len(header.Transactions))
+	for i, txHash := range header.Transactions {
+		if rpcErr := c.readBackend().CallRPC(ctx, &receipts[i], "eth_getTransactionReceipt", txHash); rpcErr != nil {
+			return nil, rpcErr.Error()
+		}
+		if receipts[i] == nil {
+			return nil, i18n.NewError(ctx, msgs.MsgReceiptNotAvailable, txHash)
+		}
+	}
+	return receipts, nil
+}
+
+// ReceiptProof builds an inclusion proof of the receipt of a transaction against the receipts root of its block,
+// by building the receipts trie from all the receipts of the block. The computed root is checked against the
+// block header, so chains with a non-standard receipt encoding return an error rather than an invalid proof.
+// If a log index is supplied, the position of the log within the receipt is also returned.
+func (c *ethConnector) ReceiptProof(ctx context.Context, req *ReceiptProofRequest) (*ReceiptProofResponse, error) {
+	txHash, err := ethtypes.NewHexBytes0xPrefix(req.TransactionHash)
+	if err != nil || len(txHash) != 32 {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidTransactionHash, req.TransactionHash)
+	}
+	var logIndex *big.Int
+	if req.LogIndex != "" {
+		var ok bool
+		if logIndex, ok = new(big.Int).SetString(req.LogIndex, 0); !ok {
+			return nil, i18n.NewError(ctx, msgs.MsgInvalidLogIndex, req.LogIndex)
+		}
+	}
+
+	var receipt *txReceiptJSONRPC
+	if rpcErr := c.readBackendForTx(txHash.String()).CallRPC(ctx, &receipt, "eth_getTransactionReceipt", txHash); rpcErr != nil {
+		return nil, rpcErr.Error()
+	}
+	if receipt == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgReceiptNotAvailable, txHash)
+	}
+
+	var header *BlockHeader
+	if rpcErr := c.readBackend().CallRPC(ctx, &header, "eth_getBlockByHash", receipt.BlockHash, false); rpcErr != nil {
+		return nil, rpcErr.Error()
+	}
+	if header == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgBlockNotFound, receipt.BlockHash)
+	}
+	receipts, err := c.getBlockReceipts(ctx, header)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([][]byte, len(receipts))
+	values := make([][]byte, len(receipts))
+	position := -1
+	for i, r := range receipts {
+		keys[i] = rlp.WrapInt(r.TransactionIndex.BigInt()).Encode()
+		values[i] = encodeReceipt(r)
+		if bytes.Equal(r.TransactionHash, txHash) {
+			position = i
+		}
+	}
+	trie := newMerkleTrie(keys, values)
+	if root := trie.rootHash(); !bytes.Equal(root, header.ReceiptsRoot) {
+		return nil, i18n.NewError(ctx, msgs.MsgReceiptsRootMismatch, ethtypes.HexBytes0xPrefix(root), header.ReceiptsRoot, header.Hash)
+	}
+	if position < 0 {
+		return nil, i18n.NewError(ctx, msgs.MsgReceiptNotAvailable, txHash)
+	}
+
+	proof, _ := trie.proof(keys[position]) // every key of the trie has a proof
+	res := &ReceiptProofResponse{
+		BlockNumber:      (*fftypes.FFBigInt)(header.Number),
+		BlockHash:        header.Hash,
+		ReceiptsRoot:     header.ReceiptsRoot,
+		TransactionIndex: (*fftypes.FFBigInt)(receipts[position].TransactionIndex),
+		Key:              keys[position],
+		Receipt:          values[position],
+		Proof:            make([]ethtypes.HexBytes0xPrefix, len(proof)),
+	}
+	for i, p := range proof {
+		res.Proof[i] = p
+	}
+	if logIndex != nil {
+		for i, l := range receipts[position].Logs {
+			if l.LogIndex.BigInt().Cmp(logIndex) == 0 {
+				pos := i
+				res.LogPosition = &pos
+			}
+		}
+		if res.LogPosition == nil {
+			return nil, i18n.NewError(ctx, msgs.MsgLogNotInReceipt, req.LogIndex, txHash)
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,327 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hyperledger/firefly-evmconnect/mocks/rpcbackendmocks"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rlp"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testProofBlockHash = "0x6197ef1a58a2a592bb447efb651f0db7945de21aa8048801b250bd7b7431f9b6"
+
+func testProofTxHash(i int) ethtypes.HexBytes0xPrefix {
+	return ethtypes.MustNewHexBytes0xPrefix(fmt.Sprintf("0x%064x", 0xfeed0000+i))
+}
+
+func testBlockReceipts(n int) []*txReceiptJSONRPC {
+	receipts := make([]*txReceiptJSONRPC, n)
+	for i := range receipts {
+		receipts[i] = &txReceiptJSONRPC{
+			BlockHash:         ethtypes.MustNewHexBytes0xPrefix(testProofBlockHash),
+			BlockNumber:       ethtypes.NewHexInteger64(1977),
+			TransactionHash:   testProofTxHash(i),
+			TransactionIndex:  ethtypes.NewHexInteger64(int64(i)),
+			CumulativeGasUsed: ethtypes.NewHexInteger64(int64(21000 * (i + 1))),
+			Status:            ethtypes.NewHexInteger64(1),
+			Type:              ethtypes.NewHexInteger64(int64(i % 3)),
+			LogsBloom:         make([]byte, 256),
+			Logs: []*logJSONRPC{
+				{
+					LogIndex: ethtypes.NewHexInteger64(int64(i * 2)),
+					Address:  ethtypes.MustNewAddress("0x302259069aaa5b10dc6f29a9a3f72a8e52837cc3"),
+					Topics: []ethtypes.HexBytes0xPrefix{
+						ethtypes.MustNewHexBytes0xPrefix("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"),
+					},
+					Data: ethtypes.MustNewHexBytes0xPrefix("0x000000000000000000000000000000000000000000000000016345785d8a0000"),
+				},
+				{
+					LogIndex: ethtypes.NewHexInteger64(int64(i*2 + 1)),
+					Address:  ethtypes.MustNewAddress("0x302259069aaa5b10dc6f29a9a3f72a8e52837cc3"),
+				},
+			},
+		}
+	}
+	return receipts
+}
+
+func testReceiptsRoot(receipts []*txReceiptJSONRPC) ethtypes.HexBytes0xPrefix {
+	keys := make([][]byte, len(receipts))
+	values := make([][]byte, len(receipts))
+	for i, r := range receipts {
+		keys[i] = rlp.WrapInt(r.TransactionIndex.BigInt()).Encode()
+		values[i] = encodeReceipt(r)
+	}
+	return newMerkleTrie(keys, values).rootHash()
+}
+
+func mockReceiptProofBlock(mRPC *rpcbackendmocks.Backend, receipts []*txReceiptJSONRPC, receiptsRoot ethtypes.HexBytes0xPrefix, target *txReceiptJSONRPC) {
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionReceipt", target.TransactionHash).
+		Run(func(args mock.Arguments) {
+			*(args[1].(**txReceiptJSONRPC)) = target
+		}).
+		Return(nil).Once()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByHash", ethtypes.MustNewHexBytes0xPrefix(testProofBlockHash), false).
+		Run(func(args mock.Arguments) {
+			header := &BlockHeader{
+				Number:       ethtypes.NewHexInteger64(1977),
+				Hash:         ethtypes.MustNewHexBytes0xPrefix(testProofBlockHash),
+				ReceiptsRoot: receiptsRoot,
+			}
+			for _, r := range receipts {
+				header.Transactions = append(header.Transactions, r.TransactionHash)
+			}
+			*(args[1].(**BlockHeader)) = header
+		}).
+		Return(nil)
+}
+
+func TestEncodeReceipt(t *testing.T) {
+	receipts := testBlockReceipts(3)
+
+	legacy := encodeReceipt(receipts[0])
+	decoded, _, err := rlp.Decode(legacy)
+	assert.NoError(t, err)
+	l := decoded.(rlp.List)
+	assert.Len(t, l, 4)
+	assert.Equal(t, int64(1), l[0].ToData().Int().Int64())
+	assert.Equal(t, int64(21000), l[1].ToData().Int().Int64())
+	assert.Len(t, l[2].ToData(), 256)
+	assert.Len(t, l[3].(rlp.List), 2)
+
+	typed := encodeReceipt(receipts[2])
+	assert.Equal(t, byte(2), typed[0])
+	_, _, err = rlp.Decode(typed[1:])
+	assert.NoError(t, err)
+
+	receipts[0].Root = ethtypes.MustNewHexBytes0xPrefix(testProofBlockHash)
+	decoded, _, err = rlp.Decode(encodeReceipt(receipts[0]))
+	assert.NoError(t, err)
+	assert.Equal(t, testProofBlockHash, ethtypes.HexBytes0xPrefix(decoded.(rlp.List)[0].ToData()).String())
+}
+
+func TestReceiptProofOK(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	receipts := testBlockReceipts(200)
+	root := testReceiptsRoot(receipts)
+	mockReceiptProofBlock(mRPC, receipts, root, receipts[130])
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockReceipts", ethtypes.MustNewHexBytes0xPrefix(testProofBlockHash)).
+		Run(func(args mock.Arguments) {
+			*(args[1].(*[]*txReceiptJSONRPC)) = receipts
+		}).
+		Return(nil)
+
+	res, err := c.ReceiptProof(ctx, &ReceiptProofRequest{
+		TransactionHash: testProofTxHash(130).String(),
+		LogIndex:        "261",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, root, res.ReceiptsRoot)
+	assert.Equal(t, int64(130), res.TransactionIndex.Int64())
+	assert.Equal(t, 1, *res.LogPosition)
+	assert.Equal(t, encodeReceipt(receipts[130]), []byte(res.Receipt))
+
+	proof := make([][]byte, len(res.Proof))
+	for i, p := range res.Proof {
+		proof[i] = p
+	}
+	assert.Equal(t, []byte(res.Receipt), verifyTrieProof(t, res.ReceiptsRoot, res.Key, proof))
+}
+
+func TestReceiptProofFallbackToTransactionReceipts(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	receipts := testBlockReceipts(3)
+	mockReceiptProofBlock(mRPC, receipts, testReceiptsRoot(receipts), receipts[1])
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockReceipts", mock.Anything).
+		Return(&rpcbackend.RPCError{Message: "the method eth_getBlockReceipts does not exist/is not available"})
+	for i := range receipts {
+		r := receipts[i]
+		mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionReceipt", r.TransactionHash).
+			Run(func(args mock.Arguments) {
+				*(args[1].(**txReceiptJSONRPC)) = r
+			}).
+			Return(nil).Once()
+	}
+
+	res, err := c.ReceiptProof(ctx, &ReceiptProofRequest{TransactionHash: testProofTxHash(1).String()})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), res.TransactionIndex.Int64())
+	assert.Nil(t, res.LogPosition)
+}
+
+func TestReceiptProofFallbackFail(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	receipts := testBlockReceipts(3)
+	mockReceiptProofBlock(mRPC, receipts, testReceiptsRoot(receipts), receipts[1])
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockReceipts", mock.Anything).
+		Return(&rpcbackend.RPCError{Message: "pop"})
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionReceipt", testProofTxHash(0)).
+		Return(&rpcbackend.RPCError{Message: "pop"})
+
+	_, err := c.ReceiptProof(ctx, &ReceiptProofRequest{TransactionHash: testProofTxHash(1).String()})
+	assert.Regexp(t, "pop", err)
+}
+
+func TestReceiptProofFallbackMissingReceipt(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	receipts := testBlockReceipts(3)
+	mockReceiptProofBlock(mRPC, receipts, testReceiptsRoot(receipts), receipts[1])
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockReceipts", mock.Anything).
+		Return(nil)
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionReceipt", testProofTxHash(0)).
+		Return(nil)
+
+	_, err := c.ReceiptProof(ctx, &ReceiptProofRequest{TransactionHash: testProofTxHash(1).String()})
+	assert.Regexp(t, "FF23012", err)
+}
+
+func TestReceiptProofRootMismatch(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	receipts := testBlockReceipts(3)
+	mockReceiptProofBlock(mRPC, receipts, ethtypes.MustNewHexBytes0xPrefix(testProofBlockHash), receipts[1])
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockReceipts", mock.Anything).
+		Run(func(args mock.Arguments) {
+			*(args[1].(*[]*txReceiptJSONRPC)) = receipts
+		}).
+		Return(nil)
+
+	_, err := c.ReceiptProof(ctx, &ReceiptProofRequest{TransactionHash: testProofTxHash(1).String()})
+	assert.Regexp(t, "FF23091", err)
+}
+
+func TestReceiptProofNotInBlock(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	receipts := testBlockReceipts(3)
+	mockReceiptProofBlock(mRPC, receipts[:2], testReceiptsRoot(receipts[:2]), receipts[2])
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockReceipts", mock.Anything).
+		Run(func(args mock.Arguments) {
+			*(args[1].(*[]*txReceiptJSONRPC)) = receipts[:2]
+		}).
+		Return(nil)
+
+	_, err := c.ReceiptProof(ctx, &ReceiptProofRequest{TransactionHash: testProofTxHash(2).String()})
+	assert.Regexp(t, "FF23012", err)
+}
+
+func TestReceiptProofLogNotInReceipt(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	receipts := testBlockReceipts(3)
+	mockReceiptProofBlock(mRPC, receipts, testReceiptsRoot(receipts), receipts[1])
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockReceipts", mock.Anything).
+		Run(func(args mock.Arguments) {
+			*(args[1].(*[]*txReceiptJSONRPC)) = receipts
+		}).
+		Return(nil)
+
+	_, err := c.ReceiptProof(ctx, &ReceiptProofRequest{TransactionHash: testProofTxHash(1).String(), LogIndex: "0x0"})
+	assert.Regexp(t, "FF23092", err)
+}
+
+func TestReceiptProofBadInputs(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t)
+	defer done()
+
+	_, err := c.ReceiptProof(ctx, &ReceiptProofRequest{TransactionHash: "0x1234"})
+	assert.Regexp(t, "FF23089", err)
+
+	_, err = c.ReceiptProof(ctx, &ReceiptProofRequest{TransactionHash: testProofTxHash(1).String(), LogIndex: "wrong"})
+	assert.Regexp(t, "FF23090", err)
+}
+
+func TestReceiptProofReceiptFail(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionReceipt", mock.Anything).
+		Return(&rpcbackend.RPCError{Message: "pop"}).Once()
+	_, err := c.ReceiptProof(ctx, &ReceiptProofRequest{TransactionHash: testProofTxHash(1).String()})
+	assert.Regexp(t, "pop", err)
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionReceipt", mock.Anything).
+		Return(nil).Once()
+	_, err = c.ReceiptProof(ctx, &ReceiptProofRequest{TransactionHash: testProofTxHash(1).String()})
+	assert.Regexp(t, "FF23012", err)
+}
+
+func TestReceiptProofBlockFail(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	receipts := testBlockReceipts(1)
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionReceipt", mock.Anything).
+		Run(func(args mock.Arguments) {
+			*(args[1].(**txReceiptJSONRPC)) = receipts[0]
+		}).
+		Return(nil)
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByHash", mock.Anything, false).
+		Return(&rpcbackend.RPCError{Message: "pop"}).Once()
+	_, err := c.ReceiptProof(ctx, &ReceiptProofRequest{TransactionHash: testProofTxHash(0).String()})
+	assert.Regexp(t, "pop", err)
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByHash", mock.Anything, false).
+		Return(nil).Once()
+	_, err = c.ReceiptProof(ctx, &ReceiptProofRequest{TransactionHash: testProofTxHash(0).String()})
+	assert.Regexp(t, "FF23072", err)
+}
+
+func TestGetReceiptProofRoute(t *testing.T) {
+	_, c, mRPC, done := newTestConnector(t)
+	defer done()
+	url, close := newTestRouteServer(t, c)
+	defer close()
+
+	receipts := testBlockReceipts(2)
+	mockReceiptProofBlock(mRPC, receipts, testReceiptsRoot(receipts), receipts[0])
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockReceipts", mock.Anything).
+		Run(func(args mock.Arguments) {
+			*(args[1].(*[]*txReceiptJSONRPC)) = receipts
+		}).
+		Return(nil)
+
+	res, err := http.Get(url + "/transactions/" + testProofTxHash(0).String() + "/receipt/proof?logIndex=1")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var proof ReceiptProofResponse
+	err = json.NewDecoder(res.Body).Decode(&proof)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, *proof.LogPosition)
+	assert.True(t, bytes.Equal(encodeReceipt(receipts[0]), proof.Receipt))
+}
@@ -0,0 +1,74 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+)
+
+// The ways the success of a transaction is determined, when its receipt has no status field
+const (
+	ReceiptStatusFallbackGasUsed = "gasUsed"
+	ReceiptStatusFallbackTrace   = "trace"
+	ReceiptStatusFallbackSuccess = "success"
+	ReceiptStatusFallbackFailure = "failure"
+)
+
+var receiptStatusFallbacks = []string{ReceiptStatusFallbackGasUsed, ReceiptStatusFallbackTrace, ReceiptStatusFallbackSuccess, ReceiptStatusFallbackFailure}
+
+// receiptSuccess determines whether the transaction of a receipt succeeded, from the status of the receipt (EIP-658)
+// when it has one. Receipts from chains that predate Byzantium have a state root in its place, and some chains omit
+// it entirely, so the configured fallback decides for those - which is returned for the extra info of the receipt.
+func (c *ethConnector) receiptSuccess(ctx context.Context, hash string, receipt *txReceiptJSONRPC) (success bool, fallback string, err error) {
+	if receipt.Status != nil {
+		return receipt.Status.BigInt().Sign() > 0, "", nil
+	}
+	fallback = c.receiptStatusFallback
+	switch fallback {
+	case ReceiptStatusFallbackSuccess:
+		return true, fallback, nil
+	case ReceiptStatusFallbackFailure:
+		return false, fallback, nil
+	case ReceiptStatusFallbackTrace:
+		var debugTrace *txDebugTrace
+		rpcErr := c.readBackendForTx(hash).CallRPC(ctx, &debugTrace, "debug_traceTransaction", hash)
+		if rpcErr == nil && debugTrace != nil {
+			return !debugTrace.Failed, fallback, nil
+		}
+		log.L(ctx).Warnf("Unable to trace transaction %s for its status, so using the gas it used instead: %v", hash, rpcErr)
+		fallback = ReceiptStatusFallbackGasUsed
+	}
+	success, err = c.receiptSuccessFromGasUsed(ctx, receipt)
+	return success, fallback, err
+}
+
+// receiptSuccessFromGasUsed treats a transaction that used all of its gas as failed, as every failure did before REVERT
+// was added in Byzantium. A transaction that succeeds using exactly the gas it was given is indistinguishable.
+func (c *ethConnector) receiptSuccessFromGasUsed(ctx context.Context, receipt *txReceiptJSONRPC) (bool, error) {
+	txInfo, err := c.getTransactionInfo(ctx, receipt.TransactionHash)
+	if err != nil {
+		return false, err
+	}
+	if txInfo == nil || txInfo.Gas == nil || receipt.GasUsed == nil {
+		return false, i18n.NewError(ctx, msgs.MsgReceiptStatusUndetermined, receipt.TransactionHash)
+	}
+	return receipt.GasUsed.BigInt().Cmp(txInfo.Gas.BigInt()) < 0, nil
+}
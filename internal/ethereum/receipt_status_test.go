@@ -0,0 +1,163 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-evmconnect/mocks/rpcbackendmocks"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// mockPreByzantiumReceipt returns the sample receipt, which used 33812 gas, with a state root in place of its status
+func mockPreByzantiumReceipt(t *testing.T, mRPC *rpcbackendmocks.Backend) {
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionReceipt", mock.Anything).
+		Run(func(args mock.Arguments) {
+			err := json.Unmarshal([]byte(sampleJSONRPCReceipt), args[1])
+			assert.NoError(t, err)
+			receipt := *args[1].(**txReceiptJSONRPC)
+			receipt.Status = nil
+			receipt.Root = ethtypes.MustNewHexBytes0xPrefix("0x2a3f")
+		}).
+		Return(nil)
+}
+
+func mockTransactionGas(mRPC *rpcbackendmocks.Backend, gas int64) {
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionByHash", mock.Anything).
+		Run(func(args mock.Arguments) {
+			*(args[1].(**txInfoJSONRPC)) = &txInfoJSONRPC{Gas: ethtypes.NewHexInteger64(gas)}
+		}).
+		Return(nil).Once()
+}
+
+func getStatusLessReceipt(t *testing.T, c *ethConnector) (*ffcapi.TransactionReceiptResponse, *receiptExtraInfo, error) {
+	var req ffcapi.TransactionReceiptRequest
+	err := json.Unmarshal([]byte(sampleGetReceipt), &req)
+	assert.NoError(t, err)
+	res, _, err := c.TransactionReceipt(context.Background(), &req)
+	if err != nil {
+		return nil, nil, err
+	}
+	var extraInfo receiptExtraInfo
+	err = json.Unmarshal(res.ExtraInfo.Bytes(), &extraInfo)
+	assert.NoError(t, err)
+	return res, &extraInfo, nil
+}
+
+func TestReceiptStatusFallbackGasUsed(t *testing.T) {
+	_, c, mRPC, done := newTestConnector(t)
+	defer done()
+	mockPreByzantiumReceipt(t, mRPC)
+	mockTransactionGas(mRPC, 50000)
+
+	res, extraInfo, err := getStatusLessReceipt(t, c)
+	assert.NoError(t, err)
+	assert.True(t, res.Success)
+	assert.Nil(t, extraInfo.Status)
+	assert.Equal(t, ReceiptStatusFallbackGasUsed, extraInfo.StatusFallback)
+	assert.Nil(t, extraInfo.ErrorMessage)
+
+	// Using all of the gas is a failure
+	c.txCache.Purge()
+	mockTransactionGas(mRPC, 33812)
+	res, extraInfo, err = getStatusLessReceipt(t, c)
+	assert.NoError(t, err)
+	assert.False(t, res.Success)
+	assert.NotNil(t, extraInfo.ErrorMessage)
+	mRPC.AssertExpectations(t)
+}
+
+func TestReceiptStatusFallbackGasUsedUnavailable(t *testing.T) {
+	_, c, mRPC, done := newTestConnector(t)
+	defer done()
+	mockPreByzantiumReceipt(t, mRPC)
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionByHash", mock.Anything).
+		Return(&rpcbackend.RPCError{Message: "pop"}).Once()
+
+	_, _, err := getStatusLessReceipt(t, c)
+	assert.Regexp(t, "pop", err)
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionByHash", mock.Anything).
+		Return(nil).Once()
+	_, _, err = getStatusLessReceipt(t, c)
+	assert.Regexp(t, "FF23191", err)
+}
+
+func TestReceiptStatusFallbackTrace(t *testing.T) {
+	_, c, mRPC, done := newTestConnector(t, func(conf config.Section) {
+		conf.Set(ReceiptStatusFallback, ReceiptStatusFallbackTrace)
+	})
+	defer done()
+	mockPreByzantiumReceipt(t, mRPC)
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "debug_traceTransaction", mock.Anything).
+		Run(func(args mock.Arguments) {
+			*(args[1].(**txDebugTrace)) = &txDebugTrace{Failed: true}
+		}).
+		Return(nil).Once()
+
+	res, extraInfo, err := getStatusLessReceipt(t, c)
+	assert.NoError(t, err)
+	assert.False(t, res.Success)
+	assert.Equal(t, ReceiptStatusFallbackTrace, extraInfo.StatusFallback)
+
+	// Falls back to the gas used when the node cannot trace the transaction
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "debug_traceTransaction", mock.Anything).
+		Return(&rpcbackend.RPCError{Message: "method not found"}).Once()
+	mockTransactionGas(mRPC, 50000)
+	res, extraInfo, err = getStatusLessReceipt(t, c)
+	assert.NoError(t, err)
+	assert.True(t, res.Success)
+	assert.Equal(t, ReceiptStatusFallbackGasUsed, extraInfo.StatusFallback)
+	mRPC.AssertExpectations(t)
+}
+
+func TestReceiptStatusFallbackFixed(t *testing.T) {
+	_, c, mRPC, done := newTestConnector(t, func(conf config.Section) {
+		conf.Set(ReceiptStatusFallback, ReceiptStatusFallbackSuccess)
+	})
+	defer done()
+	mockPreByzantiumReceipt(t, mRPC)
+
+	res, extraInfo, err := getStatusLessReceipt(t, c)
+	assert.NoError(t, err)
+	assert.True(t, res.Success)
+	assert.Equal(t, ReceiptStatusFallbackSuccess, extraInfo.StatusFallback)
+
+	c.receiptStatusFallback = ReceiptStatusFallbackFailure
+	res, extraInfo, err = getStatusLessReceipt(t, c)
+	assert.NoError(t, err)
+	assert.False(t, res.Success)
+	assert.Equal(t, ReceiptStatusFallbackFailure, extraInfo.StatusFallback)
+}
+
+func TestReceiptStatusFallbackConfig(t *testing.T) {
+	config.RootConfigReset()
+	conf := config.RootSection("unittest")
+	InitConfig(conf)
+	conf.Set(ffresty.HTTPConfigURL, "http://localhost:8545")
+	conf.Set(ReceiptStatusFallback, "wrong")
+	_, err := NewEthereumConnector(context.Background(), conf)
+	assert.Regexp(t, "FF23190", err)
+}
@@ -0,0 +1,968 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/apitypes"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+)
+
+// Routes returns the REST API routes for EVM specific operations that are not part of FFCAPI,
+// to be registered alongside the routes of the transaction manager
+func (c *ethConnector) Routes() []*ffapi.Route {
+	return []*ffapi.Route{
+		getContractCapabilities(c),
+		getTokenMetadata(c),
+		postStorageQuery(c),
+		postStateProof(c),
+		getAccountBalance(c),
+		postQuery(c),
+		getReceiptProof(c),
+		postNonceGap(c),
+		getSignerMempool(c),
+		getTransactionMempool(c),
+		getProxyInfo(c),
+		getContractMetadata(c),
+		postDecodeCallData(c),
+		postFFIToABI(c),
+		postABIToFFI(c),
+		postDecodeTransaction(c),
+		postPrivateQuery(c),
+		postPrivateSend(c),
+		postPrivateReceipt(c),
+		getBlockByHash(c),
+		getBlockAtTimestamp(c),
+		getBlockTransactionCount(c),
+		getBlockTransactions(c),
+		getBlockL1Inclusion(c),
+		getBaseFee(c),
+		postFeeBump(c),
+		postUserOpHash(c),
+		postDeployDryRun(c),
+		postPrepareTransaction(c),
+		postReplayEvents(c),
+		postPauseListener(c),
+		postResumeListener(c),
+		getListenerSchema(c),
+		getStreamSettings(c),
+		patchStreamSettings(c),
+		getQuarantinedEvents(c),
+		postRetryQuarantinedEvent(c),
+		getDeadLetters(c),
+		postReplayDeadLetter(c),
+		getAdminStatus(c),
+		getAdminSnapshot(c),
+		postAdminRestore(c),
+		getAdminCosts(c),
+	}
+}
+
+// queryParamList combines repeated and comma separated values of a query parameter
+func queryParamList(r *ffapi.APIRequest, name string) []string {
+	values := []string{}
+	for _, v := range r.QAP[name] {
+		for _, s := range strings.Split(v, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				values = append(values, s)
+			}
+		}
+	}
+	return values
+}
+
+var getContractCapabilities = func(c *ethConnector) *ffapi.Route {
+	return &ffapi.Route{
+		Name:   "getContractCapabilities",
+		Path:   "/contracts/{address}/capabilities",
+		Method: http.MethodGet,
+		PathParams: []*ffapi.PathParam{
+			{Name: "address", Description: msgs.APIParamContractAddress},
+		},
+		QueryParams: []*ffapi.QueryParam{
+			{Name: "interfaces", Description: msgs.APIParamInterfaces, IsArray: true},
+		},
+		Description:     msgs.APIEndpointGetContractCapabilities,
+		JSONInputValue:  nil,
+		JSONOutputValue: func() interface{} { return &ContractCapabilitiesResponse{} },
+		JSONOutputCodes: []int{http.StatusOK},
+		JSONHandler: func(r *ffapi.APIRequest) (output interface{}, err error) {
+			return c.ContractCapabilities(r.Req.Context(), &ContractCapabilitiesRequest{
+				Address:    r.PP["address"],
+				Interfaces: queryParamList(r, "interfaces"),
+			})
+		},
+	}
+}
+
+var getTokenMetadata = func(c *ethConnector) *ffapi.Route {
+	return &ffapi.Route{
+		Name:   "getTokenMetadata",
+		Path:   "/tokens/{address}/metadata",
+		Method: http.MethodGet,
+		PathParams: []*ffapi.PathParam{
+			{Name: "address", Description: msgs.APIParamContractAddress},
+		},
+		QueryParams: []*ffapi.QueryParam{
+			{Name: "tokenId", Description: msgs.APIParamTokenID},
+		},
+		Description:     msgs.APIEndpointGetTokenMetadata,
+		JSONInputValue:  nil,
+		JSONOutputValue: func() interface{} { return &TokenMetadataResponse{} },
+		JSONOutputCodes: []int{http.StatusOK},
+		JSONHandler: func(r *ffapi.APIRequest) (output interface{}, err error) {
+			return c.TokenMetadata(r.Req.Context(), &TokenMetadataRequest{
+				Address: r.PP["address"],
+				TokenID: r.QP["tokenId"],
+			})
+		},
+	}
+}
+
+var postStorageQuery = func(c *ethConnector) *ffapi.Route {
+	return &ffapi.Route{
+		Name:   "postStorageQuery",
+		Path:   "/contracts/{address}/storage",
+		Method: http.MethodPost,
+		PathParams: []*ffapi.PathParam{
+			{Name: "address", Description: msgs.APIParamContractAddress},
+		},
+		QueryParams:     nil,
+		Description:     msgs.APIEndpointPostStorageQuery,
+		JSONInputValue:  func() interface{} { return &StorageQueryRequest{} },
+		JSONInputMask:   []string{"Address"},
+		JSONOutputValue: func() interface{} { return &StorageQueryResponse{} },
+		JSONOutputCodes: []int{http.StatusOK},
+		JSONHandler: func(r *ffapi.APIRequest) (output interface{}, err error) {
+			req := r.Input.(*StorageQueryRequest)
+			req.Address = r.PP["address"]
+			return c.StorageQuery(r.Req.Context(), req)
+		},
+	}
+}
+
+var postStateProof = func(c *ethConnector) *ffapi.Route {
+	return &ffapi.Route{
+		Name:   "postStateProof",
+		Path:   "/accounts/{address}/proof",
+		Method: http.MethodPost,
+		PathParams: []*ffapi.PathParam{
+			{Name: "address", Description: msgs.APIParamAccountAddress},
+		},
+		QueryParams:     nil,
+		Description:     msgs.APIEndpointPostStateProof,
+		JSONInputValue:  func() interface{} { return &StateProofRequest{} },
+		JSONInputMask:   []string{"Address"},
+		JSONOutputValue: func() interface{} { return &StateProofResponse{} },
+		JSONOutputCodes: []int{http.StatusOK},
+		JSONHandler: func(r *ffapi.APIRequest) (output interface{}, err error) {
+			req := r.Input.(*StateProofRequest)
+			req.Address = r.PP["address"]
+			return c.StateProof(r.Req.Context(), req)
+		},
+	}
+}
+
+var getAccountBalance = func(c *ethConnector) *ffapi.Route {
+	return &ffapi.Route{
+		Name:   "getAccountBalance",
+		Path:   "/accounts/{address}/balance",
+		Method: http.MethodGet,
+		PathParams: []*ffapi.PathParam{
+			{Name: "address", Description: msgs.APIParamAccountAddress},
+		},
+		QueryParams: []*ffapi.QueryParam{
+			{Name: "blockTag", Description: msgs.APIParamBlockTag},
+			{Name: "numberFormat", Description: msgs.APIParamNumberFormat},
+		},
+		Description:     msgs.APIEndpointGetAccountBalance,
+		JSONInputValue:  nil,
+		JSONOutputValue: func() interface{} { return &AccountBalance{} },
+		JSONOutputCodes: []int{http.StatusOK},
+		JSONHandler: func(r *ffapi.APIRequest) (output interface{}, err error) {
+			res, _, err := c.FormattedAddressBalance(r.Req.Context(), r.PP["address"], r.QP["blockTag"], r.QP["numberFormat"])
+			return res, err
+		},
+	}
+}
+
+var postQuery = func(c *ethConnector) *ffapi.Route {
+	return &ffapi.Route{
+		Name:            "postQuery",
+		Path:            "/query",
+		Method:          http.MethodPost,
+		PathParams:      nil,
+		QueryParams:     nil,
+		Description:     msgs.APIEndpointPostQuery,
+		JSONInputValue:  func() interface{} { return &QueryRequest{} },
+		JSONOutputValue: func() interface{} { return &ffcapi.QueryInvokeResponse{} },
+		JSONOutputCodes: []int{http.StatusOK},
+		JSONHandler: func(r *ffapi.APIRequest) (output interface{}, err error) {
+			res, _, err := c.FormattedQueryInvoke(r.Req.Context(), r.Input.(*QueryRequest))
+			return res, err
+		},
+	}
+}
+
+var getReceiptProof = func(c *ethConnector) *ffapi.Route {
+	return &ffapi.Route{
+		Name:   "getReceiptProof",
+		Path:   "/transactions/{hash}/receipt/proof",
+		Method: http.MethodGet,
+		PathParams: []*ffapi.PathParam{
+			{Name: "hash", Description: msgs.APIParamTransactionHash},
+		},
+		QueryParams: []*ffapi.QueryParam{
+			{Name: "logIndex", Description: msgs.APIParamLogIndex},
+		},
+		Description:     msgs.APIEndpointGetReceiptProof,
+		JSONInputValue:  nil,
+		JSONOutputValue: func() interface{} { return &ReceiptProofResponse{} },
+		JSONOutputCodes: []int{http.StatusOK},
+		JSONHandler: func(r *ffapi.APIRequest) (output interface{}, err error) {
+			return c.ReceiptProof(r.Req.Context(), &ReceiptProofRequest{
+				TransactionHash: r.PP["hash"],
+				LogIndex:        r.QP["logIndex"],
+			})
+		},
+	}
+}
+
+var postNonceGap = func(c *ethConnector) *ffapi.Route {
+	return &ffapi.Route{
+		Name:   "postNonceGap",
+		Path:   "/signers/{address}/noncegap",
+		Method: http.MethodPost,
+		PathParams: []*ffapi.PathParam{
+			{Name: "address", Description: msgs.APIParamSignerAddress},
+		},
+		QueryParams:     nil,
+		Description:     msgs.APIEndpointPostNonceGap,
+		JSONInputValue:  func() interface{} { return &NonceGapRequest{} },
+		JSONInputMask:   []string{"Signer"},
+		JSONOutputValue: func() interface{} { return &NonceGapResponse{} },
+		JSONOutputCodes: []int{http.StatusOK},
+		JSONHandler: func(r *ffapi.APIRequest) (output interface{}, err error) {
+			req := r.Input.(*NonceGapRequest)
+			req.Signer = r.PP["address"]
+			return c.NonceGap(r.Req.Context(), req)
+		},
+	}
+}
+
+var getSignerMempool = func(c *ethConnector) *ffapi.Route {
+	return &ffapi.Route{
+		Name:   "getSignerMempool",
+		Path:   "/signers/{address}/mempool",
+		Method: http.MethodGet,
+		PathParams: []*ffapi.PathParam{
+			{Name: "address", Description: msgs.APIParamSignerAddress},
+		},
+		QueryParams:     nil,
+		Description:     msgs.APIEndpointGetSignerMempool,
+		JSONInputValue:  nil,
+		JSONOutputValue: func() interface{} { return &MempoolSignerResponse{} },
+		JSONOutputCodes: []int{http.StatusOK},
+		JSONHandler: func(r *ffapi.APIRequest) (output interface{}, err error) {
+			return c.MempoolForSigner(r.Req.Context(), r.PP["address"])
+		},
+	}
+}
+
+var getTransactionMempool = func(c *ethConnector) *ffapi.Route {
+	return &ffapi.Route{
+		Name:   "getTransactionMempool",
+		Path:   "/transactions/{hash}/mempool",
+		Method: http.MethodGet,
+		PathParams: []*ffapi.PathParam{
+			{Name: "hash", Description: msgs.APIParamTransactionHash},
+		},
+		QueryParams:     nil,
+		Description:     msgs.APIEndpointGetTransactionMempool,
+		JSONInputValue:  nil,
+		JSONOutputValue: func() interface{} { return &MempoolTransactionResponse{} },
+		JSONOutputCodes: []int{http.StatusOK},
+		JSONHandler: func(r *ffapi.APIRequest) (output interface{}, err error) {
+			return c.MempoolTransactionStatus(r.Req.Context(), r.PP["hash"])
+		},
+	}
+}
+
+var getProxyInfo = func(c *ethConnector) *ffapi.Route {
+	return &ffapi.Route{
+		Name:   "getProxyInfo",
+		Path:   "/contracts/{address}/proxy",
+		Method: http.MethodGet,
+		PathParams: []*ffapi.PathParam{
+			{Name: "address", Description: msgs.APIParamContractAddress},
+		},
+		QueryParams:     nil,
+		Description:     msgs.APIEndpointGetProxyInfo,
+		JSONInputValue:  nil,
+		JSONOutputValue: func() interface{} { return &ProxyInfo{} },
+		JSONOutputCodes: []int{http.StatusOK},
+		JSONHandler: func(r *ffapi.APIRequest) (output interface{}, err error) {
+			return c.ResolveProxy(r.Req.Context(), r.PP["address"])
+		},
+	}
+}
+
+var getContractMetadata = func(c *ethConnector) *ffapi.Route {
+	return &ffapi.Route{
+		Name:   "getContractMetadata",
+		Path:   "/contracts/{address}/metadata",
+		Method: http.MethodGet,
+		PathParams: []*ffapi.PathParam{
+			{Name: "address", Description: msgs.APIParamContractAddress},
+		},
+		QueryParams: []*ffapi.QueryParam{
+			{Name: "ipfs", Description: msgs.APIParamMetadataIPFS},
+		},
+		Description:     msgs.APIEndpointGetContractMetadata,
+		JSONInputValue:  nil,
+		JSONOutputValue: func() interface{} { return &ContractMetadata{} },
+		JSONOutputCodes: []int{http.StatusOK},
+		JSONHandler: func(r *ffapi.APIRequest) (output interface{}, err error) {
+			return c.ContractMetadata(r.Req.Context(), r.PP["address"], r.QP["ipfs"])
+		},
+	}
+}
+
+var postDecodeCallData = func(c *ethConnector) *ffapi.Route {
+	return &ffapi.Route{
+		Name:            "postDecodeCallData",
+		Path:            "/decode/calldata",
+		Method:          http.MethodPost,
+		PathParams:      nil,
+		QueryParams:     nil,
+		Description:     msgs.APIEndpointPostDecodeCallData,
+		JSONInputValue:  func() interface{} { return &DecodeCallDataRequest{} },
+		JSONOutputValue: func() interface{} { return &DecodeCallDataResponse{} },
+		JSONOutputCodes: []int{http.StatusOK},
+		JSONHandler: func(r *ffapi.APIRequest) (output interface{}, err error) {
+			return c.DecodeCallData(r.Req.Context(), r.Input.(*DecodeCallDataRequest))
+		},
+	}
+}
+
+var postFFIToABI = func(c *ethConnector) *ffapi.Route {
+	return &ffapi.Route{
+		Name:            "postFFIToABI",
+		Path:            "/ffi/abi",
+		Method:          http.MethodPost,
+		PathParams:      nil,
+		QueryParams:     nil,
+		Description:     msgs.APIEndpointPostFFIToABI,
+		JSONInputValue:  func() interface{} { return &fftypes.FFI{} },
+		JSONOutputValue: func() interface{} { return &abi.ABI{} },
+		JSONOutputCodes: []int{http.StatusOK},
+		JSONHandler: func(r *ffapi.APIRequest) (output interface{}, err error) {
+			return c.FFIToABI(r.Req.Context(), r.Input.(*fftypes.FFI))
+		},
+	}
+}
+
+var postABIToFFI = func(c *ethConnector) *ffapi.Route {
+	return &ffapi.Route{
+		Name:            "postABIToFFI",
+		Path:            "/abi/ffi",
+		Method:          http.MethodPost,
+		PathParams:      nil,
+		QueryParams:     nil,
+		Description:     msgs.APIEndpointPostABIToFFI,
+		JSONInputValue:  func() interface{} { return &fftypes.FFIGenerationRequest{} },
+		JSONOutputValue: func() interface{} { return &fftypes.FFI{} },
+		JSONOutputCodes: []int{http.StatusOK},
+		JSONHandler: func(r *ffapi.APIRequest) (output interface{}, err error) {
+			return c.ABIToFFI(r.Req.Context(), r.Input.(*fftypes.FFIGenerationRequest))
+		},
+	}
+}
+
+var postUserOpHash = func(c *ethConnector) *ffapi.Route {
+	return &ffapi.Route{
+		Name:            "postUserOpHash",
+		Path:            "/erc4337/userophash",
+		Method:          http.MethodPost,
+		PathParams:      nil,
+		QueryParams:     nil,
+		Description:     msgs.APIEndpointPostUserOpHash,
+		JSONInputValue:  func() interface{} { return &UserOpHashRequest{} },
+		JSONOutputValue: func() interface{} { return &UserOpHashResponse{} },
+		JSONOutputCodes: []int{http.StatusOK},
+		JSONHandler: func(r *ffapi.APIRequest) (output interface{}, err error) {
+			return c.UserOpHash(r.Req.Context(), r.Input.(*UserOpHashRequest))
+		},
+	}
+}
+
+var postDeployDryRun = func(c *ethConnector) *ffapi.Route {
+	return &ffapi.Route{
+		Name:            "postDeployDryRun",
+		Path:            "/deploy/dryrun",
+		Method:          http.MethodPost,
+		PathParams:      nil,
+		QueryParams:     nil,
+		Description:     msgs.APIEndpointPostDeployDryRun,
+		JSONInputValue:  func() interface{} { return &ffcapi.ContractDeployPrepareRequest{} },
+		JSONOutputValue: func() interface{} { return &DeployDryRunResponse{} },
+		JSONOutputCodes: []int{http.StatusOK},
+		JSONHandler: func(r *ffapi.APIRequest) (output interface{}, err error) {
+			res, _, err := c.DeployContractDryRun(r.Req.Context(), r.Input.(*ffcapi.ContractDeployPrepareRequest))
+			return res, err
+		},
+	}
+}
+
+var postPrepareTransaction = func(c *ethConnector) *ffapi.Route {
+	return &ffapi.Route{
+		Name:            "postPrepareTransaction",
+		Path:            "/transactions/prepare",
+		Method:          http.MethodPost,
+		PathParams:      nil,
+		QueryParams:     nil,
+		Description:     msgs.APIEndpointPostPrepareTransaction,
+		JSONInputValue:  func() interface{} { return &PrepareTransactionRequest{} },
+		JSONOutputValue: func() interface{} { return &PrepareTransactionResponse{} },
+		JSONOutputCodes: []int{http.StatusOK},
+		JSONHandler: func(r *ffapi.APIRequest) (output interface{}, err error) {
+			res, _, err := c.PrepareTransactionPreflight(r.Req.Context(), r.Input.(*PrepareTransactionRequest))
+			return res, err
+		},
+	}
+}
+
+var postDecodeTransaction = func(c *ethConnector) *ffapi.Route {
+	return &ffapi.Route{
+		Name:            "postDecodeTransaction",
+		Path:            "/decode/transaction",
+		Method:          http.MethodPost,
+		PathParams:      nil,
+		QueryParams:     nil,
+		Description:     msgs.APIEndpointPostDecodeTransaction,
+		JSONInputValue:  func() interface{} { return &DecodeTransactionRequest{} },
+		JSONOutputValue: func() interface{} { return &DecodedTransaction{} },
+		JSONOutputCodes: []int{http.StatusOK},
+		JSONHandler: func(r *ffapi.APIRequest) (output interface{}, err error) {
+			return c.DecodeTransaction(r.Req.Context(), r.Input.(*DecodeTransactionRequest))
+		},
+	}
+}
+
+var postPrivateQuery = func(c *ethConnector) *ffapi.Route {
+	return &ffapi.Route{
+		Name:            "postPrivateQuery",
+		Path:            "/privacy/query",
+		Method:          http.MethodPost,
+		PathParams:      nil,
+		QueryParams:     nil,
+		Description:     msgs.APIEndpointPostPrivateQuery,
+		JSONInputValue:  func() interface{} { return &PrivateQueryRequest{} },
+		JSONOutputValue: func() interface{} { return &ffcapi.QueryInvokeResponse{} },
+		JSONOutputCodes: []int{http.StatusOK},
+		JSONHandler: func(r *ffapi.APIRequest) (output interface{}, err error) {
+			req := r.Input.(*PrivateQueryRequest)
+			res, _, err := c.PrivateQueryInvoke(r.Req.Context(), req.PrivacyGroupID, &req.QueryInvokeRequest, req.NumberFormat)
+			return res, err
+		},
+	}
+}
+
+var postPrivateSend = func(c *ethConnector) *ffapi.Route {
+	return &ffapi.Route{
+		Name:            "postPrivateSend",
+		Path:            "/privacy/tessera/send",
+		Method:          http.MethodPost,
+		PathParams:      nil,
+		QueryParams:     nil,
+		Description:     msgs.APIEndpointPostPrivateSend,
+		JSONInputValue:  func() interface{} { return &PrivateSendRequest{} },
+		JSONOutputValue: func() interface{} { return &ffcapi.TransactionSendResponse{} },
+		JSONOutputCodes: []int{http.StatusOK},
+		JSONHandler: func(r *ffapi.APIRequest) (output interface{}, err error) {
+			res, _, err := c.PrivateTransactionSend(r.Req.Context(), r.Input.(*PrivateSendRequest))
+			return res, err
+		},
+	}
+}
+
+var postPrivateReceipt = func(c *ethConnector) *ffapi.Route {
+	return &ffapi.Route{
+		Name:            "postPrivateReceipt",
+		Path:            "/privacy/tessera/receipt",
+		Method:          http.MethodPost,
+		PathParams:      nil,
+		QueryParams:     nil,
+		Description:     msgs.APIEndpointPostPrivateReceipt,
+		JSONInputValue:  func() interface{} { return &ffcapi.TransactionReceiptRequest{} },
+		JSONOutputValue: func() interface{} { return &ffcapi.TransactionReceiptResponse{} },
+		JSONOutputCodes: []int{http.StatusOK},
+		JSONHandler: func(r *ffapi.APIRequest) (output interface{}, err error) {
+			res, _, err := c.PrivateTransactionReceipt(r.Req.Context(), r.Input.(*ffcapi.TransactionReceiptRequest))
+			return res, err
+		},
+	}
+}
+
+var getBlockByHash = func(c *ethConnector) *ffapi.Route {
+	return &ffapi.Route{
+		Name:   "getBlockByHash",
+		Path:   "/blocks/{hash}",
+		Method: http.MethodGet,
+		PathParams: []*ffapi.PathParam{
+			{Name: "hash", Description: msgs.APIParamBlockHash},
+		},
+		QueryParams: []*ffapi.QueryParam{
+			{Name: "includeUncles", Description: msgs.APIParamIncludeUncles, IsBool: true},
+			{Name: "includeTransactions", Description: msgs.APIParamIncludeTransactions, IsBool: true},
+		},
+		Description:     msgs.APIEndpointGetBlockByHash,
+		JSONInputValue:  nil,
+		JSONOutputValue: func() interface{} { return &BlockHeaderResponse{} },
+		JSONOutputCodes: []int{http.StatusOK},
+		JSONHandler: func(r *ffapi.APIRequest) (output interface{}, err error) {
+			return c.BlockHeaderByHash(r.Req.Context(), r.PP["hash"], strings.EqualFold(r.QP["includeUncles"], "true"), strings.EqualFold(r.QP["includeTransactions"], "true"))
+		},
+	}
+}
+
+var getBlockAtTimestamp = func(c *ethConnector) *ffapi.Route {
+	return &ffapi.Route{
+		Name:   "getBlockAtTimestamp",
+		Path:   "/blocks/timestamp/{timestamp}",
+		Method: http.MethodGet,
+		PathParams: []*ffapi.PathParam{
+			{Name: "timestamp", Description: msgs.APIParamTimestamp},
+		},
+		QueryParams:     nil,
+		Description:     msgs.APIEndpointGetBlockAtTimestamp,
+		JSONInputValue:  nil,
+		JSONOutputValue: func() interface{} { return &BlockAtTimestampResponse{} },
+		JSONOutputCodes: []int{http.StatusOK},
+		JSONHandler: func(r *ffapi.APIRequest) (output interface{}, err error) {
+			return c.BlockAtTimestamp(r.Req.Context(), r.PP["timestamp"])
+		},
+	}
+}
+
+var getBlockTransactionCount = func(c *ethConnector) *ffapi.Route {
+	return &ffapi.Route{
+		Name:   "getBlockTransactionCount",
+		Path:   "/blocks/number/{number}/transactions/count",
+		Method: http.MethodGet,
+		PathParams: []*ffapi.PathParam{
+			{Name: "number", Description: msgs.APIParamBlockNumber},
+		},
+		QueryParams:     nil,
+		Description:     msgs.APIEndpointGetBlockTxCount,
+		JSONInputValue:  nil,
+		JSONOutputValue: func() interface{} { return &BlockTransactionCount{} },
+		JSONOutputCodes: []int{http.StatusOK},
+		JSONHandler: func(r *ffapi.APIRequest) (output interface{}, err error) {
+			return c.BlockTransactionCount(r.Req.Context(), r.PP["number"])
+		},
+	}
+}
+
+var getBlockL1Inclusion = func(c *ethConnector) *ffapi.Route {
+	return &ffapi.Route{
+		Name:   "getBlockL1Inclusion",
+		Path:   "/blocks/number/{number}/l1inclusion",
+		Method: http.MethodGet,
+		PathParams: []*ffapi.PathParam{
+			{Name: "number", Description: msgs.APIParamBlockNumber},
+		},
+		QueryParams:     nil,
+		Description:     msgs.APIEndpointGetBlockL1Inclusion,
+		JSONInputValue:  nil,
+		JSONOutputValue: func() interface{} { return &BlockL1Inclusion{} },
+		JSONOutputCodes: []int{http.StatusOK},
+		JSONHandler: func(r *ffapi.APIRequest) (output interface{}, err error) {
+			return c.BlockL1Inclusion(r.Req.Context(), r.PP["number"])
+		},
+	}
+}
+
+var getBlockTransactions = func(c *ethConnector) *ffapi.Route {
+	return &ffapi.Route{
+		Name:   "getBlockTransactions",
+		Path:   "/blocks/number/{number}/transactions",
+		Method: http.MethodGet,
+		PathParams: []*ffapi.PathParam{
+			{Name: "number", Description: msgs.APIParamBlockNumber},
+		},
+		QueryParams: []*ffapi.QueryParam{
+			{Name: "start", Description: msgs.APIParamTxPageStart},
+			{Name: "limit", Description: msgs.APIParamTxPageLimit},
+		},
+		Description:     msgs.APIEndpointGetBlockTxPage,
+		JSONInputValue:  nil,
+		JSONOutputValue: func() interface{} { return &BlockTransactionsPage{} },
+		JSONOutputCodes: []int{http.StatusOK},
+		JSONHandler: func(r *ffapi.APIRequest) (output interface{}, err error) {
+			return c.BlockTransactionsPage(r.Req.Context(), r.PP["number"], r.QP["start"], r.QP["limit"])
+		},
+	}
+}
+
+var getBaseFee = func(c *ethConnector) *ffapi.Route {
+	return &ffapi.Route{
+		Name:            "getBaseFee",
+		Path:            "/gas/basefee",
+		Method:          http.MethodGet,
+		PathParams:      nil,
+		QueryParams:     nil,
+		Description:     msgs.APIEndpointGetBaseFee,
+		JSONInputValue:  nil,
+		JSONOutputValue: func() interface{} { return &BaseFeeWindow{} },
+		JSONOutputCodes: []int{http.StatusOK},
+		JSONHandler: func(r *ffapi.APIRequest) (output interface{}, err error) {
+			return c.BaseFeeWindow(r.Req.Context())
+		},
+	}
+}
+
+var postFeeBump = func(c *ethConnector) *ffapi.Route {
+	return &ffapi.Route{
+		Name:            "postFeeBump",
+		Path:            "/gas/feebump",
+		Method:          http.MethodPost,
+		PathParams:      nil,
+		QueryParams:     nil,
+		Description:     msgs.APIEndpointPostFeeBump,
+		JSONInputValue:  func() interface{} { return &FeeBumpRequest{} },
+		JSONOutputValue: func() interface{} { return &FeeBumpResponse{} },
+		JSONOutputCodes: []int{http.StatusOK},
+		JSONHandler: func(r *ffapi.APIRequest) (output interface{}, err error) {
+			return c.FeeBump(r.Req.Context(), r.Input.(*FeeBumpRequest))
+		},
+	}
+}
+
+var postReplayEvents = func(c *ethConnector) *ffapi.Route {
+	return &ffapi.Route{
+		Name:   "postReplayEvents",
+		Path:   "/eventstreams/{streamId}/listeners/{listenerId}/replay",
+		Method: http.MethodPost,
+		PathParams: []*ffapi.PathParam{
+			{Name: "streamId", Description: msgs.APIParamStreamID},
+			{Name: "listenerId", Description: msgs.APIParamListenerID},
+		},
+		QueryParams:     nil,
+		Description:     msgs.APIEndpointPostReplayEvents,
+		JSONInputValue:  func() interface{} { return &ReplayEventsRequest{} },
+		JSONOutputValue: func() interface{} { return &ReplayEventsResponse{} },
+		JSONOutputCodes: []int{http.StatusOK},
+		JSONHandler: func(r *ffapi.APIRequest) (output interface{}, err error) {
+			streamID, err := fftypes.ParseUUID(r.Req.Context(), r.PP["streamId"])
+			if err != nil {
+				return nil, err
+			}
+			listenerID, err := fftypes.ParseUUID(r.Req.Context(), r.PP["listenerId"])
+			if err != nil {
+				return nil, err
+			}
+			return c.ReplayEvents(r.Req.Context(), streamID, listenerID, r.Input.(*ReplayEventsRequest))
+		},
+	}
+}
+
+var postPauseListener = func(c *ethConnector) *ffapi.Route {
+	return &ffapi.Route{
+		Name:   "postPauseListener",
+		Path:   "/eventstreams/{streamId}/listeners/{listenerId}/pause",
+		Method: http.MethodPost,
+		PathParams: []*ffapi.PathParam{
+			{Name: "streamId", Description: msgs.APIParamStreamID},
+			{Name: "listenerId", Description: msgs.APIParamListenerID},
+		},
+		QueryParams:     nil,
+		Description:     msgs.APIEndpointPostPauseListener,
+		JSONInputValue:  func() interface{} { return &struct{}{} },
+		JSONOutputValue: func() interface{} { return &ListenerPauseResponse{} },
+		JSONOutputCodes: []int{http.StatusOK},
+		JSONHandler: func(r *ffapi.APIRequest) (output interface{}, err error) {
+			streamID, err := fftypes.ParseUUID(r.Req.Context(), r.PP["streamId"])
+			if err != nil {
+				return nil, err
+			}
+			listenerID, err := fftypes.ParseUUID(r.Req.Context(), r.PP["listenerId"])
+			if err != nil {
+				return nil, err
+			}
+			return c.PauseListener(r.Req.Context(), streamID, listenerID)
+		},
+	}
+}
+
+var postResumeListener = func(c *ethConnector) *ffapi.Route {
+	return &ffapi.Route{
+		Name:   "postResumeListener",
+		Path:   "/eventstreams/{streamId}/listeners/{listenerId}/resume",
+		Method: http.MethodPost,
+		PathParams: []*ffapi.PathParam{
+			{Name: "streamId", Description: msgs.APIParamStreamID},
+			{Name: "listenerId", Description: msgs.APIParamListenerID},
+		},
+		QueryParams:     nil,
+		Description:     msgs.APIEndpointPostResumeListener,
+		JSONInputValue:  func() interface{} { return &struct{}{} },
+		JSONOutputValue: func() interface{} { return &ListenerPauseResponse{} },
+		JSONOutputCodes: []int{http.StatusOK},
+		JSONHandler: func(r *ffapi.APIRequest) (output interface{}, err error) {
+			streamID, err := fftypes.ParseUUID(r.Req.Context(), r.PP["streamId"])
+			if err != nil {
+				return nil, err
+			}
+			listenerID, err := fftypes.ParseUUID(r.Req.Context(), r.PP["listenerId"])
+			if err != nil {
+				return nil, err
+			}
+			return c.ResumeListener(r.Req.Context(), streamID, listenerID)
+		},
+	}
+}
+
+var getListenerSchema = func(c *ethConnector) *ffapi.Route {
+	return &ffapi.Route{
+		Name:   "getListenerSchema",
+		Path:   "/eventstreams/{streamId}/listeners/{listenerId}/schema",
+		Method: http.MethodGet,
+		PathParams: []*ffapi.PathParam{
+			{Name: "streamId", Description: msgs.APIParamStreamID},
+			{Name: "listenerId", Description: msgs.APIParamListenerID},
+		},
+		QueryParams:     nil,
+		Description:     msgs.APIEndpointGetListenerSchema,
+		JSONInputValue:  nil,
+		JSONOutputValue: func() interface{} { return &ListenerSchema{} },
+		JSONOutputCodes: []int{http.StatusOK},
+		JSONHandler: func(r *ffapi.APIRequest) (output interface{}, err error) {
+			streamID, err := fftypes.ParseUUID(r.Req.Context(), r.PP["streamId"])
+			if err != nil {
+				return nil, err
+			}
+			listenerID, err := fftypes.ParseUUID(r.Req.Context(), r.PP["listenerId"])
+			if err != nil {
+				return nil, err
+			}
+			return c.ListenerSchema(r.Req.Context(), streamID, listenerID)
+		},
+	}
+}
+
+var getStreamSettings = func(c *ethConnector) *ffapi.Route {
+	return &ffapi.Route{
+		Name:   "getStreamSettings",
+		Path:   "/eventstreams/{streamId}/settings",
+		Method: http.MethodGet,
+		PathParams: []*ffapi.PathParam{
+			{Name: "streamId", Description: msgs.APIParamStreamID},
+		},
+		QueryParams:     nil,
+		Description:     msgs.APIEndpointGetStreamSettings,
+		JSONInputValue:  nil,
+		JSONOutputValue: func() interface{} { return &EventStreamSettings{} },
+		JSONOutputCodes: []int{http.StatusOK},
+		JSONHandler: func(r *ffapi.APIRequest) (output interface{}, err error) {
+			streamID, err := fftypes.ParseUUID(r.Req.Context(), r.PP["streamId"])
+			if err != nil {
+				return nil, err
+			}
+			return c.EventStreamSettings(r.Req.Context(), streamID)
+		},
+	}
+}
+
+var patchStreamSettings = func(c *ethConnector) *ffapi.Route {
+	return &ffapi.Route{
+		Name:   "patchStreamSettings",
+		Path:   "/eventstreams/{streamId}/settings",
+		Method: http.MethodPatch,
+		PathParams: []*ffapi.PathParam{
+			{Name: "streamId", Description: msgs.APIParamStreamID},
+		},
+		QueryParams:     nil,
+		Description:     msgs.APIEndpointPatchStreamSettings,
+		JSONInputValue:  func() interface{} { return &EventStreamSettings{} },
+		JSONOutputValue: func() interface{} { return &EventStreamSettings{} },
+		JSONOutputCodes: []int{http.StatusOK},
+		JSONHandler: func(r *ffapi.APIRequest) (output interface{}, err error) {
+			streamID, err := fftypes.ParseUUID(r.Req.Context(), r.PP["streamId"])
+			if err != nil {
+				return nil, err
+			}
+			return c.UpdateEventStreamSettings(r.Req.Context(), streamID, r.Input.(*EventStreamSettings))
+		},
+	}
+}
+
+var getQuarantinedEvents = func(c *ethConnector) *ffapi.Route {
+	return &ffapi.Route{
+		Name:            "getQuarantinedEvents",
+		Path:            "/quarantine",
+		Method:          http.MethodGet,
+		PathParams:      nil,
+		QueryParams:     nil,
+		Description:     msgs.APIEndpointGetQuarantinedEvents,
+		JSONInputValue:  nil,
+		JSONOutputValue: func() interface{} { return []*QuarantinedEvent{} },
+		JSONOutputCodes: []int{http.StatusOK},
+		JSONHandler: func(_ *ffapi.APIRequest) (output interface{}, err error) {
+			return c.QuarantinedEvents(), nil
+		},
+	}
+}
+
+var postRetryQuarantinedEvent = func(c *ethConnector) *ffapi.Route {
+	return &ffapi.Route{
+		Name:   "postRetryQuarantinedEvent",
+		Path:   "/quarantine/{id}/retry",
+		Method: http.MethodPost,
+		PathParams: []*ffapi.PathParam{
+			{Name: "id", Description: msgs.APIParamQuarantineID},
+		},
+		QueryParams:     nil,
+		Description:     msgs.APIEndpointPostRetryQuarantined,
+		JSONInputValue:  func() interface{} { return &struct{}{} },
+		JSONOutputValue: func() interface{} { return &apitypes.EventWithContext{} },
+		JSONOutputCodes: []int{http.StatusOK},
+		JSONHandler: func(r *ffapi.APIRequest) (output interface{}, err error) {
+			id, err := fftypes.ParseUUID(r.Req.Context(), r.PP["id"])
+			if err != nil {
+				return nil, err
+			}
+			return c.RetryQuarantinedEvent(r.Req.Context(), id)
+		},
+	}
+}
+
+var getDeadLetters = func(c *ethConnector) *ffapi.Route {
+	return &ffapi.Route{
+		Name:            "getDeadLetters",
+		Path:            "/deadletter",
+		Method:          http.MethodGet,
+		PathParams:      nil,
+		QueryParams:     nil,
+		Description:     msgs.APIEndpointGetDeadLetters,
+		JSONInputValue:  nil,
+		JSONOutputValue: func() interface{} { return []*DeadLetterBatch{} },
+		JSONOutputCodes: []int{http.StatusOK},
+		JSONHandler: func(_ *ffapi.APIRequest) (output interface{}, err error) {
+			return c.DeadLetters(), nil
+		},
+	}
+}
+
+var postReplayDeadLetter = func(c *ethConnector) *ffapi.Route {
+	return &ffapi.Route{
+		Name:   "postReplayDeadLetter",
+		Path:   "/deadletter/{id}/replay",
+		Method: http.MethodPost,
+		PathParams: []*ffapi.PathParam{
+			{Name: "id", Description: msgs.APIParamDeadLetterID},
+		},
+		QueryParams:     nil,
+		Description:     msgs.APIEndpointPostReplayDeadLetter,
+		JSONInputValue:  func() interface{} { return &struct{}{} },
+		JSONOutputValue: func() interface{} { return &DeadLetterReplayResponse{} },
+		JSONOutputCodes: []int{http.StatusOK},
+		JSONHandler: func(r *ffapi.APIRequest) (output interface{}, err error) {
+			id, err := fftypes.ParseUUID(r.Req.Context(), r.PP["id"])
+			if err != nil {
+				return nil, err
+			}
+			return c.ReplayDeadLetter(r.Req.Context(), id)
+		},
+	}
+}
+
+var getAdminStatus = func(c *ethConnector) *ffapi.Route {
+	return &ffapi.Route{
+		Name:            "getAdminStatus",
+		Path:            "/admin/status",
+		Method:          http.MethodGet,
+		PathParams:      nil,
+		QueryParams:     nil,
+		Description:     msgs.APIEndpointGetAdminStatus,
+		JSONInputValue:  nil,
+		JSONOutputValue: func() interface{} { return &ConnectorStatus{} },
+		JSONOutputCodes: []int{http.StatusOK},
+		JSONHandler: func(r *ffapi.APIRequest) (output interface{}, err error) {
+			return c.Status(r.Req.Context()), nil
+		},
+	}
+}
+
+var getAdminSnapshot = func(c *ethConnector) *ffapi.Route {
+	return &ffapi.Route{
+		Name:            "getAdminSnapshot",
+		Path:            "/admin/snapshot",
+		Method:          http.MethodGet,
+		PathParams:      nil,
+		QueryParams:     nil,
+		Description:     msgs.APIEndpointGetAdminSnapshot,
+		JSONInputValue:  nil,
+		JSONOutputValue: func() interface{} { return &ConnectorSnapshot{} },
+		JSONOutputCodes: []int{http.StatusOK},
+		JSONHandler: func(r *ffapi.APIRequest) (output interface{}, err error) {
+			return c.Snapshot(r.Req.Context())
+		},
+	}
+}
+
+var postAdminRestore = func(c *ethConnector) *ffapi.Route {
+	return &ffapi.Route{
+		Name:            "postAdminRestore",
+		Path:            "/admin/restore",
+		Method:          http.MethodPost,
+		PathParams:      nil,
+		QueryParams:     nil,
+		Description:     msgs.APIEndpointPostAdminRestore,
+		JSONInputValue:  func() interface{} { return &ConnectorSnapshot{} },
+		JSONOutputValue: func() interface{} { return &SnapshotRestoreResponse{} },
+		JSONOutputCodes: []int{http.StatusOK},
+		JSONHandler: func(r *ffapi.APIRequest) (output interface{}, err error) {
+			return c.Restore(r.Req.Context(), r.Input.(*ConnectorSnapshot))
+		},
+	}
+}
+
+var getAdminCosts = func(c *ethConnector) *ffapi.Route {
+	return &ffapi.Route{
+		Name:       "getAdminCosts",
+		Path:       "/admin/costs",
+		Method:     http.MethodGet,
+		PathParams: nil,
+		QueryParams: []*ffapi.QueryParam{
+			{Name: "reset", Description: msgs.APIParamCostsReset, IsBool: true},
+		},
+		Description:     msgs.APIEndpointGetAdminCosts,
+		JSONInputValue:  nil,
+		JSONOutputValue: func() interface{} { return &CostReport{} },
+		JSONOutputCodes: []int{http.StatusOK},
+		JSONHandler: func(r *ffapi.APIRequest) (output interface{}, err error) {
+			return c.CostReport(r.Req.Context(), strings.EqualFold(r.QP["reset"], "true"))
+		},
+	}
+}
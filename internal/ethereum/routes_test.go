@@ -0,0 +1,77 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRouteServer(t *testing.T, c *ethConnector) (string, func()) {
+	router := mux.NewRouter()
+	hf := ffapi.HandlerFactory{DefaultRequestTimeout: 10 * time.Second}
+	for _, r := range c.Routes() {
+		router.Path(r.Path).Methods(r.Method).Handler(hf.RouteHandler(r))
+	}
+	server := httptest.NewServer(router)
+	return server.URL, server.Close
+}
+
+func TestGetContractCapabilitiesRoute(t *testing.T) {
+	_, c, mRPC, done := newTestConnector(t)
+	defer done()
+	url, close := newTestRouteServer(t, c)
+	defer close()
+
+	mockGetCode(mRPC, "0x6080604052")
+	mockSupportsInterface(mRPC, "01ffc9a7", abiTrue)
+	mockSupportsInterface(mRPC, "ffffffff", abiFalse)
+	mockSupportsInterface(mRPC, "80ac58cd", abiTrue)
+	mockSupportsInterface(mRPC, "d9b67a26", abiFalse)
+	mockSupportsInterface(mRPC, "5b5e139f", abiTrue)
+
+	res, err := http.Get(url + "/contracts/" + sampleContractAddress + "/capabilities?interfaces=0x80ac58cd,0xd9b67a26&interfaces=0x5b5e139f")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var caps ContractCapabilitiesResponse
+	err = json.NewDecoder(res.Body).Decode(&caps)
+	assert.NoError(t, err)
+	assert.True(t, caps.ERC165)
+	assert.Equal(t, map[string]bool{
+		"0x80ac58cd": true,
+		"0xd9b67a26": false,
+		"0x5b5e139f": true,
+	}, caps.Interfaces)
+}
+
+func TestGetContractCapabilitiesRouteBadAddress(t *testing.T) {
+	_, c, _, done := newTestConnector(t)
+	defer done()
+	url, close := newTestRouteServer(t, c)
+	defer close()
+
+	res, err := http.Get(url + "/contracts/wrong/capabilities")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+}
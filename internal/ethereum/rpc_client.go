@@ -0,0 +1,186 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"sync/atomic"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+)
+
+// managedBackend wraps each JSON/RPC client created from config, tracking the number of requests
+// in-flight, scheduling requests by priority within the limits of the endpoint, and allowing the
+// client to be replaced when the configuration is reloaded.
+// Requests already in-flight complete against the client they were sent to.
+type managedBackend struct {
+	mux       sync.RWMutex
+	client    rpcbackend.Backend
+	scheduler *rpcScheduler
+	inFlight  atomic.Int64
+	costs     *costTracker
+	endpoint  string
+}
+
+func newManagedBackend(client rpcbackend.Backend, scheduler *rpcScheduler) *managedBackend {
+	return &managedBackend{client: client, scheduler: scheduler}
+}
+
+// withCosts accounts the requests sent by the backend to the named endpoint, when cost accounting is enabled
+func (mb *managedBackend) withCosts(costs *costTracker, endpoint string) *managedBackend {
+	mb.costs = costs
+	mb.endpoint = endpoint
+	return mb
+}
+
+// rpcClientOptions are the connector level options, that apply to the clients of both the primary
+// and read endpoints
+type rpcClientOptions struct {
+	maxConcurrentRequests int64
+	compression           compressionOptions
+	faults                *faultInjector
+	correlationHeaders    *correlationHeaders
+	rateLimitHeaders      *rateLimitHeaders
+}
+
+func newRPCClientOptions(ctx context.Context, conf config.Section) (rpcClientOptions, error) {
+	faults, err := newFaultInjector(ctx, conf)
+	if err != nil {
+		return rpcClientOptions{}, err
+	}
+	return rpcClientOptions{
+		maxConcurrentRequests: conf.GetInt64(MaxConcurrentRequests),
+		compression: compressionOptions{
+			responses:      conf.GetBool(CompressionResponses),
+			requests:       conf.GetBool(CompressionRequests),
+			requestMinSize: conf.GetByteSize(CompressionRequestMinSize),
+		},
+		faults:             faults,
+		correlationHeaders: newCorrelationHeaders(conf),
+		rateLimitHeaders:   newRateLimitHeaders(conf),
+	}, nil
+}
+
+// newRPCClient builds a JSON/RPC client from an ffresty config section. This is an HTTP client,
+// unless the URL has the unix scheme, in which case it is an IPC client for the socket at the path.
+// The concurrency and rate limits are not applied by the client, but by the rpcScheduler of the
+// managed backend, so that requests are sent in priority order.
+func newRPCClient(ctx context.Context, conf config.Section, opts rpcClientOptions) (rpcbackend.Backend, error) {
+	requestTimeout := conf.GetDuration(ffresty.HTTPConfigRequestTimeout)
+	if u, err := url.Parse(conf.GetString(ffresty.HTTPConfigURL)); err == nil && u.Scheme == IPCURLScheme {
+		return opts.faults.wrap(newIPCClient(u.Path,
+			conf.GetDuration(ffresty.HTTPConnectionTimeout),
+			requestTimeout,
+		), requestTimeout), nil
+	}
+	httpConf, err := ffresty.GenerateConfig(ctx, conf)
+	if err != nil {
+		return nil, err
+	}
+	httpConf.ThrottleRequestsPerSecond = 0
+	httpConf.ThrottleBurst = 0
+	if httpConf.ProxyURL, err = egressProxyURL(ctx, conf); err != nil {
+		return nil, err
+	}
+	client := ffresty.NewWithConfig(ctx, *httpConf)
+	applyCompression(client, opts.compression)
+	applyCorrelationHeaders(client, opts.correlationHeaders)
+	applyRateLimitHeaders(client, opts.rateLimitHeaders)
+	return opts.faults.wrap(rpcbackend.NewRPCClient(client), requestTimeout), nil
+}
+
+func (mb *managedBackend) current() (rpcbackend.Backend, *rpcScheduler) {
+	mb.mux.RLock()
+	defer mb.mux.RUnlock()
+	return mb.client, mb.scheduler
+}
+
+func (mb *managedBackend) swap(client rpcbackend.Backend, scheduler *rpcScheduler) {
+	mb.mux.Lock()
+	defer mb.mux.Unlock()
+	if ic, ok := mb.client.(*ipcClient); ok && ic != client {
+		ic.close()
+	}
+	mb.client = client
+	mb.scheduler = scheduler
+}
+
+func (mb *managedBackend) CallRPC(ctx context.Context, result interface{}, method string, params ...interface{}) *rpcbackend.RPCError {
+	ctx = withCorrelationIDs(ctx)
+	mb.inFlight.Add(1)
+	defer mb.inFlight.Add(-1)
+	client, scheduler := mb.current()
+	if scheduler != nil {
+		if err := scheduler.acquire(ctx, rpcPriorityFor(ctx, method), method); err != nil {
+			return &rpcbackend.RPCError{Code: int64(rpcbackend.RPCCodeInternalError), Message: err.Error()}
+		}
+		defer scheduler.release()
+		ctx = withRPCScheduler(ctx, scheduler)
+	}
+	if err := checkCallerWaiting(ctx, method); err != nil {
+		return &rpcbackend.RPCError{Code: int64(rpcbackend.RPCCodeInternalError), Message: err.Error()}
+	}
+	rpcErr := client.CallRPC(ctx, result, method, params...)
+	mb.costs.record(ctx, mb.endpoint, method, rpcErr != nil)
+	return rpcErr
+}
+
+func (mb *managedBackend) SyncRequest(ctx context.Context, rpcReq *rpcbackend.RPCRequest) (*rpcbackend.RPCResponse, error) {
+	ctx = withCorrelationIDs(ctx)
+	mb.inFlight.Add(1)
+	defer mb.inFlight.Add(-1)
+	client, scheduler := mb.current()
+	if scheduler != nil {
+		if err := scheduler.acquire(ctx, rpcPriorityFor(ctx, rpcReq.Method), rpcReq.Method); err != nil {
+			return rpcbackend.RPCErrorResponse(err, rpcReq.ID, rpcbackend.RPCCodeInternalError), err
+		}
+		defer scheduler.release()
+		ctx = withRPCScheduler(ctx, scheduler)
+	}
+	if err := checkCallerWaiting(ctx, rpcReq.Method); err != nil {
+		return rpcbackend.RPCErrorResponse(err, rpcReq.ID, rpcbackend.RPCCodeInternalError), err
+	}
+	res, err := client.SyncRequest(ctx, rpcReq)
+	mb.costs.record(ctx, mb.endpoint, rpcReq.Method, err != nil || (res != nil && res.Error != nil))
+	return res, err
+}
+
+// checkCallerWaiting is called just before sending a request, so that a request is not sent to the node
+// once the caller has given up (including where it was waiting for the scheduler). Requests that have
+// been sent are cancelled by the client when the context is done, freeing the connection.
+func checkCallerWaiting(ctx context.Context, method string) error {
+	if ctx.Err() != nil {
+		log.L(ctx).Debugf("Abandoned %s request as the caller is no longer waiting: %s", method, ctx.Err())
+		return i18n.NewError(ctx, msgs.MsgRPCRequestAbandoned, method, ctx.Err())
+	}
+	return nil
+}
+
+func inFlightCount(b rpcbackend.RPC) *int64 {
+	if mb, ok := b.(*managedBackend); ok {
+		count := mb.inFlight.Load()
+		return &count
+	}
+	return nil
+}
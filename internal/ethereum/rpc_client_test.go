@@ -0,0 +1,81 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hyperledger/firefly-evmconnect/mocks/rpcbackendmocks"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestManagedBackendInFlight(t *testing.T) {
+	mRPC := &rpcbackendmocks.Backend{}
+	mb := newManagedBackend(mRPC, nil)
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_blockNumber").Return(nil).Run(func(args mock.Arguments) {
+		assert.Equal(t, int64(1), *inFlightCount(mb))
+	})
+	mRPC.On("SyncRequest", mock.Anything, mock.Anything).Return(&rpcbackend.RPCResponse{}, nil).Run(func(args mock.Arguments) {
+		assert.Equal(t, int64(1), *inFlightCount(mb))
+	})
+
+	rpcErr := mb.CallRPC(context.Background(), nil, "eth_blockNumber")
+	assert.Nil(t, rpcErr)
+	_, err := mb.SyncRequest(context.Background(), &rpcbackend.RPCRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), *inFlightCount(mb))
+	assert.Nil(t, inFlightCount(mRPC))
+
+	mRPC.AssertExpectations(t)
+}
+
+func TestManagedBackendSwap(t *testing.T) {
+	mRPC1 := &rpcbackendmocks.Backend{}
+	mRPC2 := &rpcbackendmocks.Backend{}
+	mb := newManagedBackend(mRPC1, nil)
+
+	mRPC1.On("CallRPC", mock.Anything, mock.Anything, "eth_blockNumber").Return(nil).Once()
+	mRPC2.On("CallRPC", mock.Anything, mock.Anything, "eth_blockNumber").Return(nil).Once()
+
+	assert.Nil(t, mb.CallRPC(context.Background(), nil, "eth_blockNumber"))
+	mb.swap(mRPC2, nil)
+	assert.Nil(t, mb.CallRPC(context.Background(), nil, "eth_blockNumber"))
+
+	mRPC1.AssertExpectations(t)
+	mRPC2.AssertExpectations(t)
+}
+
+func TestManagedBackendCallerGaveUp(t *testing.T) {
+	mRPC := &rpcbackendmocks.Backend{}
+	mb := newManagedBackend(mRPC, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	rpcErr := mb.CallRPC(ctx, nil, "eth_sendRawTransaction", "0x1234")
+	assert.Regexp(t, "FF23102.*eth_sendRawTransaction", rpcErr.Message)
+	res, err := mb.SyncRequest(ctx, &rpcbackend.RPCRequest{Method: "eth_blockNumber"})
+	assert.Regexp(t, "FF23102.*eth_blockNumber", err)
+	assert.Regexp(t, "FF23102", res.Error.Message)
+	assert.Equal(t, int64(0), *inFlightCount(mb))
+
+	// Nothing is sent to the node
+	mRPC.AssertExpectations(t)
+}
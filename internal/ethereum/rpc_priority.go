@@ -0,0 +1,197 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"golang.org/x/time/rate"
+)
+
+// rpcPriority is the class of a JSON/RPC request, which decides the order requests are sent to the
+// node in when the concurrency or rate limits of the endpoint are saturated
+type rpcPriority int
+
+const (
+	// rpcPriorityBulk is for catch-up queries over ranges of blocks, which can tolerate delay
+	rpcPriorityBulk rpcPriority = iota
+	// rpcPriorityNormal is the default for all other requests
+	rpcPriorityNormal
+	// rpcPriorityHigh is for submitting transactions, and confirming the transactions in-flight
+	rpcPriorityHigh
+	rpcPriorityClasses
+)
+
+func (p rpcPriority) String() string {
+	switch p {
+	case rpcPriorityBulk:
+		return "bulk"
+	case rpcPriorityHigh:
+		return "high"
+	default:
+		return "normal"
+	}
+}
+
+var highPriorityMethods = map[string]bool{
+	"eth_sendTransaction":              true,
+	"eth_sendTransactionAsync":         true,
+	"eth_sendRawTransaction":           true,
+	"eth_sendRawPrivateTransaction":    true,
+	"eth_getTransactionCount":          true,
+	"eth_getTransactionReceipt":        true,
+	"eth_getPrivateTransactionReceipt": true,
+}
+
+type rpcPriorityKey struct{}
+
+// withRPCPriority sets the priority of all the requests made with the context, overriding the
+// default priority of the method
+func withRPCPriority(ctx context.Context, priority rpcPriority) context.Context {
+	return context.WithValue(ctx, rpcPriorityKey{}, priority)
+}
+
+func rpcPriorityFor(ctx context.Context, method string) rpcPriority {
+	if priority, ok := ctx.Value(rpcPriorityKey{}).(rpcPriority); ok {
+		return priority
+	}
+	if highPriorityMethods[method] {
+		return rpcPriorityHigh
+	}
+	return rpcPriorityNormal
+}
+
+// rpcScheduler applies the concurrency and rate limits of an endpoint in front of the client, so
+// that waiting requests are sent in priority order (and in arrival order within each priority).
+// This replaces the limits of the underlying client, which would otherwise queue requests in
+// arrival order regardless of priority.
+type rpcScheduler struct {
+	maxConcurrent int64
+	limiter       *rate.Limiter
+
+	mux         sync.Mutex
+	active      int64
+	waiting     [rpcPriorityClasses][]chan struct{}
+	retryTimer  *time.Timer
+	pausedUntil time.Time // set from the rate limit headers of the responses of the provider
+}
+
+// newRPCScheduler builds the scheduler for an endpoint from the concurrency limit, and the throttle
+// settings of the ffresty config section of the endpoint. Returns nil if there are no limits, and the
+// rate limit headers of the provider are not used.
+func newRPCScheduler(conf config.Section, opts rpcClientOptions) *rpcScheduler {
+	limiter := ffresty.GetRateLimiter(conf.GetInt(ffresty.HTTPThrottleRequestsPerSecond), conf.GetInt(ffresty.HTTPThrottleBurst))
+	if opts.maxConcurrentRequests <= 0 && limiter == nil && opts.rateLimitHeaders == nil {
+		return nil
+	}
+	return &rpcScheduler{
+		maxConcurrent: opts.maxConcurrentRequests,
+		limiter:       limiter,
+	}
+}
+
+// acquire waits for the request to be scheduled, after which release must be called
+func (s *rpcScheduler) acquire(ctx context.Context, priority rpcPriority, method string) error {
+	ready := make(chan struct{})
+	s.mux.Lock()
+	s.waiting[priority] = append(s.waiting[priority], ready)
+	s.dispatch()
+	s.mux.Unlock()
+
+	select {
+	case <-ready:
+		return nil
+	case <-ctx.Done():
+	}
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	for i, w := range s.waiting[priority] {
+		if w == ready {
+			s.waiting[priority] = append(s.waiting[priority][:i], s.waiting[priority][i+1:]...)
+			return i18n.NewError(ctx, msgs.MsgRPCSchedulingCancelled, priority, method, ctx.Err())
+		}
+	}
+	// We were scheduled at the same time as being cancelled, so it is fine to continue
+	return nil
+}
+
+func (s *rpcScheduler) release() {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.active--
+	s.dispatch()
+}
+
+// dispatch must be called holding the mutex, and schedules the highest priority waiting requests
+// until there are no free slots, or the rate limit has been reached
+func (s *rpcScheduler) dispatch() {
+	for {
+		if s.maxConcurrent > 0 && s.active >= s.maxConcurrent {
+			return
+		}
+		priority := rpcPriorityHigh
+		for ; priority >= rpcPriorityBulk && len(s.waiting[priority]) == 0; priority-- {
+		}
+		if priority < rpcPriorityBulk {
+			return
+		}
+		if delay := time.Until(s.pausedUntil); delay > 0 {
+			if s.retryTimer == nil {
+				s.retryTimer = time.AfterFunc(delay, s.retryDispatch)
+			}
+			return
+		}
+		if s.limiter != nil {
+			r := s.limiter.Reserve()
+			if delay := r.Delay(); delay > 0 {
+				// Hand the token back, so it can go to whichever request is the highest priority when it is available
+				r.Cancel()
+				if s.retryTimer == nil {
+					s.retryTimer = time.AfterFunc(delay, s.retryDispatch)
+				}
+				return
+			}
+		}
+		ready := s.waiting[priority][0]
+		s.waiting[priority] = s.waiting[priority][1:]
+		s.active++
+		close(ready)
+	}
+}
+
+// pause holds back the requests that are waiting, and any new requests, until a time
+func (s *rpcScheduler) pause(until time.Time) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if until.After(s.pausedUntil) {
+		s.pausedUntil = until
+	}
+}
+
+func (s *rpcScheduler) retryDispatch() {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.retryTimer = nil
+	s.dispatch()
+}
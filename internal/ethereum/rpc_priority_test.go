@@ -0,0 +1,174 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-evmconnect/mocks/rpcbackendmocks"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/time/rate"
+)
+
+func waitForQueued(t *testing.T, s *rpcScheduler, priority rpcPriority, count int) {
+	for i := 0; i < 1000; i++ {
+		s.mux.Lock()
+		queued := len(s.waiting[priority])
+		s.mux.Unlock()
+		if queued == count {
+			return
+		}
+		time.Sleep(1 * time.Millisecond)
+	}
+	assert.Fail(t, "request not queued", "priority=%s", priority)
+}
+
+// queueRequests queues one request at each priority, lowest first, recording the order they are scheduled in
+func queueRequests(t *testing.T, s *rpcScheduler) <-chan rpcPriority {
+	scheduled := make(chan rpcPriority, rpcPriorityClasses)
+	var wg sync.WaitGroup
+	for _, priority := range []rpcPriority{rpcPriorityBulk, rpcPriorityNormal, rpcPriorityHigh} {
+		wg.Add(1)
+		go func(priority rpcPriority) {
+			defer wg.Done()
+			err := s.acquire(context.Background(), priority, "eth_test")
+			assert.NoError(t, err)
+			scheduled <- priority
+			s.release()
+		}(priority)
+		waitForQueued(t, s, priority, 1)
+	}
+	go func() {
+		wg.Wait()
+		close(scheduled)
+	}()
+	return scheduled
+}
+
+func TestRPCPriorityFor(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, rpcPriorityHigh, rpcPriorityFor(ctx, "eth_sendRawTransaction"))
+	assert.Equal(t, rpcPriorityHigh, rpcPriorityFor(ctx, "eth_getTransactionReceipt"))
+	assert.Equal(t, rpcPriorityNormal, rpcPriorityFor(ctx, "eth_getLogs"))
+	assert.Equal(t, rpcPriorityBulk, rpcPriorityFor(withRPCPriority(ctx, rpcPriorityBulk), "eth_getLogs"))
+	assert.Equal(t, rpcPriorityBulk, rpcPriorityFor(withRPCPriority(ctx, rpcPriorityBulk), "eth_getTransactionReceipt"))
+
+	assert.Equal(t, "bulk", rpcPriorityBulk.String())
+	assert.Equal(t, "normal", rpcPriorityNormal.String())
+	assert.Equal(t, "high", rpcPriorityHigh.String())
+}
+
+func TestNewRPCScheduler(t *testing.T) {
+	conf := config.RootSection("scheduler_test")
+	ffresty.InitConfig(conf)
+
+	assert.Nil(t, newRPCScheduler(conf, rpcClientOptions{}))
+
+	s := newRPCScheduler(conf, rpcClientOptions{maxConcurrentRequests: 5})
+	assert.Equal(t, int64(5), s.maxConcurrent)
+	assert.Nil(t, s.limiter)
+
+	conf.Set(ffresty.HTTPThrottleRequestsPerSecond, 10)
+	s = newRPCScheduler(conf, rpcClientOptions{})
+	assert.Equal(t, int64(0), s.maxConcurrent)
+	assert.Equal(t, rate.Limit(10), s.limiter.Limit())
+	assert.Equal(t, 10, s.limiter.Burst())
+}
+
+func TestRPCSchedulerConcurrencyPriorityOrder(t *testing.T) {
+	s := &rpcScheduler{maxConcurrent: 1}
+	err := s.acquire(context.Background(), rpcPriorityNormal, "eth_test")
+	assert.NoError(t, err)
+
+	scheduled := queueRequests(t, s)
+	s.release()
+
+	order := []rpcPriority{}
+	for priority := range scheduled {
+		order = append(order, priority)
+	}
+	assert.Equal(t, []rpcPriority{rpcPriorityHigh, rpcPriorityNormal, rpcPriorityBulk}, order)
+	assert.Equal(t, int64(0), s.active)
+}
+
+func TestRPCSchedulerRateLimitPriorityOrder(t *testing.T) {
+	s := &rpcScheduler{limiter: rate.NewLimiter(rate.Every(20*time.Millisecond), 1)}
+	err := s.acquire(context.Background(), rpcPriorityNormal, "eth_test")
+	assert.NoError(t, err)
+	s.release()
+
+	// The token bucket is empty, so requests queue until the timer fires
+	scheduled := queueRequests(t, s)
+
+	order := []rpcPriority{}
+	for priority := range scheduled {
+		order = append(order, priority)
+	}
+	assert.Equal(t, []rpcPriority{rpcPriorityHigh, rpcPriorityNormal, rpcPriorityBulk}, order)
+}
+
+func TestRPCSchedulerCancelled(t *testing.T) {
+	s := &rpcScheduler{maxConcurrent: 1}
+	err := s.acquire(context.Background(), rpcPriorityHigh, "eth_sendRawTransaction")
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		waitForQueued(t, s, rpcPriorityBulk, 1)
+		cancel()
+	}()
+	err = s.acquire(ctx, rpcPriorityBulk, "eth_getLogs")
+	assert.Regexp(t, "FF23101.*bulk.*eth_getLogs", err)
+	assert.Empty(t, s.waiting[rpcPriorityBulk])
+
+	s.release()
+	assert.Equal(t, int64(0), s.active)
+}
+
+func TestManagedBackendScheduled(t *testing.T) {
+	mRPC := &rpcbackendmocks.Backend{}
+	mb := newManagedBackend(mRPC, &rpcScheduler{maxConcurrent: 1})
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_blockNumber").Return(nil).Run(func(args mock.Arguments) {
+		assert.Equal(t, int64(1), mb.scheduler.active)
+	})
+	mRPC.On("SyncRequest", mock.Anything, mock.Anything).Return(&rpcbackend.RPCResponse{}, nil)
+
+	assert.Nil(t, mb.CallRPC(context.Background(), nil, "eth_blockNumber"))
+	_, err := mb.SyncRequest(context.Background(), &rpcbackend.RPCRequest{Method: "eth_blockNumber"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), mb.scheduler.active)
+
+	// Requests waiting for a slot respect the context
+	_ = mb.scheduler.acquire(context.Background(), rpcPriorityHigh, "eth_sendRawTransaction")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	rpcErr := mb.CallRPC(ctx, nil, "eth_blockNumber")
+	assert.Regexp(t, "FF23101", rpcErr.Message)
+	res, err := mb.SyncRequest(ctx, &rpcbackend.RPCRequest{Method: "eth_blockNumber"})
+	assert.Regexp(t, "FF23101", err)
+	assert.Regexp(t, "FF23101", res.Error.Message)
+
+	mRPC.AssertExpectations(t)
+}
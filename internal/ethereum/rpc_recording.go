@@ -0,0 +1,271 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+)
+
+const (
+	RPCRecordingModeRecord = "record"
+	RPCRecordingModeReplay = "replay"
+)
+
+// RPCRecord is written to the recording file for every request to the primary endpoint, with the response
+// or error it received
+type RPCRecord struct {
+	Method string               `json:"method"`
+	Params []*fftypes.JSONAny   `json:"params,omitempty"`
+	Result *fftypes.JSONAny     `json:"result,omitempty"`
+	Error  *rpcbackend.RPCError `json:"error,omitempty"`
+}
+
+// rpcRecording captures the JSON/RPC traffic of the primary endpoint to a file, or replays a file captured
+// earlier in place of the endpoint. As the recording applies to each client built for the primary endpoint,
+// it continues across config reloads.
+type rpcRecording struct {
+	mode     string
+	fileName string
+
+	fileMux sync.Mutex
+	file    *os.File
+
+	replayMux sync.Mutex
+	replay    map[string][]*RPCRecord
+}
+
+// newRPCRecording returns nil if no recording mode is configured. In replay mode the file is loaded up front,
+// while in record mode it is not opened until open is called, once nothing else can fail.
+func newRPCRecording(ctx context.Context, conf config.Section) (*rpcRecording, error) {
+	mode := strings.ToLower(conf.GetString(RPCRecordingMode))
+	if mode == "" {
+		return nil, nil
+	}
+	rr := &rpcRecording{mode: mode, fileName: conf.GetString(RPCRecordingFile)}
+	switch mode {
+	case RPCRecordingModeRecord:
+	case RPCRecordingModeReplay:
+		if err := rr.load(ctx); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidRPCRecordingMode, mode, []string{RPCRecordingModeRecord, RPCRecordingModeReplay})
+	}
+	return rr, nil
+}
+
+func (rr *rpcRecording) replaying() bool {
+	return rr != nil && rr.mode == RPCRecordingModeReplay
+}
+
+// load reads the records of the file, queued in order for each distinct request
+func (rr *rpcRecording) load(ctx context.Context) error {
+	f, err := os.Open(rr.fileName)
+	if err != nil {
+		return i18n.WrapError(ctx, err, msgs.MsgRPCRecordingFileFailed, rr.fileName)
+	}
+	defer f.Close()
+	rr.replay = make(map[string][]*RPCRecord)
+	decoder := json.NewDecoder(f)
+	for {
+		var rec *RPCRecord
+		if err := decoder.Decode(&rec); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return i18n.WrapError(ctx, err, msgs.MsgRPCRecordingFileFailed, rr.fileName)
+		}
+		key := rpcRecordKey(rec.Method, rec.Params)
+		rr.replay[key] = append(rr.replay[key], rec)
+	}
+	log.L(ctx).Infof("Replaying %d distinct JSON/RPC requests from %s", len(rr.replay), rr.fileName)
+	return nil
+}
+
+func (rr *rpcRecording) open(ctx context.Context) error {
+	if rr == nil || rr.mode != RPCRecordingModeRecord {
+		return nil
+	}
+	f, err := os.OpenFile(rr.fileName, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0640)
+	if err != nil {
+		return i18n.WrapError(ctx, err, msgs.MsgRPCRecordingFileFailed, rr.fileName)
+	}
+	rr.fileMux.Lock()
+	rr.file = f
+	rr.fileMux.Unlock()
+	return nil
+}
+
+func (rr *rpcRecording) close() {
+	if rr == nil {
+		return
+	}
+	rr.fileMux.Lock()
+	defer rr.fileMux.Unlock()
+	if rr.file != nil {
+		_ = rr.file.Close()
+		rr.file = nil
+	}
+}
+
+// wrap returns the client for the primary endpoint with the recording applied. In replay mode the client is
+// not used at all.
+func (rr *rpcRecording) wrap(client rpcbackend.Backend) rpcbackend.Backend {
+	switch {
+	case rr == nil:
+		return client
+	case rr.replaying():
+		return &rpcReplayClient{recording: rr}
+	default:
+		return &rpcRecordClient{client: client, recording: rr}
+	}
+}
+
+func (rr *rpcRecording) write(ctx context.Context, rec *RPCRecord) {
+	b, _ := json.Marshal(rec)
+	rr.fileMux.Lock()
+	defer rr.fileMux.Unlock()
+	if rr.file == nil {
+		return
+	}
+	if _, err := rr.file.Write(append(b, '\n')); err != nil {
+		log.L(ctx).Errorf("Failed to write JSON/RPC record %s: %s", b, err)
+	}
+}
+
+// next returns the recorded response to a request, in the order they were recorded where the same request
+// was made more than once. Once they have all been returned, the last one is repeated, so polling requests
+// such as eth_blockNumber settle on the final recorded state.
+func (rr *rpcRecording) next(method string, params []*fftypes.JSONAny) *RPCRecord {
+	key := rpcRecordKey(method, params)
+	rr.replayMux.Lock()
+	defer rr.replayMux.Unlock()
+	recs := rr.replay[key]
+	if len(recs) == 0 {
+		return nil
+	}
+	if len(recs) > 1 {
+		rr.replay[key] = recs[1:]
+	}
+	return recs[0]
+}
+
+// rpcRecordKey identifies a request by its method and parameters, which are compact when marshalled
+func rpcRecordKey(method string, params []*fftypes.JSONAny) string {
+	if len(params) == 0 {
+		return method + " []"
+	}
+	b, _ := json.Marshal(params)
+	return method + " " + string(b)
+}
+
+func rpcParamsJSON(ctx context.Context, method string, params []interface{}) ([]*fftypes.JSONAny, *rpcbackend.RPCError) {
+	jsonParams := make([]*fftypes.JSONAny, len(params))
+	for i, param := range params {
+		b, err := json.Marshal(param)
+		if err != nil {
+			return nil, rpcbackend.NewRPCError(ctx, rpcbackend.RPCCodeInvalidRequest, msgs.MsgIPCInvalidParam, i, method, err)
+		}
+		jsonParams[i] = fftypes.JSONAnyPtrBytes(b)
+	}
+	return jsonParams, nil
+}
+
+// callRPCWithSyncRequest implements CallRPC for the recording clients, on top of their SyncRequest
+func callRPCWithSyncRequest(ctx context.Context, b rpcbackend.Backend, result interface{}, method string, params []interface{}) *rpcbackend.RPCError {
+	jsonParams, rpcErr := rpcParamsJSON(ctx, method, params)
+	if rpcErr != nil {
+		return rpcErr
+	}
+	res, err := b.SyncRequest(ctx, &rpcbackend.RPCRequest{JSONRpc: "2.0", Method: method, Params: jsonParams})
+	if err != nil {
+		if res != nil && res.Error != nil && res.Error.Code != 0 {
+			return res.Error
+		}
+		return &rpcbackend.RPCError{Code: int64(rpcbackend.RPCCodeInternalError), Message: err.Error()}
+	}
+	if err := json.Unmarshal(res.Result.Bytes(), &result); err != nil {
+		return rpcbackend.NewRPCError(ctx, rpcbackend.RPCCodeParseError, msgs.MsgIPCResultParseFailed, result, err)
+	}
+	return nil
+}
+
+// rpcRecordClient passes requests through to the client of the endpoint, recording each with its response
+type rpcRecordClient struct {
+	client    rpcbackend.Backend
+	recording *rpcRecording
+}
+
+func (rc *rpcRecordClient) CallRPC(ctx context.Context, result interface{}, method string, params ...interface{}) *rpcbackend.RPCError {
+	return callRPCWithSyncRequest(ctx, rc, result, method, params)
+}
+
+func (rc *rpcRecordClient) SyncRequest(ctx context.Context, rpcReq *rpcbackend.RPCRequest) (*rpcbackend.RPCResponse, error) {
+	res, err := rc.client.SyncRequest(ctx, rpcReq)
+	rec := &RPCRecord{Method: rpcReq.Method, Params: rpcReq.Params}
+	if err != nil {
+		// The client populates the response on all error paths, but the error is the more complete description
+		rec.Error = &rpcbackend.RPCError{Code: int64(rpcbackend.RPCCodeInternalError), Message: err.Error()}
+		if res != nil && res.Error != nil && res.Error.Code != 0 {
+			rec.Error = res.Error
+		}
+	} else {
+		rec.Result = res.Result
+	}
+	rc.recording.write(ctx, rec)
+	return res, err
+}
+
+// rpcReplayClient answers requests from the recording, failing any request that was not recorded
+type rpcReplayClient struct {
+	recording *rpcRecording
+}
+
+func (rc *rpcReplayClient) CallRPC(ctx context.Context, result interface{}, method string, params ...interface{}) *rpcbackend.RPCError {
+	return callRPCWithSyncRequest(ctx, rc, result, method, params)
+}
+
+func (rc *rpcReplayClient) SyncRequest(ctx context.Context, rpcReq *rpcbackend.RPCRequest) (*rpcbackend.RPCResponse, error) {
+	rec := rc.recording.next(rpcReq.Method, rpcReq.Params)
+	if rec == nil {
+		err := i18n.NewError(ctx, msgs.MsgRPCRequestNotRecorded, rpcReq.Method, rc.recording.fileName)
+		log.L(ctx).Errorf("RPC <-- ERROR: %s", err)
+		return rpcbackend.RPCErrorResponse(err, rpcReq.ID, rpcbackend.RPCCodeInternalError), err
+	}
+	log.L(ctx).Debugf("RPC <-- %s (replayed)", rpcReq.Method)
+	if rec.Error != nil {
+		return &rpcbackend.RPCResponse{JSONRpc: "2.0", ID: rpcReq.ID, Error: rec.Error}, errors.New(rec.Error.Message)
+	}
+	result := rec.Result
+	if result == nil {
+		result = fftypes.JSONAnyPtr(fftypes.NullString)
+	}
+	return &rpcbackend.RPCResponse{JSONRpc: "2.0", ID: rpcReq.ID, Result: result}, nil
+}
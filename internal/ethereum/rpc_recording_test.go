@@ -0,0 +1,189 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-evmconnect/mocks/rpcbackendmocks"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func testRPCRecordingConf(mode, fileName string) config.Section {
+	config.RootConfigReset()
+	conf := config.RootSection("unittest")
+	InitConfig(conf)
+	conf.Set(RPCRecordingMode, mode)
+	conf.Set(RPCRecordingFile, fileName)
+	return conf
+}
+
+func mockSyncResult(mRPC *rpcbackendmocks.Backend, method, result string) {
+	mRPC.On("SyncRequest", mock.Anything, mock.MatchedBy(func(req *rpcbackend.RPCRequest) bool {
+		return req.Method == method
+	})).Return(&rpcbackend.RPCResponse{Result: fftypes.JSONAnyPtr(result)}, nil).Once()
+}
+
+func TestRPCRecordingConfig(t *testing.T) {
+	rr, err := newRPCRecording(context.Background(), testRPCRecordingConf("", ""))
+	assert.NoError(t, err)
+	assert.Nil(t, rr)
+	assert.False(t, rr.replaying())
+	client := &rpcbackendmocks.Backend{}
+	assert.Equal(t, client, rr.wrap(client))
+	assert.NoError(t, rr.open(context.Background()))
+	rr.close()
+
+	_, err = newRPCRecording(context.Background(), testRPCRecordingConf("wrong", ""))
+	assert.Regexp(t, "FF23150.*wrong", err)
+
+	_, err = newRPCRecording(context.Background(), testRPCRecordingConf(RPCRecordingModeReplay, filepath.Join(t.TempDir(), "missing.jsonl")))
+	assert.Regexp(t, "FF23151", err)
+
+	badFile := filepath.Join(t.TempDir(), "bad.jsonl")
+	err = os.WriteFile(badFile, []byte(`{"method":"eth_chainId"}`+"\n!bad"), 0600)
+	assert.NoError(t, err)
+	_, err = newRPCRecording(context.Background(), testRPCRecordingConf(RPCRecordingModeReplay, badFile))
+	assert.Regexp(t, "FF23151", err)
+
+	rr, err = newRPCRecording(context.Background(), testRPCRecordingConf(RPCRecordingModeRecord, filepath.Join(t.TempDir(), "nodir", "rpc.jsonl")))
+	assert.NoError(t, err)
+	err = rr.open(context.Background())
+	assert.Regexp(t, "FF23151", err)
+}
+
+func TestRPCRecordAndReplay(t *testing.T) {
+	ctx := context.Background()
+	fileName := filepath.Join(t.TempDir(), "rpc.jsonl")
+
+	mRPC := &rpcbackendmocks.Backend{}
+	mockSyncResult(mRPC, "eth_blockNumber", `"0x1"`)
+	mockSyncResult(mRPC, "eth_blockNumber", `"0x2"`)
+	mockSyncResult(mRPC, "eth_getBalance", `"0x64"`)
+	mRPC.On("SyncRequest", mock.Anything, mock.MatchedBy(func(req *rpcbackend.RPCRequest) bool {
+		return req.Method == "eth_call"
+	})).Return(&rpcbackend.RPCResponse{Error: &rpcbackend.RPCError{Code: -32000, Message: "execution reverted"}}, errors.New("execution reverted")).Once()
+	mRPC.On("SyncRequest", mock.Anything, mock.MatchedBy(func(req *rpcbackend.RPCRequest) bool {
+		return req.Method == "eth_gasPrice"
+	})).Return(&rpcbackend.RPCResponse{}, errors.New("pop")).Once()
+
+	rr, err := newRPCRecording(ctx, testRPCRecordingConf(RPCRecordingModeRecord, fileName))
+	assert.NoError(t, err)
+	recordClient := rr.wrap(mRPC)
+	rr.write(ctx, &RPCRecord{Method: "dropped"}) // before the file is open
+	assert.NoError(t, rr.open(ctx))
+
+	var blockNumber ethtypes.HexInteger
+	assert.Nil(t, recordClient.CallRPC(ctx, &blockNumber, "eth_blockNumber"))
+	assert.Equal(t, int64(1), blockNumber.BigInt().Int64())
+	assert.Nil(t, recordClient.CallRPC(ctx, &blockNumber, "eth_blockNumber"))
+	var balance ethtypes.HexInteger
+	assert.Nil(t, recordClient.CallRPC(ctx, &balance, "eth_getBalance", "0xabcd", "latest"))
+	rpcErr := recordClient.CallRPC(ctx, &balance, "eth_call", map[string]string{"to": "0x1234"}, "latest")
+	assert.Regexp(t, "execution reverted", rpcErr.Message)
+	rpcErr = recordClient.CallRPC(ctx, &balance, "eth_gasPrice")
+	assert.Regexp(t, "pop", rpcErr.Message)
+	rpcErr = recordClient.CallRPC(ctx, &balance, "eth_getCode", make(chan int))
+	assert.Equal(t, int64(rpcbackend.RPCCodeInvalidRequest), rpcErr.Code)
+	rr.close()
+	mRPC.AssertExpectations(t)
+
+	rr, err = newRPCRecording(ctx, testRPCRecordingConf(RPCRecordingModeReplay, fileName))
+	assert.NoError(t, err)
+	assert.True(t, rr.replaying())
+	replayClient := rr.wrap(nil)
+
+	// Repeated requests are answered in order, and the last answer is repeated
+	for _, expected := range []int64{1, 2, 2} {
+		assert.Nil(t, replayClient.CallRPC(ctx, &blockNumber, "eth_blockNumber"))
+		assert.Equal(t, expected, blockNumber.BigInt().Int64())
+	}
+	assert.Nil(t, replayClient.CallRPC(ctx, &balance, "eth_getBalance", "0xabcd", "latest"))
+	assert.Equal(t, int64(100), balance.BigInt().Int64())
+	rpcErr = replayClient.CallRPC(ctx, &balance, "eth_call", map[string]string{"to": "0x1234"}, "latest")
+	assert.Equal(t, int64(-32000), rpcErr.Code)
+	assert.Regexp(t, "execution reverted", rpcErr.Message)
+	rpcErr = replayClient.CallRPC(ctx, &balance, "eth_gasPrice")
+	assert.Regexp(t, "pop", rpcErr.Message)
+
+	rpcErr = replayClient.CallRPC(ctx, &balance, "eth_getBalance", "0xabcd", "0x1")
+	assert.Regexp(t, "FF23152.*eth_getBalance", rpcErr.Message)
+	var wrongType bool
+	rpcErr = replayClient.CallRPC(ctx, &wrongType, "eth_blockNumber")
+	assert.Equal(t, int64(rpcbackend.RPCCodeParseError), rpcErr.Code)
+	rpcErr = replayClient.CallRPC(ctx, &balance, "eth_getCode", make(chan int))
+	assert.Equal(t, int64(rpcbackend.RPCCodeInvalidRequest), rpcErr.Code)
+}
+
+func TestRPCReplayNullResult(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "rpc.jsonl")
+	err := os.WriteFile(fileName, []byte(`{"method":"eth_getTransactionReceipt","params":["0x1234"]}`+"\n"), 0600)
+	assert.NoError(t, err)
+	rr, err := newRPCRecording(context.Background(), testRPCRecordingConf(RPCRecordingModeReplay, fileName))
+	assert.NoError(t, err)
+
+	var receipt *txReceiptJSONRPC
+	rpcErr := rr.wrap(nil).CallRPC(context.Background(), &receipt, "eth_getTransactionReceipt", "0x1234")
+	assert.Nil(t, rpcErr)
+	assert.Nil(t, receipt)
+}
+
+func TestRPCReplayConnector(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "rpc.jsonl")
+	err := os.WriteFile(fileName, []byte(`{"method":"eth_chainId","result":"0x7a69"}`+"\n"), 0600)
+	assert.NoError(t, err)
+	conf := testRPCRecordingConf(RPCRecordingModeReplay, fileName)
+	conf.Set(BlockPollingInterval, "1h")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cc, err := NewEthereumConnector(ctx, conf)
+	assert.NoError(t, err)
+	c := cc.(*ethConnector)
+	var chainID string
+	rpcErr := c.backend.CallRPC(ctx, &chainID, "eth_chainId")
+	assert.Nil(t, rpcErr)
+	assert.Equal(t, "0x7a69", chainID)
+
+	err = c.ReloadConfig(ctx, conf)
+	assert.NoError(t, err)
+	rpcErr = c.backend.CallRPC(ctx, &chainID, "eth_chainId")
+	assert.Nil(t, rpcErr)
+
+	cancel()
+	c.WaitClosed()
+}
+
+func TestRPCRecordingConnectorOpenFail(t *testing.T) {
+	conf := testRPCRecordingConf(RPCRecordingModeRecord, filepath.Join(t.TempDir(), "nodir", "rpc.jsonl"))
+	conf.Set(ffresty.HTTPConfigURL, "http://localhost:8545")
+	_, err := NewEthereumConnector(context.Background(), conf)
+	assert.Regexp(t, "FF23151", err)
+
+	conf = testRPCRecordingConf("wrong", "")
+	_, err = NewEthereumConnector(context.Background(), conf)
+	assert.Regexp(t, "FF23150", err)
+}
@@ -0,0 +1,121 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/txhandler/simple"
+)
+
+type sendAttempt struct {
+	contentHash string
+	done        chan struct{}
+	completed   time.Time
+	abandoned   bool
+	res         *ffcapi.TransactionSendResponse
+	reason      ffcapi.ErrorReason
+	err         error
+}
+
+// sendRequestID is the idempotency key of a submission. FFCAPI requests do not carry a request ID, so this is
+// the ID of the FFTM managed transaction, from the run context the simple transaction handler passes in.
+func sendRequestID(ctx context.Context) string {
+	if rc, ok := ctx.(*simple.RunContext); ok && rc.TX != nil {
+		return rc.TX.ID
+	}
+	return correlationFor(ctx).txID
+}
+
+// sendContentHash distinguishes a resubmission of a request from a new submission under the same request
+// ID, such as a gas price increase from the policy engine, which must still be sent to the node
+func sendContentHash(req *ffcapi.TransactionSendRequest) string {
+	b, _ := json.Marshal(req)
+	hash := sha256.Sum256(b)
+	return hex.EncodeToString(hash[:])
+}
+
+// deduplicatedSend returns the result of a submission with the same request ID that is in-flight, or that
+// completed successfully within the deduplication window, rather than re-submitting to the node
+func (c *ethConnector) deduplicatedSend(ctx context.Context, req *ffcapi.TransactionSendRequest) (*ffcapi.TransactionSendResponse, ffcapi.ErrorReason, error) {
+	requestID := sendRequestID(ctx)
+	if requestID == "" {
+		return c.sendTransaction(ctx, req)
+	}
+	contentHash := sendContentHash(req)
+
+	for {
+		c.sendDedupMux.Lock()
+		now := time.Now()
+		for k, a := range c.sendAttempts {
+			if !a.completed.IsZero() && now.Sub(a.completed) > c.sendDedupWindow {
+				delete(c.sendAttempts, k)
+			}
+		}
+		existing, ok := c.sendAttempts[requestID]
+		if ok && existing.contentHash != contentHash {
+			log.L(ctx).Debugf("Transaction submission %s has changed since the previous attempt - submitting", requestID)
+			ok = false
+		}
+		if !ok {
+			existing = &sendAttempt{contentHash: contentHash, done: make(chan struct{})}
+			c.sendAttempts[requestID] = existing
+		}
+		c.sendDedupMux.Unlock()
+
+		if !ok {
+			return c.leadSend(ctx, requestID, existing, req)
+		}
+
+		log.L(ctx).Debugf("Duplicate transaction submission %s - returning result of previous attempt", requestID)
+		select {
+		case <-existing.done:
+		case <-ctx.Done():
+			return nil, "", i18n.NewError(ctx, msgs.MsgDuplicateSendWaitCancelled, requestID, ctx.Err())
+		}
+		if !existing.abandoned {
+			return existing.res, existing.reason, existing.err
+		}
+		// The caller of the previous attempt gave up, which cancelled the call to the node, but this caller is still waiting
+		log.L(ctx).Debugf("Previous attempt of transaction submission %s was abandoned by its caller - submitting", requestID)
+	}
+}
+
+func (c *ethConnector) leadSend(ctx context.Context, requestID string, attempt *sendAttempt, req *ffcapi.TransactionSendRequest) (*ffcapi.TransactionSendResponse, ffcapi.ErrorReason, error) {
+	attempt.res, attempt.reason, attempt.err = c.sendTransaction(ctx, req)
+	c.sendDedupMux.Lock()
+	if attempt.err != nil {
+		// Failures are not retained, so a later retry is submitted to the node
+		attempt.abandoned = ctx.Err() != nil
+		if c.sendAttempts[requestID] == attempt {
+			delete(c.sendAttempts, requestID)
+		}
+	} else {
+		attempt.completed = time.Now()
+	}
+	c.sendDedupMux.Unlock()
+	close(attempt.done)
+	return attempt.res, attempt.reason, attempt.err
+}
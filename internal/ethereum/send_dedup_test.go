@@ -0,0 +1,248 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-evmconnect/mocks/rpcbackendmocks"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/apitypes"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/txhandler/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func mockSendRaw(mRPC *rpcbackendmocks.Backend) *mock.Call {
+	return mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_sendRawTransaction", mock.Anything).
+		Run(func(args mock.Arguments) {
+			*(args[1].(*ethtypes.HexBytes0xPrefix)) = ethtypes.MustNewHexBytes0xPrefix("0xdc246933185b64d89e93d47bb6fa0500b9da8f168d5ccf39aebc7f6c36692fe2")
+		}).
+		Return(nil)
+}
+
+func newTestDedupConnector(t *testing.T) (context.Context, *ethConnector, *rpcbackendmocks.Backend, func()) {
+	return newTestConnector(t, func(conf config.Section) {
+		conf.Set(SendDeduplicationWindow, "2s")
+	})
+}
+
+func testSendRunContext(ctx context.Context, txID string) *simple.RunContext {
+	return &simple.RunContext{Context: ctx, TX: &apitypes.ManagedTX{ID: txID}}
+}
+
+func TestSendDeduplicatesRecentlyCompleted(t *testing.T) {
+	ctx, c, mRPC, done := newTestDedupConnector(t)
+	defer done()
+
+	mockSendRaw(mRPC).Once()
+
+	var req ffcapi.TransactionSendRequest
+	err := json.Unmarshal([]byte(sampleSendRawTX), &req)
+	assert.NoError(t, err)
+	rc := testSendRunContext(ctx, "ns1:tx1")
+	res1, _, err := c.TransactionSend(rc, &req)
+	assert.NoError(t, err)
+	res2, _, err := c.TransactionSend(testSendRunContext(ctx, "ns1:tx1"), &req)
+	assert.NoError(t, err)
+	assert.Equal(t, res1, res2)
+
+	// A changed submission of the same transaction, such as a gas price increase, is submitted
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_sendRawTransaction", sampleSignedLegacyTX).
+		Return(&rpcbackend.RPCError{Message: "pop"}).Times(3)
+	req2 := req
+	req2.TransactionData = sampleSignedLegacyTX
+	_, _, err = c.TransactionSend(rc, &req2)
+	assert.Regexp(t, "pop", err)
+
+	// Failures are not retained
+	_, _, err = c.TransactionSend(rc, &req2)
+	assert.Regexp(t, "pop", err)
+	assert.Empty(t, c.sendAttempts)
+
+	// Identical content for a different transaction is submitted
+	_, _, err = c.TransactionSend(testSendRunContext(ctx, "ns1:tx2"), &req2)
+	assert.Regexp(t, "pop", err)
+
+	// Expired results are pruned
+	mockSendRaw(mRPC).Twice()
+	_, _, err = c.TransactionSend(rc, &req)
+	assert.NoError(t, err)
+	for _, a := range c.sendAttempts {
+		a.completed = time.Now().Add(-1 * time.Hour)
+	}
+	_, _, err = c.TransactionSend(rc, &req)
+	assert.NoError(t, err)
+	mRPC.AssertExpectations(t)
+}
+
+func TestSendNotDeduplicatedWithoutRequestID(t *testing.T) {
+	ctx, c, mRPC, done := newTestDedupConnector(t)
+	defer done()
+
+	mockSendRaw(mRPC).Twice()
+
+	var req ffcapi.TransactionSendRequest
+	err := json.Unmarshal([]byte(sampleSendRawTX), &req)
+	assert.NoError(t, err)
+	_, _, err = c.TransactionSend(ctx, &req)
+	assert.NoError(t, err)
+	_, _, err = c.TransactionSend(ctx, &req)
+	assert.NoError(t, err)
+	assert.Empty(t, c.sendAttempts)
+	mRPC.AssertExpectations(t)
+}
+
+func TestSendDeduplicatesInFlight(t *testing.T) {
+	ctx, c, mRPC, done := newTestDedupConnector(t)
+	defer done()
+
+	release := make(chan time.Time)
+	mockSendRaw(mRPC).WaitUntil(release).Once()
+
+	var req ffcapi.TransactionSendRequest
+	err := json.Unmarshal([]byte(sampleSendRawTX), &req)
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	results := make([]*ffcapi.TransactionSendResponse, 3)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			res, _, err := c.TransactionSend(testSendRunContext(ctx, "ns1:tx1"), &req)
+			assert.NoError(t, err)
+			results[i] = res
+		}(i)
+	}
+	for {
+		c.sendDedupMux.Lock()
+		started := len(c.sendAttempts) == 1
+		c.sendDedupMux.Unlock()
+		if started {
+			break
+		}
+		time.Sleep(1 * time.Millisecond)
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	for _, res := range results {
+		assert.Equal(t, "0xdc246933185b64d89e93d47bb6fa0500b9da8f168d5ccf39aebc7f6c36692fe2", res.TransactionHash)
+	}
+}
+
+func TestSendDeduplicateWaitCancelled(t *testing.T) {
+	_, c, _, done := newTestDedupConnector(t)
+	defer done()
+
+	var req ffcapi.TransactionSendRequest
+	err := json.Unmarshal([]byte(sampleSendRawTX), &req)
+	assert.NoError(t, err)
+	c.sendAttempts["ns1:tx1"] = &sendAttempt{contentHash: sendContentHash(&req), done: make(chan struct{})}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, err = c.TransactionSend(testSendRunContext(ctx, "ns1:tx1"), &req)
+	assert.Regexp(t, "FF23126.*ns1:tx1.*canceled", err)
+}
+
+func TestSendDeduplicateLeaderAbandoned(t *testing.T) {
+	_, c, mRPC, done := newTestDedupConnector(t)
+	defer done()
+
+	var req ffcapi.TransactionSendRequest
+	err := json.Unmarshal([]byte(sampleSendRawTX), &req)
+	assert.NoError(t, err)
+
+	// The first submission is cancelled by its caller while it is in-flight to the node
+	leaderCtx, cancelLeader := context.WithCancel(context.Background())
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_sendRawTransaction", mock.Anything).
+		Run(func(args mock.Arguments) {
+			<-args[0].(context.Context).Done()
+		}).
+		Return(&rpcbackend.RPCError{Message: "context canceled"}).Once()
+	mockSendRaw(mRPC).Once()
+
+	leaderDone := make(chan error)
+	go func() {
+		_, _, err := c.TransactionSend(testSendRunContext(leaderCtx, "ns1:tx1"), &req)
+		leaderDone <- err
+	}()
+	for {
+		c.sendDedupMux.Lock()
+		started := len(c.sendAttempts) == 1
+		c.sendDedupMux.Unlock()
+		if started {
+			break
+		}
+		time.Sleep(1 * time.Millisecond)
+	}
+
+	// A duplicate caller that is still waiting submits the transaction itself
+	followerDone := make(chan *ffcapi.TransactionSendResponse)
+	go func() {
+		res, _, err := c.TransactionSend(testSendRunContext(context.Background(), "ns1:tx1"), &req)
+		assert.NoError(t, err)
+		followerDone <- res
+	}()
+	time.Sleep(10 * time.Millisecond)
+	cancelLeader()
+
+	assert.Regexp(t, "context canceled", <-leaderDone)
+	res := <-followerDone
+	assert.Equal(t, "0xdc246933185b64d89e93d47bb6fa0500b9da8f168d5ccf39aebc7f6c36692fe2", res.TransactionHash)
+	mRPC.AssertExpectations(t)
+}
+
+func TestSendDeduplicationDisabled(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mockSendRaw(mRPC).Twice()
+
+	var req ffcapi.TransactionSendRequest
+	err := json.Unmarshal([]byte(sampleSendRawTX), &req)
+	assert.NoError(t, err)
+	_, _, err = c.TransactionSend(testSendRunContext(ctx, "ns1:tx1"), &req)
+	assert.NoError(t, err)
+	_, _, err = c.TransactionSend(testSendRunContext(ctx, "ns1:tx1"), &req)
+	assert.NoError(t, err)
+	assert.Empty(t, c.sendAttempts)
+}
+
+func TestSendDeduplicateReplacedAttemptFails(t *testing.T) {
+	ctx, c, _, done := newTestDedupConnector(t)
+	defer done()
+
+	// An attempt that has been replaced by a changed submission does not remove its replacement
+	replacement := &sendAttempt{contentHash: "changed", done: make(chan struct{})}
+	c.sendAttempts["ns1:tx1"] = replacement
+	_, _, err := c.leadSend(ctx, "ns1:tx1", &sendAttempt{done: make(chan struct{})}, &ffcapi.TransactionSendRequest{
+		PreSigned:       true,
+		TransactionData: "!!!",
+	})
+	assert.Regexp(t, "FF23018", err)
+	assert.Equal(t, replacement, c.sendAttempts["ns1:tx1"])
+}
@@ -21,6 +21,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"strings"
+	"time"
 
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly-common/pkg/i18n"
@@ -32,10 +33,54 @@ import (
 	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
 )
 
-func (c *ethConnector) TransactionSend(ctx context.Context, req *ffcapi.TransactionSendRequest) (*ffcapi.TransactionSendResponse, ffcapi.ErrorReason, error) {
+func (c *ethConnector) TransactionSend(ctx context.Context, req *ffcapi.TransactionSendRequest) (res *ffcapi.TransactionSendResponse, reason ffcapi.ErrorReason, err error) {
+	ctx = withCorrelationIDs(ctx)
+	defer c.latencySLOs.observe(LatencySLOSend, time.Now())
+	if c.sendDedupWindow <= 0 {
+		res, reason, err = c.sendTransaction(ctx, req)
+	} else {
+		res, reason, err = c.deduplicatedSend(ctx, req)
+	}
+	c.auditSubmission(ctx, auditOperationSend, req, nil, res, reason, err)
+	return res, reason, c.exposeErrorDetails(reason, err)
+}
+
+func (c *ethConnector) sendTransaction(ctx context.Context, req *ffcapi.TransactionSendRequest) (*ffcapi.TransactionSendResponse, ffcapi.ErrorReason, error) {
+	confirmations, err := c.txConfirmations(ctx, req.GasPrice)
+	if err != nil {
+		return nil, ffcapi.ErrorReasonInvalidInputs, err
+	}
 	var rpcError *rpcbackend.RPCError
-	var txHash ethtypes.HexBytes0xPrefix
+	var txHash, expectedHash ethtypes.HexBytes0xPrefix
+	var txEncoding *signedTxEncoding
+	release := func() {}
 	if req.PreSigned {
+		rawTx, err := ethtypes.NewHexBytes0xPrefix(req.TransactionData)
+		if err != nil {
+			return nil, ffcapi.ErrorReasonInvalidInputs, i18n.NewError(ctx, msgs.MsgInvalidTXData, req.TransactionData, err)
+		}
+		if expectedHash, txEncoding, err = signedTxHash(ctx, rawTx); err != nil {
+			return nil, ffcapi.ErrorReasonInvalidInputs, err
+		}
+		if txEncoding == depositTxEncoding {
+			return nil, ffcapi.ErrorReasonInvalidInputs, i18n.NewError(ctx, msgs.MsgDepositTXNotSubmittable, expectedHash)
+		}
+		signed, reason, err := c.checkPreSignedPolicy(ctx, rawTx)
+		if err != nil {
+			return nil, reason, err
+		}
+		var signedGas *ethtypes.HexInteger
+		if signed != nil {
+			signedGas = signed.Gas
+		}
+		if reason, err := c.checkTxLimits(ctx, len(rawTx), signedGas); err != nil {
+			return nil, reason, err
+		}
+		if signed != nil {
+			if release, reason, err = c.reserveValue(ctx, signed.From, signed.Value); err != nil {
+				return nil, reason, err
+			}
+		}
 		rpcError = c.backend.CallRPC(ctx, &txHash, "eth_sendRawTransaction", req.TransactionData)
 	} else {
 		txData, err := hex.DecodeString(strings.TrimPrefix(req.TransactionData, "0x"))
@@ -47,22 +92,50 @@ func (c *ethConnector) TransactionSend(ctx context.Context, req *ffcapi.Transact
 		if err != nil {
 			return nil, ffcapi.ErrorReasonInvalidInputs, err
 		}
+		if reason, err := c.policy().checkTx(ctx, tx.To, tx.Value, tx.Data); err != nil {
+			return nil, reason, err
+		}
+		if reason, err := c.checkTxLimits(ctx, len(txData), tx.GasLimit); err != nil {
+			return nil, reason, err
+		}
 
 		err = c.mapGasPrice(ctx, req.GasPrice, tx)
 		if err != nil {
 			return nil, ffcapi.ErrorReasonInvalidInputs, err
 		}
-		rpcError = c.backend.CallRPC(ctx, &txHash, "eth_sendTransaction", tx)
+		feeCurrency, err := c.feeCurrency(ctx, req.GasPrice)
+		if err != nil {
+			return nil, ffcapi.ErrorReasonInvalidInputs, err
+		}
+		from, _ := ethtypes.NewAddress(req.From) // validated by buildTx
+		var reason ffcapi.ErrorReason
+		if release, reason, err = c.reserveValue(ctx, from, tx.Value); err != nil {
+			return nil, reason, err
+		}
+		rpcError = c.backend.CallRPC(ctx, &txHash, "eth_sendTransaction", withFeeCurrency(tx, feeCurrency))
 	}
 
-	if rpcError == nil && len(txHash) != 32 {
+	switch {
+	case rpcError != nil:
+		// The node did not accept the transaction, so its value does not count against the window of the signer
+		release()
+	case len(txHash) != 32:
 		rpcError = &rpcbackend.RPCError{Message: i18n.NewError(ctx, msgs.MsgInvalidTXHashReturned, len(txHash)).Error()}
+	case expectedHash != nil && !txHash.Equals(expectedHash):
+		rpcError = &rpcbackend.RPCError{Message: i18n.NewError(ctx, msgs.MsgTXHashMismatch, txHash, txEncoding.name, expectedHash).Error()}
 	}
 	if rpcError != nil {
 		// send transaction responses never returns error details, only the error message
 		// so no need to parse the error data
-		return nil, mapError(sendRPCMethods, rpcError.Error()), rpcError.Error()
+		reason := mapRPCError(sendRPCMethods, rpcError)
+		if reason == ffcapi.ErrorReasonTransactionUnderpriced {
+			c.learnMinGasPrice(ctx, rpcError.Message)
+		}
+		log.L(ctx).Errorf("Transaction submission failed (reason=%q retryable=%t): %s", reason, errorRetryable(reason), rpcError.Message)
+		return nil, reason, withErrorDetails(rpcError.Error(), rpcErrorDetails(rpcError))
 	}
+	c.recordStickyTx(txHash.String())
+	c.recordTxConfirmations(txHash.String(), confirmations)
 	return &ffcapi.TransactionSendResponse{
 		TransactionHash: txHash.String(),
 	}, "", nil
@@ -88,6 +161,9 @@ func (c *ethConnector) mapGasPrice(ctx context.Context, input *fftypes.JSONAny,
 	maxPriorityFeePerGas := (*ethtypes.HexInteger)(gasPriceObject.GetInteger("maxPriorityFeePerGas"))
 	maxFeePerGas := (*ethtypes.HexInteger)(gasPriceObject.GetInteger("maxFeePerGas"))
 	if maxPriorityFeePerGas.BigInt().Sign() > 0 || maxFeePerGas.BigInt().Sign() > 0 {
+		if c.legacyChain {
+			return i18n.NewError(ctx, msgs.MsgLegacyChainEIP1559Fees)
+		}
 		tx.MaxPriorityFeePerGas = maxPriorityFeePerGas
 		tx.MaxFeePerGas = maxFeePerGas
 		log.L(ctx).Debugf("maxPriorityFeePerGas=%s maxFeePerGas=%s", tx.MaxPriorityFeePerGas, tx.MaxFeePerGas)
@@ -49,7 +49,7 @@ const sampleSendRawTX = `{
 		"id": "904F177C-C790-4B01-BDF4-F2B4E52E607E",
 		"type": "send_transaction"
 	},
-	"transactionData": "0xd46e8dd67c5d32be8d46e8dd67c5d32be8058bb8eb970870f072445675058bb8eb970870f072445675",
+	"transactionData": "0x02f8928205396f843b9aca008504a817c800830186a094497eedc4299dea2f2a364be10025d0ad0f702de380a460fe47b100000000000000000000000000000000000000000000000000000000feedbeefc001a0b881349abfcc7e2e5dafebcbdeb8824d12b9aaa3597305bbf3a522634415c288a01088dd7bd677f6539331ed971e254d00dc5aefe778f8562662e49aa5be49b955",
 	"preSigned": true
 }`
 
@@ -176,11 +176,11 @@ func TestSendPreSignedTransactionOK(t *testing.T) {
 
 	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_sendRawTransaction",
 		mock.MatchedBy(func(data string) bool {
-			assert.Equal(t, "0xd46e8dd67c5d32be8d46e8dd67c5d32be8058bb8eb970870f072445675058bb8eb970870f072445675", data)
+			assert.Equal(t, "0x02f8928205396f843b9aca008504a817c800830186a094497eedc4299dea2f2a364be10025d0ad0f702de380a460fe47b100000000000000000000000000000000000000000000000000000000feedbeefc001a0b881349abfcc7e2e5dafebcbdeb8824d12b9aaa3597305bbf3a522634415c288a01088dd7bd677f6539331ed971e254d00dc5aefe778f8562662e49aa5be49b955", data)
 			return true
 		})).
 		Run(func(args mock.Arguments) {
-			*(args[1].(*ethtypes.HexBytes0xPrefix)) = ethtypes.MustNewHexBytes0xPrefix("0x332db2d926128920c2dc1b2067de4e86d073975fd018e22ed2470449e755b508")
+			*(args[1].(*ethtypes.HexBytes0xPrefix)) = ethtypes.MustNewHexBytes0xPrefix("0xdc246933185b64d89e93d47bb6fa0500b9da8f168d5ccf39aebc7f6c36692fe2")
 		}).
 		Return(nil)
 
@@ -191,7 +191,7 @@ func TestSendPreSignedTransactionOK(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Empty(t, reason)
 
-	assert.Equal(t, "0x332db2d926128920c2dc1b2067de4e86d073975fd018e22ed2470449e755b508", res.TransactionHash)
+	assert.Equal(t, "0xdc246933185b64d89e93d47bb6fa0500b9da8f168d5ccf39aebc7f6c36692fe2", res.TransactionHash)
 
 	mRPC.AssertExpectations(t)
 }
@@ -203,7 +203,7 @@ func TestSendPreSignedTransactionBadHash(t *testing.T) {
 
 	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_sendRawTransaction",
 		mock.MatchedBy(func(data string) bool {
-			assert.Equal(t, "0xd46e8dd67c5d32be8d46e8dd67c5d32be8058bb8eb970870f072445675058bb8eb970870f072445675", data)
+			assert.Equal(t, "0x02f8928205396f843b9aca008504a817c800830186a094497eedc4299dea2f2a364be10025d0ad0f702de380a460fe47b100000000000000000000000000000000000000000000000000000000feedbeefc001a0b881349abfcc7e2e5dafebcbdeb8824d12b9aaa3597305bbf3a522634415c288a01088dd7bd677f6539331ed971e254d00dc5aefe778f8562662e49aa5be49b955", data)
 			return true
 		})).
 		Run(func(args mock.Arguments) {
@@ -228,7 +228,7 @@ func TestSendPreSignedTransactionNonceTooLow(t *testing.T) {
 
 	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_sendRawTransaction",
 		mock.MatchedBy(func(data string) bool {
-			assert.Equal(t, "0xd46e8dd67c5d32be8d46e8dd67c5d32be8058bb8eb970870f072445675058bb8eb970870f072445675", data)
+			assert.Equal(t, "0x02f8928205396f843b9aca008504a817c800830186a094497eedc4299dea2f2a364be10025d0ad0f702de380a460fe47b100000000000000000000000000000000000000000000000000000000feedbeefc001a0b881349abfcc7e2e5dafebcbdeb8824d12b9aaa3597305bbf3a522634415c288a01088dd7bd677f6539331ed971e254d00dc5aefe778f8562662e49aa5be49b955", data)
 			return true
 		})).
 		Return(&rpcbackend.RPCError{Message: "nonce too low"})
@@ -250,7 +250,7 @@ func TestSendPreSignedTransactionKnownTransaction(t *testing.T) {
 
 	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_sendRawTransaction",
 		mock.MatchedBy(func(data string) bool {
-			assert.Equal(t, "0xd46e8dd67c5d32be8d46e8dd67c5d32be8058bb8eb970870f072445675058bb8eb970870f072445675", data)
+			assert.Equal(t, "0x02f8928205396f843b9aca008504a817c800830186a094497eedc4299dea2f2a364be10025d0ad0f702de380a460fe47b100000000000000000000000000000000000000000000000000000000feedbeefc001a0b881349abfcc7e2e5dafebcbdeb8824d12b9aaa3597305bbf3a522634415c288a01088dd7bd677f6539331ed971e254d00dc5aefe778f8562662e49aa5be49b955", data)
 			return true
 		})).
 		Return(&rpcbackend.RPCError{Message: "known transaction"})
@@ -272,7 +272,7 @@ func TestSendPreSignedTransactionUnderpriced(t *testing.T) {
 
 	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_sendRawTransaction",
 		mock.MatchedBy(func(data string) bool {
-			assert.Equal(t, "0xd46e8dd67c5d32be8d46e8dd67c5d32be8058bb8eb970870f072445675058bb8eb970870f072445675", data)
+			assert.Equal(t, "0x02f8928205396f843b9aca008504a817c800830186a094497eedc4299dea2f2a364be10025d0ad0f702de380a460fe47b100000000000000000000000000000000000000000000000000000000feedbeefc001a0b881349abfcc7e2e5dafebcbdeb8824d12b9aaa3597305bbf3a522634415c288a01088dd7bd677f6539331ed971e254d00dc5aefe778f8562662e49aa5be49b955", data)
 			return true
 		})).
 		Return(&rpcbackend.RPCError{Message: "transaction underpriced"})
@@ -294,7 +294,7 @@ func TestSendPreSignedTransactionInsufficientFunds(t *testing.T) {
 
 	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_sendRawTransaction",
 		mock.MatchedBy(func(data string) bool {
-			assert.Equal(t, "0xd46e8dd67c5d32be8d46e8dd67c5d32be8058bb8eb970870f072445675058bb8eb970870f072445675", data)
+			assert.Equal(t, "0x02f8928205396f843b9aca008504a817c800830186a094497eedc4299dea2f2a364be10025d0ad0f702de380a460fe47b100000000000000000000000000000000000000000000000000000000feedbeefc001a0b881349abfcc7e2e5dafebcbdeb8824d12b9aaa3597305bbf3a522634415c288a01088dd7bd677f6539331ed971e254d00dc5aefe778f8562662e49aa5be49b955", data)
 			return true
 		})).
 		Return(&rpcbackend.RPCError{Message: "insufficient funds"})
@@ -309,6 +309,24 @@ func TestSendPreSignedTransactionInsufficientFunds(t *testing.T) {
 	mRPC.AssertExpectations(t)
 }
 
+func TestSendPreSignedTransactionNotPermitted(t *testing.T) {
+
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_sendRawTransaction", mock.Anything).
+		Return(&rpcbackend.RPCError{Code: -32007, Message: "Sender account not authorized to send transactions"})
+
+	var req ffcapi.TransactionSendRequest
+	err := json.Unmarshal([]byte(sampleSendRawTX), &req)
+	assert.NoError(t, err)
+	_, reason, err := c.TransactionSend(ctx, &req)
+	assert.Equal(t, ErrorReasonNotPermitted, reason)
+	assert.Regexp(t, "not authorized", err.Error())
+
+	mRPC.AssertExpectations(t)
+}
+
 func TestSendTransactionFail(t *testing.T) {
 
 	ctx, c, mRPC, done := newTestConnector(t)
@@ -339,10 +357,71 @@ func TestSendErrorMapping(t *testing.T) {
 	assert.Equal(t, ffcapi.ErrorReasonTransactionUnderpriced, mapError(sendRPCMethods, fmt.Errorf("transaction underpriced")))
 	assert.Equal(t, ffcapi.ErrorKnownTransaction, mapError(sendRPCMethods, fmt.Errorf("known transaction")))
 	assert.Equal(t, ffcapi.ErrorKnownTransaction, mapError(sendRPCMethods, fmt.Errorf("already known")))
+	assert.Equal(t, ErrorReasonNotPermitted, mapError(sendRPCMethods, fmt.Errorf("Sender account not authorized to send transactions")))
+	assert.Equal(t, ErrorReasonNotPermitted, mapError(sendRPCMethods, fmt.Errorf("Sender not authorized to create contracts")))
 }
 
 func TestCallErrorMapping(t *testing.T) {
 	assert.Equal(t, ffcapi.ErrorReasonTransactionReverted, mapError(callRPCMethods, fmt.Errorf("execution reverted")))
+	assert.Equal(t, ErrorReasonNotPermitted, mapError(callRPCMethods, fmt.Errorf("Sender account not authorized to send transactions")))
+}
+
+func TestCommonErrorMapping(t *testing.T) {
+	assert.Equal(t, ErrorReasonRateLimited, mapError(sendRPCMethods, fmt.Errorf("daily request count exceeded, request rate limited")))
+	assert.Equal(t, ErrorReasonRateLimited, mapError(callRPCMethods, fmt.Errorf("FF22012: Backend RPC request failed: 429 Too Many Requests")))
+	assert.Equal(t, ffcapi.ErrorReasonDownstreamDown, mapError(sendRPCMethods, fmt.Errorf("FF22012: Backend RPC request failed: dial tcp 127.0.0.1:8545: connect: connection refused")))
+	assert.Equal(t, ffcapi.ErrorReasonDownstreamDown, mapError(callRPCMethods, fmt.Errorf("FF22012: Backend RPC request failed: 503 Service Unavailable")))
+	assert.Equal(t, ffcapi.ErrorReasonDownstreamDown, mapError(sendRPCMethods, fmt.Errorf("FF23096: IPC connection to '/geth.ipc' closed before the response was received")))
+	assert.Equal(t, ErrorReasonTimeout, mapError(sendRPCMethods, fmt.Errorf("FF22012: Backend RPC request failed: context deadline exceeded (Client.Timeout exceeded while awaiting headers)")))
+	assert.Equal(t, ErrorReasonTimeout, mapError(callRPCMethods, fmt.Errorf("FF23095: IPC request timed out after 30s")))
+	assert.Equal(t, ErrorReasonTimeout, mapError(sendRPCMethods, fmt.Errorf("FF22012: Backend RPC request failed: context canceled")))
+	assert.Equal(t, ErrorReasonNotSent, mapError(sendRPCMethods, fmt.Errorf("FF23102: Request eth_sendRawTransaction was not sent to the node as the caller is no longer waiting: context canceled")))
+	assert.Equal(t, ErrorReasonNotSent, mapError(sendRPCMethods, fmt.Errorf("FF23101: Request cancelled waiting for a high priority slot to send eth_sendRawTransaction to the node: context deadline exceeded")))
+	assert.Equal(t, ffcapi.ErrorReasonDownstreamDown, mapError(callRPCMethods, fmt.Errorf("FF23093: Failed to connect to IPC socket '/geth.ipc': no such file or directory")))
+	// Only the code at the start of the message identifies an error raised by the connector
+	assert.Equal(t, ffcapi.ErrorReason(""), mapError(sendRPCMethods, fmt.Errorf("rejected by node: ff23102")))
+	assert.Equal(t, ffcapi.ErrorReasonInvalidInputs, mapError(sendRPCMethods, fmt.Errorf("intrinsic gas too low: have 21000, want 53000")))
+	assert.Equal(t, ffcapi.ErrorReasonInvalidInputs, mapError(callRPCMethods, fmt.Errorf("invalid argument 0: hex string without 0x prefix")))
+	assert.Equal(t, ffcapi.ErrorReasonInvalidInputs, mapError(sendRPCMethods, fmt.Errorf("exceeds block gas limit")))
+
+	// Method specific mappings take precedence
+	assert.Equal(t, ffcapi.ErrorReasonTransactionReverted, mapError(callRPCMethods, fmt.Errorf("execution reverted: timeout not reached")))
+	assert.Equal(t, ffcapi.ErrorReason(""), mapError(sendRPCMethods, fmt.Errorf("something else")))
+}
+
+func TestRPCErrorMapping(t *testing.T) {
+	assert.Equal(t, ErrorReasonRateLimited, mapRPCError(sendRPCMethods, &rpcbackend.RPCError{Code: -32005, Message: "limit exceeded"}))
+	assert.Equal(t, ffcapi.ErrorReasonInvalidInputs, mapRPCError(callRPCMethods, &rpcbackend.RPCError{Code: -32602, Message: "missing value for required argument 1"}))
+	assert.Equal(t, ffcapi.ErrorReasonInvalidInputs, mapRPCError(callRPCMethods, &rpcbackend.RPCError{Code: int64(rpcbackend.RPCCodeInvalidRequest), Message: "bad request"}))
+	assert.Equal(t, ffcapi.ErrorReasonNonceTooLow, mapRPCError(sendRPCMethods, &rpcbackend.RPCError{Code: -32000, Message: "nonce too low"}))
+	assert.Equal(t, ffcapi.ErrorReason(""), mapRPCError(sendRPCMethods, &rpcbackend.RPCError{Code: -32000, Message: "something else"}))
+}
+
+func TestErrorRetryable(t *testing.T) {
+	for _, reason := range []ffcapi.ErrorReason{ErrorReasonRateLimited, ErrorReasonTimeout, ErrorReasonNotSent, ffcapi.ErrorReasonDownstreamDown, ffcapi.ErrorReasonTransactionUnderpriced, ""} {
+		assert.True(t, errorRetryable(reason), reason)
+	}
+	for _, reason := range []ffcapi.ErrorReason{ffcapi.ErrorReasonInvalidInputs, ffcapi.ErrorReasonTransactionReverted, ffcapi.ErrorReasonInsufficientFunds} {
+		assert.False(t, errorRetryable(reason), reason)
+	}
+}
+
+func TestSendTransactionRateLimited(t *testing.T) {
+
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_sendTransaction", mock.Anything).
+		Return(&rpcbackend.RPCError{Code: -32005, Message: "limit exceeded"})
+
+	var req ffcapi.TransactionSendRequest
+	err := json.Unmarshal([]byte(sampleSendTX), &req)
+	assert.NoError(t, err)
+	res, reason, err := c.TransactionSend(ctx, &req)
+	assert.Regexp(t, "limit exceeded", err)
+	assert.Equal(t, ErrorReasonRateLimited, reason)
+	assert.Nil(t, res)
+
 }
 
 func TestSendTransactionBadFrom(t *testing.T) {
@@ -0,0 +1,149 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os/exec"
+	"strconv"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/sirupsen/logrus"
+)
+
+// simulator runs an anvil process as an in-memory chain for the connector to use, so end-to-end tests can be run
+// without an external node. The process is stopped when the context of the connector is cancelled.
+type simulator struct {
+	command        string
+	args           []string
+	url            string
+	startupTimeout time.Duration
+	cmd            *exec.Cmd
+	done           chan struct{}
+}
+
+// newSimulator returns nil if the simulator is not enabled. The port is chosen up front, so the URL is known
+// before the process is started.
+func newSimulator(ctx context.Context, conf config.Section) (*simulator, error) {
+	if !conf.GetBool(SimulatorEnabled) {
+		return nil, nil
+	}
+	if conf.GetString(ffresty.HTTPConfigURL) != "" {
+		return nil, i18n.NewError(ctx, msgs.MsgSimulatorURLConflict)
+	}
+	port := conf.GetInt(SimulatorPort)
+	if port == 0 {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			return nil, i18n.NewError(ctx, msgs.MsgSimulatorStartFailed, conf.GetString(SimulatorCommand), err)
+		}
+		port = l.Addr().(*net.TCPAddr).Port
+		_ = l.Close()
+	}
+	// Extra arguments come first, so they can include a separator before the arguments for anvil
+	args := append([]string{}, conf.GetStringSlice(SimulatorArgs)...)
+	args = append(args,
+		"--host", "127.0.0.1",
+		"--port", strconv.Itoa(port),
+		"--chain-id", strconv.FormatInt(conf.GetInt64(SimulatorChainID), 10),
+		"--accounts", strconv.Itoa(conf.GetInt(SimulatorAccounts)),
+	)
+	if blockTime := conf.GetDuration(SimulatorBlockTime); blockTime > 0 {
+		args = append(args, "--block-time", strconv.FormatFloat(blockTime.Seconds(), 'f', -1, 64))
+	}
+	if mnemonic := conf.GetString(SimulatorMnemonic); mnemonic != "" {
+		args = append(args, "--mnemonic", mnemonic)
+	}
+	return &simulator{
+		command:        conf.GetString(SimulatorCommand),
+		args:           args,
+		url:            fmt.Sprintf("http://127.0.0.1:%d", port),
+		startupTimeout: conf.GetDuration(SimulatorStartupTimeout),
+	}, nil
+}
+
+// start runs the process and waits for it to answer JSON/RPC requests. The output of the process is logged at
+// debug level.
+func (s *simulator) start(ctx context.Context) error {
+	logWriter := log.L(ctx).WithField("simulator", s.command).WriterLevel(logrus.DebugLevel)
+	s.cmd = exec.CommandContext(ctx, s.command, s.args...)
+	s.cmd.Stdout = logWriter
+	s.cmd.Stderr = logWriter
+	if err := s.cmd.Start(); err != nil {
+		_ = logWriter.Close()
+		return i18n.NewError(ctx, msgs.MsgSimulatorStartFailed, s.command, err)
+	}
+	s.done = make(chan struct{})
+	go func() {
+		defer close(s.done)
+		err := s.cmd.Wait()
+		_ = logWriter.Close()
+		log.L(ctx).Infof("Simulator %s exited: %v", s.command, err)
+	}()
+
+	log.L(ctx).Infof("Waiting for simulator %s to be ready at %s", s.command, s.url)
+	client := &http.Client{Timeout: time.Second}
+	deadline := time.Now().Add(s.startupTimeout)
+	for {
+		if s.ready(ctx, client) {
+			return nil
+		}
+		select {
+		case <-s.done:
+			return i18n.NewError(ctx, msgs.MsgSimulatorStartFailed, s.command, s.cmd.ProcessState)
+		case <-time.After(100 * time.Millisecond):
+		}
+		if time.Now().After(deadline) {
+			s.stop()
+			return i18n.NewError(ctx, msgs.MsgSimulatorNotReady, s.url, s.startupTimeout)
+		}
+	}
+}
+
+func (s *simulator) ready(ctx context.Context, client *http.Client) bool {
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader([]byte(`{"jsonrpc":"2.0","id":1,"method":"eth_chainId","params":[]}`)))
+	req.Header.Set("Content-Type", "application/json")
+	res, err := client.Do(req)
+	if err != nil {
+		return false
+	}
+	_ = res.Body.Close()
+	return res.StatusCode == http.StatusOK
+}
+
+// stop kills the process, for a connector that fails to be created after the simulator has started
+func (s *simulator) stop() {
+	if s != nil && s.done != nil {
+		_ = s.cmd.Process.Kill()
+		<-s.done
+	}
+}
+
+func (s *simulator) waitClosed() {
+	if s != nil && s.done != nil {
+		<-s.done
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/stretchr/testify/assert"
+)
+
+const testSimulatorEnv = "EVMCONNECT_TEST_SIMULATOR"
+
+// TestSimulatorHelperProcess is run as the simulator by the other tests, answering JSON/RPC requests on the port
+// passed in the same way as anvil. It does nothing when run as a normal test.
+func TestSimulatorHelperProcess(t *testing.T) {
+	mode := os.Getenv(testSimulatorEnv)
+	if mode == "" {
+		return
+	}
+	if mode == "exit" {
+		os.Exit(1)
+	}
+	var port string
+	for i, arg := range os.Args {
+		if arg == "--port" && i+1 < len(os.Args) {
+			port = os.Args[i+1]
+		}
+	}
+	if mode == "hang" {
+		time.Sleep(1 * time.Hour)
+	}
+	_ = http.ListenAndServe("127.0.0.1:"+port, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x7a69"}`))
+	}))
+	os.Exit(0)
+}
+
+func testSimulatorConf(mode string) config.Section {
+	config.RootConfigReset()
+	conf := config.RootSection("unittest")
+	InitConfig(conf)
+	conf.Set(SimulatorEnabled, true)
+	conf.Set(SimulatorCommand, os.Args[0])
+	conf.Set(SimulatorArgs, []string{"-test.run=^TestSimulatorHelperProcess$", "--"})
+	conf.Set(SimulatorBlockTime, "500ms")
+	conf.Set(SimulatorMnemonic, "test test test test test test test test test test test junk")
+	conf.Set(SimulatorStartupTimeout, "10s")
+	os.Setenv(testSimulatorEnv, mode)
+	return conf
+}
+
+func TestSimulatorDisabled(t *testing.T) {
+	config.RootConfigReset()
+	conf := config.RootSection("unittest")
+	InitConfig(conf)
+	s, err := newSimulator(context.Background(), conf)
+	assert.NoError(t, err)
+	assert.Nil(t, s)
+	s.stop()
+	s.waitClosed()
+}
+
+func TestSimulatorURLConflict(t *testing.T) {
+	conf := testSimulatorConf("serve")
+	defer os.Unsetenv(testSimulatorEnv)
+	conf.Set(ffresty.HTTPConfigURL, "http://localhost:8545")
+	_, err := NewEthereumConnector(context.Background(), conf)
+	assert.Regexp(t, "FF23147", err)
+}
+
+func TestSimulatorArgs(t *testing.T) {
+	conf := testSimulatorConf("serve")
+	defer os.Unsetenv(testSimulatorEnv)
+	conf.Set(SimulatorPort, 18545)
+	s, err := newSimulator(context.Background(), conf)
+	assert.NoError(t, err)
+	assert.Equal(t, "http://127.0.0.1:18545", s.url)
+	assert.Equal(t, []string{
+		"-test.run=^TestSimulatorHelperProcess$", "--",
+		"--host", "127.0.0.1",
+		"--port", "18545",
+		"--chain-id", "31337",
+		"--accounts", "10",
+		"--block-time", "0.5",
+		"--mnemonic", "test test test test test test test test test test test junk",
+	}, s.args)
+}
+
+func TestSimulatorConnector(t *testing.T) {
+	conf := testSimulatorConf("serve")
+	defer os.Unsetenv(testSimulatorEnv)
+	ctx, cancel := context.WithCancel(context.Background())
+	cc, err := NewEthereumConnector(ctx, conf)
+	assert.NoError(t, err)
+	c := cc.(*ethConnector)
+	assert.NotNil(t, c.simulator)
+	assert.Equal(t, c.simulator.url, conf.GetString(ffresty.HTTPConfigURL))
+
+	var chainID string
+	rpcErr := c.backend.CallRPC(ctx, &chainID, "eth_chainId")
+	assert.Nil(t, rpcErr)
+	assert.Equal(t, "0x7a69", chainID)
+
+	cancel()
+	c.WaitClosed()
+}
+
+func TestSimulatorStopOnConnectorFail(t *testing.T) {
+	conf := testSimulatorConf("serve")
+	defer os.Unsetenv(testSimulatorEnv)
+	conf.Set(AuditLogFile, t.TempDir())
+	_, err := NewEthereumConnector(context.Background(), conf)
+	assert.Error(t, err)
+
+	conf.Set(AuditLogFile, "")
+	conf.Set(ffresty.HTTPConfigURL, "")
+	conf.Set(CheckpointsStore, "wrong")
+	_, err = NewEthereumConnector(context.Background(), conf)
+	assert.Regexp(t, "FF23134", err)
+}
+
+func TestSimulatorExits(t *testing.T) {
+	conf := testSimulatorConf("exit")
+	defer os.Unsetenv(testSimulatorEnv)
+	s, err := newSimulator(context.Background(), conf)
+	assert.NoError(t, err)
+	err = s.start(context.Background())
+	assert.Regexp(t, "FF23148", err)
+}
+
+func TestSimulatorNotReady(t *testing.T) {
+	conf := testSimulatorConf("hang")
+	defer os.Unsetenv(testSimulatorEnv)
+	conf.Set(SimulatorStartupTimeout, "200ms")
+	s, err := newSimulator(context.Background(), conf)
+	assert.NoError(t, err)
+	err = s.start(context.Background())
+	assert.Regexp(t, "FF23149", err)
+	s.waitClosed()
+}
+
+func TestSimulatorCommandNotFound(t *testing.T) {
+	conf := testSimulatorConf("serve")
+	defer os.Unsetenv(testSimulatorEnv)
+	conf.Set(SimulatorCommand, "/not/a/real/anvil")
+	s, err := newSimulator(context.Background(), conf)
+	assert.NoError(t, err)
+	err = s.start(context.Background())
+	assert.Regexp(t, "FF23148", err)
+}
@@ -0,0 +1,101 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+)
+
+// kafkaSink produces records to a Kafka topic through the v3 API of a Kafka REST proxy, such as the
+// Confluent REST Proxy, so no Kafka client library is needed in the connector
+type kafkaSink struct {
+	client    *resty.Client
+	clusterID string
+	topic     string
+}
+
+type kafkaRecordData struct {
+	Type string      `json:"type"`
+	Data interface{} `json:"data"`
+}
+
+type kafkaRecordHeader struct {
+	Name  string `json:"name"`
+	Value []byte `json:"value"` // base64 encoded by the JSON marshaling, as the REST proxy requires
+}
+
+type kafkaRecord struct {
+	Key     *kafkaRecordData     `json:"key,omitempty"`
+	Value   *kafkaRecordData     `json:"value"`
+	Headers []*kafkaRecordHeader `json:"headers"`
+}
+
+type kafkaProduceResponse struct {
+	ErrorCode int    `json:"error_code"`
+	Message   string `json:"message"`
+}
+
+func newKafkaSink(ctx context.Context, conf config.Section) (*kafkaSink, error) {
+	k := &kafkaSink{
+		clusterID: conf.GetString(SinkKafkaClusterID),
+		topic:     conf.GetString(SinkKafkaTopic),
+	}
+	if k.clusterID == "" || k.topic == "" {
+		return nil, i18n.NewError(ctx, msgs.MsgKafkaSinkConfigMissing, SinkKafkaClusterID, SinkKafkaTopic)
+	}
+	httpConf, err := ffresty.GenerateConfig(ctx, conf)
+	if err != nil {
+		return nil, err
+	}
+	k.client = ffresty.NewWithConfig(ctx, *httpConf)
+	return k, nil
+}
+
+func (k *kafkaSink) name() string {
+	return "kafka"
+}
+
+// publish keys the record on the contract address, so the default partitioner of the topic keeps the events of
+// each contract in order on a single partition. The deterministic ID of the record is passed in an "id" header.
+func (k *kafkaSink) publish(ctx context.Context, rec *sinkRecord) error {
+	record := &kafkaRecord{
+		Value:   &kafkaRecordData{Type: "JSON", Data: rec.Value},
+		Headers: []*kafkaRecordHeader{{Name: "id", Value: []byte(rec.ID)}},
+	}
+	if rec.Key != "" {
+		record.Key = &kafkaRecordData{Type: "STRING", Data: rec.Key}
+	}
+	var result kafkaProduceResponse
+	res, err := k.client.R().
+		SetContext(ctx).
+		SetBody(record).
+		SetResult(&result).
+		SetError(&result).
+		Post(fmt.Sprintf("/v3/clusters/%s/topics/%s/records", url.PathEscape(k.clusterID), url.PathEscape(k.topic)))
+	if err == nil && (res.IsError() || result.ErrorCode >= 400) {
+		err = i18n.NewError(ctx, msgs.MsgKafkaPublishFailed, rec.ID, k.topic, res.Status(), result.Message)
+	}
+	return err
+}
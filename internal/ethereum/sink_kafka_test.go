@@ -0,0 +1,118 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/fftls"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKafkaSinkPublish(t *testing.T) {
+	received := make(chan *kafkaRecord)
+	failed := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v3/clusters/cluster1/topics/events/records", r.URL.Path)
+		var record kafkaRecord
+		err := json.NewDecoder(r.Body).Decode(&record)
+		assert.NoError(t, err)
+		w.Header().Set("Content-Type", "application/json")
+		if !failed {
+			// The REST proxy can report a failure to produce in the body of a 200 response
+			failed = true
+			_, _ = w.Write([]byte(`{"error_code":50003,"message":"broker unavailable"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"error_code":200,"partition_id":1,"offset":5}`))
+		received <- &record
+	}))
+	defer server.Close()
+
+	ctx, c, _, done := newTestConnectorWithNoBlockerFilterDefaultMocks(t, func(conf config.Section) {
+		kafkaConf := conf.SubSection(SinksKafkaConfig)
+		kafkaConf.Set(ffresty.HTTPConfigURL, server.URL)
+		kafkaConf.Set(SinkKafkaClusterID, "cluster1")
+		kafkaConf.Set(SinkKafkaTopic, "events")
+		conf.Set(RetryInitDelay, "1ms")
+	})
+	defer done()
+
+	es := &eventStream{id: fftypes.NewUUID(), ctx: ctx, c: c}
+	event := testSinkEvent(1000, "0x12345")
+	assert.True(t, es.publishToSinks(event))
+
+	record := <-received
+	assert.Equal(t, "STRING", record.Key.Type)
+	assert.Equal(t, "0x20355f3e852d4b6a9944ada4d2d05d4c2ac7142b", record.Key.Data)
+	assert.Equal(t, "JSON", record.Value.Type)
+	value := record.Value.Data.(map[string]interface{})
+	assert.Equal(t, es.id.String(), value["streamId"])
+	assert.Equal(t, "000000001000/000000/000002", value["protocolId"])
+	assert.Equal(t, "0x12345", value["blockHash"])
+	assert.Equal(t, map[string]interface{}{"value": "1"}, value["data"])
+	assert.Equal(t, "id", record.Headers[0].Name)
+	assert.Equal(t, event.Event.ID.ListenerID.String()+"/000000001000/000000/000002", string(record.Headers[0].Value))
+}
+
+func TestKafkaSinkPublishErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error_code":40403,"message":"topic not found"}`))
+	}))
+	defer server.Close()
+
+	config.RootConfigReset()
+	conf := config.RootSection("kafka_test")
+	InitConfig(conf)
+	kafkaConf := conf.SubSection(SinksKafkaConfig)
+	kafkaConf.Set(ffresty.HTTPConfigURL, server.URL)
+	kafkaConf.Set(SinkKafkaClusterID, "cluster1")
+	kafkaConf.Set(SinkKafkaTopic, "events")
+	k, err := newKafkaSink(context.Background(), kafkaConf)
+	assert.NoError(t, err)
+	assert.Equal(t, "kafka", k.name())
+
+	err = k.publish(context.Background(), &sinkRecord{ID: "id1", Value: map[string]string{}})
+	assert.Regexp(t, "FF23129.*id1.*events.*404.*topic not found", err)
+}
+
+func TestNewKafkaSinkFail(t *testing.T) {
+	config.RootConfigReset()
+	conf := config.RootSection("kafka_test")
+	InitConfig(conf)
+	kafkaConf := conf.SubSection(SinksKafkaConfig)
+	kafkaConf.Set(ffresty.HTTPConfigURL, "http://localhost:12345")
+	kafkaConf.Set(SinkKafkaTopic, "events")
+	_, err := newKafkaSink(context.Background(), kafkaConf)
+	assert.Regexp(t, "FF23128", err)
+
+	kafkaConf.Set(SinkKafkaClusterID, "cluster1")
+	tlsConf := kafkaConf.SubSection("tls")
+	tlsConf.Set(fftls.HTTPConfTLSEnabled, true)
+	tlsConf.Set(fftls.HTTPConfTLSCAFile, "!!!badness")
+	_, err = newSinks(context.Background(), &ethConnector{}, conf)
+	assert.Regexp(t, "FF00153", err)
+}
@@ -0,0 +1,295 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftls"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+)
+
+const defaultNATSPort = "4222"
+
+// natsSink publishes records to JetStream with the NATS client protocol, over a single connection that is
+// re-established on the next attempt after any failure. Each record is published with its ID in a Nats-Msg-Id
+// header, so the stream discards duplicates within its duplicate window. Records are published one at a time,
+// waiting for the acknowledgement of the stream, so a single reply subject is used for all of them.
+type natsSink struct {
+	address        string
+	tlsConfig      *tls.Config
+	subject        string
+	username       string
+	password       string
+	token          string
+	requestTimeout time.Duration
+	inbox          string
+	conn           net.Conn
+	reader         *bufio.Reader
+}
+
+type natsConnectOptions struct {
+	Verbose      bool   `json:"verbose"`
+	Pedantic     bool   `json:"pedantic"`
+	Name         string `json:"name"`
+	Lang         string `json:"lang"`
+	Version      string `json:"version"`
+	Protocol     int    `json:"protocol"`
+	Headers      bool   `json:"headers"`
+	NoResponders bool   `json:"no_responders"`
+	User         string `json:"user,omitempty"`
+	Pass         string `json:"pass,omitempty"`
+	AuthToken    string `json:"auth_token,omitempty"`
+}
+
+type natsPubAck struct {
+	Stream    string `json:"stream"`
+	Sequence  uint64 `json:"seq"`
+	Duplicate bool   `json:"duplicate"`
+	Error     *struct {
+		Code        int    `json:"code"`
+		Description string `json:"description"`
+	} `json:"error"`
+}
+
+// natsMessage is a message received on the reply subject. The status is set from the header of a message that
+// is sent by the server itself, such as a 503 when no stream is bound to the subject.
+type natsMessage struct {
+	status string
+	data   []byte
+}
+
+func newNATSSink(ctx context.Context, conf config.Section) (*natsSink, error) {
+	natsURL := conf.GetString(SinkNATSURL)
+	u, err := url.Parse(natsURL)
+	if err != nil || u.Hostname() == "" {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidNATSURL, natsURL)
+	}
+	port := u.Port()
+	if port == "" {
+		port = defaultNATSPort
+	}
+	n := &natsSink{
+		address:        net.JoinHostPort(u.Hostname(), port),
+		subject:        conf.GetString(SinkNATSSubject),
+		username:       conf.GetString(SinkNATSUsername),
+		password:       conf.GetString(SinkNATSPassword),
+		token:          conf.GetString(SinkNATSToken),
+		requestTimeout: conf.GetDuration(SinkNATSRequestTimeout),
+		inbox:          "_INBOX." + fftypes.NewUUID().String(),
+	}
+	if n.tlsConfig, err = fftls.ConstructTLSConfig(ctx, conf.SubSection("tls"), fftls.ClientType); err != nil {
+		return nil, err
+	}
+	if n.tlsConfig == nil && u.Scheme == "tls" {
+		n.tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	if n.tlsConfig != nil && n.tlsConfig.ServerName == "" {
+		n.tlsConfig.ServerName = u.Hostname()
+	}
+	return n, nil
+}
+
+func (n *natsSink) name() string {
+	return "nats"
+}
+
+// subjectFor publishes events on a subject for the contract that emitted them, so consumers can filter
+func (n *natsSink) subjectFor(rec *sinkRecord) string {
+	if rec.Type == sinkRecordBlock {
+		return n.subject + ".blocks"
+	}
+	if rec.Key == "" {
+		return n.subject + ".events"
+	}
+	return n.subject + ".events." + rec.Key
+}
+
+func (n *natsSink) publish(ctx context.Context, rec *sinkRecord) error {
+	err := n.publishRecord(ctx, rec)
+	if err != nil && n.conn != nil {
+		// Any reply to this request is discarded with the connection, so it cannot be read as the reply to the next
+		_ = n.conn.Close()
+		n.conn = nil
+	}
+	return err
+}
+
+func (n *natsSink) publishRecord(ctx context.Context, rec *sinkRecord) error {
+	if n.conn == nil {
+		if err := n.connect(ctx); err != nil {
+			return err
+		}
+	}
+	subject := n.subjectFor(rec)
+	data, err := json.Marshal(rec.Value)
+	if err != nil {
+		return err
+	}
+	headers := fmt.Sprintf("NATS/1.0\r\nNats-Msg-Id: %s\r\n\r\n", rec.ID)
+	_ = n.conn.SetDeadline(time.Now().Add(n.requestTimeout))
+	if _, err := fmt.Fprintf(n.conn, "HPUB %s %s %d %d\r\n%s%s\r\n", subject, n.inbox, len(headers), len(headers)+len(data), headers, data); err != nil {
+		return i18n.NewError(ctx, msgs.MsgNATSPublishFailed, rec.ID, subject, err)
+	}
+	msg, err := n.readReply(ctx, false)
+	if err != nil {
+		return i18n.NewError(ctx, msgs.MsgNATSPublishFailed, rec.ID, subject, err)
+	}
+	if msg.status != "" {
+		return i18n.NewError(ctx, msgs.MsgNATSPublishFailed, rec.ID, subject, msg.status)
+	}
+	var ack natsPubAck
+	if err := json.Unmarshal(msg.data, &ack); err != nil {
+		return i18n.NewError(ctx, msgs.MsgNATSPublishFailed, rec.ID, subject, err)
+	}
+	if ack.Error != nil {
+		return i18n.NewError(ctx, msgs.MsgNATSPublishFailed, rec.ID, subject, fmt.Sprintf("%d %s", ack.Error.Code, ack.Error.Description))
+	}
+	if ack.Duplicate {
+		log.L(ctx).Debugf("Record %s was already published to stream %s", rec.ID, ack.Stream)
+	}
+	return nil
+}
+
+// connect reads the INFO sent by the server before upgrading to TLS, then authenticates, subscribes to the
+// reply subject and waits for a PONG to check the server accepted both
+func (n *natsSink) connect(ctx context.Context) error {
+	dialer := &net.Dialer{Timeout: n.requestTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", n.address)
+	if err != nil {
+		return i18n.NewError(ctx, msgs.MsgNATSConnectFailed, n.address, err)
+	}
+	n.conn = conn
+	n.reader = bufio.NewReader(conn)
+	_ = conn.SetDeadline(time.Now().Add(n.requestTimeout))
+	line, err := n.reader.ReadString('\n')
+	if err == nil && !strings.HasPrefix(line, "INFO ") {
+		err = i18n.NewError(ctx, msgs.MsgNATSProtocolError, strings.TrimSpace(line))
+	}
+	if err == nil && n.tlsConfig != nil {
+		tlsConn := tls.Client(conn, n.tlsConfig)
+		err = tlsConn.HandshakeContext(ctx)
+		n.conn = tlsConn
+		n.reader = bufio.NewReader(tlsConn)
+	}
+	if err == nil {
+		options, _ := json.Marshal(&natsConnectOptions{
+			Name:         "evmconnect",
+			Lang:         "go",
+			Protocol:     1,
+			Headers:      true,
+			NoResponders: true,
+			User:         n.username,
+			Pass:         n.password,
+			AuthToken:    n.token,
+		})
+		_, err = fmt.Fprintf(n.conn, "CONNECT %s\r\nSUB %s 1\r\nPING\r\n", options, n.inbox)
+	}
+	if err == nil {
+		_, err = n.readReply(ctx, true)
+	}
+	if err != nil {
+		_ = n.conn.Close()
+		n.conn = nil
+		return i18n.NewError(ctx, msgs.MsgNATSConnectFailed, n.address, err)
+	}
+	log.L(ctx).Infof("Connected to NATS server %s", n.address)
+	return nil
+}
+
+// readReply reads from the connection until a PONG if one is expected, or otherwise a message on the reply
+// subject, answering any PING from the server on the way
+func (n *natsSink) readReply(ctx context.Context, pong bool) (*natsMessage, error) {
+	for {
+		line, err := n.reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		switch strings.ToUpper(fields[0]) {
+		case "PING":
+			if _, err := io.WriteString(n.conn, "PONG\r\n"); err != nil {
+				return nil, err
+			}
+		case "PONG":
+			if pong {
+				return nil, nil
+			}
+		case "-ERR":
+			return nil, i18n.NewError(ctx, msgs.MsgNATSProtocolError, line)
+		case "MSG":
+			// MSG <subject> <sid> [reply-to] <#bytes>
+			size, err := strconv.Atoi(fields[len(fields)-1])
+			if err != nil || len(fields) < 4 {
+				return nil, i18n.NewError(ctx, msgs.MsgNATSProtocolError, line)
+			}
+			payload, err := n.readPayload(size)
+			if err != nil {
+				return nil, err
+			}
+			return &natsMessage{data: payload}, nil
+		case "HMSG":
+			// HMSG <subject> <sid> [reply-to] <#header bytes> <#total bytes>
+			if len(fields) < 5 {
+				return nil, i18n.NewError(ctx, msgs.MsgNATSProtocolError, line)
+			}
+			headerSize, err1 := strconv.Atoi(fields[len(fields)-2])
+			size, err2 := strconv.Atoi(fields[len(fields)-1])
+			if err1 != nil || err2 != nil || headerSize > size {
+				return nil, i18n.NewError(ctx, msgs.MsgNATSProtocolError, line)
+			}
+			payload, err := n.readPayload(size)
+			if err != nil {
+				return nil, err
+			}
+			msg := &natsMessage{data: payload[headerSize:]}
+			// The first line of the headers is the version, followed by a status for messages from the server
+			statusLine, _, _ := strings.Cut(string(payload[:headerSize]), "\r\n")
+			if status := strings.TrimSpace(strings.TrimPrefix(statusLine, "NATS/1.0")); status != "" {
+				msg.status = status
+			}
+			return msg, nil
+		}
+		// INFO updates and +OK are ignored
+	}
+}
+
+func (n *natsSink) readPayload(size int) ([]byte, error) {
+	payload := make([]byte, size+2) // followed by CRLF
+	if _, err := io.ReadFull(n.reader, payload); err != nil {
+		return nil, err
+	}
+	return payload[:size], nil
+}
@@ -0,0 +1,234 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftls"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+type testNATSPublish struct {
+	subject string
+	headers string
+	data    string
+}
+
+// newTestNATSServer accepts connections, checks the CONNECT and SUB of the client, and answers each HPUB
+// with the response returned by the handler, in which %s is replaced with the reply subject
+func newTestNATSServer(t *testing.T, connectResponse string, handler func(pub *testNATSPublish) string) (string, func()) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				r := bufio.NewReader(conn)
+				_, _ = io.WriteString(conn, "INFO {\"server_id\":\"test\",\"headers\":true}\r\n")
+				var inbox string
+				for {
+					line, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					fields := strings.Fields(line)
+					switch fields[0] {
+					case "CONNECT":
+						assert.Contains(t, line, `"headers":true`)
+					case "SUB":
+						inbox = fields[1]
+					case "PING":
+						_, _ = io.WriteString(conn, connectResponse)
+					case "HPUB":
+						headerSize, _ := strconv.Atoi(fields[3])
+						size, _ := strconv.Atoi(fields[4])
+						payload := make([]byte, size+2)
+						_, err := io.ReadFull(r, payload)
+						assert.NoError(t, err)
+						assert.Equal(t, inbox, fields[2])
+						response := handler(&testNATSPublish{
+							subject: fields[1],
+							headers: string(payload[:headerSize]),
+							data:    string(payload[headerSize:size]),
+						})
+						_, _ = io.WriteString(conn, strings.ReplaceAll(response, "%s", inbox))
+					}
+				}
+			}()
+		}
+	}()
+	return "nats://" + l.Addr().String(), func() { _ = l.Close() }
+}
+
+func natsTestAck(payload string) string {
+	return fmt.Sprintf("MSG %%s 1 %d\r\n%s\r\n", len(payload), payload)
+}
+
+func newTestNATSSink(t *testing.T, natsURL string) *natsSink {
+	config.RootConfigReset()
+	conf := config.RootSection("nats_test")
+	InitConfig(conf)
+	natsConf := conf.SubSection(SinksNATSConfig)
+	natsConf.Set(SinkNATSURL, natsURL)
+	natsConf.Set(SinkNATSToken, "token1")
+	n, err := newNATSSink(context.Background(), natsConf)
+	assert.NoError(t, err)
+	return n
+}
+
+func TestNATSSinkPublish(t *testing.T) {
+	published := make(chan *testNATSPublish, 2)
+	natsURL, closeServer := newTestNATSServer(t, "+OK\r\nPONG\r\n", func(pub *testNATSPublish) string {
+		published <- pub
+		return "PING\r\n" + natsTestAck(`{"stream":"EVMCONNECT","seq":1,"duplicate":true}`)
+	})
+	defer closeServer()
+
+	ctx, c, _, done := newTestConnectorWithNoBlockerFilterDefaultMocks(t, func(conf config.Section) {
+		natsConf := conf.SubSection(SinksNATSConfig)
+		natsConf.Set(SinkNATSURL, natsURL)
+		natsConf.Set(SinkNATSBlocks, true)
+	})
+	defer done()
+	assert.Equal(t, "nats", c.sinks[0].sink.name())
+
+	es := &eventStream{id: fftypes.NewUUID(), ctx: ctx, c: c}
+	event := testSinkEvent(1000, "0x12345")
+	assert.True(t, es.publishToSinks(event))
+	pub := <-published
+	assert.Equal(t, "evmconnect.events.0x20355f3e852d4b6a9944ada4d2d05d4c2ac7142b", pub.subject)
+	assert.Equal(t, fmt.Sprintf("NATS/1.0\r\nNats-Msg-Id: %s/000000001000/000000/000002\r\n\r\n", event.Event.ID.ListenerID), pub.headers)
+	assert.Contains(t, pub.data, `"protocolId":"000000001000/000000/000002"`)
+
+	c.blockListener.publishToSinks(&minimalBlockInfo{number: 1001, hash: "0xaaaa", parentHash: "0x12345"})
+	pub = <-published
+	assert.Equal(t, "evmconnect.blocks", pub.subject)
+	assert.Equal(t, "NATS/1.0\r\nNats-Msg-Id: block/1001/0xaaaa\r\n\r\n", pub.headers)
+	assert.JSONEq(t, `{"blockNumber":"1001","blockHash":"0xaaaa","parentHash":"0x12345"}`, pub.data)
+}
+
+func TestNATSSinkPublishFailures(t *testing.T) {
+	responses := []string{
+		"HMSG %s 1 16 16\r\nNATS/1.0 503\r\n\r\n\r\n",
+		natsTestAck(`{"error":{"code":400,"description":"bad request"}}`),
+		natsTestAck(`!json`),
+		"-ERR 'Permissions Violation'\r\n",
+		"MSG %s 1 !size\r\n",
+		"HMSG %s 1 !size\r\n",
+		natsTestAck(`{"stream":"EVMCONNECT","seq":2}`),
+	}
+	natsURL, closeServer := newTestNATSServer(t, "PONG\r\n", func(_ *testNATSPublish) string {
+		response := responses[0]
+		responses = responses[1:]
+		return response
+	})
+	defer closeServer()
+
+	n := newTestNATSSink(t, natsURL)
+	rec := &sinkRecord{ID: "id1", Type: sinkRecordEvent, Value: map[string]string{}}
+	err := n.publish(context.Background(), rec)
+	assert.Regexp(t, "FF23133.*id1.*evmconnect.events.*503", err)
+	assert.Nil(t, n.conn)
+	err = n.publish(context.Background(), rec)
+	assert.Regexp(t, "FF23133.*400 bad request", err)
+	err = n.publish(context.Background(), rec)
+	assert.Regexp(t, "FF23133", err)
+	err = n.publish(context.Background(), rec)
+	assert.Regexp(t, "FF23132.*Permissions Violation", err)
+	err = n.publish(context.Background(), rec)
+	assert.Regexp(t, "FF23132.*MSG", err)
+	err = n.publish(context.Background(), rec)
+	assert.Regexp(t, "FF23132.*HMSG", err)
+	err = n.publish(context.Background(), rec)
+	assert.NoError(t, err)
+	assert.NotNil(t, n.conn)
+
+	err = n.publish(context.Background(), &sinkRecord{ID: "id2", Value: make(chan int)})
+	assert.Error(t, err)
+}
+
+func TestNATSSinkConnectFail(t *testing.T) {
+	natsURL, closeServer := newTestNATSServer(t, "-ERR 'Authorization Violation'\r\n", nil)
+	defer closeServer()
+	n := newTestNATSSink(t, natsURL)
+	err := n.publish(context.Background(), &sinkRecord{ID: "id1"})
+	assert.Regexp(t, "FF23131.*Authorization Violation", err)
+	assert.Nil(t, n.conn)
+
+	closeServer()
+	err = n.publish(context.Background(), &sinkRecord{ID: "id1"})
+	assert.Regexp(t, "FF23131", err)
+}
+
+func TestNATSSinkConnectNotNATS(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer l.Close()
+	go func() {
+		conn, err := l.Accept()
+		if err == nil {
+			_, _ = io.WriteString(conn, "HTTP/1.1 400 Bad Request\r\n")
+			conn.Close()
+		}
+	}()
+	n := newTestNATSSink(t, "nats://"+l.Addr().String())
+	err = n.publish(context.Background(), &sinkRecord{ID: "id1"})
+	assert.Regexp(t, "FF23131.*FF23132.*HTTP", err)
+}
+
+func TestNewNATSSinkConfig(t *testing.T) {
+	n := newTestNATSSink(t, "tls://nats.example.com")
+	assert.Equal(t, "nats.example.com:4222", n.address)
+	assert.Equal(t, "nats.example.com", n.tlsConfig.ServerName)
+	assert.Equal(t, "token1", n.token)
+
+	n = newTestNATSSink(t, "nats://localhost:4333")
+	assert.Equal(t, "localhost:4333", n.address)
+	assert.Nil(t, n.tlsConfig)
+	assert.Equal(t, "evmconnect.events", n.subjectFor(&sinkRecord{Type: sinkRecordEvent}))
+}
+
+func TestNewNATSSinkFail(t *testing.T) {
+	config.RootConfigReset()
+	conf := config.RootSection("nats_test")
+	InitConfig(conf)
+	natsConf := conf.SubSection(SinksNATSConfig)
+	natsConf.Set(SinkNATSURL, "::bad")
+	_, err := newSinks(context.Background(), &ethConnector{}, conf)
+	assert.Regexp(t, "FF23130", err)
+
+	natsConf.Set(SinkNATSURL, "nats://localhost:4222")
+	tlsConf := natsConf.SubSection("tls")
+	tlsConf.Set(fftls.HTTPConfTLSEnabled, true)
+	tlsConf.Set(fftls.HTTPConfTLSCAFile, "!!!badness")
+	_, err = newNATSSink(context.Background(), natsConf)
+	assert.Regexp(t, "FF00153", err)
+}
@@ -0,0 +1,271 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+)
+
+// eventSink is an external system that events are published to directly, in addition to their delivery
+// to the transaction manager
+type eventSink interface {
+	name() string
+	publish(ctx context.Context, rec *sinkRecord) error
+}
+
+type sinkRecordType string
+
+const (
+	sinkRecordEvent sinkRecordType = "event"
+	sinkRecordBlock sinkRecordType = "block"
+)
+
+// sinkRecord is a single message for a sink. The ID is the same every time the event is delivered, including
+// redelivery after a restart, so duplicates can be discarded by the consumer.
+type sinkRecord struct {
+	ID          string
+	Type        sinkRecordType
+	Key         string // the contract address, so the events of each contract are kept in order
+	BlockNumber int64
+	BlockHash   string
+	Value       interface{}
+}
+
+// SinkEvent is the value published to a sink for each event
+type SinkEvent struct {
+	ffcapi.EventID
+	StreamID   *fftypes.UUID    `json:"streamId"`
+	ProtocolID string           `json:"protocolId"`
+	Address    string           `json:"address"`
+	Info       interface{}      `json:"info"`
+	Data       *fftypes.JSONAny `json:"data"`
+}
+
+// SinkBlock is the value published to a sink for each block added to the canonical chain
+type SinkBlock struct {
+	BlockNumber       fftypes.FFuint64     `json:"blockNumber"`
+	BlockHash         string               `json:"blockHash"`
+	ParentHash        string               `json:"parentHash"`
+	BaseFeePerGas     *ethtypes.HexInteger `json:"baseFeePerGas,omitempty"`
+	NextBaseFeePerGas *ethtypes.HexInteger `json:"nextBaseFeePerGas,omitempty"`
+}
+
+// sinkPublisher publishes records to a sink in order from a background loop, once they have enough confirmations.
+// Delivery of events on a stream waits when the queue is full, so a sink that cannot keep up holds back the stream
+// rather than events being dropped.
+type sinkPublisher struct {
+	c             *ethConnector
+	sink          eventSink
+	streams       map[string]bool
+	confirmations int64
+	blocks        bool
+	queue         chan *sinkRecord
+	done          chan struct{}
+}
+
+// newSinks returns a publisher for each configured sink. The publish loops are not running until start is called.
+func newSinks(ctx context.Context, c *ethConnector, conf config.Section) ([]*sinkPublisher, error) {
+	var sinks []*sinkPublisher
+	kafkaConf := conf.SubSection(SinksKafkaConfig)
+	if kafkaConf.GetString(ffresty.HTTPConfigURL) != "" {
+		sink, err := newKafkaSink(ctx, kafkaConf)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, newSinkPublisher(c, kafkaConf, sink))
+	}
+	natsConf := conf.SubSection(SinksNATSConfig)
+	if natsConf.GetString(SinkNATSURL) != "" {
+		sink, err := newNATSSink(ctx, natsConf)
+		if err != nil {
+			return nil, err
+		}
+		sp := newSinkPublisher(c, natsConf, sink)
+		sp.blocks = natsConf.GetBool(SinkNATSBlocks)
+		sinks = append(sinks, sp)
+	}
+	return sinks, nil
+}
+
+func newSinkPublisher(c *ethConnector, conf config.Section, sink eventSink) *sinkPublisher {
+	sp := &sinkPublisher{
+		c:             c,
+		sink:          sink,
+		streams:       make(map[string]bool),
+		confirmations: conf.GetInt64(SinkConfirmations),
+		queue:         make(chan *sinkRecord, conf.GetInt(SinkQueueSize)),
+		done:          make(chan struct{}),
+	}
+	for _, s := range conf.GetStringSlice(SinkEventStreams) {
+		sp.streams[s] = true
+	}
+	return sp
+}
+
+// publishToSinks queues the event on each of the sinks the stream publishes to, returning false if the stream
+// is stopping before there was space on the queue
+func (es *eventStream) publishToSinks(event *ffcapi.ListenerEvent) bool {
+	for _, sp := range es.c.sinks {
+		if !sp.enqueue(es.ctx, es.id, event) {
+			return false
+		}
+	}
+	return true
+}
+
+func (sp *sinkPublisher) enqueue(ctx context.Context, streamID *fftypes.UUID, event *ffcapi.ListenerEvent) bool {
+	if event.Event == nil || (len(sp.streams) > 0 && !sp.streams[streamID.String()]) {
+		return true
+	}
+	info, _ := event.Event.Info.(*eventInfo)
+	if info != nil && info.QuarantineID != nil {
+		// The error event delivered in place of a quarantined event is for the transaction manager, not the sink
+		return true
+	}
+	value := &SinkEvent{
+		EventID:    event.Event.ID,
+		StreamID:   streamID,
+		ProtocolID: event.Event.ID.ProtocolID(),
+		Info:       event.Event.Info,
+		Data:       event.Event.Data,
+	}
+	if info != nil && info.Address != nil {
+		value.Address = info.Address.String()
+	}
+	rec := &sinkRecord{
+		ID:          fmt.Sprintf("%s/%s", event.Event.ID.ListenerID, value.ProtocolID),
+		Type:        sinkRecordEvent,
+		Key:         value.Address,
+		BlockNumber: int64(event.Event.ID.BlockNumber.Uint64()),
+		BlockHash:   event.Event.ID.BlockHash,
+		Value:       value,
+	}
+	return sp.enqueueRecord(ctx, rec)
+}
+
+// publishToSinks queues the header of a block added to the canonical chain, on each of the sinks that publish blocks.
+// Blocks are published as the block listener receives them, so blocks that arrived while the connector was stopped
+// are not published.
+func (bl *blockListener) publishToSinks(mbi *minimalBlockInfo) {
+	if len(bl.c.sinks) == 0 {
+		return
+	}
+	value := &SinkBlock{
+		BlockNumber: fftypes.FFuint64(mbi.number),
+		BlockHash:   mbi.hash,
+		ParentHash:  mbi.parentHash,
+	}
+	// The block was cached when the listener received it, so the base fee is available without another query
+	if cached, ok := bl.blockCache.Peek(mbi.hash); ok {
+		bi := cached.(*blockInfoJSONRPC)
+		value.BaseFeePerGas = bi.BaseFeePerGas
+		value.NextBaseFeePerGas = (*ethtypes.HexInteger)(nextBaseFee(bi))
+	}
+	for _, sp := range bl.c.sinks {
+		if sp.blocks && !sp.enqueueRecord(bl.ctx, &sinkRecord{
+			ID:          fmt.Sprintf("block/%d/%s", mbi.number, mbi.hash),
+			Type:        sinkRecordBlock,
+			BlockNumber: mbi.number,
+			BlockHash:   mbi.hash,
+			Value:       value,
+		}) {
+			return
+		}
+	}
+}
+
+func (sp *sinkPublisher) enqueueRecord(ctx context.Context, rec *sinkRecord) bool {
+	select {
+	case sp.queue <- rec:
+		return true
+	case <-ctx.Done():
+		log.L(ctx).Warnf("Context closed before %s %s could be queued for the %s sink", rec.Type, rec.ID, sp.sink.name())
+		return false
+	}
+}
+
+// start runs the publish loop, once the connector can no longer fail to be created
+func (sp *sinkPublisher) start(ctx context.Context) {
+	go sp.publishLoop(ctx)
+}
+
+func (sp *sinkPublisher) publishLoop(ctx context.Context) {
+	defer close(sp.done)
+	// Records waiting for confirmations are checked again each time a block might have arrived
+	ticker := time.NewTicker(sp.c.blockListener.blockPollingInterval)
+	defer ticker.Stop()
+	var pending []*sinkRecord
+	for {
+		select {
+		case rec := <-sp.queue:
+			pending = append(pending, rec)
+		case <-ticker.C:
+		case <-ctx.Done():
+			log.L(ctx).Debugf("%s sink publish loop exiting with %d records unpublished", sp.sink.name(), len(pending)+len(sp.queue))
+			return
+		}
+		var ok bool
+		if pending, ok = sp.publishConfirmed(ctx, pending); !ok {
+			return
+		}
+	}
+}
+
+// publishConfirmed publishes records in order from the front of the list, until one does not yet have enough
+// confirmations. Records for a block that has since been replaced in the canonical chain are dropped, as the
+// stream redelivers the events of the new block. Each publish is retried until it succeeds, returning false
+// if the context is closed first.
+func (sp *sinkPublisher) publishConfirmed(ctx context.Context, pending []*sinkRecord) ([]*sinkRecord, bool) {
+	bl := sp.c.blockListener
+	bl.mux.Lock()
+	highestBlock := bl.highestBlock
+	bl.mux.Unlock()
+	for len(pending) > 0 {
+		rec := pending[0]
+		if sp.confirmations > 0 {
+			if rec.BlockNumber > highestBlock-sp.confirmations {
+				break
+			}
+			if hash, ok := bl.getCanonicalBlockHash(rec.BlockNumber); ok && hash != rec.BlockHash {
+				log.L(ctx).Infof("Dropping record %s for the %s sink, as block %d %s has been replaced by %s", rec.ID, sp.sink.name(), rec.BlockNumber, rec.BlockHash, hash)
+				pending = pending[1:]
+				continue
+			}
+		}
+		err := sp.c.retry.Do(ctx, fmt.Sprintf("publish to %s sink", sp.sink.name()), func(_ int) (retry bool, err error) {
+			return true, sp.sink.publish(ctx, rec)
+		})
+		if err != nil {
+			return pending, false
+		}
+		pending = pending[1:]
+	}
+	return pending, true
+}
+
+func (sp *sinkPublisher) waitClosed() {
+	<-sp.done
+}
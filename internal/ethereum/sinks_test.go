@@ -0,0 +1,211 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+	"github.com/stretchr/testify/assert"
+)
+
+type testSink struct {
+	published []*sinkRecord
+	err       error
+}
+
+func (ts *testSink) name() string {
+	return "test"
+}
+
+func (ts *testSink) publish(_ context.Context, rec *sinkRecord) error {
+	if ts.err != nil {
+		return ts.err
+	}
+	ts.published = append(ts.published, rec)
+	return nil
+}
+
+func newTestSinkPublisher(c *ethConnector, confirmations int64) (*sinkPublisher, *testSink) {
+	ts := &testSink{}
+	return &sinkPublisher{
+		c:             c,
+		sink:          ts,
+		streams:       make(map[string]bool),
+		confirmations: confirmations,
+		queue:         make(chan *sinkRecord, 10),
+		done:          make(chan struct{}),
+	}, ts
+}
+
+func testSinkEvent(block int64, blockHash string) *ffcapi.ListenerEvent {
+	return &ffcapi.ListenerEvent{
+		Event: &ffcapi.Event{
+			ID: ffcapi.EventID{
+				ListenerID:  fftypes.NewUUID(),
+				BlockHash:   blockHash,
+				BlockNumber: fftypes.FFuint64(block),
+				LogIndex:    2,
+			},
+			Info: &eventInfo{
+				logJSONRPC: logJSONRPC{
+					Address: ethtypes.MustNewAddress("0x20355f3e852d4b6a9944ada4d2d05d4c2ac7142b"),
+				},
+			},
+			Data: fftypes.JSONAnyPtr(`{"value":"1"}`),
+		},
+	}
+}
+
+func TestSinkEnqueue(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t)
+	defer done()
+	sp, _ := newTestSinkPublisher(c, 0)
+	c.sinks = []*sinkPublisher{sp}
+	defer func() { c.sinks = nil }() // not started, so not waited for on close
+
+	es := &eventStream{id: fftypes.NewUUID(), ctx: ctx, c: c}
+	event := testSinkEvent(1000, "0x12345")
+	assert.True(t, es.publishToSinks(event))
+	rec := <-sp.queue
+	assert.Equal(t, fmt.Sprintf("%s/%.12d/%.6d/%.6d", event.Event.ID.ListenerID, 1000, 0, 2), rec.ID)
+	assert.Equal(t, "0x20355f3e852d4b6a9944ada4d2d05d4c2ac7142b", rec.Key)
+	assert.Equal(t, int64(1000), rec.BlockNumber)
+	assert.Equal(t, "0x12345", rec.BlockHash)
+	value := rec.Value.(*SinkEvent)
+	assert.Equal(t, es.id, value.StreamID)
+	assert.Equal(t, event.Event.Data, value.Data)
+
+	// Block events, quarantined events, and events of other streams are not published
+	assert.True(t, es.publishToSinks(&ffcapi.ListenerEvent{BlockEvent: &ffcapi.BlockEvent{}}))
+	event.Event.Info.(*eventInfo).QuarantineID = fftypes.NewUUID()
+	assert.True(t, es.publishToSinks(event))
+	sp.streams[fftypes.NewUUID().String()] = true
+	assert.True(t, es.publishToSinks(testSinkEvent(1001, "0x23456")))
+	assert.Empty(t, sp.queue)
+}
+
+func TestSinkEnqueueStopping(t *testing.T) {
+	_, c, _, done := newTestConnector(t)
+	defer done()
+	sp, _ := newTestSinkPublisher(c, 0)
+	sp.queue = make(chan *sinkRecord)
+	c.sinks = []*sinkPublisher{sp}
+	defer func() { c.sinks = nil }() // not started, so not waited for on close
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	es := &eventStream{id: fftypes.NewUUID(), ctx: ctx, c: c}
+	assert.False(t, es.publishToSinks(testSinkEvent(1000, "0x12345")))
+}
+
+func TestSinkPublishConfirmed(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t)
+	defer done()
+	sp, ts := newTestSinkPublisher(c, 5)
+
+	bl := c.blockListener
+	bl.highestBlock = 1005
+	bl.canonicalChain.PushBack(&minimalBlockInfo{number: 1000, hash: "0xaaaa"})
+	bl.canonicalChain.PushBack(&minimalBlockInfo{number: 1001, hash: "0xbbbb"})
+	bl.updateCanonicalChainIndex()
+
+	pending := []*sinkRecord{
+		{ID: "replaced", BlockNumber: 1000, BlockHash: "0x1111"},
+		{ID: "confirmed", BlockNumber: 1000, BlockHash: "0xaaaa"},
+		{ID: "unconfirmed", BlockNumber: 1001, BlockHash: "0xbbbb"},
+	}
+	pending, ok := sp.publishConfirmed(ctx, pending)
+	assert.True(t, ok)
+	assert.Len(t, ts.published, 1)
+	assert.Equal(t, "confirmed", ts.published[0].ID)
+	assert.Len(t, pending, 1)
+	assert.Equal(t, "unconfirmed", pending[0].ID)
+
+	bl.highestBlock = 1006
+	pending, ok = sp.publishConfirmed(ctx, pending)
+	assert.True(t, ok)
+	assert.Empty(t, pending)
+	assert.Len(t, ts.published, 2)
+}
+
+func TestSinkPublishStopping(t *testing.T) {
+	_, c, _, done := newTestConnector(t)
+	defer done()
+	sp, ts := newTestSinkPublisher(c, 0)
+	ts.err = fmt.Errorf("pop")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	pending, ok := sp.publishConfirmed(ctx, []*sinkRecord{{ID: "failed"}})
+	assert.False(t, ok)
+	assert.Len(t, pending, 1)
+}
+
+func TestSinkPublishLoop(t *testing.T) {
+	_, c, _, done := newTestConnector(t)
+	defer done()
+	sp, ts := newTestSinkPublisher(c, 0)
+	ts.err = fmt.Errorf("pop")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sp.start(ctx)
+	sp.queue <- &sinkRecord{ID: "failing"}
+	cancel()
+	sp.waitClosed()
+	assert.Empty(t, ts.published)
+
+	// The second record is only received once the first has been published
+	sp, ts = newTestSinkPublisher(c, 0)
+	sp.queue = make(chan *sinkRecord)
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	sp.start(ctx)
+	sp.queue <- &sinkRecord{ID: "published"}
+	sp.queue <- &sinkRecord{ID: "stopping"}
+	cancel()
+	sp.waitClosed()
+	assert.Equal(t, "published", ts.published[0].ID)
+}
+
+func TestNewSinksDisabled(t *testing.T) {
+	_, c, _, done := newTestConnector(t)
+	defer done()
+	assert.Empty(t, c.sinks)
+}
+
+func TestNewSinksEventStreams(t *testing.T) {
+	streamID := fftypes.NewUUID()
+	_, c, _, done := newTestConnectorWithNoBlockerFilterDefaultMocks(t, func(conf config.Section) {
+		kafkaConf := conf.SubSection(SinksKafkaConfig)
+		kafkaConf.Set(ffresty.HTTPConfigURL, "http://localhost:12345")
+		kafkaConf.Set(SinkKafkaClusterID, "cluster1")
+		kafkaConf.Set(SinkKafkaTopic, "events")
+		kafkaConf.Set(SinkEventStreams, []string{streamID.String()})
+		kafkaConf.Set(SinkConfirmations, 12)
+	})
+	defer done()
+	assert.Len(t, c.sinks, 1)
+	assert.True(t, c.sinks[0].streams[streamID.String()])
+	assert.Equal(t, int64(12), c.sinks[0].confirmations)
+}
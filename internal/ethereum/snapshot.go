@@ -0,0 +1,191 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+)
+
+const snapshotVersion = 1
+
+// ConnectorSnapshot is a portable archive of the state the connector builds up while running, so a new instance
+// can take over from it without re-syncing. There are no nonces in the archive, as the connector does not cache
+// them - they are assigned by the transaction manager, or queried from the node each time they are needed.
+type ConnectorSnapshot struct {
+	Version     int                   `json:"version"`
+	ChainID     string                `json:"chainId"`
+	Created     *fftypes.FFTime       `json:"created"`
+	Checkpoints []*SnapshotCheckpoint `json:"checkpoints"`
+	Blocks      []*blockInfoJSONRPC   `json:"blocks"` // the cached blocks of the canonical chain, in block number order
+}
+
+type SnapshotCheckpoint struct {
+	StreamID   *fftypes.UUID       `json:"streamId"`
+	ListenerID *fftypes.UUID       `json:"listenerId"`
+	Checkpoint *listenerCheckpoint `json:"checkpoint"`
+}
+
+type SnapshotRestoreResponse struct {
+	Checkpoints      int             `json:"checkpoints"`
+	SkippedListeners []*fftypes.UUID `json:"skippedListeners"` // listeners already running on this instance, that keep their own checkpoint
+	Blocks           int             `json:"blocks"`
+}
+
+func (c *ethConnector) snapshotChainID(ctx context.Context) (string, error) {
+	if c.chainID != "" {
+		return c.chainID, nil
+	}
+	var chainID string
+	if err := c.backend.CallRPC(ctx, &chainID, "net_version"); err != nil {
+		return "", err.Error()
+	}
+	return chainID, nil
+}
+
+// Snapshot exports the checkpoint each listener would return to the transaction manager, and the blocks of the
+// canonical chain that are in the block cache
+func (c *ethConnector) Snapshot(ctx context.Context) (*ConnectorSnapshot, error) {
+	chainID, err := c.snapshotChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &ConnectorSnapshot{
+		Version:     snapshotVersion,
+		ChainID:     chainID,
+		Created:     fftypes.Now(),
+		Checkpoints: []*SnapshotCheckpoint{},
+		Blocks:      []*blockInfoJSONRPC{},
+	}
+
+	c.mux.Lock()
+	streams := make([]*eventStream, 0, len(c.eventStreams))
+	for _, es := range c.eventStreams {
+		streams = append(streams, es)
+	}
+	c.mux.Unlock()
+	for _, es := range streams {
+		es.mux.Lock()
+		for _, l := range es.listeners {
+			snapshot.Checkpoints = append(snapshot.Checkpoints, &SnapshotCheckpoint{
+				StreamID:   es.id,
+				ListenerID: l.id,
+				Checkpoint: l.getHWMCheckpoint(),
+			})
+		}
+		es.mux.Unlock()
+	}
+	sort.Slice(snapshot.Checkpoints, func(i, j int) bool {
+		return snapshot.Checkpoints[i].ListenerID.String() < snapshot.Checkpoints[j].ListenerID.String()
+	})
+
+	bl := c.blockListener
+	bl.mux.Lock()
+	hashes := make([]string, 0, len(bl.canonicalChainIndex))
+	for _, hash := range bl.canonicalChainIndex {
+		hashes = append(hashes, hash)
+	}
+	bl.mux.Unlock()
+	for _, hash := range hashes {
+		// Peek does not change which blocks are next to be evicted from the cache
+		if cached, ok := bl.blockCache.Peek(hash); ok {
+			snapshot.Blocks = append(snapshot.Blocks, cached.(*blockInfoJSONRPC))
+		}
+	}
+	sort.Slice(snapshot.Blocks, func(i, j int) bool {
+		return snapshot.Blocks[i].Number.BigInt().Cmp(snapshot.Blocks[j].Number.BigInt()) < 0
+	})
+	return snapshot, nil
+}
+
+// Restore loads a snapshot from another instance of the connector. The checkpoints are used when the transaction
+// manager starts each listener, if they are ahead of its own checkpoint, and are also written to the checkpoint
+// store if there is one. The blocks are added to the block cache, where blocks that were re-orged after the
+// snapshot are handled in the same way as any other cached block outside of the canonical chain.
+func (c *ethConnector) Restore(ctx context.Context, snapshot *ConnectorSnapshot) (*SnapshotRestoreResponse, error) {
+	if snapshot.Version != snapshotVersion {
+		return nil, i18n.NewError(ctx, msgs.MsgUnsupportedSnapshotVersion, snapshot.Version, snapshotVersion)
+	}
+	chainID, err := c.snapshotChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot.ChainID != chainID {
+		return nil, i18n.NewError(ctx, msgs.MsgSnapshotChainMismatch, snapshot.ChainID, chainID)
+	}
+
+	res := &SnapshotRestoreResponse{
+		SkippedListeners: []*fftypes.UUID{},
+	}
+	for _, sc := range snapshot.Checkpoints {
+		if sc.ListenerID == nil || sc.Checkpoint == nil {
+			continue
+		}
+		if c.isListenerRunning(sc.ListenerID) {
+			res.SkippedListeners = append(res.SkippedListeners, sc.ListenerID)
+			continue
+		}
+		if c.checkpointStore != nil {
+			if err := c.checkpointStore.put(ctx, sc.ListenerID, sc.Checkpoint); err != nil {
+				return nil, err
+			}
+		}
+		c.snapshotMux.Lock()
+		c.restoredCheckpoints[*sc.ListenerID] = sc.Checkpoint
+		c.snapshotMux.Unlock()
+		res.Checkpoints++
+	}
+
+	for _, bi := range snapshot.Blocks {
+		if bi == nil || bi.Number == nil || bi.Hash == nil {
+			continue
+		}
+		c.blockListener.addToBlockCache(bi)
+		res.Blocks++
+	}
+	log.L(ctx).Infof("Restored snapshot taken at %s with %d checkpoints (%d listeners skipped) and %d blocks", snapshot.Created, res.Checkpoints, len(res.SkippedListeners), res.Blocks)
+	return res, nil
+}
+
+func (c *ethConnector) isListenerRunning(listenerID *fftypes.UUID) bool {
+	c.mux.Lock()
+	streams := make([]*eventStream, 0, len(c.eventStreams))
+	for _, es := range c.eventStreams {
+		streams = append(streams, es)
+	}
+	c.mux.Unlock()
+	for _, es := range streams {
+		es.mux.Lock()
+		_, ok := es.listeners[*listenerID]
+		es.mux.Unlock()
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *ethConnector) restoredCheckpoint(listenerID *fftypes.UUID) *listenerCheckpoint {
+	c.snapshotMux.Lock()
+	defer c.snapshotMux.Unlock()
+	return c.restoredCheckpoints[*listenerID]
+}
@@ -0,0 +1,229 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func testSnapshotBlock(number int64) *blockInfoJSONRPC {
+	return &blockInfoJSONRPC{
+		Number:       ethtypes.NewHexInteger64(number),
+		Hash:         ethtypes.MustNewHexBytes0xPrefix(fmt.Sprintf("0x%064x", number)),
+		ParentHash:   ethtypes.MustNewHexBytes0xPrefix(fmt.Sprintf("0x%064x", number-1)),
+		Timestamp:    ethtypes.NewHexInteger64(1000 + number),
+		Transactions: []ethtypes.HexBytes0xPrefix{},
+	}
+}
+
+func TestSnapshotRestoreRoutes(t *testing.T) {
+	lID := fftypes.NewUUID()
+	ctx, c, mRPC, cDone := newTestConnectorWithNoBlockerFilterDefaultMocks(t)
+	// The block listener indexes the cached blocks it is notified of as the canonical chain, which it
+	// replaces on every poll, so it is not written by the test while the listener is running
+	bl := c.blockListener
+	hashes := []ethtypes.HexBytes0xPrefix{}
+	for _, n := range []int64{testHighBlock - 1, testHighBlock} {
+		bi := testSnapshotBlock(n)
+		bl.addToBlockCache(bi)
+		hashes = append(hashes, bi.Hash)
+	}
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_newBlockFilter").Return(nil).Run(func(args mock.Arguments) {
+		*args[1].(*string) = testBlockFilterID1
+	}).Maybe()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getFilterChanges", testBlockFilterID1).Return(nil).Run(func(args mock.Arguments) {
+		*args[1].(*[]ethtypes.HexBytes0xPrefix) = hashes
+	}).Once()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getFilterChanges", testBlockFilterID1).Return(nil).Maybe()
+	mockStreamLoopEmpty(mRPC)
+	es, _, _, done := testEventStreamExistingConnector(t, ctx, cDone, c, mRPC, &ffcapi.EventListenerAddRequest{
+		ListenerID: lID,
+		EventListenerOptions: ffcapi.EventListenerOptions{
+			Filters: []fftypes.JSONAny{
+				*fftypes.JSONAnyPtr(`{"address":"0x20355f3E852D4b6a9944AdA8d5399dDD3409A431","event":` + abiTransferEvent + `}`),
+			},
+			Options:   fftypes.JSONAnyPtr(`{}`),
+			FromBlock: strconv.Itoa(testHighBlock),
+		},
+	})
+	defer done()
+	assert.Eventually(t, func() bool {
+		bl.mux.Lock()
+		defer bl.mux.Unlock()
+		return len(bl.canonicalChainIndex) == 2
+	}, 5*time.Second, 1*time.Millisecond)
+	// Only cached blocks of the canonical chain are exported
+	bl.mux.Lock()
+	bl.canonicalChainIndex[testHighBlock+1] = testSnapshotBlock(testHighBlock + 1).Hash.String()
+	bl.mux.Unlock()
+	bl.addToBlockCache(testSnapshotBlock(testHighBlock - 5))
+
+	url, close := newTestRouteServer(t, es.c)
+	defer close()
+	res, err := http.Get(url + "/admin/snapshot")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var snapshot ConnectorSnapshot
+	err = json.NewDecoder(res.Body).Decode(&snapshot)
+	assert.NoError(t, err)
+	assert.Equal(t, snapshotVersion, snapshot.Version)
+	assert.Equal(t, "12345", snapshot.ChainID)
+	assert.Len(t, snapshot.Checkpoints, 1)
+	assert.Equal(t, lID, snapshot.Checkpoints[0].ListenerID)
+	assert.Equal(t, es.id, snapshot.Checkpoints[0].StreamID)
+	assert.GreaterOrEqual(t, snapshot.Checkpoints[0].Checkpoint.Block, int64(testHighBlock))
+	assert.Len(t, snapshot.Blocks, 2)
+	assert.Equal(t, int64(testHighBlock-1), snapshot.Blocks[0].Number.Int64())
+	assert.Equal(t, int64(testHighBlock), snapshot.Blocks[1].Number.Int64())
+
+	// Restored on a new instance, the checkpoint is used in place of the older one from the transaction manager
+	_, c2, _, done2 := newTestConnector(t)
+	defer done2()
+	c2.chainID = "12345"
+	url2, close2 := newTestRouteServer(t, c2)
+	defer close2()
+	b, _ := json.Marshal(&snapshot)
+	res, err = http.Post(url2+"/admin/restore", "application/json", bytes.NewReader(b))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var restoreRes SnapshotRestoreResponse
+	err = json.NewDecoder(res.Body).Decode(&restoreRes)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, restoreRes.Checkpoints)
+	assert.Equal(t, 2, restoreRes.Blocks)
+	assert.Empty(t, restoreRes.SkippedListeners)
+
+	cp, err := c2.storedCheckpoint(context.Background(), lID, &listenerCheckpoint{Block: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, snapshot.Checkpoints[0].Checkpoint, cp)
+	cached, ok := c2.blockListener.blockCache.Get(strconv.Itoa(testHighBlock))
+	assert.True(t, ok)
+	assert.Equal(t, snapshot.Blocks[1].Hash, cached.(*blockInfoJSONRPC).Hash)
+
+	c2.removeStoredCheckpoint(context.Background(), lID)
+	assert.Nil(t, c2.restoredCheckpoint(lID))
+}
+
+func TestRestoreSkipsRunningListeners(t *testing.T) {
+	lID := fftypes.NewUUID()
+	es, _, _, done := testEventStream(t, &ffcapi.EventListenerAddRequest{
+		ListenerID: lID,
+		EventListenerOptions: ffcapi.EventListenerOptions{
+			Filters: []fftypes.JSONAny{
+				*fftypes.JSONAnyPtr(`{"event":` + abiTransferEvent + `}`),
+			},
+			Options:   fftypes.JSONAnyPtr(`{}`),
+			FromBlock: strconv.Itoa(testHighBlock),
+		},
+	})
+	defer done()
+	ts := newTestCheckpointStore()
+	es.c.checkpointStore = ts
+	defer func() { es.c.checkpointStore = nil }()
+
+	stoppedID := fftypes.NewUUID()
+	res, err := es.c.Restore(context.Background(), &ConnectorSnapshot{
+		Version: snapshotVersion,
+		ChainID: "12345",
+		Checkpoints: []*SnapshotCheckpoint{
+			{ListenerID: lID, Checkpoint: &listenerCheckpoint{Block: testHighBlock + 100}},
+			{ListenerID: stoppedID, Checkpoint: &listenerCheckpoint{Block: 12345}},
+			{ListenerID: fftypes.NewUUID()},
+		},
+		Blocks: []*blockInfoJSONRPC{nil, {}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, res.Checkpoints)
+	assert.Equal(t, []*fftypes.UUID{lID}, res.SkippedListeners)
+	assert.Zero(t, res.Blocks)
+	assert.Equal(t, int64(12345), ts.checkpoints[*stoppedID].Block)
+	assert.Nil(t, ts.checkpoints[*lID])
+}
+
+func TestRestoreCheckpointStoreFail(t *testing.T) {
+	_, c, _, done := newTestConnector(t)
+	defer done()
+	c.chainID = "12345"
+	ts := newTestCheckpointStore()
+	ts.err = fmt.Errorf("pop")
+	c.checkpointStore = ts
+	defer func() { c.checkpointStore = nil }()
+
+	lID := fftypes.NewUUID()
+	_, err := c.Restore(context.Background(), &ConnectorSnapshot{
+		Version:     snapshotVersion,
+		ChainID:     "12345",
+		Checkpoints: []*SnapshotCheckpoint{{ListenerID: lID, Checkpoint: &listenerCheckpoint{Block: 12345}}},
+	})
+	assert.Regexp(t, "pop", err)
+	assert.Nil(t, c.restoredCheckpoint(lID))
+}
+
+func TestRestoreBadVersion(t *testing.T) {
+	_, c, _, done := newTestConnector(t)
+	defer done()
+
+	_, err := c.Restore(context.Background(), &ConnectorSnapshot{Version: 2})
+	assert.Regexp(t, "FF23142", err)
+}
+
+func TestRestoreChainMismatch(t *testing.T) {
+	_, c, mRPC, done := newTestConnector(t)
+	defer done()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "net_version").
+		Run(func(args mock.Arguments) {
+			*(args[1].(*string)) = "80001"
+		}).
+		Return(nil)
+
+	_, err := c.Restore(context.Background(), &ConnectorSnapshot{Version: snapshotVersion, ChainID: "12345"})
+	assert.Regexp(t, "FF23143.*12345.*80001", err)
+}
+
+func TestRestoreChainIDFail(t *testing.T) {
+	_, c, mRPC, done := newTestConnector(t)
+	defer done()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "net_version").
+		Return(&rpcbackend.RPCError{Message: "pop"})
+
+	_, err := c.Restore(context.Background(), &ConnectorSnapshot{Version: snapshotVersion, ChainID: "12345"})
+	assert.Regexp(t, "pop", err)
+}
+
+func TestSnapshotChainIDFail(t *testing.T) {
+	_, c, mRPC, done := newTestConnector(t)
+	defer done()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "net_version").
+		Return(&rpcbackend.RPCError{Message: "pop"})
+
+	_, err := c.Snapshot(context.Background())
+	assert.Regexp(t, "pop", err)
+}
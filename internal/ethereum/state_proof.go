@@ -0,0 +1,113 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+)
+
+type StateProofRequest struct {
+	Address      string         `json:"address"`
+	StorageSlots []*StorageSlot `json:"storageSlots,omitempty"`
+	BlockNumber  string         `json:"blockNumber,omitempty"`
+}
+
+// StateProofResponse contains the Merkle proofs of the account, and each storage slot, against the
+// state root of the block - which is included so the proofs can be checked without another call
+type StateProofResponse struct {
+	BlockNumber  *fftypes.FFBigInt           `json:"blockNumber"`
+	BlockHash    ethtypes.HexBytes0xPrefix   `json:"blockHash"`
+	StateRoot    ethtypes.HexBytes0xPrefix   `json:"stateRoot"`
+	Address      *ethtypes.Address0xHex      `json:"address"`
+	AccountProof []ethtypes.HexBytes0xPrefix `json:"accountProof"`
+	Balance      *fftypes.FFBigInt           `json:"balance"`
+	Nonce        *fftypes.FFBigInt           `json:"nonce"`
+	CodeHash     ethtypes.HexBytes0xPrefix   `json:"codeHash"`
+	StorageHash  ethtypes.HexBytes0xPrefix   `json:"storageHash"`
+	StorageProof []*StorageProof             `json:"storageProof"`
+}
+
+type StorageProof struct {
+	Key   string                      `json:"key"`
+	Value *ethtypes.HexInteger        `json:"value"`
+	Proof []ethtypes.HexBytes0xPrefix `json:"proof"`
+}
+
+// accountProofJSONRPC is the result of eth_getProof as defined in EIP-1186
+type accountProofJSONRPC struct {
+	Address      *ethtypes.Address0xHex      `json:"address"`
+	AccountProof []ethtypes.HexBytes0xPrefix `json:"accountProof"`
+	Balance      *ethtypes.HexInteger        `json:"balance"`
+	Nonce        *ethtypes.HexInteger        `json:"nonce"`
+	CodeHash     ethtypes.HexBytes0xPrefix   `json:"codeHash"`
+	StorageHash  ethtypes.HexBytes0xPrefix   `json:"storageHash"`
+	StorageProof []*StorageProof             `json:"storageProof"`
+}
+
+// StateProof gets the EIP-1186 account and storage proofs of an address with eth_getProof.
+// The block is resolved to a header first, so that a block tag such as "latest" cannot move
+// between the proof and the state root it is to be verified against.
+func (c *ethConnector) StateProof(ctx context.Context, req *StateProofRequest) (*StateProofResponse, error) {
+	address, err := ethtypes.NewAddress(req.Address)
+	if err != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidAccountAddress, req.Address, err)
+	}
+	storageKeys := make([]ethtypes.HexBytes0xPrefix, len(req.StorageSlots))
+	for i, s := range req.StorageSlots {
+		if storageKeys[i], err = computeStorageSlot(ctx, s); err != nil {
+			return nil, err
+		}
+	}
+
+	blockNumber := req.BlockNumber
+	if blockNumber == "" {
+		blockNumber = "latest"
+	}
+	backend := c.readBackend()
+	var header *BlockHeader
+	if rpcErr := backend.CallRPC(ctx, &header, "eth_getBlockByNumber", blockNumber, false); rpcErr != nil {
+		return nil, rpcErr.Error()
+	}
+	if header == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgBlockNotFound, blockNumber)
+	}
+
+	var proof *accountProofJSONRPC
+	if rpcErr := backend.CallRPC(ctx, &proof, "eth_getProof", address, storageKeys, header.Number); rpcErr != nil {
+		return nil, rpcErr.Error()
+	}
+	if proof == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgStateProofNotAvailable, req.Address, header.Number.BigInt())
+	}
+	return &StateProofResponse{
+		BlockNumber:  (*fftypes.FFBigInt)(header.Number),
+		BlockHash:    header.Hash,
+		StateRoot:    header.StateRoot,
+		Address:      proof.Address,
+		AccountProof: proof.AccountProof,
+		Balance:      (*fftypes.FFBigInt)(proof.Balance),
+		Nonce:        (*fftypes.FFBigInt)(proof.Nonce),
+		CodeHash:     proof.CodeHash,
+		StorageHash:  proof.StorageHash,
+		StorageProof: proof.StorageProof,
+	}, nil
+}
@@ -0,0 +1,187 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hyperledger/firefly-evmconnect/mocks/rpcbackendmocks"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const sampleJSONRPCProof = `{
+	"address": "0x4a8c8f1717570f9774652075e249ded38124d708",
+	"accountProof": [
+		"0xf90211a0aaaa",
+		"0xf8518080a0bbbb"
+	],
+	"balance": "0x1bc16d674ec80000",
+	"codeHash": "0xc5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470",
+	"nonce": "0x2",
+	"storageHash": "0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421",
+	"storageProof": [
+		{
+			"key": "0xfbcaac306dbb7211900a6b99edaddde8ab8cab1e6fa9bbf891de9d58c5551c91",
+			"value": "0x2a",
+			"proof": ["0xe3a120cccc"]
+		}
+	]
+}`
+
+func mockGetBlockByNumberForProof(mRPC *rpcbackendmocks.Backend, blockNumber string) *mock.Call {
+	return mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", blockNumber, false).
+		Run(func(args mock.Arguments) {
+			*(args[1].(**BlockHeader)) = &BlockHeader{
+				Number:    ethtypes.NewHexInteger64(1977),
+				Hash:      ethtypes.MustNewHexBytes0xPrefix("0x6197ef1a58a2a592bb447efb651f0db7945de21aa8048801b250bd7b7431f9b6"),
+				StateRoot: ethtypes.MustNewHexBytes0xPrefix("0xd7f8974fb5ac78d9ac099b9ad5018bedc2ce0a72dad1827a1709da30580f0544"),
+			}
+		}).
+		Return(nil)
+}
+
+func TestStateProofOK(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mockGetBlockByNumberForProof(mRPC, "latest")
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getProof",
+		mock.MatchedBy(func(addr *ethtypes.Address0xHex) bool {
+			return addr.String() == sampleContractAddress
+		}),
+		mock.MatchedBy(func(keys []ethtypes.HexBytes0xPrefix) bool {
+			return len(keys) == 1 && keys[0].String() == "0xfbcaac306dbb7211900a6b99edaddde8ab8cab1e6fa9bbf891de9d58c5551c91"
+		}),
+		mock.MatchedBy(func(blockNumber *ethtypes.HexInteger) bool {
+			return blockNumber.BigInt().Int64() == 1977
+		})).
+		Run(func(args mock.Arguments) {
+			err := json.Unmarshal([]byte(sampleJSONRPCProof), args[1])
+			assert.NoError(t, err)
+		}).
+		Return(nil)
+
+	res, err := c.StateProof(ctx, &StateProofRequest{
+		Address: sampleContractAddress,
+		StorageSlots: []*StorageSlot{
+			{Slot: "3", MappingKeys: []string{"0xd0f2f5103fd050739a9fb567251bc460cc24d091"}},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1977), res.BlockNumber.Int64())
+	assert.Equal(t, "0xd7f8974fb5ac78d9ac099b9ad5018bedc2ce0a72dad1827a1709da30580f0544", res.StateRoot.String())
+	assert.Equal(t, "2000000000000000000", res.Balance.String())
+	assert.Equal(t, int64(2), res.Nonce.Int64())
+	assert.Len(t, res.AccountProof, 2)
+	assert.Len(t, res.StorageProof, 1)
+	assert.Equal(t, int64(42), res.StorageProof[0].Value.BigInt().Int64())
+}
+
+func TestStateProofBadAddress(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t)
+	defer done()
+
+	_, err := c.StateProof(ctx, &StateProofRequest{Address: "wrong"})
+	assert.Regexp(t, "FF23087", err)
+}
+
+func TestStateProofBadSlot(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t)
+	defer done()
+
+	_, err := c.StateProof(ctx, &StateProofRequest{
+		Address:      sampleContractAddress,
+		StorageSlots: []*StorageSlot{{Slot: "wrong"}},
+	})
+	assert.Regexp(t, "FF23063", err)
+}
+
+func TestStateProofBlockFail(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", "0x1b4", false).
+		Return(&rpcbackend.RPCError{Message: "pop"})
+
+	_, err := c.StateProof(ctx, &StateProofRequest{Address: sampleContractAddress, BlockNumber: "0x1b4"})
+	assert.Regexp(t, "pop", err)
+}
+
+func TestStateProofBlockNotFound(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", "0x1b4", false).
+		Return(nil)
+
+	_, err := c.StateProof(ctx, &StateProofRequest{Address: sampleContractAddress, BlockNumber: "0x1b4"})
+	assert.Regexp(t, "FF23072", err)
+}
+
+func TestStateProofFail(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mockGetBlockByNumberForProof(mRPC, "latest")
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getProof", mock.Anything, mock.Anything, mock.Anything).
+		Return(&rpcbackend.RPCError{Message: "pop"})
+
+	_, err := c.StateProof(ctx, &StateProofRequest{Address: sampleContractAddress})
+	assert.Regexp(t, "pop", err)
+}
+
+func TestStateProofNotAvailable(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mockGetBlockByNumberForProof(mRPC, "latest")
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getProof", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil)
+
+	_, err := c.StateProof(ctx, &StateProofRequest{Address: sampleContractAddress})
+	assert.Regexp(t, "FF23088", err)
+}
+
+func TestPostStateProofRoute(t *testing.T) {
+	_, c, mRPC, done := newTestConnector(t)
+	defer done()
+	url, close := newTestRouteServer(t, c)
+	defer close()
+
+	mockGetBlockByNumberForProof(mRPC, "0x7b9")
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getProof", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			err := json.Unmarshal([]byte(sampleJSONRPCProof), args[1])
+			assert.NoError(t, err)
+		}).
+		Return(nil)
+
+	res, err := http.Post(url+"/accounts/"+sampleContractAddress+"/proof", "application/json",
+		bytes.NewReader([]byte(`{"storageSlots":[{"slot":"0"}],"blockNumber":"0x7b9"}`)))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var proof StateProofResponse
+	err = json.NewDecoder(res.Body).Decode(&proof)
+	assert.NoError(t, err)
+	assert.Equal(t, "0x6197ef1a58a2a592bb447efb651f0db7945de21aa8048801b250bd7b7431f9b6", proof.BlockHash.String())
+}
@@ -34,11 +34,24 @@ func (c *ethConnector) IsReady(ctx context.Context) (*ffcapi.ReadyResponse, ffca
 	if err != nil {
 		return &ffcapi.ReadyResponse{
 			Ready: false,
-		}, mapError(netVersionRPCMethods, err.Error()), err.Error()
+		}, mapRPCError(netVersionRPCMethods, err), err.Error()
 	}
 
 	details := &fftypes.JSONObject{
 		"chainID": c.chainID,
+		"node":    nodeInfo(ctx, c.backend),
+	}
+	if c.readOnlyBackend != nil {
+		(*details)["endpoints"] = c.readEndpointDetails()
+	}
+	if c.latencySLOs != nil {
+		// Breached latency SLOs do not make the connector unready, but degrade the status reported to probes
+		slos, degraded := c.latencySLOs.status()
+		(*details)["status"] = ReadyStatusOK
+		if degraded {
+			(*details)["status"] = ReadyStatusDegraded
+		}
+		(*details)["latencySLOs"] = slos
 	}
 
 	return &ffcapi.ReadyResponse{
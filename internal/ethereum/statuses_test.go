@@ -43,6 +43,7 @@ func TestIsReady(t *testing.T) {
 			*(args[1].(*string)) = "80001"
 		}).
 		Return(nil)
+	mockNodeInfo(mRPC, "Geth/v1.14.12-stable/linux-amd64/go1.23.4", 25)
 
 	status, _, err := c.IsReady(ctx)
 	assert.NoError(t, err)
@@ -51,6 +52,9 @@ func TestIsReady(t *testing.T) {
 
 	details := status.DownstreamDetails.JSONObject()
 	assert.Equal(t, details.GetString("chainID"), "80001")
+	assert.Equal(t, "Geth/v1.14.12-stable/linux-amd64/go1.23.4", details.GetObject("node").GetString("clientVersion"))
+	assert.Equal(t, "0x41", details.GetObject("node").GetString("protocolVersion"))
+	assert.Equal(t, int64(25), details.GetObject("node").GetInt64("peerCount"))
 }
 
 func TestIsReadyError(t *testing.T) {
@@ -0,0 +1,150 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"math/big"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"golang.org/x/crypto/sha3"
+)
+
+var (
+	// See https://eips.ethereum.org/EIPS/eip-1967 - each slot is keccak256(name) - 1
+	eip1967ImplementationSlot = eip1967Slot("eip1967.proxy.implementation")
+	eip1967AdminSlot          = eip1967Slot("eip1967.proxy.admin")
+	eip1967BeaconSlot         = eip1967Slot("eip1967.proxy.beacon")
+
+	wellKnownStorageSlots = map[string][]byte{
+		"eip1967.proxy.implementation": eip1967ImplementationSlot,
+		"eip1967.proxy.admin":          eip1967AdminSlot,
+		"eip1967.proxy.beacon":         eip1967BeaconSlot,
+	}
+
+	uint256Mod = new(big.Int).Lsh(big.NewInt(1), 256)
+)
+
+// StorageSlot is a base storage slot, with the mapping keys and array index to apply to it
+type StorageSlot struct {
+	Slot        string   `json:"slot"`
+	MappingKeys []string `json:"mappingKeys,omitempty"`
+	ArrayIndex  string   `json:"arrayIndex,omitempty"`
+}
+
+type StorageQueryRequest struct {
+	Address string `json:"address"`
+	StorageSlot
+	BlockNumber string `json:"blockNumber,omitempty"`
+}
+
+type StorageQueryResponse struct {
+	Slot  ethtypes.HexBytes0xPrefix `json:"slot"`
+	Value ethtypes.HexBytes0xPrefix `json:"value"`
+}
+
+func keccak256(data ...[]byte) []byte {
+	hash := sha3.NewLegacyKeccak256()
+	for _, d := range data {
+		hash.Write(d)
+	}
+	return hash.Sum(nil)
+}
+
+func eip1967Slot(name string) []byte {
+	slot := new(big.Int).SetBytes(keccak256([]byte(name)))
+	return slot.Sub(slot, big.NewInt(1)).FillBytes(make([]byte, 32))
+}
+
+// parseStorageWord parses a decimal integer, or 0x prefixed hex of up to 32 bytes, as a left padded 32 byte word
+func parseStorageWord(s string) ([]byte, bool) {
+	if strings.HasPrefix(s, "0x") {
+		b, err := ethtypes.NewHexBytes0xPrefix(s)
+		if err != nil || len(b) > 32 {
+			return nil, false
+		}
+		return append(make([]byte, 32-len(b)), b...), true
+	}
+	i, ok := new(big.Int).SetString(s, 10)
+	if !ok || i.Sign() < 0 || i.BitLen() > 256 {
+		return nil, false
+	}
+	return i.FillBytes(make([]byte, 32)), true
+}
+
+// computeStorageSlot follows the Solidity storage layout rules, starting from a base slot:
+//   - each mapping key (32 bytes, left padded) is applied in order with keccak256(key . slot)
+//   - an array index addresses an element of the dynamic array at the resulting slot with keccak256(slot) + index
+func computeStorageSlot(ctx context.Context, req *StorageSlot) ([]byte, error) {
+	slot, ok := wellKnownStorageSlots[req.Slot]
+	if !ok {
+		if slot, ok = parseStorageWord(req.Slot); !ok {
+			return nil, i18n.NewError(ctx, msgs.MsgInvalidStorageSlot, req.Slot)
+		}
+	}
+	for _, k := range req.MappingKeys {
+		key, ok := parseStorageWord(k)
+		if !ok {
+			return nil, i18n.NewError(ctx, msgs.MsgInvalidStorageKey, k)
+		}
+		slot = keccak256(key, slot)
+	}
+	if req.ArrayIndex != "" {
+		index, ok := parseStorageWord(req.ArrayIndex)
+		if !ok {
+			return nil, i18n.NewError(ctx, msgs.MsgInvalidStorageArrayIndex, req.ArrayIndex)
+		}
+		element := new(big.Int).SetBytes(keccak256(slot))
+		element.Add(element, new(big.Int).SetBytes(index))
+		slot = element.Mod(element, uint256Mod).FillBytes(make([]byte, 32))
+	}
+	return slot, nil
+}
+
+// StorageQuery reads a raw storage slot of a contract with eth_getStorageAt, after computing the slot
+func (c *ethConnector) StorageQuery(ctx context.Context, req *StorageQueryRequest) (*StorageQueryResponse, error) {
+	address, err := ethtypes.NewAddress(req.Address)
+	if err != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidContractAddress, req.Address, err)
+	}
+	slot, err := computeStorageSlot(ctx, &req.StorageSlot)
+	if err != nil {
+		return nil, err
+	}
+	value, err := c.getStorageAt(ctx, address, slot, req.BlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	return &StorageQueryResponse{
+		Slot:  slot,
+		Value: value,
+	}, nil
+}
+
+func (c *ethConnector) getStorageAt(ctx context.Context, address *ethtypes.Address0xHex, slot []byte, blockNumber string) (ethtypes.HexBytes0xPrefix, error) {
+	if blockNumber == "" {
+		blockNumber = "latest"
+	}
+	var value ethtypes.HexBytes0xPrefix
+	if rpcErr := c.readBackend().CallRPC(ctx, &value, "eth_getStorageAt", address, ethtypes.HexBytes0xPrefix(slot), blockNumber); rpcErr != nil {
+		return nil, rpcErr.Error()
+	}
+	return value, nil
+}
@@ -0,0 +1,191 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hyperledger/firefly-evmconnect/mocks/rpcbackendmocks"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const sampleStorageValue = "0x000000000000000000000000d0f2f5103fd050739a9fb567251bc460cc24d091"
+
+func mockGetStorageAt(mRPC *rpcbackendmocks.Backend, slot, blockNumber string) *mock.Call {
+	return mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getStorageAt",
+		mock.MatchedBy(func(addr *ethtypes.Address0xHex) bool {
+			return addr.String() == sampleContractAddress
+		}),
+		mock.MatchedBy(func(s ethtypes.HexBytes0xPrefix) bool {
+			return s.String() == slot
+		}),
+		blockNumber).
+		Run(func(args mock.Arguments) {
+			*(args[1].(*ethtypes.HexBytes0xPrefix)) = ethtypes.MustNewHexBytes0xPrefix(sampleStorageValue)
+		}).
+		Return(nil)
+}
+
+func TestEIP1967Slots(t *testing.T) {
+	assert.Equal(t, "0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc", ethtypes.HexBytes0xPrefix(eip1967ImplementationSlot).String())
+	assert.Equal(t, "0xb53127684a568b3173ae13b9f8a6016e243e63b6e8ee1178d6a717850b5d6103", ethtypes.HexBytes0xPrefix(eip1967AdminSlot).String())
+	assert.Equal(t, "0xa3f0ad74e5423aebfd80d3ef4346578335a9a72aeaee59ff6cb3582b35133d50", ethtypes.HexBytes0xPrefix(eip1967BeaconSlot).String())
+}
+
+func TestComputeStorageSlot(t *testing.T) {
+	ctx, _, _, done := newTestConnector(t)
+	defer done()
+
+	slot, err := computeStorageSlot(ctx, &StorageSlot{Slot: "3"})
+	assert.NoError(t, err)
+	assert.Equal(t, "0x0000000000000000000000000000000000000000000000000000000000000003", ethtypes.HexBytes0xPrefix(slot).String())
+
+	slot, err = computeStorageSlot(ctx, &StorageSlot{Slot: "0x03", MappingKeys: []string{"0xd0f2f5103fd050739a9fb567251bc460cc24d091"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "0xfbcaac306dbb7211900a6b99edaddde8ab8cab1e6fa9bbf891de9d58c5551c91", ethtypes.HexBytes0xPrefix(slot).String())
+
+	slot, err = computeStorageSlot(ctx, &StorageSlot{Slot: "3", MappingKeys: []string{"0xd0f2f5103fd050739a9fb567251bc460cc24d091", "7"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "0xa81d63e8fe3cc768f6d3a28c31789055b61ef3f5e9e38a7e57db455d8d8a8d21", ethtypes.HexBytes0xPrefix(slot).String())
+
+	slot, err = computeStorageSlot(ctx, &StorageSlot{Slot: "5", ArrayIndex: "2"})
+	assert.NoError(t, err)
+	assert.Equal(t, "0x036b6384b5eca791c62761152d0c79bb0604c104a5fb6f4eb0703f3154bb3db2", ethtypes.HexBytes0xPrefix(slot).String())
+
+	slot, err = computeStorageSlot(ctx, &StorageSlot{Slot: "eip1967.proxy.beacon"})
+	assert.NoError(t, err)
+	assert.Equal(t, eip1967BeaconSlot, slot)
+}
+
+func TestComputeStorageSlotWraps(t *testing.T) {
+	ctx, _, _, done := newTestConnector(t)
+	defer done()
+
+	slot, err := computeStorageSlot(ctx, &StorageSlot{Slot: "5", ArrayIndex: "0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"})
+	assert.NoError(t, err)
+	assert.Equal(t, "0x036b6384b5eca791c62761152d0c79bb0604c104a5fb6f4eb0703f3154bb3daf", ethtypes.HexBytes0xPrefix(slot).String())
+}
+
+func TestComputeStorageSlotBadInputs(t *testing.T) {
+	ctx, _, _, done := newTestConnector(t)
+	defer done()
+
+	_, err := computeStorageSlot(ctx, &StorageSlot{Slot: "not.a.slot"})
+	assert.Regexp(t, "FF23063", err)
+
+	_, err = computeStorageSlot(ctx, &StorageSlot{Slot: "-1"})
+	assert.Regexp(t, "FF23063", err)
+
+	_, err = computeStorageSlot(ctx, &StorageSlot{Slot: "0", MappingKeys: []string{"0x" + string(bytes.Repeat([]byte("00"), 33))}})
+	assert.Regexp(t, "FF23064", err)
+
+	_, err = computeStorageSlot(ctx, &StorageSlot{Slot: "0", ArrayIndex: "0xzz"})
+	assert.Regexp(t, "FF23065", err)
+}
+
+func TestStorageQueryOK(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mockGetStorageAt(mRPC, "0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc", "latest")
+
+	res, err := c.StorageQuery(ctx, &StorageQueryRequest{
+		Address:     sampleContractAddress,
+		StorageSlot: StorageSlot{Slot: "eip1967.proxy.implementation"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, sampleStorageValue, res.Value.String())
+	assert.Equal(t, "0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc", res.Slot.String())
+}
+
+func TestStorageQueryBlockNumber(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mockGetStorageAt(mRPC, "0x0000000000000000000000000000000000000000000000000000000000000000", "0x1b4")
+
+	res, err := c.StorageQuery(ctx, &StorageQueryRequest{
+		Address:     sampleContractAddress,
+		StorageSlot: StorageSlot{Slot: "0"},
+		BlockNumber: "0x1b4",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, sampleStorageValue, res.Value.String())
+}
+
+func TestStorageQueryBadAddress(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t)
+	defer done()
+
+	_, err := c.StorageQuery(ctx, &StorageQueryRequest{Address: "wrong", StorageSlot: StorageSlot{Slot: "0"}})
+	assert.Regexp(t, "FF23060", err)
+}
+
+func TestStorageQueryBadSlot(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t)
+	defer done()
+
+	_, err := c.StorageQuery(ctx, &StorageQueryRequest{Address: sampleContractAddress, StorageSlot: StorageSlot{Slot: "wrong"}})
+	assert.Regexp(t, "FF23063", err)
+}
+
+func TestStorageQueryRPCFail(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getStorageAt", mock.Anything, mock.Anything, "latest").
+		Return(&rpcbackend.RPCError{Message: "pop"})
+
+	_, err := c.StorageQuery(ctx, &StorageQueryRequest{Address: sampleContractAddress, StorageSlot: StorageSlot{Slot: "0"}})
+	assert.Regexp(t, "pop", err)
+}
+
+func TestPostStorageQueryRoute(t *testing.T) {
+	_, c, mRPC, done := newTestConnector(t)
+	defer done()
+	url, close := newTestRouteServer(t, c)
+	defer close()
+
+	mockGetStorageAt(mRPC, "0xfbcaac306dbb7211900a6b99edaddde8ab8cab1e6fa9bbf891de9d58c5551c91", "latest")
+
+	res, err := http.Post(url+"/contracts/"+sampleContractAddress+"/storage", "application/json",
+		bytes.NewReader([]byte(`{"slot":"3","mappingKeys":["0xd0f2f5103fd050739a9fb567251bc460cc24d091"]}`)))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var storage StorageQueryResponse
+	err = json.NewDecoder(res.Body).Decode(&storage)
+	assert.NoError(t, err)
+	assert.Equal(t, sampleStorageValue, storage.Value.String())
+}
+
+func TestPostStorageQueryRouteBadSlot(t *testing.T) {
+	_, c, _, done := newTestConnector(t)
+	defer done()
+	url, close := newTestRouteServer(t, c)
+	defer close()
+
+	res, err := http.Post(url+"/contracts/"+sampleContractAddress+"/storage", "application/json",
+		bytes.NewReader([]byte(`{"slot":"wrong"}`)))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+}
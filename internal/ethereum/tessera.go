@@ -0,0 +1,204 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"math/big"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-signer/pkg/ethsigner"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rlp"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+)
+
+// GoQuorum marks a signed private transaction by using a V value of 37/38, in place of the
+// 27/28 of a homestead signature. EIP-155 signatures cannot be used for private transactions.
+const (
+	homesteadV        = 27
+	quorumPrivateV    = 37
+	quorumPrivateVGap = quorumPrivateV - homesteadV
+)
+
+// PrivateSendRequest is a transaction send request with the Tessera public keys of the
+// GoQuorum private transaction. For a pre-signed transaction, the data of the transaction
+// must be the hash of the payload already stored in Tessera.
+type PrivateSendRequest struct {
+	PrivateFrom string   `json:"privateFrom,omitempty"`
+	PrivateFor  []string `json:"privateFor"`
+	ffcapi.TransactionSendRequest
+}
+
+// quorumPrivateTx is the eth_sendTransactionAsync payload of a GoQuorum private transaction
+type quorumPrivateTx struct {
+	*ethsigner.Transaction
+	PrivateFrom string   `json:"privateFrom,omitempty"`
+	PrivateFor  []string `json:"privateFor"`
+}
+
+// quorumPrivateRawTxArgs are the arguments that accompany the signed payload to eth_sendRawPrivateTransaction
+type quorumPrivateRawTxArgs struct {
+	PrivateFor []string `json:"privateFor"`
+}
+
+// validateTesseraKeys checks the Tessera public keys of a private transaction, which are the base64 encoding of 32 bytes
+func validateTesseraKeys(ctx context.Context, privateFrom string, privateFor []string) error {
+	if len(privateFor) == 0 {
+		return i18n.NewError(ctx, msgs.MsgMissingPrivateFor)
+	}
+	keys := privateFor
+	if privateFrom != "" {
+		keys = append([]string{privateFrom}, privateFor...)
+	}
+	for _, k := range keys {
+		b, err := base64.StdEncoding.DecodeString(k)
+		if err != nil || len(b) != 32 {
+			return i18n.NewError(ctx, msgs.MsgInvalidTesseraKey, k)
+		}
+	}
+	return nil
+}
+
+// markPrivateRawTx sets the V marker of a signed legacy transaction to identify it to GoQuorum as private,
+// leaving transactions that are already marked unchanged. The transaction with the homestead V it was
+// signed with is also returned, as that is the encoding the signer can be recovered from.
+func markPrivateRawTx(ctx context.Context, rawTx string) (signed, marked ethtypes.HexBytes0xPrefix, err error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(rawTx, "0x"))
+	if err != nil {
+		return nil, nil, i18n.NewError(ctx, msgs.MsgInvalidTXData, rawTx, err)
+	}
+	decoded, _, err := rlp.Decode(b)
+	if err != nil || decoded == nil || !decoded.IsList() || len(decoded.(rlp.List)) != 9 {
+		return nil, nil, i18n.NewError(ctx, msgs.MsgInvalidPrivateRawTx)
+	}
+	tx := decoded.(rlp.List)
+	v := tx[6].ToData().IntOrZero().Int64()
+	switch v {
+	case homesteadV, homesteadV + 1:
+	case quorumPrivateV, quorumPrivateV + 1:
+		v -= quorumPrivateVGap
+	default:
+		return nil, nil, i18n.NewError(ctx, msgs.MsgInvalidPrivateTxV, v)
+	}
+	tx[6] = rlp.WrapInt(big.NewInt(v))
+	signed = tx.Encode()
+	tx[6] = rlp.WrapInt(big.NewInt(v + quorumPrivateVGap))
+	return signed, tx.Encode(), nil
+}
+
+// PrivateTransactionSend sends a GoQuorum private transaction, distributing the private payload via Tessera
+// to the parties in privateFor. Unsigned transactions are sent with eth_sendTransactionAsync, so the response
+// does not wait for the payload to be distributed, and pre-signed transactions with eth_sendRawPrivateTransaction.
+// Private transactions must be sent to the primary endpoint, as the node needs its attached Tessera.
+func (c *ethConnector) PrivateTransactionSend(ctx context.Context, req *PrivateSendRequest) (*ffcapi.TransactionSendResponse, ffcapi.ErrorReason, error) {
+	res, reason, err := c.privateTransactionSend(ctx, req)
+	c.auditSubmission(ctx, auditOperationPrivateSend, &req.TransactionSendRequest, req.PrivateFor, res, reason, err)
+	return res, reason, err
+}
+
+func (c *ethConnector) privateTransactionSend(ctx context.Context, req *PrivateSendRequest) (*ffcapi.TransactionSendResponse, ffcapi.ErrorReason, error) {
+	if err := validateTesseraKeys(ctx, req.PrivateFrom, req.PrivateFor); err != nil {
+		return nil, ffcapi.ErrorReasonInvalidInputs, err
+	}
+
+	var rpcError *rpcbackend.RPCError
+	var txHash ethtypes.HexBytes0xPrefix
+	release := func() {}
+	if req.PreSigned {
+		signedTx, rawTx, err := markPrivateRawTx(ctx, req.TransactionData)
+		if err != nil {
+			return nil, ffcapi.ErrorReasonInvalidInputs, err
+		}
+		// The policy checks the transaction as it was signed, as the private V marker does not recover the signer.
+		// Private transactions are signed without a chain ID, so need allowUnprotected when the chain ID is verified.
+		signed, reason, err := c.checkPreSignedPolicy(ctx, signedTx)
+		if err != nil {
+			return nil, reason, err
+		}
+		var signedGas *ethtypes.HexInteger
+		if signed != nil {
+			signedGas = signed.Gas
+		}
+		if reason, err := c.checkTxLimits(ctx, len(rawTx), signedGas); err != nil {
+			return nil, reason, err
+		}
+		if signed != nil {
+			if release, reason, err = c.reserveValue(ctx, signed.From, signed.Value); err != nil {
+				return nil, reason, err
+			}
+		}
+		rpcError = c.backend.CallRPC(ctx, &txHash, "eth_sendRawPrivateTransaction", rawTx, &quorumPrivateRawTxArgs{PrivateFor: req.PrivateFor})
+	} else {
+		txData, err := hex.DecodeString(strings.TrimPrefix(req.TransactionData, "0x"))
+		if err != nil {
+			return nil, ffcapi.ErrorReasonInvalidInputs, i18n.NewError(ctx, msgs.MsgInvalidTXData, req.TransactionData, err)
+		}
+
+		tx, err := c.buildTx(ctx, txTypePrePrepared, req.From, req.To, req.Nonce, req.Gas, req.Value, txData)
+		if err != nil {
+			return nil, ffcapi.ErrorReasonInvalidInputs, err
+		}
+		if reason, err := c.policy().checkTx(ctx, tx.To, tx.Value, tx.Data); err != nil {
+			return nil, reason, err
+		}
+		if reason, err := c.checkTxLimits(ctx, len(txData), tx.GasLimit); err != nil {
+			return nil, reason, err
+		}
+
+		err = c.mapGasPrice(ctx, req.GasPrice, tx)
+		if err != nil {
+			return nil, ffcapi.ErrorReasonInvalidInputs, err
+		}
+		from, _ := ethtypes.NewAddress(req.From) // validated by buildTx
+		var reason ffcapi.ErrorReason
+		if release, reason, err = c.reserveValue(ctx, from, tx.Value); err != nil {
+			return nil, reason, err
+		}
+		rpcError = c.backend.CallRPC(ctx, &txHash, "eth_sendTransactionAsync", &quorumPrivateTx{
+			Transaction: tx,
+			PrivateFrom: req.PrivateFrom,
+			PrivateFor:  req.PrivateFor,
+		})
+	}
+
+	if rpcError != nil {
+		// The node did not accept the transaction, so its value does not count against the window of the signer
+		release()
+	}
+	if rpcError == nil && len(txHash) != 32 {
+		rpcError = &rpcbackend.RPCError{Message: i18n.NewError(ctx, msgs.MsgInvalidTXHashReturned, len(txHash)).Error()}
+	}
+	if rpcError != nil {
+		return nil, mapRPCError(sendRPCMethods, rpcError), rpcError.Error()
+	}
+	return &ffcapi.TransactionSendResponse{
+		TransactionHash: txHash.String(),
+	}, "", nil
+}
+
+// PrivateTransactionReceipt gets the receipt of a GoQuorum private transaction from eth_getPrivateTransactionReceipt,
+// which returns the private receipt wrapped by a privacy marker transaction. Only a node that is a party
+// to the transaction has the private receipt, so the primary endpoint is always used.
+func (c *ethConnector) PrivateTransactionReceipt(ctx context.Context, req *ffcapi.TransactionReceiptRequest) (*ffcapi.TransactionReceiptResponse, ffcapi.ErrorReason, error) {
+	return c.transactionReceipt(ctx, req, true)
+}
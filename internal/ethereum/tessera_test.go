@@ -0,0 +1,333 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rlp"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/hyperledger/firefly-signer/pkg/secp256k1"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testTesseraKey1 = "BULeR8JyUWhiuuCMU/HLA0Q5pzkYT+cHII3ZKBey3Bo="
+const testTesseraKey2 = "QfeDAys9MPDs2XHExtc84jKGHxZg/aj52DTh0vtA3Xc="
+
+func testSignedLegacyTx(v int64) ethtypes.HexBytes0xPrefix {
+	return rlp.List{
+		rlp.WrapInt(big.NewInt(1)),
+		rlp.WrapInt(big.NewInt(0)),
+		rlp.WrapInt(big.NewInt(100000)),
+		rlp.MustWrapHex("0xe1a078b9e2b145d0a7387f09277c6ae1d9470771"),
+		rlp.WrapInt(big.NewInt(0)),
+		rlp.MustWrapHex("0x7d48ae971faf089878b57e3c28e3035540d34f38af395958d2c73c36c57c83a27d48ae971faf089878b57e3c28e3035540d34f38af395958d2c73c36c57c83a2"),
+		rlp.WrapInt(big.NewInt(v)),
+		rlp.MustWrapHex("0x1111111111111111111111111111111111111111111111111111111111111111"),
+		rlp.MustWrapHex("0x2222222222222222222222222222222222222222222222222222222222222222"),
+	}.Encode()
+}
+
+// testSignPrivateTx signs the fields of testSignedLegacyTx with a homestead signature
+func testSignPrivateTx(t *testing.T) ethtypes.HexBytes0xPrefix {
+	kp, err := secp256k1.GenerateSecp256k1KeyPair()
+	assert.NoError(t, err)
+	decoded, _, err := rlp.Decode(testSignedLegacyTx(27))
+	assert.NoError(t, err)
+	unsigned := decoded.(rlp.List)[0:6]
+	sig, err := kp.Sign(unsigned.Encode())
+	assert.NoError(t, err)
+	return append(unsigned, rlp.WrapInt(sig.V), rlp.WrapInt(sig.R), rlp.WrapInt(sig.S)).Encode()
+}
+
+func testPrivateSendRequest(t *testing.T, sample string) *PrivateSendRequest {
+	req := &PrivateSendRequest{
+		PrivateFrom: testTesseraKey1,
+		PrivateFor:  []string{testTesseraKey2},
+	}
+	err := json.Unmarshal([]byte(sample), &req.TransactionSendRequest)
+	assert.NoError(t, err)
+	return req
+}
+
+func TestValidateTesseraKeys(t *testing.T) {
+	ctx := context.Background()
+	assert.NoError(t, validateTesseraKeys(ctx, testTesseraKey1, []string{testTesseraKey2}))
+	assert.NoError(t, validateTesseraKeys(ctx, "", []string{testTesseraKey2}))
+	assert.Regexp(t, "FF23083", validateTesseraKeys(ctx, testTesseraKey1, nil))
+	assert.Regexp(t, "FF23084", validateTesseraKeys(ctx, "AAAA", []string{testTesseraKey2}))
+	assert.Regexp(t, "FF23084", validateTesseraKeys(ctx, "", []string{testTesseraKey2, "!!"}))
+}
+
+func TestMarkPrivateRawTx(t *testing.T) {
+	ctx := context.Background()
+
+	signed, marked, err := markPrivateRawTx(ctx, testSignedLegacyTx(27).String())
+	assert.NoError(t, err)
+	assert.Equal(t, testSignedLegacyTx(27), signed)
+	assert.Equal(t, testSignedLegacyTx(37), marked)
+
+	signed, marked, err = markPrivateRawTx(ctx, testSignedLegacyTx(28).String())
+	assert.NoError(t, err)
+	assert.Equal(t, testSignedLegacyTx(28), signed)
+	assert.Equal(t, testSignedLegacyTx(38), marked)
+
+	signed, marked, err = markPrivateRawTx(ctx, testSignedLegacyTx(38).String())
+	assert.NoError(t, err)
+	assert.Equal(t, testSignedLegacyTx(28), signed)
+	assert.Equal(t, testSignedLegacyTx(38), marked)
+
+	_, _, err = markPrivateRawTx(ctx, testSignedLegacyTx(2710).String())
+	assert.Regexp(t, "FF23086", err)
+
+	_, _, err = markPrivateRawTx(ctx, "0x02"+testSignedLegacyTx(27).String()[2:])
+	assert.Regexp(t, "FF23085", err)
+
+	_, _, err = markPrivateRawTx(ctx, "0xc0")
+	assert.Regexp(t, "FF23085", err)
+
+	_, _, err = markPrivateRawTx(ctx, "wrong")
+	assert.Regexp(t, "FF23018", err)
+}
+
+func TestPrivateTransactionSendOK(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_sendTransactionAsync",
+		mock.MatchedBy(func(tx *quorumPrivateTx) bool {
+			b, _ := json.Marshal(tx)
+			var m map[string]interface{}
+			_ = json.Unmarshal(b, &m)
+			return m["privateFrom"] == testTesseraKey1 &&
+				m["privateFor"].([]interface{})[0] == testTesseraKey2 &&
+				m["data"] == "0x60fe47b100000000000000000000000000000000000000000000000000000000feedbeef" &&
+				m["gasPrice"] == "0x0"
+		})).
+		Run(func(args mock.Arguments) {
+			*(args[1].(*ethtypes.HexBytes0xPrefix)) = ethtypes.MustNewHexBytes0xPrefix("0x7d48ae971faf089878b57e3c28e3035540d34f38af395958d2c73c36c57c83a2")
+		}).
+		Return(nil)
+
+	res, reason, err := c.PrivateTransactionSend(ctx, testPrivateSendRequest(t, sampleSendTX))
+	assert.NoError(t, err)
+	assert.Empty(t, reason)
+	assert.Equal(t, "0x7d48ae971faf089878b57e3c28e3035540d34f38af395958d2c73c36c57c83a2", res.TransactionHash)
+}
+
+func TestPrivateTransactionSendPreSignedOK(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t, func(conf config.Section) {
+		conf.Set(PolicyPreSignedAllowUnprotected, true)
+	})
+	defer done()
+
+	kp, err := secp256k1.GenerateSecp256k1KeyPair()
+	assert.NoError(t, err)
+	rawTx := ethtypes.HexBytes0xPrefix(testHomesteadTx(t, kp))
+	_, marked, err := markPrivateRawTx(ctx, rawTx.String())
+	assert.NoError(t, err)
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_sendRawPrivateTransaction", marked,
+		&quorumPrivateRawTxArgs{PrivateFor: []string{testTesseraKey2}}).
+		Run(func(args mock.Arguments) {
+			*(args[1].(*ethtypes.HexBytes0xPrefix)) = ethtypes.MustNewHexBytes0xPrefix("0x7d48ae971faf089878b57e3c28e3035540d34f38af395958d2c73c36c57c83a2")
+		}).
+		Return(nil)
+
+	req := testPrivateSendRequest(t, sampleSendRawTX)
+	req.TransactionData = rawTx.String()
+	res, reason, err := c.PrivateTransactionSend(ctx, req)
+	assert.NoError(t, err)
+	assert.Empty(t, reason)
+	assert.Equal(t, "0x7d48ae971faf089878b57e3c28e3035540d34f38af395958d2c73c36c57c83a2", res.TransactionHash)
+}
+
+func TestPrivateTransactionSendPreSignedUnprotected(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t)
+	defer done()
+
+	kp, err := secp256k1.GenerateSecp256k1KeyPair()
+	assert.NoError(t, err)
+	req := testPrivateSendRequest(t, sampleSendRawTX)
+	req.TransactionData = ethtypes.HexBytes0xPrefix(testHomesteadTx(t, kp)).String()
+
+	// Private transactions are signed without a chain ID
+	_, reason, err := c.PrivateTransactionSend(ctx, req)
+	assert.Regexp(t, "FF23112", err)
+	assert.Equal(t, ffcapi.ErrorReasonInvalidInputs, reason)
+}
+
+func TestPrivateTransactionSendPreSignedSignerNotAllowed(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t, func(conf config.Section) {
+		conf.Set(PolicyPreSignedAllowUnprotected, true)
+		conf.Set(PolicyPreSignedAllowedSigners, []string{sampleSignedTXFrom})
+	})
+	defer done()
+
+	kp, err := secp256k1.GenerateSecp256k1KeyPair()
+	assert.NoError(t, err)
+	req := testPrivateSendRequest(t, sampleSendRawTX)
+	req.TransactionData = ethtypes.HexBytes0xPrefix(testHomesteadTx(t, kp)).String()
+
+	// The signer is recovered from the transaction as it was signed, before it is marked as private
+	_, reason, err := c.PrivateTransactionSend(ctx, req)
+	assert.Regexp(t, "FF23111.*"+kp.Address.String(), err)
+	assert.Equal(t, ffcapi.ErrorReasonInvalidInputs, reason)
+}
+
+func TestPrivateTransactionSendBadInputs(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t)
+	defer done()
+
+	req := testPrivateSendRequest(t, sampleSendTX)
+	req.PrivateFor = nil
+	_, reason, err := c.PrivateTransactionSend(ctx, req)
+	assert.Regexp(t, "FF23083", err)
+	assert.Equal(t, ffcapi.ErrorReasonInvalidInputs, reason)
+
+	_, reason, err = c.PrivateTransactionSend(ctx, testPrivateSendRequest(t, sampleSendRawTX))
+	assert.Regexp(t, "FF23085", err)
+	assert.Equal(t, ffcapi.ErrorReasonInvalidInputs, reason)
+
+	_, reason, err = c.PrivateTransactionSend(ctx, testPrivateSendRequest(t, sampleSendTXBadData))
+	assert.Regexp(t, "FF23018", err)
+	assert.Equal(t, ffcapi.ErrorReasonInvalidInputs, reason)
+
+	_, reason, err = c.PrivateTransactionSend(ctx, testPrivateSendRequest(t, sampleSendTXBadFrom))
+	assert.Regexp(t, "FF23019", err)
+	assert.Equal(t, ffcapi.ErrorReasonInvalidInputs, reason)
+
+	_, reason, err = c.PrivateTransactionSend(ctx, testPrivateSendRequest(t, sampleSendTXBadGasPrice))
+	assert.Regexp(t, "FF23015", err)
+	assert.Equal(t, ffcapi.ErrorReasonInvalidInputs, reason)
+}
+
+func TestPrivateTransactionSendTooLarge(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t, func(conf config.Section) {
+		conf.Set(PolicyPreSignedAllowUnprotected, true)
+		conf.Set(TxLimitsMaxSize, 32)
+	})
+	defer done()
+
+	_, reason, err := c.PrivateTransactionSend(ctx, testPrivateSendRequest(t, sampleSendTX))
+	assert.Regexp(t, "FF23187.*36 bytes.*32 bytes", err)
+	assert.Equal(t, ffcapi.ErrorReasonInvalidInputs, reason)
+
+	// The whole of a pre-signed transaction is checked, as it is submitted with the private marker
+	req := testPrivateSendRequest(t, sampleSendRawTX)
+	req.TransactionData = testSignPrivateTx(t).String()
+	_, reason, err = c.PrivateTransactionSend(ctx, req)
+	assert.Regexp(t, "FF23187", err)
+	assert.Equal(t, ffcapi.ErrorReasonInvalidInputs, reason)
+}
+
+func TestPrivateTransactionSendExceedsBlockGasLimit(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t, enableBlockGasLimitCheck, func(conf config.Section) {
+		conf.Set(PolicyPreSignedAllowUnprotected, true)
+	})
+	defer done()
+	mockLatestBlockGasLimit(mRPC, 50000)
+
+	_, reason, err := c.PrivateTransactionSend(ctx, testPrivateSendRequest(t, sampleSendTX))
+	assert.Regexp(t, "FF23188.*1000000.*50000", err)
+	assert.Equal(t, ffcapi.ErrorReasonInvalidInputs, reason)
+
+	// Pre-signed transactions are decoded to check their gas
+	req := testPrivateSendRequest(t, sampleSendRawTX)
+	req.TransactionData = testSignPrivateTx(t).String()
+	_, reason, err = c.PrivateTransactionSend(ctx, req)
+	assert.Regexp(t, "FF23188.*100000.*50000", err)
+	assert.Equal(t, ffcapi.ErrorReasonInvalidInputs, reason)
+}
+
+func TestPrivateTransactionSendFail(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_sendTransactionAsync", mock.Anything).
+		Return(&rpcbackend.RPCError{Message: "nonce too low"})
+
+	_, reason, err := c.PrivateTransactionSend(ctx, testPrivateSendRequest(t, sampleSendTX))
+	assert.Regexp(t, "nonce too low", err)
+	assert.Equal(t, ffcapi.ErrorReasonNonceTooLow, reason)
+}
+
+func TestPrivateTransactionSendBadHash(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_sendTransactionAsync", mock.Anything).
+		Run(func(args mock.Arguments) {
+			*(args[1].(*ethtypes.HexBytes0xPrefix)) = ethtypes.MustNewHexBytes0xPrefix("0x1234")
+		}).
+		Return(nil)
+
+	_, _, err := c.PrivateTransactionSend(ctx, testPrivateSendRequest(t, sampleSendTX))
+	assert.Regexp(t, "FF23048", err)
+}
+
+func TestPrivateTransactionReceiptOK(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getPrivateTransactionReceipt",
+		"0x7d48ae971faf089878b57e3c28e3035540d34f38af395958d2c73c36c57c83a2").
+		Run(func(args mock.Arguments) {
+			err := json.Unmarshal([]byte(sampleJSONRPCReceipt), args[1])
+			assert.NoError(t, err)
+		}).
+		Return(nil)
+
+	var req ffcapi.TransactionReceiptRequest
+	err := json.Unmarshal([]byte(sampleGetReceipt), &req)
+	assert.NoError(t, err)
+	res, reason, err := c.PrivateTransactionReceipt(ctx, &req)
+	assert.NoError(t, err)
+	assert.Empty(t, reason)
+	assert.True(t, res.Success)
+	assert.Equal(t, int64(1977), res.BlockNumber.Int64())
+}
+
+func TestPrivateTransactionRoutes(t *testing.T) {
+	_, c, mRPC, done := newTestConnector(t)
+	defer done()
+	url, close := newTestRouteServer(t, c)
+	defer close()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_sendTransactionAsync", mock.Anything).
+		Return(&rpcbackend.RPCError{Message: "pop"})
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getPrivateTransactionReceipt", mock.Anything).
+		Return(nil)
+
+	b, _ := json.Marshal(testPrivateSendRequest(t, sampleSendTX))
+	res, err := http.Post(url+"/privacy/tessera/send", "application/json", bytes.NewReader(b))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+
+	res, err = http.Post(url+"/privacy/tessera/receipt", "application/json", bytes.NewReader([]byte(sampleGetReceipt)))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+}
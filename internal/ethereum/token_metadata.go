@@ -0,0 +1,139 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"math/big"
+	"unicode/utf8"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+)
+
+const (
+	tokenNameSelector     = "06fdde03" // name()
+	tokenSymbolSelector   = "95d89b41" // symbol()
+	tokenDecimalsSelector = "313ce567" // decimals()
+	tokenURISelector      = "c87b56dd" // tokenURI(uint256) - ERC-721
+	uriSelector           = "0e89341c" // uri(uint256) - ERC-1155
+)
+
+var abiStringOutput = abi.ParameterArray{{Type: "string"}}
+
+type TokenMetadataRequest struct {
+	Address string `json:"address"`
+	TokenID string `json:"tokenId,omitempty"`
+}
+
+// TokenMetadataResponse contains each of the optional metadata functions that the token implements
+type TokenMetadataResponse struct {
+	Address  string  `json:"address"`
+	Name     *string `json:"name,omitempty"`
+	Symbol   *string `json:"symbol,omitempty"`
+	Decimals *int64  `json:"decimals,omitempty"`
+	TokenURI *string `json:"tokenURI,omitempty"`
+	URI      *string `json:"uri,omitempty"`
+}
+
+// TokenMetadata queries the standard ERC-20/721/1155 metadata functions of a token contract.
+// Functions that are not implemented (or return data that cannot be decoded) are omitted.
+// The token URI functions are only queried when a token ID is supplied.
+func (c *ethConnector) TokenMetadata(ctx context.Context, req *TokenMetadataRequest) (*TokenMetadataResponse, error) {
+	address, err := ethtypes.NewAddress(req.Address)
+	if err != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidContractAddress, req.Address, err)
+	}
+	var tokenID *big.Int
+	if req.TokenID != "" {
+		var ok bool
+		if tokenID, ok = new(big.Int).SetString(req.TokenID, 0); !ok || tokenID.Sign() < 0 || tokenID.BitLen() > 256 {
+			return nil, i18n.NewError(ctx, msgs.MsgInvalidTokenID, req.TokenID)
+		}
+	}
+
+	res := &TokenMetadataResponse{Address: address.String()}
+	if res.Name, err = c.queryTokenString(ctx, address, tokenNameSelector, nil); err != nil {
+		return nil, err
+	}
+	if res.Symbol, err = c.queryTokenString(ctx, address, tokenSymbolSelector, nil); err != nil {
+		return nil, err
+	}
+	if res.Decimals, err = c.queryTokenDecimals(ctx, address); err != nil {
+		return nil, err
+	}
+	if tokenID != nil {
+		if res.TokenURI, err = c.queryTokenString(ctx, address, tokenURISelector, tokenID); err != nil {
+			return nil, err
+		}
+		if res.URI, err = c.queryTokenString(ctx, address, uriSelector, tokenID); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func tokenCallData(selector string, tokenID *big.Int) []byte {
+	callData, _ := hex.DecodeString(selector)
+	if tokenID != nil {
+		callData = append(callData, tokenID.FillBytes(make([]byte, 32))...)
+	}
+	return callData
+}
+
+func (c *ethConnector) queryTokenString(ctx context.Context, address *ethtypes.Address0xHex, selector string, tokenID *big.Int) (*string, error) {
+	outputData, err := c.callContractIgnoringReverts(ctx, address, tokenCallData(selector, tokenID), nil, "")
+	if err != nil || len(outputData) == 0 {
+		return nil, err
+	}
+	return decodeTokenString(ctx, outputData), nil
+}
+
+// decodeTokenString handles the standard ABI encoded string, as well as the bytes32 return
+// used by some early tokens (such as MKR) that pre-date the standards
+func decodeTokenString(ctx context.Context, outputData []byte) *string {
+	if cv, err := abiStringOutput.DecodeABIDataCtx(ctx, outputData, 0); err == nil && len(cv.Children) == 1 {
+		if s, ok := cv.Children[0].Value.(string); ok {
+			return &s
+		}
+	}
+	if len(outputData) == 32 {
+		trimmed := bytes.TrimRight(outputData, "\x00")
+		if utf8.Valid(trimmed) {
+			s := string(trimmed)
+			return &s
+		}
+	}
+	return nil
+}
+
+func (c *ethConnector) queryTokenDecimals(ctx context.Context, address *ethtypes.Address0xHex) (*int64, error) {
+	outputData, err := c.callContractIgnoringReverts(ctx, address, tokenCallData(tokenDecimalsSelector, nil), nil, "")
+	if err != nil || len(outputData) != 32 {
+		return nil, err
+	}
+	decimals := new(big.Int).SetBytes(outputData)
+	if !decimals.IsInt64() || decimals.Int64() > 255 {
+		return nil, nil
+	}
+	d := decimals.Int64()
+	return &d, nil
+}
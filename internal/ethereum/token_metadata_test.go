@@ -0,0 +1,170 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hyperledger/firefly-evmconnect/mocks/rpcbackendmocks"
+	"github.com/hyperledger/firefly-signer/pkg/ethsigner"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	// ABI encoded string "Test A"
+	abiStringTokenA = "0x000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000000065465737420410000000000000000000000000000000000000000000000000000"
+	// bytes32 "MKR"
+	bytes32MKR = "0x4d4b520000000000000000000000000000000000000000000000000000000000"
+	// ABI encoded string "ipfs://x/{id}"
+	abiStringURI = "0x0000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000000d697066733a2f2f782f7b69647d00000000000000000000000000000000000000"
+)
+
+func mockTokenCall(mRPC *rpcbackendmocks.Backend, callData string, result string, rpcErr *rpcbackend.RPCError) *mock.Call {
+	return mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_call",
+		mock.MatchedBy(func(tx *ethsigner.Transaction) bool {
+			return tx.Data.String() == callData
+		}), "latest").
+		Run(func(args mock.Arguments) {
+			if result != "" {
+				*(args[1].(*ethtypes.HexBytes0xPrefix)) = ethtypes.MustNewHexBytes0xPrefix(result)
+			}
+		}).
+		Return(rpcErr)
+}
+
+func TestTokenMetadataERC20(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mockTokenCall(mRPC, "0x06fdde03", abiStringTokenA, nil)
+	mockTokenCall(mRPC, "0x95d89b41", bytes32MKR, nil)
+	mockTokenCall(mRPC, "0x313ce567", "0x0000000000000000000000000000000000000000000000000000000000000012", nil)
+
+	res, err := c.TokenMetadata(ctx, &TokenMetadataRequest{Address: sampleContractAddress})
+	assert.NoError(t, err)
+	b, _ := json.Marshal(res)
+	assert.JSONEq(t, `{
+		"address": "0x4a8c8f1717570f9774652075e249ded38124d708",
+		"name": "Test A",
+		"symbol": "MKR",
+		"decimals": 18
+	}`, string(b))
+}
+
+func TestTokenMetadataNFT(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mockTokenCall(mRPC, "0x06fdde03", "", &rpcbackend.RPCError{Message: "execution reverted"})
+	mockTokenCall(mRPC, "0x95d89b41", "0x", nil)
+	mockTokenCall(mRPC, "0x313ce567", "0x01", nil)
+	mockTokenCall(mRPC, "0xc87b56dd000000000000000000000000000000000000000000000000000000000000000a", abiStringURI, nil)
+	mockTokenCall(mRPC, "0x0e89341c000000000000000000000000000000000000000000000000000000000000000a", "", &rpcbackend.RPCError{Message: "reverted", Data: `"0x"`})
+
+	res, err := c.TokenMetadata(ctx, &TokenMetadataRequest{Address: sampleContractAddress, TokenID: "0x0a"})
+	assert.NoError(t, err)
+	b, _ := json.Marshal(res)
+	assert.JSONEq(t, `{
+		"address": "0x4a8c8f1717570f9774652075e249ded38124d708",
+		"tokenURI": "ipfs://x/{id}"
+	}`, string(b))
+}
+
+func TestTokenMetadataUndecodable(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mockTokenCall(mRPC, "0x06fdde03", "0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", nil)
+	mockTokenCall(mRPC, "0x95d89b41", "0x1234", nil)
+	mockTokenCall(mRPC, "0x313ce567", "0x0000000000000000000000000000000000000000000000000000000000000100", nil)
+
+	res, err := c.TokenMetadata(ctx, &TokenMetadataRequest{Address: sampleContractAddress})
+	assert.NoError(t, err)
+	assert.Nil(t, res.Name)
+	assert.Nil(t, res.Symbol)
+	assert.Nil(t, res.Decimals)
+}
+
+func TestTokenMetadataCallFailures(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	pop := &rpcbackend.RPCError{Message: "pop"}
+	mockTokenCall(mRPC, "0x06fdde03", "", pop).Once()
+	_, err := c.TokenMetadata(ctx, &TokenMetadataRequest{Address: sampleContractAddress})
+	assert.Regexp(t, "pop", err)
+
+	mockTokenCall(mRPC, "0x06fdde03", abiStringTokenA, nil)
+	mockTokenCall(mRPC, "0x95d89b41", "", pop).Once()
+	_, err = c.TokenMetadata(ctx, &TokenMetadataRequest{Address: sampleContractAddress})
+	assert.Regexp(t, "pop", err)
+
+	mockTokenCall(mRPC, "0x95d89b41", abiStringTokenA, nil)
+	mockTokenCall(mRPC, "0x313ce567", "", pop).Once()
+	_, err = c.TokenMetadata(ctx, &TokenMetadataRequest{Address: sampleContractAddress})
+	assert.Regexp(t, "pop", err)
+
+	mockTokenCall(mRPC, "0x313ce567", "0x", nil)
+	mockTokenCall(mRPC, "0xc87b56dd0000000000000000000000000000000000000000000000000000000000000001", "", pop).Once()
+	_, err = c.TokenMetadata(ctx, &TokenMetadataRequest{Address: sampleContractAddress, TokenID: "1"})
+	assert.Regexp(t, "pop", err)
+
+	mockTokenCall(mRPC, "0xc87b56dd0000000000000000000000000000000000000000000000000000000000000001", "0x", nil)
+	mockTokenCall(mRPC, "0x0e89341c0000000000000000000000000000000000000000000000000000000000000001", "", pop).Once()
+	_, err = c.TokenMetadata(ctx, &TokenMetadataRequest{Address: sampleContractAddress, TokenID: "1"})
+	assert.Regexp(t, "pop", err)
+}
+
+func TestTokenMetadataBadInputs(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t)
+	defer done()
+
+	_, err := c.TokenMetadata(ctx, &TokenMetadataRequest{Address: "wrong"})
+	assert.Regexp(t, "FF23060", err)
+
+	_, err = c.TokenMetadata(ctx, &TokenMetadataRequest{Address: sampleContractAddress, TokenID: "-1"})
+	assert.Regexp(t, "FF23062", err)
+
+	_, err = c.TokenMetadata(ctx, &TokenMetadataRequest{Address: sampleContractAddress, TokenID: "abc"})
+	assert.Regexp(t, "FF23062", err)
+}
+
+func TestGetTokenMetadataRoute(t *testing.T) {
+	_, c, mRPC, done := newTestConnector(t)
+	defer done()
+	url, close := newTestRouteServer(t, c)
+	defer close()
+
+	mockTokenCall(mRPC, "0x06fdde03", abiStringTokenA, nil)
+	mockTokenCall(mRPC, "0x95d89b41", bytes32MKR, nil)
+	mockTokenCall(mRPC, "0x313ce567", "0x", nil)
+	mockTokenCall(mRPC, "0xc87b56dd0000000000000000000000000000000000000000000000000000000000000001", abiStringURI, nil)
+	mockTokenCall(mRPC, "0x0e89341c0000000000000000000000000000000000000000000000000000000000000001", abiStringURI, nil)
+
+	res, err := http.Get(url + "/tokens/" + sampleContractAddress + "/metadata?tokenId=1")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var metadata TokenMetadataResponse
+	err = json.NewDecoder(res.Body).Decode(&metadata)
+	assert.NoError(t, err)
+	assert.Equal(t, "ipfs://x/{id}", *metadata.URI)
+}
@@ -0,0 +1,132 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-signer/pkg/ethsigner"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rlp"
+)
+
+const (
+	txType4844 byte = 0x03
+	// rlpListPrefix is the lowest first byte of an RLP list, which distinguishes legacy transactions from typed envelopes
+	rlpListPrefix byte = 0xc0
+)
+
+// signedTxEncoding is the layout of the RLP list of a signed transaction type
+type signedTxEncoding struct {
+	txType     byte
+	name       string
+	fields     []string
+	listFields map[int]bool
+}
+
+var legacyTxEncoding = &signedTxEncoding{
+	txType: ethsigner.TransactionTypeLegacy,
+	name:   "legacy",
+	fields: []string{"nonce", "gasPrice", "gasLimit", "to", "value", "data", "v", "r", "s"},
+}
+
+// typedTxEncodings are the EIP-2718 transaction types, which are the type byte followed by the RLP list
+var typedTxEncodings = map[byte]*signedTxEncoding{
+	ethsigner.TransactionType2930: {
+		txType:     ethsigner.TransactionType2930,
+		name:       "EIP-2930",
+		fields:     []string{"chainId", "nonce", "gasPrice", "gasLimit", "to", "value", "data", "accessList", "yParity", "r", "s"},
+		listFields: map[int]bool{7: true},
+	},
+	ethsigner.TransactionType1559: {
+		txType:     ethsigner.TransactionType1559,
+		name:       "EIP-1559",
+		fields:     []string{"chainId", "nonce", "maxPriorityFeePerGas", "maxFeePerGas", "gasLimit", "to", "value", "data", "accessList", "yParity", "r", "s"},
+		listFields: map[int]bool{8: true},
+	},
+	txType4844: {
+		txType:     txType4844,
+		name:       "EIP-4844",
+		fields:     []string{"chainId", "nonce", "maxPriorityFeePerGas", "maxFeePerGas", "gasLimit", "to", "value", "data", "accessList", "maxFeePerBlobGas", "blobVersionedHashes", "yParity", "r", "s"},
+		listFields: map[int]bool{8: true, 10: true},
+	},
+	opDepositTxType: depositTxEncoding,
+}
+
+// blobTxNetworkFields is the number of fields in the network form of a blob transaction, as submitted
+// with eth_sendRawTransaction, which wraps the transaction with its blobs, commitments and proofs
+const blobTxNetworkFields = 4
+
+// signedTxHash calculates the hash of a signed transaction from its encoding, checking the RLP matches
+// the layout of the transaction type. The hash covers only the transaction fields, so excludes the blob
+// sidecar of a blob transaction in its network form. Returns a nil hash for transaction types that
+// are not known, so the check can be skipped.
+func signedTxHash(ctx context.Context, rawTx []byte) (ethtypes.HexBytes0xPrefix, *signedTxEncoding, error) {
+	_, hashed, enc, err := parseSignedTx(ctx, rawTx)
+	if err != nil || enc == nil {
+		return nil, enc, err
+	}
+	return keccak256(hashed), enc, nil
+}
+
+// parseSignedTx decodes the RLP fields of a signed transaction, checking they match the layout of the
+// transaction type, and returns them with the encoding that is hashed to give the transaction hash.
+// Returns a nil encoding for transaction types that are not known.
+func parseSignedTx(ctx context.Context, rawTx []byte) (rlp.List, []byte, *signedTxEncoding, error) {
+	enc, payload := legacyTxEncoding, rawTx
+	if len(rawTx) > 0 && rawTx[0] < rlpListPrefix {
+		if enc = typedTxEncodings[rawTx[0]]; enc == nil {
+			return nil, nil, nil, nil
+		}
+		payload = rawTx[1:]
+	}
+
+	decoded, endPos, err := rlp.Decode(payload)
+	if err != nil {
+		return nil, nil, enc, i18n.NewError(ctx, msgs.MsgSignedTXDecodeFailed, enc.name, err)
+	}
+	if endPos != len(payload) {
+		return nil, nil, enc, i18n.NewError(ctx, msgs.MsgSignedTXTrailingBytes, enc.name, len(payload)-endPos)
+	}
+	fields, ok := decoded.(rlp.List)
+	if !ok {
+		return nil, nil, enc, i18n.NewError(ctx, msgs.MsgSignedTXFieldCount, enc.name, len(enc.fields), 0)
+	}
+
+	hashed := rawTx
+	if enc.txType == txType4844 && len(fields) == blobTxNetworkFields {
+		if fields, ok = fields[0].(rlp.List); !ok {
+			return nil, nil, enc, i18n.NewError(ctx, msgs.MsgSignedTXFieldType, 0, "tx_payload_body", enc.name, "list")
+		}
+		hashed = append([]byte{txType4844}, fields.Encode()...)
+	}
+	if len(fields) != len(enc.fields) {
+		return nil, nil, enc, i18n.NewError(ctx, msgs.MsgSignedTXFieldCount, enc.name, len(enc.fields), len(fields))
+	}
+	for i, field := range fields {
+		if field.IsList() != enc.listFields[i] {
+			expected := "data"
+			if enc.listFields[i] {
+				expected = "list"
+			}
+			return nil, nil, enc, i18n.NewError(ctx, msgs.MsgSignedTXFieldType, i, enc.fields[i], enc.name, expected)
+		}
+	}
+	return fields, hashed, enc, nil
+}
@@ -0,0 +1,184 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rlp"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	sampleSignedLegacyTX   = "0xf8866f80830186a094497eedc4299dea2f2a364be10025d0ad0f702de380a460fe47b100000000000000000000000000000000000000000000000000000000feedbeef820a96a0bacf720c3245f7e62f29af0e8b4fdec2297c4ca6516d49db48581919038789c0a072b51959bef7a08f33be3d739693b2ddcf197c00cdcf5cbd7c62b1a4ef613713"
+	sampleSignedLegacyHash = "0x4aaa9fd6a3772c23696d58f61599d1d2757b07e2489c07917d2ec430439f9e4c"
+	sampleSigned1559TX     = "0x02f8928205396f843b9aca008504a817c800830186a094497eedc4299dea2f2a364be10025d0ad0f702de380a460fe47b100000000000000000000000000000000000000000000000000000000feedbeefc001a0b881349abfcc7e2e5dafebcbdeb8824d12b9aaa3597305bbf3a522634415c288a01088dd7bd677f6539331ed971e254d00dc5aefe778f8562662e49aa5be49b955"
+	sampleSigned1559Hash   = "0xdc246933185b64d89e93d47bb6fa0500b9da8f168d5ccf39aebc7f6c36692fe2"
+)
+
+// testTypedTx builds a typed transaction from its fields, with placeholder values for the signature
+func testTypedTx(txType byte, fields rlp.List) []byte {
+	return append([]byte{txType}, fields.Encode()...)
+}
+
+func test2930Fields() rlp.List {
+	return rlp.List{
+		rlp.WrapInt(big.NewInt(1337)), rlp.WrapInt(big.NewInt(1)), rlp.WrapInt(big.NewInt(1000)), rlp.WrapInt(big.NewInt(21000)),
+		rlp.MustWrapHex("0x497eedc4299dea2f2a364be10025d0ad0f702de3"), rlp.WrapInt(big.NewInt(0)), rlp.Data{},
+		rlp.List{}, rlp.WrapInt(big.NewInt(1)), rlp.WrapInt(big.NewInt(12345)), rlp.WrapInt(big.NewInt(67890)),
+	}
+}
+
+func test4844Fields() rlp.List {
+	return rlp.List{
+		rlp.WrapInt(big.NewInt(1337)), rlp.WrapInt(big.NewInt(1)), rlp.WrapInt(big.NewInt(1000)), rlp.WrapInt(big.NewInt(2000)), rlp.WrapInt(big.NewInt(21000)),
+		rlp.MustWrapHex("0x497eedc4299dea2f2a364be10025d0ad0f702de3"), rlp.WrapInt(big.NewInt(0)), rlp.Data{},
+		rlp.List{}, rlp.WrapInt(big.NewInt(3000)),
+		rlp.List{rlp.MustWrapHex("0x01a915e4d060149eb4365960e6a7a45f334393093061116b197e3240065ff2d8")},
+		rlp.WrapInt(big.NewInt(1)), rlp.WrapInt(big.NewInt(12345)), rlp.WrapInt(big.NewInt(67890)),
+	}
+}
+
+func TestSignedTxHashLegacy(t *testing.T) {
+	hash, enc, err := signedTxHash(context.Background(), ethtypes.MustNewHexBytes0xPrefix(sampleSignedLegacyTX))
+	assert.NoError(t, err)
+	assert.Equal(t, "legacy", enc.name)
+	assert.Equal(t, sampleSignedLegacyHash, hash.String())
+}
+
+func TestSignedTxHash1559(t *testing.T) {
+	hash, enc, err := signedTxHash(context.Background(), ethtypes.MustNewHexBytes0xPrefix(sampleSigned1559TX))
+	assert.NoError(t, err)
+	assert.Equal(t, "EIP-1559", enc.name)
+	assert.Equal(t, sampleSigned1559Hash, hash.String())
+}
+
+func TestSignedTxHash2930(t *testing.T) {
+	rawTx := testTypedTx(0x01, test2930Fields())
+	hash, enc, err := signedTxHash(context.Background(), rawTx)
+	assert.NoError(t, err)
+	assert.Equal(t, "EIP-2930", enc.name)
+	assert.Equal(t, ethtypes.HexBytes0xPrefix(keccak256(rawTx)), hash)
+}
+
+func TestSignedTxHash4844(t *testing.T) {
+	rawTx := testTypedTx(txType4844, test4844Fields())
+	hash, enc, err := signedTxHash(context.Background(), rawTx)
+	assert.NoError(t, err)
+	assert.Equal(t, "EIP-4844", enc.name)
+	assert.Equal(t, ethtypes.HexBytes0xPrefix(keccak256(rawTx)), hash)
+
+	// The network form wraps the transaction with the blob sidecar, which is not part of the hash
+	networkTx := testTypedTx(txType4844, rlp.List{
+		test4844Fields(),
+		rlp.List{rlp.Data(make([]byte, 1024))},
+		rlp.List{rlp.Data(make([]byte, 48))},
+		rlp.List{rlp.Data(make([]byte, 48))},
+	})
+	networkHash, _, err := signedTxHash(context.Background(), networkTx)
+	assert.NoError(t, err)
+	assert.Equal(t, hash, networkHash)
+
+	_, _, err = signedTxHash(context.Background(), testTypedTx(txType4844, rlp.List{rlp.Data{}, rlp.List{}, rlp.List{}, rlp.List{}}))
+	assert.Regexp(t, "FF23106.*Field 0 \\(tx_payload_body\\).*EIP-4844.*list", err)
+}
+
+func TestSignedTxHashUnknownType(t *testing.T) {
+	hash, enc, err := signedTxHash(context.Background(), testTypedTx(0x04, test2930Fields()))
+	assert.NoError(t, err)
+	assert.Nil(t, hash)
+	assert.Nil(t, enc)
+
+	// A zero type byte is not a valid envelope, so is not treated as legacy
+	hash, _, err = signedTxHash(context.Background(), testTypedTx(0x00, test2930Fields()))
+	assert.NoError(t, err)
+	assert.Nil(t, hash)
+}
+
+func TestSignedTxHashEncodingMismatch(t *testing.T) {
+	ctx := context.Background()
+	raw1559 := ethtypes.MustNewHexBytes0xPrefix(sampleSigned1559TX)
+
+	_, _, err := signedTxHash(ctx, raw1559[0:50])
+	assert.Regexp(t, "FF23103.*EIP-1559", err)
+
+	_, _, err = signedTxHash(ctx, append(raw1559, 0x01, 0x02))
+	assert.Regexp(t, "FF23104.*EIP-1559.*2 unexpected bytes", err)
+
+	_, _, err = signedTxHash(ctx, []byte{})
+	assert.Regexp(t, "FF23105.*legacy.*9 fields, but has 0", err)
+
+	_, _, err = signedTxHash(ctx, append([]byte{0x02}, rlp.WrapString("not a list").Encode()...))
+	assert.Regexp(t, "FF23105.*EIP-1559.*12 fields, but has 0", err)
+
+	_, _, err = signedTxHash(ctx, testTypedTx(0x02, test2930Fields()))
+	assert.Regexp(t, "FF23105.*EIP-1559.*12 fields, but has 11", err)
+
+	fields := test2930Fields()
+	fields[7] = rlp.Data{}
+	_, _, err = signedTxHash(ctx, testTypedTx(0x01, fields))
+	assert.Regexp(t, "FF23106.*Field 7 \\(accessList\\).*EIP-2930.*list", err)
+
+	fields = test2930Fields()
+	fields[1] = rlp.List{}
+	_, _, err = signedTxHash(ctx, testTypedTx(0x01, fields))
+	assert.Regexp(t, "FF23106.*Field 1 \\(nonce\\).*EIP-2930.*data", err)
+}
+
+func TestSendPreSignedTransactionHashMismatch(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_sendRawTransaction", sampleSignedLegacyTX).
+		Run(func(args mock.Arguments) {
+			*(args[1].(*ethtypes.HexBytes0xPrefix)) = ethtypes.MustNewHexBytes0xPrefix(sampleSigned1559Hash)
+		}).
+		Return(nil)
+
+	_, reason, err := c.TransactionSend(ctx, &ffcapi.TransactionSendRequest{
+		PreSigned:       true,
+		TransactionData: sampleSignedLegacyTX,
+	})
+	assert.Regexp(t, "FF23107.*"+sampleSigned1559Hash+".*legacy.*"+sampleSignedLegacyHash, err)
+	assert.Empty(t, reason)
+
+	mRPC.AssertExpectations(t)
+}
+
+func TestSendPreSignedTransactionBadEncoding(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t)
+	defer done()
+
+	var req ffcapi.TransactionSendRequest
+	err := json.Unmarshal([]byte(sampleSendRawTX), &req)
+	assert.NoError(t, err)
+	req.TransactionData += "00"
+	_, reason, err := c.TransactionSend(ctx, &req)
+	assert.Regexp(t, "FF23104", err)
+	assert.Equal(t, ffcapi.ErrorReasonInvalidInputs, reason)
+
+	req.TransactionData = "not hex"
+	_, reason, err = c.TransactionSend(ctx, &req)
+	assert.Regexp(t, "FF23018", err)
+	assert.Equal(t, ffcapi.ErrorReasonInvalidInputs, reason)
+}
@@ -0,0 +1,47 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+)
+
+// checkTxLimits checks the size and gas limit of a transaction before it is submitted, so a transaction the node
+// (or the provider in front of it) would reject fails with an error that says why. The size is that of the data of a
+// transaction the connector builds, or the whole of a pre-signed transaction. A nil gas limit is not checked.
+func (c *ethConnector) checkTxLimits(ctx context.Context, size int, gas *ethtypes.HexInteger) (ffcapi.ErrorReason, error) {
+	tp := c.policy()
+	if tp.maxTxSize > 0 && int64(size) > tp.maxTxSize {
+		return ffcapi.ErrorReasonInvalidInputs, i18n.NewError(ctx, msgs.MsgTransactionTooLarge, size, tp.maxTxSize)
+	}
+	if !tp.checkBlockGasLimit || gas == nil || gas.BigInt().Sign() == 0 {
+		return "", nil
+	}
+	latest, err := c.latestBlockInfo(ctx)
+	if err != nil {
+		return "", err
+	}
+	if latest.GasLimit != nil && gas.BigInt().Cmp(latest.GasLimit.BigInt()) > 0 {
+		return ffcapi.ErrorReasonInvalidInputs, i18n.NewError(ctx, msgs.MsgGasExceedsBlockGasLimit, gas.BigInt(), latest.GasLimit.BigInt(), latest.Number.BigInt())
+	}
+	return "", nil
+}
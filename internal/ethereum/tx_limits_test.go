@@ -0,0 +1,125 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-evmconnect/mocks/rpcbackendmocks"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func enableBlockGasLimitCheck(conf config.Section) {
+	conf.Set(TxLimitsCheckBlockGasLimit, true)
+}
+
+func mockLatestBlockGasLimit(mRPC *rpcbackendmocks.Backend, gasLimit int64) {
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", "latest", false).
+		Run(func(args mock.Arguments) {
+			*(args[1].(**blockInfoJSONRPC)) = testBaseFeeBlock(100, 7, 0, gasLimit)
+		}).
+		Return(nil)
+}
+
+func TestSendTransactionTooLarge(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t, func(conf config.Section) {
+		conf.Set(TxLimitsMaxSize, 32)
+	})
+	defer done()
+
+	var req ffcapi.TransactionSendRequest
+	err := json.Unmarshal([]byte(sampleSendTX), &req)
+	assert.NoError(t, err)
+	_, reason, err := c.TransactionSend(ctx, &req)
+	assert.Regexp(t, "FF23187.*36 bytes.*32 bytes", err)
+	assert.Equal(t, ffcapi.ErrorReasonInvalidInputs, reason)
+
+	// The whole of a pre-signed transaction is checked
+	err = json.Unmarshal([]byte(sampleSendRawTX), &req)
+	assert.NoError(t, err)
+	_, reason, err = c.TransactionSend(ctx, &req)
+	assert.Regexp(t, "FF23187.*149 bytes", err)
+	assert.Equal(t, ffcapi.ErrorReasonInvalidInputs, reason)
+}
+
+func TestSendTransactionExceedsBlockGasLimit(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t, enableBlockGasLimitCheck)
+	defer done()
+	mockLatestBlockGasLimit(mRPC, 500000)
+
+	var req ffcapi.TransactionSendRequest
+	err := json.Unmarshal([]byte(sampleSendTX), &req)
+	assert.NoError(t, err)
+	_, reason, err := c.TransactionSend(ctx, &req)
+	assert.Regexp(t, "FF23188.*1000000.*500000.*100", err)
+	assert.Equal(t, ffcapi.ErrorReasonInvalidInputs, reason)
+
+	// Pre-signed transactions are decoded to check their gas, which is within the limit
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_sendRawTransaction", mock.Anything).
+		Run(func(args mock.Arguments) {
+			*(args[1].(*ethtypes.HexBytes0xPrefix)) = ethtypes.MustNewHexBytes0xPrefix("0xdc246933185b64d89e93d47bb6fa0500b9da8f168d5ccf39aebc7f6c36692fe2")
+		}).
+		Return(nil)
+	err = json.Unmarshal([]byte(sampleSendRawTX), &req)
+	assert.NoError(t, err)
+	_, _, err = c.TransactionSend(ctx, &req)
+	assert.NoError(t, err)
+}
+
+func TestSendTransactionBlockGasLimitUnavailable(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t, enableBlockGasLimitCheck)
+	defer done()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", "latest", false).
+		Return(&rpcbackend.RPCError{Message: "pop"})
+
+	var req ffcapi.TransactionSendRequest
+	err := json.Unmarshal([]byte(sampleSendTX), &req)
+	assert.NoError(t, err)
+	_, reason, err := c.TransactionSend(ctx, &req)
+	assert.Regexp(t, "pop", err)
+	assert.Empty(t, reason)
+
+	// Transactions without a gas limit are not checked
+	req.Gas = nil
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_sendTransaction", mock.Anything).
+		Run(func(args mock.Arguments) {
+			*(args[1].(*ethtypes.HexBytes0xPrefix)) = ethtypes.MustNewHexBytes0xPrefix("0x7d48ae971faf089878b57e3c28e3035540d34f38af395958d2c73c36c57c83a2")
+		}).
+		Return(nil)
+	_, _, err = c.TransactionSend(ctx, &req)
+	assert.NoError(t, err)
+}
+
+func TestTxLimitsConfig(t *testing.T) {
+	config.RootConfigReset()
+	conf := config.RootSection("unittest")
+	InitConfig(conf)
+	tp, err := newTxPolicy(context.Background(), conf)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(131072), tp.maxTxSize)
+
+	conf.Set(TxLimitsMaxSize, -1)
+	_, err = newTxPolicy(context.Background(), conf)
+	assert.Regexp(t, "FF23189", err)
+}
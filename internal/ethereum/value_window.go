@@ -0,0 +1,83 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-evmconnect/internal/msgs"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+)
+
+type valueTransfer struct {
+	submitted time.Time
+	value     *big.Int
+}
+
+// reserveValue counts the value of a transaction against the rolling window of its signer, before it is
+// submitted. The returned function releases the value again, for when the node does not accept the
+// transaction. The history is held on the connector rather than the policy, so it is kept when the
+// policy is reloaded.
+func (c *ethConnector) reserveValue(ctx context.Context, from *ethtypes.Address0xHex, value *ethtypes.HexInteger) (release func(), reason ffcapi.ErrorReason, err error) {
+	tp := c.policy()
+	if tp.maxValuePerWindow == nil || from == nil || value.BigInt().Sign() == 0 {
+		return func() {}, "", nil
+	}
+
+	c.valueMux.Lock()
+	defer c.valueMux.Unlock()
+	now := time.Now()
+	for signer, transfers := range c.valueTransfers {
+		for len(transfers) > 0 && now.Sub(transfers[0].submitted) > tp.valueWindow {
+			transfers = transfers[1:]
+		}
+		if len(transfers) == 0 {
+			delete(c.valueTransfers, signer)
+		} else {
+			c.valueTransfers[signer] = transfers
+		}
+	}
+
+	signer := from.String()
+	total := new(big.Int)
+	for _, t := range c.valueTransfers[signer] {
+		total.Add(total, t.value)
+	}
+	if new(big.Int).Add(total, value.BigInt()).Cmp(tp.maxValuePerWindow) > 0 {
+		log.L(ctx).Errorf("Transaction value %s from %s rejected, with %s submitted in the last %s", value.BigInt(), signer, total, tp.valueWindow)
+		return nil, ErrorReasonPolicyViolation, i18n.NewError(ctx, msgs.MsgValueExceedsWindowLimit, value.BigInt(), signer, tp.maxValuePerWindow, tp.valueWindow, total)
+	}
+
+	reserved := &valueTransfer{submitted: now, value: value.BigInt()}
+	c.valueTransfers[signer] = append(c.valueTransfers[signer], reserved)
+	return func() {
+		c.valueMux.Lock()
+		defer c.valueMux.Unlock()
+		transfers := c.valueTransfers[signer]
+		for i, t := range transfers {
+			if t == reserved {
+				c.valueTransfers[signer] = append(transfers[0:i:i], transfers[i+1:]...)
+				break
+			}
+		}
+	}, "", nil
+}
@@ -0,0 +1,201 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rlp"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/hyperledger/firefly-signer/pkg/secp256k1"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReserveValueWindow(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t, func(conf config.Section) {
+		conf.Set(PolicyValueMaxPerWindow, "100")
+		conf.Set(PolicyValueWindow, "1h")
+	})
+	defer done()
+
+	signer1 := ethtypes.MustNewAddress("0xb480F96c0a3d6E9e9a263e4665a39bFa6c4d01E8")
+	signer2 := ethtypes.MustNewAddress(sampleSignedTXFrom)
+
+	release, _, err := c.reserveValue(ctx, signer1, ethtypes.NewHexInteger64(60))
+	assert.NoError(t, err)
+
+	_, reason, err := c.reserveValue(ctx, signer1, ethtypes.NewHexInteger64(50))
+	assert.Regexp(t, "FF23119.*50.*0xb480f96c0a3d6e9e9a263e4665a39bfa6c4d01e8.*100.*1h0m0s.*60", err)
+	assert.Equal(t, ErrorReasonPolicyViolation, reason)
+
+	// Each signer has its own window, and transactions without value are not counted
+	_, _, err = c.reserveValue(ctx, signer2, ethtypes.NewHexInteger64(50))
+	assert.NoError(t, err)
+	_, _, err = c.reserveValue(ctx, signer1, nil)
+	assert.NoError(t, err)
+
+	release()
+	_, _, err = c.reserveValue(ctx, signer1, ethtypes.NewHexInteger64(50))
+	assert.NoError(t, err)
+
+	// Transfers drop out of the window once it has passed
+	c.valueTransfers[signer1.String()][0].submitted = time.Now().Add(-2 * time.Hour)
+	c.valueTransfers[signer2.String()][0].submitted = time.Now().Add(-2 * time.Hour)
+	_, _, err = c.reserveValue(ctx, signer1, ethtypes.NewHexInteger64(100))
+	assert.NoError(t, err)
+	assert.Len(t, c.valueTransfers, 1)
+}
+
+func TestReserveValueWindowDisabled(t *testing.T) {
+	ctx, c, _, done := newTestConnector(t)
+	defer done()
+
+	release, _, err := c.reserveValue(ctx, ethtypes.MustNewAddress(sampleSignedTXFrom), ethtypes.NewHexInteger64(1000))
+	assert.NoError(t, err)
+	release()
+	assert.Empty(t, c.valueTransfers)
+}
+
+func TestSendTransactionValueWindow(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t, func(conf config.Section) {
+		conf.Set(PolicyValueMaxPerWindow, "100")
+	})
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_sendTransaction", mock.Anything).
+		Return(&rpcbackend.RPCError{Message: "pop"}).Once()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_sendTransaction", mock.Anything).
+		Run(func(args mock.Arguments) {
+			*(args[1].(*ethtypes.HexBytes0xPrefix)) = ethtypes.MustNewHexBytes0xPrefix("0x3e2398ff4a875a8b9f87a6eeaaa41a139a68adeb509731300d4b90d1bdc1c4fc")
+		}).
+		Return(nil).Once()
+
+	var req ffcapi.TransactionSendRequest
+	err := json.Unmarshal([]byte(sampleSendTX), &req)
+	assert.NoError(t, err)
+	req.Value = fftypes.NewFFBigInt(60)
+
+	// The value of a transaction the node rejected is not counted
+	_, _, err = c.TransactionSend(ctx, &req)
+	assert.Regexp(t, "pop", err)
+	_, _, err = c.TransactionSend(ctx, &req)
+	assert.NoError(t, err)
+
+	req.Nonce = fftypes.NewFFBigInt(112)
+	_, reason, err := c.TransactionSend(ctx, &req)
+	assert.Regexp(t, "FF23119", err)
+	assert.Equal(t, ErrorReasonPolicyViolation, reason)
+}
+
+func TestSendPreSignedTransactionValueWindow(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t, func(conf config.Section) {
+		conf.Set(PolicyPreSignedVerifyChainID, false)
+		conf.Set(PolicyValueMaxPerWindow, "100")
+	})
+	defer done()
+
+	kp, err := secp256k1.GenerateSecp256k1KeyPair()
+	assert.NoError(t, err)
+	signedTx := func(nonce, value int64) []byte {
+		fields := test2930Fields()
+		fields[1] = rlp.WrapInt(big.NewInt(nonce))
+		fields[5] = rlp.WrapInt(big.NewInt(value))
+		return testSignTypedTx(t, kp, 0x01, fields)
+	}
+
+	rawTx := signedTx(1, 60)
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_sendRawTransaction", mock.Anything).
+		Run(func(args mock.Arguments) {
+			*(args[1].(*ethtypes.HexBytes0xPrefix)) = keccak256(rawTx)
+		}).
+		Return(nil).Once()
+	_, _, err = c.TransactionSend(ctx, &ffcapi.TransactionSendRequest{
+		PreSigned:       true,
+		TransactionData: ethtypes.HexBytes0xPrefix(rawTx).String(),
+	})
+	assert.NoError(t, err)
+
+	_, _, err = c.TransactionSend(ctx, &ffcapi.TransactionSendRequest{
+		PreSigned:       true,
+		TransactionData: ethtypes.HexBytes0xPrefix(signedTx(2, 50)).String(),
+	})
+	assert.Regexp(t, "FF23119.*"+kp.Address.String(), err)
+}
+
+func TestPrivateTransactionSendValueWindow(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t, func(conf config.Section) {
+		conf.Set(PolicyPreSignedVerifyChainID, false)
+		conf.Set(PolicyValueMaxPerWindow, "100")
+	})
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_sendTransactionAsync", mock.Anything).
+		Return(&rpcbackend.RPCError{Message: "pop"}).Once()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_sendTransactionAsync", mock.Anything).
+		Run(func(args mock.Arguments) {
+			*(args[1].(*ethtypes.HexBytes0xPrefix)) = ethtypes.MustNewHexBytes0xPrefix("0x7d48ae971faf089878b57e3c28e3035540d34f38af395958d2c73c36c57c83a2")
+		}).
+		Return(nil).Once()
+
+	req := testPrivateSendRequest(t, sampleSendTX)
+	req.Value = fftypes.NewFFBigInt(60)
+
+	// The value of a transaction the node rejected is not counted
+	_, _, err := c.PrivateTransactionSend(ctx, req)
+	assert.Regexp(t, "pop", err)
+	_, _, err = c.PrivateTransactionSend(ctx, req)
+	assert.NoError(t, err)
+
+	req.Nonce = fftypes.NewFFBigInt(112)
+	_, reason, err := c.PrivateTransactionSend(ctx, req)
+	assert.Regexp(t, "FF23119", err)
+	assert.Equal(t, ErrorReasonPolicyViolation, reason)
+
+	// Pre-signed private transactions count against the window of the signer they are recovered from
+	kp, err := secp256k1.GenerateSecp256k1KeyPair()
+	assert.NoError(t, err)
+	signedTx := func(nonce, value int64) []byte {
+		unsigned := rlp.List{
+			rlp.WrapInt(big.NewInt(nonce)), rlp.WrapInt(big.NewInt(0)), rlp.WrapInt(big.NewInt(100000)),
+			rlp.MustWrapHex("0xe1a078b9e2b145d0a7387f09277c6ae1d9470771"), rlp.WrapInt(big.NewInt(value)), rlp.Data{},
+		}
+		sig, err := kp.Sign(unsigned.Encode())
+		assert.NoError(t, err)
+		return append(unsigned, rlp.WrapInt(sig.V), rlp.WrapInt(sig.R), rlp.WrapInt(sig.S)).Encode()
+	}
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_sendRawPrivateTransaction", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			*(args[1].(*ethtypes.HexBytes0xPrefix)) = ethtypes.MustNewHexBytes0xPrefix("0x7d48ae971faf089878b57e3c28e3035540d34f38af395958d2c73c36c57c83a2")
+		}).
+		Return(nil).Once()
+	privateReq := testPrivateSendRequest(t, sampleSendRawTX)
+	privateReq.TransactionData = ethtypes.HexBytes0xPrefix(signedTx(1, 60)).String()
+	_, _, err = c.PrivateTransactionSend(ctx, privateReq)
+	assert.NoError(t, err)
+
+	privateReq.TransactionData = ethtypes.HexBytes0xPrefix(signedTx(2, 50)).String()
+	_, _, err = c.PrivateTransactionSend(ctx, privateReq)
+	assert.Regexp(t, "FF23119.*"+kp.Address.String(), err)
+}
@@ -0,0 +1,73 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/hyperledger/firefly-common/pkg/log"
+)
+
+// zeroGasNetwork is the result of checking whether the node is on a free gas network, such as a Besu or GoQuorum
+// consortium chain, with the gas limit of the latest block at the time of the check
+type zeroGasNetwork struct {
+	detected      bool
+	blockGasLimit *big.Int
+}
+
+// detectZeroGas checks whether the network is a free gas network when the node returns a gas price of zero,
+// which is a network with no base fee in its latest block. The result is cached until the endpoints are
+// reloaded, and networks with a non-zero gas price are never checked, so they make no extra requests.
+func (c *ethConnector) detectZeroGas(ctx context.Context, nodeGasPrice *big.Int) bool {
+	if nodeGasPrice.Sign() != 0 || !c.gas().zeroGasDetect {
+		return false
+	}
+	if zg := c.zeroGas.Load(); zg != nil {
+		return zg.detected
+	}
+	latest, err := c.latestBlockInfo(ctx)
+	if err != nil {
+		log.L(ctx).Warnf("Unable to check for a zero gas network: %s", err)
+		return false
+	}
+	zg := &zeroGasNetwork{
+		detected: latest.BaseFeePerGas == nil,
+	}
+	if latest.GasLimit != nil {
+		zg.blockGasLimit = latest.GasLimit.BigInt()
+	}
+	if zg.detected {
+		log.L(ctx).Infof("Detected a zero gas network, with a gas price of zero and no base fee in block %s", latest.Number.BigInt())
+	}
+	c.zeroGas.Store(zg)
+	return zg.detected
+}
+
+// zeroGasLimit returns the gas limit for transactions prepared without one on a detected zero gas network, which
+// is no more than the gas limit of the latest block, or nil if the gas is to be estimated
+func (c *ethConnector) zeroGasLimit() *big.Int {
+	zg, gp := c.zeroGas.Load(), c.gas()
+	if zg == nil || !zg.detected || !gp.zeroGasDetect || gp.zeroGasLimit <= 0 {
+		return nil
+	}
+	gasLimit := big.NewInt(gp.zeroGasLimit)
+	if zg.blockGasLimit != nil && zg.blockGasLimit.Sign() > 0 && zg.blockGasLimit.Cmp(gasLimit) < 0 {
+		gasLimit = zg.blockGasLimit
+	}
+	return gasLimit
+}
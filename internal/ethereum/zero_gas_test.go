@@ -0,0 +1,126 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-evmconnect/mocks/rpcbackendmocks"
+	"github.com/hyperledger/firefly-signer/pkg/rpcbackend"
+	"github.com/hyperledger/firefly-transaction-manager/pkg/ffcapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func mockZeroGasLatestBlock(mRPC *rpcbackendmocks.Backend, baseFee, gasLimit int64) *mock.Call {
+	return mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", "latest", false).
+		Run(func(args mock.Arguments) {
+			bi := testBaseFeeBlock(100, baseFee, 0, gasLimit)
+			if baseFee == 0 {
+				bi.BaseFeePerGas = nil
+			}
+			*(args[1].(**blockInfoJSONRPC)) = bi
+		}).
+		Return(nil)
+}
+
+func zeroGasPrepareRequest(t *testing.T) *ffcapi.TransactionPrepareRequest {
+	var req ffcapi.TransactionPrepareRequest
+	err := json.Unmarshal([]byte(samplePrepareTXEstimateGas), &req)
+	assert.NoError(t, err)
+	return &req
+}
+
+func TestZeroGasNetworkDetected(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t, func(conf config.Section) {
+		conf.Set(GasPriceSuggestions, true)
+	})
+	defer done()
+	mockNodeGasPrice(mRPC, 0)
+	mockZeroGasLatestBlock(mRPC, 0, 8000000).Once()
+
+	// The network is only checked once, and the estimate is not an object even with suggestions enabled
+	for i := 0; i < 2; i++ {
+		res, _, err := c.GasPriceEstimate(ctx, &ffcapi.GasPriceEstimateRequest{})
+		assert.NoError(t, err)
+		assert.Equal(t, `"0"`, res.GasPrice.String())
+	}
+
+	// Transactions prepared without gas use the gas limit, limited to that of the block, without estimating
+	res, _, err := c.TransactionPrepare(ctx, zeroGasPrepareRequest(t))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(8000000), res.Gas.Int64())
+
+	c.gas().zeroGasLimit = 1000000
+	res, _, err = c.TransactionPrepare(ctx, zeroGasPrepareRequest(t))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1000000), res.Gas.Int64())
+	mRPC.AssertExpectations(t)
+}
+
+func TestZeroGasNetworkWithBaseFee(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+	mockNodeGasPrice(mRPC, 0)
+	mockZeroGasLatestBlock(mRPC, 7, 30000000).Once()
+
+	res, _, err := c.GasPriceEstimate(ctx, &ffcapi.GasPriceEstimateRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, `"0"`, res.GasPrice.String())
+	assert.False(t, c.zeroGas.Load().detected)
+	assert.Nil(t, c.zeroGasLimit())
+}
+
+func TestZeroGasNetworkCheckFails(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t)
+	defer done()
+	mockNodeGasPrice(mRPC, 0)
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", "latest", false).
+		Return(&rpcbackend.RPCError{Message: "pop"}).Once()
+
+	res, _, err := c.GasPriceEstimate(ctx, &ffcapi.GasPriceEstimateRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, `"0"`, res.GasPrice.String())
+	assert.Nil(t, c.zeroGas.Load())
+
+	// Checked again on the next estimate, and a block without a gas limit uses the configured limit
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", "latest", false).
+		Run(func(args mock.Arguments) {
+			*(args[1].(**blockInfoJSONRPC)) = &blockInfoJSONRPC{Number: testBaseFeeBlock(1, 0, 0, 0).Number}
+		}).
+		Return(nil).Once()
+	_, _, err = c.GasPriceEstimate(ctx, &ffcapi.GasPriceEstimateRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10000000), c.zeroGasLimit().Int64())
+}
+
+func TestZeroGasNetworkDisabled(t *testing.T) {
+	ctx, c, mRPC, done := newTestConnector(t, func(conf config.Section) {
+		conf.Set(ZeroGasAutoDetect, false)
+	})
+	defer done()
+	mockNodeGasPrice(mRPC, 0)
+
+	res, _, err := c.GasPriceEstimate(ctx, &ffcapi.GasPriceEstimateRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, `"0"`, res.GasPrice.String())
+	assert.Nil(t, c.zeroGas.Load())
+	c.zeroGas.Store(&zeroGasNetwork{detected: true})
+	assert.Nil(t, c.zeroGasLimit())
+}
@@ -0,0 +1,101 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msgs
+
+import (
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"golang.org/x/text/language"
+)
+
+var ffm = func(key, translation string) i18n.MessageKey {
+	return i18n.FFM(language.AmericanEnglish, key, translation)
+}
+
+//revive:disable
+var (
+	APIEndpointGetContractCapabilities = ffm("api.endpoints.get.contract.capabilities", "Check whether there is a contract deployed at an address, and optionally which ERC-165 interfaces it supports")
+	APIEndpointGetTokenMetadata        = ffm("api.endpoints.get.token.metadata", "Get the standard ERC-20, ERC-721 and ERC-1155 metadata of a token contract, omitting any functions the token does not implement")
+	APIEndpointPostStorageQuery        = ffm("api.endpoints.post.contract.storage", "Read a raw storage slot of a contract, optionally computing the slot of a mapping entry or dynamic array element from a base slot")
+	APIEndpointPostStateProof          = ffm("api.endpoints.post.account.proof", "Get the EIP-1186 Merkle proofs of an account and its storage slots with eth_getProof, along with the state root of the block to verify them against")
+	APIEndpointGetReceiptProof         = ffm("api.endpoints.get.transaction.receipt.proof", "Build a Merkle Patricia inclusion proof of the receipt of a transaction against the receipts root of its block, from all the receipts of the block")
+	APIEndpointGetProxyInfo            = ffm("api.endpoints.get.contract.proxy", "Detect whether a contract is an EIP-1967, beacon or EIP-1822 (UUPS) proxy, and resolve the implementation contract")
+	APIEndpointGetContractMetadata     = ffm("api.endpoints.get.contract.metadata", "Get the build metadata the Solidity compiler appended to the code deployed at an address, including the IPFS or Swarm hash of the metadata JSON and the compiler version")
+	APIEndpointPostDecodeCallData      = ffm("api.endpoints.post.decode.calldata", "Decode the method and arguments of transaction input data, supplied directly or fetched by transaction hash, against an ABI")
+	APIEndpointPostDecodeTransaction   = ffm("api.endpoints.post.decode.transaction", "Decode a raw signed transaction, recovering the signer, without submitting it")
+	APIEndpointPostFFIToABI            = ffm("api.endpoints.post.ffi.abi", "Convert a FireFly Interface (FFI) to an ABI, keeping the structure of nested objects, arrays of objects and fixed size arrays, and the sizes of integers and fixed bytes")
+	APIEndpointPostABIToFFI            = ffm("api.endpoints.post.abi.ffi", "Generate a FireFly Interface (FFI) from a Solidity ABI, supplied directly or as the abi of the output of a compiler, with the schema and Ethereum details of each parameter")
+	APIEndpointPostPrivateQuery        = ffm("api.endpoints.post.privacygroup.query", "Query the private state of a Besu privacy group with priv_call, using the same request as a query of public state")
+	APIEndpointPostPrivateSend         = ffm("api.endpoints.post.privacy.tessera.send", "Send a GoQuorum private transaction, distributing the private payload through Tessera to the parties in privateFor")
+	APIEndpointPostPrivateReceipt      = ffm("api.endpoints.post.privacy.tessera.receipt", "Get the private receipt of a GoQuorum private transaction, using the same request as a receipt of a public transaction")
+	APIEndpointGetBlockByHash          = ffm("api.endpoints.get.block", "Get the full header of a block by hash, including the state, transaction and receipt roots, the logs bloom, uncles and withdrawals")
+	APIEndpointPostPauseListener       = ffm("api.endpoints.post.listener.pause", "Pause the delivery of the events of a listener, keeping its checkpoint, until it is resumed")
+	APIEndpointPostResumeListener      = ffm("api.endpoints.post.listener.resume", "Resume the delivery of the events of a paused listener from its checkpoint")
+	APIEndpointGetListenerSchema       = ffm("api.endpoints.get.listener.schema", "Get the JSON schema of the decoded data of each event of a listener, in the configured data format and the number format of the listener")
+	APIEndpointGetStreamSettings       = ffm("api.endpoints.get.eventstreams.settings", "Get the settings for the delivery of the events of a running event stream to the transaction manager")
+	APIEndpointPatchStreamSettings     = ffm("api.endpoints.patch.eventstreams.settings", "Change the error handling mode, delivery timeout and retry policy of a running event stream, without restarting it")
+	APIEndpointPostReplayEvents        = ffm("api.endpoints.post.listener.replay", "Replay the historical events of a listener over a range of blocks, without affecting the checkpoint of the listener. Events are returned one page at a time, flagged as replays")
+	APIEndpointGetBlockTxCount         = ffm("api.endpoints.get.block.transactions.count", "Get the number of transactions in a block, by number or tag, without fetching the block")
+	APIEndpointGetBlockTxPage          = ffm("api.endpoints.get.block.transactions", "Page through the transactions of a block, by number or tag, fetching each transaction by its index so that no response holds the full payload of the block")
+	APIEndpointGetBlockAtTimestamp     = ffm("api.endpoints.get.block.timestamp", "Find the first block with a timestamp at or after the supplied time, or the next block to be mined if there is none yet")
+	APIEndpointGetBlockL1Inclusion     = ffm("api.endpoints.get.block.l1inclusion", "Get the status of the inclusion of a block, by number or tag, in the state of L2 submitted to L1 by the rollup contract, with the L1 block and transaction of the submission")
+	APIEndpointGetBaseFee              = ffm("api.endpoints.get.gas.basefee", "Get the EIP-1559 base fee per gas of the latest blocks, with the base fee projected for the next block")
+	APIEndpointPostFeeBump             = ffm("api.endpoints.post.gas.feebump", "Suggest the fees to replace an in-flight transaction with, which exceed its current fees by the price bump the node requires of a replacement, and are raised to the current market fees if those are higher")
+	APIEndpointPostUserOpHash          = ffm("api.endpoints.post.erc4337.userophash", "Compute the userOpHash of an ERC-4337 v0.7 packed user operation, as emitted in the UserOperationEvent and AccountDeployed events of the EntryPoint")
+	APIEndpointPostDeployDryRun        = ffm("api.endpoints.post.deploy.dryrun", "Validate the deployment of a contract without submitting it, by encoding the constructor arguments, executing the constructor with an eth_call, and estimating the gas of the deployment")
+	APIEndpointPostPrepareTransaction  = ffm("api.endpoints.post.transactions.prepare", "Prepare the invocation of a contract method, optionally running it as an eth_call with the prepared gas to return the decoded outputs the transaction would have")
+	APIEndpointGetQuarantinedEvents    = ffm("api.endpoints.get.quarantine", "List the events that were quarantined after repeatedly failing processing for a listener")
+	APIEndpointPostRetryQuarantined    = ffm("api.endpoints.post.quarantine.retry", "Process a quarantined event again, returning the event and removing it from the quarantine on success")
+	APIEndpointGetDeadLetters          = ffm("api.endpoints.get.deadletter", "List the batches of events in the dead letter store, that the transaction manager repeatedly failed to accept")
+	APIEndpointPostReplayDeadLetter    = ffm("api.endpoints.post.deadletter.replay", "Return the events of a batch in the dead letter store, flagged as replays, removing the batch from the store")
+	APIEndpointGetAdminStatus          = ffm("api.endpoints.get.admin.status", "Get the runtime state of the connector, including the checkpoints of each listener, endpoint health, cache sizes, in-flight requests and the active configuration with credentials redacted")
+	APIEndpointGetAdminSnapshot        = ffm("api.endpoints.get.admin.snapshot", "Export the checkpoints of all running listeners and the cached blocks of the canonical chain, as an archive that can be restored on a new instance of the connector")
+	APIEndpointPostAdminRestore        = ffm("api.endpoints.post.admin.restore", "Restore an archive exported from another instance of the connector, so listeners resume from its checkpoints and blocks are served from the cache without being queried again")
+	APIEndpointGetAdminCosts           = ffm("api.endpoints.get.admin.costs", "Get the JSON/RPC requests made by the connector, with their estimated compute units, by endpoint, method, class of operation, event stream and listener")
+	APIEndpointPostNonceGap            = ffm("api.endpoints.post.signer.noncegap", "Find the missing nonces of a signer that are blocking transactions queued in the txpool of the node, optionally submitting zero value transfers to fill them")
+	APIEndpointGetSignerMempool        = ffm("api.endpoints.get.signer.mempool", "List the pending and queued transactions of a signer in the txpool of the node, in nonce order")
+	APIEndpointGetTransactionMempool   = ffm("api.endpoints.get.transaction.mempool", "Check whether a transaction is known to the node, and if so whether it is mined, pending in the mempool, or queued behind a nonce gap")
+	APIEndpointGetAccountBalance       = ffm("api.endpoints.get.account.balance", "Get the balance of an account in the native currency, in the requested number format")
+	APIEndpointPostQuery               = ffm("api.endpoints.post.query", "Query a contract method with an eth_call, with the integers of the decoded outputs in the requested number format")
+
+	APIParamContractAddress     = ffm("api.params.contractAddress", "The address of the contract")
+	APIParamAccountAddress      = ffm("api.params.accountAddress", "The address of the account")
+	APIParamSignerAddress       = ffm("api.params.signer", "The address of the signing account")
+	APIParamTransactionHash     = ffm("api.params.transactionHash", "The hash of the transaction")
+	APIParamLogIndex            = ffm("api.params.logIndex", "Optional index of a log in the block, to find the position of the log within the proven receipt")
+	APIParamInterfaces          = ffm("api.params.interfaces", "ERC-165 interface IDs to probe, as 4 byte hex strings. Can be repeated, or comma separated")
+	APIParamBlockHash           = ffm("api.params.blockHash", "The hash of the block")
+	APIParamIncludeUncles       = ffm("api.params.includeUncles", "When true, the full headers of each uncle (ommer) of the block are also returned")
+	APIParamIncludeTransactions = ffm("api.params.includeTransactions", "When true, the full objects of the transactions of the block are also returned, fetched by index in batches for blocks over the paging threshold")
+	APIParamTimestamp           = ffm("api.params.timestamp", "An RFC3339 timestamp, or an integer number of seconds since the epoch")
+	APIParamMetadataIPFS        = ffm("api.params.metadataIPFS", "The IPFS CID of the metadata JSON of a build, to verify the deployed code was produced by that build")
+	APIParamStreamID            = ffm("api.params.replay.streamId", "The ID of the event stream")
+	APIParamListenerID          = ffm("api.params.replay.listenerId", "The ID of the event listener")
+	APIParamQuarantineID        = ffm("api.params.quarantineId", "The ID of the quarantined event")
+	APIParamDeadLetterID        = ffm("api.params.deadLetterId", "The ID of the dead letter batch")
+	APIParamTokenID             = ffm("api.params.tokenId", "Optional token ID for which to query the ERC-721 tokenURI and ERC-1155 uri, as a decimal or 0x prefixed hex integer")
+	APIParamBlockTag            = ffm("api.params.blockTag", "Optional block number or tag to query at, defaulting to latest")
+	APIParamBlockNumber         = ffm("api.params.blockNumber", "A decimal or 0x prefixed hex block number, or a block tag such as latest or finalized")
+	APIParamTxPageStart         = ffm("api.params.transactions.start", "The index of the first transaction of the page, defaulting to 0")
+	APIParamTxPageLimit         = ffm("api.params.transactions.limit", "The maximum number of transactions in the page, defaulting to the configured blockTransactions.batchSize")
+	APIParamNumberFormat        = ffm("api.params.numberFormat", "Optional format for integers, overriding the configured format - decimal, hex or scientific")
+	APIParamCostsReset          = ffm("api.params.costs.reset", "When true, a new report is started after the current one is returned")
+
+	FFIIntegerDescription = ffm("api.ffi.integer", "An integer. You are recommended to use a JSON string. A JSON number can be used for values up to the safe maximum.")
+	FFIFloatDescription   = ffm("api.ffi.float", "A floating point number, which will be converted to a fixed point number. You are recommended to use a JSON string. A JSON number can be used for values up to the safe maximum.")
+	FFIBoolDescription    = ffm("api.ffi.bool", "A boolean. You can use a boolean or a string true/false as input")
+	FFIHexDescription     = ffm("api.ffi.hex", "A hex encoded set of bytes, with an optional '0x' prefix")
+)
@@ -28,11 +28,159 @@ var ffc = func(key, translation string, fieldType string) i18n.ConfigMessageKey
 //revive:disable
 var (
 	_ = ffc("config.connector.url", "URL of JSON/RPC endpoint for the Ethereum node/gateway", "string")
+	_ = ffc("config.connector.read.url", "Optional URL of a separate JSON/RPC endpoint, such as a replica, used for read-heavy queries (eth_call, receipts and transaction lookups). Transaction submission, nonces, gas estimation, filters and the log queries of event streams always use the primary url", "string")
+	_ = ffc("config.connector.read.stickyWindow", "When a read endpoint is configured, receipt and transaction queries for transactions submitted through this connector are sent to the primary url for this period after submission, to avoid not-found results caused by replica lag", i18n.TimeDurationType)
+	_ = ffc("config.connector.read.maxLagBlocks", "Maximum number of blocks the read endpoint can be behind the best known chain head, before queries are routed to the primary url instead", i18n.IntType)
+	_ = ffc("config.connector.read.lagCheckInterval", "Interval at which the block height of the read endpoint is compared with the primary url. Set to 0 to disable lag checking", i18n.TimeDurationType)
+	_ = ffc("config.connector.read.hedging.enabled", "When true, queries to the read endpoint that have not completed within the hedging delay are duplicated to the primary url, and the first successful response is used", i18n.BooleanType)
+	_ = ffc("config.connector.read.hedging.percentile", "Percentile of recent read endpoint latencies used as the delay before a query is hedged", i18n.FloatType)
+	_ = ffc("config.connector.read.hedging.minDelay", "Minimum delay before a query to the read endpoint is hedged", i18n.TimeDurationType)
+	_ = ffc("config.connector.simulator.enabled", "When true, an anvil process is run as an in-memory chain for the connector to use, for end-to-end tests without an external node. The url must not be set", i18n.BooleanType)
+	_ = ffc("config.connector.simulator.command", "The anvil command to run, which must be on the path if it is not an absolute path", i18n.StringType)
+	_ = ffc("config.connector.simulator.args", "Additional arguments passed to the simulator, before those generated from the other simulator settings", i18n.ArrayStringType)
+	_ = ffc("config.connector.simulator.port", "The local port the simulator listens on. A free port is chosen when set to 0", i18n.IntType)
+	_ = ffc("config.connector.simulator.chainId", "The chain ID of the simulated chain", i18n.IntType)
+	_ = ffc("config.connector.simulator.accounts", "The number of funded development accounts created by the simulator", i18n.IntType)
+	_ = ffc("config.connector.simulator.mnemonic", "The BIP-39 mnemonic the development accounts are derived from, so they are the same on every run. A random mnemonic is used when not set", i18n.StringType)
+	_ = ffc("config.connector.simulator.blockTime", "The interval at which the simulator mines blocks. When 0, a block is mined for each transaction", i18n.TimeDurationType)
+	_ = ffc("config.connector.simulator.startupTimeout", "The maximum time to wait for the simulator to answer JSON/RPC requests after it is started", i18n.TimeDurationType)
+	_ = ffc("config.connector.rpcRecording.mode", "Set to 'record' to capture every request to the url, with its response, to the rpcRecording file, or 'replay' to answer requests from a file captured earlier in place of the url. Requests to the read and verification endpoints are not recorded", i18n.StringType)
+	_ = ffc("config.connector.rpcRecording.file", "The JSON lines file requests are appended to in record mode, or read from in replay mode", i18n.StringType)
+	_ = ffc("config.connector.faultInjection.enabled", "When true, faults are injected into the requests to the JSON/RPC endpoints according to the rules, to test the resilience of a deployment to an unreliable node. Must not be enabled in production", i18n.BooleanType)
+	_ = ffc("config.connector.faultInjection.rules[].methods", "The JSON/RPC methods the rule applies to, or all methods when empty. Only the first rule matching a method is applied", i18n.ArrayStringType)
+	_ = ffc("config.connector.faultInjection.rules[].latency", "Delay added before each matching request is sent", i18n.TimeDurationType)
+	_ = ffc("config.connector.faultInjection.rules[].errorRate", "The fraction of matching requests, from 0 to 1, that fail with the error without being sent", i18n.FloatType)
+	_ = ffc("config.connector.faultInjection.rules[].errorCode", "The JSON/RPC error code of injected errors", i18n.IntType)
+	_ = ffc("config.connector.faultInjection.rules[].errorMessage", "The message of injected errors", i18n.StringType)
+	_ = ffc("config.connector.faultInjection.rules[].dropRate", "The fraction of matching requests, from 0 to 1, that are sent but their response discarded, so they fail after the request timeout", i18n.FloatType)
+	_ = ffc("config.connector.faultInjection.rules[].malformedRate", "The fraction of matching requests, from 0 to 1, that are sent but their result replaced with malformed JSON", i18n.FloatType)
+	_ = ffc("config.connector.verification.url", "Optional URL of a JSON/RPC endpoint of an independent provider, that the logs of event streams and transaction receipts from the primary url are cross-checked against before they are delivered", i18n.StringType)
+	_ = ffc("config.connector.verification.mode", "What happens when a log or receipt does not match the verification endpoint, or cannot be checked against it. 'warn' logs and notifies the mismatch, and 'block' also holds back delivery until the endpoints agree", i18n.StringType)
+	_ = ffc("config.connector.finality.enabled", "When true, receipts are returned as not found, and events are not delivered, until their block is finalized. For zkEVM rollups this is once the block is proven on L1", i18n.BooleanType)
+	_ = ffc("config.connector.finality.profile", "The chain profile of a zkEVM rollup, which is one of linea, scroll, polygonZkEVM or zkSync, to use the method of its rollup contract on L1 by default", i18n.StringType)
+	_ = ffc("config.connector.finality.cacheTTL", "How long the finalized block is cached for, before it is queried again", i18n.TimeDurationType)
+	_ = ffc("config.connector.finality.l1.url", "Optional URL of a JSON/RPC endpoint of L1, to query the last finalized L2 block from the rollup contract at the finalized block of L1, rather than using the finalized block tag of the L2 node", i18n.StringType)
+	_ = ffc("config.connector.finality.l1.rollupContract", "The address of the rollup contract on L1", i18n.StringType)
+	_ = ffc("config.connector.finality.l1.method", "The signature of the view function of the rollup contract that returns the last finalized L2 block, such as currentL2BlockNumber(), which defaults from the profile", i18n.StringType)
+	_ = ffc("config.connector.finality.l1.proxy.username", "Username to authenticate to the proxy server in proxy.url with, which can be an HTTP proxy (http:// or https://) or a SOCKS5 proxy (socks5:// or socks5h://), for requests to the L1 endpoint", i18n.StringType)
+	_ = ffc("config.connector.finality.l1.proxy.password", "Password to authenticate to the proxy server in proxy.url with", i18n.StringType)
+	_ = ffc("config.connector.l1Inclusion.enabled", "When true, the submissions of the state of L2 by the rollup contract on L1 are tracked, to annotate events with the L1 inclusion status of their block", i18n.BooleanType)
+	_ = ffc("config.connector.l1Inclusion.profile", "The chain profile of the rollup, which is one of linea or opStack, to use the event of its rollup contract on L1 by default", i18n.StringType)
+	_ = ffc("config.connector.l1Inclusion.cacheTTL", "How long the submissions found on L1 are used for, before L1 is queried for new submissions", i18n.TimeDurationType)
+	_ = ffc("config.connector.l1Inclusion.lookbackBlocks", "The number of L1 blocks before the head of L1 to look for submissions in, when the connector starts or has not queried L1 for longer than that", i18n.IntType)
+	_ = ffc("config.connector.l1Inclusion.l1.url", "URL of a JSON/RPC endpoint of L1, to query the events of the rollup contract from", i18n.StringType)
+	_ = ffc("config.connector.l1Inclusion.l1.rollupContract", "The address of the rollup contract on L1 that the state of L2 is submitted to", i18n.StringType)
+	_ = ffc("config.connector.l1Inclusion.l1.event", "The signature of the event the rollup contract emits for each submission, such as OutputProposed(bytes32,uint256,uint256,uint256), which defaults from the profile", i18n.StringType)
+	_ = ffc("config.connector.l1Inclusion.l1.l2BlockTopic", "The index of the indexed topic of the event that holds the last L2 block covered by the submission, from 1 to 3, which defaults from the profile", i18n.IntType)
+	_ = ffc("config.connector.l1Inclusion.l1.stateRootTopic", "The index of the indexed topic of the event that holds the state or output root of the submission, from 1 to 3, which defaults from the profile", i18n.IntType)
+	_ = ffc("config.connector.l1Inclusion.l1.proxy.username", "Username to authenticate to the proxy server in proxy.url with, which can be an HTTP proxy (http:// or https://) or a SOCKS5 proxy (socks5:// or socks5h://), for requests to the L1 endpoint", i18n.StringType)
+	_ = ffc("config.connector.l1Inclusion.l1.proxy.password", "Password to authenticate to the proxy server in proxy.url with", i18n.StringType)
+	_ = ffc("config.connector.proxyResolution.enabled", "When true, events emitted by EIP-1967, beacon and EIP-1822 (UUPS) proxy contracts are enriched with the address of the implementation contract at the block of the event, and are decoded against the configured ABI of that implementation", i18n.BooleanType)
+	_ = ffc("config.connector.proxyResolution.cacheTTL", "How long a resolved proxy implementation address is cached before the proxy storage slots are read again", i18n.TimeDurationType)
+	_ = ffc("config.connector.proxyResolution.abis[].implementation", "The address of an implementation contract that proxies delegate to", "string")
+	_ = ffc("config.connector.proxyResolution.abis[].abi", "The JSON ABI of the implementation contract. Events emitted by a proxy are decoded against the matching event of this ABI while the proxy delegates to the implementation, and its errors are used to decode reverts of calls to the proxy", "JSON Array")
 	_ = ffc("config.connector.ws.enabled", "When true a WebSocket is established for block listening, in addition to the HTTP RPC connections used for other functions", i18n.BooleanType)
 	_ = ffc("config.connector.dataFormat", "Configure the JSON data format for query output and events", "map,flat_array,self_describing")
+	_ = ffc("config.connector.numberFormat", "The format of integer values in decoded events, query outputs and balances, which can be overridden for each listener and request. All formats are JSON strings, as 256-bit values cannot be represented exactly by the JSON numbers of many consumers", "decimal,hex,scientific")
+	_ = ffc("config.connector.jsonNumbers.strict", "When true, every number in the JSON of events and receipts is a string, including counts and indexes, so no value can lose precision in a consumer that parses JSON numbers as floating point", i18n.BooleanType)
+	_ = ffc("config.connector.jsonNumbers.eventStreams", "The IDs of the event streams whose events are strict when jsonNumbers.strict is set, defaulting to all event streams. Receipts are strict regardless", i18n.StringType)
+	_ = ffc("config.connector.gasPriceSmoothing.enabled", "When true, the gas price returned to the policy engine is an exponentially weighted moving average of the node gas price, rather than the latest value", i18n.BooleanType)
+	_ = ffc("config.connector.gasPriceSmoothing.alpha", "The weight given to the latest gas price in the moving average, greater than 0 and at most 1. Higher values track the node price more closely", i18n.FloatType)
+	_ = ffc("config.connector.gasPriceSmoothing.spikeCap", "The maximum multiple of the moving average that a single gas price sample can contribute, to protect against outlier blocks", i18n.FloatType)
+	_ = ffc("config.connector.gasPriceSuggestions.enabled", "When true, the gas price estimate is an object containing the gasPrice, and low/medium/high EIP-1559 fee suggestions with their target inclusion blocks computed from the fee history of recent blocks", i18n.BooleanType)
+	_ = ffc("config.connector.gasPriceSuggestions.feeHistoryBlocks", "The number of recent blocks of fee history used to compute the gas price suggestions", i18n.IntType)
+	_ = ffc("config.connector.baseFee.windowBlocks", "The number of the latest blocks of the canonical chain whose base fee is returned by the base fee API, up to the depth of the canonical chain held in memory", i18n.IntType)
+	_ = ffc("config.connector.blockTransactions.pagingThreshold", "The number of transactions above which the full transactions of a block are fetched individually by index, rather than in a single eth_getBlockByHash response that can be hundreds of MB for huge blocks", i18n.IntType)
+	_ = ffc("config.connector.blockTransactions.batchSize", "The number of transactions of a block fetched concurrently by index in each batch, when paging the transactions of a block", i18n.IntType)
+	_ = ffc("config.connector.blobGas.enabled", "When true, the gas price estimate is an object that includes the EIP-4844 blob base fee of the next block, and a maxFeePerBlobGas for blob-carrying transactions, computed from the excessBlobGas of the latest block", i18n.BooleanType)
+	_ = ffc("config.connector.blobGas.targetPerBlock", "The target blob gas per block of the chain. The default is that of Ethereum mainnet since the Prague fork", i18n.IntType)
+	_ = ffc("config.connector.blobGas.maxPerBlock", "The maximum blob gas per block of the chain. The default is that of Ethereum mainnet since the Prague fork", i18n.IntType)
+	_ = ffc("config.connector.blobGas.updateFraction", "The blob base fee update fraction of the chain, which controls how quickly the blob base fee changes. The default is that of Ethereum mainnet since the Prague fork", i18n.IntType)
+	_ = ffc("config.connector.feeCurrency.address", "The address of the ERC-20 fee currency (or fee currency adapter) that transactions pay their fees in, on chains such as Celo that support alternative fee currencies. Gas prices are queried in the fee currency, and it is included in gas estimation and submission unless the gas price of a transaction has its own feeCurrency", i18n.StringType)
+	_ = ffc("config.connector.feeBump.priceBump", "The percentage by which the fees of a replacement transaction must exceed those of the transaction it replaces for the node to accept it, which is the txpool.pricebump setting of geth", i18n.IntType)
+	_ = ffc("config.connector.feeBump.blobPriceBump", "The percentage by which the fees of a replacement blob-carrying transaction must exceed those of the transaction it replaces, which is the blobpool.pricebump setting of geth", i18n.IntType)
+	_ = ffc("config.connector.feeBump.urgentAfter", "The age after which the fee bump suggestion for an EIP-1559 transaction is based on the high fee suggestion of the recent fee history, rather than the medium one", i18n.TimeDurationType)
+	_ = ffc("config.connector.minGasPrice.value", "The minimum gas price, in wei, that the node accepts transactions at, such as the txpool.pricelimit of geth or the min-gas-price of Besu. Gas price estimates, and the priority fees of gas price suggestions, are raised to at least this price", i18n.StringType)
+	_ = ffc("config.connector.minGasPrice.learn", "When true, the minimum gas price of the node is learned from the minimum it reports when it rejects a transaction as underpriced, and raises gas price estimates in the same way as a configured minimum", i18n.BooleanType)
+	_ = ffc("config.connector.zeroGas.autoDetect", "When true, a node that returns a gas price of zero is checked for a base fee in its latest block, and if it has none the network is treated as a free gas network, with gas price estimates of zero and the gas limit of prepared transactions defaulting to zeroGas.gasLimit", i18n.BooleanType)
+	_ = ffc("config.connector.zeroGas.gasLimit", "The gas limit of transactions prepared without one on a detected free gas network, instead of estimating the gas, limited to the gas limit of the latest block. Set to 0 to estimate the gas as on other networks", i18n.IntType)
+	_ = ffc("config.connector.configReload.watchFile", "When true, the config file is watched for changes, which are applied to the endpoint, gas and policy configuration of the running connector in the same way as on receipt of a SIGHUP", i18n.BooleanType)
+	_ = ffc("config.connector.policy.preSigned.verifyChainId", "When true, pre-signed transactions are decoded before submission and rejected unless they were signed for the chain ID of the node, to prevent a transaction signed for another network being sent to this one. Transactions of types the connector cannot decode are rejected", i18n.BooleanType)
+	_ = ffc("config.connector.policy.preSigned.allowUnprotected", "When chain ID verification is enabled, whether legacy pre-signed transactions without EIP-155 replay protection, which are valid on any chain, are accepted", i18n.BooleanType)
+	_ = ffc("config.connector.policy.preSigned.allowedSigners", "When set, pre-signed transactions are only accepted if the signer recovered from the signature is one of these addresses", i18n.ArrayStringType)
+	_ = ffc("config.connector.policy.destinations.allowed", "When set, transactions can only be sent to these contract addresses. An entry shorter than a full address matches all addresses with that hex prefix. Contract deployments are not restricted", i18n.ArrayStringType)
+	_ = ffc("config.connector.policy.destinations.denied", "Contract addresses, or hex prefixes of addresses, that transactions cannot be sent to, even if they match the allowed destinations", i18n.ArrayStringType)
+	_ = ffc("config.connector.policy.value.maxPerTransaction", "When set, transactions that transfer more than this amount of the native token, in wei, are rejected before submission", i18n.StringType)
+	_ = ffc("config.connector.policy.value.maxPerWindow", "When set, transactions are rejected if the native token they transfer, in wei, would take the total submitted by their signer within the rolling window above this amount", i18n.StringType)
+	_ = ffc("config.connector.policy.value.window", "The rolling time window of the maximum value per signer", i18n.TimeDurationType)
+	_ = ffc("config.connector.auditLog.file", "Path of a file that a JSON record of every transaction submission attempt is appended to, with its signer, destination, method, value, gas and result", i18n.StringType)
+	_ = ffc("config.connector.auditLog.webhook.url", "URL that a JSON record of every transaction submission attempt is posted to, in the order of submission", i18n.StringType)
+	_ = ffc("config.connector.auditLog.queueSize", "Number of audit records that can be waiting for delivery to the webhook, before submissions wait for the webhook to catch up", i18n.IntType)
+	_ = ffc("config.connector.notifications.webhook.url", "URL that notifications of significant connector events are posted to, such as the read endpoint failing over to the primary, an endpoint changed by a config reload, event streams lagging, deep re-orgs, quarantined events, signed transactions for another chain, logs that do not match their receipts and results that do not match the verification endpoint", i18n.StringType)
+	_ = ffc("config.connector.notifications.events", "The notification types posted to the webhook. All types are posted when not set", i18n.ArrayStringType)
+	_ = ffc("config.connector.notifications.queueSize", "Number of notifications that can be waiting for delivery to the webhook, after which further notifications are dropped", i18n.IntType)
+	_ = ffc("config.connector.notifications.streamLagBlocks", "Number of blocks an event stream catching up can be behind the head of the chain, before a stream_lag notification. Set to 0 to disable", i18n.IntType)
+	_ = ffc("config.connector.notifications.reorgDepth", "Number of blocks that must be replaced by a single re-org of the canonical chain, before a reorg notification. Set to 0 to disable", i18n.IntType)
+	_ = ffc("config.connector.sinks.kafka.url", "URL of a Kafka REST proxy supporting the v3 API, that decoded events are published to in addition to their delivery to the transaction manager", i18n.StringType)
+	_ = ffc("config.connector.sinks.kafka.clusterId", "The ID of the Kafka cluster on the REST proxy", i18n.StringType)
+	_ = ffc("config.connector.sinks.kafka.topic", "The Kafka topic events are published to, keyed on the contract address that emitted the event", i18n.StringType)
+	_ = ffc("config.connector.sinks.kafka.eventStreams", "The IDs of the event streams whose events are published to Kafka. The events of all streams are published when not set", i18n.ArrayStringType)
+	_ = ffc("config.connector.sinks.kafka.confirmations", "The number of blocks that must follow the block of an event before it is published to Kafka. Events of blocks replaced by a re-org within these blocks are not published. 0 publishes as events are delivered", i18n.IntType)
+	_ = ffc("config.connector.sinks.kafka.queueSize", "Number of events that can be waiting to be published to Kafka, before delivery of events on the stream waits for Kafka to catch up", i18n.IntType)
+	_ = ffc("config.connector.sinks.nats.url", "URL of a NATS server with JetStream enabled, such as nats://localhost:4222, that decoded events are published to in addition to their delivery to the transaction manager. Use a tls:// URL, or the tls settings, to connect with TLS", i18n.StringType)
+	_ = ffc("config.connector.sinks.nats.subject", "The prefix of the subjects that are published to. Events are published to <subject>.events.<address> and blocks to <subject>.blocks, so a JetStream stream must be bound to <subject>.>", i18n.StringType)
+	_ = ffc("config.connector.sinks.nats.username", "Username to authenticate to the NATS server with", i18n.StringType)
+	_ = ffc("config.connector.sinks.nats.password", "Password to authenticate to the NATS server with", i18n.StringType)
+	_ = ffc("config.connector.sinks.nats.token", "Token to authenticate to the NATS server with", i18n.StringType)
+	_ = ffc("config.connector.sinks.nats.requestTimeout", "The maximum time to wait to connect to the NATS server, or for the stream to acknowledge a published record", i18n.TimeDurationType)
+	_ = ffc("config.connector.sinks.nats.blocks", "When true, the header of each block added to the canonical chain is published, as well as events", i18n.BooleanType)
+	_ = ffc("config.connector.sinks.nats.eventStreams", "The IDs of the event streams whose events are published to NATS. The events of all streams are published when not set", i18n.ArrayStringType)
+	_ = ffc("config.connector.sinks.nats.confirmations", "The number of blocks that must follow a block before it, and its events, are published to NATS. Blocks and events replaced by a re-org within these blocks are not published. 0 publishes as they are delivered", i18n.IntType)
+	_ = ffc("config.connector.sinks.nats.queueSize", "Number of records that can be waiting to be published to NATS, before delivery of events on the stream and of blocks waits for NATS to catch up", i18n.IntType)
+	_ = ffc("config.connector.checkpoints.store", "Where listener checkpoints are stored, in addition to the transaction manager. One of fftm (only the transaction manager), redis or postgres. A listener starts from the stored checkpoint if it is ahead of the one passed by the transaction manager", i18n.StringType)
+	_ = ffc("config.connector.checkpoints.requestTimeout", "The maximum time for a request to the checkpoint store", i18n.TimeDurationType)
+	_ = ffc("config.connector.checkpoints.redis.url", "URL of the Redis server checkpoints are stored in, such as redis://localhost:6379/0. Use a rediss:// URL, or the tls settings, to connect with TLS", i18n.StringType)
+	_ = ffc("config.connector.checkpoints.redis.username", "Username to authenticate to Redis with, when using access control lists", i18n.StringType)
+	_ = ffc("config.connector.checkpoints.redis.password", "Password to authenticate to Redis with", i18n.StringType)
+	_ = ffc("config.connector.checkpoints.redis.keyPrefix", "The prefix of the Redis key of each checkpoint, which is followed by the listener ID", i18n.StringType)
+	_ = ffc("config.connector.checkpoints.postgres.url", "The PostgreSQL connection string of the database checkpoints are stored in", i18n.StringType)
+	_ = ffc("config.connector.checkpoints.postgres.table", "The table checkpoints are stored in, which is created if it does not exist", i18n.StringType)
+	_ = ffc("config.connector.checkpoints.postgres.maxConns", "The maximum number of connections to the PostgreSQL database", i18n.IntType)
+	_ = ffc("config.connector.policy.methods[].contract", "The address of a contract that transactions can only invoke the listed methods on", "string")
+	_ = ffc("config.connector.policy.methods[].selectors", "The methods that can be invoked on the contract, each either a 4 byte hex function selector or a function signature such as transfer(address,uint256). Transactions to the contract without a function selector in their data are rejected", i18n.ArrayStringType)
+	_ = ffc("config.connector.txLimits.maxSize", "The maximum size in bytes of the data of a transaction, or of the whole of a pre-signed transaction, checked before submission. The default is the 128KB limit of geth. Set to 0 for no limit", i18n.IntType)
+	_ = ffc("config.connector.txLimits.checkBlockGasLimit", "When true, the gas limit of a transaction is checked before submission against the gas limit of the latest block, which a transaction cannot exceed", i18n.BooleanType)
+	_ = ffc("config.connector.compression.responses", "When true, gzip compressed responses are requested from the HTTP JSON/RPC endpoints with an Accept-Encoding header, and are used if the node supports them", i18n.BooleanType)
+	_ = ffc("config.connector.compression.requests", "When true, request bodies sent to the HTTP JSON/RPC endpoints are gzip compressed. Only enable this if the node, or the gateway in front of it, accepts a Content-Encoding of gzip", i18n.BooleanType)
+	_ = ffc("config.connector.compression.requestMinSize", "The minimum size of a request body to compress, when request compression is enabled", i18n.ByteSizeType)
+	_ = ffc("config.connector.correlation.headers.enabled", "When true, the ID of the FFCAPI request and the FireFly operation ID are sent in HTTP headers on each JSON/RPC request, for node providers that record them", i18n.BooleanType)
+	_ = ffc("config.connector.correlation.headers.requestId", "The HTTP header the request ID is sent in. The request ID is always sent in the FireFly request ID header as well", i18n.StringType)
+	_ = ffc("config.connector.correlation.headers.operationId", "The HTTP header the FireFly operation ID is sent in", i18n.StringType)
+	_ = ffc("config.connector.rateLimitHeaders.enabled", "When true, requests to an endpoint are paused when its responses have a Retry-After header, or report the remaining requests of the rate limit are exhausted, rather than only backing off after requests are rejected", i18n.BooleanType)
+	_ = ffc("config.connector.rateLimitHeaders.remaining", "The response header with the number of requests, or compute units, remaining in the current rate limit window of the provider", i18n.StringType)
+	_ = ffc("config.connector.rateLimitHeaders.reset", "The response header with the time the rate limit window resets, in seconds from now or seconds since the epoch", i18n.StringType)
+	_ = ffc("config.connector.rateLimitHeaders.minRemaining", "Requests are paused until the reset once the remaining requests are at or below this number", i18n.FloatType)
+	_ = ffc("config.connector.rateLimitHeaders.maxPause", "The longest requests are paused for, whatever the headers of the provider say", i18n.TimeDurationType)
+	_ = ffc("config.connector.contractMetadata.receipts", "When true, the receipt of a contract deployment includes the metadata appended to the code of the deployed contract by the compiler", i18n.BooleanType)
+	_ = ffc("config.connector.errorDetails.enabled", "When true, the errors returned to the transaction manager have a JSON object of machine-readable details appended to the message, after 'errorDetails='", i18n.BooleanType)
+	_ = ffc("config.connector.gasReport.receipts", "When true, the receipt of a transaction reports its gas limit and maximum fee against the gas it used and the fee it paid, and the totals for successful transactions are returned by the admin status API, to tune the gas estimation factor", i18n.BooleanType)
+	_ = ffc("config.connector.arbitrumRetryables.receipts", "When true, the receipt of a transaction that submits or creates Arbitrum retryable tickets includes the status of each ticket, and of its redemption on L2", i18n.BooleanType)
+	_ = ffc("config.connector.legacyChain.enabled", "When true, the connector is compatible with chains that predate EIP-155 replay protection. Pre-signed transactions must be legacy transactions signed without a chain ID, EIP-1559 fees are rejected, and net_version is used in place of eth_chainId", i18n.BooleanType)
+	_ = ffc("config.connector.costAccounting.enabled", "When true, the JSON/RPC requests to each endpoint are counted with their estimated compute units, against the event stream, listener and class of operation they were made for, and reported on the admin API", i18n.BooleanType)
+	_ = ffc("config.connector.costAccounting.computeUnits", "The compute units of JSON/RPC methods, keyed by method name, to match the pricing of the provider. These override the built in estimates", i18n.MapStringStringType)
+	_ = ffc("config.connector.latencySLO.enabled", "When true, a percentile of the recent latencies of transaction submission, queries and log polling is tracked against the target of each, and the ready response reports a degraded status while any of them is breached persistently", i18n.BooleanType)
+	_ = ffc("config.connector.latencySLO.percentile", "Percentile of the recent latencies of an operation that is compared against its target", i18n.FloatType)
+	_ = ffc("config.connector.latencySLO.window", "Number of recent latencies of each operation the percentile is calculated over", i18n.IntType)
+	_ = ffc("config.connector.latencySLO.degradeAfter", "How long the latency of an operation must stay above its target before the ready response reports a degraded status", i18n.TimeDurationType)
+	_ = ffc("config.connector.latencySLO.send", "Target latency for submitting a transaction to the node. Zero disables the SLO", i18n.TimeDurationType)
+	_ = ffc("config.connector.latencySLO.query", "Target latency for a query of a contract. Zero disables the SLO", i18n.TimeDurationType)
+	_ = ffc("config.connector.latencySLO.logPoll", "Target latency for a poll of the logs of the listeners of an event stream. Zero disables the SLO", i18n.TimeDurationType)
+	_ = ffc("config.connector.costAccounting.defaultComputeUnits", "The compute units of a JSON/RPC method that does not have an estimate", i18n.IntType)
 	_ = ffc("config.connector.gasEstimationFactor", "The factor to apply to the gas estimation to determine the gas limit", i18n.FloatType)
+	_ = ffc("config.connector.gasEstimationSpoofBalance", "When true, gas estimation passes a state override granting the sender a large temporary balance, so estimation succeeds for accounts that are funded just-in-time or sponsored. Requires a node that supports state overrides on eth_estimateGas", i18n.BooleanType)
 	_ = ffc("config.connector.blockCacheSize", "Maximum of blocks to hold in the block info cache", i18n.IntType)
 	_ = ffc("config.connector.blockPollingInterval", "Interval for polling to check for new blocks", i18n.TimeDurationType)
+	_ = ffc("config.connector.canonicalChainDepth", "The number of blocks at the head of the chain to hold in the in-memory canonical chain, used to detect re-orgs and to answer block queries without re-fetching headers. Recently forked blocks are tracked to the same depth. Defaults to the value of events.checkpointBlockGap", i18n.IntType)
 	_ = ffc("config.connector.queryLoopRetry.initialDelay", "Initial delay for retrying query requests to the RPC endpoint, applicable to all the query loops", i18n.TimeDurationType)
 	_ = ffc("config.connector.queryLoopRetry.factor", "Factor to increase the delay by, between each query request retry to the RPC endpoint, applicable to all the query loops", i18n.FloatType)
 	_ = ffc("config.connector.queryLoopRetry.maxDelay", "Maximum delay for between each query request retry to the RPC endpoint, applicable to all the query loops", i18n.TimeDurationType)
@@ -45,8 +193,29 @@ var (
 	_ = ffc("config.connector.events.catchupDownscaleRegex", "An error pattern to check for from JSON/RPC providers if they limit response sizes to eth_getLogs(). If an error is returned from eth_getLogs() and that error matches the configured pattern, the number of logs requested (catchupPageSize) will be reduced automatically.", "string")
 	_ = ffc("config.connector.events.checkpointBlockGap", "The number of blocks at the head of the chain that should be considered unstable (could be dropped from the canonical chain after a re-org). Unless events with a full set of confirmations are detected, the restart checkpoint will this many blocks behind the chain head.", i18n.IntType)
 	_ = ffc("config.connector.events.filterPollingInterval", "The interval between polling calls to a filter, when checking for newly arrived events", i18n.TimeDurationType)
+	_ = ffc("config.connector.events.pollingMode", "How event streams poll for new events at the head of the chain - filters installs a filter on the node with eth_newFilter and polls it with eth_getFilterChanges, and getLogs queries each new range of blocks with eth_getLogs", i18n.StringType)
+	_ = ffc("config.connector.events.bloomScreening.enabled", "When true, the logsBloom of each new block is checked against the listener event signatures and addresses, and the filter is not polled while none of the new blocks can contain a matching event", i18n.BooleanType)
+	_ = ffc("config.connector.events.bloomScreening.maxSkip", "The maximum time the filter poll will be skipped due to bloom screening, before polling anyway so the filter does not expire on the node", i18n.TimeDurationType)
+	_ = ffc("config.connector.events.logVerification.sampleRate", "The fraction of transactions and blocks, from 0 to 1, for which the logs returned by the node are cross-checked against the transaction receipts. Zero disables verification", i18n.FloatType)
+	_ = ffc("config.connector.events.logVerification.failOnMismatch", "When true, logs that do not match the receipts are not delivered and the query is retried, rather than only being logged and notified", i18n.BooleanType)
+	_ = ffc("config.connector.events.quarantine.maxAttempts", "The number of consecutive attempts to process an event for a listener, after which it is quarantined and an error event is delivered in its place. 0 retries indefinitely", i18n.IntType)
+	_ = ffc("config.connector.events.deadLetter.directory", "Directory that batches of events the transaction manager repeatedly fails to accept are persisted to, so the event stream can move on and the batch replayed later through the admin API. Disabled when empty, in which case delivery waits indefinitely", i18n.StringType)
+	_ = ffc("config.connector.events.deadLetter.deliveryTimeout", "How long each attempt to deliver an event to the transaction manager waits for it to be accepted, when the dead letter store is enabled", i18n.TimeDurationType)
+	_ = ffc("config.connector.events.deadLetter.maxAttempts", "The number of attempts to deliver an event to the transaction manager, after which the event and the rest of its batch are persisted to the dead letter store", i18n.IntType)
+	_ = ffc("config.connector.events.deadLetter.retry.initialDelay", "The initial delay between attempts to deliver an event, before it is persisted to the dead letter store", i18n.TimeDurationType)
+	_ = ffc("config.connector.events.deadLetter.retry.maxDelay", "The maximum delay between attempts to deliver an event, before it is persisted to the dead letter store", i18n.TimeDurationType)
+	_ = ffc("config.connector.events.deadLetter.retry.factor", "The factor the delay between attempts to deliver an event is multiplied by on each attempt", i18n.FloatType)
 	_ = ffc("config.connector.txCacheSize", "Maximum of transactions to hold in the transaction info cache", i18n.IntType)
 	_ = ffc("config.connector.maxConcurrentRequests", "Maximum of concurrent requests to be submitted to the blockchain", i18n.IntType)
 	_ = ffc("config.connector.hederaCompatibilityMode", "Compatibility mode for Hedera, allowing non-standard block header hashes to be processed", i18n.BooleanType)
 	_ = ffc("config.connector.traceTXForRevertReason", "Enable the use of transaction trace functions (e.g. debug_traceTransaction) to obtain transaction revert reasons. This can place a high load on the EVM client.", i18n.BooleanType)
+	_ = ffc("config.connector.sendDeduplicationWindow", "Period for which the result of a successful transaction submission is returned when the same managed transaction is submitted again with the same content, rather than sending it to the node again. Resubmissions while the first is in-flight wait for its result. Disabled when 0", i18n.TimeDurationType)
+	_ = ffc("config.connector.proxy.username", "Username to authenticate to the proxy server in proxy.url with, which can be an HTTP proxy (http:// or https://) or a SOCKS5 proxy (socks5:// or socks5h://), for requests to the primary url", i18n.StringType)
+	_ = ffc("config.connector.proxy.password", "Password to authenticate to the proxy server in proxy.url with", i18n.StringType)
+	_ = ffc("config.connector.read.proxy.username", "Username to authenticate to the proxy server in proxy.url with, which can be an HTTP proxy (http:// or https://) or a SOCKS5 proxy (socks5:// or socks5h://), for requests to the read endpoint", i18n.StringType)
+	_ = ffc("config.connector.read.proxy.password", "Password to authenticate to the proxy server in proxy.url with", i18n.StringType)
+	_ = ffc("config.connector.verification.proxy.username", "Username to authenticate to the proxy server in proxy.url with, which can be an HTTP proxy (http:// or https://) or a SOCKS5 proxy (socks5:// or socks5h://), for requests to the verification endpoint", i18n.StringType)
+	_ = ffc("config.connector.verification.proxy.password", "Password to authenticate to the proxy server in proxy.url with", i18n.StringType)
+	_ = ffc("config.connector.receiptConfirmations.max", "The maximum number of confirmations a transaction submission can require in the confirmations of its gas price object, before the receipt of the transaction is returned", i18n.IntType)
+	_ = ffc("config.connector.receiptStatus.fallback", "How the success of a transaction is determined when its receipt has no status field, as on chains that predate Byzantium. One of gasUsed, trace, success or failure", i18n.StringType)
 )
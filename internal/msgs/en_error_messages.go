@@ -17,6 +17,8 @@
 package msgs
 
 import (
+	"net/http"
+
 	"github.com/hyperledger/firefly-common/pkg/i18n"
 	"golang.org/x/text/language"
 )
@@ -56,9 +58,9 @@ var (
 	MsgListenerAlreadyStarted          = ffe("FF23038", "Listener already started: %s")
 	MsgInvalidCheckpoint               = ffe("FF23039", "Invalid checkpoint: %s")
 	MsgCacheInitFail                   = ffe("FF23040", "Failed to initialize %s cache")
-	MsgStreamNotStarted                = ffe("FF23041", "Event stream %s not started")
+	MsgStreamNotStarted                = ffe("FF23041", "Event stream %s not started", http.StatusNotFound)
 	MsgStreamAlreadyStarted            = ffe("FF23042", "Event stream %s already started")
-	MsgListenerNotStarted              = ffe("FF23043", "Event listener %s not started in event stream %s")
+	MsgListenerNotStarted              = ffe("FF23043", "Event listener %s not started in event stream %s", http.StatusNotFound)
 	MsgListenerNotInitialized          = ffe("FF23044", "Event listener %s not initialized in event stream %s")
 	MsgStreamNotStopped                = ffe("FF23045", "Event stream %s not stopped")
 	MsgTimedOutQueryingChainHead       = ffe("FF23046", "Timed out waiting for chain head block number")
@@ -73,4 +75,154 @@ var (
 	MsgInvalidProtocolID               = ffe("FF23055", "Invalid protocol ID in event log: %s")
 	MsgFailedToRetrieveChainID         = ffe("FF23056", "Failed to retrieve chain ID for event enrichment")
 	MsgFailedToRetrieveTransactionInfo = ffe("FF23057", "Failed to retrieve transaction info for transaction hash '%s'")
+	MsgInvalidCanonicalChainDepth      = ffe("FF23058", "Invalid canonical chain depth %d - must be at least 1")
+	MsgInvalidHedgingPercentile        = ffe("FF23059", "Invalid hedging percentile %f - must be greater than 0 and at most 100")
+	MsgInvalidContractAddress          = ffe("FF23060", "Invalid contract address '%s': %s", http.StatusBadRequest)
+	MsgInvalidInterfaceID              = ffe("FF23061", "Invalid interface ID '%s' - must be 4 bytes of hex", http.StatusBadRequest)
+	MsgInvalidTokenID                  = ffe("FF23062", "Invalid token ID '%s' - must be a non-negative 256 bit integer", http.StatusBadRequest)
+	MsgInvalidStorageSlot              = ffe("FF23063", "Invalid storage slot '%s' - must be an integer, 32 bytes of hex, or a well known slot name", http.StatusBadRequest)
+	MsgInvalidStorageKey               = ffe("FF23064", "Invalid storage mapping key '%s' - must be an integer, or up to 32 bytes of hex", http.StatusBadRequest)
+	MsgInvalidStorageArrayIndex        = ffe("FF23065", "Invalid storage array index '%s'", http.StatusBadRequest)
+	MsgInvalidABI                      = ffe("FF23066", "Invalid ABI: %s", http.StatusBadRequest)
+	MsgTransactionNotFound             = ffe("FF23067", "Transaction '%s' not found", http.StatusNotFound)
+	MsgCallDataMissingSelector         = ffe("FF23068", "Call data must contain at least a 4 byte function selector", http.StatusBadRequest)
+	MsgNoMatchingABIMethod             = ffe("FF23069", "No function in the ABI matches the function selector '%s'", http.StatusBadRequest)
+	MsgDecodeCallDataFailed            = ffe("FF23070", "Failed to decode call data using '%s': %s", http.StatusBadRequest)
+	MsgInvalidBlockHash                = ffe("FF23071", "Invalid block hash '%s' - must be 32 bytes of hex", http.StatusBadRequest)
+	MsgBlockNotFound                   = ffe("FF23072", "Block '%s' not found", http.StatusNotFound)
+	MsgInvalidReplayRange              = ffe("FF23073", "Invalid replay range fromBlock=%v toBlock=%d", http.StatusBadRequest)
+	MsgQuarantinedEventNotFound        = ffe("FF23074", "Quarantined event %s not found", http.StatusNotFound)
+	MsgQuarantinedEventNoMatch         = ffe("FF23075", "Quarantined event %s no longer matches listener filters %s", http.StatusConflict)
+	MsgBlockNotCanonical               = ffe("FF23076", "Block '%s' is not the block at height %d on the canonical chain", http.StatusBadRequest)
+	MsgInvalidTimestamp                = ffe("FF23077", "Invalid timestamp '%s' - must be RFC3339 or seconds since the epoch", http.StatusBadRequest)
+	MsgInvalidGasPriceSmoothing        = ffe("FF23078", "Invalid gas price smoothing alpha %f and spike cap %f - alpha must be greater than 0 and at most 1, and the spike cap at least 1")
+	MsgInvalidPrivacyGroupID           = ffe("FF23082", "Invalid privacy group ID '%s' - must be the base64 encoding of 32 bytes", http.StatusBadRequest)
+	MsgMissingPrivateFor               = ffe("FF23083", "Missing privateFor - a private transaction must be distributed to at least one Tessera public key", http.StatusBadRequest)
+	MsgInvalidTesseraKey               = ffe("FF23084", "Invalid Tessera public key '%s' - must be the base64 encoding of 32 bytes", http.StatusBadRequest)
+	MsgInvalidPrivateRawTx             = ffe("FF23085", "Pre-signed private transactions must be RLP encoded legacy transactions", http.StatusBadRequest)
+	MsgInvalidPrivateTxV               = ffe("FF23086", "Pre-signed private transactions must have a homestead signature with V of 27/28, or be marked private with V of 37/38 - V is %d", http.StatusBadRequest)
+	MsgInvalidAccountAddress           = ffe("FF23087", "Invalid account address '%s': %s", http.StatusBadRequest)
+	MsgStateProofNotAvailable          = ffe("FF23088", "State proof not available for account '%s' at block %s", http.StatusNotFound)
+	MsgInvalidTransactionHash          = ffe("FF23089", "Invalid transaction hash '%s' - must be 32 bytes of hex", http.StatusBadRequest)
+	MsgInvalidLogIndex                 = ffe("FF23090", "Invalid log index '%s'", http.StatusBadRequest)
+	MsgReceiptsRootMismatch            = ffe("FF23091", "Computed receipts root %s does not match receipts root %s of block %s - the chain may use a non-standard receipt encoding", http.StatusConflict)
+	MsgLogNotInReceipt                 = ffe("FF23092", "Log with index %s is not in the receipt of transaction %s", http.StatusBadRequest)
+	MsgIPCConnectFailed                = ffe("FF23093", "Failed to connect to IPC socket '%s': %s", http.StatusBadGateway)
+	MsgIPCRequestFailed                = ffe("FF23094", "IPC request failed: %s", http.StatusBadGateway)
+	MsgIPCRequestTimeout               = ffe("FF23095", "IPC request timed out after %s", http.StatusGatewayTimeout)
+	MsgIPCConnectionLost               = ffe("FF23096", "IPC connection to '%s' closed before the response was received", http.StatusBadGateway)
+	MsgIPCInvalidParam                 = ffe("FF23097", "Invalid parameter %d for method %s: %s")
+	MsgIPCResultParseFailed            = ffe("FF23098", "Failed to parse result into %T: %s")
+	MsgTxPoolNotAvailable              = ffe("FF23099", "Cannot fill nonce gaps for signer %s as the txpool of the node could not be queried", http.StatusConflict)
+	MsgNonceGapTooLarge                = ffe("FF23100", "There are %d missing nonces, which is more than the maximum of %d fill transactions", http.StatusBadRequest)
+	MsgRPCSchedulingCancelled          = ffe("FF23101", "Request cancelled waiting for a %s priority slot to send %s to the node: %s")
+	MsgRPCRequestAbandoned             = ffe("FF23102", "Request %s was not sent to the node as the caller is no longer waiting: %s")
+	MsgSignedTXDecodeFailed            = ffe("FF23103", "Failed to decode the RLP of the signed %s transaction: %s", http.StatusBadRequest)
+	MsgSignedTXTrailingBytes           = ffe("FF23104", "Signed %s transaction has %d unexpected bytes after the RLP list", http.StatusBadRequest)
+	MsgSignedTXFieldCount              = ffe("FF23105", "Signed %s transaction must be an RLP list of %d fields, but has %d", http.StatusBadRequest)
+	MsgSignedTXFieldType               = ffe("FF23106", "Field %d (%s) of the signed %s transaction must be RLP %s", http.StatusBadRequest)
+	MsgTXHashMismatch                  = ffe("FF23107", "Received transaction hash %s from node for the signed %s transaction, which does not match the calculated hash %s")
+	MsgSignedTXUnsupportedType         = ffe("FF23108", "Signed transaction type 0x%02x is not supported", http.StatusBadRequest)
+	MsgSignedTXRecoverFailed           = ffe("FF23109", "Failed to recover the signer of the %s transaction: %s", http.StatusBadRequest)
+	MsgInvalidPolicyAddress            = ffe("FF23110", "Invalid address '%s' in %s: %s")
+	MsgSignerNotAllowed                = ffe("FF23111", "Signer %s of signed transaction %s is not an allowed signer", http.StatusBadRequest)
+	MsgSignedTXUnprotected             = ffe("FF23112", "Signed transaction %s does not have EIP-155 replay protection, so is valid on any chain", http.StatusBadRequest)
+	MsgSignedTXChainIDMismatch         = ffe("FF23113", "Signed transaction %s is for chain ID %s, but the node is on chain ID %s", http.StatusBadRequest)
+	MsgDestinationNotAllowed           = ffe("FF23114", "Transactions to %s are not allowed by the destination policy of the connector", http.StatusForbidden)
+	MsgMethodNotAllowed                = ffe("FF23115", "Method %s is not allowed on contract %s by the method policy of the connector, which allows %s", http.StatusForbidden)
+	MsgInvalidPolicySelector           = ffe("FF23116", "Invalid method '%s' for contract %s in the method policy - must be a 4 byte hex function selector, or a function signature")
+	MsgInvalidPolicyValue              = ffe("FF23117", "Invalid value '%s' for %s - must be a non-negative integer amount in wei")
+	MsgValueExceedsTransactionLimit    = ffe("FF23118", "Transaction value %s exceeds the maximum of %s per transaction in the value policy of the connector", http.StatusForbidden)
+	MsgValueExceedsWindowLimit         = ffe("FF23119", "Transaction value %s from %s would exceed the maximum of %s per %s in the value policy of the connector, as %s has already been submitted in that time", http.StatusForbidden)
+	MsgAuditLogOpenFailed              = ffe("FF23120", "Failed to open audit log file '%s'")
+	MsgAuditWebhookFailed              = ffe("FF23121", "Audit webhook returned status %s")
+	MsgUnknownNotificationType         = ffe("FF23122", "Unknown notification type '%s' - must be one of %s")
+	MsgNotificationWebhookFailed       = ffe("FF23123", "Notification webhook returned status %s")
+	MsgChainAPIPortRequired            = ffe("FF23124", "Chain '%s' must set %s, so its API is served separately from the other chains")
+	MsgChainPersistenceRequired        = ffe("FF23125", "Chain '%s' must set %s or %s, so its transactions are stored separately from the other chains")
+	MsgDuplicateSendWaitCancelled      = ffe("FF23126", "Cancelled waiting for the in-flight submission of transaction %s: %s")
+	MsgInvalidImplementationABI        = ffe("FF23127", "Invalid ABI configured for proxy implementation contract '%s': %s")
+	MsgKafkaSinkConfigMissing          = ffe("FF23128", "Both %s and %s must be set for the Kafka sink")
+	MsgKafkaPublishFailed              = ffe("FF23129", "Failed to publish record %s to Kafka topic '%s' [%s]: %s")
+	MsgInvalidNATSURL                  = ffe("FF23130", "Invalid NATS server URL '%s'")
+	MsgNATSConnectFailed               = ffe("FF23131", "Failed to connect to NATS server %s: %s")
+	MsgNATSProtocolError               = ffe("FF23132", "Unexpected response from NATS server: %s")
+	MsgNATSPublishFailed               = ffe("FF23133", "Failed to publish record %s to NATS subject '%s': %s")
+	MsgUnknownCheckpointStore          = ffe("FF23134", "Unknown checkpoint store '%s' - must be one of %s")
+	MsgInvalidStoredCheckpoint         = ffe("FF23135", "Invalid checkpoint stored for listener %s: %s")
+	MsgInvalidRedisURL                 = ffe("FF23136", "Invalid Redis URL '%s' - must be redis://host:port/db or rediss:// for TLS")
+	MsgRedisCommandFailed              = ffe("FF23138", "Redis %s command failed: %s")
+	MsgInvalidCheckpointTable          = ffe("FF23140", "Invalid table name '%s' for the PostgreSQL checkpoint store")
+	MsgPostgresCheckpointStoreFailed   = ffe("FF23141", "PostgreSQL checkpoint store request failed")
+	MsgUnsupportedSnapshotVersion      = ffe("FF23142", "Unsupported snapshot version %d - this connector supports version %d")
+	MsgSnapshotChainMismatch           = ffe("FF23143", "Snapshot was taken on chain '%s', but the connector is connected to chain '%s'")
+	MsgLogVerificationFailed           = ffe("FF23144", "%d logs returned by the node do not match the transaction receipts, including: %s")
+	MsgInvalidVerificationMode         = ffe("FF23145", "Invalid verification mode '%s' - must be one of %s")
+	MsgVerificationMismatch            = ffe("FF23146", "%d results do not match the verification endpoint, including: %s")
+	MsgSimulatorURLConflict            = ffe("FF23147", "The URL of the backend JSON/RPC endpoint must not be set when the simulator is enabled")
+	MsgSimulatorStartFailed            = ffe("FF23148", "Failed to start simulator '%s': %s")
+	MsgSimulatorNotReady               = ffe("FF23149", "Simulator did not become ready at %s within %s")
+	MsgInvalidRPCRecordingMode         = ffe("FF23150", "Invalid JSON/RPC recording mode '%s' - must be one of %s")
+	MsgRPCRecordingFileFailed          = ffe("FF23151", "Failed to access JSON/RPC recording file '%s'")
+	MsgRPCRequestNotRecorded           = ffe("FF23152", "No %s request with these parameters was recorded in '%s'")
+	MsgInvalidFaultInjectionRule       = ffe("FF23153", "Invalid fault injection rule %d - the rates must be between 0 and 1, and total no more than 1: %v")
+	MsgInjectedResponseDropped         = ffe("FF23154", "Injected fault: the response to the %s request was dropped")
+	MsgBaseFeeNotAvailable             = ffe("FF23155", "Block %s does not have an EIP-1559 base fee")
+	MsgInvalidBlobGasConfig            = ffe("FF23156", "Invalid blob gas config - the target (%d) and update fraction (%d) must be greater than zero, and the maximum (%d) no less than the target")
+	MsgUnknownListenerPreset           = ffe("FF23157", "Unknown listener preset '%s' - must be one of %s")
+	MsgMissingUserOperation            = ffe("FF23158", "The user operation, including its sender, must be supplied")
+	MsgInvalidUserOperationField       = ffe("FF23159", "The %s of the user operation is longer than 32 bytes")
+	MsgInvalidLibraryAddress           = ffe("FF23160", "Invalid address for library '%s' '%s': %s")
+	MsgUnlinkedLibrary                 = ffe("FF23161", "The bytecode has an unlinked library placeholder '%s' - supply the address of the library in the 'libraries' of the contract")
+	MsgContractCodeNotFound            = ffe("FF23162", "No contract code is deployed at address %s", http.StatusNotFound)
+	MsgContractMetadataNotFound        = ffe("FF23163", "The code deployed at address %s does not end with CBOR contract metadata", http.StatusNotFound)
+	MsgInvalidFeeCurrency              = ffe("FF23164", "Invalid fee currency '%s': %s")
+	MsgInvalidNumberFormat             = ffe("FF23165", "Invalid number format '%s' - must be one of %v")
+	MsgBlockTransactionMismatch        = ffe("FF23166", "Transaction %d of block %s was '%s' rather than '%s' - the block might have been replaced by a re-org")
+	MsgInvalidBlockTxPaging            = ffe("FF23167", "Invalid block transaction paging config - the threshold (%d) must not be negative, and the batch size (%d) must be greater than zero")
+	MsgInvalidBlockNumber              = ffe("FF23168", "Invalid block '%s' - must be a decimal or 0x prefixed hex block number, or one of %v", http.StatusBadRequest)
+	MsgInvalidTransactionPage          = ffe("FF23169", "Invalid transaction page start '%s' and limit '%s' - the start must not be negative, and the limit must be greater than zero", http.StatusBadRequest)
+	MsgBlockChangedWhilePaging         = ffe("FF23170", "Block %s changed while paging its transactions - the block might have been replaced by a re-org", http.StatusConflict)
+	MsgInvalidEventVersion             = ffe("FF23171", "Invalid version %d of the event - it must have an event, and a fromBlock (%d) after the previous version (%d)", http.StatusBadRequest)
+	MsgInvalidEventPollingMode         = ffe("FF23172", "Invalid event polling mode '%s' - must be one of %v")
+	MsgCostAccountingDisabled          = ffe("FF23173", "Cost accounting is not enabled", http.StatusNotFound)
+	MsgLegacyChainConfigConflict       = ffe("FF23174", "%s cannot be enabled on a legacy chain")
+	MsgLegacyChainTXNotSupported       = ffe("FF23175", "Signed %s transaction %s is not supported on a legacy chain, which only accepts legacy transactions without EIP-155 replay protection", http.StatusBadRequest)
+	MsgLegacyChainEIP1559Fees          = ffe("FF23176", "EIP-1559 fees are not supported on a legacy chain - a gasPrice must be supplied", http.StatusBadRequest)
+	MsgInvalidNetworkID                = ffe("FF23177", "Invalid network ID '%s' returned by the node")
+	MsgListenerResumeTimeout           = ffe("FF23178", "Timed out waiting for the catchup of listener %s to stop before resuming it", http.StatusRequestTimeout)
+	MsgInvalidFilterExpression         = ffe("FF23179", "Invalid listener filter expression '%s': %s", http.StatusBadRequest)
+	MsgUnknownIndexedParam             = ffe("FF23180", "Event filter has a value for '%s', which is not an indexed parameter of event %s", http.StatusBadRequest)
+	MsgInvalidIndexedValue             = ffe("FF23181", "Invalid value for indexed parameter '%s' of event filter: %v", http.StatusBadRequest)
+	MsgInvalidEgressProxyURL           = ffe("FF23182", "Invalid proxy URL, which must have a host and a scheme that is one of %v", http.StatusBadRequest)
+	MsgInvalidTxConfirmations          = ffe("FF23183", "Invalid confirmations '%s' for the transaction, which must be a whole number from 0 to %d", http.StatusBadRequest)
+	MsgReceiptAwaitingConfirmations    = ffe("FF23184", "Receipt for transaction '%s' has %d of the %d confirmations it requires")
+	MsgInvalidFeeBumpConfig            = ffe("FF23185", "Invalid fee bump config - the price bump (%d) and blob price bump (%d) must be zero or more percent")
+	MsgInvalidMinGasPrice              = ffe("FF23186", "Invalid minimum gas price '%s', which must be a whole number of wei")
+	MsgTransactionTooLarge             = ffe("FF23187", "Transaction of %d bytes exceeds the maximum size of %d bytes", http.StatusBadRequest)
+	MsgGasExceedsBlockGasLimit         = ffe("FF23188", "Gas limit %s of the transaction exceeds the gas limit %s of the latest block %s", http.StatusBadRequest)
+	MsgInvalidTxLimits                 = ffe("FF23189", "Invalid maximum transaction size %d, which must be zero or more bytes")
+	MsgInvalidReceiptStatusFallback    = ffe("FF23190", "Invalid receipt status fallback '%s' - must be one of %v")
+	MsgReceiptStatusUndetermined       = ffe("FF23191", "Unable to determine the status of transaction '%s', as its receipt has no status and the gas limit of the transaction is not available")
+	MsgDepositTXMissingFrom            = ffe("FF23192", "OP deposit transaction %s must have the 20 byte address it is from", http.StatusBadRequest)
+	MsgDepositTXNotSubmittable         = ffe("FF23193", "OP deposit transaction %s cannot be submitted, as deposits are derived from L1 by the rollup node", http.StatusBadRequest)
+	MsgInvalidChainProfile             = ffe("FF23194", "Invalid chain profile '%s' - must be one of %v")
+	MsgInvalidFinalityL1Config         = ffe("FF23195", "Invalid L1 finality config - the rollup contract '%s' must be an address, and a method is required for chain profile '%s'")
+	MsgFinalizedBlockNotAvailable      = ffe("FF23196", "The finalized block is not available from the node")
+	MsgInvalidFinalizedBlockResult     = ffe("FF23197", "Invalid result '%s' for the last finalized L2 block from the rollup contract")
+	MsgReceiptAwaitingFinality         = ffe("FF23198", "Receipt for transaction '%s' in block %d is not final, as the finalized block is %d")
+	MsgInvalidL1InclusionConfig        = ffe("FF23199", "Invalid L1 inclusion config - the URL of L1, an address for the rollup contract '%s', and an event with the topic of the L2 block are required for chain profile '%s'")
+	MsgL1InclusionDisabled             = ffe("FF23200", "L1 inclusion tracking is not enabled", http.StatusNotFound)
+	MsgInvalidFFISchema                = ffe("FF23201", "Invalid schema for FFI parameter '%s': %s", http.StatusBadRequest)
+	MsgFFITypeMismatch                 = ffe("FF23202", "FFI parameter '%s' has JSON type '%s', which is not valid for ABI type '%s'", http.StatusBadRequest)
+	MsgInvalidFFIPropertyIndex         = ffe("FF23203", "FFI property '%s' must have a details.index from 0 to %d that is unique within its object", http.StatusBadRequest)
+	MsgInvalidMethodSelector           = ffe("FF23204", "Invalid method selector '%s' - must be 4 bytes of 0x prefixed hex", http.StatusBadRequest)
+	MsgMethodNotInABI                  = ffe("FF23205", "No function in the ABI matches method '%s'", http.StatusBadRequest)
+	MsgAmbiguousMethod                 = ffe("FF23206", "Method '%s' matches more than one overloaded function - use the signature of one of: %s", http.StatusBadRequest)
+	MsgDeadLetterStoreFailed           = ffe("FF23207", "Failed to access the dead letter store in directory '%s'")
+	MsgEventDeliveryTimeout            = ffe("FF23208", "Event was not accepted by the transaction manager within %s")
+	MsgDeadLetterNotFound              = ffe("FF23209", "Dead letter batch %s not found", http.StatusNotFound)
+	MsgInvalidErrorHandling            = ffe("FF23210", "Invalid error handling mode '%s' - must be one of %v", http.StatusBadRequest)
+	MsgDeadLetterNotEnabled            = ffe("FF23211", "Error handling mode '%s' requires events.deadLetter.directory to be configured", http.StatusBadRequest)
+	MsgInvalidDeliverySettings         = ffe("FF23212", "Invalid delivery settings - the delivery timeout must be positive, the maximum attempts at least 1, the retry delays zero or more and the retry factor at least 1", http.StatusBadRequest)
 )